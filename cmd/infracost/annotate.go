@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func annotateCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "annotate",
+		Short: "Write monthly cost comments above Terraform resource and module blocks",
+		Long: `Write monthly cost comments above Terraform resource and module blocks.
+
+A comment such as "# infracost: ~$214/mo" is added above each resource and module block
+that has a cost, and comments added by a previous run are updated or removed. The changes
+are saved as a patch file or committed to a new git branch, so the working tree isn't
+changed. Only projects whose path is a Terraform directory can be annotated.`,
+		Example: `  Save the cost comments as a patch and apply it:
+
+      infracost annotate --path /code --patch-file costs.patch
+      git apply costs.patch
+
+  Commit the cost comments to a new branch to open a pull request from:
+
+      infracost annotate --config-file infracost.yml --branch infracost/cost-comments`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			patchFile, _ := cmd.Flags().GetString("patch-file")
+			branch, _ := cmd.Flags().GetString("branch")
+
+			if (patchFile == "") == (branch == "") {
+				ui.PrintUsage(cmd)
+				return errors.New("Exactly one of --patch-file or --branch is required")
+			}
+
+			if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			est, err := estimateProjects(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			if est.cancelErr != nil {
+				return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+			}
+
+			changes, err := costCommentChanges(est.root)
+			if err != nil {
+				return err
+			}
+
+			ctx.SetContextValue("annotatedFileCount", len(changes))
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-annotate", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if len(changes) == 0 {
+				cmd.PrintErrln("Cost comments are already up to date")
+				return nil
+			}
+
+			if patchFile != "" {
+				patch, err := costCommentPatch(changes)
+				if err != nil {
+					return err
+				}
+
+				err = saveOutFileWithMsg(ctx, cmd, patchFile, fmt.Sprintf("Cost comments for %d %s saved to %s", len(changes), pluralFiles(len(changes)), patchFile), patch)
+				if err != nil {
+					return err
+				}
+			} else {
+				message, _ := cmd.Flags().GetString("commit-message")
+
+				files := make(map[string][]byte, len(changes))
+				for _, c := range changes {
+					files[c.filename] = c.after
+				}
+
+				commit, err := config.GitCommitToBranch(changes[0].filename, branch, message, files)
+				if err != nil {
+					return errors.Wrap(err, "Error committing cost comments")
+				}
+
+				cmd.PrintErrf("Cost comments for %d %s committed to branch %s (%s)\n", len(changes), pluralFiles(len(changes)), branch, shortSHA(commit))
+			}
+
+			if ctx.Config.FailOn == clierror.FailOnWarning && ctx.WarningCount() > 0 {
+				return newWarningsError(ctx.WarningCount())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse HCL code instead of generating a Terraform plan. This does not need credentials and is faster (experimental)")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform’s -var-file flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform’s -var flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-init-flags", "", "Flags to pass to 'terraform init'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+	addFailOnFlag(cmd)
+
+	cmd.Flags().String("patch-file", "", "Path to save the cost comments to as a patch that can be applied with git apply")
+	cmd.Flags().String("branch", "", "Name of a new git branch to commit the cost comments to")
+	cmd.Flags().String("commit-message", "Update infracost cost comments", "Message of the commit on the branch")
+
+	_ = cmd.MarkFlagFilename("path", "tf")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("patch-file", "patch")
+
+	return cmd
+}
+
+type costCommentChange struct {
+	filename string
+	before   []byte
+	after    []byte
+}
+
+// costCommentChanges returns the files whose cost comments are out of date, sorted by filename.
+func costCommentChanges(out output.Root) ([]costCommentChange, error) {
+	comments := output.CostComments(out)
+
+	filenames := make([]string, 0, len(comments))
+	for filename := range comments {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	var changes []costCommentChange
+
+	for _, filename := range filenames {
+		before, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error reading %s", filename)
+		}
+
+		after := terraform.UpdateCostComments(before, comments[filename])
+		if bytes.Equal(before, after) {
+			continue
+		}
+
+		changes = append(changes, costCommentChange{filename: filename, before: before, after: after})
+	}
+
+	return changes, nil
+}
+
+// costCommentPatch returns a unified diff of the changes with paths relative to the git repo,
+// or the current directory if the files aren't in a git repo.
+func costCommentPatch(changes []costCommentChange) ([]byte, error) {
+	root := config.DetectVCSRoot(changes[0].filename)
+	if root == "" {
+		root = "."
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting patch root directory")
+	}
+
+	var b bytes.Buffer
+
+	for _, c := range changes {
+		absFilename, err := filepath.Abs(c.filename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting absolute path of %s", c.filename)
+		}
+
+		if resolved, err := filepath.EvalSymlinks(absFilename); err == nil {
+			absFilename = resolved
+		}
+
+		rel, err := filepath.Rel(absRoot, absFilename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting relative path of %s", c.filename)
+		}
+		rel = filepath.ToSlash(rel)
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(c.before)),
+			B:        difflib.SplitLines(string(c.after)),
+			FromFile: "a/" + rel,
+			ToFile:   "b/" + rel,
+			Context:  3,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error generating patch for %s", rel)
+		}
+
+		b.WriteString(diff)
+	}
+
+	return b.Bytes(), nil
+}
+
+func pluralFiles(n int) string {
+	if n == 1 {
+		return "file"
+	}
+	return "files"
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,38 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestAnnotateHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"annotate", "--help"}, nil)
+}
+
+func TestAnnotateNoDestination(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"annotate", "--path", "./testdata/annotate_patch", "--pricing-mock"}, nil)
+}
+
+func TestAnnotatePatch(t *testing.T) {
+	testName := testutil.CalcGoldenFileTestdataDirName()
+	goldenFilePath := "./testdata/" + testName + "/costs_patch.golden"
+	patchFile := filepath.Join(t.TempDir(), "costs.patch")
+
+	GoldenFileCommandTest(t, testName, []string{
+		"annotate",
+		"--path", "./testdata/" + testName,
+		"--terraform-parse-hcl",
+		"--pricing-mock",
+		"--patch-file", patchFile,
+	}, nil)
+
+	actual, err := os.ReadFile(patchFile)
+	require.NoError(t, err)
+
+	testutil.AssertGoldenFile(t, goldenFilePath, actual)
+}
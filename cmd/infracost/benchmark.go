@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/benchmark"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+)
+
+func benchmarkCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "benchmark",
+		Short: "Measure how long each phase of estimating a Terraform directory takes",
+		Long: `Measure how long each phase of estimating a Terraform directory takes.
+
+The directory is estimated by parsing its HCL, and the duration, peak heap and allocated
+memory of each phase are output as JSON: parse, modules, evaluate, resources and price. This
+can be attached to reports of slow runs. With --iterations the directory is estimated more
+than once and the median of each phase is shown.
+
+The benchmarks of the directories in internal/benchmark/testdata are run by
+'make benchmark' to catch performance regressions.`,
+		Example: `  Benchmark a Terraform directory:
+
+      infracost benchmark --path /code
+
+  Benchmark without the network time of the Cloud Pricing API:
+
+      infracost benchmark --path /code --pricing-mock --iterations 5`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("path")
+			if path == "" {
+				ui.PrintUsage(cmd)
+				return errors.New("--path is required")
+			}
+
+			iterations, _ := cmd.Flags().GetInt("iterations")
+			if iterations < 1 {
+				ui.PrintUsage(cmd)
+				return errors.New("--iterations must be at least 1")
+			}
+
+			if usesPricingMock(cmd, ctx.Config) {
+				ctx.Config.PricingBackend = prices.MockPricingBackend
+				ctx.Config.EventsDisabled = true
+			} else if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+				return err
+			}
+
+			usageData := map[string]*schema.UsageData{}
+			if usageFilePath, _ := cmd.Flags().GetString("usage-file"); usageFilePath != "" {
+				usageFile, err := usage.LoadUsageFile(usageFilePath)
+				if err != nil {
+					return err
+				}
+				usageData = usageFile.ToUsageDataMap()
+			}
+
+			defer modules.CloseSharedDownloads()
+
+			result, err := benchmark.Run(ctx, path, usageData, iterations)
+			if err != nil {
+				return errors.Wrap(err, "Error benchmarking path")
+			}
+
+			b, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return errors.Wrap(err, "Error generating output")
+			}
+			b = append(b, '\n')
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				return saveOutFile(ctx, cmd, outFile, b)
+			}
+
+			cmd.Print(string(b))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().Int("iterations", 1, "Number of times to estimate the directory")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+	cmd.Flags().String("out-file", "", "Save output to a file")
+
+	_ = cmd.MarkFlagDirname("path")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+
+	return cmd
+}
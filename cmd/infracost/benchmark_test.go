@@ -0,0 +1,15 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestBenchmarkHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"benchmark", "--help"}, nil)
+}
+
+func TestBenchmarkNoPath(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"benchmark"}, nil)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/infracost/infracost/internal/config"
@@ -20,11 +21,17 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 
       terraform plan -out tfplan.binary
       terraform show -json tfplan.binary > plan.json
-      infracost breakdown --path plan.json`,
+      infracost breakdown --path plan.json
+
+  Show the monthly savings from destroying the resources, e.g. of a preview environment:
+
+      infracost breakdown --path /path/to/code --destroy`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
-				return err
+			if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
 			}
 
 			err := loadRunFlags(ctx.Config, cmd)
@@ -32,9 +39,23 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 				return err
 			}
 
+			ctx.Config.Destroy, _ = cmd.Flags().GetBool("destroy")
+			if ctx.Config.Destroy {
+				err = checkDestroyConfig(ctx.Config)
+				if err != nil {
+					ui.PrintUsage(cmd)
+					return err
+				}
+
+				// A breakdown of the destroyed resources would be empty, so show the diff instead
+				if ctx.Config.Format == "table" {
+					ctx.Config.Format = "diff"
+				}
+			}
+
 			ctx.SetContextValue("outputFormat", ctx.Config.Format)
 
-			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
 			if err != nil {
 				ui.PrintUsage(cmd)
 				return err
@@ -48,8 +69,10 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 
 	cmd.Flags().String("out-file", "", "Save output to a file, helpful with format flag")
 	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
-	cmd.Flags().String("format", "table", "Output format: json, table, html")
+	cmd.Flags().String("format", "table", "Output format: json, table, html, cost-centers, csv")
+	cmd.Flags().Bool("destroy", false, "Show the monthly savings from destroying all of the resources, e.g. when decommissioning an environment")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
+	addVerbosityFlags(cmd)
 
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return validRunFormats, cobra.ShellCompDirectiveDefault
@@ -57,3 +80,15 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 
 	return cmd
 }
+
+func checkDestroyConfig(cfg *config.Config) error {
+	if cfg.CompareTo != "" {
+		return errors.New("--destroy cannot be used with --compare-to")
+	}
+
+	if cfg.Format != "" && cfg.Format != "table" && cfg.Format != "json" {
+		return errors.New("--destroy only supports the table and json formats")
+	}
+
+	return nil
+}
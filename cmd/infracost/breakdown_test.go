@@ -1,7 +1,11 @@
 package main_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"io/ioutil"
+	"os"
 	"path"
 	"path/filepath"
 	"testing"
@@ -40,6 +44,54 @@ func TestBreakdownTerraformPlanJSON(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json", "--usage-file", "./testdata/example_usage.yml"}, nil)
 }
 
+func TestBreakdownPricingMock(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json", "--usage-file", "./testdata/example_usage.yml", "--pricing-mock"}, nil)
+}
+
+// withStdin replaces stdin with the contents of b for the duration of the test.
+func withStdin(t *testing.T, b []byte) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "stdin")
+	require.NoError(t, os.WriteFile(path, b, 0600))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+
+	stdin := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		f.Close()
+	})
+}
+
+func TestBreakdownStdinHCL(t *testing.T) {
+	b, err := os.ReadFile("../../examples/terraform/main.tf")
+	require.NoError(t, err)
+	withStdin(t, b)
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "-", "--pricing-mock"}, nil)
+}
+
+func TestBreakdownStdinTarball(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range []string{"main.tf", "infracost-usage.yml"} {
+		b, err := os.ReadFile(filepath.Join("../../examples/terraform", name))
+		require.NoError(t, err)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "terraform/" + name, Typeflag: tar.TypeReg, Mode: 0600, Size: int64(len(b))}))
+		_, err = tw.Write(b)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	withStdin(t, buf.Bytes())
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "-", "--pricing-mock"}, nil)
+}
+
 func TestBreakdownTerraformDirectory(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "../../examples/terraform"}, &GoldenFileOptions{RunHCL: true})
 }
@@ -271,3 +323,54 @@ func TestBreakdownInitFlagsError(t *testing.T) {
 		},
 	)
 }
+
+func TestBreakdownDestroy(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json", "--usage-file", "./testdata/example_usage.yml", "--pricing-mock", "--destroy"}, nil)
+}
+
+func TestBreakdownDestroyFormatJSON(t *testing.T) {
+	opts := DefaultOptions()
+	opts.IsJSON = true
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--format", "json", "--path", "./testdata/example_plan.json", "--usage-file", "./testdata/example_usage.yml", "--pricing-mock", "--destroy"}, opts)
+}
+
+func TestBreakdownDestroyFormatHTML(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--format", "html", "--path", "./testdata/example_plan.json", "--pricing-mock", "--destroy"}, nil)
+}
+
+func TestBreakdownShowConfidence(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json", "--pricing-mock", "--show-confidence"}, nil)
+}
+
+func TestBreakdownShowConfidenceFormatJSON(t *testing.T) {
+	opts := DefaultOptions()
+	opts.IsJSON = true
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--format", "json", "--path", "./testdata/example_plan.json", "--usage-file", "./testdata/example_usage.yml", "--pricing-mock", "--show-confidence"}, opts)
+}
+
+func TestBreakdownSetOverride(t *testing.T) {
+	testName := testutil.CalcGoldenFileTestdataDirName()
+	GoldenFileCommandTest(t, testName, []string{
+		"breakdown",
+		"--path", "./testdata/" + testName,
+		"--terraform-parse-hcl",
+		"--pricing-mock",
+		"--set", "aws_instance.*.instance_type=m6g.large",
+		"--set", "aws_lambda_function.*.memory_size=1024",
+	}, nil)
+}
+
+func TestBreakdownCanonicalAddresses(t *testing.T) {
+	testName := testutil.CalcGoldenFileTestdataDirName()
+	GoldenFileCommandTest(t, testName, []string{
+		"breakdown",
+		"--path", "./testdata/" + testName,
+		"--terraform-parse-hcl",
+		"--pricing-mock",
+	}, nil)
+}
+
+func TestBreakdownHeuristicPricing(t *testing.T) {
+	dir := path.Join("./testdata", testutil.CalcGoldenFileTestdataDirName())
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", dir, "--heuristic-pricing", "--show-skipped", "--pricing-mock"}, &GoldenFileOptions{OnlyRunHCL: true})
+}
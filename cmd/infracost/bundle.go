@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/bundle"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// bundlePricingBackend is the pricing backend used by bundle create to record the prices of the run.
+const bundlePricingBackend = "bundle-recorder"
+
+func bundleCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Create and load air-gapped bundles for running Infracost offline",
+		Long: `Create and load air-gapped bundles for running Infracost offline.
+
+A bundle is a single tarball with the prices, remote modules and provider schemas
+that projects need. Create it on a machine with network access, transfer it to the
+offline network and load it there. Then set INFRACOST_BUNDLE to the loaded bundle
+directory to run Infracost entirely from the bundle, without any outbound requests.`,
+		Example: `  Create a bundle for a project, then load and use it on the offline network:
+
+      infracost bundle create --path /code --terraform-parse-hcl --out-file infracost-bundle.tar.gz
+      infracost bundle load infracost-bundle.tar.gz --dir /opt/infracost-bundle
+      INFRACOST_BUNDLE=/opt/infracost-bundle infracost breakdown --path /code --terraform-parse-hcl`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(bundleCreateCmd(ctx), bundleLoadCmd(ctx))
+
+	return cmd
+}
+
+func bundleCreateCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a bundle of the prices, modules and provider schemas of projects",
+		Long: `Create a bundle of the prices, modules and provider schemas of projects.
+
+The projects are estimated so that the prices of their resources are recorded and
+their remote modules are downloaded. Only those prices are in the bundle, so create
+it from the same projects that will be run from it.`,
+		Example: `  Create a bundle for the projects in a config file:
+
+      infracost bundle create --config-file infracost.yml --out-file infracost-bundle.tar.gz`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ctx.Config.BundlePath != "" {
+				return errors.New("A bundle can't be created while running from a bundle, unset INFRACOST_BUNDLE")
+			}
+
+			if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			snapshot := prices.NewPriceSnapshot()
+			recorder, err := prices.NewRecordingPriceFetcher(ctx, ctx.Config.PricingBackend, snapshot)
+			if err != nil {
+				return err
+			}
+
+			prices.RegisterPriceFetcher(bundlePricingBackend, func(*config.RunContext) (prices.PriceFetcher, error) {
+				return recorder, nil
+			})
+			ctx.Config.PricingBackend = bundlePricingBackend
+
+			est, err := estimateProjects(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			if est.cancelErr != nil {
+				return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+			}
+
+			outFile, _ := cmd.Flags().GetString("out-file")
+			manifest, err := bundle.Create(outFile, snapshot, ctx.Config.Projects, ctx.Config.Currency)
+			if err != nil {
+				return err
+			}
+
+			ui.PrintSuccessf(cmd.ErrOrStderr(), "Bundle with %d prices for %d projects saved to %s", snapshot.Len(), len(manifest.Projects), outFile)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse HCL code instead of generating a Terraform plan. This does not need credentials and is faster (experimental)")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform’s -var-file flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform’s -var flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-init-flags", "", "Flags to pass to 'terraform init'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+	addFailOnFlag(cmd)
+
+	cmd.Flags().String("out-file", "infracost-bundle.tar.gz", "Path of the bundle to create")
+
+	return cmd
+}
+
+func bundleLoadCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load BUNDLE",
+		Short: "Extract a bundle so Infracost can be run from it",
+		Long: `Extract a bundle so Infracost can be run from it.
+
+Set INFRACOST_BUNDLE to the directory the bundle is extracted to. Runs then use the
+bundle's prices, restore its modules to projects that haven't downloaded them, and
+block all outbound requests.`,
+		Example: `  Extract a bundle and run Infracost from it:
+
+      infracost bundle load infracost-bundle.tar.gz --dir /opt/infracost-bundle
+      INFRACOST_BUNDLE=/opt/infracost-bundle infracost breakdown --path /code --terraform-parse-hcl`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, _ := cmd.Flags().GetString("dir")
+
+			manifest, err := bundle.Load(args[0], dir)
+			if err != nil {
+				return err
+			}
+
+			ui.PrintSuccessf(cmd.ErrOrStderr(), "Bundle for %d projects created at %s loaded to %s", len(manifest.Projects), manifest.CreatedAt.Format("2006-01-02 15:04:05 MST"), dir)
+			cmd.PrintErrf("\nRun Infracost from the bundle by setting INFRACOST_BUNDLE=%s\n", dir)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("dir", "infracost-bundle", "Directory to extract the bundle to")
+
+	return cmd
+}
+
+// applyBundleToProjects restores the modules and provider schemas of the bundle the run uses to the
+// projects.
+func applyBundleToProjects(cfg *config.Config) error {
+	b, err := bundle.Open(cfg.BundlePath)
+	if err != nil {
+		return err
+	}
+
+	return b.ApplyToProjects(cfg.Projects)
+}
@@ -0,0 +1,65 @@
+package main_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	main "github.com/infracost/infracost/cmd/infracost"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestBundleHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"bundle", "--help"}, nil)
+}
+
+func TestBundleCreateAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	bundleFile := filepath.Join(dir, "infracost-bundle.tar.gz")
+	bundleDir := filepath.Join(dir, "infracost-bundle")
+
+	stderr := runBundleCommand(t, []string{
+		"bundle", "create",
+		"--path", "./testdata/example_plan.json",
+		"--usage-file", "./testdata/example_usage.yml",
+		"--pricing-mock",
+		"--out-file", bundleFile,
+	})
+	assert.Contains(t, stderr, "Bundle with 14 prices for 1 projects saved to "+bundleFile)
+
+	stderr = runBundleCommand(t, []string{"bundle", "load", bundleFile, "--dir", bundleDir})
+	assert.Contains(t, stderr, "Run Infracost from the bundle by setting INFRACOST_BUNDLE="+bundleDir)
+
+	// The prices are the same as the mock prices the bundle was created with, and no API key is needed.
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{
+		"breakdown",
+		"--path", "./testdata/example_plan.json",
+		"--usage-file", "./testdata/example_usage.yml",
+	}, nil, func(c *config.RunContext) {
+		c.Config.BundlePath = bundleDir
+		c.Config.APIKey = ""
+	})
+}
+
+func runBundleCommand(t *testing.T, args []string) string {
+	t.Helper()
+
+	errBuf := bytes.NewBuffer([]byte{})
+	exitCode := 0
+
+	main.Run(func(c *config.RunContext) {
+		c.Config.EventsDisabled = true
+		c.Config.NoColor = true
+		c.ErrWriter = errBuf
+		c.OutWriter = bytes.NewBuffer([]byte{})
+		c.Exit = func(code int) { exitCode = code }
+	}, &args)
+
+	require.Equal(t, 0, exitCode, errBuf.String())
+
+	return errBuf.String()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// The CI platforms that have a summary page that the Markdown report is added to.
+const (
+	ciSummaryGitHubActions  = "github_actions"
+	ciSummaryAzurePipelines = "azure_pipelines"
+)
+
+// githubStepSummaryMaxSize is the maximum size of a job's step summary. Longer reports are
+// truncated so they're still shown.
+const githubStepSummaryMaxSize = 1024 * 1024
+
+// ciSummaryPlatform returns the CI platform with a summary page that the run is in, or an empty
+// string if it isn't in one.
+func ciSummaryPlatform() string {
+	if os.Getenv("GITHUB_ACTIONS") == "true" && os.Getenv("GITHUB_STEP_SUMMARY") != "" {
+		return ciSummaryGitHubActions
+	}
+
+	if os.Getenv("TF_BUILD") == "True" {
+		return ciSummaryAzurePipelines
+	}
+
+	return ""
+}
+
+// writeCISummary adds the Markdown report of the run to the summary page of the GitHub Actions or
+// Azure Pipelines run, so the results are shown there without extra workflow steps.
+func writeCISummary(cmd *cobra.Command, runCtx *config.RunContext, r output.Root, opts output.Options) error {
+	platform := ciSummaryPlatform()
+	if platform == "" || runCtx.Config.DisableCISummary {
+		return nil
+	}
+
+	// The summary pages render Markdown like the comments of the platforms, so the report uses their
+	// verbosity.
+	mdOpts := output.MarkdownOptions{}
+	format := "azure-repos-comment"
+	if platform == ciSummaryGitHubActions {
+		mdOpts.MaxMessageSize = githubStepSummaryMaxSize
+		format = "github-comment"
+	}
+
+	opts.ShowSkipped = true
+	opts.Verbosity = outputVerbosity(cmd, runCtx.Config.Verbosity[format])
+
+	b, err := output.ToMarkdown(r, opts, mdOpts)
+	if err != nil {
+		return errors.Wrap(err, "Error generating CI summary")
+	}
+
+	switch platform {
+	case ciSummaryGitHubActions:
+		// Other steps write to the same summary file, so the report is appended to it.
+		f, err := os.OpenFile(os.Getenv("GITHUB_STEP_SUMMARY"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return errors.Wrap(err, "Error writing GitHub Actions step summary")
+		}
+		defer f.Close()
+
+		_, err = f.Write(append(b, '\n'))
+		if err != nil {
+			return errors.Wrap(err, "Error writing GitHub Actions step summary")
+		}
+	case ciSummaryAzurePipelines:
+		// Azure Pipelines uploads the summary from a file when it's told about it with a logging
+		// command. Each run needs its own file since there can be more than one run in a job.
+		dir := os.Getenv("AGENT_TEMPDIRECTORY")
+		if dir == "" {
+			dir = os.TempDir()
+		}
+
+		f, err := os.CreateTemp(dir, "infracost-summary-*.md")
+		if err != nil {
+			return errors.Wrap(err, "Error writing Azure Pipelines summary")
+		}
+		defer f.Close()
+
+		_, err = f.Write(b)
+		if err != nil {
+			return errors.Wrap(err, "Error writing Azure Pipelines summary")
+		}
+
+		// Logging commands are read from stderr as well as stdout, and using stderr means the
+		// command isn't mixed into the output, e.g. JSON that's piped to a file.
+		fmt.Fprintf(cmd.ErrOrStderr(), "##vso[task.uploadsummary]%s\n", f.Name())
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package main_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	main "github.com/infracost/infracost/cmd/infracost"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestBreakdownGitHubActionsSummary(t *testing.T) {
+	testdataName := testutil.CalcGoldenFileTestdataDirName()
+	goldenFilePath := "./testdata/" + testdataName + "/step_summary.golden"
+	summaryPath := filepath.Join(t.TempDir(), "step_summary.md")
+
+	// The report is appended to what the other steps wrote.
+	err := os.WriteFile(summaryPath, []byte("## Terraform plan\n\n"), 0600)
+	require.NoError(t, err)
+
+	GoldenFileCommandTest(t, testdataName, []string{"breakdown", "--path", "./testdata/example_plan.json", "--pricing-mock"}, &GoldenFileOptions{
+		Env: map[string]string{
+			"GITHUB_ACTIONS":               "true",
+			"GITHUB_STEP_SUMMARY":          summaryPath,
+			"INFRACOST_DISABLE_CI_SUMMARY": "false",
+		},
+	})
+
+	actual, err := os.ReadFile(summaryPath)
+	require.NoError(t, err)
+
+	testutil.AssertGoldenFile(t, goldenFilePath, actual)
+}
+
+func TestBreakdownGitHubActionsSummaryDisabled(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "step_summary.md")
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json", "--pricing-mock"}, &GoldenFileOptions{
+		Env: map[string]string{
+			"GITHUB_ACTIONS":               "true",
+			"GITHUB_STEP_SUMMARY":          summaryPath,
+			"INFRACOST_DISABLE_CI_SUMMARY": "true",
+		},
+	})
+
+	assert.NoFileExists(t, summaryPath)
+}
+
+func TestBreakdownAzurePipelinesSummary(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TF_BUILD", "True")
+	t.Setenv("AGENT_TEMPDIRECTORY", dir)
+	t.Setenv("INFRACOST_DISABLE_CI_SUMMARY", "false")
+
+	errBuf := bytes.NewBuffer([]byte{})
+	main.Run(func(c *config.RunContext) {
+		c.Config.EventsDisabled = true
+		c.Config.NoColor = true
+		c.ErrWriter = errBuf
+		c.OutWriter = io.Discard
+		c.Exit = func(code int) {}
+	}, &[]string{"breakdown", "--path", "./testdata/example_plan.json", "--format", "json", "--pricing-mock"})
+
+	// The path of the summary file is random so there can be more than one run in a job.
+	m := regexp.MustCompile(`##vso\[task.uploadsummary\](.+)\n`).FindStringSubmatch(errBuf.String())
+	require.Len(t, m, 2, errBuf.String())
+	assert.Equal(t, dir, filepath.Dir(m[1]))
+
+	summary, err := os.ReadFile(m[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(summary), "Infracost estimate")
+}
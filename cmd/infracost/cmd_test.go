@@ -3,6 +3,7 @@ package main_test
 import (
 	"bytes"
 	"encoding/json"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -21,6 +22,7 @@ var (
 	projectPathRegex = regexp.MustCompile(`(Project: .*) \(.*/infracost/examples/.*\)`)
 	versionRegex     = regexp.MustCompile(`Infracost v.*`)
 	panicRegex       = regexp.MustCompile(`runtime\serror:([\w\d\n\r\[\]\:\/\.\\(\)\+\,\{\}\*\@\s\?]*)Environment`)
+	crashReportRegex = regexp.MustCompile(`\S*infracost-crash-\d+\.json`)
 )
 
 type GoldenFileOptions = struct {
@@ -68,6 +70,12 @@ func goldenFileCommandTest(t *testing.T, testName string, args []string, testOpt
 		testOptions = DefaultOptions()
 	}
 
+	cleanupModuleCaches(t)
+
+	// Don't add the output of the tests to the summary of the CI run that's running them. Tests of
+	// the summary turn it back on with their env.
+	os.Setenv("INFRACOST_DISABLE_CI_SUMMARY", "true")
+
 	for k, v := range testOptions.Env {
 		os.Setenv(k, v)
 		defer os.Unsetenv(k)
@@ -147,6 +155,46 @@ func goldenFileCommandTest(t *testing.T, testName string, args []string, testOpt
 	testutil.AssertGoldenFile(t, goldenFilePath, actual)
 }
 
+// cleanupModuleCaches removes the .infracost directories that the module loader writes to the testdata
+// projects during the test, so running the tests doesn't leave untracked files behind.
+func cleanupModuleCaches(t *testing.T) {
+	t.Helper()
+
+	existing := map[string]bool{}
+	for _, dir := range findModuleCaches(t) {
+		existing[dir] = true
+	}
+
+	t.Cleanup(func() {
+		for _, dir := range findModuleCaches(t) {
+			if !existing[dir] {
+				require.NoError(t, os.RemoveAll(dir))
+			}
+		}
+	})
+}
+
+func findModuleCaches(t *testing.T) []string {
+	t.Helper()
+
+	var dirs []string
+	err := filepath.WalkDir("testdata", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() && d.Name() == ".infracost" {
+			dirs = append(dirs, path)
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return dirs
+}
+
 // stripDynamicValues strips out any values that change between test runs from the output,
 // including timestamps and temp file paths
 func stripDynamicValues(actual []byte) []byte {
@@ -155,6 +203,7 @@ func stripDynamicValues(actual []byte) []byte {
 	actual = projectPathRegex.ReplaceAll(actual, []byte("$1 REPLACED_PROJECT_PATH"))
 	actual = versionRegex.ReplaceAll(actual, []byte("Infracost vREPLACED_VERSION"))
 	actual = panicRegex.ReplaceAll(actual, []byte("runtime error: REPLACED ERROR\nEnvironment"))
+	actual = crashReportRegex.ReplaceAll(actual, []byte("REPLACED_CRASH_REPORT"))
 
 	return actual
 }
@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
@@ -12,16 +14,33 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/wasmext"
 )
 
+// commentMaxMessageSizes are the maximum lengths of comments on each platform, keyed by the output format.
+// Comments that are longer are rendered with a lower verbosity so that they can still be posted.
+var commentMaxMessageSizes = map[string]int{
+	"github-comment":      65536,
+	"gitlab-comment":      1000000,
+	"azure-repos-comment": 150000,
+	"bitbucket-comment":   32768,
+	"gitea-comment":       1000000,
+	"gerrit-comment":      16384,
+	// Buildkite annotations and CircleCI artifacts aren't comments, but the reports are still
+	// limited so they can be shown in the browser.
+	"buildkite-annotation": 1048576,
+	"circleci-artifact":    1048576,
+}
+
 func commentCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "comment",
-		Short: "Post an Infracost comment to GitHub, GitLab, Azure Repos or Bitbucket",
-		Long:  "Post an Infracost comment to GitHub, GitLab, Azure Repos or Bitbucket",
+		Short: "Post an Infracost comment to GitHub, GitLab, Azure Repos, Bitbucket, Gitea, Gerrit, Buildkite or CircleCI",
+		Long:  "Post an Infracost comment to GitHub, GitLab, Azure Repos, Bitbucket, Gitea, Gerrit, Buildkite or CircleCI",
 		Example: `  Update the Infracost comment on a GitHub pull request:
 
       infracost comment github --repo my-org/my-repo --pull-request 3 --path infracost.json --behavior update --github-token $GITHUB_TOKEN
@@ -32,16 +51,24 @@ func commentCmd(ctx *config.RunContext) *cobra.Command {
 
   Post a new comment to an Azure Repos pull request:
 
-      infracost comment azure-repos --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --path infracost.json --behavior new --azure-access-token $AZURE_ACCESS_TOKEN`,
+      infracost comment azure-repos --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --path infracost.json --behavior new --azure-access-token $AZURE_ACCESS_TOKEN
+
+  Add an annotation to a Buildkite build:
+
+      infracost comment buildkite --path infracost.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 
-	cmds := []*cobra.Command{commentGitHubCmd(ctx), commentGitLabCmd(ctx), commentAzureReposCmd(ctx), commentBitbucketCmd(ctx)}
+	cmds := []*cobra.Command{commentGitHubCmd(ctx), commentGitLabCmd(ctx), commentAzureReposCmd(ctx), commentBitbucketCmd(ctx), commentGiteaCmd(ctx), commentGerritCmd(ctx), commentBuildkiteCmd(ctx), commentCircleCICmd(ctx)}
 	for _, subCmd := range cmds {
 		subCmd.Flags().StringArray("policy-path", nil, "Path to Infracost policy files, glob patterns need quotes (experimental)")
+		subCmd.Flags().StringArray("policy-wasm", nil, "Path to a WASM module that checks Infracost policies, run in a sandbox (experimental)")
+		addFailOnFlag(subCmd)
+		addVerbosityFlags(subCmd)
+		addFullReportFlags(subCmd)
 	}
 
 	cmd.AddCommand(cmds...)
@@ -49,7 +76,41 @@ func commentCmd(ctx *config.RunContext) *cobra.Command {
 	return cmd
 }
 
+// addFullReportFlags adds the flags that link comments that are too long to post in full to the full report.
+func addFullReportFlags(cmd *cobra.Command) {
+	cmd.Flags().String("full-report-url", "", "URL of the full report linked to when the comment is truncated to fit the size limit, e.g. a CI artifact")
+	cmd.Flags().String("full-report-file", "", "Save the full diff output to a file, e.g. to upload as the CI artifact linked to with --full-report-url")
+}
+
+// saveFullReport saves the full diff output to the --full-report-file, if it's set, so it's available even
+// if the comment has to be truncated.
+func saveFullReport(cmd *cobra.Command, ctx *config.RunContext, out output.Root, opts output.Options) error {
+	path, _ := cmd.Flags().GetString("full-report-file")
+	if path == "" {
+		return nil
+	}
+
+	opts.Verbosity = output.VerbosityFull
+	b, err := output.ToDiff(out, opts)
+	if err != nil {
+		return err
+	}
+
+	return saveOutFileWithMsg(ctx, cmd, path, fmt.Sprintf("Full report saved to %s", path), []byte(ui.StripColor(string(b))))
+}
+
 func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string, mdOpts output.MarkdownOptions) ([]byte, error) {
+	ctx.Config.FailOn, _ = cmd.Flags().GetString("fail-on")
+	if !clierror.IsValidFailOn(ctx.Config.FailOn) {
+		ui.PrintUsage(cmd)
+		return nil, fmt.Errorf("--fail-on only supports %s", strings.Join(clierror.FailOnLevels(), ", "))
+	}
+
+	err := checkVerbosity(cmd, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	inputs, err := output.LoadPaths(paths)
 	if err != nil {
 		return nil, err
@@ -65,6 +126,7 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 	if ctx.Config.EnableDashboard && !dryRun {
 		if ctx.Config.IsSelfHosted() {
 			ui.PrintWarning(cmd.ErrOrStderr(), "The dashboard is part of Infracost's hosted services. Contact hello@infracost.io for help.")
+			ctx.RecordWarning()
 		}
 
 		combined.RunID, combined.ShareURL = shareCombinedRun(ctx, combined, inputs)
@@ -77,9 +139,20 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 		if err != nil {
 			return nil, err
 		}
+	}
 
+	wasmPolicyPaths, _ := cmd.Flags().GetStringArray("policy-wasm")
+	if len(wasmPolicyPaths) > 0 {
+		err = queryWASMPolicies(ctx.Context(), wasmPolicyPaths, combined, &policyChecks)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if policyChecks.Enabled {
 		ctx.SetContextValue("passedPolicyCount", len(policyChecks.Passed))
 		ctx.SetContextValue("failedPolicyCount", len(policyChecks.Failures))
+		ctx.SetContextValue("suppressedPolicyCount", len(policyChecks.Suppressed))
 	}
 
 	opts := output.Options{
@@ -87,8 +160,16 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 		NoColor:          ctx.Config.NoColor,
 		ShowSkipped:      true,
 		PolicyChecks:     policyChecks,
+		Verbosity:        outputVerbosity(cmd, ""),
+	}
+
+	err = saveFullReport(cmd, ctx, combined, opts)
+	if err != nil {
+		return nil, err
 	}
 
+	mdOpts.FullReportURL, _ = cmd.Flags().GetString("full-report-url")
+
 	b, err := output.ToMarkdown(combined, opts, mdOpts)
 	if err != nil {
 		return nil, err
@@ -101,6 +182,20 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 	return b, nil
 }
 
+// commentFailOnError returns the error the comment command should fail with once the
+// comment has been posted, based on the --fail-on flag.
+func commentFailOnError(ctx *config.RunContext, policyFailure output.PolicyCheckFailures) error {
+	if policyFailure != nil && ctx.Config.FailOn != clierror.FailOnError {
+		return policyFailure
+	}
+
+	if ctx.Config.FailOn == clierror.FailOnWarning && ctx.WarningCount() > 0 {
+		return newWarningsError(ctx.WarningCount())
+	}
+
+	return nil
+}
+
 type PRNumber int
 
 func (p *PRNumber) Set(value string) error {
@@ -161,11 +256,11 @@ func queryPolicy(policyPaths []string, input output.Root) (output.PolicyCheck, e
 	for _, e := range res[0].Expressions {
 		switch v := e.Value.(type) {
 		case map[string]interface{}:
-			readPolicyOut(v, &checks)
+			readPolicyOut(v, &checks, input)
 		case []interface{}:
 			for _, ii := range v {
 				if m, ok := ii.(map[string]interface{}); ok {
-					readPolicyOut(m, &checks)
+					readPolicyOut(m, &checks, input)
 				}
 			}
 		}
@@ -174,7 +269,42 @@ func queryPolicy(policyPaths []string, input output.Root) (output.PolicyCheck, e
 	return checks, nil
 }
 
-func readPolicyOut(v map[string]interface{}, checks *output.PolicyCheck) {
+// queryWASMPolicies runs the WASM policy modules with the Infracost JSON as their input and adds their
+// results to checks. The modules write a JSON array of rule output objects, the same as the objects of
+// Rego data.infracost.deny rules.
+func queryWASMPolicies(ctx context.Context, paths []string, input output.Root, checks *output.PolicyCheck) error {
+	checks.Enabled = true
+
+	b, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("Unable to process Infracost output into WASM policy input: %s", err.Error())
+	}
+
+	for _, path := range paths {
+		out, err := wasmext.RunFile(ctx, path, b)
+		if err != nil {
+			return err
+		}
+
+		var results []map[string]interface{}
+		err = json.Unmarshal(out, &results)
+		if err != nil {
+			return fmt.Errorf("The WASM policy %s didn't output a JSON array of rule output objects: %s", path, err.Error())
+		}
+
+		for _, v := range results {
+			readPolicyOut(v, checks, input)
+		}
+	}
+
+	return nil
+}
+
+// readPolicyOut reads a single policy rule output object into checks. Failed rules that set the optional
+// {project: string, resource: string, policy: string} properties are recorded as suppressed rather than
+// failed if the resource in that project has a matching infracost-ignore comment. The project can be
+// the project name or path.
+func readPolicyOut(v map[string]interface{}, checks *output.PolicyCheck, input output.Root) {
 	if _, ok := v["msg"]; !ok {
 		checks.Failures = append(checks.Failures, "Policy rule invalid as it did not contain {msg: string} property in output object. Please edit rule output object.")
 		return
@@ -189,7 +319,28 @@ func readPolicyOut(v map[string]interface{}, checks *output.PolicyCheck) {
 	failed, _ := v["failed"].(bool)
 
 	if failed {
+		project, _ := v["project"].(string)
+		resourceName, _ := v["resource"].(string)
+		policy, _ := v["policy"].(string)
+
+		if project != "" && resourceName != "" && policy != "" {
+			if s := input.FindSuppression(project, resourceName, policy); s != nil {
+				suppressed := fmt.Sprintf("%s (suppressed on %s", msg, resourceName)
+				if s.Reason != "" {
+					suppressed += fmt.Sprintf(`, reason: "%s"`, s.Reason)
+				}
+				checks.Suppressed = append(checks.Suppressed, suppressed+")")
+				return
+			}
+		}
+
 		checks.Failures = append(checks.Failures, msg)
+		if resourceName != "" {
+			if checks.ResourceFailures == nil {
+				checks.ResourceFailures = map[string][]string{}
+			}
+			checks.ResourceFailures[resourceName] = append(checks.ResourceFailures[resourceName], msg)
+		}
 		return
 	}
 
@@ -67,6 +67,7 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 				WillUpdate:          prNumber != 0 && behavior == "update",
 				WillReplace:         prNumber != 0 && behavior == "delete-and-new",
 				IncludeFeedbackLink: true,
+				MaxMessageSize:      commentMaxMessageSizes["azure-repos-comment"],
 			})
 			var policyFailure output.PolicyCheckFailures
 			if err != nil {
@@ -96,8 +97,8 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to Azure Repos (--dry-run was specified)")
 			}
 
-			if policyFailure != nil {
-				return policyFailure
+			if err := commentFailOnError(ctx, policyFailure); err != nil {
+				return err
 			}
 
 			return nil
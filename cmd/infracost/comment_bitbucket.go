@@ -82,6 +82,7 @@ func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 				WillReplace:         prNumber != 0 && behavior == "delete-and-new",
 				IncludeFeedbackLink: true,
 				BasicSyntax:         true,
+				MaxMessageSize:      commentMaxMessageSizes["bitbucket-comment"],
 			})
 			var policyFailure output.PolicyCheckFailures
 			if err != nil {
@@ -111,8 +112,8 @@ func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to Bitbucket (--dry-run was specified)")
 			}
 
-			if policyFailure != nil {
-				return policyFailure
+			if err := commentFailOnError(ctx, policyFailure); err != nil {
+				return err
 			}
 
 			return nil
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/comment"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validCommentBuildkiteBehaviors = []string{"update", "append"}
+
+func commentBuildkiteCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "buildkite",
+		Short: "Add an Infracost annotation to a Buildkite build",
+		Long: `Add an Infracost annotation to a Buildkite build.
+
+The annotation is added with buildkite-agent annotate, so this must be run from a
+Buildkite job and doesn't need an API token. The annotation is shown with the error
+style if any policies fail, unless --style is set.`,
+		Example: `  Update the annotation of the build:
+
+      infracost comment buildkite --path infracost.json
+
+  Add the estimate of each job in a parallel step to the same annotation:
+
+      infracost comment buildkite --path infracost.json --behavior append
+
+  Add a separate annotation for each step:
+
+      infracost comment buildkite --path infracost.json --context infracost-$BUILDKITE_STEP_KEY`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.SetContextValue("platform", "buildkite")
+			ctx.SetContextValue("targetType", "build")
+
+			behavior, _ := cmd.Flags().GetString("behavior")
+			if behavior != "" && !contains(validCommentBuildkiteBehaviors, behavior) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentBuildkiteBehaviors, ", "))
+			}
+			ctx.SetContextValue("behavior", behavior)
+
+			style, _ := cmd.Flags().GetString("style")
+			if !contains(comment.BuildkiteAnnotationStyles, style) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--style only supports %s", strings.Join(comment.BuildkiteAnnotationStyles, ", "))
+			}
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			body, err := buildCommentBody(cmd, ctx, paths, output.MarkdownOptions{
+				IncludeFeedbackLink: true,
+				MaxMessageSize:      commentMaxMessageSizes["buildkite-annotation"],
+			})
+			var policyFailure output.PolicyCheckFailures
+			if err != nil {
+				if v, ok := err.(output.PolicyCheckFailures); ok {
+					policyFailure = v
+				} else {
+					return err
+				}
+			}
+
+			if policyFailure != nil && !cmd.Flags().Changed("style") {
+				style = "error"
+			}
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if !dryRun {
+				annotationContext, _ := cmd.Flags().GetString("context")
+				annotator, err := comment.NewBuildkiteAnnotator(annotationContext, style)
+				if err != nil {
+					return err
+				}
+
+				err = annotator.Annotate(ctx.Context(), string(body), behavior == "append")
+				if err != nil {
+					return err
+				}
+
+				pricingClient := apiclient.NewPricingAPIClient(ctx)
+				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
+				if err != nil {
+					log.Errorf("Error reporting event: %s", err)
+				}
+
+				cmd.Println("Annotation added to Buildkite build")
+			} else {
+				cmd.Println(string(body))
+				cmd.Println("Annotation not added to Buildkite build (--dry-run was specified)")
+			}
+
+			if err := commentFailOnError(ctx, policyFailure); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("behavior", "update", `Behavior when adding annotation, one of:
+  update (default)  Replace the annotation with the same context
+  append            Append to the annotation with the same context`)
+	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validCommentBuildkiteBehaviors, cobra.ShellCompDirectiveDefault
+	})
+	cmd.Flags().String("context", "", "Context of the annotation, used to replace or append to it in later runs (default \"infracost-comment\")")
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+	cmd.Flags().String("style", "info", "Style of the annotation: info, success, warning, error")
+	_ = cmd.RegisterFlagCompletionFunc("style", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return comment.BuildkiteAnnotationStyles, cobra.ShellCompDirectiveDefault
+	})
+	cmd.Flags().Bool("dry-run", false, "Generate annotation without actually adding it to Buildkite")
+
+	return cmd
+}
@@ -0,0 +1,72 @@
+package main_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+// fakeBuildkiteAgent adds a buildkite-agent script to the PATH that saves the arguments and body of
+// annotations to dir.
+func fakeBuildkiteAgent(t *testing.T, dir string) {
+	t.Helper()
+
+	script := fmt.Sprintf("#!/bin/sh\necho \"$@\" > %s\ncat > %s\n", filepath.Join(dir, "args"), filepath.Join(dir, "body"))
+	err := os.WriteFile(filepath.Join(dir, "buildkite-agent"), []byte(script), 0755) // nolint:gosec
+	require.NoError(t, err)
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestCommentBuildkiteHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"comment", "buildkite", "--help"}, nil)
+}
+
+func TestCommentBuildkiteDryRun(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "buildkite", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run"},
+		nil)
+}
+
+func TestCommentBuildkite(t *testing.T) {
+	dir := t.TempDir()
+	fakeBuildkiteAgent(t, dir)
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "buildkite", "--path", "./testdata/terraform_v0.14_breakdown.json", "--context", "infracost-prod", "--behavior", "append"},
+		nil)
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	assert.Equal(t, "annotate --context infracost-prod --style info --append\n", string(args))
+
+	body, err := os.ReadFile(filepath.Join(dir, "body"))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Infracost estimate")
+}
+
+func TestCommentBuildkitePolicyFailure(t *testing.T) {
+	testdataName := testutil.CalcGoldenFileTestdataDirName()
+	dir := t.TempDir()
+	fakeBuildkiteAgent(t, dir)
+
+	GoldenFileCommandTest(t, testdataName,
+		[]string{"comment", "buildkite", "--path", "./testdata/terraform_v0.14_breakdown.json", "--policy-path", "./testdata/" + testdataName + "/policy.rego"},
+		nil)
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	assert.Equal(t, "annotate --context infracost-comment --style error\n", string(args))
+}
+
+func TestCommentBuildkiteInvalidStyle(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "buildkite", "--path", "./testdata/terraform_v0.14_breakdown.json", "--style", "purple", "--dry-run"},
+		nil)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// circleCICommentFilename is the name of the file the comment is saved to in the artifacts directory.
+const circleCICommentFilename = "infracost-comment.md"
+
+func commentCircleCICmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "circleci",
+		Short: "Save an Infracost comment as a CircleCI artifact",
+		Long: `Save an Infracost comment as a CircleCI artifact.
+
+CircleCI doesn't have an API for annotating jobs, so the comment is saved to the
+artifacts directory for a store_artifacts step to upload. It's then shown in the
+Artifacts tab of the job, and can be linked to from other comments with --full-report-url.`,
+		Example: `  Save the comment to the artifacts directory:
+
+      infracost comment circleci --path infracost.json --artifacts-dir /tmp/artifacts
+
+  Then upload it in a later step of the job:
+
+      - store_artifacts:
+          path: /tmp/artifacts`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.SetContextValue("platform", "circleci")
+			ctx.SetContextValue("targetType", "artifact")
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			body, err := buildCommentBody(cmd, ctx, paths, output.MarkdownOptions{
+				IncludeFeedbackLink: true,
+				MaxMessageSize:      commentMaxMessageSizes["circleci-artifact"],
+			})
+			var policyFailure output.PolicyCheckFailures
+			if err != nil {
+				if v, ok := err.(output.PolicyCheckFailures); ok {
+					policyFailure = v
+				} else {
+					return err
+				}
+			}
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if !dryRun {
+				dir, _ := cmd.Flags().GetString("artifacts-dir")
+				err = os.MkdirAll(dir, 0755)
+				if err != nil {
+					return errors.Wrap(err, "Error creating CircleCI artifacts directory")
+				}
+
+				path := filepath.Join(dir, circleCICommentFilename)
+				err = os.WriteFile(path, body, 0644) // nolint:gosec
+				if err != nil {
+					return errors.Wrap(err, "Error saving CircleCI artifact")
+				}
+
+				pricingClient := apiclient.NewPricingAPIClient(ctx)
+				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
+				if err != nil {
+					log.Errorf("Error reporting event: %s", err)
+				}
+
+				cmd.Printf("Comment saved to %s, upload it with a store_artifacts step\n", path)
+			} else {
+				cmd.Println(string(body))
+				cmd.Println("Comment not saved as a CircleCI artifact (--dry-run was specified)")
+			}
+
+			if err := commentFailOnError(ctx, policyFailure); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("artifacts-dir", "infracost-artifacts", "Directory to save the comment to, which should be uploaded with a store_artifacts step")
+	_ = cmd.MarkFlagDirname("artifacts-dir")
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+	cmd.Flags().Bool("dry-run", false, "Generate comment without actually saving it")
+
+	return cmd
+}
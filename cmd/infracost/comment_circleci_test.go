@@ -0,0 +1,38 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestCommentCircleciHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"comment", "circleci", "--help"}, nil)
+}
+
+func TestCommentCircleciDryRun(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "circleci", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run"},
+		nil)
+}
+
+func TestCommentCircleci(t *testing.T) {
+	// The directory is relative so the path in the output is the same in every run.
+	dir := "./testdata/comment_circleci/artifacts"
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "circleci", "--path", "./testdata/terraform_v0.14_breakdown.json", "--artifacts-dir", dir},
+		nil)
+
+	body, err := os.ReadFile(filepath.Join(dir, "infracost-comment.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Infracost estimate")
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/comment"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+func commentGerritCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gerrit",
+		Short: "Post an Infracost review to a Gerrit change",
+		Long: `Post an Infracost review to a Gerrit change.
+
+The review adds the comment as a message on the change and votes on a label. The
+failure vote is used if the run fails with --fail-on, e.g. when a policy fails,
+otherwise the success vote is used. A success vote of 0 removes a previous failure
+vote once the change is fixed. Gerrit messages can't be updated, so each run posts
+a new message.`,
+		Example: `  Post a review on the current patch set of a change, voting Code-Review -1 if a policy fails:
+
+      infracost comment gerrit --gerrit-server-url https://gerrit.example.com --change 1234 --path infracost.json --policy-path policy.rego --gerrit-username infracost-bot --gerrit-password $GERRIT_HTTP_PASSWORD
+
+  Vote on a custom label:
+
+      infracost comment gerrit --gerrit-server-url https://gerrit.example.com --change 1234 --path infracost.json --label Cost-Review --failure-vote -2 --success-vote 1 --gerrit-username infracost-bot --gerrit-password $GERRIT_HTTP_PASSWORD`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.SetContextValue("platform", "gerrit")
+			ctx.SetContextValue("targetType", "change")
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			body, err := buildCommentBody(cmd, ctx, paths, output.MarkdownOptions{
+				IncludeFeedbackLink: true,
+				BasicSyntax:         true,
+				MaxMessageSize:      commentMaxMessageSizes["gerrit-comment"],
+			})
+			var policyFailure output.PolicyCheckFailures
+			if err != nil {
+				if v, ok := err.(output.PolicyCheckFailures); ok {
+					policyFailure = v
+				} else {
+					return err
+				}
+			}
+
+			failErr := commentFailOnError(ctx, policyFailure)
+
+			labels := map[string]int{}
+			voteMsg := ""
+			if label, _ := cmd.Flags().GetString("label"); label != "" {
+				vote, _ := cmd.Flags().GetInt("success-vote")
+				if failErr != nil {
+					vote, _ = cmd.Flags().GetInt("failure-vote")
+				}
+				labels[label] = vote
+
+				// Gerrit shows positive votes with a plus sign and no vote as 0.
+				voteMsg = fmt.Sprintf(" with %s %d", label, vote)
+				if vote > 0 {
+					voteMsg = fmt.Sprintf(" with %s +%d", label, vote)
+				}
+			}
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if !dryRun {
+				serverURL, _ := cmd.Flags().GetString("gerrit-server-url")
+				username, _ := cmd.Flags().GetString("gerrit-username")
+				password, _ := cmd.Flags().GetString("gerrit-password")
+				change, _ := cmd.Flags().GetString("change")
+				revision, _ := cmd.Flags().GetString("revision")
+
+				reviewer, err := comment.NewGerritReviewer(change, revision, comment.GerritExtra{
+					ServerURL: serverURL,
+					Username:  username,
+					Password:  password,
+				})
+				if err != nil {
+					return err
+				}
+
+				err = reviewer.Review(ctx.Context(), string(body), labels)
+				if err != nil {
+					return err
+				}
+
+				pricingClient := apiclient.NewPricingAPIClient(ctx)
+				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
+				if err != nil {
+					log.Errorf("Error reporting event: %s", err)
+				}
+
+				cmd.Printf("Review posted to Gerrit%s\n", voteMsg)
+			} else {
+				cmd.Println(string(body))
+				cmd.Printf("Review not posted to Gerrit%s (--dry-run was specified)\n", voteMsg)
+			}
+
+			return failErr
+		},
+	}
+
+	cmd.Flags().String("change", "", "Change to post the review on, e.g. the change number or Change-Id")
+	_ = cmd.MarkFlagRequired("change")
+	cmd.Flags().Int("failure-vote", -1, "Vote on the label when the run fails with --fail-on")
+	cmd.Flags().String("gerrit-password", "", "HTTP password of the Gerrit account")
+	_ = cmd.MarkFlagRequired("gerrit-password")
+	cmd.Flags().String("gerrit-server-url", "", "Gerrit server URL")
+	_ = cmd.MarkFlagRequired("gerrit-server-url")
+	cmd.Flags().String("gerrit-username", "", "Username of the Gerrit account")
+	_ = cmd.MarkFlagRequired("gerrit-username")
+	cmd.Flags().String("label", "Code-Review", "Label to vote on, set to an empty string to not vote")
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+	cmd.Flags().String("revision", "current", "Revision of the change to post the review on, e.g. a patch set number or commit SHA")
+	cmd.Flags().Int("success-vote", 0, "Vote on the label when the run doesn't fail")
+	cmd.Flags().Bool("dry-run", false, "Generate review without actually posting to Gerrit")
+
+	return cmd
+}
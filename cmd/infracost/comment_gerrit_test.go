@@ -0,0 +1,24 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestCommentGerritHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"comment", "gerrit", "--help"}, nil)
+}
+
+func TestCommentGerritDryRun(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "gerrit", "--gerrit-server-url", "https://gerrit.example.com", "--gerrit-username", "infracost-bot", "--gerrit-password", "abc", "--change", "1234", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run"},
+		nil)
+}
+
+func TestCommentGerritPolicyFailureDryRun(t *testing.T) {
+	testdataName := testutil.CalcGoldenFileTestdataDirName()
+	GoldenFileCommandTest(t, testdataName,
+		[]string{"comment", "gerrit", "--gerrit-server-url", "https://gerrit.example.com", "--gerrit-username", "infracost-bot", "--gerrit-password", "abc", "--change", "1234", "--path", "./testdata/terraform_v0.14_breakdown.json", "--policy-path", "./testdata/" + testdataName + "/policy.rego", "--dry-run"},
+		nil)
+}
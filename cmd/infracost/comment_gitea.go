@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/comment"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validCommentGiteaBehaviors = []string{"update", "new", "delete-and-new"}
+
+func commentGiteaCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gitea",
+		Short: "Post an Infracost comment to Gitea",
+		Long:  "Post an Infracost comment to Gitea",
+		Example: `  Update comment on a pull request:
+
+      infracost comment gitea --gitea-server-url https://gitea.example.com --repo my-org/my-repo --pull-request 3 --path infracost.json --gitea-token $GITEA_TOKEN`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.SetContextValue("platform", "gitea")
+
+			var err error
+
+			serverURL, _ := cmd.Flags().GetString("gitea-server-url")
+			token, _ := cmd.Flags().GetString("gitea-token")
+			tag, _ := cmd.Flags().GetString("tag")
+			extra := comment.GiteaExtra{
+				ServerURL: serverURL,
+				Token:     token,
+				Tag:       tag,
+			}
+
+			prNumber, _ := cmd.Flags().GetInt("pull-request")
+			repo, _ := cmd.Flags().GetString("repo")
+
+			var commentHandler *comment.CommentHandler
+			if prNumber != 0 {
+				ctx.SetContextValue("targetType", "pull-request")
+
+				commentHandler, err = comment.NewGiteaPRHandler(ctx.Context(), repo, strconv.Itoa(prNumber), extra)
+				if err != nil {
+					return err
+				}
+			} else {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--pull-request is required")
+			}
+
+			behavior, _ := cmd.Flags().GetString("behavior")
+			if behavior != "" && !contains(validCommentGiteaBehaviors, behavior) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentGiteaBehaviors, ", "))
+			}
+			ctx.SetContextValue("behavior", behavior)
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			body, err := buildCommentBody(cmd, ctx, paths, output.MarkdownOptions{
+				WillUpdate:          behavior == "update",
+				WillReplace:         behavior == "delete-and-new",
+				IncludeFeedbackLink: true,
+				MaxMessageSize:      commentMaxMessageSizes["gitea-comment"],
+			})
+			var policyFailure output.PolicyCheckFailures
+			if err != nil {
+				if v, ok := err.(output.PolicyCheckFailures); ok {
+					policyFailure = v
+				} else {
+					return err
+				}
+			}
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if !dryRun {
+				err = commentHandler.CommentWithBehavior(ctx.Context(), behavior, string(body))
+				if err != nil {
+					return err
+				}
+
+				pricingClient := apiclient.NewPricingAPIClient(ctx)
+				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
+				if err != nil {
+					log.Errorf("Error reporting event: %s", err)
+				}
+
+				cmd.Println("Comment posted to Gitea")
+			} else {
+				cmd.Println(string(body))
+				cmd.Println("Comment not posted to Gitea (--dry-run was specified)")
+			}
+
+			if err := commentFailOnError(ctx, policyFailure); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("behavior", "update", `Behavior when posting comment, one of:
+  update (default)  Update latest comment
+  new               Create a new comment
+  delete-and-new    Delete previous matching comments and create a new comment`)
+	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validCommentGiteaBehaviors, cobra.ShellCompDirectiveDefault
+	})
+	cmd.Flags().String("gitea-server-url", "", "Gitea server URL")
+	_ = cmd.MarkFlagRequired("gitea-server-url")
+	cmd.Flags().String("gitea-token", "", "Gitea access token with write access to the repository's issues")
+	_ = cmd.MarkFlagRequired("gitea-token")
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+	var prNumber PRNumber
+	cmd.Flags().Var(&prNumber, "pull-request", "Pull request number to post comment on")
+	cmd.Flags().String("repo", "", "Repository in format owner/repo")
+	_ = cmd.MarkFlagRequired("repo")
+	cmd.Flags().String("tag", "", "Customize hidden markdown tag used to detect comments posted by Infracost")
+	cmd.Flags().Bool("dry-run", false, "Generate comment without actually posting to Gitea")
+
+	return cmd
+}
@@ -0,0 +1,17 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestCommentGiteaHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"comment", "gitea", "--help"}, nil)
+}
+
+func TestCommentGiteaPullRequest(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "gitea", "--gitea-server-url", "https://gitea.example.com", "--gitea-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run"},
+		nil)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -28,21 +29,20 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 
   Post a new comment to a commit:
 
-      infracost comment github --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior hide-and-new --github-token $GITHUB_TOKEN`,
+      infracost comment github --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior hide-and-new --github-token $GITHUB_TOKEN
+
+  Update comment on a pull request as a GitHub App:
+
+      infracost comment github --repo my-org/my-repo --pull-request 3 --path infracost.json --github-app-id 12345 --github-app-private-key-path app.pem`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx.SetContextValue("platform", "github")
 
-			var err error
-
-			apiURL, _ := cmd.Flags().GetString("github-api-url")
-			token, _ := cmd.Flags().GetString("github-token")
-			tag, _ := cmd.Flags().GetString("tag")
-			extra := comment.GitHubExtra{
-				APIURL: apiURL,
-				Token:  token,
-				Tag:    tag,
+			extra, err := gitHubExtraFromFlags(cmd)
+			if err != nil {
+				return err
 			}
+			extra.Tag, _ = cmd.Flags().GetString("tag")
 
 			commit, _ := cmd.Flags().GetString("commit")
 			prNumber, _ := cmd.Flags().GetInt("pull-request")
@@ -81,6 +81,7 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 				WillUpdate:          prNumber != 0 && behavior == "update",
 				WillReplace:         prNumber != 0 && behavior == "delete-and-new",
 				IncludeFeedbackLink: true,
+				MaxMessageSize:      commentMaxMessageSizes["github-comment"],
 			})
 			var policyFailure output.PolicyCheckFailures
 			if err != nil {
@@ -110,9 +111,9 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to GitHub (--dry-run was specified)")
 			}
 
-			if policyFailure != nil {
+			if err := commentFailOnError(ctx, policyFailure); err != nil {
 				cmd.Printf("\n")
-				return policyFailure
+				return err
 			}
 
 			return nil
@@ -128,9 +129,7 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 		return validCommentGitHubBehaviors, cobra.ShellCompDirectiveDefault
 	})
 	cmd.Flags().String("commit", "", "Commit SHA to post comment on, mutually exclusive with pull-request")
-	cmd.Flags().String("github-api-url", "https://api.github.com", "GitHub API URL")
-	cmd.Flags().String("github-token", "", "GitHub token")
-	_ = cmd.MarkFlagRequired("github-token")
+	addGitHubAuthFlags(cmd)
 	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
 	_ = cmd.MarkFlagRequired("path")
 	_ = cmd.MarkFlagFilename("path", "json")
@@ -143,3 +142,46 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 
 	return cmd
 }
+
+// addGitHubAuthFlags adds the flags for the GitHub API URL and either a token or a GitHub App
+// to authenticate with.
+func addGitHubAuthFlags(cmd *cobra.Command) {
+	cmd.Flags().String("github-api-url", "https://api.github.com", "GitHub API URL")
+	cmd.Flags().String("github-token", "", "GitHub token, required unless using a GitHub App")
+	cmd.Flags().Int64("github-app-id", 0, "GitHub App ID to authenticate as instead of using a GitHub token")
+	cmd.Flags().Int64("github-app-installation-id", 0, "GitHub App installation ID, defaults to the installation for the repo")
+	cmd.Flags().String("github-app-private-key-path", "", "Path to the GitHub App's PEM encoded private key")
+	_ = cmd.MarkFlagFilename("github-app-private-key-path", "pem")
+}
+
+// gitHubExtraFromFlags returns the GitHub API URL and credentials from the flags added by
+// addGitHubAuthFlags.
+func gitHubExtraFromFlags(cmd *cobra.Command) (comment.GitHubExtra, error) {
+	var extra comment.GitHubExtra
+
+	extra.APIURL, _ = cmd.Flags().GetString("github-api-url")
+	extra.Token, _ = cmd.Flags().GetString("github-token")
+	extra.AppID, _ = cmd.Flags().GetInt64("github-app-id")
+	extra.AppInstallationID, _ = cmd.Flags().GetInt64("github-app-installation-id")
+	appKeyPath, _ := cmd.Flags().GetString("github-app-private-key-path")
+
+	if extra.Token == "" && extra.AppID == 0 {
+		ui.PrintUsage(cmd)
+		return extra, fmt.Errorf("either --github-token or --github-app-id is required")
+	}
+
+	if extra.AppID != 0 {
+		if appKeyPath == "" {
+			ui.PrintUsage(cmd)
+			return extra, fmt.Errorf("--github-app-private-key-path is required when using --github-app-id")
+		}
+
+		key, err := os.ReadFile(appKeyPath)
+		if err != nil {
+			return extra, fmt.Errorf("Error reading GitHub App private key: %w", err)
+		}
+		extra.AppPrivateKey = key
+	}
+
+	return extra, nil
+}
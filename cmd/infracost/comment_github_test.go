@@ -1,8 +1,12 @@
 package main_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/infracost/infracost/internal/testutil"
 )
 
@@ -21,3 +25,27 @@ func TestCommentGitHubCommit(t *testing.T) {
 		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--commit", "5", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run"},
 		nil)
 }
+
+func TestCommentGitHubFullReportFile(t *testing.T) {
+	testdataName := testutil.CalcGoldenFileTestdataDirName()
+	goldenFilePath := "./testdata/" + testdataName + "/full_report.golden"
+	reportPath := filepath.Join(t.TempDir(), "full_report.txt")
+
+	GoldenFileCommandTest(t, testdataName,
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run", "--full-report-file", reportPath, "--verbosity", "quiet"},
+		nil)
+
+	actual, err := os.ReadFile(reportPath)
+	require.Nil(t, err)
+	actual = stripDynamicValues(actual)
+
+	testutil.AssertGoldenFile(t, goldenFilePath, actual)
+}
+
+func TestCommentGitHubPolicyWASM(t *testing.T) {
+	policy := testutil.WASMModule(t, "./testdata/wasm/policy")
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "github", "--github-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/terraform_v0.14_breakdown.json", "--dry-run", "--policy-wasm", policy},
+		nil)
+}
@@ -81,6 +81,7 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 				WillUpdate:          mrNumber != 0 && behavior == "update",
 				WillReplace:         mrNumber != 0 && behavior == "delete-and-new",
 				IncludeFeedbackLink: true,
+				MaxMessageSize:      commentMaxMessageSizes["gitlab-comment"],
 			})
 			var policyFailure output.PolicyCheckFailures
 			if err != nil {
@@ -110,8 +111,8 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to GitLab (--dry-run was specified)")
 			}
 
-			if policyFailure != nil {
-				return policyFailure
+			if err := commentFailOnError(ctx, policyFailure); err != nil {
+				return err
 			}
 
 			return nil
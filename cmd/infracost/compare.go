@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validCompareFormats = []string{"table", "json"}
+
+func compareCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare run1.json run2.json [run3.json ...]",
+		Short: "Compare the cost of each resource across saved Infracost JSON runs",
+		Long: `Compare the cost of each resource across saved Infracost JSON runs.
+
+The resources of each run are aligned by their project name and address, and the monthly
+cost of each resource is shown for each run in the order they're given, with the change
+from the first run to the last. Resources that aren't in a run are shown as -. This is
+useful for comparing the estimates of several branches, or tracking the effect of
+successive PRs. The JSON format also includes the min, max and mean cost of each resource.`,
+		Example: `  Compare the estimates of two branches:
+
+      infracost compare main.json feature.json
+
+  Track the effect of successive PRs, only showing the resources that changed:
+
+      infracost compare pr-101.json pr-102.json pr-103.json --changed-only
+
+  Export the cost history of the runs in a directory as JSON:
+
+      infracost compare "runs/*.json" --format json --out-file history.json # glob needs quotes`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			if !contains(validCompareFormats, format) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--format only supports %s", strings.Join(validCompareFormats, ", "))
+			}
+
+			inputs, err := output.LoadPaths(args)
+			if err != nil {
+				return err
+			}
+
+			if len(inputs) < 2 {
+				ui.PrintUsage(cmd)
+				return errors.New("At least two Infracost JSON files are needed to compare")
+			}
+
+			comparison, err := output.NewComparison(inputs)
+			if err != nil {
+				return err
+			}
+
+			if changedOnly, _ := cmd.Flags().GetBool("changed-only"); changedOnly {
+				changed := []output.ComparisonResource{}
+				for _, r := range comparison.Resources {
+					if r.Changed() {
+						changed = append(changed, r)
+					}
+				}
+				comparison.Resources = changed
+			}
+
+			ctx.SetContextValue("outputFormat", format)
+			ctx.SetContextValue("compareRunCount", len(inputs))
+
+			var b []byte
+			switch format {
+			case "json":
+				b, err = output.ToComparisonJSON(comparison)
+				b = append(b, '\n')
+			default:
+				b = output.ToComparisonTable(comparison)
+			}
+			if err != nil {
+				return errors.Wrap(err, "Error generating output")
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-compare", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				return saveOutFile(ctx, cmd, outFile, b)
+			}
+
+			cmd.Print(string(b))
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("changed-only", false, "Only show the resources that aren't in every run or whose cost changed between runs")
+	cmd.Flags().String("format", "table", "Output format: table, json")
+	cmd.Flags().StringP("out-file", "o", "", "Save output to a file")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validCompareFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
@@ -0,0 +1,27 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestCompareHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"compare", "--help"}, nil)
+}
+
+func TestCompareTable(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"compare", "./testdata/compare/main.json", "./testdata/compare/pr-101.json", "./testdata/compare/pr-102.json"}, nil)
+}
+
+func TestCompareChangedOnly(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"compare", "./testdata/compare/main.json", "./testdata/compare/pr-101.json", "--changed-only"}, nil)
+}
+
+func TestCompareJSON(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"compare", "./testdata/compare/main.json", "./testdata/compare/pr-102.json", "--format", "json"}, nil)
+}
+
+func TestCompareSingleRun(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"compare", "./testdata/compare/main.json"}, nil)
+}
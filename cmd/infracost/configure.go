@@ -9,17 +9,25 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/httpclient"
 	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/update"
 )
 
 var supportedConfigureKeys = map[string]struct{}{
-	"api_key":                  {},
-	"currency":                 {},
-	"pricing_api_endpoint":     {},
-	"enable_dashboard":         {},
-	"disable_hcl":              {},
-	"tls_insecure_skip_verify": {},
-	"tls_ca_cert_file":         {},
+	"api_key":                   {},
+	"currency":                  {},
+	"pricing_api_endpoint":      {},
+	"enable_dashboard":          {},
+	"disable_hcl":               {},
+	"tls_insecure_skip_verify":  {},
+	"tls_ca_cert_file":          {},
+	"update_channel":            {},
+	"network_policy":            {},
+	"module_source_allow":       {},
+	"module_source_deny":        {},
+	"module_source_policy_mode": {},
 }
 
 func configureCmd(ctx *config.RunContext) *cobra.Command {
@@ -106,6 +114,33 @@ func configureSetCmd(ctx *config.RunContext) *cobra.Command {
 			case "currency":
 				ctx.Config.Configuration.Currency = value
 				saveConfiguration = true
+			case "update_channel":
+				if value != "" && !update.IsValidChannel(value) {
+					return fmt.Errorf("Invalid value, must be one of: %s", strings.Join(update.Channels(), ", "))
+				}
+
+				ctx.Config.Configuration.UpdateChannel = value
+				saveConfiguration = true
+			case "network_policy":
+				if value != "" && !httpclient.IsValidNetworkPolicy(value) {
+					return fmt.Errorf("Invalid value, must be one of: %s", strings.Join(httpclient.NetworkPolicies(), ", "))
+				}
+
+				ctx.Config.Configuration.NetworkPolicy = value
+				saveConfiguration = true
+			case "module_source_allow":
+				ctx.Config.Configuration.ModuleSourceAllow = splitConfigureList(value)
+				saveConfiguration = true
+			case "module_source_deny":
+				ctx.Config.Configuration.ModuleSourceDeny = splitConfigureList(value)
+				saveConfiguration = true
+			case "module_source_policy_mode":
+				if value != "" && !modules.IsValidSourcePolicyMode(value) {
+					return fmt.Errorf("Invalid value, must be one of: %s", strings.Join(modules.SourcePolicyModes(), ", "))
+				}
+
+				ctx.Config.Configuration.ModuleSourcePolicyMode = value
+				saveConfiguration = true
 			case "disable_hcl":
 				b, err := strconv.ParseBool(value)
 				if err != nil {
@@ -244,6 +279,40 @@ func configureGetCmd(ctx *config.RunContext) *cobra.Command {
 					)
 					ui.PrintWarning(cmd.ErrOrStderr(), msg)
 				}
+			case "update_channel":
+				value = ctx.Config.Configuration.UpdateChannel
+
+				if value == "" {
+					msg := fmt.Sprintf("No update channel in your saved config (%s), defaulting to stable.\nSet an update channel using %s.",
+						config.ConfigurationFilePath(),
+						ui.PrimaryString("infracost configure set update_channel beta"),
+					)
+					ui.PrintWarning(cmd.ErrOrStderr(), msg)
+				}
+			case "network_policy":
+				value = ctx.Config.Configuration.NetworkPolicy
+
+				if value == "" {
+					msg := fmt.Sprintf("No network policy in your saved config (%s), defaulting to all.\nSet a network policy using %s.",
+						config.ConfigurationFilePath(),
+						ui.PrimaryString("infracost configure set network_policy pricing_only"),
+					)
+					ui.PrintWarning(cmd.ErrOrStderr(), msg)
+				}
+			case "module_source_allow":
+				value = strings.Join(ctx.Config.Configuration.ModuleSourceAllow, ",")
+			case "module_source_deny":
+				value = strings.Join(ctx.Config.Configuration.ModuleSourceDeny, ",")
+			case "module_source_policy_mode":
+				value = ctx.Config.Configuration.ModuleSourcePolicyMode
+
+				if value == "" {
+					msg := fmt.Sprintf("No module source policy mode in your saved config (%s), defaulting to error.\nSet a module source policy mode using %s.",
+						config.ConfigurationFilePath(),
+						ui.PrimaryString("infracost configure set module_source_policy_mode warn"),
+					)
+					ui.PrintWarning(cmd.ErrOrStderr(), msg)
+				}
 			case "enable_dashboard":
 				if ctx.Config.Configuration.EnableDashboard == nil {
 					value = ""
@@ -278,11 +347,29 @@ Supported settings:
   - enable_dashboard: enable the Infracost dashboard
   - tls_insecure_skip_verify: skip TLS certificate checks for a self-hosted Cloud Pricing API
   - tls_ca_cert_file: verify certificate of a self-hosted Cloud Pricing API using this CA certificate
+  - update_channel: release channel used by infracost update, stable or beta
+  - network_policy: limit network access to all, pricing_only (only the Cloud Pricing API) or none
+  - module_source_allow: comma-separated patterns of the remote module sources that can be downloaded
+  - module_source_deny: comma-separated patterns of the remote module sources that can't be downloaded
+  - module_source_policy_mode: error or warn when a module source isn't allowed
 `
 
 	return fmt.Sprintf("%s.\n%s", description, settings)
 }
 
+// splitConfigureList splits a comma-separated configure value into its trimmed, non-empty items.
+func splitConfigureList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
 func validConfigureKeys() []string {
 	keys := make([]string, len(supportedConfigureKeys))
 
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func consoleCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "console",
+		Short: "Evaluate expressions against a Terraform directory",
+		Long: `Evaluate expressions against a Terraform directory, similar to terraform console.
+
+The directory is parsed and evaluated in the same way as when Infracost estimates it,
+so this can be used to debug why an attribute used for pricing resolved the way it did.
+If no --expr flags are given, expressions are read from stdin, one per line.`,
+		Example: `  Evaluate a module output:
+
+      infracost console --path /code --expr 'module.vpc.cidr_block'
+
+  Evaluate expressions read from stdin:
+
+      echo 'var.instance_type' | infracost console --path /code`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectCfg := &config.Project{
+				TerraformParseHCL: true,
+			}
+			projectCfg.Path, _ = cmd.Flags().GetString("path")
+			projectCfg.TerraformVarFiles, _ = cmd.Flags().GetStringSlice("terraform-var-file")
+			tfVars, _ := cmd.Flags().GetStringSlice("terraform-var")
+			projectCfg.TerraformVars = tfVarsToMap(tfVars)
+			projectCfg.TerraformWorkspace, _ = cmd.Flags().GetString("terraform-workspace")
+
+			opts := []hcl.Option{
+				hcl.OptionWithSpinner(ctx.NewSpinner),
+				hcl.OptionWithWarningFunc(ctx.NewWarningWriter()),
+			}
+			if projectCfg.TerraformWorkspace != "" {
+				opts = append(opts, hcl.OptionWithWorkspaceName(projectCfg.TerraformWorkspace))
+			}
+
+			provider, err := terraform.NewHCLProvider(config.NewProjectContext(ctx, projectCfg), nil, opts...)
+			if err != nil {
+				return err
+			}
+
+			rootModule, err := provider.Parser.ParseDirectory(ctx.Context())
+			modules.CloseSharedDownloads()
+			if err != nil {
+				return err
+			}
+
+			exprs, _ := cmd.Flags().GetStringArray("expr")
+			if len(exprs) > 0 {
+				for _, expr := range exprs {
+					if err := evaluateConsoleExpression(cmd.OutOrStdout(), rootModule, expr); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}
+
+			return evaluateConsoleInput(cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), rootModule)
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory")
+	cmd.Flags().StringArray("expr", nil, "Expression to evaluate, e.g. module.vpc.cidr_block. Can be repeated")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform’s -var-file flag")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform’s -var flag")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use")
+
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagDirname("path")
+
+	return cmd
+}
+
+func evaluateConsoleExpression(out io.Writer, module *hcl.Module, expr string) error {
+	val, err := module.EvaluateExpression(expr)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, hcl.FormatValue(val))
+	return nil
+}
+
+// evaluateConsoleInput evaluates each line of in as an expression. Errors are written to errOut so that
+// the remaining expressions are still evaluated.
+func evaluateConsoleInput(in io.Reader, out io.Writer, errOut io.Writer, module *hcl.Module) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		expr := strings.TrimSpace(scanner.Text())
+		if expr == "" {
+			continue
+		}
+
+		if err := evaluateConsoleExpression(out, module, expr); err != nil {
+			ui.PrintError(errOut, err.Error())
+		}
+	}
+
+	return scanner.Err()
+}
@@ -24,8 +24,10 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
       infracost diff --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
-				return err
+			if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
 			}
 
 			err := loadRunFlags(ctx.Config, cmd)
@@ -33,7 +35,7 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 				return err
 			}
 
-			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
 			if err != nil {
 				ui.PrintUsage(cmd)
 				return err
@@ -54,6 +56,7 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 	addRunFlags(cmd)
 
 	cmd.Flags().String("out-file", "", "Save output to a file")
+	addVerbosityFlags(cmd)
 
 	return cmd
 }
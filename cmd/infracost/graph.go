@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validGraphFormats = []string{"dot", "json"}
+
+func graphCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Export the module and resource dependency graph with costs",
+		Long: `Export the module and resource dependency graph with costs.
+
+Each project, module and resource is a node labelled with its monthly cost. Projects and
+modules are connected to the modules and resources they contain, and those edges are
+labelled with the spend they carry. Resources are connected to the resources they
+reference with dashed edges.`,
+		Example: `  Render the graph of a Terraform directory as an SVG with Graphviz:
+
+      infracost graph --path /code | dot -Tsvg > graph.svg
+
+  Export the graph of the projects in a config file as JSON:
+
+      infracost graph --config-file infracost.yml --format json --out-file graph.json`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx.SetContextValue("outputFormat", ctx.Config.Format)
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			est, err := estimateProjects(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			if est.cancelErr != nil {
+				return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+			}
+
+			g := output.NewGraph(est.projects, est.root.Currency)
+			ctx.SetContextValue("graphNodeCount", len(g.Nodes))
+
+			var b []byte
+			switch ctx.Config.Format {
+			case "json":
+				b, err = output.ToGraphJSON(g)
+			default:
+				b = output.ToGraphDot(g)
+			}
+			if err != nil {
+				return errors.Wrap(err, "Error generating output")
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-graph", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				err = saveOutFile(ctx, cmd, outFile, b)
+				if err != nil {
+					return err
+				}
+			} else {
+				cmd.Print(string(b))
+			}
+
+			if ctx.Config.FailOn == clierror.FailOnWarning && ctx.WarningCount() > 0 {
+				return newWarningsError(ctx.WarningCount())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse HCL code instead of generating a Terraform plan. This does not need credentials and is faster (experimental)")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform’s -var-file flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform’s -var flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-init-flags", "", "Flags to pass to 'terraform init'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+	addFailOnFlag(cmd)
+
+	cmd.Flags().String("format", "dot", "Output format: dot, json")
+	cmd.Flags().String("out-file", "", "Save output to a file")
+
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validGraphFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
@@ -0,0 +1,20 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestGraphHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"graph", "--help"}, nil)
+}
+
+func TestGraphDot(t *testing.T) {
+	testName := testutil.CalcGoldenFileTestdataDirName()
+	GoldenFileCommandTest(t, testName, []string{"graph", "--path", "./testdata/" + testName, "--terraform-parse-hcl", "--pricing-mock"}, nil)
+}
+
+func TestGraphInvalidFormat(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"graph", "--path", "./testdata/graph_dot", "--format", "table", "--pricing-mock"}, nil)
+}
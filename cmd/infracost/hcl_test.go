@@ -65,3 +65,9 @@ func TestHCLModuleOutputCountsNested(t *testing.T) {
 		&GoldenFileOptions{OnlyRunHCL: true},
 	)
 }
+
+func TestHCLProjectDependencies(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"breakdown", "--config-file", path.Join("./testdata", testutil.CalcGoldenFileTestdataDirName(), "infracost.config.yml"), "--pricing-mock"},
+		&GoldenFileOptions{OnlyRunHCL: true})
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validInventoryFormats = []string{"table", "json"}
+
+func inventoryCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Count the resources of each type without pricing them",
+		Long: `Count the resources of each type without pricing them.
+
+For each project the resource types are listed with the number of resources and whether
+they are priced, free or not supported yet. Priced resource types also show the number
+of price queries they need, which is useful for estimating API usage before onboarding
+new repos. No prices are fetched, so an API key isn't needed.`,
+		Example: `  Show the resource types of a Terraform directory:
+
+      infracost inventory --path /code --terraform-parse-hcl
+
+  Export the inventory of the projects in a config file as JSON:
+
+      infracost inventory --config-file infracost.yml --format json --out-file inventory.json`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx.Config.SkipPricing = true
+			ctx.SetContextValue("outputFormat", ctx.Config.Format)
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			est, err := estimateProjects(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			if est.cancelErr != nil {
+				return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+			}
+
+			inv := output.NewInventory(est.projects)
+			ctx.SetContextValue("inventoryResourceCount", inv.Summary.TotalResources)
+
+			var b []byte
+			switch ctx.Config.Format {
+			case "json":
+				b, err = output.ToInventoryJSON(inv)
+			default:
+				b = output.ToInventoryTable(inv)
+			}
+			if err != nil {
+				return errors.Wrap(err, "Error generating output")
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-inventory", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				err = saveOutFile(ctx, cmd, outFile, b)
+				if err != nil {
+					return err
+				}
+			} else {
+				cmd.Print(string(b))
+			}
+
+			if ctx.Config.FailOn == clierror.FailOnWarning && ctx.WarningCount() > 0 {
+				return newWarningsError(ctx.WarningCount())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse HCL code instead of generating a Terraform plan. This does not need credentials and is faster (experimental)")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform’s -var-file flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform’s -var flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-init-flags", "", "Flags to pass to 'terraform init'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+	addFailOnFlag(cmd)
+
+	cmd.Flags().String("format", "table", "Output format: table, json")
+	cmd.Flags().String("out-file", "", "Save output to a file")
+
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validInventoryFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
@@ -0,0 +1,30 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestInventoryHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"inventory", "--help"}, nil)
+}
+
+func TestInventoryTable(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"inventory", "--path", "./testdata/example_plan.json"}, nil, withoutAPIKey)
+}
+
+func TestInventoryJSON(t *testing.T) {
+	testName := testutil.CalcGoldenFileTestdataDirName()
+	GoldenFileCommandTest(t, testName, []string{"inventory", "--path", "./testdata/" + testName, "--terraform-parse-hcl", "--format", "json"}, nil, withoutAPIKey)
+}
+
+func TestInventoryInvalidFormat(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"inventory", "--path", "./testdata/example_plan.json", "--format", "html"}, nil)
+}
+
+// withoutAPIKey unsets the API key, for commands that don't need one.
+func withoutAPIKey(c *config.RunContext) {
+	c.Config.APIKey = ""
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/lsp"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage"
+)
+
+func lspCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Start a language server that shows costs while editing Terraform",
+		Long: `Start a language server that shows costs while editing Terraform.
+
+The server implements the Language Server Protocol over stdin and stdout, so editor
+extensions such as those for VS Code and Neovim can show the monthly cost of each resource
+and module block as a code lens, a breakdown of the cost when hovering over a block, and
+policy failures as diagnostics. The directory of each open .tf file is parsed as HCL, using
+the unsaved contents of the open files, and is estimated again when they change.
+
+Files are parsed in a hardened mode, so a file that is too large, too deeply nested, takes too
+long to parse or makes the parser panic is skipped and shown as a diagnostic instead of stopping
+the server. Other commands use this mode when INFRACOST_HARDENED_PARSE is true.`,
+		Example: `  Configure an editor to start the server with:
+
+      infracost lsp
+
+  Show policy failures and use a usage file:
+
+      infracost lsp --policy-path policy.rego --usage-file infracost-usage.yml`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if usesPricingMock(cmd, ctx.Config) {
+				ctx.Config.PricingBackend = prices.MockPricingBackend
+				ctx.Config.EventsDisabled = true
+			} else if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+				return err
+			}
+
+			// the server runs for as long as the editor is open, so a pathological file shouldn't take it down.
+			ctx.Config.HardenedParse = true
+
+			usageFile, _ := cmd.Flags().GetString("usage-file")
+			policyPaths, _ := cmd.Flags().GetStringArray("policy-path")
+
+			// the remote modules downloaded by each estimate are shared until the server exits.
+			defer modules.CloseSharedDownloads()
+
+			server := lsp.NewServer(lspEstimateFunc(ctx, usageFile, policyPaths), lsp.DefaultDebounce)
+
+			return server.Serve(ctx.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().StringArray("policy-path", nil, "Path to Infracost policy files to show failures of as diagnostics, glob patterns need quotes (experimental)")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("policy-path", "rego")
+
+	return cmd
+}
+
+// lspEstimateFunc returns a function that estimates a Terraform directory by parsing its HCL,
+// using the contents of the files open in the editor instead of the ones on disk.
+func lspEstimateFunc(ctx *config.RunContext, usageFilePath string, policyPaths []string) lsp.EstimateFunc {
+	return func(_ context.Context, dir string, overrides map[string][]byte) (*lsp.Estimate, error) {
+		projectCfg := &config.Project{
+			Path:              dir,
+			TerraformParseHCL: true,
+			UsageFile:         usageFilePath,
+		}
+		projectCtx := config.NewProjectContext(ctx, projectCfg)
+
+		provider, err := terraform.NewHCLProvider(projectCtx, terraform.NewPlanJSONProvider(projectCtx, false), hcl.OptionWithFileOverrides(overrides))
+		if err != nil {
+			return nil, err
+		}
+
+		usageData := map[string]*schema.UsageData{}
+		if usageFilePath != "" {
+			usageFile, err := usage.LoadUsageFile(usageFilePath)
+			if err != nil {
+				return nil, err
+			}
+			usageData = usageFile.ToUsageDataMap()
+		}
+
+		projects, err := provider.LoadResources(usageData)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error loading resources")
+		}
+
+		est := &lsp.Estimate{
+			Currency:       ctx.Config.Currency,
+			PolicyFailures: map[string][]string{},
+		}
+		for _, d := range provider.Parser.FileDiagnostics() {
+			est.FileErrors = append(est.FileErrors, lsp.FileError{Path: d.Filename, Line: d.Line, Message: d.Message})
+		}
+		if est.Currency == "" {
+			est.Currency = "USD"
+		}
+
+		for _, project := range projects {
+			terraform.AddSuppressions(dir, project.Resources)
+
+			err := prices.PopulatePrices(ctx, project)
+			if err != nil {
+				return nil, err
+			}
+
+			schema.CalculateCosts(project)
+			est.Resources = append(est.Resources, project.Resources...)
+		}
+
+		if len(policyPaths) == 0 {
+			return est, nil
+		}
+
+		root, err := output.ToOutputFormat(projects)
+		if err != nil {
+			return nil, err
+		}
+		root.Currency = est.Currency
+
+		checks, err := queryPolicy(policyPaths, root)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, msgs := range checks.ResourceFailures {
+			est.PolicyFailures[name] = msgs
+		}
+
+		if failures := otherPolicyFailures(checks); len(failures) > 0 {
+			est.PolicyFailures[""] = failures
+		}
+
+		return est, nil
+	}
+}
+
+// otherPolicyFailures returns the policy failures that aren't for a resource. Failures for a
+// resource are in both checks.Failures and checks.ResourceFailures.
+func otherPolicyFailures(checks output.PolicyCheck) []string {
+	remaining := map[string]int{}
+	for _, msgs := range checks.ResourceFailures {
+		for _, msg := range msgs {
+			remaining[msg]++
+		}
+	}
+
+	var failures []string
+	for _, msg := range checks.Failures {
+		if remaining[msg] > 0 {
+			remaining[msg]--
+			continue
+		}
+		failures = append(failures, msg)
+	}
+
+	return failures
+}
@@ -2,10 +2,14 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/signal"
 	"runtime/debug"
+	"strings"
+	"syscall"
 
 	"github.com/fatih/color"
 	"github.com/pkg/errors"
@@ -14,7 +18,15 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/bundle"
+	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/crash"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/httpclient"
+	"github.com/infracost/infracost/internal/logredact"
+	"github.com/infracost/infracost/internal/profile"
+	"github.com/infracost/infracost/internal/resourceplugin"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/update"
 	"github.com/infracost/infracost/internal/version"
@@ -28,7 +40,17 @@ func main() {
 // Cmd args and flags are parsed from the cli, but can also be directly injected
 // using the modifyCtx and args parameters.
 func Run(modifyCtx func(*config.RunContext), args *[]string) {
-	ctx, err := config.NewRunContextFromEnv(context.Background())
+	// Cancel the run context on Ctrl-C or SIGTERM, e.g. from a CI timeout, so in-flight
+	// requests and goroutines stop. Once cancelled we stop listening for the signals so a
+	// second Ctrl-C exits immediately.
+	rootCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-rootCtx.Done()
+		stop()
+	}()
+
+	ctx, err := config.NewRunContextFromEnv(rootCtx)
 	if err != nil {
 		if err.Error() != "" {
 			ui.PrintError(ctx.ErrWriter, err.Error())
@@ -41,6 +63,46 @@ func Run(modifyCtx func(*config.RunContext), args *[]string) {
 		modifyCtx(ctx)
 	}
 
+	// Redact the messages, warnings and errors written to stderr with the log_redaction rules from
+	// the config file, which are set once it's loaded.
+	ctx.ErrWriter = logredact.NewWriter(ctx.ErrWriter)
+
+	if ctx.Config.BundlePath != "" {
+		b, err := bundle.Open(ctx.Config.BundlePath)
+		if err != nil {
+			ui.PrintError(ctx.ErrWriter, err.Error())
+			ctx.Exit(1)
+		}
+
+		b.Configure(ctx.Config)
+	}
+
+	httpclient.Configure(ctx.Config.TLSCACertFile, ctx.Config.TLSInsecureSkipVerify)
+
+	err = httpclient.SetNetworkPolicy(ctx.Config.NetworkPolicy)
+	if err != nil {
+		ui.PrintError(ctx.ErrWriter, err.Error())
+		ctx.Exit(1)
+	}
+
+	err = modules.SetSourcePolicy(modules.SourcePolicy{
+		Allow: ctx.Config.ModuleSourceAllow,
+		Deny:  ctx.Config.ModuleSourceDeny,
+		Mode:  ctx.Config.ModuleSourcePolicyMode,
+	})
+	if err != nil {
+		ui.PrintError(ctx.ErrWriter, err.Error())
+		ctx.Exit(1)
+	}
+
+	// Telemetry and the update check would be blocked anyway, so don't attempt them.
+	if !httpclient.Allowed(httpclient.PurposeTelemetry) {
+		ctx.Config.EventsDisabled = true
+	}
+	if !httpclient.Allowed(httpclient.PurposeUpdate) {
+		ctx.Config.SkipUpdateCheck = true
+	}
+
 	var appErr error
 	updateMessageChan := make(chan *update.Info)
 
@@ -55,14 +117,26 @@ func Run(modifyCtx func(*config.RunContext), args *[]string) {
 
 		unexpectedErr := recover()
 		if unexpectedErr != nil {
-			withStack := fmt.Errorf("%s\n%s", unexpectedErr, debug.Stack())
-			handleUnexpectedErr(ctx, withStack)
+			handleUnexpectedErr(ctx, newPanicError(unexpectedErr, debug.Stack(), nil))
 		}
 
 		handleUpdateMessage(updateMessageChan)
 
-		if appErr != nil || unexpectedErr != nil {
-			ctx.Exit(1)
+		if err := profile.Stop(); err != nil {
+			log.Warnf("%s", err)
+		}
+
+		if err := httpclient.CloseAuditLog(); err != nil {
+			log.Debugf("Error closing audit log: %s", err)
+		}
+
+		resourceplugin.Close()
+		removeStdinProjects()
+
+		if unexpectedErr != nil {
+			ctx.Exit(clierror.ExitCodeError)
+		} else if appErr != nil {
+			ctx.Exit(clierror.ExitCode(appErr))
 		}
 	}()
 
@@ -107,16 +181,37 @@ func newRootCmd(ctx *config.RunContext) *cobra.Command {
 	}
 
 	rootCmd.PersistentFlags().Bool("no-color", false, "Turn off colored output")
+	rootCmd.PersistentFlags().Bool("no-progress", false, "Turn off progress spinners and messages")
 	rootCmd.PersistentFlags().String("log-level", "", "Log level (trace, debug, info, warn, error, fatal)")
+	rootCmd.PersistentFlags().String("audit-log", "", "Path to a JSON lines file to record every outbound request made during the run to")
+	rootCmd.PersistentFlags().String("cpu-profile", "", "Path to write a pprof CPU profile of the run to")
+	rootCmd.PersistentFlags().String("mem-profile", "", "Path to write a pprof heap profile of the run to")
+	rootCmd.PersistentFlags().String("trace", "", "Path to write an execution trace of the run to")
 
 	rootCmd.AddCommand(registerCmd(ctx))
 	rootCmd.AddCommand(configureCmd(ctx))
+	rootCmd.AddCommand(updateCmd(ctx))
 	rootCmd.AddCommand(diffCmd(ctx))
 	rootCmd.AddCommand(breakdownCmd(ctx))
 	rootCmd.AddCommand(outputCmd(ctx))
+	rootCmd.AddCommand(compareCmd(ctx))
 	rootCmd.AddCommand(commentCmd(ctx))
+	rootCmd.AddCommand(statusCmd(ctx))
+	rootCmd.AddCommand(reportCmd(ctx))
+	rootCmd.AddCommand(graphCmd(ctx))
+	rootCmd.AddCommand(inventoryCmd(ctx))
+	rootCmd.AddCommand(annotateCmd(ctx))
+	rootCmd.AddCommand(lspCmd(ctx))
+	rootCmd.AddCommand(benchmarkCmd(ctx))
+	rootCmd.AddCommand(testCmd(ctx))
+	rootCmd.AddCommand(moduleCostsCmd(ctx))
+	rootCmd.AddCommand(recommendCmd(ctx))
+	rootCmd.AddCommand(policyContextCmd(ctx))
+	rootCmd.AddCommand(uploadCmd(ctx))
+	rootCmd.AddCommand(bundleCmd(ctx))
 	rootCmd.AddCommand(completionCmd())
 	rootCmd.AddCommand(figAutocompleteCmd())
+	rootCmd.AddCommand(consoleCmd(ctx))
 
 	rootCmd.SetUsageTemplate(fmt.Sprintf(`%s{{if .Runnable}}
   {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
@@ -151,7 +246,7 @@ Use "{{.CommandPath}} [command] --help" for more information about a command.{{e
 		ui.BoldString("ADDITIONAL HELP TOPICS"),
 	))
 
-	rootCmd.SetVersionTemplate("Infracost {{.Version}}\n")
+	rootCmd.SetVersionTemplate(fmt.Sprintf("Infracost {{.Version}}\nCrypto: %s\n", version.CryptoMode()))
 	rootCmd.SetOut(ctx.OutWriter)
 	rootCmd.SetErr(ctx.ErrWriter)
 
@@ -171,9 +266,11 @@ func startUpdateCheck(ctx *config.RunContext, c chan *update.Info) {
 
 func checkAPIKey(apiKey string, apiEndpoint string, defaultEndpoint string) error {
 	if apiEndpoint == defaultEndpoint && apiKey == "" {
-		return fmt.Errorf(
-			"No INFRACOST_API_KEY environment variable is set.\nWe run a free Cloud Pricing API, to get an API key run %s",
-			ui.PrimaryString("infracost register"),
+		return clierror.New(
+			clierror.CodeMissingAPIKey,
+			clierror.CategoryConfig,
+			"No INFRACOST_API_KEY environment variable is set.",
+			fmt.Sprintf("We run a free Cloud Pricing API, to get an API key run %s", ui.PrimaryString("infracost register")),
 		)
 	}
 
@@ -185,16 +282,34 @@ func handleCLIError(ctx *config.RunContext, cliErr error) {
 		ui.PrintError(ctx.ErrWriter, cliErr.Error())
 	}
 
+	// When JSON output is requested, also write the error as a diagnostic so that
+	// scripts reading the output can check the error code and category.
+	if strings.ToLower(ctx.Config.Format) == "json" {
+		b, err := json.MarshalIndent(map[string]interface{}{"error": clierror.NewDiagnostic(cliErr)}, "", "  ")
+		if err == nil {
+			fmt.Fprintln(ctx.OutWriter, string(logredact.Bytes(b)))
+		}
+	}
+
 	err := apiclient.ReportCLIError(ctx, cliErr)
 	if err != nil {
 		log.Warnf("Error reporting CLI error: %s", err)
 	}
 }
 
-func handleUnexpectedErr(ctx *config.RunContext, err error) {
-	ui.PrintUnexpectedErrorStack(ctx.ErrWriter, err)
+// handleUnexpectedErr writes a crash report for the panic and prints where it was saved.
+// If the crash report can't be written the full stack trace is printed instead.
+func handleUnexpectedErr(ctx *config.RunContext, panicErr *panicError) {
+	report := crash.NewReport(ctx, panicErr.recovered, panicErr.stack, panicErr.project)
+	reportPath, err := report.Write("")
+	if err != nil {
+		log.Debugf("Error writing crash report: %s", err)
+		ui.PrintUnexpectedErrorStack(ctx.ErrWriter, panicErr)
+	} else {
+		ui.PrintUnexpectedErrorWithCrashReport(ctx.ErrWriter, fmt.Sprintf("%s", panicErr.recovered), reportPath)
+	}
 
-	err = apiclient.ReportCLIError(ctx, err)
+	err = apiclient.ReportCLIError(ctx, panicErr)
 	if err != nil {
 		log.Warnf("Error reporting unexpected error: %s", err)
 	}
@@ -220,6 +335,10 @@ func loadGlobalFlags(ctx *config.RunContext, cmd *cobra.Command) error {
 	}
 	color.NoColor = ctx.Config.NoColor
 
+	if cmd.Flags().Changed("no-progress") {
+		ctx.Config.NoProgress, _ = cmd.Flags().GetBool("no-progress")
+	}
+
 	if cmd.Flags().Changed("log-level") {
 		ctx.Config.LogLevel, _ = cmd.Flags().GetString("log-level")
 		err := ctx.Config.ConfigureLogger()
@@ -228,6 +347,38 @@ func loadGlobalFlags(ctx *config.RunContext, cmd *cobra.Command) error {
 		}
 	}
 
+	if cmd.Flags().Changed("audit-log") {
+		ctx.Config.AuditLogPath, _ = cmd.Flags().GetString("audit-log")
+	}
+
+	if ctx.Config.AuditLogPath != "" {
+		err := httpclient.EnableAuditLog(ctx.Config.AuditLogPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("cpu-profile") {
+		ctx.Config.CPUProfilePath, _ = cmd.Flags().GetString("cpu-profile")
+	}
+
+	if cmd.Flags().Changed("mem-profile") {
+		ctx.Config.MemProfilePath, _ = cmd.Flags().GetString("mem-profile")
+	}
+
+	if cmd.Flags().Changed("trace") {
+		ctx.Config.TracePath, _ = cmd.Flags().GetString("trace")
+	}
+
+	err := profile.Start(profile.Options{
+		CPUProfile: ctx.Config.CPUProfilePath,
+		MemProfile: ctx.Config.MemProfilePath,
+		Trace:      ctx.Config.TracePath,
+	})
+	if err != nil {
+		return err
+	}
+
 	ctx.SetContextValue("dashboardEnabled", ctx.Config.EnableDashboard)
 	ctx.SetContextValue("isDefaultPricingAPIEndpoint", ctx.Config.PricingAPIEndpoint == ctx.Config.DefaultPricingAPIEndpoint)
 
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func moduleCostsCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "module-costs",
+		Short: "Generate a cost summary of the examples of a Terraform module",
+		Long: `Generate a cost summary of the examples of a Terraform module.
+
+Each Terraform directory in the examples directory of the module is estimated by parsing its
+HCL, and the total monthly cost and the cost of each resource are output as JSON. The summary
+can be published with the module's docs, e.g. by a registry, so consumers can see its
+indicative cost before using it. If an example has an infracost-usage.yml file it's used for
+the estimate, otherwise usage-based costs aren't included.`,
+		Example: `  Generate the cost summary of a module with examples in its examples directory:
+
+      infracost module-costs --path terraform-aws-vpc --out-file costs.json
+
+  Generate the cost summary of a module with examples in another directory:
+
+      infracost module-costs --path terraform-aws-vpc --examples-dir tests/fixtures`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("path")
+			if path == "" {
+				ui.PrintUsage(cmd)
+				return errors.New("--path is required")
+			}
+
+			if usesPricingMock(cmd, ctx.Config) {
+				ctx.Config.PricingBackend = prices.MockPricingBackend
+				ctx.Config.EventsDisabled = true
+			} else if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			examplesDir, _ := cmd.Flags().GetString("examples-dir")
+			dirs, err := moduleExampleDirs(filepath.Join(path, examplesDir))
+			if err != nil {
+				return err
+			}
+
+			ctx.Config.Projects = hclDirProjects(dirs)
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			est, err := estimateProjects(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			if est.cancelErr != nil {
+				return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+			}
+
+			roots, err := est.rootsByPath(ctx.Config.Currency)
+			if err != nil {
+				return err
+			}
+
+			absPath, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+
+			costs := output.ModuleCosts{
+				Version:  output.ModuleCostsVersion,
+				Module:   filepath.Base(absPath),
+				Currency: ctx.Config.Currency,
+				Examples: make([]output.ModuleExample, 0, len(dirs)),
+			}
+
+			for _, dir := range dirs {
+				rel, err := filepath.Rel(path, dir)
+				if err != nil {
+					return err
+				}
+
+				costs.Examples = append(costs.Examples, output.NewModuleExample(filepath.Base(dir), filepath.ToSlash(rel), roots[dir]))
+			}
+
+			ctx.SetContextValue("moduleExampleCount", len(dirs))
+
+			b, err := output.ToModuleCostsJSON(costs)
+			if err != nil {
+				return errors.Wrap(err, "Error generating output")
+			}
+			b = append(b, '\n')
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-module-costs", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				return saveOutFile(ctx, cmd, outFile, b)
+			}
+
+			cmd.Print(string(b))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform module")
+	cmd.Flags().String("examples-dir", "examples", "Directory in the module that has a directory for each example configuration")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+	cmd.Flags().String("out-file", "", "Save output to a file")
+
+	_ = cmd.MarkFlagDirname("path")
+
+	return cmd
+}
+
+// moduleExampleDirs returns the directories in dir that have Terraform files, sorted by name.
+// Examples that are nested deeper aren't searched for.
+func moduleExampleDirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "Error reading examples directory")
+	}
+
+	var dirs []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		p := filepath.Join(dir, e.Name())
+		if terraform.IsTerraformDir(p) {
+			dirs = append(dirs, p)
+		}
+	}
+
+	if len(dirs) == 0 {
+		return nil, clierror.New(
+			clierror.CodeNoTerraformFiles,
+			clierror.CategoryUser,
+			fmt.Sprintf("No examples found in %s", dir),
+			"Add a directory with the Terraform configuration of each example, or set --examples-dir",
+		)
+	}
+
+	return dirs, nil
+}
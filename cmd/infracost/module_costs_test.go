@@ -0,0 +1,19 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestModuleCostsHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"module-costs", "--help"}, nil)
+}
+
+func TestModuleCosts(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"module-costs", "--path", "./testdata/module_costs", "--pricing-mock"}, nil)
+}
+
+func TestModuleCostsNoExamples(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"module-costs", "--path", "./testdata/module_costs", "--examples-dir", "missing", "--pricing-mock"}, nil)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/wasmext"
 )
 
 var (
@@ -25,6 +26,8 @@ var (
 		"azure-repos-comment",
 		"bitbucket-comment",
 		"slack-message",
+		"cost-centers",
+		"csv",
 	}
 
 	validCompareToFormats = map[string]bool{
@@ -69,7 +72,15 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 
   Create markdown report to post in a Bitbucket comment:
 
-      infracost output --format bitbucket-comment --path "out*.json" # glob needs quotes`,
+      infracost output --format bitbucket-comment --path "out*.json" # glob needs quotes
+
+  Export the cost of each cost center to CSV for chargeback:
+
+      infracost output --format csv --path "out*.json" --out-file chargeback.csv # glob needs quotes
+
+  Create a custom report with a WASM module that's run in a sandbox:
+
+      infracost output --transform-wasm report.wasm --path "out*.json" # glob needs quotes`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
@@ -82,6 +93,12 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				return fmt.Errorf("--format only supports %s", strings.Join(validOutputFormats, ", "))
 			}
 
+			transformPath, _ := cmd.Flags().GetString("transform-wasm")
+			if transformPath != "" && cmd.Flags().Changed("format") {
+				ui.PrintUsage(cmd)
+				return errors.New("--transform-wasm can't be used with --format since the output of the WASM module is used")
+			}
+
 			paths, _ := cmd.Flags().GetStringArray("path")
 
 			inputs, err := output.LoadPaths(paths)
@@ -138,6 +155,14 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 			}
 			opts.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
 
+			err = checkVerbosity(cmd, nil)
+			if err != nil {
+				return err
+			}
+			opts.Verbosity = outputVerbosity(cmd, "")
+
+			warnStalePrices(cmd, ctx, combined)
+
 			validFieldsFormats := []string{"table", "html"}
 
 			if cmd.Flags().Changed("fields") && !contains(validFieldsFormats, format) {
@@ -159,19 +184,28 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				return errors.New("The --compare-to option cannot be used with table and html formats as they output breakdowns, use `--format diff` or one of the comment formats.")
 			}
 
-			switch format {
-			case "json":
+			switch {
+			case transformPath != "":
+				b, err = transformOutput(ctx, transformPath, combined, opts)
+			case format == "json":
 				b, err = output.ToJSON(combined, opts)
-			case "html":
+			case format == "html":
 				b, err = output.ToHTML(combined, opts)
-			case "diff":
+			case format == "diff":
 				b, err = output.ToDiff(combined, opts)
-			case "github-comment", "gitlab-comment", "azure-repos-comment":
-				b, err = output.ToMarkdown(combined, opts, output.MarkdownOptions{})
-			case "bitbucket-comment":
-				b, err = output.ToMarkdown(combined, opts, output.MarkdownOptions{BasicSyntax: true})
-			case "slack-message":
+			case format == "github-comment", format == "gitlab-comment", format == "azure-repos-comment", format == "bitbucket-comment":
+				fullReportURL, _ := cmd.Flags().GetString("full-report-url")
+				b, err = output.ToMarkdown(combined, opts, output.MarkdownOptions{
+					BasicSyntax:    format == "bitbucket-comment",
+					MaxMessageSize: commentMaxMessageSizes[format],
+					FullReportURL:  fullReportURL,
+				})
+			case format == "slack-message":
 				b, err = output.ToSlackMessage(combined, opts)
+			case format == "cost-centers":
+				b, err = output.ToCostCenters(combined, opts)
+			case format == "csv":
+				b, err = output.ToCSV(combined, opts)
 			default:
 				b, err = output.ToTable(combined, opts)
 			}
@@ -203,12 +237,16 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 
 	cmd.Flags().String("compare-to", "", "Path to Infracost JSON file to compare against")
 
-	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, github-comment, gitlab-comment, azure-repos-comment, bitbucket-comment, slack-message")
+	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, github-comment, gitlab-comment, azure-repos-comment, bitbucket-comment, slack-message, cost-centers, csv")
 	cmd.Flags().Bool("show-skipped", false, "List unsupported and free resources")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
+	addVerbosityFlags(cmd)
+	cmd.Flags().String("full-report-url", "", "URL of the full report linked to when a comment is truncated to fit the size limit, e.g. a CI artifact")
+	cmd.Flags().String("transform-wasm", "", "Path to a WASM module that transforms the Infracost JSON into a custom output, run in a sandbox (experimental)")
 
 	_ = cmd.MarkFlagRequired("path")
 	_ = cmd.MarkFlagFilename("path", "json")
+	_ = cmd.MarkFlagFilename("transform-wasm", "wasm")
 
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return validOutputFormats, cobra.ShellCompDirectiveDefault
@@ -217,6 +255,17 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 	return cmd
 }
 
+// transformOutput runs the WASM module at path with the Infracost JSON of out as its input, and returns
+// the module's output.
+func transformOutput(ctx *config.RunContext, path string, out output.Root, opts output.Options) ([]byte, error) {
+	b, err := output.ToJSON(out, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return wasmext.RunFile(ctx.Context(), path, b)
+}
+
 func shareCombinedRun(ctx *config.RunContext, combined output.Root, inputs []output.ReportInput) (string, string) {
 	if len(inputs) == 1 && inputs[0].Root.RunID != "" {
 		result := inputs[0].Root
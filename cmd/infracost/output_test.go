@@ -25,6 +25,13 @@ func TestOutputFormatJSON(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--format", "json", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json"}, opts)
 }
 
+func TestOutputFormatJSONStalePrices(t *testing.T) {
+	opts := DefaultOptions()
+	opts.IsJSON = true
+	opts.Env = map[string]string{"INFRACOST_PRICE_MAX_AGE_DAYS": "30"}
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--format", "json", "--path", "./testdata/stale_prices_out.json"}, opts)
+}
+
 func TestOutputFormatGitHubComment(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--format", "github-comment", "--path", "./testdata/example_out.json", "--path", "./testdata/terraform_v0.14_breakdown.json", "--path", "./testdata/terraform_v0.14_nochange_breakdown.json"}, nil)
 }
@@ -81,6 +88,18 @@ func TestOutputFormatTable(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--format", "table", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json"}, nil)
 }
 
+func TestOutputFormatTableQuiet(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--format", "table", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json", "--verbosity", "quiet"}, nil)
+}
+
+func TestOutputFormatDiffQuiet(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--format", "diff", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json", "--verbosity", "quiet"}, nil)
+}
+
+func TestOutputFormatInvalidVerbosity(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--format", "table", "--path", "./testdata/example_out.json", "--verbosity", "loud"}, nil)
+}
+
 func TestOutputTerraformFieldsAll(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json", "--fields", "all"}, nil)
 }
@@ -146,3 +165,13 @@ func TestOutputWithCompareTo(t *testing.T) {
 			"diff",
 		}, nil)
 }
+
+func TestOutputTransformWASM(t *testing.T) {
+	transform := testutil.WASMModule(t, "./testdata/wasm/transform")
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--transform-wasm", transform, "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json"}, nil)
+}
+
+func TestOutputTransformWASMWithFormat(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--transform-wasm", "transform.wasm", "--format", "json", "--path", "./testdata/example_out.json"}, nil)
+}
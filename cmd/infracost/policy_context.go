@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func policyContextCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy-context",
+		Short: "Add the cost estimate to a Terraform plan JSON for Sentinel and OPA policies",
+		Long: `Add the cost estimate to a Terraform plan JSON for Sentinel and OPA policies.
+
+The plan is estimated and each of its resource_changes is given a cost object with the
+hourly, monthly, previous monthly and monthly diff cost of the resource, and the total cost
+is added to the top level as infracost. The rest of the plan is unchanged, so existing plan
+policies can check costs without joining the plan with the Infracost JSON, e.g.
+input.resource_changes[_].cost.monthly in OPA or rc.cost.monthly in Sentinel.
+
+Costs are numbers so policies can compare them. Resources that aren't supported yet have a
+cost of 0 and supported set to false.`,
+		Example: `  Add the cost estimate to a plan and evaluate an OPA policy on it:
+
+      terraform show -json tfplan.binary > plan.json
+      infracost policy-context --path plan.json --out-file plan-with-costs.json
+      opa eval --input plan-with-costs.json --data policy.rego "data.terraform.deny"
+
+  Use a usage file for the usage-based costs:
+
+      infracost policy-context --path plan.json --usage-file infracost-usage.yml`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("path")
+			if path == "" {
+				ui.PrintUsage(cmd)
+				return errors.New("--path is required")
+			}
+
+			plan, err := readPolicyContextPlan(path)
+			if err != nil {
+				return err
+			}
+
+			if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			err = loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			est, err := estimateProjects(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			if est.cancelErr != nil {
+				return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+			}
+
+			if len(est.projects) != 1 {
+				return fmt.Errorf("Expected the plan to be estimated as one project, but it was estimated as %d", len(est.projects))
+			}
+
+			b, err := output.ToPlanPolicyContext(plan, ctx.Config.Currency, est.projects[0])
+			if err != nil {
+				return errors.Wrap(err, "Error generating output")
+			}
+			b = append(b, '\n')
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-policy-context", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				return saveOutFile(ctx, cmd, outFile, b)
+			}
+
+			cmd.Print(string(b))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform plan JSON file")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+	addFailOnFlag(cmd)
+	cmd.Flags().String("out-file", "", "Save output to a file")
+
+	_ = cmd.MarkFlagFilename("path", "json")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+
+	return cmd
+}
+
+// readPolicyContextPlan reads the plan JSON that the costs are added to. Only plan JSON files can be
+// used, since Terraform directories and binary plans don't have a plan JSON to add them to.
+func readPolicyContextPlan(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading Terraform plan JSON file")
+	}
+
+	if !gjson.ValidBytes(b) || !gjson.GetBytes(b, "resource_changes").Exists() {
+		return nil, clierror.New(
+			clierror.CodePathTypeNotDetected,
+			clierror.CategoryUser,
+			fmt.Sprintf("%s is not a Terraform plan JSON file", path),
+			"Generate the plan JSON with terraform show -json tfplan.binary > plan.json and set --path to it",
+		)
+	}
+
+	return b, nil
+}
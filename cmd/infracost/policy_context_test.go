@@ -0,0 +1,19 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestPolicyContextHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"policy-context", "--help"}, nil)
+}
+
+func TestPolicyContext(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"policy-context", "--path", "./testdata/policy_context/plan.json", "--pricing-mock"}, nil, withoutAPIKey)
+}
+
+func TestPolicyContextNotPlanJSON(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"policy-context", "--path", "./testdata/example_out.json", "--pricing-mock"}, nil)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// projectDependencies tracks the runs of the projects that other projects depend on, so the dependent
+// projects can wait for them to finish and use their outputs.
+type projectDependencies struct {
+	results map[string]*dependencyResult
+}
+
+type dependencyResult struct {
+	done    chan struct{}
+	once    sync.Once
+	outputs map[string]interface{}
+}
+
+// newProjectDependencies returns the projectDependencies of the projects that are depended on by the
+// other projects in the run.
+func newProjectDependencies(projects []*config.Project) *projectDependencies {
+	inRun := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		inRun[filepath.Clean(p.Path)] = true
+	}
+
+	results := map[string]*dependencyResult{}
+	for _, p := range projects {
+		for _, dep := range p.DependsOn {
+			path := filepath.Clean(dep.Path)
+			if inRun[path] && results[path] == nil {
+				results[path] = &dependencyResult{done: make(chan struct{})}
+			}
+		}
+	}
+
+	return &projectDependencies{results: results}
+}
+
+// finish records the evaluated outputs of a project once it has run, and unblocks the projects that
+// depend on it. It's also called when the project fails so the dependent projects don't wait forever.
+func (d *projectDependencies) finish(project *config.Project, outputs map[string]interface{}) {
+	if project == nil {
+		return
+	}
+
+	res := d.results[filepath.Clean(project.Path)]
+	if res == nil {
+		return
+	}
+
+	res.once.Do(func() {
+		res.outputs = outputs
+		close(res.done)
+	})
+}
+
+// wait blocks until the dependency has run and returns its evaluated outputs. Dependencies that
+// aren't part of the run return no outputs straight away.
+func (d *projectDependencies) wait(ctx context.Context, dep *config.ProjectDependency) (map[string]interface{}, error) {
+	res := d.results[filepath.Clean(dep.Path)]
+	if res == nil {
+		return nil, nil
+	}
+
+	select {
+	case <-res.done:
+		return res.outputs, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// dependencyVars waits for the projects that the project depends on and returns the input variables
+// that are set from their outputs. The outputs in a dependency's state take precedence over the ones
+// evaluated from its HCL, which take precedence over the mock outputs.
+func (r *parallelRunner) dependencyVars(ctx *config.ProjectContext) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+
+	for _, dep := range ctx.ProjectConfig.DependsOn {
+		evaluated, err := r.dependencies.wait(r.runCtx.Context(), dep)
+		if err != nil {
+			return nil, err
+		}
+
+		state, err := dependencyStateOutputs(dep)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs := make(map[string]interface{}, len(dep.MockOutputs)+len(evaluated)+len(state))
+		for _, m := range []map[string]interface{}{dep.MockOutputs, evaluated, state} {
+			for k, v := range m {
+				outputs[k] = v
+			}
+		}
+
+		if len(dep.Inputs) == 0 {
+			for k, v := range outputs {
+				vars[k] = v
+			}
+
+			continue
+		}
+
+		names := make([]string, 0, len(dep.Inputs))
+		for name := range dep.Inputs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			output := dep.Inputs[name]
+
+			v, ok := outputs[output]
+			if !ok {
+				ui.PrintWarningf(r.cmd.ErrOrStderr(), "Output %s of %s is not known so variable %s of %s is not set, add it to mock_outputs to set it\n", output, dep.Path, name, ctx.ProjectConfig.Path)
+				r.runCtx.RecordWarning()
+				continue
+			}
+
+			vars[name] = v
+		}
+	}
+
+	return vars, nil
+}
+
+// dependencyStateOutputs returns the outputs in the state file of the dependency. If the state file
+// isn't set and there's no local state in the dependency's path it returns no outputs.
+func dependencyStateOutputs(dep *config.ProjectDependency) (map[string]interface{}, error) {
+	path := dep.StateFile
+	if path == "" {
+		path = filepath.Join(dep.Path, "terraform.tfstate")
+		if !config.FileExists(path) {
+			return nil, nil
+		}
+	}
+
+	outputs, err := terraform.LoadStateOutputs(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error loading outputs of %s", dep.Path)
+	}
+
+	return outputs, nil
+}
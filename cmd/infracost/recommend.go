@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validRecommendFormats = []string{"table", "json"}
+
+func recommendCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recommend",
+		Short: "Find savings opportunities in the cost estimate",
+		Long: `Find savings opportunities in the cost estimate.
+
+The priced resources are scanned for common patterns that cost more than they need to:
+unattached Elastic IPs, gp2 EBS volumes that could be gp3, previous generation instance
+families, more NAT gateways than availability zones, application load balancers that
+could be consolidated and paid detailed monitoring. Each finding shows the estimated
+monthly savings, based on the resource's current cost.`,
+		Example: `  Show the savings opportunities of a Terraform directory:
+
+      infracost recommend --path /code --terraform-parse-hcl
+
+  Export the savings opportunities of the projects in a config file as JSON:
+
+      infracost recommend --config-file infracost.yml --format json --out-file savings.json`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx.SetContextValue("outputFormat", ctx.Config.Format)
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			est, err := estimateProjects(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			if est.cancelErr != nil {
+				return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+			}
+
+			recs := output.NewRecommendations(ctx.Config.Currency, est.projects)
+			ctx.SetContextValue("recommendationCount", len(recs.Recommendations))
+
+			var b []byte
+			switch ctx.Config.Format {
+			case "json":
+				b, err = output.ToRecommendationsJSON(recs)
+				b = append(b, '\n')
+			default:
+				b = output.ToRecommendationsTable(recs)
+			}
+			if err != nil {
+				return errors.Wrap(err, "Error generating output")
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-recommend", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				err = saveOutFile(ctx, cmd, outFile, b)
+				if err != nil {
+					return err
+				}
+			} else {
+				cmd.Print(string(b))
+			}
+
+			if ctx.Config.FailOn == clierror.FailOnWarning && ctx.WarningCount() > 0 {
+				return newWarningsError(ctx.WarningCount())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse HCL code instead of generating a Terraform plan. This does not need credentials and is faster (experimental)")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform’s -var-file flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform’s -var flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-init-flags", "", "Flags to pass to 'terraform init'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+	addFailOnFlag(cmd)
+
+	cmd.Flags().String("format", "table", "Output format: table, json")
+	cmd.Flags().String("out-file", "", "Save output to a file")
+
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validRecommendFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
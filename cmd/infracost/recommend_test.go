@@ -0,0 +1,25 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestRecommendHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"recommend", "--help"}, nil)
+}
+
+func TestRecommendTable(t *testing.T) {
+	testName := testutil.CalcGoldenFileTestdataDirName()
+	GoldenFileCommandTest(t, testName, []string{"recommend", "--path", "./testdata/" + testName, "--terraform-parse-hcl", "--pricing-mock"}, nil, withoutAPIKey)
+}
+
+func TestRecommendJSON(t *testing.T) {
+	testName := testutil.CalcGoldenFileTestdataDirName()
+	GoldenFileCommandTest(t, testName, []string{"recommend", "--path", "./testdata/" + testName, "--terraform-parse-hcl", "--pricing-mock", "--format", "json"}, nil, withoutAPIKey)
+}
+
+func TestRecommendInvalidFormat(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"recommend", "--path", "./testdata/example_plan.json", "--pricing-mock", "--format", "html"}, nil)
+}
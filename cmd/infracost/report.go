@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/notify"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func reportCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report cost drift since the previous run and send a digest",
+		Long: `Report cost drift since the previous run and send a digest.
+
+The projects are estimated and compared with the snapshot saved by the previous run,
+then the new estimate is saved as the snapshot for the next run. If the monthly cost
+changed, a digest is sent to the Slack and webhook destinations.
+
+Use --schedule when running from cron or a scheduled CI pipeline: progress output is
+turned off, and the digest is only printed and sent if the monthly cost changed by at
+least --min-diff.`,
+		Example: `  Send a weekly Slack digest of cost changes to the main branch from cron:
+
+      infracost report --config-file infracost.yml --snapshot-file /var/lib/infracost/main.json --schedule --slack-webhook-url $SLACK_WEBHOOK_URL
+
+  Post the digest as JSON to a webhook if the monthly cost changed by at least $100:
+
+      infracost report --path /code --snapshot-file main.json --schedule --min-diff 100 --webhook-url https://example.com/infracost`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			schedule, _ := cmd.Flags().GetBool("schedule")
+			if schedule {
+				ctx.Config.NoProgress = true
+			}
+
+			snapshotFile, _ := cmd.Flags().GetString("snapshot-file")
+			hasSnapshot := config.FileExists(snapshotFile)
+			if hasSnapshot {
+				ctx.Config.CompareTo = snapshotFile
+			}
+
+			minDiff, _ := cmd.Flags().GetFloat64("min-diff")
+
+			var notifiers []notify.Notifier
+			if u, _ := cmd.Flags().GetString("slack-webhook-url"); u != "" {
+				notifiers = append(notifiers, &notify.SlackNotifier{WebhookURL: u})
+			}
+			if u, _ := cmd.Flags().GetString("webhook-url"); u != "" {
+				notifiers = append(notifiers, &notify.WebhookNotifier{URL: u})
+			}
+
+			est, err := estimateProjects(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			// Don't save a partial snapshot, otherwise the next run would report the missing
+			// projects as new.
+			if est.cancelErr != nil {
+				return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+			}
+
+			r := est.root
+
+			diff := decimal.Zero
+			if r.TotalMonthlyCost != nil && r.PastTotalMonthlyCost != nil {
+				diff = r.TotalMonthlyCost.Sub(*r.PastTotalMonthlyCost)
+			}
+			changed := hasSnapshot && !diff.IsZero() && diff.Abs().GreaterThanOrEqual(decimal.NewFromFloat(minDiff))
+
+			ctx.SetContextValue("hasSnapshot", hasSnapshot)
+			ctx.SetContextValue("changed", changed)
+			ctx.SetContextValue("notifierCount", len(notifiers))
+
+			if !schedule || changed {
+				b, err := output.ToDiff(r, output.Options{NoColor: ctx.Config.NoColor})
+				if err != nil {
+					return errors.Wrap(err, "Error generating output")
+				}
+				cmd.Println(string(b))
+			}
+
+			if changed {
+				digest := notify.Digest{Title: output.CostChangeTitle(r), Root: r}
+				for _, n := range notifiers {
+					err := n.Notify(ctx.Context(), digest)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			b, err := output.ToJSON(r, output.Options{})
+			if err != nil {
+				return errors.Wrap(err, "Error generating snapshot")
+			}
+
+			err = os.WriteFile(snapshotFile, b, 0600)
+			if err != nil {
+				return errors.Wrap(err, "Error saving snapshot")
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-report", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			switch {
+			case !hasSnapshot:
+				cmd.PrintErrf("No previous snapshot found, saved the first snapshot to %s\n", snapshotFile)
+			case changed && len(notifiers) > 0:
+				cmd.PrintErrln("Monthly cost changed, digest sent")
+			case !changed && !schedule:
+				cmd.PrintErrln("Monthly cost did not change enough to send a digest")
+			}
+
+			if ctx.Config.FailOn == clierror.FailOnWarning && ctx.WarningCount() > 0 {
+				return newWarningsError(ctx.WarningCount())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse HCL code instead of generating a Terraform plan. This does not need credentials and is faster (experimental)")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform’s -var-file flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform’s -var flag. Applicable with --terraform-parse-hcl (experimental)")
+	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-init-flags", "", "Flags to pass to 'terraform init'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+	addFailOnFlag(cmd)
+
+	cmd.Flags().String("snapshot-file", "", "Path to the Infracost JSON snapshot to compare with, which is replaced by this run's estimate")
+	_ = cmd.MarkFlagRequired("snapshot-file")
+	cmd.Flags().Bool("schedule", false, "Run non-interactively from cron, only printing and sending the digest if the monthly cost changed")
+	cmd.Flags().Float64("min-diff", 0, "Only send the digest if the monthly cost changed by at least this amount")
+	cmd.Flags().String("slack-webhook-url", "", "Slack incoming webhook URL to send the digest to")
+	cmd.Flags().String("webhook-url", "", "URL to post the digest to as JSON")
+
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("snapshot-file", "json")
+
+	return cmd
+}
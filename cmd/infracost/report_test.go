@@ -0,0 +1,57 @@
+package main_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/notify"
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestReportHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"report", "--help"}, nil)
+}
+
+func TestReportScheduleChanged(t *testing.T) {
+	testName := testutil.CalcGoldenFileTestdataDirName()
+
+	snapshot, err := os.ReadFile(filepath.Join("testdata", testName, "snapshot.json"))
+	require.NoError(t, err)
+
+	snapshotFile := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(snapshotFile, snapshot, 0600))
+
+	var payload notify.WebhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &payload))
+	}))
+	defer srv.Close()
+
+	GoldenFileCommandTest(t, testName, []string{
+		"report",
+		"--path", "./testdata/example_plan.json",
+		"--usage-file", "./testdata/example_usage.yml",
+		"--pricing-mock",
+		"--snapshot-file", snapshotFile,
+		"--schedule",
+		"--webhook-url", srv.URL,
+	}, nil)
+
+	assert.Contains(t, payload.Title, "Monthly cost will increase by")
+	require.Len(t, payload.Projects, 1)
+	assert.Equal(t, "infracost/infracost/cmd/infracost/testdata/example_plan.json", payload.Projects[0].Name)
+
+	// The snapshot is replaced so the next run compares against this estimate.
+	updated, err := os.ReadFile(snapshotFile)
+	require.NoError(t, err)
+	assert.NotEqual(t, snapshot, updated)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"sort"
@@ -24,10 +25,14 @@ import (
 	"github.com/infracost/infracost/internal/apiclient"
 	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/hcl/modules"
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/prices"
 	"github.com/infracost/infracost/internal/providers"
 	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/reportstorage"
+	"github.com/infracost/infracost/internal/resourceplugin"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/usage"
@@ -49,15 +54,36 @@ type hclRunDiff struct {
 	missingResources []string
 }
 
-var validRunFormats = []string{"json", "table", "html"}
+var validRunFormats = []string{"json", "table", "html", "cost-centers", "csv"}
+
+// dirUsageFile is the usage file that is used for a directory if it has one, when a command
+// estimates the Terraform directories that it finds itself.
+const dirUsageFile = "infracost-usage.yml"
+
+// hclDirProjects returns the project configs to estimate the Terraform directories by parsing
+// their HCL, using the dirUsageFile of each directory if it has one.
+func hclDirProjects(dirs []string) []*config.Project {
+	projects := make([]*config.Project, 0, len(dirs))
+	for _, dir := range dirs {
+		project := &config.Project{Path: dir, TerraformParseHCL: true}
+		if _, err := os.Stat(filepath.Join(dir, dirUsageFile)); err == nil {
+			project.UsageFile = filepath.Join(dir, dirUsageFile)
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects
+}
 
 func addRunFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse HCL code instead of generating a Terraform plan. This does not need credentials and is faster (experimental)")
 	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform’s -var-file flag. Applicable with --terraform-parse-hcl (experimental)")
 	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform’s -var flag. Applicable with --terraform-parse-hcl (experimental)")
-	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file, or - to read a tar archive or HCL from stdin")
 
 	cmd.Flags().String("compare-to", "", "Path to Infracost JSON file to compare against, cannot be used with table and html formats")
+	cmd.Flags().String("git-diff-base", "", "Only estimate projects affected by files changed since this git ref, e.g. origin/main")
 
 	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
 	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
@@ -69,40 +95,299 @@ func addRunFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
 
 	cmd.Flags().Bool("show-skipped", false, "List unsupported and free resources")
+	cmd.Flags().Bool("show-advisories", false, "Show the cost of cheaper alternatives to some resources, e.g. NAT instances instead of NAT gateways")
+	cmd.Flags().Bool("show-confidence", false, "Show the confidence level of each resource's estimate and a confidence score for each project")
+	cmd.Flags().Bool("heuristic-pricing", false, "Give unsupported resources a rough estimate from the category their type and attributes look like, e.g. compute or storage")
+	cmd.Flags().StringArray("set", nil, "Override an attribute of the resources that match an address pattern before pricing them, e.g. 'aws_instance.*.instance_type=t3.large'")
+	cmd.Flags().String("trace-resource", "", "Print the attributes, usage keys and price filters used to cost a resource, e.g. aws_instance.web")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
 
 	cmd.Flags().Bool("sync-usage-file", false, "Sync usage-file with missing resources, needs usage-file too (experimental)")
 
+	cmd.Flags().Bool("fail-on-budget", false, "Exit with a non-zero code if a project's monthly cost is over its config file budget")
+	addFailOnFlag(cmd)
+
+	cmd.Flags().Bool("share", false, "Upload the output and print a short-lived link to share it, e.g. in Slack")
+	cmd.Flags().Bool("share-redact", false, "Remove project names, paths and resource names from the output shared with --share")
+
+	cmd.Flags().String("write-eval-report", "", "Path to write a JSON report of attributes that couldn't be evaluated and why, for debugging. Applicable with --terraform-parse-hcl (experimental)")
+
 	_ = cmd.MarkFlagFilename("path", "json", "tf")
 	_ = cmd.MarkFlagFilename("config-file", "yml")
 	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("write-eval-report", "json")
+}
+
+// addFailOnFlag adds the --fail-on flag which sets which failures give a non-zero exit code.
+func addFailOnFlag(cmd *cobra.Command) {
+	cmd.Flags().String("fail-on", clierror.FailOnPolicy, `Failures that exit with a non-zero code, one of:
+  error    Only errors, e.g. Terraform code that can't be parsed
+  policy   Errors, policy failures and projects over budget with --fail-on-budget
+  warning  All of the above and any warnings`)
+
+	_ = cmd.RegisterFlagCompletionFunc("fail-on", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return clierror.FailOnLevels(), cobra.ShellCompDirectiveDefault
+	})
+}
+
+// addVerbosityFlags adds the --verbosity and --summary-only flags which set how much detail the output shows.
+func addVerbosityFlags(cmd *cobra.Command) {
+	cmd.Flags().String("verbosity", "", `Detail shown by the table, diff and comment formats, JSON always has full detail, one of:
+  verbose  Same as full with the price tiers of tiered cost components
+  full     All resources and cost components (default)
+  summary  Project totals and the resources with the largest costs
+  quiet    Only project totals`)
+	cmd.Flags().Bool("summary-only", false, "Only show project totals and the resources with the largest costs, same as --verbosity summary")
+
+	_ = cmd.RegisterFlagCompletionFunc("verbosity", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return output.VerbosityLevels(), cobra.ShellCompDirectiveDefault
+	})
+}
+
+// checkVerbosity returns an error if the --verbosity flag or any of the verbosity levels set for
+// the output formats in the config file aren't valid.
+func checkVerbosity(cmd *cobra.Command, cfgVerbosity map[string]string) error {
+	levels := strings.Join(output.VerbosityLevels(), ", ")
+
+	if v, _ := cmd.Flags().GetString("verbosity"); !output.IsValidVerbosity(v) {
+		ui.PrintUsage(cmd)
+		return fmt.Errorf("--verbosity only supports %s", levels)
+	}
+
+	formats := make([]string, 0, len(cfgVerbosity))
+	for format := range cfgVerbosity {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+
+	for _, format := range formats {
+		if !output.IsValidVerbosity(cfgVerbosity[format]) {
+			return fmt.Errorf("verbosity for the %s format only supports %s", format, levels)
+		}
+	}
+
+	return nil
+}
+
+// outputVerbosity returns the verbosity level set by the --verbosity or --summary-only flags, or the
+// fallback if neither of them are set.
+func outputVerbosity(cmd *cobra.Command, fallback string) string {
+	if v, _ := cmd.Flags().GetString("verbosity"); v != "" {
+		return v
+	}
+
+	if summaryOnly, _ := cmd.Flags().GetBool("summary-only"); summaryOnly {
+		return output.VerbositySummary
+	}
+
+	return fallback
+}
+
+func newWarningsError(count int) error {
+	msg := "1 warning was shown and --fail-on is set to warning"
+	if count > 1 {
+		msg = fmt.Sprintf("%d warnings were shown and --fail-on is set to warning", count)
+	}
+
+	return clierror.New(clierror.CodeWarnings, clierror.CategoryUser, msg, "")
 }
 
 // panicError is used to collect goroutine panics into an error interface so
 // that we can do type assertion on err checking.
 type panicError struct {
 	msg string
+
+	// recovered is the value passed to panic and stack is the stack trace of the goroutine.
+	recovered interface{}
+	stack     []byte
+	// project is the config of the project that was being run when the panic happened.
+	project *config.Project
+}
+
+func newPanicError(recovered interface{}, stack []byte, project *config.Project) *panicError {
+	return &panicError{
+		msg:       fmt.Sprintf("%s\n%s", recovered, stack),
+		recovered: recovered,
+		stack:     stack,
+		project:   project,
+	}
 }
 
 func (p *panicError) Error() string {
 	return p.msg
 }
 
+// shareLinkTTL is how long links created with --share can be used for.
+var shareLinkTTL = 7 * 24 * time.Hour
+
 func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 	if runCtx.Config.IsSelfHosted() && runCtx.Config.EnableDashboard {
 		ui.PrintWarning(cmd.ErrOrStderr(), "The dashboard is part of Infracost's hosted services. Contact hello@infracost.io for help.")
+		runCtx.RecordWarning()
 	}
 
-	parallelism, err := getParallelism(cmd, runCtx)
+	est, err := estimateProjects(cmd, runCtx)
 	if err != nil {
 		return err
 	}
+	r := est.root
+
+	warnStalePrices(cmd, runCtx, r)
+
+	dashboardClient := apiclient.NewDashboardAPIClient(runCtx)
+	result, err := dashboardClient.AddRun(runCtx, est.projectContexts, r)
+	if err != nil {
+		log.Errorf("Error reporting run: %s", err)
+	}
+
+	r.RunID, r.ShareURL = result.RunID, result.ShareURL
+
+	opts := output.Options{
+		DashboardEnabled: runCtx.Config.EnableDashboard,
+		ShowSkipped:      runCtx.Config.ShowSkipped,
+		NoColor:          runCtx.Config.NoColor,
+		Fields:           runCtx.Config.Fields,
+	}
+
+	var b []byte
+
+	format := strings.ToLower(runCtx.Config.Format)
+	opts.Verbosity = outputVerbosity(cmd, runCtx.Config.Verbosity[format])
+
+	if runCtx.Config.CompareTo != "" && !validCompareToFormats[format] {
+		return errors.New("The --compare-to option cannot be used with table and html formats as they output breakdowns, specify a different --format.")
+	}
+
+	switch format {
+	case "json":
+		b, err = output.ToJSON(r, opts)
+	case "html":
+		b, err = output.ToHTML(r, opts)
+	case "diff":
+		b, err = output.ToDiff(r, opts)
+	case "cost-centers":
+		b, err = output.ToCostCenters(r, opts)
+	case "csv":
+		b, err = output.ToCSV(r, opts)
+	default:
+		b, err = output.ToTable(r, opts)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "Error generating output")
+	}
+
+	if runCtx.Config.Format == "diff" || runCtx.Config.Format == "table" {
+		lines := bytes.Count(b, []byte("\n")) + 1
+		runCtx.SetContextValue("lineCount", lines)
+	}
+
+	env := buildRunEnv(runCtx, est.projectContexts, r, est.projects, est.hclR, est.hclProjects)
+
+	pricingClient := apiclient.NewPricingAPIClient(runCtx)
+	err = pricingClient.AddEvent("infracost-run", env)
+	if err != nil {
+		log.Errorf("Error reporting event: %s", err)
+	}
+
+	if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+		err = saveOutFile(runCtx, cmd, outFile, b)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Print a new line to separate the logs from the output
+		if runCtx.Config.IsLogging() {
+			cmd.PrintErrln()
+		}
+		cmd.Println(string(b))
+	}
+
+	if share, _ := cmd.Flags().GetBool("share"); share {
+		err = shareRun(cmd, runCtx, r)
+		if err != nil {
+			return err
+		}
+	}
+
+	if runCtx.Config.ReportStorage != nil {
+		err = uploadReports(cmd, runCtx, r, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeCISummary(cmd, runCtx, r, opts)
+	if err != nil {
+		return err
+	}
+
+	if est.cancelErr != nil {
+		return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+	}
+
+	if failOnBudget, _ := cmd.Flags().GetBool("fail-on-budget"); failOnBudget && runCtx.Config.FailOn != clierror.FailOnError {
+		if exceeded := r.ExceededBudgets(opts.DashboardEnabled); len(exceeded) > 0 {
+			return clierror.New(clierror.CodeBudgetExceeded, clierror.CategoryUser, fmt.Sprintf("Monthly cost is over budget for: %s", strings.Join(exceeded, ", ")), "")
+		}
+	}
+
+	if runCtx.Config.FailOn == clierror.FailOnWarning && runCtx.WarningCount() > 0 {
+		return newWarningsError(runCtx.WarningCount())
+	}
+
+	return nil
+}
+
+// runEstimate is the output of running all the projects in the config, before it is formatted.
+type runEstimate struct {
+	root            output.Root
+	projects        []*schema.Project
+	projectContexts []*config.ProjectContext
+	hclR            *output.Root
+	hclProjects     []*schema.Project
+	// cancelErr is set if the run was cancelled, in which case only the projects that finished
+	// are included.
+	cancelErr error
+}
+
+// rootsByPath returns the output of the projects of each project path in the config, for the
+// commands that output each of the directories they estimate on its own.
+func (e *runEstimate) rootsByPath(currency string) (map[string]output.Root, error) {
+	projects := make(map[string][]*schema.Project)
+	for i, project := range e.projects {
+		path := e.projectContexts[i].ProjectConfig.Path
+		projects[path] = append(projects[path], project)
+	}
+
+	roots := make(map[string]output.Root, len(projects))
+	for path, p := range projects {
+		r, err := output.ToOutputFormat(p)
+		if err != nil {
+			return nil, err
+		}
+		r.Currency = currency
+
+		roots[path] = r
+	}
+
+	return roots, nil
+}
+
+// estimateProjects runs the projects in the config in parallel and returns their combined
+// output, compared to the --compare-to snapshot if one is set.
+func estimateProjects(cmd *cobra.Command, runCtx *config.RunContext) (*runEstimate, error) {
+	parallelism, err := getParallelism(cmd, runCtx)
+	if err != nil {
+		return nil, err
+	}
 	runCtx.SetContextValue("parallelism", parallelism)
 
 	numJobs := len(runCtx.Config.Projects)
 	jobs := make(chan projectJob, numJobs)
 
 	projectResultChan := make(chan projectResult, numJobs)
-	errGroup, _ := errgroup.WithContext(context.Background())
+	errGroup, _ := errgroup.WithContext(runCtx.Context())
 
 	runInParallel := parallelism > 1 && numJobs > 1
 	if (runInParallel || runCtx.IsCIRun()) && !runCtx.Config.IsLogging() {
@@ -115,13 +400,13 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		runCtx.Config.LogLevel = "info"
 		err := runCtx.Config.ConfigureLogger()
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	pr, err := newParallelRunner(cmd, runCtx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for i := 0; i < parallelism; i++ {
@@ -130,19 +415,39 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 			// This is done as recover works only in the same goroutine that it is called.
 			// We need to catch any child goroutine panics and hand them up to the main caller
 			// so that it can be caught and displayed correctly to the user.
+			var currentProject *config.Project
 			defer func() {
 				e := recover()
 				if e != nil {
-					err = &panicError{msg: fmt.Sprintf("%s\n%s", e, debug.Stack())}
+					pr.dependencies.finish(currentProject, nil)
+					err = newPanicError(e, debug.Stack(), currentProject)
 				}
 			}()
 
 			for job := range jobs {
+				currentProject = job.projectCfg
+
+				// Stop picking up new projects once the run has been cancelled so the
+				// projects that have already finished can be reported.
+				if err := runCtx.Context().Err(); err != nil {
+					return err
+				}
+
 				ctx := config.NewProjectContext(runCtx, job.projectCfg)
+				if len(job.projectCfg.DependsOn) > 0 {
+					ctx.DependencyVars, err = pr.dependencyVars(ctx)
+					if err != nil {
+						pr.dependencies.finish(job.projectCfg, nil)
+						return err
+					}
+				}
+
 				configProjects, err := pr.runProjectConfig(ctx)
 				if err != nil {
+					pr.dependencies.finish(job.projectCfg, nil)
 					return err
 				}
+				pr.dependencies.finish(job.projectCfg, configProjects.outputs)
 
 				projectResultChan <- projectResult{
 					index:      job.index,
@@ -155,14 +460,21 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		})
 	}
 
-	for i, p := range runCtx.Config.Projects {
-		jobs <- projectJob{index: i, projectCfg: p}
+	// the projects are run after the projects they depend on, but the results are still output in
+	// the order of the config file.
+	for _, i := range config.ProjectRunOrder(runCtx.Config.Projects) {
+		jobs <- projectJob{index: i, projectCfg: runCtx.Config.Projects[i]}
 	}
 	close(jobs)
 
 	err = errGroup.Wait()
-	if err != nil {
-		return err
+	modules.CloseSharedDownloads()
+
+	// If the run was cancelled, e.g. by Ctrl-C or a CI timeout, any error is most likely
+	// caused by the cancellation so we output the projects that finished instead.
+	cancelErr := runCtx.Context().Err()
+	if err != nil && cancelErr == nil {
+		return nil, err
 	}
 
 	close(projectResultChan)
@@ -170,6 +482,14 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 	for result := range projectResultChan {
 		projectResults = append(projectResults, result)
 	}
+
+	if cancelErr != nil {
+		if len(projectResults) == 0 {
+			return nil, fmt.Errorf("Run cancelled: %w", cancelErr)
+		}
+
+		ui.PrintWarningf(cmd.ErrOrStderr(), "Run cancelled, only showing results for %d of %d projects", len(projectResults), numJobs)
+	}
 	sort.Slice(projectResults, func(i, j int) bool {
 		return projectResults[i].index < projectResults[j].index
 	})
@@ -187,6 +507,13 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		hclProjects = append(hclProjects, projectResult.projectOut.hclProjects...)
 	}
 
+	if runCtx.Config.EvalReportPath != "" {
+		err := writeEvalReport(runCtx, cmd, projectResults)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	wg := &sync.WaitGroup{}
 	var hclR *output.Root
 	if len(hclProjects) > 0 {
@@ -197,81 +524,176 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 
 	r, err := output.ToOutputFormat(projects)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if pr.prior != nil {
 		r, err = output.CompareTo(r, *pr.prior)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	wg.Wait()
 	r.IsCIRun = runCtx.IsCIRun()
 	r.Currency = runCtx.Config.Currency
+	r.CommittedSpend = output.RollupCommittedSpend(r, outputCommittedSpend(runCtx.Config.CommittedSpend))
+
+	return &runEstimate{
+		root:            r,
+		projects:        projects,
+		projectContexts: projectContexts,
+		hclR:            hclR,
+		hclProjects:     hclProjects,
+		cancelErr:       cancelErr,
+	}, nil
+}
 
-	dashboardClient := apiclient.NewDashboardAPIClient(runCtx)
-	result, err := dashboardClient.AddRun(runCtx, projectContexts, r)
-	if err != nil {
-		log.Errorf("Error reporting run: %s", err)
+// warnStalePrices warns when the prices of the output were retrieved from the pricing API longer ago
+// than the configured maximum age, so the costs might not reflect the current prices.
+func warnStalePrices(cmd *cobra.Command, runCtx *config.RunContext, r output.Root) {
+	maxAge := runCtx.Config.PriceMaxAgeDays
+	if maxAge == nil || *maxAge <= 0 {
+		return
 	}
 
-	r.RunID, r.ShareURL = result.RunID, result.ShareURL
+	retrievedAt := r.Pricing.StaleSince(time.Duration(*maxAge)*24*time.Hour, time.Now())
+	if retrievedAt == nil {
+		return
+	}
 
-	opts := output.Options{
-		DashboardEnabled: runCtx.Config.EnableDashboard,
-		ShowSkipped:      runCtx.Config.ShowSkipped,
-		NoColor:          runCtx.Config.NoColor,
-		Fields:           runCtx.Config.Fields,
+	ui.PrintWarningf(cmd.ErrOrStderr(), "Prices were retrieved on %s, which is more than %d days ago. The costs might not reflect current prices.\n", retrievedAt.Format("2006-01-02"), *maxAge)
+	runCtx.RecordWarning()
+}
+
+// outputCommittedSpend converts the committed spend in the config file to the commitments that the
+// output rolls the project costs up to.
+func outputCommittedSpend(commitments []*config.CommittedSpend) []output.CommittedSpend {
+	out := make([]output.CommittedSpend, 0, len(commitments))
+
+	for _, c := range commitments {
+		monthly := c.MonthlyAmount()
+		out = append(out, output.CommittedSpend{
+			Name:              c.Name,
+			MonthlyCommitment: &monthly,
+			Providers:         c.Providers,
+			Projects:          c.Projects,
+		})
 	}
 
-	var b []byte
+	return out
+}
 
-	format := strings.ToLower(runCtx.Config.Format)
-	if runCtx.Config.CompareTo != "" && !validCompareToFormats[format] {
-		return errors.New("The --compare-to option cannot be used with table and html formats as they output breakdowns, specify a different --format.")
+// writeEvalReport writes the attributes that couldn't be evaluated and the blocks that were skipped for each
+// project to the --write-eval-report path. Projects that weren't parsed as HCL are included with no attributes.
+func writeEvalReport(runCtx *config.RunContext, cmd *cobra.Command, projectResults []projectResult) error {
+	report := evalReport{Projects: make([]evalReportProject, 0, len(projectResults))}
+	for _, projectResult := range projectResults {
+		unresolved := projectResult.projectOut.unresolved
+		if unresolved == nil {
+			unresolved = []hcl.UnresolvedAttribute{}
+		}
+
+		skipped := projectResult.projectOut.skipped
+		if skipped == nil {
+			skipped = []hcl.SkippedBlock{}
+		}
+
+		report.Projects = append(report.Projects, evalReportProject{
+			Path:                 projectResult.ctx.ProjectConfig.Path,
+			UnresolvedAttributes: unresolved,
+			SkippedBlocks:        skipped,
+		})
 	}
 
-	switch format {
-	case "json":
-		b, err = output.ToJSON(r, opts)
-	case "html":
-		b, err = output.ToHTML(r, opts)
-	case "diff":
-		b, err = output.ToDiff(r, opts)
-	default:
-		b, err = output.ToTable(r, opts)
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Error generating eval report")
 	}
 
+	path := runCtx.Config.EvalReportPath
+	return saveOutFileWithMsg(runCtx, cmd, path, fmt.Sprintf("Eval report saved to %s", path), b)
+}
+
+// shareRun uploads the output and prints a link that can be used to view it in the dashboard
+// until it expires. The output is redacted first if --share-redact is set.
+func shareRun(cmd *cobra.Command, runCtx *config.RunContext, r output.Root) error {
+	if runCtx.Config.IsSelfHosted() {
+		return errors.New("--share uses Infracost's hosted services and can't be used with a self-hosted Cloud Pricing API. Contact hello@infracost.io for help.")
+	}
+
+	if redact, _ := cmd.Flags().GetBool("share-redact"); redact {
+		var err error
+		r, err = output.Redact(r)
+		if err != nil {
+			return errors.Wrap(err, "Error redacting output")
+		}
+	}
+
+	dashboardClient := apiclient.NewDashboardAPIClient(runCtx)
+	result, err := dashboardClient.ShareRun(r, shareLinkTTL)
 	if err != nil {
-		return errors.Wrap(err, "Error generating output")
+		return errors.Wrap(err, "Error creating share link")
 	}
 
-	if runCtx.Config.Format == "diff" || runCtx.Config.Format == "table" {
-		lines := bytes.Count(b, []byte("\n")) + 1
-		runCtx.SetContextValue("lineCount", lines)
+	msg := fmt.Sprintf("Share this cost estimate using %s", ui.LinkString(result.ShareURL))
+	if !result.ExpiresAt.IsZero() {
+		msg += fmt.Sprintf(", the link expires on %s", result.ExpiresAt.Local().Format("2006-01-02 15:04 MST"))
 	}
+	ui.PrintSuccess(cmd.ErrOrStderr(), msg)
 
-	env := buildRunEnv(runCtx, projectContexts, r, projects, hclR, hclProjects)
+	return nil
+}
 
-	pricingClient := apiclient.NewPricingAPIClient(runCtx)
-	err = pricingClient.AddEvent("infracost-run", env)
+// uploadReports uploads the output in each of the report storage formats to the bucket in the
+// config file, with keys from its key template.
+func uploadReports(cmd *cobra.Command, runCtx *config.RunContext, r output.Root, opts output.Options) error {
+	storage := runCtx.Config.ReportStorage
+
+	uploader, err := reportstorage.NewUploader(runCtx.Context(), storage)
 	if err != nil {
-		log.Errorf("Error reporting event: %s", err)
+		return errors.Wrap(err, "Error uploading reports")
 	}
 
-	if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
-		err = saveOutFile(runCtx, cmd, outFile, b)
+	path := "."
+	if len(runCtx.Config.Projects) > 0 {
+		path = runCtx.Config.Projects[0].Path
+	}
+
+	data := reportstorage.KeyData{
+		Branch:    config.DetectVCSBranch(path),
+		Commit:    config.DetectVCSCommit(path),
+		Timestamp: time.Unix(runCtx.StartTime, 0).UTC(),
+	}
+
+	for _, format := range storage.UploadFormats() {
+		opts.Verbosity = outputVerbosity(cmd, runCtx.Config.Verbosity[format])
+
+		var b []byte
+		var key string
+
+		switch format {
+		case "json":
+			b, err = output.ToJSON(r, opts)
+		case "html":
+			b, err = output.ToHTML(r, opts)
+		}
+		if err != nil {
+			return errors.Wrap(err, "Error generating output")
+		}
+
+		data.Format = format
+		key, err = reportstorage.Key(storage.KeyTemplate(), data)
 		if err != nil {
 			return err
 		}
-	} else {
-		// Print a new line to separate the logs from the output
-		if runCtx.Config.IsLogging() {
-			cmd.PrintErrln()
+
+		err = uploader.Upload(runCtx.Context(), key, reportstorage.ContentType(format), b)
+		if err != nil {
+			return errors.Wrap(err, "Error uploading reports")
 		}
-		cmd.Println(string(b))
+
+		ui.PrintSuccessf(cmd.ErrOrStderr(), "Uploaded %s report to %s", format, uploader.Location(key))
 	}
 
 	return nil
@@ -325,13 +747,41 @@ func formatHCLProjects(wg *sync.WaitGroup, ctx *config.RunContext, hclProjects [
 type projectOutput struct {
 	projects    []*schema.Project
 	hclProjects []*schema.Project
+	unresolved  []hcl.UnresolvedAttribute
+	skipped     []hcl.SkippedBlock
+	outputs     map[string]interface{}
+}
+
+// unresolvedAttributesProvider is implemented by the providers that parse HCL and can report the
+// attributes that they couldn't evaluate and the blocks that they skipped.
+type unresolvedAttributesProvider interface {
+	UnresolvedAttributes() []hcl.UnresolvedAttribute
+	SkippedBlocks() []hcl.SkippedBlock
+}
+
+// outputsProvider is implemented by the providers that can evaluate the outputs of the project, which
+// are passed to the projects that depend on it.
+type outputsProvider interface {
+	Outputs() map[string]interface{}
+}
+
+// evalReport is the JSON report written by --write-eval-report.
+type evalReport struct {
+	Projects []evalReportProject `json:"projects"`
+}
+
+type evalReportProject struct {
+	Path                 string                    `json:"path"`
+	UnresolvedAttributes []hcl.UnresolvedAttribute `json:"unresolvedAttributes"`
+	SkippedBlocks        []hcl.SkippedBlock        `json:"skippedBlocks"`
 }
 
 type parallelRunner struct {
-	cmd      *cobra.Command
-	runCtx   *config.RunContext
-	pathMuxs map[string]*sync.Mutex
-	prior    *output.Root
+	cmd          *cobra.Command
+	runCtx       *config.RunContext
+	pathMuxs     map[string]*sync.Mutex
+	prior        *output.Root
+	dependencies *projectDependencies
 }
 
 func newParallelRunner(cmd *cobra.Command, runCtx *config.RunContext) (*parallelRunner, error) {
@@ -354,16 +804,20 @@ func newParallelRunner(cmd *cobra.Command, runCtx *config.RunContext) (*parallel
 	}
 
 	return &parallelRunner{
-		runCtx:   runCtx,
-		cmd:      cmd,
-		pathMuxs: pathMuxs,
-		prior:    prior,
+		runCtx:       runCtx,
+		cmd:          cmd,
+		pathMuxs:     pathMuxs,
+		prior:        prior,
+		dependencies: newProjectDependencies(runCtx.Config.Projects),
 	}, nil
 }
 
 func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectOutput, error) {
+	// projects that run Terraform in an ephemeral workspace don't share any files so they can run concurrently.
+	ephemeral := ctx.ProjectConfig.TerraformEphemeralWorkspace && !ctx.ProjectConfig.TerraformParseHCL
+
 	mux := r.pathMuxs[ctx.ProjectConfig.Path]
-	if mux != nil {
+	if mux != nil && !ephemeral {
 		mux.Lock()
 		defer mux.Unlock()
 	}
@@ -377,19 +831,20 @@ func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectO
 
 		warn = v.Warn()
 	} else if err != nil {
-		m := fmt.Sprintf("%s\n\n", err)
-		m += fmt.Sprintf("Try setting --path to a Terraform plan JSON file. See %s for how to generate this.", ui.LinkString("https://infracost.io/troubleshoot"))
+		hint := fmt.Sprintf("Try setting --path to a Terraform plan JSON file. See %s for how to generate this.", ui.LinkString("https://infracost.io/troubleshoot"))
+		e := clierror.Wrap(err, clierror.CodePathTypeNotDetected, clierror.CategoryUser, hint)
 
-		return nil, clierror.NewSanitizedError(errors.New(m), "Could not detect path type")
+		return nil, clierror.NewSanitizedError(e, "Could not detect path type")
 	}
 
 	ctx.SetContextValue("projectType", provider.Type())
 
 	if r.cmd.Name() == "diff" && provider.Type() == "terraform_state_json" {
-		m := "Cannot use Terraform state JSON with the infracost diff command.\n\n"
-		m += fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
-		m += " - Terraform plan JSON file\n - Terraform/Terragrunt directory\n - Terraform plan file"
-		return nil, clierror.NewSanitizedError(errors.New(m), "Cannot use Terraform state JSON with the infracost diff command")
+		m := "Cannot use Terraform state JSON with the infracost diff command."
+		hint := fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
+		hint += " - Terraform plan JSON file\n - Terraform/Terragrunt directory\n - Terraform plan file"
+		e := clierror.New(clierror.CodeStateJSONWithDiff, clierror.CategoryUser, m, hint)
+		return nil, clierror.NewSanitizedError(e, "Cannot use Terraform state JSON with the infracost diff command")
 	}
 
 	m := fmt.Sprintf("Detected %s at %s", provider.DisplayType(), ui.DisplayPath(ctx.ProjectConfig.Path))
@@ -401,6 +856,7 @@ func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectO
 
 	if warn != nil {
 		ui.PrintWarning(r.runCtx.ErrWriter, *warn)
+		r.runCtx.RecordWarning()
 	}
 
 	// Generate usage file
@@ -430,6 +886,7 @@ func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectO
 				"The following usage file parameters are invalid and will be ignored: %s\n",
 				strings.Join(invalidKeys, ", "),
 			)
+			r.runCtx.RecordWarning()
 		}
 	} else {
 		usageFile = usage.NewBlankUsageFile()
@@ -474,47 +931,71 @@ func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectO
 	}
 
 	t1 := time.Now()
-	projects, err := provider.LoadResources(usageData)
+
+	// Each project is priced as soon as it's loaded, so for the providers that load multiple projects,
+	// e.g. Terragrunt, the pricing queries of the projects that have been evaluated overlap with the
+	// evaluation of the rest. Providers that can also pass on the resources of a project while it's
+	// being loaded, e.g. the resources of each Terraform module once it's evaluated, have their prices
+	// prefetched, so the pricing queries overlap with the evaluation of the rest of the project too.
+	prefetcher := r.newPrefetcher(provider)
+
+	pipeline := newProjectPipeline(ctx.ProjectConfig, func(project *schema.Project) error {
+		r.prepareProject(ctx, project, usageData, usageFile)
+
+		if r.runCtx.Config.SkipPricing {
+			return nil
+		}
+
+		return r.priceProject(project, prefetcher)
+	})
+
+	projects, err := loadResources(provider, usageData, pipeline.add)
 	if err != nil {
+		_ = pipeline.wait()
 		r.cmd.PrintErrln()
-		return nil, err
-	}
 
-	spinnerOpts := ui.SpinnerOptions{
-		EnableLogging: r.runCtx.Config.IsLogging(),
-		NoColor:       r.runCtx.Config.NoColor,
-		Indent:        "  ",
+		var cliErr *clierror.Error
+		if errors.As(err, &cliErr) || errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+
+		return nil, clierror.Wrap(err, clierror.CodeParseFailed, clierror.CategoryUser, "")
 	}
-	spinner := ui.NewSpinner("Retrieving cloud prices to calculate costs", spinnerOpts)
-	defer spinner.Fail()
 
-	for _, project := range projects {
-		if err := prices.PopulatePrices(r.runCtx, project); err != nil {
-			spinner.Fail()
-			r.cmd.PrintErrln()
+	if p, ok := provider.(outputsProvider); ok {
+		out.outputs = p.Outputs()
+	}
 
-			if e := unwrapped(err); errors.Is(e, apiclient.ErrInvalidAPIKey) {
-				return nil, fmt.Errorf("%v\n%s %s %s %s %s\n%s",
-					e.Error(),
-					"Please check your",
-					ui.PrimaryString(config.CredentialsFilePath()),
-					"file or",
-					ui.PrimaryString("INFRACOST_API_KEY"),
-					"environment variable.",
-					"If you continue having issues please email hello@infracost.io",
-				)
-			}
+	if r.runCtx.Config.SkipPricing {
+		if err := pipeline.wait(); err != nil {
+			return nil, err
+		}
 
-			if e, ok := err.(*apiclient.APIError); ok {
-				return nil, fmt.Errorf("%v\n%s", e.Error(), "We have been notified of this issue.")
-			}
+		wg.Wait()
+		out.projects = projects
 
-			return nil, err
+		if !r.runCtx.Config.IsLogging() && !r.runCtx.Config.SkipErrLine {
+			r.cmd.PrintErrln()
 		}
 
-		schema.CalculateCosts(project)
+		return out, nil
+	}
 
-		project.CalculateDiff()
+	spinnerOpts := ui.SpinnerOptions{
+		EnableLogging:  r.runCtx.Config.IsLogging(),
+		NoColor:        r.runCtx.Config.NoColor,
+		NoProgress:     r.runCtx.Config.NoProgress,
+		NonInteractive: r.runCtx.Config.NonInteractive,
+		Indent:         "  ",
+	}
+	spinner := ui.NewSpinner("Retrieving cloud prices to calculate costs", spinnerOpts)
+	defer spinner.Fail()
+
+	if err := pipeline.wait(); err != nil {
+		spinner.Fail()
+		r.cmd.PrintErrln()
+
+		return nil, err
 	}
 
 	t2 := time.Now()
@@ -527,6 +1008,19 @@ func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectO
 	spinner.Success()
 	out.projects = projects
 
+	if r.runCtx.Config.TraceResource != "" {
+		r.printTraces(projects)
+	}
+
+	if len(r.runCtx.Config.AttributeOverrides) > 0 {
+		r.warnUnmatchedOverrides(projects)
+	}
+
+	if p, ok := provider.(unresolvedAttributesProvider); ok {
+		out.unresolved = p.UnresolvedAttributes()
+		out.skipped = p.SkippedBlocks()
+	}
+
 	if !r.runCtx.Config.IsLogging() && !r.runCtx.Config.SkipErrLine {
 		r.cmd.PrintErrln()
 	}
@@ -534,6 +1028,297 @@ func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectO
 	return out, nil
 }
 
+// prepareProject applies the usage file and the project config to the resources of a project once
+// it has been loaded.
+func (r *parallelRunner) prepareProject(ctx *config.ProjectContext, project *schema.Project, usageData map[string]*schema.UsageData, usageFile *usage.UsageFile) {
+	terraform.AddSuppressions(ctx.ProjectConfig.Path, project.Resources)
+	usage.SetMonthlyGrowthRates(project.Resources, usageData)
+	usage.AddLicenseFees(project.Resources, usageFile.LicenseFees)
+	usage.AddLicenseFees(project.PastResources, usageFile.LicenseFees)
+
+	if ctx.ProjectConfig.RollupResources {
+		project.Resources = schema.RollupResources(project.Resources)
+		project.PastResources = schema.RollupResources(project.PastResources)
+	}
+
+	r.runCtx.Config.CostCenters.Allocate(project.Resources)
+	r.runCtx.Config.CostCenters.Allocate(project.PastResources)
+
+	if r.runCtx.Config.CodeOwners {
+		// Projects found in the path, e.g. Terragrunt modules, have their own path in the metadata.
+		path := ctx.ProjectConfig.Path
+		if project.Metadata != nil && project.Metadata.Path != "" {
+			path = project.Metadata.Path
+		}
+
+		terraform.AddOwners(path, project.Resources)
+		terraform.AddOwners(path, project.PastResources)
+	}
+
+	if r.runCtx.Config.ShowConfidence {
+		schema.SetConfidence(project.Resources)
+		schema.SetConfidence(project.PastResources)
+	}
+
+	// Destroying the project removes the resources it would have after it's applied
+	if r.runCtx.Config.Destroy {
+		project.PastResources = project.Resources
+		project.Resources = nil
+		project.HasDiff = true
+	}
+
+	if project.Metadata != nil {
+		if len(ctx.ProjectConfig.Labels) > 0 {
+			project.Metadata.Labels = ctx.ProjectConfig.Labels
+		}
+
+		if ctx.ProjectConfig.Budget > 0 {
+			budget := decimal.NewFromFloat(ctx.ProjectConfig.Budget)
+			project.Metadata.MonthlyBudget = &budget
+		}
+
+		project.Metadata.Lifetime = ctx.ProjectConfig.Lifetime
+	}
+
+	if ctx.ProjectConfig.Name != "" {
+		name, err := schema.RenderProjectName(ctx.ProjectConfig.Name, project)
+		if err != nil {
+			ui.PrintWarningf(r.runCtx.ErrWriter, "Could not generate the name of project %s from its name template: %s", project.Name, err)
+			r.runCtx.RecordWarning()
+		} else if name != "" {
+			project.Name = name
+		}
+	}
+}
+
+// newPrefetcher returns a prices.Prefetcher that the provider passes its resources to as they're
+// loaded, or nil if the provider doesn't implement schema.PrefetchingProvider or the run isn't priced.
+func (r *parallelRunner) newPrefetcher(provider schema.Provider) *prices.Prefetcher {
+	p, ok := provider.(schema.PrefetchingProvider)
+	if !ok || r.runCtx.Config.SkipPricing {
+		return nil
+	}
+
+	// the error is returned when the project is priced without prefetching.
+	fetcher, err := prices.NewPriceFetcher(r.runCtx)
+	if err != nil {
+		return nil
+	}
+
+	prefetcher := prices.NewPrefetcher(r.runCtx, fetcher)
+	p.SetPrefetchFunc(prefetcher.Add)
+
+	return prefetcher
+}
+
+// priceProject retrieves the prices of the resources of a project and calculates its costs. The prices
+// that have already been fetched by the prefetcher are used if it isn't nil.
+func (r *parallelRunner) priceProject(project *schema.Project, prefetcher *prices.Prefetcher) error {
+	var err error
+	if prefetcher != nil {
+		err = prices.PopulatePricesWithFetcher(r.runCtx, prefetcher, project)
+	} else {
+		err = prices.PopulatePrices(r.runCtx, project)
+	}
+
+	if err != nil {
+		if e := unwrapped(err); errors.Is(e, apiclient.ErrInvalidAPIKey) {
+			hint := fmt.Sprintf("%s %s %s %s %s\n%s",
+				"Please check your",
+				ui.PrimaryString(config.CredentialsFilePath()),
+				"file or",
+				ui.PrimaryString("INFRACOST_API_KEY"),
+				"environment variable.",
+				"If you continue having issues please email hello@infracost.io",
+			)
+			return clierror.New(clierror.CodeInvalidAPIKey, clierror.CategoryConfig, e.Error(), hint)
+		}
+
+		var cliErr *clierror.Error
+		if errors.As(err, &cliErr) && cliErr.Code == clierror.CodeAPIQuotaExceeded {
+			return cliErr
+		}
+
+		if e, ok := err.(*apiclient.APIError); ok {
+			return clierror.Wrap(e, clierror.CodeAPIRequestFailed, clierror.CategoryNetwork, "We have been notified of this issue.")
+		}
+
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		return clierror.Wrap(err, clierror.CodeAPIRequestFailed, clierror.CategoryNetwork, "")
+	}
+
+	schema.CalculateCosts(project)
+
+	project.CalculateDiff()
+
+	return nil
+}
+
+// loadResources loads the projects of the provider, calling onProject with each of them. Providers
+// that implement schema.StreamingProvider call it as soon as each project is loaded, otherwise it's
+// called once all of them are loaded. A project is only passed to onProject once all of its resources
+// have been loaded, the resources of a project that is still being loaded are only passed to the
+// prefetcher of a schema.PrefetchingProvider.
+func loadResources(provider schema.Provider, usageData map[string]*schema.UsageData, onProject func(*schema.Project)) ([]*schema.Project, error) {
+	if p, ok := provider.(schema.StreamingProvider); ok {
+		return p.StreamResources(usageData, onProject)
+	}
+
+	projects, err := provider.LoadResources(usageData)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		onProject(project)
+	}
+
+	return projects, nil
+}
+
+// projectPipeline runs a function on each of the projects that are added to it in a separate
+// goroutine, in the order they're added, so the projects can be processed while more of them are
+// being loaded. Adding a project never blocks. Once the function returns an error it isn't run on
+// the rest of the projects.
+type projectPipeline struct {
+	projectCfg *config.Project
+	f          func(*schema.Project) error
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []*schema.Project
+	closed bool
+
+	done chan struct{}
+	err  error
+}
+
+func newProjectPipeline(projectCfg *config.Project, f func(*schema.Project) error) *projectPipeline {
+	p := &projectPipeline{
+		projectCfg: projectCfg,
+		f:          f,
+		done:       make(chan struct{}),
+	}
+	p.cond = sync.NewCond(&p.mu)
+
+	go p.run()
+
+	return p
+}
+
+func (p *projectPipeline) run() {
+	defer close(p.done)
+
+	// the pipeline runs in its own goroutine, so its panics have to be handed to the caller of wait
+	// to be shown like the panics of the project's goroutine.
+	defer func() {
+		if e := recover(); e != nil {
+			p.err = newPanicError(e, debug.Stack(), p.projectCfg)
+		}
+	}()
+
+	for {
+		project, ok := p.next()
+		if !ok {
+			return
+		}
+
+		if p.err == nil {
+			p.err = p.f(project)
+		}
+	}
+}
+
+// next returns the next project that was added, waiting for one if there aren't any. It returns
+// false once the pipeline is closed and all the projects have been returned.
+func (p *projectPipeline) next() (*schema.Project, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.queue) == 0 && !p.closed {
+		p.cond.Wait()
+	}
+
+	if len(p.queue) == 0 {
+		return nil, false
+	}
+
+	project := p.queue[0]
+	p.queue = p.queue[1:]
+
+	return project, true
+}
+
+// add queues a project to be processed.
+func (p *projectPipeline) add(project *schema.Project) {
+	p.mu.Lock()
+	p.queue = append(p.queue, project)
+	p.mu.Unlock()
+
+	p.cond.Signal()
+}
+
+// wait closes the pipeline and waits for all the projects that were added to be processed. It
+// returns the first error from processing them.
+func (p *projectPipeline) wait() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	p.cond.Broadcast()
+	<-p.done
+
+	return p.err
+}
+
+// printTraces prints the trace of the resource passed to --trace-resource.
+func (r *parallelRunner) printTraces(projects []*schema.Project) {
+	found := false
+	for _, project := range projects {
+		for _, res := range project.Resources {
+			if res.Trace == nil {
+				continue
+			}
+
+			found = true
+			fmt.Fprintf(r.runCtx.ErrWriter, "\n%s\n", output.FormatTrace(r.runCtx.Config.Currency, res))
+		}
+	}
+
+	if !found {
+		ui.PrintWarningf(r.runCtx.ErrWriter, "No resource with address %s was found to trace", r.runCtx.Config.TraceResource)
+	}
+}
+
+// warnUnmatchedOverrides warns about the --set overrides that didn't match any resources, since
+// they're most likely a typo.
+func (r *parallelRunner) warnUnmatchedOverrides(projects []*schema.Project) {
+	for _, s := range r.runCtx.Config.AttributeOverrides {
+		o, err := terraform.ParseAttributeOverride(s)
+		if err != nil {
+			continue
+		}
+
+		matched := false
+		for _, project := range projects {
+			for _, res := range project.Resources {
+				if o.Matches(res.Name) {
+					matched = true
+					break
+				}
+			}
+		}
+
+		if !matched {
+			ui.PrintWarningf(r.runCtx.ErrWriter, "--set %s didn't match any resources", s)
+			r.runCtx.RecordWarning()
+		}
+	}
+}
+
 func (r *parallelRunner) runHCLProvider(wg *sync.WaitGroup, ctx *config.ProjectContext, usageFile *usage.UsageFile, out *projectOutput) {
 	defer func() {
 		err := recover()
@@ -547,7 +1332,7 @@ func (r *parallelRunner) runHCLProvider(wg *sync.WaitGroup, ctx *config.ProjectC
 			}
 		}
 	}()
-	if r.runCtx.Config.DisableHCLParsing {
+	if r.runCtx.Config.DisableHCLParsing || r.runCtx.Config.SkipPricing {
 		return
 	}
 
@@ -608,9 +1393,11 @@ func (r *parallelRunner) generateUsageFile(ctx *config.ProjectContext, provider
 	}
 
 	spinnerOpts := ui.SpinnerOptions{
-		EnableLogging: r.runCtx.Config.IsLogging(),
-		NoColor:       r.runCtx.Config.NoColor,
-		Indent:        "  ",
+		EnableLogging:  r.runCtx.Config.IsLogging(),
+		NoColor:        r.runCtx.Config.NoColor,
+		NoProgress:     r.runCtx.Config.NoProgress,
+		NonInteractive: r.runCtx.Config.NonInteractive,
+		Indent:         "  ",
 	}
 
 	spinner := ui.NewSpinner("Syncing usage data from cloud", spinnerOpts)
@@ -721,6 +1508,15 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	if hasProjectFlags {
 		projectCfg.Path, _ = cmd.Flags().GetString("path")
 		projectCfg.TerraformParseHCL, _ = cmd.Flags().GetBool("terraform-parse-hcl")
+		if projectCfg.Path == stdinPath {
+			dir, err := readStdinProject(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			projectCfg.Path = dir
+			projectCfg.TerraformParseHCL = true
+		}
 		projectCfg.TerraformVarFiles, _ = cmd.Flags().GetStringSlice("terraform-var-file")
 		tfVars, _ := cmd.Flags().GetStringSlice("terraform-var")
 		projectCfg.TerraformVars = tfVarsToMap(tfVars)
@@ -747,18 +1543,77 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 				p.TerraformParseHCL = true
 			}
 		}
+
+		err = resourceplugin.Load(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	if gitDiffBase, _ := cmd.Flags().GetString("git-diff-base"); gitDiffBase != "" {
+		cfgFilePath, _ := cmd.Flags().GetString("config-file")
+		err := filterChangedProjects(cmd, cfg, gitDiffBase, cfgFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.BundlePath != "" {
+		err := applyBundleToProjects(cfg)
+		if err != nil {
+			return err
+		}
 	}
 
 	cfg.NoCache, _ = cmd.Flags().GetBool("no-cache")
 
 	cfg.Format, _ = cmd.Flags().GetString("format")
 
-	if cfg.Format != "" && !contains(validRunFormats, cfg.Format) {
+	validFormats := validRunFormats
+	switch cmd.Name() {
+	case "graph":
+		validFormats = validGraphFormats
+	case "inventory":
+		validFormats = validInventoryFormats
+	case "recommend":
+		validFormats = validRecommendFormats
+	}
+
+	if cfg.Format != "" && !contains(validFormats, cfg.Format) {
 		ui.PrintUsage(cmd)
-		return fmt.Errorf("--format only supports %s", strings.Join(validRunFormats, ", "))
+		return fmt.Errorf("--format only supports %s", strings.Join(validFormats, ", "))
 	}
 
+	cfg.FailOn, _ = cmd.Flags().GetString("fail-on")
+	if !clierror.IsValidFailOn(cfg.FailOn) {
+		ui.PrintUsage(cmd)
+		return fmt.Errorf("--fail-on only supports %s", strings.Join(clierror.FailOnLevels(), ", "))
+	}
+
+	err := checkVerbosity(cmd, cfg.Verbosity)
+	if err != nil {
+		return err
+	}
+
+	cfg.EvalReportPath, _ = cmd.Flags().GetString("write-eval-report")
 	cfg.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
+	cfg.ShowAdvisories, _ = cmd.Flags().GetBool("show-advisories")
+	cfg.ShowConfidence, _ = cmd.Flags().GetBool("show-confidence")
+	if heuristicPricing, _ := cmd.Flags().GetBool("heuristic-pricing"); heuristicPricing {
+		cfg.HeuristicPricing = true
+	}
+	cfg.TraceResource, _ = cmd.Flags().GetString("trace-resource")
+	cfg.AttributeOverrides, _ = cmd.Flags().GetStringArray("set")
+	for _, s := range cfg.AttributeOverrides {
+		if _, err := terraform.ParseAttributeOverride(s); err != nil {
+			ui.PrintUsage(cmd)
+			return err
+		}
+	}
+	if usesPricingMock(cmd, cfg) {
+		cfg.PricingBackend = prices.MockPricingBackend
+		cfg.EventsDisabled = true
+	}
 	cfg.SyncUsageFile, _ = cmd.Flags().GetBool("sync-usage-file")
 
 	includeAllFields := "all"
@@ -789,6 +1644,49 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	return nil
 }
 
+// filterChangedProjects removes the projects that aren't affected by the files changed since
+// gitDiffBase, so monorepos only estimate the projects that a pull request changes. A project
+// is affected by changes to its own files or to the local modules it calls. If the config
+// file changed then all the projects are kept, since any of them may have changed.
+func filterChangedProjects(cmd *cobra.Command, cfg *config.Config, gitDiffBase string, cfgFilePath string) error {
+	repoPath := cfg.Projects[0].Path
+	if cfgFilePath != "" {
+		repoPath = cfgFilePath
+	}
+	if repoPath == "" {
+		repoPath = "."
+	}
+
+	changedFiles, err := config.GitChangedFiles(repoPath, gitDiffBase)
+	if err != nil {
+		return fmt.Errorf("Error detecting changed files for --git-diff-base: %w", err)
+	}
+
+	if cfgFilePath != "" {
+		if config.ContainsPath(changedFiles, cfgFilePath) {
+			log.Debugf("Config file %s changed since %s, estimating all projects", cfgFilePath, gitDiffBase)
+			return nil
+		}
+	}
+
+	var projects []*config.Project
+	for _, p := range cfg.Projects {
+		if p.IsAffectedBy(changedFiles, terraform.LocalModuleDirs(p.Path)) {
+			projects = append(projects, p)
+		} else {
+			log.Debugf("Skipping project %s since it has not changed since %s", p.Path, gitDiffBase)
+		}
+	}
+
+	if skipped := len(cfg.Projects) - len(projects); skipped > 0 {
+		cmd.PrintErrf("Skipping %d of %d projects not affected by changes since %s\n\n", skipped, len(cfg.Projects), gitDiffBase)
+	}
+
+	cfg.Projects = projects
+
+	return nil
+}
+
 func tfVarsToMap(vars []string) map[string]string {
 	if len(vars) == 0 {
 		return nil
@@ -807,9 +1705,24 @@ func tfVarsToMap(vars []string) map[string]string {
 	return m
 }
 
-func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
+// usesPricingMock returns true if the run uses the mock pricing backend, which doesn't need an API key.
+func usesPricingMock(cmd *cobra.Command, cfg *config.Config) bool {
+	mock, _ := cmd.Flags().GetBool("pricing-mock")
+	return mock || cfg.PricingBackend == prices.MockPricingBackend
+}
+
+// usesOfflinePricing returns true if the run doesn't call the pricing API, so it doesn't need an API key.
+// This is the case with mock prices or when running from an air-gapped bundle.
+func usesOfflinePricing(cmd *cobra.Command, cfg *config.Config) bool {
+	return usesPricingMock(cmd, cfg) || cfg.PricingBackend == prices.SnapshotPricingBackend
+}
+
+func checkRunConfig(warningWriter io.Writer, ctx *config.RunContext) error {
+	cfg := ctx.Config
+
 	if cfg.Format == "json" && cfg.ShowSkipped {
 		ui.PrintWarning(warningWriter, "show-skipped is not needed with JSON output format as that always includes them.\n")
+		ctx.RecordWarning()
 	}
 
 	if cfg.SyncUsageFile {
@@ -821,15 +1734,24 @@ func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
 		}
 		if len(missingUsageFile) == 1 {
 			ui.PrintWarning(warningWriter, "Ignoring sync-usage-file as no usage-file is specified.\n")
+			ctx.RecordWarning()
 		} else if len(missingUsageFile) == len(cfg.Projects) {
 			ui.PrintWarning(warningWriter, "Ignoring sync-usage-file since no projects have a usage-file specified.\n")
+			ctx.RecordWarning()
 		} else if len(missingUsageFile) > 1 {
 			ui.PrintWarning(warningWriter, fmt.Sprintf("Ignoring sync-usage-file for following projects as no usage-file is specified for them: %s.\n", strings.Join(missingUsageFile, ", ")))
+			ctx.RecordWarning()
 		}
 	}
 
+	// This isn't recorded as a warning so that --fail-on can be demoed with mock prices.
+	if cfg.PricingBackend == prices.MockPricingBackend {
+		ui.PrintWarning(warningWriter, "Using mock prices, these are not real costs.\n")
+	}
+
 	if money.GetCurrency(cfg.Currency) == nil {
 		ui.PrintWarning(warningWriter, fmt.Sprintf("Ignoring unknown currency '%s', using USD.\n", cfg.Currency))
+		ctx.RecordWarning()
 		cfg.Currency = "USD"
 	}
 
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestProjectPipelineOrder(t *testing.T) {
+	var processed []string
+	p := newProjectPipeline(&config.Project{}, func(project *schema.Project) error {
+		processed = append(processed, project.Name)
+		return nil
+	})
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		p.add(&schema.Project{Name: name})
+	}
+
+	require.NoError(t, p.wait())
+	assert.Equal(t, []string{"a", "b", "c", "d"}, processed)
+}
+
+func TestProjectPipelineAddDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	var processed []string
+	p := newProjectPipeline(&config.Project{}, func(project *schema.Project) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		processed = append(processed, project.Name)
+		return nil
+	})
+
+	p.add(&schema.Project{Name: "a"})
+	<-started
+
+	// The first project is still being processed so these are queued.
+	added := make(chan struct{})
+	go func() {
+		p.add(&schema.Project{Name: "b"})
+		p.add(&schema.Project{Name: "c"})
+		close(added)
+	}()
+
+	select {
+	case <-added:
+	case <-time.After(time.Second):
+		t.Fatal("add blocked while a project was being processed")
+	}
+
+	close(release)
+
+	require.NoError(t, p.wait())
+	assert.Equal(t, []string{"a", "b", "c"}, processed)
+}
+
+func TestProjectPipelineNoProjects(t *testing.T) {
+	called := false
+	p := newProjectPipeline(&config.Project{}, func(project *schema.Project) error {
+		called = true
+		return nil
+	})
+
+	require.NoError(t, p.wait())
+	assert.False(t, called)
+}
+
+func TestProjectPipelineErrorStopsLaterProjects(t *testing.T) {
+	errPricing := errors.New("pricing failed")
+
+	var processed []string
+	p := newProjectPipeline(&config.Project{}, func(project *schema.Project) error {
+		processed = append(processed, project.Name)
+		if project.Name == "b" {
+			return errPricing
+		}
+		return nil
+	})
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		p.add(&schema.Project{Name: name})
+	}
+
+	err := p.wait()
+	assert.ErrorIs(t, err, errPricing)
+	assert.Equal(t, []string{"a", "b"}, processed)
+}
+
+func TestProjectPipelinePanic(t *testing.T) {
+	projectCfg := &config.Project{Path: "infra/prod"}
+
+	var processed []string
+	p := newProjectPipeline(projectCfg, func(project *schema.Project) error {
+		processed = append(processed, project.Name)
+		if project.Name == "b" {
+			panic("unexpected resource")
+		}
+		return nil
+	})
+
+	for _, name := range []string{"a", "b", "c"} {
+		p.add(&schema.Project{Name: name})
+	}
+
+	err := p.wait()
+
+	var panicErr *panicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "unexpected resource", panicErr.recovered)
+	assert.Equal(t, projectCfg, panicErr.project)
+	assert.NotEmpty(t, panicErr.stack)
+	assert.Contains(t, panicErr.Error(), "unexpected resource")
+	assert.Equal(t, []string{"a", "b"}, processed)
+}
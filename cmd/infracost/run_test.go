@@ -1,6 +1,7 @@
 package main_test
 
 import (
+	"io"
 	"os"
 	"testing"
 
@@ -27,7 +28,7 @@ func TestFlagErrorsConfigFileAndTerraformWorkspace(t *testing.T) {
 }
 
 func TestFlagErrorsConfigFileAndTerraformWorkspaceEnv(t *testing.T) {
-	os.Setenv("INFRACOST_TERRAFORM_WORKSPACE", "dev")
+	t.Setenv("INFRACOST_TERRAFORM_WORKSPACE", "dev")
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--config-file", "./testdata/infracost-config.yml"}, nil)
 }
 
@@ -43,8 +44,36 @@ func TestConfigFileInvalidPathErrors(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--config-file", "./testdata/infracost-config-invalid-path.yml"}, nil)
 }
 
+func TestConfigFileLifetime(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--config-file", "./testdata/infracost-config-lifetime.yml", "--pricing-mock"}, nil)
+}
+
+func TestConfigFileLifetimeDiff(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"diff", "--config-file", "./testdata/infracost-config-lifetime.yml", "--pricing-mock"}, nil)
+}
+
+func TestConfigFileCostCenters(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--config-file", "./testdata/infracost-config-cost-centers.yml", "--format", "cost-centers", "--pricing-mock"}, nil)
+}
+
+func TestConfigFileCostCentersCSV(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--config-file", "./testdata/infracost-config-cost-centers.yml", "--format", "csv", "--pricing-mock"}, nil)
+}
+
+func TestConfigFileCommittedSpendDiff(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"diff", "--config-file", "./testdata/infracost-config-committed-spend.yml", "--pricing-mock"}, nil)
+}
+
+func TestConfigFileProjectNameTemplate(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"diff", "--config-file", "./testdata/infracost-config-project-name.yml", "--pricing-mock"}, nil)
+}
+
+func TestConfigFileLogRedaction(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--config-file", "./testdata/infracost-config-log-redaction.yml", "--pricing-mock"}, nil)
+}
+
 func TestFlagErrorsTerraformWorkspaceFlagAndEnv(t *testing.T) {
-	os.Setenv("INFRACOST_TERRAFORM_WORKSPACE", "dev")
+	t.Setenv("INFRACOST_TERRAFORM_WORKSPACE", "dev")
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "../../examples/terraform", "--terraform-workspace", "prod"}, nil)
 }
 
@@ -55,6 +84,35 @@ func TestCatchesRuntimeError(t *testing.T) {
 	})
 }
 
+func TestFlagNoProgress(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		args     []string
+		env      string
+		expected bool
+	}{
+		{name: "default", args: []string{"configure", "get", "currency"}, env: "false", expected: false},
+		{name: "flag", args: []string{"configure", "get", "currency", "--no-progress"}, env: "false", expected: true},
+		{name: "env", args: []string{"configure", "get", "currency"}, env: "true", expected: true},
+		{name: "flag overrides env", args: []string{"configure", "get", "currency", "--no-progress=false"}, env: "true", expected: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("INFRACOST_NO_PROGRESS", tt.env)
+
+			var ctx *config.RunContext
+			main.Run(func(c *config.RunContext) {
+				ctx = c
+				c.Config.EventsDisabled = true
+				c.ErrWriter = io.Discard
+				c.OutWriter = io.Discard
+				c.Exit = func(code int) {}
+			}, &tt.args)
+
+			assert.Equal(t, tt.expected, ctx.Config.NoProgress)
+		})
+	}
+}
+
 func TestAddHCLEnvVars(t *testing.T) {
 	type args struct {
 		r           output.Root
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func statusCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Publish an Infracost cost check to GitHub",
+		Long: `Publish an Infracost cost check to GitHub.
+
+The check shows the cost summary in the pull request checks list, annotates the
+Terraform blocks whose cost changed and passes or fails based on cost thresholds,
+so it can be used as a required check to gate merges.`,
+		Example: `  Publish a check run that fails if the monthly cost increases by more than $100:
+
+      infracost status github --repo my-org/my-repo --commit 2ca7182 --path infracost.json --max-diff 100 --github-token $GITHUB_TOKEN`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(statusGitHubCmd(ctx))
+
+	return cmd
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/comment"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func statusGitHubCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "github",
+		Short: "Publish an Infracost check run to GitHub",
+		Long: `Publish an Infracost check run to GitHub.
+
+GitHub only lets GitHub Apps create check runs, so use --github-app-id or a token
+that belongs to an app, such as the GITHUB_TOKEN in GitHub Actions.`,
+		Example: `  Publish a check run that fails if the monthly cost increases by more than 10%:
+
+      infracost status github --repo my-org/my-repo --commit 2ca7182 --path infracost.json --max-diff-percent 10 --github-token $GITHUB_TOKEN
+
+  Publish a check run as a GitHub App that fails if any project is over its budget:
+
+      infracost status github --repo my-org/my-repo --commit 2ca7182 --path infracost.json --fail-on-budget --github-app-id 12345 --github-app-private-key-path app.pem`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.SetContextValue("platform", "github")
+
+			extra, err := gitHubExtraFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			repo, _ := cmd.Flags().GetString("repo")
+			commit, _ := cmd.Flags().GetString("commit")
+			name, _ := cmd.Flags().GetString("name")
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			var thresholds output.CheckThresholds
+			if cmd.Flags().Changed("max-diff") {
+				v, _ := cmd.Flags().GetFloat64("max-diff")
+				d := decimal.NewFromFloat(v)
+				thresholds.MaxDiff = &d
+			}
+			if cmd.Flags().Changed("max-diff-percent") {
+				v, _ := cmd.Flags().GetFloat64("max-diff-percent")
+				d := decimal.NewFromFloat(v)
+				thresholds.MaxDiffPercent = &d
+			}
+			thresholds.FailOnBudget, _ = cmd.Flags().GetBool("fail-on-budget")
+
+			inputs, err := output.LoadPaths(paths)
+			if err != nil {
+				return err
+			}
+
+			combined, err := output.Combine(inputs)
+			if err != nil {
+				return err
+			}
+			combined.IsCIRun = ctx.IsCIRun()
+
+			result := output.ToCheckResult(combined, thresholds)
+
+			text, err := output.ToMarkdown(combined, output.Options{
+				NoColor:     ctx.Config.NoColor,
+				ShowSkipped: true,
+			}, output.MarkdownOptions{})
+			if err != nil {
+				return err
+			}
+
+			run := comment.GitHubCheckRun{
+				Name:       name,
+				HeadSHA:    commit,
+				Conclusion: "success",
+				Title:      result.Title,
+				Summary:    result.Summary,
+				Text:       string(text),
+			}
+			if !result.Passed {
+				run.Conclusion = "failure"
+			}
+
+			for _, a := range output.CheckAnnotations(combined, ".") {
+				run.Annotations = append(run.Annotations, comment.GitHubCheckAnnotation{
+					Path:    a.Path,
+					Line:    a.Line,
+					Level:   a.Level,
+					Title:   a.Title,
+					Message: a.Message,
+				})
+			}
+
+			ctx.SetContextValue("conclusion", run.Conclusion)
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if dryRun {
+				cmd.Printf("Conclusion: %s\n\n%s", run.Conclusion, run.Summary)
+				for _, a := range run.Annotations {
+					cmd.Printf("\n%s:%d %s: %s: %s", a.Path, a.Line, a.Level, a.Title, a.Message)
+				}
+				cmd.Println("\nCheck run not published to GitHub (--dry-run was specified)")
+				return nil
+			}
+
+			url, err := comment.PublishGitHubCheckRun(ctx.Context(), repo, extra, run)
+			if err != nil {
+				return err
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-status", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			msg := fmt.Sprintf("Check run published to GitHub with conclusion %s", run.Conclusion)
+			if url != "" {
+				msg += ", view it at " + ui.LinkString(url)
+			}
+			cmd.Println(msg)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("commit", "", "Commit SHA to publish the check run on")
+	_ = cmd.MarkFlagRequired("commit")
+	addGitHubAuthFlags(cmd)
+	cmd.Flags().String("name", "Infracost", "Name of the check run")
+	cmd.Flags().Float64("max-diff", 0, "Fail the check if the total monthly cost increases by more than this amount")
+	cmd.Flags().Float64("max-diff-percent", 0, "Fail the check if the total monthly cost increases by more than this percentage")
+	cmd.Flags().Bool("fail-on-budget", false, "Fail the check if a project's monthly cost is over its config file budget")
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+	cmd.Flags().String("repo", "", "Repository in format owner/repo")
+	_ = cmd.MarkFlagRequired("repo")
+	cmd.Flags().Bool("dry-run", false, "Generate the check run without actually publishing to GitHub")
+
+	return cmd
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// stdinPath is the --path that reads the Terraform project from stdin, e.g. in build sandboxes that
+// can pipe the source to infracost but not mount it.
+const stdinPath = "-"
+
+var (
+	stdinProjectDirsMu sync.Mutex
+	stdinProjectDirs   []string
+)
+
+// readStdinProject writes the Terraform project read from r to a temp directory and returns the directory.
+// The project is either a tar archive of the project directory, which can be gzipped, or HCL that's written
+// to main.tf, e.g. the .tf files of the project concatenated together. The project's HCL is parsed, since
+// it can't be planned outside of the source directory. The directory is removed when the run exits, see
+// removeStdinProjects.
+func readStdinProject(r io.Reader) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("Error reading project from stdin: %w", err)
+	}
+
+	if len(bytes.TrimSpace(b)) == 0 {
+		return "", fmt.Errorf("No project read from stdin, pipe a tar archive or Terraform HCL to --path %s", stdinPath)
+	}
+
+	if bytes.HasPrefix(b, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return "", fmt.Errorf("Error reading gzipped project from stdin: %w", err)
+		}
+
+		b, err = io.ReadAll(gz)
+		if err != nil {
+			return "", fmt.Errorf("Error reading gzipped project from stdin: %w", err)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "infracost-stdin-")
+	if err != nil {
+		return "", fmt.Errorf("Error creating directory for project from stdin: %w", err)
+	}
+
+	stdinProjectDirsMu.Lock()
+	stdinProjectDirs = append(stdinProjectDirs, dir)
+	stdinProjectDirsMu.Unlock()
+
+	if !isTar(b) {
+		err = os.WriteFile(filepath.Join(dir, "main.tf"), b, 0600)
+		if err != nil {
+			return "", fmt.Errorf("Error writing project from stdin: %w", err)
+		}
+
+		return dir, nil
+	}
+
+	err = extractStdinTar(bytes.NewReader(b), dir)
+	if err != nil {
+		return "", fmt.Errorf("Error extracting project from stdin: %w", err)
+	}
+
+	return archiveRoot(dir), nil
+}
+
+// archiveRoot returns the directory that an archive was created from if it was extracted to dir, e.g.
+// from tar -cz project, since that's the project directory. Otherwise it returns dir.
+func archiveRoot(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return dir
+	}
+
+	return filepath.Join(dir, entries[0].Name())
+}
+
+// isTar returns true if b starts with a tar header, which has the ustar magic at offset 257.
+func isTar(b []byte) bool {
+	return len(b) > 262 && string(b[257:262]) == "ustar"
+}
+
+// extractStdinTar extracts the regular files in the tar archive to dir. Other entries, like symlinks,
+// are skipped so the archive can't write outside of dir.
+func extractStdinTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			if hdr.Typeflag != tar.TypeDir {
+				log.Debugf("Skipping %s in project from stdin since it isn't a regular file", hdr.Name)
+			}
+			continue
+		}
+
+		clean := path.Clean(hdr.Name)
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("invalid file path %s", hdr.Name)
+		}
+
+		dest := filepath.Join(dir, filepath.FromSlash(clean))
+		err = os.MkdirAll(filepath.Dir(dest), 0700)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(f, tr) // nolint:gosec
+		if err != nil {
+			f.Close()
+			return err
+		}
+
+		err = f.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// removeStdinProjects removes the temp directories of the projects read from stdin.
+func removeStdinProjects() {
+	stdinProjectDirsMu.Lock()
+	defer stdinProjectDirsMu.Unlock()
+
+	for _, dir := range stdinProjectDirs {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Debugf("Error removing project from stdin %s: %s", dir, err)
+		}
+	}
+
+	stdinProjectDirs = nil
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+const (
+	// testGoldenFile is the file in each test directory that has the expected breakdown JSON.
+	testGoldenFile = "infracost.golden.json"
+	// testRecursiveSuffix is added to the path to test every Terraform directory below it.
+	testRecursiveSuffix = "/..."
+)
+
+func testCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Compare the cost estimates of Terraform directories with their golden files",
+		Long: `Compare the cost estimates of Terraform directories with their golden files.
+
+Each directory is estimated by parsing its HCL, and the breakdown JSON is compared with the
+infracost.golden.json file in the directory. If the directory has an infracost-usage.yml file
+it's used for the estimate. Use --update to write the golden files, and commit them so that
+changes in cost are caught when the tests are run in CI.
+
+When the path ends with /... every Terraform directory below it is tested. Directories that
+are in a test directory, e.g. its local modules, aren't tested on their own. The timestamp
+isn't included in the golden files and project paths are relative to the test directory, so
+they're the same on every machine.`,
+		Example: `  Create or update the golden files of the test directories:
+
+      infracost test --path testdata/... --update
+
+  Check the cost estimates haven't changed, e.g. in CI:
+
+      infracost test --path testdata/...`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("path")
+			if path == "" {
+				ui.PrintUsage(cmd)
+				return errors.New("--path is required")
+			}
+
+			if usesPricingMock(cmd, ctx.Config) {
+				ctx.Config.PricingBackend = prices.MockPricingBackend
+				ctx.Config.EventsDisabled = true
+			} else if !usesOfflinePricing(cmd, ctx.Config) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			dirs, err := testDirs(path)
+			if err != nil {
+				return err
+			}
+
+			ctx.Config.Projects = hclDirProjects(dirs)
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			est, err := estimateProjects(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			if est.cancelErr != nil {
+				return fmt.Errorf("Run cancelled: %w", est.cancelErr)
+			}
+
+			snapshots, err := testSnapshots(ctx, dirs, est)
+			if err != nil {
+				return err
+			}
+
+			update, _ := cmd.Flags().GetBool("update")
+			failed := 0
+
+			for i, dir := range dirs {
+				goldenPath := filepath.Join(dir, testGoldenFile)
+
+				if update {
+					err := os.WriteFile(goldenPath, snapshots[i], 0644) // nolint:gosec
+					if err != nil {
+						return errors.Wrap(err, "Unable to save golden file")
+					}
+
+					cmd.Printf("updated %s\n", dir)
+					continue
+				}
+
+				msg, err := compareTestSnapshot(goldenPath, snapshots[i])
+				if err != nil {
+					return err
+				}
+
+				if msg == "" {
+					cmd.Printf("ok      %s\n", dir)
+					continue
+				}
+
+				failed++
+				cmd.Printf("FAIL    %s\n%s\n", dir, ui.Indent(msg, "    "))
+			}
+
+			ctx.SetContextValue("testDirCount", len(dirs))
+			ctx.SetContextValue("testFailedCount", failed)
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-test", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if failed > 0 {
+				return clierror.New(
+					clierror.CodeSnapshotMismatch,
+					clierror.CategoryUser,
+					fmt.Sprintf("%d of %d cost estimates don't match their golden files", failed, len(dirs)),
+					"If the changes in cost are expected, run infracost test with --update to update the golden files",
+				)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory to test, end it with /... to test every Terraform directory below it")
+	cmd.Flags().Bool("update", false, "Write the golden files instead of comparing with them")
+	cmd.Flags().Bool("pricing-mock", false, "Use stable synthetic prices instead of the Cloud Pricing API, for demos and testing CI integrations without an API key")
+
+	_ = cmd.MarkFlagDirname("path")
+
+	return cmd
+}
+
+// testDirs returns the Terraform directories to test for the path. Directories that are hidden or
+// ignored by the ignore files in the path aren't searched, see hcl.IgnoreFiles.
+func testDirs(path string) ([]string, error) {
+	root := strings.TrimSuffix(filepath.ToSlash(path), testRecursiveSuffix)
+	recursive := root != filepath.ToSlash(path)
+	if root == "" {
+		root = "."
+	}
+	root = filepath.FromSlash(root)
+
+	info, err := os.Stat(root)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	if !recursive {
+		if !terraform.IsTerraformDir(root) {
+			return nil, clierror.New(clierror.CodeNoTerraformFiles, clierror.CategoryUser, fmt.Sprintf("No Terraform files found in %s", root), "")
+		}
+
+		return []string{root}, nil
+	}
+
+	ignoreRules, err := hcl.LoadIgnoreRules(root)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading ignore files")
+	}
+
+	var dirs []string
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if p != root && (strings.HasPrefix(d.Name(), ".") || ignoreRules.Ignored(p, true)) {
+			return filepath.SkipDir
+		}
+
+		if terraform.IsTerraformDir(p) {
+			dirs = append(dirs, p)
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error searching for Terraform directories")
+	}
+
+	if len(dirs) == 0 {
+		return nil, clierror.New(clierror.CodeNoTerraformFiles, clierror.CategoryUser, fmt.Sprintf("No Terraform directories found in %s", root), "")
+	}
+
+	return dirs, nil
+}
+
+// testSnapshots returns the breakdown JSON of each of the test directories, in the same order as
+// dirs, without the values that change between runs or machines.
+func testSnapshots(ctx *config.RunContext, dirs []string, est *runEstimate) ([][]byte, error) {
+	roots, err := est.rootsByPath(ctx.Config.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([][]byte, 0, len(dirs))
+	for _, dir := range dirs {
+		b, err := testSnapshot(roots[dir], dir)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error generating output")
+		}
+
+		snapshots = append(snapshots, b)
+	}
+
+	return snapshots, nil
+}
+
+func testSnapshot(r output.Root, dir string) ([]byte, error) {
+	for i, project := range r.Projects {
+		if project.Metadata == nil {
+			continue
+		}
+
+		metadata := *project.Metadata
+		if rel, err := filepath.Rel(dir, metadata.Path); err == nil {
+			metadata.Path = filepath.ToSlash(rel)
+		}
+		metadata.VCSRepoURL = ""
+		metadata.VCSSubPath = ""
+		metadata.VCSPullRequestURL = ""
+
+		r.Projects[i].Name = metadata.Path
+		r.Projects[i].Metadata = &metadata
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot map[string]interface{}
+	err = json.Unmarshal(b, &snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range []string{"timeGenerated", "runId", "shareUrl"} {
+		delete(snapshot, key)
+	}
+
+	b, err = json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// compareTestSnapshot returns why the snapshot doesn't match the golden file, or an empty string if
+// it does.
+func compareTestSnapshot(goldenPath string, snapshot []byte) (string, error) {
+	expected, err := os.ReadFile(goldenPath)
+	if os.IsNotExist(err) {
+		return fmt.Sprintf("%s doesn't exist, run with --update to create it", testGoldenFile), nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "Unable to read golden file")
+	}
+
+	if bytes.Equal(expected, snapshot) {
+		return "", nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expected)),
+		B:        difflib.SplitLines(string(snapshot)),
+		FromFile: testGoldenFile,
+		ToFile:   "current",
+		Context:  3,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(diff, "\n "), nil
+}
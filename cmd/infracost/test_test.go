@@ -0,0 +1,23 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestTestHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"test", "--help"}, nil)
+}
+
+func TestTestNoPath(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"test"}, nil)
+}
+
+func TestTestMatch(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"test", "--path", "./testdata/test_match", "--pricing-mock"}, nil)
+}
+
+func TestTestMismatch(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"test", "--path", "./testdata/test_mismatch", "--pricing-mock"}, nil)
+}
@@ -0,0 +1,23 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+# infracost: ~$10/mo
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = "m5.large"
+}
+
+# infracost: ~$10/mo
+resource "aws_iam_user" "free" {
+  name = "free"
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-east-1a"
+  size              = 100
+}
@@ -0,0 +1,17 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+module "web" {
+  for_each = toset(["a.b", "c\"d", "$${e}"])
+  source   = "./modules/web"
+}
+
+module "worker" {
+  count  = 2
+  source = "./modules/web"
+}
+
+resource "aws_eip" "ip" {
+  for_each = toset(["k[0]", "k\\1"])
+}
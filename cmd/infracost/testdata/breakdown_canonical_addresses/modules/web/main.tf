@@ -0,0 +1,5 @@
+resource "aws_instance" "web" {
+  count         = 1
+  ami           = "ami-674cbc1e"
+  instance_type = "t3.micro"
+}
@@ -0,0 +1,58 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+provider "azurerm" {
+  features {}
+}
+
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = "m5.large"
+}
+
+resource "aws_appstream_fleet" "desktops" {
+  name          = "desktops"
+  instance_type = "stream.standard.medium"
+
+  compute_capacity {
+    desired_instances = 2
+  }
+}
+
+resource "aws_memorydb_cluster" "sessions" {
+  name      = "sessions"
+  node_type = "db.t4g.small"
+  acl_name  = "open-access"
+}
+
+resource "azurerm_netapp_volume" "shared" {
+  name                = "shared"
+  location            = "eastus"
+  resource_group_name = "example"
+  account_name        = "example"
+  pool_name           = "example"
+  volume_path         = "shared"
+  service_level       = "Premium"
+  subnet_id           = "subnet"
+  storage_quota_in_gb = 500
+}
+
+resource "google_network_services_gateway" "ingress" {
+  name  = "ingress"
+  type  = "SECURE_WEB_GATEWAY"
+  ports = [443]
+}
+
+resource "aws_globalaccelerator_accelerator" "edge" {
+  name = "edge"
+}
@@ -0,0 +1,12 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = "m5.large"
+}
+
+module "app" {
+  source = "./modules/app"
+}
@@ -0,0 +1,4 @@
+resource "aws_instance" "app" {
+  ami           = "ami-674cbc1e"
+  instance_type = "c5.xlarge"
+}
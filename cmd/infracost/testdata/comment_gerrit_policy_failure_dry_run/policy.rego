@@ -0,0 +1,15 @@
+package infracost
+
+deny[out] {
+	maxDiff := 1.0
+
+	msg := sprintf(
+		"Total monthly cost diff must be less than $%.2f (actual diff is $%.2f)",
+		[maxDiff, to_number(input.diffTotalMonthlyCost)],
+	)
+
+	out := {
+		"msg": msg,
+		"failed": to_number(input.diffTotalMonthlyCost) >= maxDiff,
+	}
+}
@@ -0,0 +1,16 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = "m5.large"
+}
+
+module "storage" {
+  source = "./modules/storage"
+}
@@ -0,0 +1,23 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+variable "instance_type" {
+  default = "t3.micro"
+}
+
+variable "subnet_id" {}
+
+variable "volume_size" {
+  default = 8
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = var.instance_type
+  subnet_id     = var.subnet_id
+
+  root_block_device {
+    volume_size = var.volume_size
+  }
+}
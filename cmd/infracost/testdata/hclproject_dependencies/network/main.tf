@@ -0,0 +1,20 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+variable "app_instance_type" {
+  default = "m5.xlarge"
+}
+
+resource "aws_nat_gateway" "main" {
+  allocation_id = "eip-12345678"
+  subnet_id     = "subnet-12345678"
+}
+
+output "app_instance_type" {
+  value = var.app_instance_type
+}
+
+output "private_subnet_id" {
+  value = aws_nat_gateway.main.id
+}
@@ -0,0 +1,16 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+variable "volume_size" {
+  default = 20
+}
+
+resource "aws_ebs_volume" "shared" {
+  availability_zone = "us-east-1a"
+  size              = var.volume_size
+}
+
+output "volume_size" {
+  value = aws_ebs_volume.shared.size
+}
@@ -0,0 +1,27 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_instance" "web" {
+  count         = 2
+  ami           = "ami-674cbc1e"
+  instance_type = "t3.micro"
+}
+
+resource "aws_iam_role" "web" {
+  name               = "web"
+  assume_role_policy = "{}"
+}
+
+resource "aws_datasync_task" "sync" {
+  destination_location_arn = "arn:aws:datasync:us-east-1:123456789012:location/loc-1"
+  source_location_arn      = "arn:aws:datasync:us-east-1:123456789012:location/loc-2"
+}
+
+resource "random_id" "suffix" {
+  byte_length = 4
+}
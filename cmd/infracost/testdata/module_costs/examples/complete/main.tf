@@ -0,0 +1,10 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+module "app" {
+  source = "../.."
+
+  instance_type      = "m5.large"
+  enable_nat_gateway = true
+}
@@ -0,0 +1,25 @@
+variable "instance_type" {
+  type    = string
+  default = "t3.micro"
+}
+
+variable "enable_nat_gateway" {
+  type    = bool
+  default = false
+}
+
+resource "aws_instance" "app" {
+  ami           = "ami-674cbc1e"
+  instance_type = var.instance_type
+}
+
+resource "aws_nat_gateway" "nat" {
+  count = var.enable_nat_gateway ? 1 : 0
+
+  allocation_id = "eip-12345678"
+  subnet_id     = "subnet-12345678"
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "app-logs"
+}
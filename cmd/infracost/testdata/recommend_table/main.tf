@@ -0,0 +1,40 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+resource "aws_eip" "unattached" {}
+
+resource "aws_eip" "attached" {
+  instance = aws_instance.web.id
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = "m4.large"
+  monitoring    = true
+
+  root_block_device {
+    volume_size = 50
+  }
+}
+
+resource "aws_instance" "api" {
+  ami           = "ami-674cbc1e"
+  instance_type = "m5.large"
+
+  root_block_device {
+    volume_type = "gp3"
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-east-1a"
+  size              = 500
+  type              = "gp2"
+}
+
+resource "aws_nat_gateway" "nat" {
+  count         = 4
+  allocation_id = "eipalloc-${count.index}"
+  subnet_id     = "subnet-${count.index}"
+}
@@ -0,0 +1,17 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = "m5.large"
+
+  root_block_device {
+    volume_size = 50
+  }
+}
+
+resource "aws_nat_gateway" "nat" {
+  allocation_id = "eip-12345678"
+  subnet_id     = "subnet-12345678"
+}
@@ -0,0 +1,42 @@
+// policy checks that the total monthly cost of the Infracost JSON is less than 1000.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+type root struct {
+	TotalMonthlyCost string `json:"totalMonthlyCost"`
+	Projects         []struct {
+		Name string `json:"name"`
+	} `json:"projects"`
+}
+
+type result struct {
+	Msg    string `json:"msg"`
+	Failed bool   `json:"failed"`
+}
+
+func main() {
+	var r root
+	if err := json.NewDecoder(os.Stdin).Decode(&r); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	total, _ := strconv.ParseFloat(r.TotalMonthlyCost, 64)
+
+	_ = json.NewEncoder(os.Stdout).Encode([]result{
+		{
+			Msg:    fmt.Sprintf("Total monthly cost must be less than $1000, it's $%.2f", total),
+			Failed: total >= 1000,
+		},
+		{
+			Msg:    "There must be at least one project",
+			Failed: len(r.Projects) == 0,
+		},
+	})
+}
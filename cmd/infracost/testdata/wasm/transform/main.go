@@ -0,0 +1,31 @@
+// transform outputs the total monthly cost of each project in the Infracost JSON as CSV.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type root struct {
+	Currency string `json:"currency"`
+	Projects []struct {
+		Name      string `json:"name"`
+		Breakdown struct {
+			TotalMonthlyCost string `json:"totalMonthlyCost"`
+		} `json:"breakdown"`
+	} `json:"projects"`
+}
+
+func main() {
+	var r root
+	if err := json.NewDecoder(os.Stdin).Decode(&r); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("project,monthly_cost_%s\n", r.Currency)
+	for _, p := range r.Projects {
+		fmt.Printf("%s,%s\n", p.Name, p.Breakdown.TotalMonthlyCost)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/update"
+	"github.com/infracost/infracost/internal/version"
+)
+
+func updateCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update Infracost to the latest version",
+		Long: `Update Infracost to the latest version.
+
+The signature of the release checksums and the checksum of the download are
+verified before the binary is replaced, and the update is refused if the release
+is not signed. If Infracost was installed using Homebrew then use brew upgrade
+instead.`,
+		Example: `  Update to the latest stable release:
+
+      infracost update
+
+  Update to the latest beta release:
+
+      infracost update --channel beta
+
+  Check if there is a new version without updating:
+
+      infracost update --check`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			channel := ctx.Config.UpdateChannel
+			if cmd.Flags().Changed("channel") {
+				channel, _ = cmd.Flags().GetString("channel")
+			}
+
+			if !update.IsValidChannel(channel) {
+				return fmt.Errorf("Invalid update channel %s, must be one of: %s", channel, strings.Join(update.Channels(), ", "))
+			}
+
+			spinner := ctx.NewSpinner(fmt.Sprintf("Checking for the latest %s release", channel))
+			release, err := update.FindLatestRelease(ctx.Context(), channel)
+			if err != nil {
+				spinner.Fail()
+				return err
+			}
+			spinner.Success()
+
+			if !release.IsNewer() {
+				cmd.Printf("Infracost %s is already the latest %s version\n", version.Version, channel)
+				return nil
+			}
+
+			if check, _ := cmd.Flags().GetBool("check"); check {
+				cmd.Printf("A new version of Infracost is available: %s → %s\n", ui.PrimaryString(version.Version), ui.PrimaryString(release.Version))
+				cmd.Printf("Run %s to update\n", ui.PrimaryString("infracost update"))
+				return nil
+			}
+
+			isBrew, err := update.IsBrewInstall()
+			if err != nil {
+				log.Debugf("error checking if executable was installed via brew: %v", err)
+			}
+			if isBrew {
+				return errors.New("Infracost was installed using Homebrew, run brew upgrade infracost to update it")
+			}
+
+			exe, err := os.Executable()
+			if err != nil {
+				return errors.Wrap(err, "Error finding infracost executable")
+			}
+
+			exe, err = filepath.EvalSymlinks(exe)
+			if err != nil {
+				return errors.Wrap(err, "Error evaluating infracost executable symlink")
+			}
+
+			spinner = ctx.NewSpinner(fmt.Sprintf("Downloading Infracost %s", release.Version))
+			err = update.Apply(ctx.Context(), release, exe)
+			if err != nil {
+				spinner.Fail()
+				return err
+			}
+			spinner.Success()
+
+			ui.PrintSuccessf(cmd.OutOrStdout(), "Updated Infracost %s → %s", version.Version, release.Version)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("channel", "", "Release channel to update from: stable, beta. Defaults to the update_channel setting or stable")
+	cmd.Flags().Bool("check", false, "Only check if there is a new version, don't update")
+
+	return cmd
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func uploadCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upload",
+		Short: "Upload an Infracost JSON file to the Infracost dashboard",
+		Long: `Upload an Infracost JSON file to the Infracost dashboard.
+
+This is useful in CI pipelines where the cost estimate is generated in one stage and
+uploaded in a later stage. The run is tagged with the organization, repository, branch
+and pull request, these are detected from the CI environment or git if not specified.`,
+		Example: `  Upload an Infracost JSON file:
+
+      infracost breakdown --path /code --format json --out-file infracost.json
+      infracost upload --path infracost.json
+
+  Upload an Infracost JSON file for a pull request:
+
+      infracost upload --path infracost.json --repo https://github.com/my-org/my-repo --branch my-branch --pull-request https://github.com/my-org/my-repo/pull/3`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+				return err
+			}
+
+			if ctx.Config.IsSelfHosted() {
+				return errors.New("The dashboard is part of Infracost's hosted services. Contact hello@infracost.io for help.")
+			}
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			inputs, err := output.LoadPaths(paths)
+			if err != nil {
+				return err
+			}
+
+			combined, err := output.Combine(inputs)
+			if err != nil {
+				return err
+			}
+			combined.IsCIRun = ctx.IsCIRun()
+
+			tags := detectRunTags(cmd, combined)
+
+			dashboardClient := apiclient.NewDashboardAPIClient(ctx)
+			result, err := dashboardClient.UploadRun(ctx, combined, tags)
+			if err != nil {
+				return errors.Wrap(err, "Error uploading run")
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-upload", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			msg := "Run uploaded to the Infracost dashboard"
+			if result.ShareURL != "" {
+				msg += ", view it at " + ui.LinkString(result.ShareURL)
+			}
+			ui.PrintSuccess(cmd.ErrOrStderr(), msg)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	cmd.Flags().String("org", "", "Organization to tag the run with. Defaults to the owner of the repository")
+	cmd.Flags().String("repo", "", "Repository URL to tag the run with. Defaults to the repository in the Infracost JSON file, CI environment or git")
+	cmd.Flags().String("branch", "", "Branch to tag the run with. Defaults to the branch in the CI environment or git")
+	cmd.Flags().String("pull-request", "", "Pull request URL to tag the run with. Defaults to the pull request in the Infracost JSON file or CI environment")
+
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+
+	return cmd
+}
+
+// detectRunTags returns the tags for the run using the flags and falls back to the project
+// metadata in the Infracost JSON file and then the CI environment or git.
+func detectRunTags(cmd *cobra.Command, root output.Root) apiclient.RunTags {
+	tags := apiclient.RunTags{}
+	tags.Organization, _ = cmd.Flags().GetString("org")
+	tags.Repository, _ = cmd.Flags().GetString("repo")
+	tags.Branch, _ = cmd.Flags().GetString("branch")
+	tags.PullRequest, _ = cmd.Flags().GetString("pull-request")
+
+	for _, p := range root.Projects {
+		if p.Metadata == nil {
+			continue
+		}
+
+		if tags.Repository == "" {
+			tags.Repository = p.Metadata.VCSRepoURL
+		}
+
+		if tags.PullRequest == "" {
+			tags.PullRequest = p.Metadata.VCSPullRequestURL
+		}
+	}
+
+	if tags.Repository == "" || tags.PullRequest == "" {
+		metadata := config.DetectProjectMetadata(".")
+
+		if tags.Repository == "" {
+			tags.Repository = metadata.VCSRepoURL
+		}
+
+		if tags.PullRequest == "" {
+			tags.PullRequest = metadata.VCSPullRequestURL
+		}
+	}
+
+	if tags.Branch == "" {
+		tags.Branch = config.DetectVCSBranch(".")
+	}
+
+	if tags.Organization == "" {
+		tags.Organization = repoOwner(tags.Repository)
+	}
+
+	return tags
+}
+
+// repoOwner returns the owner of the repository from its URL, e.g. my-org for
+// https://github.com/my-org/my-repo or git@github.com:my-org/my-repo.git.
+func repoOwner(repoURL string) string {
+	if repoURL == "" {
+		return ""
+	}
+
+	p := repoURL
+	if u, err := url.Parse(repoURL); err == nil && u.Host != "" {
+		p = u.Path
+	} else if i := strings.Index(repoURL, ":"); i != -1 {
+		p = repoURL[i+1:]
+	}
+
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[0]
+}
@@ -9,7 +9,7 @@ import (
 	"go/token"
 	"io/fs"
 	"os"
-	"path"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
@@ -102,7 +102,7 @@ func (c config) RegistryFuncName() string {
 }
 
 func (c config) ProviderDirectory() string {
-	return path.Join("internal/providers/terraform/", c.CloudProvider)
+	return filepath.Join("internal/providers/terraform/", c.CloudProvider)
 }
 
 func (c config) ResourceURL() string {
@@ -126,11 +126,11 @@ func (c config) RunTestCommand() string {
 }
 
 func (c config) registryLocation() string {
-	return path.Join(c.ProviderDirectory(), "registry.go")
+	return filepath.Join(c.ProviderDirectory(), "registry.go")
 }
 
 func (c config) testDataLocation() string {
-	return path.Join(c.ProviderDirectory(), "testdata", c.Filename+"_test")
+	return filepath.Join(c.ProviderDirectory(), "testdata", c.Filename+"_test")
 }
 
 type rep struct {
@@ -178,7 +178,7 @@ func writeOutput(c config, written []string) {
 	b.WriteString(strings.Join(
 		[]string{
 			"Start by adding an example resource to the Terraform test file:",
-			fmt.Sprintf("\t%s", path.Join(c.testDataLocation(), c.Filename+"_test.tf")),
+			fmt.Sprintf("\t%s", filepath.Join(c.testDataLocation(), c.Filename+"_test.tf")),
 			"and running the following command to generate initial Infracost output:",
 			fmt.Sprintf("\t%s", c.RunTestCommand()),
 			"Check out 'Adding new resources' section in our CONTRIBUTING.md guide for next steps!",
@@ -233,7 +233,7 @@ func writeFiles(assetMap map[string]*template.Template, c config) ([]string, err
 
 	for p, tmpl := range assetMap {
 		p = embedPathExp.ReplaceAllString(p, "")
-		fileLoc := path.Join(renderDir, p)
+		fileLoc := filepath.Join(renderDir, p)
 		for _, repl := range replacements {
 			fileLoc = repl.exp.ReplaceAllString(fileLoc, repl.value)
 		}
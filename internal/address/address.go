@@ -0,0 +1,442 @@
+// Package address parses and formats Terraform resource instance addresses, such as
+// module.vpc["prod"].aws_nat_gateway.this[0], in the same canonical form that Terraform uses in
+// terraform state list and the plan JSON. Using it for every address in the output means the
+// results can be joined against Terraform's own output by external tools.
+package address
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var trailingKeyReg = regexp.MustCompile(`\[[^\]]*\]$`)
+
+// Mode is whether an address is for a managed resource or a data source.
+type Mode int
+
+const (
+	// ManagedMode is the mode of resources declared with a resource block.
+	ManagedMode Mode = iota
+	// DataMode is the mode of data sources declared with a data block.
+	DataMode
+)
+
+// InstanceKey is the count index or for_each key of a module call or resource instance.
+type InstanceKey interface {
+	// String returns the key in the brackets that follow the name, e.g. [0] or ["a"].
+	String() string
+	instanceKey()
+}
+
+// IntKey is the key of an instance of a module call or resource that uses count.
+type IntKey int
+
+// String returns the key as an index, e.g. [0].
+func (k IntKey) String() string {
+	return fmt.Sprintf("[%d]", int(k))
+}
+
+func (k IntKey) instanceKey() {}
+
+// StringKey is the key of an instance of a module call or resource that uses for_each.
+type StringKey string
+
+// String returns the key as a quoted HCL string, e.g. ["a"], escaping the characters the same way
+// as Terraform does.
+func (k StringKey) String() string {
+	return fmt.Sprintf("[%s]", QuoteString(string(k)))
+}
+
+func (k StringKey) instanceKey() {}
+
+// ModuleInstance is a call to a module in an address, and its key if the call uses count or for_each.
+type ModuleInstance struct {
+	Name string
+	Key  InstanceKey
+}
+
+// String returns the module call in an address, e.g. module.vpc["prod"].
+func (m ModuleInstance) String() string {
+	if m.Key == nil {
+		return "module." + m.Name
+	}
+
+	return "module." + m.Name + m.Key.String()
+}
+
+// Module is the path of module calls from the root module to a module instance. It's empty for
+// the root module.
+type Module []ModuleInstance
+
+// String returns the address of the module instance, e.g. module.a["k"].module.b, or an empty
+// string for the root module.
+func (m Module) String() string {
+	parts := make([]string, len(m))
+	for i, call := range m {
+		parts[i] = call.String()
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// Ancestors returns the address of each module instance from the outermost module call to m,
+// including m, e.g. module.a.module.b returns module.a and module.a.module.b.
+func (m Module) Ancestors() []string {
+	addrs := make([]string, len(m))
+	for i := range m {
+		addrs[i] = m[:i+1].String()
+	}
+
+	return addrs
+}
+
+// Address is a resource instance address.
+type Address struct {
+	// Module is the module instance the resource is in.
+	Module Module
+	Mode   Mode
+	Type   string
+	Name   string
+	Key    InstanceKey
+}
+
+// String returns the canonical address, e.g. module.vpc["prod"].aws_nat_gateway.this[0].
+func (a Address) String() string {
+	module := a.ModuleAddress()
+	if module == "" {
+		return a.ResourceAddress()
+	}
+
+	return module + "." + a.ResourceAddress()
+}
+
+// ModuleAddress returns the address of the module instance the resource is in, e.g.
+// module.vpc["prod"], or an empty string if it's in the root module.
+func (a Address) ModuleAddress() string {
+	return a.Module.String()
+}
+
+// ModuleAddresses returns the address of each module instance that the resource is in, from the
+// outermost module, e.g. module.a.module.b.aws_instance.web is in module.a and module.a.module.b.
+func (a Address) ModuleAddresses() []string {
+	return a.Module.Ancestors()
+}
+
+// ResourceAddress returns the address relative to the module the resource is in, e.g.
+// aws_nat_gateway.this[0] or data.aws_region.current.
+func (a Address) ResourceAddress() string {
+	s := a.Type + "." + a.Name
+	if a.Mode == DataMode {
+		s = "data." + s
+	}
+
+	if a.Key != nil {
+		s += a.Key.String()
+	}
+
+	return s
+}
+
+// ConfigAddress returns the address without any instance keys, e.g. module.vpc.aws_nat_gateway.this.
+// This is the address of the block that declares the resource, which is how resources are referred
+// to in the configuration section of the plan JSON.
+func (a Address) ConfigAddress() string {
+	c := Address{Mode: a.Mode, Type: a.Type, Name: a.Name}
+	for _, m := range a.Module {
+		c.Module = append(c.Module, ModuleInstance{Name: m.Name})
+	}
+
+	return c.String()
+}
+
+// Canonical returns the address in canonical form, e.g. with the for_each keys quoted the same way as
+// Terraform. Strings that aren't valid addresses, e.g. the names of resources from other IaC tools,
+// are returned unchanged.
+func Canonical(s string) string {
+	a, err := Parse(s)
+	if err != nil {
+		return s
+	}
+
+	return a.String()
+}
+
+// TrimKey returns s without the instance key at its end, e.g. aws_instance.web[0] returns
+// aws_instance.web. Unlike ConfigAddress it keeps the keys of any module calls, and works for
+// strings that aren't valid addresses.
+func TrimKey(s string) string {
+	return trailingKeyReg.ReplaceAllString(s, "")
+}
+
+// Parse parses a resource instance address. The for_each keys can be quoted HCL strings with escape
+// sequences, as Terraform outputs them, and names can have any characters other than . and [.
+func Parse(s string) (Address, error) {
+	p := parser{s: s}
+
+	a, err := p.parse()
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid address %q: %w", s, err)
+	}
+
+	return a, nil
+}
+
+// SplitModule parses the module calls at the start of s, which can be a resource address or a module
+// address, and returns them with the rest of s after them. For example module.a["k"].aws_instance.web
+// returns module.a["k"] and aws_instance.web, and module.a.module.b returns module.a.module.b and an
+// empty string.
+func SplitModule(s string) (Module, string, error) {
+	p := parser{s: s}
+
+	m, err := p.module()
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid address %q: %w", s, err)
+	}
+
+	return m, p.s[p.pos:], nil
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+// module parses the module calls at the current position, and the dot after the last one if it's
+// followed by the rest of a resource address.
+func (p *parser) module() (Module, error) {
+	var m Module
+
+	for strings.HasPrefix(p.s[p.pos:], "module.") {
+		p.pos += len("module.")
+
+		name, key, err := p.nameAndKey()
+		if err != nil {
+			return nil, err
+		}
+
+		m = append(m, ModuleInstance{Name: name, Key: key})
+
+		if p.pos == len(p.s) {
+			break
+		}
+
+		if err := p.dot(); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func (p *parser) parse() (Address, error) {
+	var a Address
+
+	var err error
+	a.Module, err = p.module()
+	if err != nil {
+		return a, err
+	}
+
+	if strings.HasPrefix(p.s[p.pos:], "data.") {
+		a.Mode = DataMode
+		p.pos += len("data.")
+	}
+
+	a.Type = p.name()
+	if a.Type == "" {
+		return a, fmt.Errorf("missing resource type")
+	}
+
+	if err := p.dot(); err != nil {
+		return a, err
+	}
+
+	a.Name, a.Key, err = p.nameAndKey()
+	if err != nil {
+		return a, err
+	}
+
+	if p.pos < len(p.s) {
+		return a, fmt.Errorf("unexpected %q after the resource name", p.s[p.pos:])
+	}
+
+	return a, nil
+}
+
+func (p *parser) dot() error {
+	if p.pos >= len(p.s) || p.s[p.pos] != '.' {
+		return fmt.Errorf("expected . at position %d", p.pos)
+	}
+
+	p.pos++
+	return nil
+}
+
+func (p *parser) name() string {
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] != '.' && p.s[p.pos] != '[' {
+		p.pos++
+	}
+
+	return p.s[start:p.pos]
+}
+
+func (p *parser) nameAndKey() (string, InstanceKey, error) {
+	name := p.name()
+	if name == "" {
+		return "", nil, fmt.Errorf("missing name at position %d", p.pos)
+	}
+
+	if p.pos >= len(p.s) || p.s[p.pos] != '[' {
+		return name, nil, nil
+	}
+
+	key, err := p.key()
+	return name, key, err
+}
+
+func (p *parser) key() (InstanceKey, error) {
+	p.pos++ // [
+
+	var key InstanceKey
+	if p.pos < len(p.s) && p.s[p.pos] == '"' {
+		s, n, err := UnquoteString(p.s[p.pos:])
+		if err != nil {
+			return nil, err
+		}
+
+		key = StringKey(s)
+		p.pos += n
+	} else {
+		start := p.pos
+		for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+
+		i, err := strconv.Atoi(p.s[start:p.pos])
+		if err != nil {
+			return nil, fmt.Errorf("invalid key at position %d, it should be a number or a quoted string", start)
+		}
+
+		key = IntKey(i)
+	}
+
+	if p.pos >= len(p.s) || p.s[p.pos] != ']' {
+		return nil, fmt.Errorf("expected ] at position %d", p.pos)
+	}
+	p.pos++
+
+	return key, nil
+}
+
+// QuoteString returns s as a quoted HCL string, the same as Terraform quotes for_each keys in
+// addresses. Unlike strconv.Quote, template sequences are escaped, e.g. ${ as $${, and characters that
+// aren't printable are written as \u escapes.
+func QuoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+
+	for i, r := range s {
+		switch r {
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '$', '%':
+			b.WriteRune(r)
+			if strings.HasPrefix(s[i+1:], "{") {
+				b.WriteRune(r)
+			}
+		default:
+			switch {
+			case unicode.IsPrint(r):
+				b.WriteRune(r)
+			case r < 0x10000:
+				fmt.Fprintf(&b, `\u%04x`, r)
+			default:
+				fmt.Fprintf(&b, `\U%08x`, r)
+			}
+		}
+	}
+
+	b.WriteByte('"')
+	return b.String()
+}
+
+// UnquoteString parses the quoted HCL string at the start of s, returning its value and the number of
+// bytes it takes up in s. It accepts the escapes written by QuoteString.
+func UnquoteString(s string) (string, int, error) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", 0, fmt.Errorf("expected a quoted string")
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case r == '"':
+			return b.String(), i + 1, nil
+		case r == '\\':
+			if i+1 >= len(s) {
+				return "", 0, fmt.Errorf("unterminated escape sequence")
+			}
+
+			switch s[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"':
+				b.WriteByte('"')
+			case '\\':
+				b.WriteByte('\\')
+			case 'u', 'U':
+				n := 4
+				if s[i+1] == 'U' {
+					n = 8
+				}
+
+				if i+2+n > len(s) {
+					return "", 0, fmt.Errorf("invalid unicode escape sequence")
+				}
+
+				c, err := strconv.ParseUint(s[i+2:i+2+n], 16, 32)
+				if err != nil {
+					return "", 0, fmt.Errorf("invalid unicode escape sequence %q", s[i:i+2+n])
+				}
+
+				b.WriteRune(rune(c))
+				i += 2 + n
+				continue
+			default:
+				return "", 0, fmt.Errorf("invalid escape sequence %q", s[i:i+2])
+			}
+
+			i += 2
+			continue
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], string(r)+"{"):
+			// $${ and %%{ are the escaped template sequences ${ and %{.
+			b.WriteRune(r)
+			i += 2
+			continue
+		}
+
+		b.WriteRune(r)
+		i += size
+	}
+
+	return "", 0, fmt.Errorf("unterminated quoted string")
+}
@@ -0,0 +1,120 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		address string
+		want    Address
+	}{
+		{
+			`aws_instance.web`,
+			Address{Type: "aws_instance", Name: "web"},
+		},
+		{
+			`data.aws_region.current`,
+			Address{Mode: DataMode, Type: "aws_region", Name: "current"},
+		},
+		{
+			`aws_instance.web[0]`,
+			Address{Type: "aws_instance", Name: "web", Key: IntKey(0)},
+		},
+		{
+			`module.x["a"].aws_instance.y[0]`,
+			Address{Module: Module{{Name: "x", Key: StringKey("a")}}, Type: "aws_instance", Name: "y", Key: IntKey(0)},
+		},
+		{
+			`module.a.module.b[2].data.aws_ami.ubuntu["x.y"]`,
+			Address{Module: Module{{Name: "a"}, {Name: "b", Key: IntKey(2)}}, Mode: DataMode, Type: "aws_ami", Name: "ubuntu", Key: StringKey("x.y")},
+		},
+		{
+			`module.x["a\"]b"].aws_instance.y["c\\d"]`,
+			Address{Module: Module{{Name: "x", Key: StringKey(`a"]b`)}}, Type: "aws_instance", Name: "y", Key: StringKey(`c\d`)},
+		},
+		{
+			`aws_instance.y["$${a}\né"]`,
+			Address{Type: "aws_instance", Name: "y", Key: StringKey("${a}\né")},
+		},
+	}
+
+	for _, tt := range tests {
+		a, err := Parse(tt.address)
+		require.NoError(t, err, tt.address)
+		assert.Equal(t, tt.want, a, tt.address)
+	}
+
+	for _, s := range []string{"", "aws_instance", "aws_instance.", "aws_instance.web[", "aws_instance.web[a]", `aws_instance.web["a]`, "aws_instance.web[0].id", "module.x", "module.x.aws_instance"} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []string{
+		`aws_instance.web`,
+		`data.aws_region.current`,
+		`module.x["a"].aws_instance.y[0]`,
+		`module.a.module.b[2].data.aws_ami.ubuntu["x.y"]`,
+		`module.x["a\"]b"].aws_instance.y["c\\d"]`,
+		`aws_instance.y["$${a} %%{b} $c\n\t\u0007é"]`,
+	}
+
+	for _, s := range tests {
+		a, err := Parse(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, s, a.String())
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	assert.Equal(t, `aws_instance.y["\u0007"]`, Canonical(`aws_instance.y["\U00000007"]`))
+	assert.Equal(t, `aws_instance.y["$${a}"]`, Canonical(`aws_instance.y["${a}"]`))
+	assert.Equal(t, "MyBucket", Canonical("MyBucket"))
+}
+
+func TestAddressParts(t *testing.T) {
+	a, err := Parse(`module.a["k"].module.b.data.aws_ami.ubuntu[1]`)
+	require.NoError(t, err)
+
+	assert.Equal(t, `module.a["k"].module.b`, a.ModuleAddress())
+	assert.Equal(t, []string{`module.a["k"]`, `module.a["k"].module.b`}, a.ModuleAddresses())
+	assert.Equal(t, `data.aws_ami.ubuntu[1]`, a.ResourceAddress())
+	assert.Equal(t, `module.a.module.b.data.aws_ami.ubuntu`, a.ConfigAddress())
+
+	root, err := Parse(`aws_instance.web`)
+	require.NoError(t, err)
+	assert.Equal(t, "", root.ModuleAddress())
+	assert.Empty(t, root.ModuleAddresses())
+}
+
+func TestSplitModule(t *testing.T) {
+	m, rest, err := SplitModule(`module.a["x.y"].module.b.aws_instance.web[0]`)
+	require.NoError(t, err)
+	assert.Equal(t, Module{{Name: "a", Key: StringKey("x.y")}, {Name: "b"}}, m)
+	assert.Equal(t, "aws_instance.web[0]", rest)
+
+	m, rest, err = SplitModule(`module.a.module.b[1]`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"module.a", "module.a.module.b[1]"}, m.Ancestors())
+	assert.Equal(t, "", rest)
+
+	m, rest, err = SplitModule(`aws_instance.web`)
+	require.NoError(t, err)
+	assert.Empty(t, m)
+	assert.Equal(t, "aws_instance.web", rest)
+
+	_, _, err = SplitModule(`module.a["x].aws_instance.web`)
+	assert.Error(t, err)
+}
+
+func TestTrimKey(t *testing.T) {
+	assert.Equal(t, "aws_instance.web", TrimKey("aws_instance.web[0]"))
+	assert.Equal(t, `module.a["k"].aws_instance.web`, TrimKey(`module.a["k"].aws_instance.web["x"]`))
+	assert.Equal(t, "aws_instance.web", TrimKey("aws_instance.web"))
+	assert.Equal(t, "AWS::EC2::Instance", TrimKey("AWS::EC2::Instance"))
+}
@@ -2,25 +2,34 @@ package apiclient
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/httpclient"
 	"github.com/infracost/infracost/internal/version"
 )
 
 type APIClient struct {
-	endpoint  string
-	apiKey    string
-	tlsConfig *tls.Config
-	uuid      uuid.UUID
+	// ctx is used to cancel in-flight requests, if it is nil then requests can't be cancelled.
+	ctx      context.Context
+	endpoint string
+	apiKey   string
+	uuid     uuid.UUID
+	// purpose is recorded with each request in the audit log.
+	purpose string
+	// keys rotates between multiple API keys and backs off when they are rate limited. If it
+	// is nil then apiKey is used for every request.
+	keys *apiKeyPool
 }
 
 type GraphQLQuery struct {
@@ -41,7 +50,7 @@ type APIErrorResponse struct {
 	Error string `json:"error"`
 }
 
-var ErrInvalidAPIKey = errors.New("Invalid API key")
+var ErrInvalidAPIKey = clierror.New(clierror.CodeInvalidAPIKey, clierror.CategoryConfig, "Invalid API key", "")
 
 func (c *APIClient) doQueries(queries []GraphQLQuery) ([]gjson.Result, error) {
 	if len(queries) == 0 {
@@ -59,45 +68,90 @@ func (c *APIClient) doRequest(method string, path string, d interface{}) ([]byte
 		return []byte{}, errors.Wrap(err, "Error generating request body")
 	}
 
-	req, err := http.NewRequest(method, c.endpoint+path, bytes.NewBuffer(reqBody))
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for attempt := 0; ; attempt++ {
+		apiKey := c.apiKey
+		if c.keys != nil {
+			var wait time.Duration
+			apiKey, wait = c.keys.acquire(time.Now())
+			if wait > 0 {
+				log.Debugf("All API keys are rate limited, waiting %s", wait)
+			}
+			if err := sleep(ctx, wait); err != nil {
+				return []byte{}, err
+			}
+		}
+
+		resp, respBody, err := c.send(ctx, method, path, reqBody, apiKey)
+		if err != nil {
+			return []byte{}, err
+		}
+
+		// Rate limited requests are retried with the next API key, or after the time the API
+		// asked for if all keys are rate limited.
+		if resp.StatusCode == http.StatusTooManyRequests && c.keys != nil {
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if attempt >= maxRateLimitRetries+c.keys.size()-1 || retryAfter > maxRetryAfter {
+				var r APIErrorResponse
+				_ = json.Unmarshal(respBody, &r)
+				return []byte{}, newQuotaExceededError(retryAfter, c.keys.size(), r.Error)
+			}
+
+			log.Debugf("API request was rate limited, retrying after %s", retryAfter)
+			c.keys.limit(apiKey, time.Now().Add(retryAfter))
+			continue
+		}
+
+		if resp.StatusCode != 200 {
+			var r APIErrorResponse
+
+			err = json.Unmarshal(respBody, &r)
+			if err != nil {
+				return []byte{}, &APIError{fmt.Errorf(resp.Status), "Invalid API response"}
+			}
+
+			if r.Error == "Invalid API key" {
+				return []byte{}, ErrInvalidAPIKey
+			}
+			return []byte{}, &APIError{fmt.Errorf("%v %v", resp.Status, r.Error), "Received error from API"}
+		}
+
+		return respBody, nil
+	}
+}
+
+// send sends a single request to the API using the given API key and returns the response and
+// its body, which has already been read and closed.
+func (c *APIClient) send(ctx context.Context, method string, path string, reqBody []byte, apiKey string) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "Error generating request")
+		return nil, []byte{}, errors.Wrap(err, "Error generating request")
 	}
 
 	c.AddAuthHeaders(req)
+	req.Header.Set("X-Api-Key", apiKey)
 
-	// Use the DefaultTransport since this handles the HTTP/HTTPS proxy and other defaults
-	// and add the TLS config that was passed into the client
-	transport := http.DefaultTransport.(*http.Transport)
-	transport.TLSClientConfig = c.tlsConfig
+	purpose := c.purpose
+	if path == "/event" {
+		purpose = httpclient.PurposeTelemetry
+	}
 
-	client := &http.Client{Transport: transport}
-	resp, err := client.Do(req)
+	resp, err := httpclient.NewClient(purpose).Do(req)
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "Error sending API request")
+		return nil, []byte{}, errors.Wrap(err, "Error sending API request")
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return []byte{}, &APIError{err, "Invalid API response"}
-	}
-
-	if resp.StatusCode != 200 {
-		var r APIErrorResponse
-
-		err = json.Unmarshal(respBody, &r)
-		if err != nil {
-			return []byte{}, &APIError{fmt.Errorf(resp.Status), "Invalid API response"}
-		}
-
-		if r.Error == "Invalid API key" {
-			return []byte{}, ErrInvalidAPIKey
-		}
-		return []byte{}, &APIError{fmt.Errorf("%v %v", resp.Status, r.Error), "Received error from API"}
+		return nil, []byte{}, &APIError{err, "Invalid API response"}
 	}
 
-	return respBody, nil
+	return resp, respBody, nil
 }
 
 func (c *APIClient) AddDefaultHeaders(req *http.Request) {
@@ -8,6 +8,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpclient"
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/schema"
 )
@@ -27,11 +28,26 @@ type AddRunResponse struct {
 	ShareURL string `json:"shareUrl"`
 }
 
+// RunTags are used by the dashboard to group runs by organization, repository, branch
+// and pull request.
+type RunTags struct {
+	Organization string `json:"organization,omitempty"`
+	Repository   string `json:"repository,omitempty"`
+	Branch       string `json:"branch,omitempty"`
+	PullRequest  string `json:"pullRequest,omitempty"`
+}
+
+type ShareRunResponse struct {
+	ShareURL  string    `json:"shareUrl"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type runInput struct {
 	ProjectResults []projectResultInput   `json:"projectResults"`
 	Currency       string                 `json:"currency"`
 	TimeGenerated  time.Time              `json:"timeGenerated"`
 	Metadata       map[string]interface{} `json:"metadata"`
+	Tags           *RunTags               `json:"tags,omitempty"`
 }
 
 type projectResultInput struct {
@@ -50,6 +66,8 @@ func NewDashboardAPIClient(ctx *config.RunContext) *DashboardAPIClient {
 			endpoint: ctx.Config.DashboardAPIEndpoint,
 			apiKey:   ctx.Config.APIKey,
 			uuid:     ctx.UUID(),
+			purpose:  httpclient.PurposeDashboard,
+			ctx:      ctx.Context(),
 		},
 		dashboardEnabled: ctx.Config.EnableDashboard && !ctx.Config.IsSelfHosted(),
 	}
@@ -79,33 +97,40 @@ func (c *DashboardAPIClient) CreateAPIKey(name string, email string, ciInterest
 }
 
 func (c *DashboardAPIClient) AddRun(ctx *config.RunContext, projectContexts []*config.ProjectContext, out output.Root) (AddRunResponse, error) {
-	response := AddRunResponse{}
-
 	if !c.dashboardEnabled {
 		log.Debug("Skipping sending project results to your dashboard since it is not enabled. Run 'infracost configure set enable_dashboard true' to enable it.")
-		return response, nil
+		return AddRunResponse{}, nil
 	}
 
-	projectResultInputs := make([]projectResultInput, len(out.Projects))
-	for i, project := range out.Projects {
-		projectResultInputs[i] = projectResultInput{
-			ProjectName:     project.Name,
-			ProjectMetadata: project.Metadata,
-			PastBreakdown:   project.PastBreakdown,
-			Breakdown:       project.Breakdown,
-			Diff:            project.Diff,
-			Summary:         project.Summary,
-			Metadata:        projectContexts[i].ContextValues(),
-		}
+	return c.addRun(ctx, projectContexts, out, nil)
+}
+
+// UploadRun uploads the results from an Infracost JSON file to the dashboard with the
+// given tags. Unlike AddRun this doesn't check if the dashboard is enabled since the
+// user has explicitly asked for the run to be uploaded.
+func (c *DashboardAPIClient) UploadRun(ctx *config.RunContext, out output.Root, tags RunTags) (AddRunResponse, error) {
+	projectContexts := make([]*config.ProjectContext, len(out.Projects))
+	for i := range out.Projects {
+		projectContexts[i] = config.EmptyProjectContext()
 	}
 
+	response, err := c.addRun(ctx, projectContexts, out, &tags)
+	if err != nil {
+		return response, err
+	}
+
+	if response.RunID == "" {
+		return response, errors.New("Invalid response from API: no run ID returned")
+	}
+
+	return response, nil
+}
+
+func (c *DashboardAPIClient) addRun(ctx *config.RunContext, projectContexts []*config.ProjectContext, out output.Root, tags *RunTags) (AddRunResponse, error) {
+	response := AddRunResponse{}
+
 	v := map[string]interface{}{
-		"run": runInput{
-			ProjectResults: projectResultInputs,
-			Currency:       out.Currency,
-			TimeGenerated:  out.TimeGenerated,
-			Metadata:       ctx.ContextValues(),
-		},
+		"run": newRunInput(out, projectContexts, ctx.ContextValues(), tags),
 	}
 
 	q := `
@@ -132,3 +157,73 @@ func (c *DashboardAPIClient) AddRun(ctx *config.RunContext, projectContexts []*c
 	}
 	return response, nil
 }
+
+// ShareRun uploads the run and returns a share link that expires after the ttl. The run
+// isn't added to the dashboard and no metadata is sent with it, so the output should be
+// redacted before it's shared if it contains anything sensitive.
+func (c *DashboardAPIClient) ShareRun(out output.Root, ttl time.Duration) (ShareRunResponse, error) {
+	response := ShareRunResponse{}
+
+	projectContexts := make([]*config.ProjectContext, len(out.Projects))
+	for i := range out.Projects {
+		projectContexts[i] = config.EmptyProjectContext()
+	}
+
+	v := map[string]interface{}{
+		"run":              newRunInput(out, projectContexts, map[string]interface{}{}, nil),
+		"expiresInSeconds": int(ttl.Seconds()),
+	}
+
+	q := `
+	mutation($run: RunInput!, $expiresInSeconds: Int!) {
+			createShareLink(run: $run, expiresInSeconds: $expiresInSeconds) {
+				shareUrl
+				expiresAt
+			}
+		}
+	`
+	results, err := c.doQueries([]GraphQLQuery{{q, v}})
+	if err != nil {
+		return response, err
+	}
+
+	if len(results) == 0 {
+		return response, errors.New("Invalid response from API: no share link returned")
+	}
+
+	if results[0].Get("errors").Exists() {
+		return response, errors.New(results[0].Get("errors").String())
+	}
+
+	response.ShareURL = results[0].Get("data.createShareLink.shareUrl").String()
+	response.ExpiresAt = results[0].Get("data.createShareLink.expiresAt").Time()
+
+	if response.ShareURL == "" {
+		return response, errors.New("Invalid response from API: no share link returned")
+	}
+
+	return response, nil
+}
+
+func newRunInput(out output.Root, projectContexts []*config.ProjectContext, metadata map[string]interface{}, tags *RunTags) runInput {
+	projectResultInputs := make([]projectResultInput, len(out.Projects))
+	for i, project := range out.Projects {
+		projectResultInputs[i] = projectResultInput{
+			ProjectName:     project.Name,
+			ProjectMetadata: project.Metadata,
+			PastBreakdown:   project.PastBreakdown,
+			Breakdown:       project.Breakdown,
+			Diff:            project.Diff,
+			Summary:         project.Summary,
+			Metadata:        projectContexts[i].ContextValues(),
+		}
+	}
+
+	return runInput{
+		ProjectResults: projectResultInputs,
+		Currency:       out.Currency,
+		TimeGenerated:  out.TimeGenerated,
+		Metadata:       metadata,
+		Tags:           tags,
+	}
+}
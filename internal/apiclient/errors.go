@@ -7,6 +7,7 @@ import (
 
 	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/logredact"
 	"github.com/infracost/infracost/internal/ui"
 )
 
@@ -24,9 +25,14 @@ func ReportCLIError(ctx *config.RunContext, cliErr error) error {
 	}
 
 	errMsg = pathRegex.ReplaceAllString(errMsg, "REPLACED_PATH")
+	errMsg = logredact.String(errMsg)
+
+	diag := clierror.NewDiagnostic(cliErr)
 
 	d := ctx.EventEnv()
 	d["error"] = errMsg
+	d["errorCode"] = diag.Code
+	d["errorCategory"] = diag.Category
 
 	c := NewPricingAPIClient(ctx)
 	return c.AddEvent("infracost-error", d)
@@ -1,12 +1,10 @@
 package apiclient
 
 import (
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
-	"io/ioutil"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpclient"
 	"github.com/infracost/infracost/internal/schema"
 
 	log "github.com/sirupsen/logrus"
@@ -22,6 +20,32 @@ type PricingAPIClient struct {
 type PriceQueryKey struct {
 	Resource      *schema.Resource
 	CostComponent *schema.CostComponent
+	// PriceTier is set when the query is for the price of a tier of a tiered cost component.
+	PriceTier *schema.PriceTier
+}
+
+// CostComponentQueryKeys returns the keys of the price queries of a cost component, which is one
+// for each of its price tiers, or one for the cost component if it isn't tiered.
+func CostComponentQueryKeys(r *schema.Resource, c *schema.CostComponent) []PriceQueryKey {
+	if len(c.PriceTiers) == 0 {
+		return []PriceQueryKey{{Resource: r, CostComponent: c}}
+	}
+
+	keys := make([]PriceQueryKey, 0, len(c.PriceTiers))
+	for _, t := range c.PriceTiers {
+		keys = append(keys, PriceQueryKey{Resource: r, CostComponent: c, PriceTier: t})
+	}
+
+	return keys
+}
+
+// PriceFilter returns the price filter of the query.
+func (k PriceQueryKey) PriceFilter() *schema.PriceFilter {
+	if k.PriceTier != nil {
+		return k.CostComponent.TierPriceFilter(k.PriceTier)
+	}
+
+	return k.CostComponent.PriceFilter
 }
 
 type PriceQueryResult struct {
@@ -35,40 +59,14 @@ func NewPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
 		currency = "USD"
 	}
 
-	tlsConfig := tls.Config{} // nolint: gosec
-
-	if ctx.Config.TLSCACertFile != "" {
-		rootCAs, _ := x509.SystemCertPool()
-		if rootCAs == nil {
-			rootCAs = x509.NewCertPool()
-		}
-
-		caCerts, err := ioutil.ReadFile(ctx.Config.TLSCACertFile)
-		if err != nil {
-			log.Errorf("Error reading CA cert file %s: %v", ctx.Config.TLSCACertFile, err)
-		} else {
-			ok := rootCAs.AppendCertsFromPEM(caCerts)
-
-			if !ok {
-				log.Warningf("No CA certs appended, only using system certs")
-			} else {
-				log.Debugf("Loaded CA certs from %s", ctx.Config.TLSCACertFile)
-			}
-		}
-
-		tlsConfig.RootCAs = rootCAs
-	}
-
-	if ctx.Config.TLSInsecureSkipVerify != nil {
-		tlsConfig.InsecureSkipVerify = *ctx.Config.TLSInsecureSkipVerify
-	}
-
 	return &PricingAPIClient{
 		APIClient: APIClient{
-			endpoint:  ctx.Config.PricingAPIEndpoint,
-			apiKey:    ctx.Config.APIKey,
-			tlsConfig: &tlsConfig,
-			uuid:      ctx.UUID(),
+			ctx:      ctx.Context(),
+			endpoint: ctx.Config.PricingAPIEndpoint,
+			apiKey:   ctx.Config.APIKey,
+			uuid:     ctx.UUID(),
+			purpose:  httpclient.PurposePricing,
+			keys:     sharedAPIKeyPool(ctx.Config.PricingAPIEndpoint, append([]string{ctx.Config.APIKey}, ctx.Config.APIKeys...)),
 		},
 		Currency:       currency,
 		EventsDisabled: ctx.Config.EventsDisabled,
@@ -117,6 +115,7 @@ func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *sche
 			products(filter: $productFilter) {
 				prices(filter: $priceFilter) {
 					priceHash
+					effectiveDateStart
 					%s
 				}
 			}
@@ -128,22 +127,39 @@ func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *sche
 
 // Batch all the queries for this resource so we can use one GraphQL call.
 // Use PriceQueryKeys to keep track of which query maps to which sub-resource and price component.
+// Cost components without a product filter only have a custom price, so they aren't queried.
 func (c *PricingAPIClient) batchQueries(r *schema.Resource) ([]PriceQueryKey, []GraphQLQuery) {
 	keys := make([]PriceQueryKey, 0)
 	queries := make([]GraphQLQuery, 0)
 
 	for _, component := range r.CostComponents {
-		keys = append(keys, PriceQueryKey{r, component})
-		queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
+		if component.ProductFilter == nil {
+			continue
+		}
+		for _, k := range CostComponentQueryKeys(r, component) {
+			keys = append(keys, k)
+			queries = append(queries, c.buildQuery(component.ProductFilter, k.PriceFilter()))
+		}
 	}
 
 	for _, subresource := range r.FlattenedSubResources() {
 		for _, component := range subresource.CostComponents {
-			keys = append(keys, PriceQueryKey{subresource, component})
-			queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
+			if component.ProductFilter == nil {
+				continue
+			}
+			for _, k := range CostComponentQueryKeys(subresource, component) {
+				keys = append(keys, k)
+				queries = append(queries, c.buildQuery(component.ProductFilter, k.PriceFilter()))
+			}
 		}
 	}
 
+	for _, alternative := range r.Alternatives {
+		altKeys, altQueries := c.batchQueries(alternative)
+		keys = append(keys, altKeys...)
+		queries = append(queries, altQueries...)
+	}
+
 	return keys, queries
 }
 
@@ -0,0 +1,183 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/clierror"
+)
+
+var (
+	// maxRateLimitRetries is the number of times a request that was rate limited is retried
+	// before a quota exceeded error is returned.
+	maxRateLimitRetries = 3
+	// maxRetryAfter is the longest the client waits for a rate limited API key. If the API asks
+	// for a longer wait then the quota has been used up and a quota exceeded error is returned.
+	maxRetryAfter = 60 * time.Second
+	// defaultRetryAfter is used when a rate limited response doesn't have a Retry-After header.
+	defaultRetryAfter = 5 * time.Second
+)
+
+// apiKeyPool hands out API keys in round-robin order, skipping keys that have been rate limited
+// until the time the API asked to retry after. Pools are shared by all clients in the process
+// that use the same endpoint and keys, so concurrent requests back off together.
+type apiKeyPool struct {
+	mu           sync.Mutex
+	keys         []string
+	limitedUntil []time.Time
+	next         int
+}
+
+var (
+	apiKeyPoolsMu sync.Mutex
+	apiKeyPools   = map[string]*apiKeyPool{}
+)
+
+// sharedAPIKeyPool returns the pool for the endpoint and keys, creating it if needed. Empty and
+// duplicate keys are ignored.
+func sharedAPIKeyPool(endpoint string, keys []string) *apiKeyPool {
+	var unique []string
+	seen := map[string]bool{}
+	for _, k := range keys {
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, k)
+	}
+
+	if len(unique) == 0 {
+		return nil
+	}
+
+	id := endpoint + "\n" + strings.Join(unique, "\n")
+
+	apiKeyPoolsMu.Lock()
+	defer apiKeyPoolsMu.Unlock()
+
+	if p, ok := apiKeyPools[id]; ok {
+		return p
+	}
+
+	p := newAPIKeyPool(unique)
+	apiKeyPools[id] = p
+
+	return p
+}
+
+func newAPIKeyPool(keys []string) *apiKeyPool {
+	return &apiKeyPool{
+		keys:         keys,
+		limitedUntil: make([]time.Time, len(keys)),
+	}
+}
+
+// acquire returns the next key that isn't rate limited. If every key is rate limited it returns
+// the key that can be used soonest and how long to wait before using it.
+func (p *apiKeyPool) acquire(now time.Time) (string, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	soonest := -1
+	for i := 0; i < len(p.keys); i++ {
+		idx := (p.next + i) % len(p.keys)
+
+		if !p.limitedUntil[idx].After(now) {
+			p.next = (idx + 1) % len(p.keys)
+			return p.keys[idx], 0
+		}
+
+		if soonest == -1 || p.limitedUntil[idx].Before(p.limitedUntil[soonest]) {
+			soonest = idx
+		}
+	}
+
+	p.next = (soonest + 1) % len(p.keys)
+	return p.keys[soonest], p.limitedUntil[soonest].Sub(now)
+}
+
+// limit marks the key as rate limited until the given time.
+func (p *apiKeyPool) limit(key string, until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, k := range p.keys {
+		if k == key && until.After(p.limitedUntil[i]) {
+			p.limitedUntil[i] = until
+		}
+	}
+}
+
+// size returns the number of keys in the pool.
+func (p *apiKeyPool) size() int {
+	return len(p.keys)
+}
+
+// parseRetryAfter returns the duration of a Retry-After header, which is either a number of
+// seconds or an HTTP date. It returns defaultRetryAfter if the header is missing or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return defaultRetryAfter
+	}
+
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(header); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+		return 0
+	}
+
+	return defaultRetryAfter
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newQuotaExceededError returns the error for a request that was still rate limited after
+// retrying, or that the API asked to retry too far in the future.
+func newQuotaExceededError(retryAfter time.Duration, keyCount int, apiMsg string) error {
+	msg := "Cloud Pricing API quota exceeded"
+	if apiMsg != "" {
+		msg = fmt.Sprintf("%s: %s", msg, apiMsg)
+	}
+
+	hint := fmt.Sprintf("The API asked to retry after %s.", retryAfter.Round(time.Second))
+	if keyCount <= 1 {
+		hint += " Large organizations can spread requests over multiple API keys by setting INFRACOST_API_KEYS to a comma separated list of keys."
+	} else {
+		hint += fmt.Sprintf(" All %d API keys are rate limited.", keyCount)
+	}
+
+	log.Debugf("Cloud Pricing API quota exceeded, retry after %s", retryAfter)
+
+	return clierror.New(clierror.CodeAPIQuotaExceeded, clierror.CategoryNetwork, msg, hint)
+}
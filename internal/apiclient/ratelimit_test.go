@@ -0,0 +1,101 @@
+package apiclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/clierror"
+)
+
+func TestAPIKeyPoolAcquire(t *testing.T) {
+	now := time.Now()
+	p := newAPIKeyPool([]string{"a", "b", "c"})
+
+	var keys []string
+	for i := 0; i < 4; i++ {
+		k, wait := p.acquire(now)
+		assert.Zero(t, wait)
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, keys)
+
+	p.limit("b", now.Add(10*time.Second))
+	k, _ := p.acquire(now)
+	assert.Equal(t, "c", k)
+	k, _ = p.acquire(now)
+	assert.Equal(t, "a", k)
+
+	p.limit("a", now.Add(5*time.Second))
+	p.limit("c", now.Add(20*time.Second))
+	k, wait := p.acquire(now)
+	assert.Equal(t, "a", k)
+	assert.Equal(t, 5*time.Second, wait)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter("Mon, 01 Jan 2024 00:01:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Sun, 31 Dec 2023 00:00:00 GMT", now))
+	assert.Equal(t, defaultRetryAfter, parseRetryAfter("", now))
+	assert.Equal(t, defaultRetryAfter, parseRetryAfter("soon", now))
+}
+
+func TestDoRequestRotatesRateLimitedKeys(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("X-Api-Key"))
+		mu.Unlock()
+
+		if r.Header.Get("X-Api-Key") == "limited" {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error": "Too many requests"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c := &APIClient{endpoint: srv.URL, keys: newAPIKeyPool([]string{"limited", "ok"})}
+
+	_, err := c.doRequest("POST", "/graphql", nil)
+	require.NoError(t, err)
+	_, err = c.doRequest("POST", "/graphql", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"limited", "ok", "ok"}, keys)
+}
+
+func TestDoRequestQuotaExceeded(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error": "Monthly quota exceeded"}`))
+	}))
+	defer srv.Close()
+
+	c := &APIClient{endpoint: srv.URL, keys: newAPIKeyPool([]string{"key"})}
+
+	_, err := c.doRequest("POST", "/graphql", nil)
+
+	var cliErr *clierror.Error
+	require.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, clierror.CodeAPIQuotaExceeded, cliErr.Code)
+	assert.Equal(t, "Cloud Pricing API quota exceeded: Monthly quota exceeded", cliErr.Msg)
+	assert.Contains(t, cliErr.Hint, "retry after 1h0m0s")
+	assert.Contains(t, cliErr.Hint, "INFRACOST_API_KEYS")
+	assert.Equal(t, clierror.ExitCodePricingUnavailable, clierror.ExitCode(err))
+}
@@ -0,0 +1,277 @@
+// Package benchmark measures how long each phase of estimating a Terraform directory takes and how
+// much memory it uses, so slow runs can be quantified and performance regressions caught.
+package benchmark
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The phases that are measured after the directory has been parsed and evaluated, see hcl.PhaseFunc
+// for the phases before.
+const (
+	// PhaseResources is when the resources are built from the evaluated blocks.
+	PhaseResources = "resources"
+	// PhasePrice is when the prices of the resources are retrieved and their costs calculated.
+	PhasePrice = "price"
+)
+
+// sampleInterval is how often the heap is sampled to find the peak memory of each phase.
+const sampleInterval = 5 * time.Millisecond
+
+// Result is the benchmark of a Terraform directory. When it's estimated more than once, the
+// duration and allocated bytes of each phase are the median of the runs, and the peak heap is the
+// highest of the runs.
+type Result struct {
+	Path            string  `json:"path"`
+	Iterations      int     `json:"iterations"`
+	Resources       int     `json:"resources"`
+	Phases          []Phase `json:"phases"`
+	TotalDurationMs float64 `json:"totalDurationMs"`
+	PeakHeapBytes   uint64  `json:"peakHeapBytes"`
+}
+
+// Phase is the time and memory used by a phase of estimating the directory.
+type Phase struct {
+	Name           string  `json:"name"`
+	DurationMs     float64 `json:"durationMs"`
+	PeakHeapBytes  uint64  `json:"peakHeapBytes"`
+	AllocatedBytes uint64  `json:"allocatedBytes"`
+}
+
+// Run estimates the Terraform directory at path by parsing its HCL, as many times as iterations,
+// and returns how long each phase took and how much memory it used. The prices are retrieved using
+// the pricing backend of the run context, e.g. prices.MockPricingBackend to leave out the network.
+func Run(ctx *config.RunContext, path string, usageData map[string]*schema.UsageData, iterations int) (*Result, error) {
+	if iterations < 1 {
+		iterations = 1
+	}
+
+	runs := make([][]Phase, 0, iterations)
+	resources := 0
+
+	for i := 0; i < iterations; i++ {
+		phases, n, err := runOnce(ctx, path, usageData)
+		if err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, phases)
+		resources = n
+	}
+
+	return newResult(path, resources, runs), nil
+}
+
+func runOnce(ctx *config.RunContext, path string, usageData map[string]*schema.UsageData) ([]Phase, int, error) {
+	projectCtx := config.NewProjectContext(ctx, &config.Project{
+		Path:              path,
+		TerraformParseHCL: true,
+	})
+
+	// start from a clean heap so the memory of a previous run isn't counted.
+	runtime.GC()
+
+	rec := newRecorder()
+	defer rec.stop()
+
+	provider, err := terraform.NewHCLProvider(projectCtx, terraform.NewPlanJSONProvider(projectCtx, false), hcl.OptionWithPhaseFunc(rec.start))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	b, err := provider.LoadPlanJSON()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rec.start(PhaseResources)
+	projects, err := provider.Provider.LoadResourcesFromSrc(usageData, b, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rec.start(PhasePrice)
+	resources := 0
+	for _, project := range projects {
+		if err := prices.PopulatePrices(ctx, project); err != nil {
+			return nil, 0, err
+		}
+
+		schema.CalculateCosts(project)
+		resources += len(project.Resources)
+	}
+
+	return rec.stop(), resources, nil
+}
+
+func newResult(path string, resources int, runs [][]Phase) *Result {
+	r := &Result{
+		Path:       path,
+		Iterations: len(runs),
+		Resources:  resources,
+	}
+
+	for i, phase := range runs[0] {
+		durations := make([]float64, 0, len(runs))
+		allocated := make([]float64, 0, len(runs))
+		for _, run := range runs {
+			if i >= len(run) {
+				continue
+			}
+
+			durations = append(durations, run[i].DurationMs)
+			allocated = append(allocated, float64(run[i].AllocatedBytes))
+
+			if run[i].PeakHeapBytes > phase.PeakHeapBytes {
+				phase.PeakHeapBytes = run[i].PeakHeapBytes
+			}
+		}
+
+		phase.DurationMs = median(durations)
+		phase.AllocatedBytes = uint64(median(allocated))
+
+		r.Phases = append(r.Phases, phase)
+		r.TotalDurationMs += phase.DurationMs
+		if phase.PeakHeapBytes > r.PeakHeapBytes {
+			r.PeakHeapBytes = phase.PeakHeapBytes
+		}
+	}
+
+	return r
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
+
+// recorder measures the phases that are started one after the other. The heap is sampled in the
+// background while a phase runs since its peak can be higher than at either end.
+type recorder struct {
+	mu      sync.Mutex
+	phases  []Phase
+	current *phaseRecord
+
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+type phaseRecord struct {
+	name       string
+	start      time.Time
+	startAlloc uint64
+	peakHeap   uint64
+}
+
+func newRecorder() *recorder {
+	r := &recorder{
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	go r.sample()
+
+	return r
+}
+
+func (r *recorder) sample() {
+	defer close(r.stopped)
+
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			var m runtime.MemStats
+			runtime.ReadMemStats(&m)
+
+			r.mu.Lock()
+			if r.current != nil && m.HeapAlloc > r.current.peakHeap {
+				r.current.peakHeap = m.HeapAlloc
+			}
+			r.mu.Unlock()
+		}
+	}
+}
+
+// start ends the current phase, if there is one, and starts the named phase.
+func (r *recorder) start(name string) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.endCurrent(now, &m)
+	r.current = &phaseRecord{
+		name:       name,
+		start:      now,
+		startAlloc: m.TotalAlloc,
+		peakHeap:   m.HeapAlloc,
+	}
+}
+
+// stop ends the current phase and the sampling, and returns the phases that were recorded. It can
+// be called more than once.
+func (r *recorder) stop() []Phase {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	now := time.Now()
+
+	r.mu.Lock()
+	r.endCurrent(now, &m)
+	phases := r.phases
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+	default:
+		close(r.done)
+	}
+	<-r.stopped
+
+	return phases
+}
+
+func (r *recorder) endCurrent(now time.Time, m *runtime.MemStats) {
+	if r.current == nil {
+		return
+	}
+
+	peak := r.current.peakHeap
+	if m.HeapAlloc > peak {
+		peak = m.HeapAlloc
+	}
+
+	r.phases = append(r.phases, Phase{
+		Name:           r.current.name,
+		DurationMs:     float64(now.Sub(r.current.start).Microseconds()) / 1000,
+		PeakHeapBytes:  peak,
+		AllocatedBytes: m.TotalAlloc - r.current.startAlloc,
+	})
+	r.current = nil
+}
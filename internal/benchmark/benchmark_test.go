@@ -0,0 +1,119 @@
+package benchmark
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/prices"
+)
+
+// corpus are the Terraform directories in testdata that are benchmarked, with the number of
+// resources they have.
+var corpus = map[string]int{
+	"many_resources": 350,
+	"nested_modules": 80,
+	"expressions":    60,
+}
+
+func TestRunCorpus(t *testing.T) {
+	for name, resources := range corpus {
+		t.Run(name, func(t *testing.T) {
+			result, err := Run(mockRunContext(), copyCorpusDir(t, name), nil, 2)
+			require.NoError(t, err)
+
+			assert.Equal(t, 2, result.Iterations)
+			assert.Equal(t, resources, result.Resources)
+
+			names := make([]string, 0, len(result.Phases))
+			for _, p := range result.Phases {
+				names = append(names, p.Name)
+				assert.Positive(t, p.PeakHeapBytes)
+			}
+			assert.Equal(t, []string{hcl.PhaseParse, hcl.PhaseModules, hcl.PhaseEvaluate, PhaseResources, PhasePrice}, names)
+			assert.Positive(t, result.TotalDurationMs)
+		})
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	runs := [][]Phase{
+		{{Name: "parse", DurationMs: 3, PeakHeapBytes: 100, AllocatedBytes: 10}},
+		{{Name: "parse", DurationMs: 1, PeakHeapBytes: 300, AllocatedBytes: 30}},
+		{{Name: "parse", DurationMs: 2, PeakHeapBytes: 200, AllocatedBytes: 20}},
+	}
+
+	assert.Equal(t, &Result{
+		Path:            "main",
+		Iterations:      3,
+		Resources:       4,
+		Phases:          []Phase{{Name: "parse", DurationMs: 2, PeakHeapBytes: 300, AllocatedBytes: 20}},
+		TotalDurationMs: 2,
+		PeakHeapBytes:   300,
+	}, newResult("main", 4, runs))
+}
+
+// BenchmarkCorpus estimates each of the Terraform directories in testdata without the network, so
+// that performance regressions can be caught by comparing the results with benchstat.
+func BenchmarkCorpus(b *testing.B) {
+	for name := range corpus {
+		b.Run(name, func(b *testing.B) {
+			dir := copyCorpusDir(b, name)
+			ctx := mockRunContext()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, _, err := runOnce(ctx, dir, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func mockRunContext() *config.RunContext {
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingBackend = prices.MockPricingBackend
+
+	return ctx
+}
+
+// copyCorpusDir copies a directory of the corpus to a temporary directory, since parsing writes
+// the module manifest to the directory.
+func copyCorpusDir(tb testing.TB, name string) string {
+	src := filepath.Join("testdata", name)
+	dst := filepath.Join(tb.TempDir(), name)
+
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), os.ModePerm)
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(filepath.Join(dst, rel), b, os.ModePerm)
+	})
+	require.NoError(tb, err)
+
+	return dst
+}
@@ -0,0 +1,39 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+locals {
+  environments = ["dev", "staging", "prod"]
+  services     = [for i in range(20) : "service-${i}"]
+
+  deployments = flatten([
+    for env in local.environments : [
+      for service in local.services : {
+        key           = "${env}-${service}"
+        env           = env
+        service       = service
+        instance_type = env == "prod" ? "m5.xlarge" : "t3.medium"
+      }
+    ]
+  ])
+
+  deployments_by_key = { for d in local.deployments : d.key => d }
+
+  tags = merge(
+    { for env in local.environments : "env-${env}" => upper(env) },
+    { managed_by = "terraform" },
+  )
+}
+
+resource "aws_instance" "service" {
+  for_each = local.deployments_by_key
+
+  ami           = "ami-674cbc1e"
+  instance_type = each.value.instance_type
+
+  tags = merge(local.tags, {
+    Name        = each.key
+    Environment = each.value.env
+    Service     = each.value.service
+  })
+}
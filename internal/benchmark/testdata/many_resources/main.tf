@@ -0,0 +1,32 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+resource "aws_instance" "web" {
+  count = 200
+
+  ami           = "ami-674cbc1e"
+  instance_type = count.index % 2 == 0 ? "m5.large" : "t3.medium"
+
+  root_block_device {
+    volume_size = 50
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  for_each = { for i in range(100) : "data-${i}" => i }
+
+  availability_zone = "us-east-1a"
+  size              = 100 + each.value
+  type              = "gp3"
+}
+
+resource "aws_lambda_function" "worker" {
+  count = 50
+
+  function_name = "worker-${count.index}"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs12.x"
+  memory_size   = 1024
+}
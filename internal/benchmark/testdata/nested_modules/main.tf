@@ -0,0 +1,11 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+module "service" {
+  source = "./modules/service"
+  count  = 10
+
+  name           = "service-${count.index}"
+  instance_count = 5
+}
@@ -0,0 +1,16 @@
+variable "name" {
+  type = string
+}
+
+resource "aws_eip" "nat" {
+  vpc = true
+}
+
+resource "aws_nat_gateway" "nat" {
+  allocation_id = aws_eip.nat.id
+  subnet_id     = "subnet-${var.name}"
+}
+
+output "subnet_id" {
+  value = "subnet-${var.name}"
+}
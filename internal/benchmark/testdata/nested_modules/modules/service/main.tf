@@ -0,0 +1,32 @@
+variable "name" {
+  type = string
+}
+
+variable "instance_count" {
+  type = number
+}
+
+module "network" {
+  source = "../network"
+
+  name = var.name
+}
+
+resource "aws_instance" "app" {
+  count = var.instance_count
+
+  ami           = "ami-674cbc1e"
+  instance_type = "m5.large"
+  subnet_id     = module.network.subnet_id
+
+  tags = {
+    Name = "${var.name}-${count.index}"
+  }
+}
+
+resource "aws_db_instance" "db" {
+  identifier        = var.name
+  engine            = "postgres"
+  instance_class    = "db.t3.large"
+  allocated_storage = 100
+}
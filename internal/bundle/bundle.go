@@ -0,0 +1,389 @@
+// Package bundle creates and loads air-gapped bundles. A bundle is a tar.gz archive of everything a run
+// needs from the network: the prices of the projects' cost components, the remote modules they call and
+// their provider schemas. It is created with infracost bundle create on a machine with network access,
+// transferred to an offline network and extracted there with infracost bundle load.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/otiai10/copy"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/version"
+)
+
+const (
+	// manifestFile is the name of the file in the bundle that describes its contents.
+	manifestFile = "bundle.json"
+	// pricesFile is the name of the pricing snapshot in the bundle.
+	pricesFile = "prices.json"
+	// modulesDir is the directory in the bundle that each project's downloaded modules are stored in.
+	modulesDir = "modules"
+	// providerSchemasDir is the directory in the bundle that each project's provider schemas file is stored in.
+	providerSchemasDir = "provider_schemas"
+)
+
+// Manifest describes the contents of a bundle.
+type Manifest struct {
+	InfracostVersion string    `json:"infracostVersion"`
+	CreatedAt        time.Time `json:"createdAt"`
+	Currency         string    `json:"currency"`
+	Projects         []Project `json:"projects"`
+}
+
+// Project is a project whose modules and provider schemas are in the bundle.
+type Project struct {
+	// Path is the slash separated path of the project relative to the working directory the bundle was
+	// created in. Projects are matched by this path when the bundle is used.
+	Path string `json:"path"`
+	// ModulesDir is the directory in the bundle with the project's downloaded modules.
+	ModulesDir string `json:"modulesDir,omitempty"`
+	// ProviderSchemaFile is the file in the bundle with the project's provider schemas.
+	ProviderSchemaFile string `json:"providerSchemaFile,omitempty"`
+}
+
+// Create writes a bundle of the price snapshot and the modules and provider schemas of the projects to
+// filename. The projects should have been run first so that their modules are downloaded.
+func Create(filename string, snapshot *prices.PriceSnapshot, projects []*config.Project, currency string) (*Manifest, error) {
+	if currency == "" {
+		currency = "USD"
+	}
+
+	manifest := &Manifest{
+		InfracostVersion: version.Version,
+		CreatedAt:        time.Now().UTC(),
+		Currency:         currency,
+		Projects:         make([]Project, 0, len(projects)),
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating bundle: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	for i, p := range projects {
+		bp, err := addProject(tw, i, p)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Projects = append(manifest.Projects, bp)
+	}
+
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("Error marshalling pricing snapshot: %w", err)
+	}
+
+	err = addFile(tw, pricesFile, b)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err = json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("Error marshalling bundle manifest: %w", err)
+	}
+
+	err = addFile(tw, manifestFile, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("Error writing bundle: %w", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("Error writing bundle: %w", err)
+	}
+
+	return manifest, f.Close()
+}
+
+// addProject adds the downloaded modules and provider schemas file of the project to the bundle.
+func addProject(tw *tar.Writer, i int, p *config.Project) (Project, error) {
+	relPath, err := relativePath(p.Path)
+	if err != nil {
+		return Project{}, err
+	}
+
+	bp := Project{Path: relPath}
+
+	downloadDir := modules.DownloadDir(p.Path)
+	if info, err := os.Stat(downloadDir); err == nil && info.IsDir() {
+		bp.ModulesDir = path.Join(modulesDir, fmt.Sprint(i))
+
+		err = addDir(tw, downloadDir, bp.ModulesDir)
+		if err != nil {
+			return Project{}, err
+		}
+	}
+
+	if p.TerraformProviderSchemaFile != "" {
+		b, err := os.ReadFile(p.TerraformProviderSchemaFile)
+		if err != nil {
+			return Project{}, fmt.Errorf("Error reading provider schemas file: %w", err)
+		}
+
+		bp.ProviderSchemaFile = path.Join(providerSchemasDir, fmt.Sprintf("%d.json", i))
+
+		err = addFile(tw, bp.ProviderSchemaFile, b)
+		if err != nil {
+			return Project{}, err
+		}
+	}
+
+	return bp, nil
+}
+
+func addFile(tw *tar.Writer, name string, b []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(b)),
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("Error writing %s to bundle: %w", name, err)
+	}
+
+	_, err = tw.Write(b)
+	if err != nil {
+		return fmt.Errorf("Error writing %s to bundle: %w", name, err)
+	}
+
+	return nil
+}
+
+// addDir adds the regular files in dir to the bundle under name. Symlinks aren't followed since they
+// could point outside of the modules directory.
+func addDir(tw *tar.Writer, dir string, name string) error {
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("Error reading module file: %w", err)
+		}
+
+		return addFile(tw, path.Join(name, filepath.ToSlash(rel)), b)
+	})
+}
+
+// Load extracts the bundle in filename to dir and returns its manifest.
+func Load(filename string, dir string) (*Manifest, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening bundle: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading bundle %s: %w", filename, err)
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error reading bundle %s: %w", filename, err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		dest, err := extractPath(dir, hdr.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		err = extractFile(tr, dest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Manifest, nil
+}
+
+// extractPath returns the path in dir to extract the file name to, checking that it doesn't escape dir.
+func extractPath(dir string, name string) (string, error) {
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("Invalid file path in bundle: %s", name)
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(clean)), nil
+}
+
+func extractFile(r io.Reader, dest string) error {
+	err := os.MkdirAll(filepath.Dir(dest), 0700)
+	if err != nil {
+		return fmt.Errorf("Error extracting bundle: %w", err)
+	}
+
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("Error extracting bundle: %w", err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r) // nolint:gosec
+	if err != nil {
+		return fmt.Errorf("Error extracting bundle: %w", err)
+	}
+
+	return f.Close()
+}
+
+// Bundle is a bundle extracted to a directory.
+type Bundle struct {
+	Dir      string
+	Manifest *Manifest
+}
+
+// Open reads the manifest of the bundle extracted to dir.
+func Open(dir string) (*Bundle, error) {
+	b, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not an Infracost bundle, run infracost bundle load to extract one: %w", dir, err)
+	}
+
+	var manifest Manifest
+	err = json.Unmarshal(b, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing bundle manifest: %w", err)
+	}
+
+	return &Bundle{Dir: dir, Manifest: &manifest}, nil
+}
+
+// Configure sets the config to run entirely from the bundle: prices come from the bundle's pricing
+// snapshot and outbound requests are blocked by the network policy.
+func (b *Bundle) Configure(cfg *config.Config) {
+	cfg.PricingBackend = prices.SnapshotPricingBackend
+	cfg.PricingSnapshotFile = filepath.Join(b.Dir, pricesFile)
+	cfg.NetworkPolicy = "none"
+
+	if cfg.Currency == "" {
+		cfg.Currency = b.Manifest.Currency
+	}
+}
+
+// ApplyToProjects restores the bundled modules of the projects that don't have any modules downloaded
+// yet, and sets their provider schemas file if it's not already set. Projects that aren't in the bundle
+// are left as they are.
+func (b *Bundle) ApplyToProjects(projects []*config.Project) error {
+	for _, p := range projects {
+		relPath, err := relativePath(p.Path)
+		if err != nil {
+			return err
+		}
+
+		bp := b.findProject(relPath)
+		if bp == nil {
+			log.Debugf("Project %s is not in the bundle", p.Path)
+			continue
+		}
+
+		if bp.ModulesDir != "" {
+			err = b.restoreModules(*bp, p.Path)
+			if err != nil {
+				return err
+			}
+		}
+
+		if bp.ProviderSchemaFile != "" && p.TerraformProviderSchemaFile == "" {
+			p.TerraformProviderSchemaFile = filepath.Join(b.Dir, filepath.FromSlash(bp.ProviderSchemaFile))
+		}
+	}
+
+	return nil
+}
+
+func (b *Bundle) findProject(relPath string) *Project {
+	for i := range b.Manifest.Projects {
+		if b.Manifest.Projects[i].Path == relPath {
+			return &b.Manifest.Projects[i]
+		}
+	}
+
+	return nil
+}
+
+// restoreModules copies the bundled modules to the project's download directory, unless it already has
+// a module manifest. The modules are then loaded from there without downloading them.
+func (b *Bundle) restoreModules(bp Project, projectPath string) error {
+	downloadDir := modules.DownloadDir(projectPath)
+	if config.FileExists(filepath.Join(downloadDir, "manifest.json")) {
+		log.Debugf("Not restoring bundled modules for %s since it already has downloaded modules", projectPath)
+		return nil
+	}
+
+	err := copy.Copy(filepath.Join(b.Dir, filepath.FromSlash(bp.ModulesDir)), downloadDir)
+	if err != nil {
+		return fmt.Errorf("Error restoring bundled modules for %s: %w", projectPath, err)
+	}
+
+	return nil
+}
+
+// relativePath returns the slash separated path of p relative to the working directory.
+func relativePath(p string) (string, error) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(rel), nil
+}
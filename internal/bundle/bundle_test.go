@@ -0,0 +1,91 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/prices"
+)
+
+func TestCreateAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	projectPath := filepath.Join(dir, "project")
+	downloadDir := modules.DownloadDir(projectPath)
+	require.NoError(t, os.MkdirAll(filepath.Join(downloadDir, "vpc"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(downloadDir, "manifest.json"), []byte(`{"modules":[]}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(downloadDir, "vpc", "main.tf"), []byte(`resource "aws_nat_gateway" "nat" {}`), 0600))
+
+	schemaFile := filepath.Join(dir, "schemas.json")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(`{"format_version":"1.0"}`), 0600))
+
+	planProject := &config.Project{Path: filepath.Join(dir, "plan.json")}
+	hclProject := &config.Project{Path: projectPath, TerraformProviderSchemaFile: schemaFile}
+
+	bundleFile := filepath.Join(dir, "infracost-bundle.tar.gz")
+	manifest, err := Create(bundleFile, prices.NewPriceSnapshot(), []*config.Project{planProject, hclProject}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "USD", manifest.Currency)
+	require.Len(t, manifest.Projects, 2)
+	assert.Empty(t, manifest.Projects[0].ModulesDir)
+	assert.Equal(t, "modules/1", manifest.Projects[1].ModulesDir)
+	assert.Equal(t, "provider_schemas/1.json", manifest.Projects[1].ProviderSchemaFile)
+
+	bundleDir := filepath.Join(dir, "infracost-bundle")
+	loaded, err := Load(bundleFile, bundleDir)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Projects, loaded.Projects)
+
+	b, err := Open(bundleDir)
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	b.Configure(cfg)
+	assert.Equal(t, prices.SnapshotPricingBackend, cfg.PricingBackend)
+	assert.Equal(t, filepath.Join(bundleDir, "prices.json"), cfg.PricingSnapshotFile)
+	assert.Equal(t, "none", cfg.NetworkPolicy)
+	assert.Equal(t, "USD", cfg.Currency)
+
+	// the modules are restored to a project that hasn't downloaded them.
+	require.NoError(t, os.RemoveAll(downloadDir))
+
+	project := &config.Project{Path: projectPath}
+	require.NoError(t, b.ApplyToProjects([]*config.Project{project}))
+
+	content, err := os.ReadFile(filepath.Join(downloadDir, "vpc", "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, `resource "aws_nat_gateway" "nat" {}`, string(content))
+	assert.Equal(t, filepath.Join(bundleDir, "provider_schemas", "1.json"), project.TerraformProviderSchemaFile)
+}
+
+func TestLoadInvalidPath(t *testing.T) {
+	dir := t.TempDir()
+	bundleFile := filepath.Join(dir, "infracost-bundle.tar.gz")
+
+	f, err := os.Create(bundleFile)
+	require.NoError(t, err)
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, addFile(tw, "../escaped.txt", []byte("escaped")))
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+
+	_, err = Load(bundleFile, filepath.Join(dir, "infracost-bundle"))
+	assert.EqualError(t, err, "Invalid file path in bundle: ../escaped.txt")
+	assert.NoFileExists(t, filepath.Join(dir, "escaped.txt"))
+}
+
+func TestOpenNotABundle(t *testing.T) {
+	_, err := Open(t.TempDir())
+	assert.ErrorContains(t, err, "is not an Infracost bundle")
+}
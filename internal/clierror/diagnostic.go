@@ -0,0 +1,115 @@
+package clierror
+
+import (
+	"errors"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Category groups errors by who needs to act to fix them.
+type Category string
+
+const (
+	// CategoryUser is for errors caused by how the CLI was called, e.g. an invalid path.
+	CategoryUser Category = "user"
+	// CategoryConfig is for errors in the config file, environment variables or credentials.
+	CategoryConfig Category = "config"
+	// CategoryNetwork is for errors calling the Infracost APIs or remote module sources.
+	CategoryNetwork Category = "network"
+	// CategoryInternal is for unexpected errors and is used for any errors that aren't typed.
+	CategoryInternal Category = "internal"
+)
+
+// Code is a stable identifier for an error that can be matched on by scripts and CI systems.
+type Code string
+
+const (
+	CodeUnknown             Code = "unknown"
+	CodeNoTerraformFiles    Code = "no_terraform_files"
+	CodePathTypeNotDetected Code = "path_type_not_detected"
+	CodeStateJSONWithDiff   Code = "state_json_with_diff"
+	CodeTerraformNotFound   Code = "terraform_not_found"
+	CodeTerraformVersion    Code = "terraform_version"
+	CodeMissingAPIKey       Code = "missing_api_key"
+	CodeInvalidAPIKey       Code = "invalid_api_key"
+	CodeAPIRequestFailed    Code = "api_request_failed"
+	CodeAPIQuotaExceeded    Code = "api_quota_exceeded"
+	CodeParseFailed         Code = "parse_failed"
+	CodeBudgetExceeded      Code = "budget_exceeded"
+	CodePolicyFailed        Code = "policy_failed"
+	CodeWarnings            Code = "warnings"
+	CodeInvalidVariable     Code = "invalid_variable"
+	CodeSnapshotMismatch    Code = "snapshot_mismatch"
+)
+
+// Error is an error with a code, category and a hint with the suggested fix, so that
+// errors can be shown consistently in the CLI output and JSON diagnostics.
+type Error struct {
+	Code     Code
+	Category Category
+	Msg      string
+	Hint     string
+	err      error
+}
+
+// New returns an Error with the given message and hint.
+func New(code Code, category Category, msg string, hint string) *Error {
+	return &Error{
+		Code:     code,
+		Category: category,
+		Msg:      msg,
+		Hint:     hint,
+	}
+}
+
+// Wrap returns an Error that uses the message of err and can be unwrapped to err.
+func Wrap(err error, code Code, category Category, hint string) *Error {
+	return &Error{
+		Code:     code,
+		Category: category,
+		Msg:      err.Error(),
+		Hint:     hint,
+		err:      err,
+	}
+}
+
+// Error returns the message followed by the hint, separated by a blank line.
+func (e *Error) Error() string {
+	if e.Hint == "" {
+		return e.Msg
+	}
+
+	return e.Msg + "\n\n" + e.Hint
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Diagnostic is the JSON representation of an error.
+type Diagnostic struct {
+	Code     Code     `json:"code"`
+	Category Category `json:"category"`
+	Message  string   `json:"message"`
+	Hint     string   `json:"hint,omitempty"`
+}
+
+// NewDiagnostic returns the diagnostic for err. Errors that don't wrap an Error are
+// reported with the unknown code and the internal category.
+func NewDiagnostic(err error) Diagnostic {
+	var e *Error
+	if errors.As(err, &e) {
+		return Diagnostic{
+			Code:     e.Code,
+			Category: e.Category,
+			Message:  ui.StripColor(e.Msg),
+			Hint:     ui.StripColor(e.Hint),
+		}
+	}
+
+	return Diagnostic{
+		Code:     CodeUnknown,
+		Category: CategoryInternal,
+		Message:  ui.StripColor(err.Error()),
+	}
+}
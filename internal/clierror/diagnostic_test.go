@@ -0,0 +1,51 @@
+package clierror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorMessage(t *testing.T) {
+	assert.Equal(t, "Something failed", New(CodeUnknown, CategoryUser, "Something failed", "").Error())
+	assert.Equal(t, "Something failed\n\nTry this", New(CodeUnknown, CategoryUser, "Something failed", "Try this").Error())
+
+	cause := errors.New("cause")
+	err := Wrap(cause, CodeAPIRequestFailed, CategoryNetwork, "Try again")
+	assert.Equal(t, "cause\n\nTry again", err.Error())
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestNewDiagnostic(t *testing.T) {
+	err := New(CodeNoTerraformFiles, CategoryUser, "No files", "Try a different directory")
+
+	tests := []struct {
+		name string
+		err  error
+		want Diagnostic
+	}{
+		{
+			name: "typed error",
+			err:  err,
+			want: Diagnostic{Code: CodeNoTerraformFiles, Category: CategoryUser, Message: "No files", Hint: "Try a different directory"},
+		},
+		{
+			name: "wrapped typed error",
+			err:  NewSanitizedError(fmt.Errorf("parsing: %w", err), "sanitized"),
+			want: Diagnostic{Code: CodeNoTerraformFiles, Category: CategoryUser, Message: "No files", Hint: "Try a different directory"},
+		},
+		{
+			name: "untyped error",
+			err:  errors.New("boom"),
+			want: Diagnostic{Code: CodeUnknown, Category: CategoryInternal, Message: "boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewDiagnostic(tt.err))
+		})
+	}
+}
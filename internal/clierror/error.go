@@ -10,6 +10,10 @@ func (e *SanitizedError) Error() string {
 	return e.err.Error()
 }
 
+func (e *SanitizedError) Unwrap() error {
+	return e.err
+}
+
 func (e *SanitizedError) SanitizedError() string {
 	return e.sanitizedMsg
 }
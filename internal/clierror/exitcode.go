@@ -0,0 +1,81 @@
+package clierror
+
+import (
+	"errors"
+)
+
+// Exit codes returned by the CLI so that pipelines can tell failures apart without
+// parsing the output.
+const (
+	// ExitCodeOK is returned when the command succeeded.
+	ExitCodeOK = 0
+	// ExitCodeError is returned for any error that doesn't have a more specific exit code.
+	ExitCodeError = 1
+	// ExitCodeFailure is returned when a threshold or policy check failed, e.g. the run
+	// is over budget, a policy failed or an estimate doesn't match its golden file.
+	ExitCodeFailure = 2
+	// ExitCodeParse is returned when the Terraform code or plan couldn't be parsed.
+	ExitCodeParse = 3
+	// ExitCodePricingUnavailable is returned when prices couldn't be retrieved from the
+	// Cloud Pricing API.
+	ExitCodePricingUnavailable = 4
+)
+
+const (
+	// FailOnError only fails the command for errors.
+	FailOnError = "error"
+	// FailOnPolicy fails the command for errors, budget and policy failures.
+	FailOnPolicy = "policy"
+	// FailOnWarning fails the command for errors, budget and policy failures and warnings.
+	FailOnWarning = "warning"
+)
+
+// FailOnLevels returns the supported --fail-on values, from least to most strict.
+func FailOnLevels() []string {
+	return []string{FailOnError, FailOnPolicy, FailOnWarning}
+}
+
+// IsValidFailOn returns true if the level is one of the supported --fail-on values.
+func IsValidFailOn(level string) bool {
+	for _, l := range FailOnLevels() {
+		if l == level {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExitCoder is implemented by errors that set their own exit code.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode returns the exit code for the error. It uses the first error in the chain
+// that implements ExitCoder and defaults to ExitCodeError.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+
+	var e ExitCoder
+	if errors.As(err, &e) {
+		return e.ExitCode()
+	}
+
+	return ExitCodeError
+}
+
+// ExitCode returns the exit code for the error code.
+func (e *Error) ExitCode() int {
+	switch e.Code {
+	case CodeBudgetExceeded, CodePolicyFailed, CodeWarnings, CodeSnapshotMismatch:
+		return ExitCodeFailure
+	case CodeNoTerraformFiles, CodeParseFailed, CodeInvalidVariable:
+		return ExitCodeParse
+	case CodeInvalidAPIKey, CodeAPIRequestFailed, CodeAPIQuotaExceeded:
+		return ExitCodePricingUnavailable
+	}
+
+	return ExitCodeError
+}
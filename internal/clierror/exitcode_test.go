@@ -0,0 +1,48 @@
+package clierror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testExitCoder struct{}
+
+func (testExitCoder) Error() string { return "custom" }
+func (testExitCoder) ExitCode() int { return 42 }
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "no error", err: nil, want: ExitCodeOK},
+		{name: "untyped error", err: errors.New("boom"), want: ExitCodeError},
+		{name: "budget exceeded", err: New(CodeBudgetExceeded, CategoryUser, "Over budget", ""), want: ExitCodeFailure},
+		{name: "warnings", err: New(CodeWarnings, CategoryUser, "Warnings", ""), want: ExitCodeFailure},
+		{name: "snapshot mismatch", err: New(CodeSnapshotMismatch, CategoryUser, "Estimates changed", ""), want: ExitCodeFailure},
+		{name: "parse failed", err: Wrap(errors.New("bad hcl"), CodeParseFailed, CategoryUser, ""), want: ExitCodeParse},
+		{name: "wrapped no terraform files", err: fmt.Errorf("loading: %w", New(CodeNoTerraformFiles, CategoryUser, "No files", "")), want: ExitCodeParse},
+		{name: "invalid variable", err: New(CodeInvalidVariable, CategoryUser, "Invalid value", ""), want: ExitCodeParse},
+		{name: "pricing unavailable", err: Wrap(errors.New("timeout"), CodeAPIRequestFailed, CategoryNetwork, ""), want: ExitCodePricingUnavailable},
+		{name: "invalid API key", err: New(CodeInvalidAPIKey, CategoryConfig, "Invalid API key", ""), want: ExitCodePricingUnavailable},
+		{name: "other typed error", err: New(CodeMissingAPIKey, CategoryConfig, "No API key", ""), want: ExitCodeError},
+		{name: "exit coder", err: fmt.Errorf("wrapped: %w", testExitCoder{}), want: 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestIsValidFailOn(t *testing.T) {
+	assert.True(t, IsValidFailOn(FailOnError))
+	assert.True(t, IsValidFailOn(FailOnPolicy))
+	assert.True(t, IsValidFailOn(FailOnWarning))
+	assert.False(t, IsValidFailOn("info"))
+}
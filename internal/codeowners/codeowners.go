@@ -0,0 +1,163 @@
+// Package codeowners parses CODEOWNERS files, as used by GitHub, GitLab and Bitbucket, so the cost
+// of resources can be attributed to the teams that own the files they're defined in.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Locations are the paths, relative to the repo root, that a CODEOWNERS file is looked for in. The
+// first one that exists is used.
+var Locations = []string{
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Rule is a line of a CODEOWNERS file.
+type Rule struct {
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Rules []Rule
+}
+
+// Find returns the path of the CODEOWNERS file of the repo at root, or an empty string if it doesn't
+// have one.
+func Find(root string) string {
+	for _, l := range Locations {
+		p := filepath.Join(root, l)
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+	}
+
+	return ""
+}
+
+// Load parses the CODEOWNERS file at path.
+func Load(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse parses a CODEOWNERS file. Comments, blank lines and GitLab section headings are skipped, and
+// patterns without owners are kept since they remove the owners of the paths they match.
+func Parse(r io.Reader) (*File, error) {
+	file := &File{}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		// GitLab section headings, e.g. [Database] @org/dba or ^[Docs][2] @org/docs
+		if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+
+		var owners []string
+		for _, o := range fields[1:] {
+			if strings.HasPrefix(o, "#") {
+				break
+			}
+			owners = append(owners, o)
+		}
+
+		re, err := patternRegexp(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid CODEOWNERS pattern %s on line %d: %w", fields[0], line, err)
+		}
+
+		file.Rules = append(file.Rules, Rule{Pattern: fields[0], Owners: owners, re: re})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Owners returns the owners of the path, which is relative to the repo root and uses forward
+// slashes. Like GitHub, the last rule that matches the path takes precedence.
+func (f *File) Owners(path string) []string {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].re.MatchString(path) {
+			return f.Rules[i].Owners
+		}
+	}
+
+	return nil
+}
+
+// patternRegexp converts a CODEOWNERS pattern, which follows most of the gitignore rules, to a
+// regular expression that matches the paths it applies to:
+//   - patterns with a slash before the end are relative to the repo root, others match at any depth
+//   - patterns match the directories they name and everything in them, except those ending in /*
+//     which only match the files directly in the directory
+//   - * and ? match within a path segment, and ** matches across segments
+func patternRegexp(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	p := strings.TrimPrefix(pattern, "/")
+	p = strings.TrimSuffix(p, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored && !strings.HasPrefix(p, "**") {
+		b.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' {
+				i++
+				if i+1 < len(p) && p[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if !strings.HasSuffix(p, "/*") {
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
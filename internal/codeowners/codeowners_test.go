@@ -0,0 +1,60 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwners(t *testing.T) {
+	f, err := Parse(strings.NewReader(`
+# Default owners
+*                       @org/platform
+
+[Networking]
+/infra/network/         @org/network @alice
+*.tfvars                @org/config
+/infra/apps/*           @org/apps
+/infra/apps/legacy/**/db.tf @org/dba # legacy databases
+infra/shared/
+modules/                @org/modules
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path   string
+		owners []string
+	}{
+		{"main.tf", []string{"@org/platform"}},
+		{"infra/network/vpc.tf", []string{"@org/network", "@alice"}},
+		{"infra/network/subnets/private.tf", []string{"@org/network", "@alice"}},
+		{"infra/network/prod.tfvars", []string{"@org/config"}},
+		{"infra/apps/web.tf", []string{"@org/apps"}},
+		{"infra/apps/api/main.tf", []string{"@org/platform"}},
+		{"infra/apps/legacy/eu/db.tf", []string{"@org/dba"}},
+		{"infra/apps/legacy/db.tf", []string{"@org/dba"}},
+		{"infra/shared/iam.tf", nil},
+		{"infra/modules/vpc/main.tf", []string{"@org/modules"}},
+		{"/infra/network/vpc.tf", []string{"@org/network", "@alice"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.owners, f.Owners(tt.path), tt.path)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	assert.Equal(t, "", Find(root))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte("* @org/platform\n"), 0600))
+	assert.Equal(t, filepath.Join(root, "CODEOWNERS"), Find(root))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte("* @org/infra\n"), 0600))
+	assert.Equal(t, filepath.Join(root, ".github", "CODEOWNERS"), Find(root))
+}
@@ -15,6 +15,8 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
+
+	"github.com/infracost/infracost/internal/httpclient"
 )
 
 // azureReposComment represents a comment on an Azure Repos pull request. It
@@ -97,7 +99,7 @@ func newAzureReposAPIClient(ctx context.Context, token string) (*http.Client, er
 			TokenType:   tokenType,
 		},
 	)
-	httpClient := oauth2.NewClient(ctx, ts)
+	httpClient := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, httpclient.NewClient(httpclient.PurposeComment)), ts)
 
 	return httpClient, nil
 }
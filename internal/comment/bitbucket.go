@@ -14,6 +14,8 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
+
+	"github.com/infracost/infracost/internal/httpclient"
 )
 
 // Bitbucket doesn't support markdown comments
@@ -105,7 +107,7 @@ func newBitbucketAPIClient(ctx context.Context, token string) (*http.Client, err
 			TokenType:   tokenType,
 		},
 	)
-	httpClient := oauth2.NewClient(ctx, ts)
+	httpClient := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, httpclient.NewClient(httpclient.PurposeComment)), ts)
 
 	return httpClient, nil
 }
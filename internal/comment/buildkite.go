@@ -0,0 +1,75 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// BuildkiteAnnotationStyles are the styles that a Buildkite annotation can be shown with.
+var BuildkiteAnnotationStyles = []string{"info", "success", "warning", "error"}
+
+// BuildkiteAnnotator adds Infracost annotations to a Buildkite build. Buildkite
+// doesn't have comments, so the report is shown as an annotation at the top of
+// the build page instead. Annotations are added with buildkite-agent, which
+// uses the job's credentials, so no API token is needed.
+type BuildkiteAnnotator struct {
+	// AgentPath is the path to the buildkite-agent binary.
+	AgentPath string
+	// Context identifies the annotation so later runs replace or append to it
+	// rather than adding another annotation.
+	Context string
+	// Style sets how the annotation is shown, one of BuildkiteAnnotationStyles.
+	Style string
+}
+
+// NewBuildkiteAnnotator returns a BuildkiteAnnotator that uses the
+// buildkite-agent binary on the PATH.
+func NewBuildkiteAnnotator(annotationContext, style string) (*BuildkiteAnnotator, error) {
+	path, err := exec.LookPath("buildkite-agent")
+	if err != nil {
+		return nil, errors.New("buildkite-agent was not found, annotations can only be added from a Buildkite job")
+	}
+
+	if annotationContext == "" {
+		annotationContext = defaultTag
+	}
+
+	return &BuildkiteAnnotator{
+		AgentPath: path,
+		Context:   annotationContext,
+		Style:     style,
+	}, nil
+}
+
+// Annotate adds the body as the annotation of the build. If there is already an
+// annotation with the same context it is replaced, unless appendBody is true in
+// which case the body is added to the end of it.
+func (a *BuildkiteAnnotator) Annotate(ctx context.Context, body string, appendBody bool) error {
+	args := []string{"annotate", "--context", a.Context}
+	if a.Style != "" {
+		args = append(args, "--style", a.Style)
+	}
+	if appendBody {
+		args = append(args, "--append")
+	}
+
+	// The body is passed on stdin since it can be longer than the maximum length of arguments.
+	cmd := exec.CommandContext(ctx, a.AgentPath, args...)
+	cmd.Stdin = strings.NewReader(body)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.Wrapf(err, "Error adding Buildkite annotation: %s", msg)
+		}
+		return errors.Wrap(err, "Error adding Buildkite annotation")
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/httpclient"
+)
+
+// gerritMessageTag is the tag of the review messages posted by Infracost. Gerrit
+// groups messages with tags that start with autogenerated: as bot messages, so
+// they can be filtered out of the change log.
+const gerritMessageTag = "autogenerated:infracost"
+
+// GerritExtra contains any extra inputs that can be passed to the Gerrit reviewer.
+type GerritExtra struct {
+	// ServerURL is the URL of the Gerrit server, e.g. https://gerrit.example.com.
+	ServerURL string
+	// Username and Password are the HTTP credentials of the Gerrit account that
+	// posts the review.
+	Username string
+	Password string
+}
+
+// GerritReviewer posts Infracost reviews on Gerrit changes. Gerrit review
+// messages can't be updated or deleted, so unlike the other platforms each
+// run adds a new message to the change.
+type GerritReviewer struct {
+	httpClient *http.Client
+	extra      GerritExtra
+	change     string
+	revision   string
+}
+
+// NewGerritReviewer creates a new GerritReviewer for the revision of the change.
+// The change can be any identifier that Gerrit accepts, e.g. the change number
+// or Change-Id, and the revision can be a patch set number, a commit SHA or current.
+func NewGerritReviewer(change, revision string, extra GerritExtra) (*GerritReviewer, error) {
+	if extra.ServerURL == "" {
+		return nil, errors.New("Gerrit server URL is required")
+	}
+
+	if revision == "" {
+		revision = "current"
+	}
+
+	return &GerritReviewer{
+		httpClient: httpclient.NewClient(httpclient.PurposeComment),
+		extra:      extra,
+		change:     change,
+		revision:   revision,
+	}, nil
+}
+
+// Review posts the message on the revision and sets the votes on the given
+// labels, e.g. {"Code-Review": -1}. A vote of 0 removes any previous vote on
+// the label by the account.
+func (r *GerritReviewer) Review(ctx context.Context, message string, labels map[string]int) error {
+	reqData, err := json.Marshal(map[string]interface{}{
+		"message": message,
+		"labels":  labels,
+		"tag":     gerritMessageTag,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error marshaling review")
+	}
+
+	// The /a/ prefix makes Gerrit authenticate the request with the HTTP credentials.
+	u := fmt.Sprintf("%s/a/changes/%s/revisions/%s/review", strings.TrimSuffix(r.extra.ServerURL, "/"), url.PathEscape(r.change), url.PathEscape(r.revision))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewBuffer(reqData))
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(r.extra.Username, r.extra.Password)
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Error posting review")
+	}
+
+	if res.Body != nil {
+		defer res.Body.Close()
+	}
+
+	if res.StatusCode != http.StatusOK {
+		// Gerrit explains why a review was rejected in the response body, e.g. if the account
+		// isn't allowed to vote on the label.
+		resBody, _ := ioutil.ReadAll(res.Body)
+		if msg := strings.TrimSpace(string(resBody)); msg != "" {
+			return errors.Errorf("Error posting review: %s: %s", res.Status, msg)
+		}
+		return errors.Errorf("Error posting review: %s", res.Status)
+	}
+
+	return nil
+}
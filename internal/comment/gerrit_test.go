@@ -0,0 +1,57 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGerritReviewerReview(t *testing.T) {
+	var review struct {
+		Message string         `json:"message"`
+		Labels  map[string]int `json:"labels"`
+		Tag     string         `json:"tag"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/a/changes/my-project~1234/revisions/current/review", r.URL.Path)
+
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "infracost-bot", username)
+		assert.Equal(t, "secret", password)
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&review))
+		_, _ = w.Write([]byte(")]}'\n{}"))
+	}))
+	defer srv.Close()
+
+	r, err := NewGerritReviewer("my-project~1234", "", GerritExtra{ServerURL: srv.URL, Username: "infracost-bot", Password: "secret"})
+	require.NoError(t, err)
+
+	err = r.Review(context.Background(), "Monthly cost will increase by $10", map[string]int{"Code-Review": -1})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Monthly cost will increase by $10", review.Message)
+	assert.Equal(t, map[string]int{"Code-Review": -1}, review.Labels)
+	assert.Equal(t, "autogenerated:infracost", review.Tag)
+}
+
+func TestGerritReviewerReviewError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Applying label \"Code-Review\": -2 is restricted", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	r, err := NewGerritReviewer("1234", "2", GerritExtra{ServerURL: srv.URL})
+	require.NoError(t, err)
+
+	err = r.Review(context.Background(), "msg", map[string]int{"Code-Review": -2})
+	assert.EqualError(t, err, `Error posting review: 403 Forbidden: Applying label "Code-Review": -2 is restricted`)
+}
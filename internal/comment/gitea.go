@@ -0,0 +1,286 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/infracost/infracost/internal/httpclient"
+)
+
+// giteaComment represents a comment on a Gitea pull request. It implements
+// the Comment interface.
+type giteaComment struct {
+	id        int64
+	body      string
+	createdAt string
+	url       string
+}
+
+// Body returns the body of the comment
+func (c *giteaComment) Body() string {
+	return c.body
+}
+
+// Ref returns the reference to the comment. For Gitea this is the HTML URL of
+// the comment.
+func (c *giteaComment) Ref() string {
+	return c.url
+}
+
+// Less compares the comment to another comment and returns true if this
+// comment should be sorted before the other comment.
+func (c *giteaComment) Less(other Comment) bool {
+	j := other.(*giteaComment)
+
+	if c.createdAt != j.createdAt {
+		return c.createdAt < j.createdAt
+	}
+
+	return c.id < j.id
+}
+
+// IsHidden always returns false for Gitea since Gitea doesn't have a
+// feature for hiding comments.
+func (c *giteaComment) IsHidden() bool {
+	return false
+}
+
+// GiteaExtra contains any extra inputs that can be passed to the Gitea comment
+// handlers.
+type GiteaExtra struct {
+	// ServerURL is the URL of the Gitea server, e.g. https://gitea.example.com.
+	ServerURL string
+	// Token is the Gitea access token.
+	Token string
+	// Tag used to identify the Infracost comment
+	Tag string
+}
+
+// giteaAPIComment represents the API response structure of a Gitea comment.
+type giteaAPIComment struct {
+	ID        int64  `json:"id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	HTMLURL   string `json:"html_url"`
+}
+
+// newGiteaAPIClient creates a HTTP client that authenticates with the Gitea
+// access token.
+func newGiteaAPIClient(ctx context.Context, token string) *http.Client {
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{
+			AccessToken: token,
+			TokenType:   "token",
+		},
+	)
+
+	return oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, httpclient.NewClient(httpclient.PurposeComment)), ts)
+}
+
+// giteaPRHandler is a PlatformHandler for Gitea pull requests. It
+// implements the PlatformHandler interface and contains the functions
+// for finding, creating, updating, deleting comments on Gitea pull requests.
+type giteaPRHandler struct {
+	httpClient *http.Client
+	apiURL     string
+	prNumber   int
+}
+
+// NewGiteaPRHandler creates a new PlatformHandler for Gitea pull requests.
+func NewGiteaPRHandler(ctx context.Context, repo string, targetRef string, extra GiteaExtra) (*CommentHandler, error) {
+	prNumber, err := strconv.Atoi(targetRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing targetRef as pull request number")
+	}
+
+	if extra.ServerURL == "" {
+		return nil, errors.New("Gitea server URL is required")
+	}
+
+	h := &giteaPRHandler{
+		httpClient: newGiteaAPIClient(ctx, extra.Token),
+		apiURL:     fmt.Sprintf("%s/api/v1/repos/%s/", strings.TrimSuffix(extra.ServerURL, "/"), repo),
+		prNumber:   prNumber,
+	}
+
+	return NewCommentHandler(ctx, h, extra.Tag), nil
+}
+
+// CallFindMatchingComments calls the Gitea API to find the pull request
+// comments that match the given tag, which has been embedded at the beginning
+// of the comment.
+func (h *giteaPRHandler) CallFindMatchingComments(ctx context.Context, tag string) ([]Comment, error) {
+	// Pull requests are issues in Gitea, so their comments are issue comments.
+	url := fmt.Sprintf("%sissues/%d/comments", h.apiURL, h.prNumber)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return []Comment{}, errors.Wrap(err, "Error getting comments")
+	}
+
+	res, err := h.httpClient.Do(req)
+	if err != nil {
+		return []Comment{}, errors.Wrap(err, "Error getting comments")
+	}
+
+	if res.Body != nil {
+		defer res.Body.Close()
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return []Comment{}, errors.Errorf("Error getting comments: %s", res.Status)
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return []Comment{}, errors.Wrap(err, "Error reading response body")
+	}
+
+	var resData []giteaAPIComment
+	err = json.Unmarshal(resBody, &resData)
+	if err != nil {
+		return []Comment{}, errors.Wrap(err, "Error unmarshaling response body")
+	}
+
+	matchingComments := []Comment{}
+	for _, c := range resData {
+		if !strings.Contains(c.Body, markdownTag(tag)) {
+			continue
+		}
+
+		matchingComments = append(matchingComments, &giteaComment{
+			id:        c.ID,
+			body:      c.Body,
+			createdAt: c.CreatedAt,
+			url:       c.HTMLURL,
+		})
+	}
+
+	return matchingComments, nil
+}
+
+// CallCreateComment calls the Gitea API to create a new comment on the pull request.
+func (h *giteaPRHandler) CallCreateComment(ctx context.Context, body string) (Comment, error) {
+	reqData, err := json.Marshal(map[string]interface{}{
+		"body": body,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshaling comment body")
+	}
+
+	url := fmt.Sprintf("%sissues/%d/comments", h.apiURL, h.prNumber)
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(reqData))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating comment")
+	}
+
+	if res.Body != nil {
+		defer res.Body.Close()
+	}
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, errors.Errorf("Error creating comment: %s", res.Status)
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading response body")
+	}
+
+	resData := giteaAPIComment{}
+	err = json.Unmarshal(resBody, &resData)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error unmarshaling response body")
+	}
+
+	return &giteaComment{
+		id:        resData.ID,
+		body:      resData.Body,
+		createdAt: resData.CreatedAt,
+		url:       resData.HTMLURL,
+	}, nil
+}
+
+// CallUpdateComment calls the Gitea API to update the body of a comment on the pull request.
+func (h *giteaPRHandler) CallUpdateComment(ctx context.Context, comment Comment, body string) error {
+	reqData, err := json.Marshal(map[string]interface{}{
+		"body": body,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error marshaling comment body")
+	}
+
+	url := fmt.Sprintf("%sissues/comments/%d", h.apiURL, comment.(*giteaComment).id)
+
+	req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(reqData))
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := h.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Error updating comment")
+	}
+
+	if res.Body != nil {
+		defer res.Body.Close()
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("Error updating comment: %s", res.Status)
+	}
+
+	return nil
+}
+
+// CallDeleteComment calls the Gitea API to delete the pull request comment.
+func (h *giteaPRHandler) CallDeleteComment(ctx context.Context, comment Comment) error {
+	url := fmt.Sprintf("%sissues/comments/%d", h.apiURL, comment.(*giteaComment).id)
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+
+	res, err := h.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Error deleting comment")
+	}
+
+	if res.Body != nil {
+		defer res.Body.Close()
+	}
+
+	if res.StatusCode != http.StatusNoContent {
+		return errors.Errorf("Error deleting comment: %s", res.Status)
+	}
+
+	return nil
+}
+
+// CallHideComment calls the Gitea API to minimize the pull request comment.
+func (h *giteaPRHandler) CallHideComment(ctx context.Context, comment Comment) error {
+	return errors.New("Not implemented")
+}
+
+// AddMarkdownTag prepends a tag as a markdown comment to the given string.
+func (h *giteaPRHandler) AddMarkdownTag(s string, tag string) string {
+	return addMarkdownTag(s, tag)
+}
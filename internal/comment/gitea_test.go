@@ -0,0 +1,46 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGiteaPRHandlerUpdateComment(t *testing.T) {
+	var updated string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/my-org/my-repo/issues/3/comments", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token abc", r.Header.Get("Authorization"))
+		assert.Equal(t, http.MethodGet, r.Method)
+		fmt.Fprint(w, `[
+			{"id": 1, "body": "LGTM", "created_at": "2022-01-01T00:00:00Z"},
+			{"id": 2, "body": "[//]: <> (infracost-comment)\nold estimate", "created_at": "2022-01-02T00:00:00Z"}
+		]`)
+	})
+	mux.HandleFunc("/api/v1/repos/my-org/my-repo/issues/comments/2", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+
+		var reqData struct {
+			Body string `json:"body"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reqData))
+		updated = reqData.Body
+
+		fmt.Fprint(w, `{"id": 2}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	h, err := NewGiteaPRHandler(context.Background(), "my-org/my-repo", "3", GiteaExtra{ServerURL: srv.URL + "/", Token: "abc"})
+	require.NoError(t, err)
+
+	err = h.CommentWithBehavior(context.Background(), "update", "new estimate")
+	require.NoError(t, err)
+	assert.Equal(t, "[//]: <> (infracost-comment)\nnew estimate", updated)
+}
@@ -3,6 +3,7 @@ package comment
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -12,6 +13,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
+
+	"github.com/infracost/infracost/internal/httpclient"
 )
 
 // githubComment represents a comment on a GitHub pull request or commit. It
@@ -64,6 +67,13 @@ type GitHubExtra struct {
 	APIURL string
 	// Token is the GitHub API token.
 	Token string
+	// AppID is the ID of a GitHub App to authenticate as instead of using Token.
+	AppID int64
+	// AppInstallationID is the ID of the GitHub App's installation. If not set, the
+	// installation for the repo is looked up.
+	AppInstallationID int64
+	// AppPrivateKey is the PEM encoded private key of the GitHub App.
+	AppPrivateKey []byte
 	// Tag used to identify the Infracost comment
 	Tag string
 }
@@ -78,25 +88,68 @@ func splitGitHubProject(project string) (string, string, error) {
 }
 
 // newGitHubAPIClients creates a v3 GitHub client and a v4 (GraphQL) GitHub client.
-// If the apiURL is not set, the default GitHub API URL will be used.
-func newGitHubAPIClients(ctx context.Context, token string, apiURL string) (*github.Client, *githubv4.Client, error) {
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(ctx, ts)
+// If the apiURL is not set, the default GitHub API URL will be used. The clients
+// authenticate with the token, or as an installation of the GitHub App if an app ID
+// is set.
+func newGitHubAPIClients(ctx context.Context, extra GitHubExtra, owner, repo string) (*github.Client, *githubv4.Client, error) {
+	var ts oauth2.TokenSource
+	if extra.AppID != 0 {
+		appTS, err := newGitHubAppTokenSource(ctx, extra, owner, repo)
+		if err != nil {
+			return nil, nil, err
+		}
+		ts = appTS
+	} else {
+		ts = oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: extra.Token},
+		)
+	}
+	tc := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, httpclient.NewClient(httpclient.PurposeComment)), ts)
+
+	v3client, err := newGitHubV3Client(tc, extra.APIURL)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Handle default GitHub API client
-	if apiURL == "" || apiURL == "https://api.github.com" {
-		return github.NewClient(tc), githubv4.NewClient(tc), nil
+	apiURL, err := gitHubEnterpriseAPIURL(extra.APIURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if apiURL == "" {
+		return v3client, githubv4.NewClient(tc), nil
+	}
+
+	v4client := githubv4.NewEnterpriseClient(apiURL+"graphql", tc)
+
+	return v3client, v4client, nil
+}
+
+// newGitHubV3Client creates a v3 GitHub client that uses the given HTTP client.
+func newGitHubV3Client(httpClient *http.Client, apiURL string) (*github.Client, error) {
+	apiURL, err := gitHubEnterpriseAPIURL(apiURL)
+	if err != nil {
+		return nil, err
 	}
 
-	// Handle GitHub Enterprise API client
+	if apiURL == "" {
+		return github.NewClient(httpClient), nil
+	}
 
 	// GitHub Enterprise v3 client needs a base URL and upload URL
-	// So we need to parse the API URL and add the necessary parts
+	return github.NewEnterpriseClient(apiURL+"v3/", apiURL+"uploads/", httpClient)
+}
+
+// gitHubEnterpriseAPIURL returns the base URL of a GitHub Enterprise API, ending in /api/,
+// or an empty string if the apiURL is the default GitHub API URL.
+func gitHubEnterpriseAPIURL(apiURL string) (string, error) {
+	if apiURL == "" || apiURL == "https://api.github.com" {
+		return "", nil
+	}
+
 	u, err := url.Parse(apiURL)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "Error parsing API URL")
+		return "", errors.Wrap(err, "Error parsing API URL")
 	}
 
 	// Add trailing slash
@@ -109,16 +162,7 @@ func newGitHubAPIClients(ctx context.Context, token string, apiURL string) (*git
 		u.Path += "api/"
 	}
 
-	apiURL = u.String()
-
-	v3client, err := github.NewEnterpriseClient(apiURL+"v3/", apiURL+"uploads/", tc)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	v4client := githubv4.NewEnterpriseClient(apiURL+"graphql", tc)
-
-	return v3client, v4client, nil
+	return u.String(), nil
 }
 
 // githubPRHandler is a PlatformHandler for GitHub pull requests. It
@@ -144,7 +188,7 @@ func NewGitHubPRHandler(ctx context.Context, project, targetRef string, extra Gi
 		return nil, errors.Wrap(err, "Error parsing targetRef as pull request number")
 	}
 
-	v3client, v4client, err := newGitHubAPIClients(ctx, extra.Token, extra.APIURL)
+	v3client, v4client, err := newGitHubAPIClients(ctx, extra, owner, repo)
 	if err != nil {
 		return nil, err
 	}
@@ -323,7 +367,7 @@ func NewGitHubCommitHandler(ctx context.Context, project, targetRef string, extr
 		return nil, err
 	}
 
-	v3client, v4client, err := newGitHubAPIClients(ctx, extra.Token, extra.APIURL)
+	v3client, v4client, err := newGitHubAPIClients(ctx, extra, owner, repo)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,201 @@
+package comment
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/infracost/infracost/internal/httpclient"
+)
+
+var (
+	// githubAppJWTExpiry is how long the JWTs used to authenticate as a GitHub App are valid
+	// for. GitHub allows at most 10 minutes.
+	githubAppJWTExpiry = 9 * time.Minute
+	// githubAppTokenRefreshBefore is how long before an installation token expires that it is
+	// refreshed, so that it doesn't expire while a request is in flight.
+	githubAppTokenRefreshBefore = 5 * time.Minute
+)
+
+var (
+	githubAppTokenSourcesMu sync.Mutex
+	githubAppTokenSources   = map[string]*githubAppTokenSource{}
+)
+
+// githubAppTokenSource is an oauth2.TokenSource that returns installation access tokens for a
+// GitHub App. The token is cached until shortly before it expires, and sources are shared by all
+// handlers in the process that use the same app and repo, so posting comments and statuses for
+// the same repo only creates one installation token.
+type githubAppTokenSource struct {
+	mu             sync.Mutex
+	appClient      *github.Client
+	installationID int64
+	owner          string
+	repo           string
+	token          *oauth2.Token
+}
+
+// newGitHubAppTokenSource returns the token source for the GitHub App in extra. If no
+// installation ID is given, the installation for the owner/repo is looked up when the first
+// token is created.
+func newGitHubAppTokenSource(ctx context.Context, extra GitHubExtra, owner, repo string) (*githubAppTokenSource, error) {
+	key, err := parseGitHubAppPrivateKey(extra.AppPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	id := fmt.Sprintf("%s\n%d\n%d\n%s/%s", extra.APIURL, extra.AppID, extra.AppInstallationID, owner, repo)
+
+	githubAppTokenSourcesMu.Lock()
+	defer githubAppTokenSourcesMu.Unlock()
+
+	if ts, ok := githubAppTokenSources[id]; ok {
+		return ts, nil
+	}
+
+	httpClient := httpclient.NewClient(httpclient.PurposeComment)
+	httpClient.Transport = &githubAppTransport{
+		appID: extra.AppID,
+		key:   key,
+		base:  httpClient.Transport,
+	}
+
+	appClient, err := newGitHubV3Client(httpClient, extra.APIURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := &githubAppTokenSource{
+		appClient:      appClient,
+		installationID: extra.AppInstallationID,
+		owner:          owner,
+		repo:           repo,
+	}
+	githubAppTokenSources[id] = ts
+
+	return ts, nil
+}
+
+// Token returns the cached installation token, or creates a new one if it is about to expire.
+func (s *githubAppTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && time.Until(s.token.Expiry) > githubAppTokenRefreshBefore {
+		return s.token, nil
+	}
+
+	ctx := context.Background()
+
+	if s.installationID == 0 {
+		installation, _, err := s.appClient.Apps.FindRepositoryInstallation(ctx, s.owner, s.repo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error finding GitHub App installation for %s/%s", s.owner, s.repo)
+		}
+
+		s.installationID = installation.GetID()
+	}
+
+	t, _, err := s.appClient.Apps.CreateInstallationToken(ctx, s.installationID, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating GitHub App installation token")
+	}
+
+	s.token = &oauth2.Token{
+		AccessToken: t.GetToken(),
+		TokenType:   "token",
+		Expiry:      t.GetExpiresAt(),
+	}
+
+	return s.token, nil
+}
+
+// githubAppTransport authenticates requests as the GitHub App itself using a JWT signed with the
+// app's private key. This is only used to create installation tokens.
+type githubAppTransport struct {
+	appID int64
+	key   *rsa.PrivateKey
+	base  http.RoundTripper
+}
+
+func (t *githubAppTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	jwt, err := newGitHubAppJWT(t.appID, t.key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	return t.base.RoundTrip(req)
+}
+
+// newGitHubAppJWT returns a JWT for the app signed with RS256, as described in
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/generating-a-json-web-token-jwt-for-a-github-app.
+// The issued at time is backdated to allow for clock drift.
+func newGitHubAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(githubAppJWTExpiry).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	hash := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", errors.Wrap(err, "Error signing GitHub App JWT")
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// parseGitHubAppPrivateKey parses the PEM encoded private key that GitHub generates for an app,
+// which is PKCS#1, or a PKCS#8 key if it has been converted.
+func parseGitHubAppPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("Invalid GitHub App private key, expecting a PEM encoded RSA private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing GitHub App private key")
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("Invalid GitHub App private key, expecting an RSA private key")
+	}
+
+	return key, nil
+}
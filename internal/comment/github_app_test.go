@@ -0,0 +1,69 @@
+package comment
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAppTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var tokensCreated int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/my-org/my-repo/installation", func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "))
+		fmt.Fprint(w, `{"id": 42}`)
+	})
+	mux.HandleFunc("/api/v3/app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		n := atomic.AddInt32(&tokensCreated, 1)
+		fmt.Fprintf(w, `{"token": "installation-token-%d", "expires_at": "%s"}`, n, time.Now().Add(time.Hour).Format(time.RFC3339))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	extra := GitHubExtra{APIURL: srv.URL, AppID: 1, AppPrivateKey: keyPEM}
+
+	ts, err := newGitHubAppTokenSource(context.Background(), extra, "my-org", "my-repo")
+	require.NoError(t, err)
+
+	token, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "installation-token-1", token.AccessToken)
+
+	// The token is cached and the source is shared with other handlers for the same repo.
+	other, err := newGitHubAppTokenSource(context.Background(), extra, "my-org", "my-repo")
+	require.NoError(t, err)
+	assert.Same(t, ts, other)
+
+	token, err = other.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "installation-token-1", token.AccessToken)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokensCreated))
+
+	// Tokens that are about to expire are refreshed.
+	ts.token.Expiry = time.Now().Add(time.Minute)
+	token, err = ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "installation-token-2", token.AccessToken)
+}
+
+func TestParseGitHubAppPrivateKey(t *testing.T) {
+	_, err := parseGitHubAppPrivateKey([]byte("not a key"))
+	assert.EqualError(t, err, "Invalid GitHub App private key, expecting a PEM encoded RSA private key")
+}
@@ -0,0 +1,121 @@
+package comment
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/pkg/errors"
+)
+
+// maxGitHubCheckAnnotations is the number of annotations GitHub accepts in one request to
+// create or update a check run. Any more are sent in follow-up updates.
+const maxGitHubCheckAnnotations = 50
+
+// maxGitHubCheckText is the longest summary or text that GitHub accepts for a check run.
+const maxGitHubCheckText = 65535
+
+// GitHubCheckAnnotation is an annotation on a line of a file in a GitHub check run.
+type GitHubCheckAnnotation struct {
+	Path string
+	Line int
+	// Level is one of notice, warning or failure.
+	Level   string
+	Title   string
+	Message string
+}
+
+// GitHubCheckRun is a completed GitHub check run.
+type GitHubCheckRun struct {
+	Name    string
+	HeadSHA string
+	// Conclusion is one of success, failure or neutral.
+	Conclusion  string
+	Title       string
+	Summary     string
+	Text        string
+	Annotations []GitHubCheckAnnotation
+}
+
+// PublishGitHubCheckRun creates the check run on the commit and returns its URL. GitHub only lets
+// GitHub Apps create check runs, so extra should have an app ID or a token that belongs to an app,
+// such as the GITHUB_TOKEN in GitHub Actions.
+func PublishGitHubCheckRun(ctx context.Context, project string, extra GitHubExtra, run GitHubCheckRun) (string, error) {
+	owner, repo, err := splitGitHubProject(project)
+	if err != nil {
+		return "", err
+	}
+
+	v3client, _, err := newGitHubAPIClients(ctx, extra, owner, repo)
+	if err != nil {
+		return "", err
+	}
+
+	annotations := make([]*github.CheckRunAnnotation, 0, len(run.Annotations))
+	for _, a := range run.Annotations {
+		annotations = append(annotations, &github.CheckRunAnnotation{
+			Path:            github.String(a.Path),
+			StartLine:       github.Int(a.Line),
+			EndLine:         github.Int(a.Line),
+			AnnotationLevel: github.String(a.Level),
+			Title:           github.String(a.Title),
+			Message:         github.String(a.Message),
+		})
+	}
+
+	first := annotations
+	if len(first) > maxGitHubCheckAnnotations {
+		first = first[:maxGitHubCheckAnnotations]
+	}
+
+	output := &github.CheckRunOutput{
+		Title:       github.String(run.Title),
+		Summary:     github.String(truncateGitHubCheckText(run.Summary)),
+		Annotations: first,
+	}
+	if run.Text != "" {
+		output.Text = github.String(truncateGitHubCheckText(run.Text))
+	}
+
+	checkRun, _, err := v3client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+		Name:        run.Name,
+		HeadSHA:     run.HeadSHA,
+		Status:      github.String("completed"),
+		Conclusion:  github.String(run.Conclusion),
+		CompletedAt: &github.Timestamp{Time: time.Now()},
+		Output:      output,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "Error creating GitHub check run")
+	}
+
+	for i := len(first); i < len(annotations); i += maxGitHubCheckAnnotations {
+		end := i + maxGitHubCheckAnnotations
+		if end > len(annotations) {
+			end = len(annotations)
+		}
+
+		_, _, err := v3client.Checks.UpdateCheckRun(ctx, owner, repo, checkRun.GetID(), github.UpdateCheckRunOptions{
+			Name: run.Name,
+			Output: &github.CheckRunOutput{
+				Title:       output.Title,
+				Summary:     output.Summary,
+				Annotations: annotations[i:end],
+			},
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "Error adding annotations to GitHub check run")
+		}
+	}
+
+	return checkRun.GetHTMLURL(), nil
+}
+
+func truncateGitHubCheckText(s string) string {
+	if len(s) <= maxGitHubCheckText {
+		return s
+	}
+
+	suffix := "\n\n... truncated"
+	return s[:maxGitHubCheckText-len(suffix)] + suffix
+}
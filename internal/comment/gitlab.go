@@ -14,6 +14,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/shurcooL/graphql"
 	"golang.org/x/oauth2"
+
+	"github.com/infracost/infracost/internal/httpclient"
 )
 
 // gitlabComment represents a comment on a GitLab merge request or commit. It
@@ -72,7 +74,7 @@ func newGitLabAPIClients(ctx context.Context, token string, serverURL string) (*
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
-	httpClient := oauth2.NewClient(ctx, ts)
+	httpClient := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, httpclient.NewClient(httpclient.PurposeComment)), ts)
 
 	u, err := url.Parse(serverURL)
 	if err != nil {
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+)
+
+// ProjectCacheDirName is the name of the directory in a project that runs cache files in, e.g. the
+// Terraform modules that are downloaded.
+const ProjectCacheDirName = ".infracost"
+
+// ProjectCacheDir returns the directory that runs cache the files of the project at path in. It's the
+// .infracost directory in the project, unless ReadOnly is set, when it's a directory in the system
+// temp directory that is unique to the project's absolute path, so later runs can still use the cache.
+func (c *Config) ProjectCacheDir(path string) string {
+	if !c.ReadOnly {
+		return filepath.Join(path, ProjectCacheDirName)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(os.TempDir(), "infracost", "projects", hex.EncodeToString(sum[:8]))
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectCacheDir(t *testing.T) {
+	c := &Config{}
+	assert.Equal(t, filepath.Join("path", "to", "project", ".infracost"), c.ProjectCacheDir("path/to/project"))
+
+	c.ReadOnly = true
+	dir := c.ProjectCacheDir("path/to/project")
+	assert.True(t, strings.HasPrefix(dir, os.TempDir()), dir)
+	assert.Equal(t, dir, c.ProjectCacheDir("./path/to/project/"))
+	assert.NotEqual(t, dir, c.ProjectCacheDir("path/to/other"))
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// CommittedSpendMonthly is the period of a commitment whose amount is per month.
+	CommittedSpendMonthly = "monthly"
+	// CommittedSpendAnnual is the period of a commitment whose amount is per year. It's the default since
+	// most commitments, like EDPs and MACCs, are annual.
+	CommittedSpendAnnual = "annual"
+)
+
+// CommittedSpend is an organization's commitment to spend an amount with a cloud provider, e.g. an AWS
+// Enterprise Discount Program (EDP) or Microsoft Azure Consumption Commitment (MACC). The outputs show
+// how the estimated costs of the projects progress toward it.
+type CommittedSpend struct {
+	// Name is shown in the output, e.g. AWS EDP.
+	Name string `yaml:"name"`
+	// Amount is the committed spend for each period.
+	Amount float64 `yaml:"amount"`
+	// Period is how often the amount is committed to, monthly or annual.
+	Period string `yaml:"period,omitempty"`
+	// Providers limit the costs that count toward the commitment to the resources of the Terraform
+	// providers, e.g. aws or azurerm. All resources count if it isn't set.
+	Providers []string `yaml:"providers,omitempty"`
+	// Projects limit the costs that count toward the commitment to the projects whose path or name
+	// match one of the glob patterns. All projects count if it isn't set.
+	Projects []string `yaml:"projects,omitempty"`
+}
+
+// MonthlyAmount returns the amount committed to for each month.
+func (c *CommittedSpend) MonthlyAmount() decimal.Decimal {
+	amount := decimal.NewFromFloat(c.Amount)
+	if c.Period == "" || c.Period == CommittedSpendAnnual {
+		return amount.Div(decimal.NewFromInt(12))
+	}
+
+	return amount
+}
+
+func (c *CommittedSpend) validate() error {
+	if c.Name == "" {
+		return errors.New("committed spend must have a name")
+	}
+
+	if c.Amount <= 0 {
+		return errors.New("committed spend amount must be positive")
+	}
+
+	if c.Period != "" && c.Period != CommittedSpendMonthly && c.Period != CommittedSpendAnnual {
+		return fmt.Errorf("committed spend period must be %s or %s", CommittedSpendMonthly, CommittedSpendAnnual)
+	}
+
+	return nil
+}
+
+func validateCommittedSpend(commitments []*CommittedSpend) error {
+	validationError := &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+	}
+
+	names := make(map[string]bool, len(commitments))
+
+	for i, c := range commitments {
+		if c == nil {
+			c = &CommittedSpend{}
+		}
+
+		err := c.validate()
+		if err == nil && names[c.Name] {
+			err = fmt.Errorf("committed spend %s is defined more than once", c.Name)
+		}
+		names[c.Name] = true
+
+		if err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("committed spend at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
+	if validationError.isValid() {
+		return validationError
+	}
+
+	return nil
+}
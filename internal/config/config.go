@@ -10,6 +10,9 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/logredact"
+	"github.com/infracost/infracost/internal/ui"
 )
 
 // Project defines a specific terraform project config. This can be used
@@ -20,6 +23,10 @@ type Project struct {
 	// Path to the Terraform directory or JSON/plan file.
 	// A path can be repeated with different parameters, e.g. for multiple workspaces.
 	Path string `yaml:"path,omitempty" ignored:"true"`
+	// Name overrides the generated name of the project in the outputs. It's a Go template so that projects
+	// found in the path, e.g. by Terragrunt, get readable names, such as {{ .Dir | base }}-{{ .Workspace }}.
+	// See schema.ProjectNameData for the fields that can be used.
+	Name string `yaml:"name,omitempty" ignored:"true"`
 	// TerraformParseHCL will run a project by parsing hcl files the given Path rather than using a plan.json or terraform binary.
 	TerraformParseHCL bool `yaml:"hcl_only,omitempty"`
 	// TerraformVarFiles is the number of var files that are needed to run an TerraformParseHCL run
@@ -30,8 +37,20 @@ type Project struct {
 	TerraformPlanFlags string `yaml:"terraform_plan_flags,omitempty" ignored:"true"`
 	// TerraformInitFlags are flags to pass to terraform init
 	TerraformInitFlags string `yaml:"terraform_init_flags,omitempty" ignored:"true"`
-	// TerraformBinary is an optional field used to change the path to the terraform or terragrunt binary
+	// TerraformBinary is an optional field used to change the path to the terraform, tofu or terragrunt binary
 	TerraformBinary string `yaml:"terraform_binary,omitempty" envconfig:"INFRACOST_TERRAFORM_BINARY"`
+	// TerraformRegistryHost is an optional field used to change the registry that module sources without
+	// a hostname are downloaded from. This defaults to registry.opentofu.org when TerraformBinary is tofu.
+	TerraformRegistryHost string `yaml:"terraform_registry_host,omitempty" envconfig:"INFRACOST_TERRAFORM_REGISTRY_HOST"`
+	// GitSSHKeyFile is an optional path to the SSH private key used to download git::ssh:// module sources.
+	// It's used instead of the keys from ssh-agent or the SSH config, unless the source has an sshkey parameter.
+	GitSSHKeyFile string `yaml:"git_ssh_key_file,omitempty" envconfig:"INFRACOST_GIT_SSH_KEY_FILE"`
+	// GitKnownHosts is an optional path to a known_hosts file that the host keys of git::ssh:// module sources
+	// are checked against. Hosts that aren't in the file are rejected.
+	GitKnownHosts string `yaml:"git_known_hosts,omitempty" envconfig:"INFRACOST_GIT_KNOWN_HOSTS"`
+	// TerraformProviderSchemaFile is an optional path to the output of terraform providers schema -json. It's used
+	// with the bundled provider schemas to check resource attributes when parsing HCL, see hcl.ProviderSchemas.
+	TerraformProviderSchemaFile string `yaml:"terraform_provider_schema_file,omitempty" envconfig:"INFRACOST_TERRAFORM_PROVIDER_SCHEMA_FILE"`
 	// TerraformWorkspace is an optional field used to set the Terraform workspace
 	TerraformWorkspace string `yaml:"terraform_workspace,omitempty" envconfig:"INFRACOST_TERRAFORM_WORKSPACE"`
 	// TerraformCloudHost is used to override the default app.terraform.io backend host. Only applicable for
@@ -44,19 +63,66 @@ type Project struct {
 	TerragruntFlags string `envconfig:"INFRACOST_TERRAGRUNT_FLAGS"`
 	// UsageFile is the full path to usage file that specifies values for usage-based resources
 	UsageFile string `yaml:"usage_file,omitempty" ignored:"true"`
+	// TerraformEphemeralWorkspace runs terraform init and plan in a temporary copy of the project
+	// with its own plugin cache, so the project's .terraform directory and lock file aren't changed
+	// and projects with the same path can be run concurrently.
+	TerraformEphemeralWorkspace bool `yaml:"terraform_ephemeral_workspace,omitempty" envconfig:"INFRACOST_TERRAFORM_EPHEMERAL_WORKSPACE"`
+	// ProviderMirror is a network mirror URL (https://...) or filesystem mirror directory that terraform init
+	// installs all providers from, for environments that can't reach the provider registry.
+	ProviderMirror string `yaml:"provider_mirror,omitempty" envconfig:"INFRACOST_PROVIDER_MIRROR"`
+	// RollupResources nests resources that belong to another resource under it in the output, e.g.
+	// Kubernetes node groups and node pools under their cluster, so the cluster's cost includes them.
+	RollupResources bool `yaml:"rollup_resources,omitempty" envconfig:"INFRACOST_ROLLUP_RESOURCES"`
 	// TerraformUseState sets if the users wants to use the terraform state for infracost ops.
 	TerraformUseState bool              `yaml:"terraform_use_state,omitempty" ignored:"true"`
 	Env               map[string]string `yaml:"env,omitempty" ignored:"true"`
+	// Labels are free-form key/value pairs, e.g. team, cost-center or environment, that are added
+	// to the project metadata in the output and dashboard so costs can be grouped downstream.
+	Labels map[string]string `yaml:"labels,omitempty" ignored:"true"`
+	// Budget is an optional monthly budget for the project. The outputs show how much of the budget
+	// is consumed by the estimated monthly cost.
+	Budget float64 `yaml:"budget,omitempty" ignored:"true"`
+	// Lifetime is how long an ephemeral project, e.g. a preview environment, exists for, such as 72h
+	// or 7d. The outputs show the cost of the project over its lifetime as well as its monthly cost.
+	Lifetime string `yaml:"lifetime,omitempty" ignored:"true"`
+	// ProviderCredentials maps Terraform provider config keys, e.g. aws or aws.prod, to the credentials
+	// used when reading remote state and fetching usage for resources that use that provider.
+	ProviderCredentials map[string]*ProviderCredentials `yaml:"credentials,omitempty" ignored:"true"`
+	// RemoteVariables loads input variables from Azure DevOps variable groups, Google Secret Manager
+	// and Google Runtime Configurator when parsing HCL.
+	RemoteVariables *RemoteVariables `yaml:"terraform_remote_variables,omitempty" ignored:"true"`
+	// DependsOn are the other projects in the config file that this project depends on. They're run
+	// first and their outputs are passed to this project as input variables when parsing HCL.
+	DependsOn []*ProjectDependency `yaml:"depends_on,omitempty" ignored:"true"`
+}
+
+// ResourcePlugin is an external binary that maps custom resource types to cost components.
+type ResourcePlugin struct {
+	// Name identifies the plugin in log and error messages.
+	Name string `yaml:"name"`
+	// Path is the path of the plugin binary. Relative paths are relative to the config file, and
+	// names without a directory are looked up in PATH.
+	Path string `yaml:"path"`
+	// Args are extra arguments passed to the plugin binary.
+	Args []string `yaml:"args,omitempty"`
+	// ResourceTypes limits the resource types the plugin is used for. All the resource types
+	// the plugin describes are used if this is empty.
+	ResourceTypes []string `yaml:"resource_types,omitempty"`
 }
 
 type Config struct {
 	Credentials   Credentials
 	Configuration Configuration
 
-	Version         string `yaml:"version,omitempty" ignored:"true"`
-	LogLevel        string `yaml:"log_level,omitempty" envconfig:"INFRACOST_LOG_LEVEL"`
-	NoColor         bool   `yaml:"no_color,omitempty" envconfig:"INFRACOST_NO_COLOR"`
+	Version    string `yaml:"version,omitempty" ignored:"true"`
+	LogLevel   string `yaml:"log_level,omitempty" envconfig:"INFRACOST_LOG_LEVEL"`
+	NoColor    bool   `yaml:"no_color,omitempty" envconfig:"INFRACOST_NO_COLOR"`
+	NoProgress bool   `yaml:"no_progress,omitempty" envconfig:"INFRACOST_NO_PROGRESS"`
+	// NonInteractive is set when stderr is not a terminal or we're running in CI. Spinners
+	// are replaced with line-based progress messages since they can't redraw the line.
+	NonInteractive  bool   `ignored:"true"`
 	SkipUpdateCheck bool   `yaml:"skip_update_check,omitempty" envconfig:"INFRACOST_SKIP_UPDATE_CHECK"`
+	UpdateChannel   string `yaml:"update_channel,omitempty" envconfig:"INFRACOST_UPDATE_CHANNEL"`
 	Parallelism     *int   `envconfig:"INFRACOST_PARALLELISM"`
 
 	APIKey                    string `envconfig:"INFRACOST_API_KEY"`
@@ -65,18 +131,114 @@ type Config struct {
 	DashboardAPIEndpoint      string `yaml:"dashboard_api_endpoint,omitempty" envconfig:"INFRACOST_DASHBOARD_API_ENDPOINT"`
 	EnableDashboard           bool   `yaml:"enable_dashboard,omitempty" envconfig:"INFRACOST_ENABLE_DASHBOARD"`
 	DisableHCLParsing         bool   `yaml:"disable_hcl_parsing,omitempty" envconfig:"INFRACOST_DISABLE_HCL_PARSING"`
+	// DisableCISummary stops the Markdown report being added to the summary page of GitHub Actions
+	// and Azure Pipelines runs, which is done by default when they're detected.
+	DisableCISummary bool `yaml:"disable_ci_summary,omitempty" envconfig:"INFRACOST_DISABLE_CI_SUMMARY"`
+
+	// PricingBackend is the name of the backend used to fetch prices. It defaults to the GraphQL
+	// pricing API, other backends can be registered with prices.RegisterPriceFetcher.
+	PricingBackend string `yaml:"pricing_backend,omitempty" envconfig:"INFRACOST_PRICING_BACKEND"`
+	// PricingSnapshotFile is the file of recorded prices used by the snapshot pricing backend.
+	PricingSnapshotFile string `yaml:"pricing_snapshot_file,omitempty" envconfig:"INFRACOST_PRICING_SNAPSHOT_FILE"`
+	// PriceMaxAgeDays warns when the prices were retrieved from the pricing API more than this many
+	// days ago, e.g. when they're from an old pricing snapshot or bundle.
+	PriceMaxAgeDays *int `yaml:"price_max_age_days,omitempty" envconfig:"INFRACOST_PRICE_MAX_AGE_DAYS"`
+	// BundlePath is the directory of an air-gapped bundle extracted by infracost bundle load. When it's
+	// set the run uses the bundle's prices, modules and provider schemas, and makes no outbound requests.
+	BundlePath string `envconfig:"INFRACOST_BUNDLE"`
+	// APIKeys are extra API keys that requests to the Cloud Pricing API are spread over in
+	// round-robin order, for organizations that run more estimates than one key's quota allows.
+	APIKeys []string `envconfig:"INFRACOST_API_KEYS"`
+	// AuditLogPath is the path of a JSON lines file that every outbound request is recorded to.
+	AuditLogPath string `envconfig:"INFRACOST_AUDIT_LOG"`
+	// CPUProfilePath, MemProfilePath and TracePath are the files that a pprof CPU profile, a pprof
+	// heap profile and an execution trace of the run are written to, for attaching to reports of
+	// slow runs.
+	CPUProfilePath string `envconfig:"INFRACOST_CPU_PROFILE"`
+	MemProfilePath string `envconfig:"INFRACOST_MEM_PROFILE"`
+	TracePath      string `envconfig:"INFRACOST_TRACE"`
+	// NetworkPolicy limits the outbound requests the CLI makes, one of all, pricing_only or none.
+	NetworkPolicy string `envconfig:"INFRACOST_NETWORK_POLICY"`
+	// ModuleSourceAllow and ModuleSourceDeny are patterns of the remote module sources that can and can't be
+	// downloaded, and ModuleSourcePolicyMode sets if other sources fail the run or show a warning, see
+	// modules.SourcePolicy.
+	ModuleSourceAllow      []string `envconfig:"INFRACOST_MODULE_SOURCE_ALLOW"`
+	ModuleSourceDeny       []string `envconfig:"INFRACOST_MODULE_SOURCE_DENY"`
+	ModuleSourcePolicyMode string   `envconfig:"INFRACOST_MODULE_SOURCE_POLICY_MODE"`
 
 	TLSInsecureSkipVerify *bool  `envconfig:"INFRACOST_TLS_INSECURE_SKIP_VERIFY"`
 	TLSCACertFile         string `envconfig:"INFRACOST_TLS_CA_CERT_FILE"`
 
 	Currency string `envconfig:"INFRACOST_CURRENCY"`
 
-	Projects      []*Project `yaml:"projects" ignored:"true"`
-	Format        string     `yaml:"format,omitempty" ignored:"true"`
-	ShowSkipped   bool       `yaml:"show_skipped,omitempty" ignored:"true"`
-	SyncUsageFile bool       `yaml:"sync_usage_file,omitempty" ignored:"true"`
-	Fields        []string   `yaml:"fields,omitempty" ignored:"true"`
-	CompareTo     string
+	Projects    []*Project `yaml:"projects" ignored:"true"`
+	Format      string     `yaml:"format,omitempty" ignored:"true"`
+	ShowSkipped bool       `yaml:"show_skipped,omitempty" ignored:"true"`
+	// ShowAdvisories prices the alternatives of resources that have cheaper ways of doing the same
+	// thing, and shows how much they would save in the output.
+	ShowAdvisories bool `yaml:"show_advisories,omitempty" ignored:"true"`
+	// ShowConfidence shows how much the estimate of each resource and project can be trusted, based
+	// on whether they have usage-based costs or assume default usage.
+	ShowConfidence bool     `yaml:"show_confidence,omitempty" ignored:"true"`
+	SyncUsageFile  bool     `yaml:"sync_usage_file,omitempty" ignored:"true"`
+	Fields         []string `yaml:"fields,omitempty" ignored:"true"`
+	// Verbosity sets how much detail is shown by each output format, keyed by the format, e.g.
+	// {"table": "summary"}. See output.VerbosityLevels for the valid levels.
+	Verbosity map[string]string `yaml:"verbosity,omitempty" ignored:"true"`
+	// ResourcePlugins are external binaries that price resource types Infracost doesn't support, e.g.
+	// the resources of in-house Terraform providers. See the resourceplugin package for the protocol.
+	ResourcePlugins []*ResourcePlugin `yaml:"resource_plugins,omitempty" ignored:"true"`
+	// CostCenters are the rules for allocating the cost of resources to cost centers, which are shown
+	// by the cost-centers and csv output formats.
+	CostCenters *CostCenters `yaml:"cost_centers,omitempty" ignored:"true"`
+	// CommittedSpend are the organization's spend commitments with cloud providers, e.g. an AWS EDP,
+	// that the outputs show the progress of the estimated costs toward.
+	CommittedSpend []*CommittedSpend `yaml:"committed_spend,omitempty" ignored:"true"`
+	// ReportStorage is an S3, GCS or Azure Blob Storage bucket that the JSON and HTML reports are
+	// uploaded to after each run, see the reportstorage package.
+	ReportStorage *ReportStorage `yaml:"report_storage,omitempty" ignored:"true"`
+	// LogRedaction are regular expressions and keywords that are redacted from all log lines and
+	// diagnostics, e.g. error messages and crash reports, see the logredact package.
+	LogRedaction *LogRedaction `yaml:"log_redaction,omitempty" ignored:"true"`
+	// CodeOwners sets the owner of each resource from the CODEOWNERS file of the repo, by the file the
+	// resource is defined in, so costs can be grouped by owner in the comment and csv outputs.
+	CodeOwners bool `yaml:"codeowners,omitempty" envconfig:"INFRACOST_CODEOWNERS"`
+	// HeuristicPricing gives unsupported resources a rough estimate from the category that their type
+	// and attributes look like, e.g. compute or storage, instead of leaving them out of the totals.
+	HeuristicPricing bool `yaml:"heuristic_pricing,omitempty" envconfig:"INFRACOST_HEURISTIC_PRICING"`
+	// WASMPluginsOnly only allows resource plugins that are WASM modules, which are run in a sandbox,
+	// for organizations that don't allow plugin binaries to be run from config.
+	WASMPluginsOnly bool `envconfig:"INFRACOST_WASM_PLUGINS_ONLY"`
+	// HardenedParse parses Terraform directories with limits on the size and nesting of each file, and
+	// timeouts for parsing and evaluating them, skipping the files that are over them or make the parser
+	// panic. It's always used by infracost lsp, see hcl.OptionHardened.
+	HardenedParse bool `envconfig:"INFRACOST_HARDENED_PARSE"`
+	// ReadOnly stops runs from writing to the project directories, e.g. in build sandboxes where the
+	// source is mounted read-only. The files that are cached in the .infracost directory of a project,
+	// like downloaded modules, are written to the system temp directory instead, see ProjectCacheDir.
+	ReadOnly bool `envconfig:"INFRACOST_READ_ONLY"`
+	// MaxFiles, MaxFileSize and MaxModuleDepth override the limits on the Terraform directories
+	// that are parsed as HCL, see hcl.DefaultDirectoryLimits. Zero turns a limit off.
+	MaxFiles       *int   `envconfig:"INFRACOST_MAX_FILES"`
+	MaxFileSize    *int64 `envconfig:"INFRACOST_MAX_FILE_SIZE"`
+	MaxModuleDepth *int   `envconfig:"INFRACOST_MAX_MODULE_DEPTH"`
+	CompareTo      string
+	// TraceResource is the address of a resource to print the attributes, usage keys and price
+	// filters used to build its cost components for.
+	TraceResource string `yaml:"trace_resource,omitempty" ignored:"true"`
+	// AttributeOverrides are what-if changes to the attributes of resources that are made before
+	// they're priced, e.g. aws_instance.*.instance_type=t3.large. See terraform.ParseAttributeOverride.
+	AttributeOverrides []string `ignored:"true"`
+	// FailOn sets which failures exit with a non-zero code: error, policy or warning.
+	FailOn string `ignored:"true"`
+	// EvalReportPath is the path to write the report of HCL attributes that couldn't be evaluated to.
+	EvalReportPath string `ignored:"true"`
+	// SkipPricing loads the resources of the projects without fetching their prices, for commands that
+	// only count them like infracost inventory.
+	SkipPricing bool `ignored:"true"`
+	// Destroy estimates the projects as if all of their resources were destroyed, so the output shows
+	// the monthly savings from destroying them.
+	Destroy bool `ignored:"true"`
 
 	NoCache bool `yaml:"fields,omitempty" ignored:"true"`
 
@@ -121,6 +283,14 @@ func (c *Config) LoadFromConfigFile(path string) error {
 	}
 
 	c.Projects = cfgFile.Projects
+	c.Verbosity = cfgFile.Verbosity
+	c.ResourcePlugins = cfgFile.ResourcePlugins
+	c.CostCenters = cfgFile.CostCenters
+	c.CommittedSpend = cfgFile.CommittedSpend
+	c.ReportStorage = cfgFile.ReportStorage
+	c.LogRedaction = cfgFile.LogRedaction
+	c.CodeOwners = cfgFile.CodeOwners
+	c.HeuristicPricing = cfgFile.HeuristicPricing
 
 	// Reload the environment to overwrite any of the config file configs
 	err = c.LoadFromEnv()
@@ -137,6 +307,8 @@ func (c *Config) LoadFromEnv() error {
 		return err
 	}
 
+	c.detectTerminal()
+
 	err = c.ConfigureLogger()
 	if err != nil {
 		return err
@@ -171,8 +343,34 @@ func (c *Config) loadEnvVars() error {
 	return nil
 }
 
+// isTerminal is used to check if the output is a terminal, it is a var so that tests
+// can override it.
+var isTerminal = ui.IsTerminal
+
+// detectTerminal disables colors and spinners when the output isn't going to a terminal
+// or we're running in CI. The NO_COLOR env var is also respected, see https://no-color.org.
+func (c *Config) detectTerminal() {
+	isCI := ciPlatform() != ""
+
+	if os.Getenv("NO_COLOR") != "" || isCI || !isTerminal(os.Stdout) {
+		c.NoColor = true
+	}
+
+	c.NonInteractive = isCI || !isTerminal(os.Stderr)
+}
+
 func (c *Config) ConfigureLogger() error {
-	logrus.SetFormatter(&logrus.TextFormatter{
+	var redaction LogRedaction
+	if c.LogRedaction != nil {
+		redaction = *c.LogRedaction
+	}
+
+	err := logredact.Configure(redaction.Patterns, redaction.Keywords)
+	if err != nil {
+		return err
+	}
+
+	logrus.SetFormatter(&logredact.Formatter{Formatter: &logrus.TextFormatter{
 		FullTimestamp:    true,
 		DisableTimestamp: c.LogDisableTimestamps,
 		DisableColors:    true,
@@ -185,7 +383,7 @@ func (c *Config) ConfigureLogger() error {
 				}
 			}
 		},
-	})
+	}})
 
 	if c.LogLevel == "" {
 		logrus.SetOutput(io.Discard)
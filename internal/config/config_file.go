@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
@@ -10,6 +11,8 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/mod/semver"
 	"gopkg.in/yaml.v2"
+
+	"github.com/infracost/infracost/internal/schema"
 )
 
 const (
@@ -54,25 +57,25 @@ func (y *YamlError) isValid() bool {
 // YamlError.Error supports multiple nesting and can construct heavily indented output if needed.
 // e.g.
 //
-// 		&YamlError{
-//			base: "top message",
-//			errors: []error{
-//				errors.New("top error 1"),
-//				&YamlError{
-//					base: "child message",
-//					errors: []error{
-//						errors.New("child error 1"),
-//					},
+//	&YamlError{
+//		base: "top message",
+//		errors: []error{
+//			errors.New("top error 1"),
+//			&YamlError{
+//				base: "child message",
+//				errors: []error{
+//					errors.New("child error 1"),
 //				},
 //			},
-//		}
+//		},
+//	}
 //
 // would output a string like so:
 //
-//		top message:
-//			top error 1
-//			child message:
-//				child error 1
+//	top message:
+//		top error 1
+//		child message:
+//			child error 1
 //
 // This can be useful for ui error messages where you need to highlight issues
 // with specific fields/entries.
@@ -111,6 +114,22 @@ func (y *YamlError) Error() string {
 type fileSpec struct {
 	Version  string     `yaml:"version"`
 	Projects []*Project `yaml:"projects" ignored:"true"`
+	// Verbosity is the verbosity level of each output format, keyed by the format.
+	Verbosity map[string]string `yaml:"verbosity,omitempty"`
+	// ResourcePlugins are the external binaries used to price custom resource types.
+	ResourcePlugins []*ResourcePlugin `yaml:"resource_plugins,omitempty"`
+	// CostCenters are the rules for allocating the cost of resources to cost centers.
+	CostCenters *CostCenters `yaml:"cost_centers,omitempty"`
+	// CommittedSpend are the organization's spend commitments with cloud providers.
+	CommittedSpend []*CommittedSpend `yaml:"committed_spend,omitempty"`
+	// ReportStorage is the bucket that reports are uploaded to.
+	ReportStorage *ReportStorage `yaml:"report_storage,omitempty"`
+	// LogRedaction are the values redacted from log lines and diagnostics.
+	LogRedaction *LogRedaction `yaml:"log_redaction,omitempty"`
+	// CodeOwners attributes resources to their owners in the repo's CODEOWNERS file.
+	CodeOwners bool `yaml:"codeowners,omitempty"`
+	// HeuristicPricing gives unsupported resources a rough estimate.
+	HeuristicPricing bool `yaml:"heuristic_pricing,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.v2.Unmarshaller interface. Marshalls the
@@ -175,6 +194,18 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			projectError.add(fmt.Errorf("%s is not a valid project configuration option", k))
 		}
 
+		if v, ok := fields["lifetime"]; ok {
+			if _, err := schema.ParseLifetime(fmt.Sprint(v)); err != nil {
+				projectError.add(err)
+			}
+		}
+
+		if v, ok := fields["name"]; ok {
+			if _, err := schema.ParseProjectNameTemplate(fmt.Sprint(v)); err != nil {
+				projectError.add(fmt.Errorf("name is not a valid template: %s", err))
+			}
+		}
+
 		if projectError.isValid() {
 			validationError.add(projectError)
 		}
@@ -203,6 +234,14 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	f.Version = c.Version
 	f.Projects = c.Projects
+	f.Verbosity = c.Verbosity
+	f.ResourcePlugins = c.ResourcePlugins
+	f.CostCenters = c.CostCenters
+	f.CommittedSpend = c.CommittedSpend
+	f.ReportStorage = c.ReportStorage
+	f.LogRedaction = c.LogRedaction
+	f.CodeOwners = c.CodeOwners
+	f.HeuristicPricing = c.HeuristicPricing
 	return nil
 }
 
@@ -235,9 +274,71 @@ func loadConfigFile(path string) (fileSpec, error) {
 		return cfgFile, fmt.Errorf("%w: %s", ErrorInvalidConfigFile, err)
 	}
 
+	err = resolveResourcePlugins(cfgFile.ResourcePlugins, filepath.Dir(path))
+	if err != nil {
+		return cfgFile, err
+	}
+
+	err = validateCostCenters(cfgFile.CostCenters)
+	if err != nil {
+		return cfgFile, err
+	}
+
+	err = validateCommittedSpend(cfgFile.CommittedSpend)
+	if err != nil {
+		return cfgFile, err
+	}
+
+	err = validateLogRedaction(cfgFile.LogRedaction)
+	if err != nil {
+		return cfgFile, err
+	}
+
+	err = validateReportStorage(cfgFile.ReportStorage)
+	if err != nil {
+		return cfgFile, err
+	}
+
+	err = validateProjectDependencies(cfgFile.Projects)
+	if err != nil {
+		return cfgFile, err
+	}
+
 	return cfgFile, nil
 }
 
+// resolveResourcePlugins checks every resource plugin has a path and makes the relative paths
+// relative to the config file directory. Paths without a directory are looked up in PATH.
+func resolveResourcePlugins(plugins []*ResourcePlugin, dir string) error {
+	validationError := &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+	}
+
+	for i, p := range plugins {
+		if p == nil || p.Path == "" {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("resource plugin at index %d was invalid", i),
+				errors: []error{errors.New("resource plugin must have a valid path definition")},
+			})
+			continue
+		}
+
+		if strings.ContainsRune(p.Path, filepath.Separator) && !filepath.IsAbs(p.Path) {
+			p.Path = filepath.Join(dir, p.Path)
+		}
+
+		if p.Name == "" {
+			p.Name = filepath.Base(p.Path)
+		}
+	}
+
+	if validationError.isValid() {
+		return validationError
+	}
+
+	return nil
+}
+
 func checkVersion(v string) bool {
 	if !strings.HasPrefix(v, "v") {
 		v = "v" + v
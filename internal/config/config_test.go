@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,6 +51,61 @@ projects:
 				},
 			},
 		},
+		{
+			name: "should parse project provider credentials",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+    credentials:
+      aws:
+        aws_profile: dev
+      aws.prod:
+        aws_profile: prod
+      google:
+        gcp_credentials_file: sa.json
+`),
+			expected: []*Project{
+				{
+					Path: "path/to/my_terraform",
+					ProviderCredentials: map[string]*ProviderCredentials{
+						"aws":      {AWSProfile: "dev"},
+						"aws.prod": {AWSProfile: "prod"},
+						"google":   {GCPCredentialsFile: "sa.json"},
+					},
+				},
+			},
+		},
+		{
+			name: "should parse project lifetime",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/preview
+    lifetime: 72h
+`),
+			expected: []*Project{
+				{
+					Path:     "path/to/preview",
+					Lifetime: "72h",
+				},
+			},
+		},
+		{
+			name: "should parse project name template",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+    name: "{{ .Dir | base }}-{{ .Workspace }}"
+`),
+			expected: []*Project{
+				{
+					Path: "path/to/my_terraform",
+					Name: "{{ .Dir | base }}-{{ .Workspace }}",
+				},
+			},
+		},
 		{
 			name: "should return error if no projects given",
 			contents: []byte(`version: 0.1
@@ -86,6 +143,46 @@ projects:
 				},
 			},
 		},
+		{
+			name: "should error invalid project lifetime given",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/preview
+    lifetime: 3 days
+`),
+			error: &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{
+					&YamlError{
+						base: "project config defined for path: [path/to/preview] is invalid",
+						errors: []error{
+							errors.New(`lifetime "3 days" is not a valid duration, e.g. 72h or 7d`),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "should error invalid project name template given",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+    name: "{{ .Dir | base "
+`),
+			error: &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{
+					&YamlError{
+						base: "project config defined for path: [path/to/my_terraform] is invalid",
+						errors: []error{
+							errors.New("name is not a valid template: template: name:1: unclosed action"),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "should error invalid version given",
 			contents: []byte(`version: 81923.1
@@ -116,3 +213,423 @@ projects:
 		})
 	}
 }
+
+func TestConfigLoadVerbosityFromConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+verbosity:
+  table: summary
+  diff: quiet
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{"table": "summary", "diff": "quiet"}, c.Verbosity)
+}
+
+func TestConfigLoadResourcePluginsFromConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+resource_plugins:
+  - name: acme
+    path: ./plugins/acme-infracost
+    args: ["--region", "eu"]
+    resource_types: [acme_database]
+  - path: internal-pricer
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+
+	require.Equal(t, []*ResourcePlugin{
+		{
+			Name:          "acme",
+			Path:          filepath.Join(dir, "plugins", "acme-infracost"),
+			Args:          []string{"--region", "eu"},
+			ResourceTypes: []string{"acme_database"},
+		},
+		{
+			Name: "internal-pricer",
+			Path: "internal-pricer",
+		},
+	}, c.ResourcePlugins)
+}
+
+func TestConfigLoadResourcePluginWithoutPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+resource_plugins:
+  - name: acme
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "resource plugin must have a valid path definition")
+}
+
+func TestConfigLoadCostCentersFromConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+cost_centers:
+  tag: team
+  splits:
+    - resources: [aws_db_instance.shared]
+      percentages:
+        team-a: 60
+        team-b: 40
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+
+	require.Equal(t, &CostCenters{
+		Tag: "team",
+		Splits: []*CostCenterSplit{
+			{
+				Resources:   []string{"aws_db_instance.shared"},
+				Percentages: map[string]float64{"team-a": 60, "team-b": 40},
+			},
+		},
+	}, c.CostCenters)
+}
+
+func TestConfigLoadInvalidCostCenterSplits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+cost_centers:
+  splits:
+    - resources: [aws_db_instance.shared]
+      percentages:
+        team-a: 60
+        team-b: 30
+    - percentages:
+        team-a: 100
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Equal(t, &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+		errors: []error{
+			&YamlError{
+				base:   "cost center split at index 0 was invalid",
+				errors: []error{errors.New("percentages must add up to 100, got 90")},
+			},
+			&YamlError{
+				base:   "cost center split at index 1 was invalid",
+				errors: []error{errors.New("split must match resources or tags")},
+			},
+		},
+	}, err)
+}
+
+func TestConfigLoadCommittedSpendFromConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+committed_spend:
+  - name: AWS EDP
+    amount: 1200000
+    providers: [aws]
+  - name: Platform MACC
+    amount: 50000
+    period: monthly
+    projects: [platform/*]
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+
+	require.Equal(t, []*CommittedSpend{
+		{Name: "AWS EDP", Amount: 1200000, Providers: []string{"aws"}},
+		{Name: "Platform MACC", Amount: 50000, Period: "monthly", Projects: []string{"platform/*"}},
+	}, c.CommittedSpend)
+	require.Equal(t, "100000", c.CommittedSpend[0].MonthlyAmount().String())
+	require.Equal(t, "50000", c.CommittedSpend[1].MonthlyAmount().String())
+}
+
+func TestConfigLoadInvalidCommittedSpend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+committed_spend:
+  - name: AWS EDP
+    amount: 1200000
+  - name: AWS EDP
+    amount: 100
+  - name: MACC
+    amount: 0
+  - name: CUD
+    amount: 100
+    period: weekly
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Equal(t, &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+		errors: []error{
+			&YamlError{
+				base:   "committed spend at index 1 was invalid",
+				errors: []error{errors.New("committed spend AWS EDP is defined more than once")},
+			},
+			&YamlError{
+				base:   "committed spend at index 2 was invalid",
+				errors: []error{errors.New("committed spend amount must be positive")},
+			},
+			&YamlError{
+				base:   "committed spend at index 3 was invalid",
+				errors: []error{errors.New("committed spend period must be monthly or annual")},
+			},
+		},
+	}, err)
+}
+
+func TestConfigLoadReportStorageFromConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+report_storage:
+  provider: s3
+  bucket: infracost-reports
+  endpoint: http://localhost:9000
+  key: "{{ .Branch }}/{{ .Commit }}.{{ .Format }}"
+  formats: [json, html]
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+
+	require.Equal(t, &ReportStorage{
+		Provider: ReportStorageS3,
+		Bucket:   "infracost-reports",
+		Endpoint: "http://localhost:9000",
+		Key:      "{{ .Branch }}/{{ .Commit }}.{{ .Format }}",
+		Formats:  []string{"json", "html"},
+	}, c.ReportStorage)
+}
+
+func TestConfigLoadInvalidReportStorage(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		err  string
+	}{
+		{
+			name: "missing provider",
+			yaml: "bucket: reports",
+			err:  "report storage must have a provider",
+		},
+		{
+			name: "unknown provider",
+			yaml: "provider: ftp\n  bucket: reports",
+			err:  "report storage provider must be s3, gcs or azure",
+		},
+		{
+			name: "missing bucket",
+			yaml: "provider: gcs",
+			err:  "report storage must have a bucket",
+		},
+		{
+			name: "azure without account",
+			yaml: "provider: azure\n  bucket: reports",
+			err:  "report storage account or endpoint must be set for azure",
+		},
+		{
+			name: "unsupported format",
+			yaml: "provider: s3\n  bucket: reports\n  formats: [table]",
+			err:  "report storage format table is not supported, must be json or html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "infracost.yml")
+			err := os.WriteFile(path, []byte("version: 0.1\n\nreport_storage:\n  "+tt.yaml+"\n\nprojects:\n  - path: path/to/my_terraform\n"), os.ModePerm)
+			require.NoError(t, err)
+
+			c := Config{}
+			err = c.LoadFromConfigFile(path)
+			require.Equal(t, &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{
+					&YamlError{
+						base:   "report storage was invalid",
+						errors: []error{errors.New(tt.err)},
+					},
+				},
+			}, err)
+		})
+	}
+}
+
+func TestConfigLoadInvalidLogRedaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+log_redaction:
+  patterns:
+    - acme-[a-z]+\.internal
+    - "secret-("
+  keywords:
+    - db_root_password
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.EqualError(t, err, "config file is invalid, see https://infracost.io/config-file for valid options:\n\tlog redaction pattern at index 1 was invalid:\n\t\terror parsing regexp: missing closing ): `secret-(`")
+}
+
+// unsetTerminalEnv removes the env vars that detectTerminal reads so the tests behave
+// the same when they are run in CI. They are restored when the test finishes.
+func unsetTerminalEnv(t *testing.T) {
+	t.Helper()
+
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+
+		unset := k == "CI" || k == "NO_COLOR"
+		if _, ok := ciMap.vars[k]; ok {
+			unset = true
+		}
+		for prefix := range ciMap.prefixes {
+			if strings.HasPrefix(k, prefix) {
+				unset = true
+			}
+		}
+
+		if unset {
+			t.Setenv(k, v)
+			require.NoError(t, os.Unsetenv(k))
+		}
+	}
+}
+
+func TestConfigDetectTerminal(t *testing.T) {
+	tests := []struct {
+		name                   string
+		env                    map[string]string
+		terminal               bool
+		initialNoColor         bool
+		expectedNoColor        bool
+		expectedNonInteractive bool
+	}{
+		{
+			name:     "terminal",
+			terminal: true,
+		},
+		{
+			name:                   "not a terminal",
+			terminal:               false,
+			expectedNoColor:        true,
+			expectedNonInteractive: true,
+		},
+		{
+			name:            "NO_COLOR set",
+			env:             map[string]string{"NO_COLOR": "1"},
+			terminal:        true,
+			expectedNoColor: true,
+		},
+		{
+			name:            "NO_COLOR empty",
+			env:             map[string]string{"NO_COLOR": ""},
+			terminal:        true,
+			expectedNoColor: false,
+		},
+		{
+			name:                   "generic CI",
+			env:                    map[string]string{"CI": "true"},
+			terminal:               true,
+			expectedNoColor:        true,
+			expectedNonInteractive: true,
+		},
+		{
+			name:                   "GitHub Actions",
+			env:                    map[string]string{"GITHUB_ACTIONS": "true"},
+			terminal:               true,
+			expectedNoColor:        true,
+			expectedNonInteractive: true,
+		},
+		{
+			name:                   "CI env var prefix",
+			env:                    map[string]string{"ATLANTIS_PULL_NUM": "1"},
+			terminal:               true,
+			expectedNoColor:        true,
+			expectedNonInteractive: true,
+		},
+		{
+			name:            "no color from config is kept",
+			terminal:        true,
+			initialNoColor:  true,
+			expectedNoColor: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetTerminalEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			origIsTerminal := isTerminal
+			isTerminal = func(*os.File) bool { return tt.terminal }
+			t.Cleanup(func() { isTerminal = origIsTerminal })
+
+			c := Config{NoColor: tt.initialNoColor}
+			c.detectTerminal()
+
+			assert.Equal(t, tt.expectedNoColor, c.NoColor)
+			assert.Equal(t, tt.expectedNonInteractive, c.NonInteractive)
+		})
+	}
+}
@@ -2,7 +2,7 @@ package config
 
 import (
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -19,6 +19,12 @@ type Configuration struct {
 	DisableHCLParsing     *bool  `yaml:"disable_hcl_parsing,omitempty"`
 	TLSInsecureSkipVerify *bool  `yaml:"tls_insecure_skip_verify,omitempty"`
 	TLSCACertFile         string `yaml:"tls_ca_cert_file,omitempty"`
+	UpdateChannel         string `yaml:"update_channel,omitempty"`
+	NetworkPolicy         string `yaml:"network_policy,omitempty"`
+
+	ModuleSourceAllow      []string `yaml:"module_source_allow,omitempty"`
+	ModuleSourceDeny       []string `yaml:"module_source_deny,omitempty"`
+	ModuleSourcePolicyMode string   `yaml:"module_source_policy_mode,omitempty"`
 }
 
 func loadConfiguration(cfg *Config) error {
@@ -58,6 +64,35 @@ func loadConfiguration(cfg *Config) error {
 		cfg.TLSCACertFile = cfg.Configuration.TLSCACertFile
 	}
 
+	if cfg.UpdateChannel == "" {
+		cfg.UpdateChannel = cfg.Configuration.UpdateChannel
+	}
+	if cfg.UpdateChannel == "" {
+		cfg.UpdateChannel = "stable"
+	}
+
+	if cfg.NetworkPolicy == "" {
+		cfg.NetworkPolicy = cfg.Configuration.NetworkPolicy
+	}
+	if cfg.NetworkPolicy == "" {
+		cfg.NetworkPolicy = "all"
+	}
+
+	if len(cfg.ModuleSourceAllow) == 0 {
+		cfg.ModuleSourceAllow = cfg.Configuration.ModuleSourceAllow
+	}
+
+	if len(cfg.ModuleSourceDeny) == 0 {
+		cfg.ModuleSourceDeny = cfg.Configuration.ModuleSourceDeny
+	}
+
+	if cfg.ModuleSourcePolicyMode == "" {
+		cfg.ModuleSourcePolicyMode = cfg.Configuration.ModuleSourcePolicyMode
+	}
+	if cfg.ModuleSourcePolicyMode == "" {
+		cfg.ModuleSourcePolicyMode = "error"
+	}
+
 	return nil
 }
 
@@ -91,7 +126,7 @@ func writeConfigurationFile(c Configuration) error {
 		return err
 	}
 
-	err = os.MkdirAll(path.Dir(ConfigurationFilePath()), 0700)
+	err = os.MkdirAll(filepath.Dir(ConfigurationFilePath()), 0700)
 	if err != nil {
 		return err
 	}
@@ -100,5 +135,5 @@ func writeConfigurationFile(c Configuration) error {
 }
 
 func ConfigurationFilePath() string {
-	return path.Join(userConfigDir(), "configuration.yml")
+	return filepath.Join(userConfigDir(), "configuration.yml")
 }
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// CostCenters are the rules for allocating the cost of resources to cost centers for chargeback, e.g.
+// to the teams that own them. A resource is allocated by the first split that matches it, otherwise
+// to the value of its Tag. Resources that aren't allocated are shown as unallocated in the output.
+type CostCenters struct {
+	// Tag is the resource tag whose value is the cost center of the resource, e.g. team.
+	Tag string `yaml:"tag,omitempty"`
+	// Splits divide the cost of shared resources between multiple cost centers.
+	Splits []*CostCenterSplit `yaml:"splits,omitempty"`
+}
+
+// CostCenterSplit divides the cost of the resources it matches between cost centers by percentage,
+// e.g. a database shared by two teams. It matches the resources with one of the addresses, or with
+// all of the tags if no addresses are set.
+type CostCenterSplit struct {
+	// Resources are the addresses of the resources to split. They can contain * wildcards, e.g.
+	// module.shared.*
+	Resources []string `yaml:"resources,omitempty"`
+	// Tags are the tags that the resources to split must have.
+	Tags map[string]string `yaml:"tags,omitempty"`
+	// Percentages is the percentage of the cost that is allocated to each cost center. They must add
+	// up to 100.
+	Percentages map[string]float64 `yaml:"percentages"`
+}
+
+func (s *CostCenterSplit) validate() error {
+	if len(s.Resources) == 0 && len(s.Tags) == 0 {
+		return errors.New("split must match resources or tags")
+	}
+
+	if len(s.Percentages) == 0 {
+		return errors.New("split must have percentages")
+	}
+
+	total := decimal.Zero
+	for _, name := range sortedKeys(s.Percentages) {
+		p := s.Percentages[name]
+		if p <= 0 {
+			return fmt.Errorf("percentage for cost center %s must be positive", name)
+		}
+
+		total = total.Add(decimal.NewFromFloat(p))
+	}
+
+	if !total.Equal(decimal.NewFromInt(100)) {
+		return fmt.Errorf("percentages must add up to 100, got %s", total)
+	}
+
+	return nil
+}
+
+func (s *CostCenterSplit) matches(r *schema.Resource) bool {
+	if len(s.Resources) > 0 {
+		for _, pattern := range s.Resources {
+			if matchAddressPattern(pattern, r.Name) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for k, v := range s.Tags {
+		if r.Tags[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Allocate sets the cost centers of each of the resources that a split or the tag applies to. Free
+// and unsupported resources aren't allocated since they have no cost.
+func (c *CostCenters) Allocate(resources []*schema.Resource) {
+	if c == nil {
+		return
+	}
+
+	for _, r := range resources {
+		if r.NoPrice || r.IsSkipped {
+			continue
+		}
+
+		r.CostCenters = c.allocation(r)
+	}
+}
+
+func (c *CostCenters) allocation(r *schema.Resource) map[string]decimal.Decimal {
+	for _, s := range c.Splits {
+		if !s.matches(r) {
+			continue
+		}
+
+		percentages := make(map[string]decimal.Decimal, len(s.Percentages))
+		for name, p := range s.Percentages {
+			percentages[name] = decimal.NewFromFloat(p)
+		}
+
+		return percentages
+	}
+
+	if v := r.Tags[c.Tag]; c.Tag != "" && v != "" {
+		return map[string]decimal.Decimal{v: decimal.NewFromInt(100)}
+	}
+
+	return nil
+}
+
+func validateCostCenters(c *CostCenters) error {
+	if c == nil {
+		return nil
+	}
+
+	validationError := &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+	}
+
+	for i, s := range c.Splits {
+		if s == nil {
+			s = &CostCenterSplit{}
+		}
+
+		if err := s.validate(); err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("cost center split at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
+	if validationError.isValid() {
+		return validationError
+	}
+
+	return nil
+}
+
+// matchAddressPattern returns true if the resource address matches the pattern, where * matches any
+// characters.
+func matchAddressPattern(pattern, addr string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	re, err := regexp.Compile("^" + strings.Join(parts, ".*") + "$")
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(addr)
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
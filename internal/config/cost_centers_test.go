@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestCostCentersAllocate(t *testing.T) {
+	c := &CostCenters{
+		Tag: "team",
+		Splits: []*CostCenterSplit{
+			{
+				Resources:   []string{"module.shared.*"},
+				Percentages: map[string]float64{"team-a": 60, "team-b": 40},
+			},
+			{
+				Tags:        map[string]string{"shared": "true"},
+				Percentages: map[string]float64{"team-a": 50, "team-c": 50},
+			},
+		},
+	}
+
+	shared := &schema.Resource{Name: "module.shared.aws_db_instance.main", Tags: map[string]string{"team": "team-b"}}
+	taggedShared := &schema.Resource{Name: "aws_elasticache_cluster.cache", Tags: map[string]string{"shared": "true"}}
+	owned := &schema.Resource{Name: "aws_instance.web", Tags: map[string]string{"team": "team-c"}}
+	untagged := &schema.Resource{Name: "aws_instance.worker"}
+	free := &schema.Resource{Name: "aws_vpc.main", NoPrice: true, Tags: map[string]string{"team": "team-c"}}
+
+	c.Allocate([]*schema.Resource{shared, taggedShared, owned, untagged, free})
+
+	// splits take priority over the tag.
+	assert.Equal(t, map[string]string{"team-a": "60", "team-b": "40"}, percentageStrings(shared.CostCenters))
+	assert.Equal(t, map[string]string{"team-a": "50", "team-c": "50"}, percentageStrings(taggedShared.CostCenters))
+	assert.Equal(t, map[string]string{"team-c": "100"}, percentageStrings(owned.CostCenters))
+	assert.Nil(t, untagged.CostCenters)
+	assert.Nil(t, free.CostCenters)
+}
+
+func percentageStrings(m map[string]decimal.Decimal) map[string]string {
+	s := make(map[string]string, len(m))
+	for k, v := range m {
+		s[k] = v.String()
+	}
+
+	return s
+}
@@ -2,7 +2,7 @@ package config
 
 import (
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -43,6 +43,10 @@ func loadCredentials(cfg *Config) error {
 		cfg.APIKey = cfg.Credentials.APIKey
 	}
 
+	if cfg.APIKey == "" && len(cfg.APIKeys) > 0 {
+		cfg.APIKey = cfg.APIKeys[0]
+	}
+
 	return nil
 }
 
@@ -76,7 +80,7 @@ func writeCredentialsFile(c Credentials) error {
 		return err
 	}
 
-	err = os.MkdirAll(path.Dir(CredentialsFilePath()), 0700)
+	err = os.MkdirAll(filepath.Dir(CredentialsFilePath()), 0700)
 	if err != nil {
 		return err
 	}
@@ -85,5 +89,5 @@ func writeCredentialsFile(c Credentials) error {
 }
 
 func CredentialsFilePath() string {
-	return path.Join(userConfigDir(), "credentials.yml")
+	return filepath.Join(userConfigDir(), "credentials.yml")
 }
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GitCommitToBranch creates a new branch from HEAD in the git repo containing dir with a single
+// commit that changes the given files to their new contents, and returns the commit's SHA.
+// The commit is built with a temporary index, so the working tree and current branch aren't
+// changed. files is keyed by path.
+func GitCommitToBranch(dir string, branch string, message string, files map[string][]byte) (string, error) {
+	topLevel, err := gitToplevel(dir)
+	if err != nil {
+		return "", fmt.Errorf("Could not find a git repo at %s", dir)
+	}
+
+	if _, err := gitOutput(topLevel, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		return "", fmt.Errorf("Branch %s already exists", branch)
+	}
+
+	indexFile, err := os.CreateTemp("", "infracost-index-")
+	if err != nil {
+		return "", fmt.Errorf("Could not create temporary git index: %w", err)
+	}
+	indexFile.Close()
+	defer os.Remove(indexFile.Name())
+
+	env := []string{"GIT_INDEX_FILE=" + indexFile.Name()}
+
+	_, err = gitOutputWithInput(topLevel, env, nil, "read-tree", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("Could not read HEAD: %w", err)
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		rel, err := filepath.Rel(topLevel, resolvePath(path))
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return "", fmt.Errorf("%s is not in the git repo at %s", path, topLevel)
+		}
+
+		sha, err := gitOutputWithInput(topLevel, nil, files[path], "hash-object", "-w", "--stdin")
+		if err != nil {
+			return "", fmt.Errorf("Could not add %s to git: %w", rel, err)
+		}
+
+		_, err = gitOutputWithInput(topLevel, env, nil, "update-index", "--add", "--cacheinfo", "100644,"+strings.TrimSpace(sha)+","+filepath.ToSlash(rel))
+		if err != nil {
+			return "", fmt.Errorf("Could not add %s to git: %w", rel, err)
+		}
+	}
+
+	tree, err := gitOutputWithInput(topLevel, env, nil, "write-tree")
+	if err != nil {
+		return "", fmt.Errorf("Could not write git tree: %w", err)
+	}
+
+	commit, err := gitOutput(topLevel, "commit-tree", strings.TrimSpace(tree), "-p", "HEAD", "-m", message)
+	if err != nil {
+		return "", fmt.Errorf("Could not create git commit: %w", err)
+	}
+	commit = strings.TrimSpace(commit)
+
+	_, err = gitOutput(topLevel, "branch", branch, commit)
+	if err != nil {
+		return "", fmt.Errorf("Could not create branch %s: %w", branch, err)
+	}
+
+	return commit, nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitCommitToBranch(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return string(out)
+	}
+
+	mainTf := filepath.Join(dir, "main.tf")
+	require.NoError(t, os.WriteFile(mainTf, []byte("resource \"aws_instance\" \"web\" {}\n"), 0600))
+	git("init", "-q", "-b", "main")
+	git("add", "-A")
+	git("commit", "-q", "-m", "init")
+
+	commit, err := GitCommitToBranch(dir, "costs", "Add cost comments", map[string][]byte{
+		mainTf: []byte("# infracost: ~$10/mo\nresource \"aws_instance\" \"web\" {}\n"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, commit, strings.TrimSpace(git("rev-parse", "costs")))
+	assert.Equal(t, "Add cost comments", strings.TrimSpace(git("log", "-1", "--format=%s", "costs")))
+	assert.Equal(t, "# infracost: ~$10/mo\nresource \"aws_instance\" \"web\" {}\n", git("show", "costs:main.tf"))
+
+	// The working tree and current branch aren't changed.
+	assert.Equal(t, "main\n", git("rev-parse", "--abbrev-ref", "HEAD"))
+	assert.Equal(t, "", git("status", "--porcelain"))
+
+	_, err = GitCommitToBranch(dir, "costs", "Add cost comments", nil)
+	assert.EqualError(t, err, "Branch costs already exists")
+}
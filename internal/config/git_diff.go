@@ -0,0 +1,162 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitChangedFiles returns the absolute paths of the files in the git repo containing path that
+// changed since it diverged from base, e.g. origin/main. This includes uncommitted and untracked
+// files, so the changes can be checked before they're committed.
+func GitChangedFiles(path string, base string) ([]string, error) {
+	topLevel, err := gitToplevel(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not find a git repo at %s", path)
+	}
+
+	mergeBase, err := gitOutput(topLevel, "merge-base", base, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("Could not find the common ancestor of %s and HEAD: %w", base, err)
+	}
+	mergeBase = strings.TrimSpace(mergeBase)
+
+	diff, err := gitOutput(topLevel, "diff", "--name-only", "-z", mergeBase)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the files changed since %s: %w", base, err)
+	}
+
+	untracked, err := gitOutput(topLevel, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the untracked files: %w", err)
+	}
+
+	var files []string
+	for _, name := range strings.Split(diff+untracked, "\x00") {
+		// Skip the .infracost directories that runs create to cache Terraform modules.
+		if name == "" || name == ".infracost" || strings.HasPrefix(name, ".infracost/") || strings.Contains(name, "/.infracost/") {
+			continue
+		}
+		files = append(files, filepath.Join(topLevel, filepath.FromSlash(name)))
+	}
+
+	return files, nil
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	return gitOutputWithInput(dir, nil, nil, args...)
+}
+
+// gitOutputWithInput runs git with the extra environment variables and stdin, and returns its
+// output. The error has git's message if it printed one.
+func gitOutputWithInput(dir string, env []string, stdin []byte, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// IsAffectedBy returns true if any of the changed files could change the project's estimate:
+// files in the project's directory or the given module directories it depends on, or its
+// usage and var files. If the project path is a plan JSON or plan file, only changes to that
+// file count. All paths should be absolute.
+func (p *Project) IsAffectedBy(changedFiles []string, moduleDirs []string) bool {
+	var files []string
+	var dirs []string
+
+	if isDir(p.Path) {
+		dirs = append(dirs, p.Path)
+	} else {
+		files = append(files, p.Path)
+	}
+	dirs = append(dirs, moduleDirs...)
+
+	if p.UsageFile != "" {
+		files = append(files, p.UsageFile)
+	}
+	for _, f := range p.TerraformVarFiles {
+		if !filepath.IsAbs(f) && isDir(p.Path) {
+			f = filepath.Join(p.Path, f)
+		}
+		files = append(files, f)
+	}
+
+	for i := range files {
+		files[i] = resolvePath(files[i])
+	}
+	for i := range dirs {
+		dirs[i] = resolvePath(dirs[i])
+	}
+
+	for _, changed := range changedFiles {
+		changed = resolvePath(changed)
+
+		for _, f := range files {
+			if changed == f {
+				return true
+			}
+		}
+
+		for _, d := range dirs {
+			if changed == d || strings.HasPrefix(changed, d+string(filepath.Separator)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ContainsPath returns true if the list of changed files returned by GitChangedFiles includes
+// the file at path.
+func ContainsPath(changedFiles []string, path string) bool {
+	path = resolvePath(path)
+	for _, changed := range changedFiles {
+		if resolvePath(changed) == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolvePath returns the absolute path with symlinks resolved so it can be compared with the
+// paths git returns, which are relative to the resolved top level directory. Paths of deleted
+// files can't be resolved, so only their directory is resolved.
+func resolvePath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		return filepath.Join(dir, filepath.Base(abs))
+	}
+
+	return abs
+}
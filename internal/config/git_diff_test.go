@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name string, content string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	git("init", "-q", "-b", "main")
+	write("dev/main.tf", "")
+	write("prod/main.tf", "")
+	write("modules/vpc/main.tf", "")
+	git("add", "-A")
+	git("commit", "-q", "-m", "init")
+
+	git("checkout", "-q", "-b", "feature")
+	write("modules/vpc/main.tf", "# changed")
+	git("commit", "-q", "-am", "change vpc")
+	write("prod/prod.tfvars", "")
+	write("dev/.infracost/terraform_modules/manifest.json", "")
+
+	files, err := GitChangedFiles(filepath.Join(dir, "dev"), "main")
+	require.NoError(t, err)
+
+	assert.True(t, ContainsPath(files, filepath.Join(dir, "modules/vpc/main.tf")))
+	assert.True(t, ContainsPath(files, filepath.Join(dir, "prod/prod.tfvars")))
+	assert.False(t, ContainsPath(files, filepath.Join(dir, "dev/main.tf")))
+	assert.Len(t, files, 2)
+
+	dev := &Project{Path: filepath.Join(dir, "dev")}
+	assert.False(t, dev.IsAffectedBy(files, nil))
+	assert.True(t, dev.IsAffectedBy(files, []string{filepath.Join(dir, "modules/vpc")}))
+
+	prod := &Project{Path: filepath.Join(dir, "prod")}
+	assert.True(t, prod.IsAffectedBy(files, nil))
+
+	_, err = GitChangedFiles(dir, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// LogRedaction are the values that are redacted from log lines and diagnostics, e.g. the names of
+// confidential variables or the hosts of private module sources.
+type LogRedaction struct {
+	// Patterns are regular expressions whose matches are redacted.
+	Patterns []string `yaml:"patterns,omitempty"`
+	// Keywords are strings that are redacted wherever they appear.
+	Keywords []string `yaml:"keywords,omitempty"`
+}
+
+func validateLogRedaction(r *LogRedaction) error {
+	if r == nil {
+		return nil
+	}
+
+	validationError := &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+	}
+
+	for i, p := range r.Patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("log redaction pattern at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
+	if validationError.isValid() {
+		return validationError
+	}
+
+	return nil
+}
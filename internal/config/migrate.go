@@ -3,7 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
 	"time"
 
 	"github.com/pkg/errors"
@@ -17,7 +17,7 @@ func (c *Config) migrateConfiguration() error {
 }
 
 func (c *Config) migrateCredentials() error {
-	oldPath := path.Join(userConfigDir(), "config.yml")
+	oldPath := filepath.Join(userConfigDir(), "config.yml")
 	credPath := CredentialsFilePath()
 
 	if FileExists(oldPath) && !FileExists(credPath) {
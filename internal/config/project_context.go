@@ -25,6 +25,10 @@ type ProjectContext struct {
 
 	UsingCache bool
 	CacheErr   string
+
+	// DependencyVars are the input variables set from the outputs of the projects that the project
+	// depends on. The values are JSON compatible, e.g. strings, numbers, lists and maps.
+	DependencyVars map[string]interface{}
 }
 
 func NewProjectContext(runCtx *RunContext, projectCfg *Project) *ProjectContext {
@@ -94,6 +98,46 @@ func DetectProjectMetadata(path string) *schema.ProjectMetadata {
 	}
 }
 
+// DetectVCSBranch returns the branch that is being run, using the INFRACOST_VCS_BRANCH
+// env var, the CI system env vars or the git branch at path.
+func DetectVCSBranch(path string) string {
+	if branch := os.Getenv("INFRACOST_VCS_BRANCH"); branch != "" {
+		return branch
+	}
+
+	if branch := ciVCSBranch(); branch != "" {
+		return branch
+	}
+
+	return gitBranch(path)
+}
+
+// DetectVCSCommit returns the commit SHA that is being run, using the INFRACOST_VCS_COMMIT_SHA
+// env var, the CI system env vars or the git HEAD commit at path.
+func DetectVCSCommit(path string) string {
+	if sha := os.Getenv("INFRACOST_VCS_COMMIT_SHA"); sha != "" {
+		return sha
+	}
+
+	if sha := ciVCSCommit(); sha != "" {
+		return sha
+	}
+
+	return gitCommit(path)
+}
+
+// DetectVCSRoot returns the top level directory of the git repo that path is in, or an
+// empty string if path isn't in a git repo.
+func DetectVCSRoot(path string) string {
+	topLevel, err := gitToplevel(path)
+	if err != nil {
+		log.Debugf("Could not get git top level directory for %s", path)
+		return ""
+	}
+
+	return topLevel
+}
+
 func gitRepo(path string) string {
 	log.Debugf("Checking if %s is a git repo", path)
 	cmd := exec.Command("git", "ls-remote", "--get-url")
@@ -112,6 +156,48 @@ func gitRepo(path string) string {
 	return strings.Split(string(out), "\n")[0]
 }
 
+func gitBranch(path string) string {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+
+	if isDir(path) {
+		cmd.Dir = path
+	} else {
+		cmd.Dir = filepath.Dir(path)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		log.Debugf("Could not detect a git branch at %s", path)
+		return ""
+	}
+
+	branch := strings.Split(string(out), "\n")[0]
+	// HEAD is returned when in a detached HEAD state, e.g. when CI checks out a commit.
+	if branch == "HEAD" {
+		return ""
+	}
+
+	return branch
+}
+
+func gitCommit(path string) string {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+
+	if isDir(path) {
+		cmd.Dir = path
+	} else {
+		cmd.Dir = filepath.Dir(path)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		log.Debugf("Could not detect a git commit at %s", path)
+		return ""
+	}
+
+	return strings.Split(string(out), "\n")[0]
+}
+
 func gitSubPath(path string) string {
 	topLevel, err := gitToplevel(path)
 	if err != nil {
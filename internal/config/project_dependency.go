@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ProjectDependency is another project in the config file that a project depends on, e.g. the
+// network stack that a platform stack is deployed into. The dependency is run first and its
+// Terraform outputs are passed to the project as input variables, so layered stacks are
+// evaluated with realistic values.
+type ProjectDependency struct {
+	// Path is the path of the project that is depended on, as it's set in the config file.
+	Path string `yaml:"path"`
+	// Inputs maps the names of the project's input variables to the names of the outputs of the
+	// dependency that they're set from. If it's empty every output is passed as the variable
+	// with the same name.
+	Inputs map[string]string `yaml:"inputs,omitempty"`
+	// StateFile is the Terraform state file, or the output of terraform show -json, that the
+	// outputs are read from. It defaults to terraform.tfstate in the dependency's path, which is
+	// where the local backend writes it.
+	StateFile string `yaml:"state_file,omitempty"`
+	// MockOutputs are the values of the outputs that aren't in the state and couldn't be
+	// evaluated from the dependency's HCL, e.g. IDs that are only known after apply.
+	MockOutputs map[string]interface{} `yaml:"mock_outputs,omitempty"`
+}
+
+// validateProjectDependencies checks that every dependency is a single other project in the
+// config file and that there are no cycles.
+func validateProjectDependencies(projects []*Project) error {
+	validationError := &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+	}
+
+	counts := make(map[string]int, len(projects))
+	for _, p := range projects {
+		counts[filepath.Clean(p.Path)]++
+	}
+
+	for _, p := range projects {
+		projectError := &YamlError{
+			base: fmt.Sprintf("project config defined for path: [%s] is invalid", p.Path),
+		}
+
+		for i, dep := range p.DependsOn {
+			switch {
+			case dep == nil || dep.Path == "":
+				projectError.add(fmt.Errorf("depends_on at index %d must have a valid path definition", i))
+			case filepath.Clean(dep.Path) == filepath.Clean(p.Path):
+				projectError.add(errors.New("project can not depend on itself"))
+			case counts[filepath.Clean(dep.Path)] == 0:
+				projectError.add(fmt.Errorf("depends_on path %s does not match any project", dep.Path))
+			case counts[filepath.Clean(dep.Path)] > 1:
+				projectError.add(fmt.Errorf("depends_on path %s matches more than one project", dep.Path))
+			}
+		}
+
+		if projectError.isValid() {
+			validationError.add(projectError)
+		}
+	}
+
+	if validationError.isValid() {
+		return validationError
+	}
+
+	if cycle := dependencyCycle(projects); len(cycle) > 0 {
+		return &YamlError{
+			base:   "config file is invalid, see https://infracost.io/config-file for valid options",
+			errors: []error{fmt.Errorf("projects have a dependency cycle: %s", strings.Join(cycle, " -> "))},
+		}
+	}
+
+	return nil
+}
+
+// ProjectRunOrder returns the indexes of the projects in the order they should be run, so each
+// project is after the projects it depends on. Projects are otherwise kept in the order they're
+// defined in. Dependencies on paths that aren't in projects, e.g. because they've been filtered out
+// of the run, are ignored.
+func ProjectRunOrder(projects []*Project) []int {
+	indexes := make(map[string]int, len(projects))
+	for i, p := range projects {
+		if _, ok := indexes[filepath.Clean(p.Path)]; !ok {
+			indexes[filepath.Clean(p.Path)] = i
+		}
+	}
+
+	order := make([]int, 0, len(projects))
+	visited := make([]bool, len(projects))
+
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+
+		for _, dep := range projects[i].DependsOn {
+			if dep == nil {
+				continue
+			}
+
+			if j, ok := indexes[filepath.Clean(dep.Path)]; ok {
+				visit(j)
+			}
+		}
+
+		order = append(order, i)
+	}
+
+	for i := range projects {
+		visit(i)
+	}
+
+	return order
+}
+
+// dependencyCycle returns the paths of the projects in a dependency cycle, starting and ending with
+// the same project, or nil if there isn't one.
+func dependencyCycle(projects []*Project) []string {
+	byPath := make(map[string]*Project, len(projects))
+	for _, p := range projects {
+		byPath[filepath.Clean(p.Path)] = p
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(projects))
+
+	var stack []string
+	var visit func(path string) []string
+	visit = func(path string) []string {
+		switch state[path] {
+		case visited:
+			return nil
+		case visiting:
+			for i, p := range stack {
+				if p == path {
+					return append(append([]string{}, stack[i:]...), path)
+				}
+			}
+		}
+
+		state[path] = visiting
+		stack = append(stack, path)
+
+		for _, dep := range byPath[path].DependsOn {
+			if cycle := visit(filepath.Clean(dep.Path)); cycle != nil {
+				return cycle
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[path] = visited
+		return nil
+	}
+
+	for _, p := range projects {
+		if cycle := visit(filepath.Clean(p.Path)); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
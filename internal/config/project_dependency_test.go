@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRunOrder(t *testing.T) {
+	projects := []*Project{
+		{Path: "app", DependsOn: []*ProjectDependency{{Path: "platform"}, {Path: "./network"}}},
+		{Path: "standalone"},
+		{Path: "platform", DependsOn: []*ProjectDependency{{Path: "network/"}}},
+		{Path: "network"},
+		{Path: "filtered", DependsOn: []*ProjectDependency{{Path: "missing"}}},
+	}
+
+	assert.Equal(t, []int{3, 2, 0, 1, 4}, ProjectRunOrder(projects))
+}
+
+func TestLoadConfigFileProjectDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `version: 0.1
+projects:
+  - path: network
+  - path: app
+    depends_on:
+      - path: network
+        inputs:
+          vpc_id: vpc_id
+        mock_outputs:
+          vpc_id: vpc-123
+`,
+		},
+		{
+			name: "unknown path",
+			content: `version: 0.1
+projects:
+  - path: app
+    depends_on:
+      - path: network
+`,
+			wantErr: "depends_on path network does not match any project",
+		},
+		{
+			name: "ambiguous path",
+			content: `version: 0.1
+projects:
+  - path: network
+    terraform_workspace: dev
+  - path: network
+    terraform_workspace: prod
+  - path: app
+    depends_on:
+      - path: network
+`,
+			wantErr: "depends_on path network matches more than one project",
+		},
+		{
+			name: "self",
+			content: `version: 0.1
+projects:
+  - path: app
+    depends_on:
+      - path: ./app
+`,
+			wantErr: "project can not depend on itself",
+		},
+		{
+			name: "cycle",
+			content: `version: 0.1
+projects:
+  - path: network
+    depends_on:
+      - path: app
+  - path: platform
+    depends_on:
+      - path: network
+  - path: app
+    depends_on:
+      - path: platform
+`,
+			wantErr: "projects have a dependency cycle: network -> app -> platform -> network",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "infracost.yml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			cfg, err := loadConfigFile(path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			dep := cfg.Projects[1].DependsOn[0]
+			assert.Equal(t, map[string]string{"vpc_id": "vpc_id"}, dep.Inputs)
+			assert.Equal(t, map[string]interface{}{"vpc_id": "vpc-123"}, dep.MockOutputs)
+		})
+	}
+}
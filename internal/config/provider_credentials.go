@@ -0,0 +1,79 @@
+package config
+
+import (
+	"strings"
+)
+
+// ProviderCredentials sets the cloud credentials that should be used for a Terraform provider
+// when reading remote state and fetching usage from the cloud provider's APIs.
+type ProviderCredentials struct {
+	// AWSProfile is the name of the profile from the AWS shared config/credentials files.
+	AWSProfile string `yaml:"aws_profile,omitempty"`
+	// GCPCredentialsFile is the path to a GCP service account key file.
+	GCPCredentialsFile string `yaml:"gcp_credentials_file,omitempty"`
+	// AzureSubscriptionID is the ID of the Azure subscription.
+	AzureSubscriptionID string `yaml:"azure_subscription_id,omitempty"`
+}
+
+// Env returns the environment variables that the cloud provider SDKs and Terraform read
+// these credentials from.
+func (c *ProviderCredentials) Env() map[string]string {
+	env := map[string]string{}
+	if c == nil {
+		return env
+	}
+
+	if c.AWSProfile != "" {
+		env["AWS_PROFILE"] = c.AWSProfile
+	}
+
+	if c.GCPCredentialsFile != "" {
+		env["GOOGLE_APPLICATION_CREDENTIALS"] = c.GCPCredentialsFile
+	}
+
+	if c.AzureSubscriptionID != "" {
+		env["ARM_SUBSCRIPTION_ID"] = c.AzureSubscriptionID
+	}
+
+	return env
+}
+
+// EnvWithCredentials returns the project env merged with the credentials of any providers
+// that don't have an alias, e.g. aws or google. Values set in the project env take precedence.
+func (p *Project) EnvWithCredentials() map[string]string {
+	env := map[string]string{}
+
+	for key, creds := range p.ProviderCredentials {
+		if strings.Contains(key, ".") {
+			continue
+		}
+
+		for k, v := range creds.Env() {
+			env[k] = v
+		}
+	}
+
+	for k, v := range p.Env {
+		env[k] = v
+	}
+
+	return env
+}
+
+// EnvForProvider returns the project env merged with the credentials for the given provider
+// config key, e.g. aws.prod. If there are no credentials for the alias then the credentials
+// for the provider without the alias are used.
+func (p *Project) EnvForProvider(providerKey string) map[string]string {
+	env := p.EnvWithCredentials()
+
+	creds, ok := p.ProviderCredentials[providerKey]
+	if !ok {
+		return env
+	}
+
+	for k, v := range creds.Env() {
+		env[k] = v
+	}
+
+	return env
+}
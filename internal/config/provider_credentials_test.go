@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectEnvForProvider(t *testing.T) {
+	p := &Project{
+		Env: map[string]string{
+			"GOOGLE_APPLICATION_CREDENTIALS": "env-sa.json",
+		},
+		ProviderCredentials: map[string]*ProviderCredentials{
+			"aws":      {AWSProfile: "dev"},
+			"aws.prod": {AWSProfile: "prod"},
+			"google":   {GCPCredentialsFile: "sa.json"},
+			"azurerm":  {AzureSubscriptionID: "00000000-0000-0000-0000-000000000000"},
+		},
+	}
+
+	defaultEnv := map[string]string{
+		"AWS_PROFILE":                    "dev",
+		"GOOGLE_APPLICATION_CREDENTIALS": "env-sa.json",
+		"ARM_SUBSCRIPTION_ID":            "00000000-0000-0000-0000-000000000000",
+	}
+
+	assert.Equal(t, defaultEnv, p.EnvWithCredentials())
+	assert.Equal(t, defaultEnv, p.EnvForProvider("aws"))
+	assert.Equal(t, defaultEnv, p.EnvForProvider("aws.europe"))
+	assert.Equal(t, "prod", p.EnvForProvider("aws.prod")["AWS_PROFILE"])
+}
@@ -0,0 +1,43 @@
+package config
+
+// RemoteVariables lists the input variables of a project that are loaded from systems other than
+// tfvars files when parsing HCL. Each key is loaded as the variable with the same name. Values
+// from var files and --terraform-var take precedence over them.
+type RemoteVariables struct {
+	AzureDevOpsVariableGroups *AzureDevOpsVariableGroups `yaml:"azure_devops_variable_groups,omitempty"`
+	GCPSecretManager          *GCPSecretManager          `yaml:"gcp_secret_manager,omitempty"`
+	GCPRuntimeConfig          *GCPRuntimeConfig          `yaml:"gcp_runtime_config,omitempty"`
+}
+
+// AzureDevOpsVariableGroups loads the variables of Azure DevOps Pipelines variable groups. The
+// access token is read from AZURE_DEVOPS_EXT_PAT or SYSTEM_ACCESSTOKEN in the project env.
+// Secret variables aren't returned by the API, so they can't be loaded.
+type AzureDevOpsVariableGroups struct {
+	// Organization is the name or URL of the organization, e.g. myorg or https://dev.azure.com/myorg.
+	Organization string `yaml:"organization"`
+	// Project is the name of the Azure DevOps project that has the variable groups.
+	Project string `yaml:"project"`
+	// Groups are the names of the variable groups, later groups taking precedence.
+	Groups []string `yaml:"groups"`
+}
+
+// GCPSecretManager loads the latest version of Google Secret Manager secrets. The credentials are
+// read from GOOGLE_OAUTH_ACCESS_TOKEN or GOOGLE_APPLICATION_CREDENTIALS in the project env or the
+// provider credentials, falling back to the application default credentials.
+type GCPSecretManager struct {
+	// Project is the ID of the Google Cloud project that has the secrets.
+	Project string `yaml:"project"`
+	// Secrets are the names of the secrets.
+	Secrets []string `yaml:"secrets"`
+}
+
+// GCPRuntimeConfig loads variables from a Google Cloud Runtime Configurator config, using the
+// same credentials as GCPSecretManager.
+type GCPRuntimeConfig struct {
+	// Project is the ID of the Google Cloud project that has the config.
+	Project string `yaml:"project"`
+	// Config is the name of the config resource.
+	Config string `yaml:"config"`
+	// Variables are the names of the variables in the config.
+	Variables []string `yaml:"variables"`
+}
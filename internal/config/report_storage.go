@@ -0,0 +1,120 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"text/template"
+)
+
+const (
+	// ReportStorageS3 stores reports in an AWS S3 bucket, or a bucket of an S3 compatible service
+	// like MinIO when the endpoint is set.
+	ReportStorageS3 = "s3"
+	// ReportStorageGCS stores reports in a Google Cloud Storage bucket.
+	ReportStorageGCS = "gcs"
+	// ReportStorageAzure stores reports in an Azure Blob Storage container.
+	ReportStorageAzure = "azure"
+
+	// DefaultReportStorageKey is the key template used when one isn't set.
+	DefaultReportStorageKey = "infracost/{{ .Branch }}/{{ .Commit }}/infracost.{{ .Format }}"
+)
+
+// reportStorageFormats are the output formats that can be uploaded to report storage.
+var reportStorageFormats = map[string]bool{
+	"json": true,
+	"html": true,
+}
+
+// ReportStorage is a bucket that reports are uploaded to after each run, so they are kept without
+// needing the Infracost Cloud dashboard.
+type ReportStorage struct {
+	// Provider is the storage service, s3, gcs or azure.
+	Provider string `yaml:"provider"`
+	// Bucket is the name of the bucket, or the container for Azure Blob Storage.
+	Bucket string `yaml:"bucket"`
+	// Key is a Go template of the object key of each report. It can use .Branch, .Commit, .Format
+	// and .Timestamp, e.g. reports/{{ .Branch }}/{{ .Timestamp.Format "2006-01-02" }}.{{ .Format }}.
+	Key string `yaml:"key,omitempty"`
+	// Formats are the output formats uploaded, json and html. Only json is uploaded if it isn't set.
+	Formats []string `yaml:"formats,omitempty"`
+	// Region is the AWS region of an S3 bucket. The region from the AWS config is used if it isn't set.
+	Region string `yaml:"region,omitempty"`
+	// Endpoint is the URL of an S3 compatible service, e.g. a MinIO server, or the blob service URL of
+	// an Azure storage account.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Account is the Azure storage account name, used for the blob service URL if the endpoint isn't set.
+	// Azure uploads are authenticated with a SAS token from the AZURE_STORAGE_SAS_TOKEN env var.
+	Account string `yaml:"account,omitempty"`
+}
+
+// KeyTemplate returns the template of the object keys.
+func (r *ReportStorage) KeyTemplate() string {
+	if r.Key == "" {
+		return DefaultReportStorageKey
+	}
+
+	return r.Key
+}
+
+// UploadFormats returns the output formats that are uploaded.
+func (r *ReportStorage) UploadFormats() []string {
+	if len(r.Formats) == 0 {
+		return []string{"json"}
+	}
+
+	return r.Formats
+}
+
+func (r *ReportStorage) validate() error {
+	switch r.Provider {
+	case ReportStorageS3, ReportStorageGCS:
+	case ReportStorageAzure:
+		if r.Account == "" && r.Endpoint == "" {
+			return errors.New("report storage account or endpoint must be set for azure")
+		}
+	case "":
+		return errors.New("report storage must have a provider")
+	default:
+		return fmt.Errorf("report storage provider must be %s, %s or %s", ReportStorageS3, ReportStorageGCS, ReportStorageAzure)
+	}
+
+	if r.Bucket == "" {
+		return errors.New("report storage must have a bucket")
+	}
+
+	for _, f := range r.Formats {
+		if !reportStorageFormats[f] {
+			return fmt.Errorf("report storage format %s is not supported, must be json or html", f)
+		}
+	}
+
+	_, err := template.New("key").Parse(r.KeyTemplate())
+	if err != nil {
+		return fmt.Errorf("report storage key is not a valid template: %w", err)
+	}
+
+	return nil
+}
+
+func validateReportStorage(r *ReportStorage) error {
+	if r == nil {
+		return nil
+	}
+
+	validationError := &YamlError{
+		base: "config file is invalid, see https://infracost.io/config-file for valid options",
+	}
+
+	if err := r.validate(); err != nil {
+		validationError.add(&YamlError{
+			base:   "report storage was invalid",
+			errors: []error{err},
+		})
+	}
+
+	if validationError.isValid() {
+		return validationError
+	}
+
+	return nil
+}
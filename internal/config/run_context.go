@@ -8,6 +8,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,6 +26,10 @@ type RunContext struct {
 	contextVals map[string]interface{}
 	StartTime   int64
 
+	// warningCount is the number of warnings shown to the user, it's updated atomically
+	// since warnings can be written by projects running in parallel.
+	warningCount int64
+
 	OutWriter io.Writer
 	ErrWriter io.Writer
 	Exit      func(code int)
@@ -77,20 +82,39 @@ var (
 func (r *RunContext) NewWarningWriter() ui.WriteWarningFunc {
 	return func(msg string) {
 		fmt.Fprintf(r.ErrWriter, "%s%s %s\n", outputIndent, ui.WarningString("Warning:"), msg)
+		r.RecordWarning()
 	}
 }
 
+// RecordWarning records that a warning was shown to the user so that the run can fail
+// when --fail-on is set to warning.
+func (r *RunContext) RecordWarning() {
+	atomic.AddInt64(&r.warningCount, 1)
+}
+
+// WarningCount returns the number of warnings shown to the user.
+func (r *RunContext) WarningCount() int {
+	return int(atomic.LoadInt64(&r.warningCount))
+}
+
 // NewSpinner returns an ui.Spinner built from the RunContext.
 func (r *RunContext) NewSpinner(msg string) *ui.Spinner {
 	return ui.NewSpinner(msg, ui.SpinnerOptions{
-		EnableLogging: r.Config.IsLogging(),
-		NoColor:       r.Config.NoColor,
-		Indent:        outputIndent,
+		EnableLogging:  r.Config.IsLogging(),
+		NoColor:        r.Config.NoColor,
+		NoProgress:     r.Config.NoProgress,
+		NonInteractive: r.Config.NonInteractive,
+		Indent:         outputIndent,
 	})
 }
 
-// Context returns the underlying context.
+// Context returns the underlying context. This is cancelled when the user interrupts the run,
+// so long-running operations should check it to stop promptly.
 func (r *RunContext) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+
 	return r.ctx
 }
 
@@ -145,6 +169,7 @@ func (r *RunContext) EventEnvWithProjectContexts(projectContexts []*ProjectConte
 func (r *RunContext) loadInitialContextValues() {
 	r.SetContextValue("version", baseVersion(version.Version))
 	r.SetContextValue("fullVersion", version.Version)
+	r.SetContextValue("fips", version.FIPS())
 	r.SetContextValue("isTest", IsTest())
 	r.SetContextValue("isDev", IsDev())
 	r.SetContextValue("os", runtime.GOOS)
@@ -265,6 +290,44 @@ func ciVCSRepo() string {
 	return ""
 }
 
+func ciVCSBranch() string {
+	if IsEnvPresent("GITHUB_HEAD_REF") {
+		return os.Getenv("GITHUB_HEAD_REF")
+	} else if IsEnvPresent("GITHUB_REF_NAME") {
+		return os.Getenv("GITHUB_REF_NAME")
+	} else if IsEnvPresent("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME") {
+		return os.Getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME")
+	} else if IsEnvPresent("CI_COMMIT_REF_NAME") {
+		return os.Getenv("CI_COMMIT_REF_NAME")
+	} else if IsEnvPresent("SYSTEM_PULLREQUEST_SOURCEBRANCH") {
+		return strings.TrimPrefix(os.Getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"), "refs/heads/")
+	} else if IsEnvPresent("BUILD_SOURCEBRANCHNAME") {
+		return os.Getenv("BUILD_SOURCEBRANCHNAME")
+	} else if IsEnvPresent("BITBUCKET_BRANCH") {
+		return os.Getenv("BITBUCKET_BRANCH")
+	} else if IsEnvPresent("CIRCLE_BRANCH") {
+		return os.Getenv("CIRCLE_BRANCH")
+	}
+
+	return ""
+}
+
+func ciVCSCommit() string {
+	if IsEnvPresent("GITHUB_SHA") {
+		return os.Getenv("GITHUB_SHA")
+	} else if IsEnvPresent("CI_COMMIT_SHA") {
+		return os.Getenv("CI_COMMIT_SHA")
+	} else if IsEnvPresent("BUILD_SOURCEVERSION") {
+		return os.Getenv("BUILD_SOURCEVERSION")
+	} else if IsEnvPresent("BITBUCKET_COMMIT") {
+		return os.Getenv("BITBUCKET_COMMIT")
+	} else if IsEnvPresent("CIRCLE_SHA1") {
+		return os.Getenv("CIRCLE_SHA1")
+	}
+
+	return ""
+}
+
 func ciVCSPullRequestURL() string {
 	if IsEnvPresent("GITHUB_EVENT_PATH") && os.Getenv("GITHUB_EVENT_NAME") == "pull_request" {
 		b, err := os.ReadFile(os.Getenv("GITHUB_EVENT_PATH"))
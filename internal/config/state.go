@@ -3,7 +3,7 @@ package config
 import (
 	"encoding/json"
 	"os"
-	"path"
+	"path/filepath"
 
 	"github.com/google/uuid"
 )
@@ -57,7 +57,7 @@ func writeStateFile(s *State) error {
 		return err
 	}
 
-	err = os.MkdirAll(path.Dir(stateFilePath()), 0700)
+	err = os.MkdirAll(filepath.Dir(stateFilePath()), 0700)
 	if err != nil {
 		return err
 	}
@@ -66,5 +66,5 @@ func writeStateFile(s *State) error {
 }
 
 func stateFilePath() string {
-	return path.Join(userConfigDir(), ".state.json")
+	return filepath.Join(userConfigDir(), ".state.json")
 }
@@ -0,0 +1,147 @@
+package crash
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/logredact"
+	"github.com/infracost/infracost/internal/version"
+)
+
+// Report is written to a file when Infracost panics so that users can attach it to a
+// GitHub issue. It only contains a redacted copy of the config, e.g. env var names but
+// not their values, so it is safe to share.
+type Report struct {
+	Time      time.Time              `json:"time"`
+	Version   string                 `json:"version"`
+	GoVersion string                 `json:"goVersion"`
+	OS        string                 `json:"os"`
+	Arch      string                 `json:"arch"`
+	Error     string                 `json:"error"`
+	Stack     string                 `json:"stack"`
+	Project   *Project               `json:"project,omitempty"`
+	Projects  []*Project             `json:"projects"`
+	Context   map[string]interface{} `json:"context"`
+}
+
+// Project is the redacted config of a project along with metadata that helps reproduce
+// the crash without having access to the user's Terraform code.
+type Project struct {
+	Path                  string   `json:"path"`
+	TerraformWorkspace    string   `json:"terraformWorkspace,omitempty"`
+	TerraformBinary       string   `json:"terraformBinary,omitempty"`
+	HCLOnly               bool     `json:"hclOnly"`
+	HasUsageFile          bool     `json:"hasUsageFile"`
+	HasTerraformCloudHost bool     `json:"hasTerraformCloudHost"`
+	TerraformVarFileCount int      `json:"terraformVarFileCount"`
+	TerraformVarNames     []string `json:"terraformVarNames,omitempty"`
+	EnvNames              []string `json:"envNames,omitempty"`
+	TerraformFileCount    int      `json:"terraformFileCount"`
+}
+
+// NewReport returns a report for the recovered panic value and stack. The project is
+// the config of the project that was being run when the panic happened, it can be nil.
+func NewReport(ctx *config.RunContext, recovered interface{}, stack []byte, project *config.Project) *Report {
+	r := &Report{
+		Time:      time.Now().UTC(),
+		Version:   version.Version,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Error:     fmt.Sprintf("%v", recovered),
+		Stack:     string(stack),
+		Project:   newProject(project),
+		Projects:  []*Project{},
+		Context:   map[string]interface{}{},
+	}
+
+	if ctx == nil {
+		return r
+	}
+
+	// The context values are already sent with events so they don't contain anything sensitive.
+	for k, v := range ctx.ContextValues() {
+		r.Context[k] = v
+	}
+
+	if ctx.Config != nil {
+		for _, p := range ctx.Config.Projects {
+			if p != nil {
+				r.Projects = append(r.Projects, newProject(p))
+			}
+		}
+	}
+
+	return r
+}
+
+// Write writes the report as JSON to a new file in dir and returns its path. If dir is
+// blank the OS temp directory is used.
+func (r *Report) Write(dir string) (string, error) {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(dir, "infracost-crash-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	_, err = f.Write(logredact.Bytes(b))
+	if err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+func newProject(p *config.Project) *Project {
+	if p == nil {
+		return nil
+	}
+
+	project := &Project{
+		Path:                  p.Path,
+		TerraformWorkspace:    p.TerraformWorkspace,
+		HCLOnly:               p.TerraformParseHCL,
+		HasUsageFile:          p.UsageFile != "",
+		HasTerraformCloudHost: p.TerraformCloudHost != "",
+		TerraformVarFileCount: len(p.TerraformVarFiles),
+		TerraformVarNames:     sortedKeys(p.TerraformVars),
+		EnvNames:              sortedKeys(p.Env),
+	}
+
+	// Only keep the binary name since the full path can contain the user's home directory.
+	if p.TerraformBinary != "" {
+		project.TerraformBinary = filepath.Base(p.TerraformBinary)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(p.Path, "*.tf"))
+	if err == nil {
+		project.TerraformFileCount = len(matches)
+	}
+
+	return project
+}
+
+func sortedKeys(m map[string]string) []string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
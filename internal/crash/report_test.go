@@ -0,0 +1,48 @@
+package crash
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func TestReportRedactsConfig(t *testing.T) {
+	project := &config.Project{
+		Path:                "testdata/project",
+		TerraformBinary:     "/home/user/bin/terraform",
+		TerraformCloudToken: "secret-token",
+		TerraformVars:       map[string]string{"db_password": "secret-password"},
+		Env:                 map[string]string{"AWS_SECRET_ACCESS_KEY": "secret-key"},
+	}
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.APIKey = "secret-api-key"
+	ctx.Config.Projects = []*config.Project{project}
+
+	report := NewReport(ctx, "runtime error: index out of range", []byte("goroutine 1 [running]:"), project)
+
+	path, err := report.Write(t.TempDir())
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "secret")
+
+	var actual Report
+	require.NoError(t, json.Unmarshal(b, &actual))
+
+	assert.Equal(t, "runtime error: index out of range", actual.Error)
+	assert.Equal(t, "goroutine 1 [running]:", actual.Stack)
+	assert.Equal(t, &Project{
+		Path:              "testdata/project",
+		TerraformBinary:   "terraform",
+		TerraformVarNames: []string{"db_password"},
+		EnvNames:          []string{"AWS_SECRET_ACCESS_KEY"},
+	}, actual.Project)
+	assert.Len(t, actual.Projects, 1)
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/httpclient"
 )
 
 // AuthedAPIClient represents an API client for authorized requests.
@@ -19,10 +21,13 @@ type AuthedAPIClient struct {
 
 // NewAuthedAPIClient returns a new API client.
 func NewAuthedAPIClient(host, token string) *AuthedAPIClient {
+	client := httpclient.NewClient(httpclient.PurposeRemoteVariables)
+	client.Timeout = time.Second * 5
+
 	return &AuthedAPIClient{
 		host:   host,
 		token:  token,
-		client: &http.Client{Timeout: time.Second * 5},
+		client: client,
 	}
 }
 
@@ -8,6 +8,8 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/infracost/infracost/internal/address"
 )
 
 // Attribute provides a wrapper struct around hcl.Attribute it provides
@@ -15,14 +17,14 @@ import (
 //
 // Attributes are key/value pairs that are part of a Block. For example take the following Block:
 //
-//		resource "aws_instance" "t3_standard" {
-//		  	ami           = "fake_ami"
-//  		instance_type = "t3.medium"
+//			resource "aws_instance" "t3_standard" {
+//			  	ami           = "fake_ami"
+//	 		instance_type = "t3.medium"
 //
-//  		credit_specification {
-//    			cpu_credits = "standard"
-//  		}
-//		}
+//	 		credit_specification {
+//	   			cpu_credits = "standard"
+//	 		}
+//			}
 //
 // "ami" & "instance_type" are the Attributes of this Block, "credit_specification" is a child Block
 // see Block.Children for more info.
@@ -119,7 +121,7 @@ func createDotReferenceFromTraversal(traversals ...hcl.Traversal) (*Reference, e
 func getIndexValue(part hcl.TraverseIndex) string {
 	switch part.Key.Type() {
 	case cty.String:
-		return fmt.Sprintf("%q", part.Key.AsString())
+		return address.QuoteString(part.Key.AsString())
 	case cty.Number:
 		var intVal int
 		if err := gocty.FromCtyValue(part.Key, &intVal); err != nil {
@@ -136,25 +138,25 @@ func getIndexValue(part hcl.TraverseIndex) string {
 // referenced block. Reference achieves this by traversing the Attribute Expression in order to find the
 // parent block. E.g. with the following HCL
 //
-// 		resource "aws_launch_template" "foo2" {
-// 			name = "foo2"
-// 		}
+//	resource "aws_launch_template" "foo2" {
+//		name = "foo2"
+//	}
 //
-//		resource "some_resource" "example_with_launch_template_3" {
-//			...
-//			name    = aws_launch_template.foo2.name
-//		}
+//	resource "some_resource" "example_with_launch_template_3" {
+//		...
+//		name    = aws_launch_template.foo2.name
+//	}
 //
 // The Attribute some_resource.name would have a reference of
 //
-//		Reference {
-//			blockType: Type{
-//				name:                  "resource",
-//				removeTypeInReference: true,
-//			}
-//			typeLabel: "aws_launch_template"
-//			nameLabel: "foo2"
+//	Reference {
+//		blockType: Type{
+//			name:                  "resource",
+//			removeTypeInReference: true,
 //		}
+//		typeLabel: "aws_launch_template"
+//		nameLabel: "foo2"
+//	}
 //
 // Reference is used to build up a Terraform JSON configuration file that holds information about the expressions
 // and their parents. Infracost uses these references in resource evaluation to lookup connecting resource information.
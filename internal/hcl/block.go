@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/infracost/infracost/internal/address"
 )
 
 var terraformSchemaV012 = &hcl.BodySchema{
@@ -47,9 +49,24 @@ var terraformSchemaV012 = &hcl.BodySchema{
 			Type:       "data",
 			LabelNames: []string{"type", "name"},
 		},
+		{
+			Type:       "check",
+			LabelNames: []string{"name"},
+		},
+		{
+			Type:       "ephemeral",
+			LabelNames: []string{"type", "name"},
+		},
 	},
 }
 
+// skippedBlockTypes are the top level Block types that are parsed but not evaluated, since they don't
+// create any infrastructure, with the reason they're skipped.
+var skippedBlockTypes = map[string]string{
+	"check":     "check blocks only run assertions against the infrastructure",
+	"ephemeral": "ephemeral resources aren't persisted to the plan or state",
+}
+
 // referencedBlocks is a helper in interface adheres to the sort.Interface interface.
 // This enables us to sort the blocks by their references to provide a list order
 // safe for context evaluation.
@@ -129,22 +146,22 @@ func (blocks Blocks) OfType(t string) Blocks {
 //
 // e.g. a type resource block could look like this in HCL:
 //
-// 		resource "aws_lb" "lb1" {
-//   		load_balancer_type = "application"
-// 		}
+//			resource "aws_lb" "lb1" {
+//	  		load_balancer_type = "application"
+//			}
 //
 // A Block can also have a set number of child Blocks, these child Blocks in turn can also have children.
 // Blocks are recursive. The following example is represents a resource Block with child Blocks:
 //
-//		resource "aws_instance" "t3_standard_cpuCredits" {
-//		  	ami           = "fake_ami"
-//  		instance_type = "t3.medium"
+//			resource "aws_instance" "t3_standard_cpuCredits" {
+//			  	ami           = "fake_ami"
+//	 		instance_type = "t3.medium"
 //
-//			# child Block starts here
-//  		credit_specification {
-//    			cpu_credits = "standard"
-//  		}
-//		}
+//				# child Block starts here
+//	 		credit_specification {
+//	   			cpu_credits = "standard"
+//	 		}
+//			}
 //
 // See Attribute for more info about how the values of Blocks are evaluated with their Context and returned.
 type Block struct {
@@ -170,6 +187,10 @@ type Block struct {
 // BlockBuilder handles generating new Blocks as part of the parsing and evaluation process.
 type BlockBuilder struct {
 	SetAttributes []SetAttributesFunc
+	// HardenedLimits are the limits that the files of modules are parsed with, if they're set.
+	HardenedLimits *HardenedLimits
+	// DirectoryLimits are the limits on the files of modules and how deep they can be nested.
+	DirectoryLimits DirectoryLimits
 }
 
 // NewBlock returns a Block with Context and child Blocks initialised.
@@ -249,9 +270,9 @@ func (b BlockBuilder) CloneBlock(block *Block, index cty.Value) *Block {
 			switch index.Type() {
 			case cty.Number:
 				f, _ := index.AsBigFloat().Float64()
-				labels[position] = fmt.Sprintf("%s[%d]", clone.hclBlock.Labels[position], int(f))
+				labels[position] = clone.hclBlock.Labels[position] + address.IntKey(int(f)).String()
 			case cty.String:
-				labels[position] = fmt.Sprintf("%s[%q]", clone.hclBlock.Labels[position], index.AsString())
+				labels[position] = clone.hclBlock.Labels[position] + address.StringKey(index.AsString()).String()
 			default:
 				log.Debugf("Invalid key type in iterable: %#v", index.Type())
 				labels[position] = fmt.Sprintf("%s[%#v]", clone.hclBlock.Labels[position], index)
@@ -273,7 +294,7 @@ func (b BlockBuilder) CloneBlock(block *Block, index cty.Value) *Block {
 // BuildModuleBlocks loads all the Blocks for the module at the given path
 func (b BlockBuilder) BuildModuleBlocks(block *Block, modulePath string) (Blocks, error) {
 	var blocks Blocks
-	moduleFiles, err := loadDirectory(modulePath, true)
+	moduleFiles, _, err := loadDirectory(modulePath, true, nil, b.HardenedLimits, b.DirectoryLimits, nil)
 	if err != nil {
 		return blocks, fmt.Errorf("failed to load module %s: %w", block.Label(), err)
 	}
@@ -471,18 +492,18 @@ func (b *Block) Provider() string {
 // GetChildBlock returns the first child Block that has the name provided. e.g:
 // If the current Block looks like such:
 //
-//		resource "aws_instance" "t3_standard_cpuCredits" {
-//		  	ami           = "fake_ami"
-//  		instance_type = "t3.medium"
+//			resource "aws_instance" "t3_standard_cpuCredits" {
+//			  	ami           = "fake_ami"
+//	 		instance_type = "t3.medium"
 //
-//  		credit_specification {
-//    			cpu_credits = "standard"
-//  		}
+//	 		credit_specification {
+//	   			cpu_credits = "standard"
+//	 		}
 //
-//			ebs_block_device {
-//				device_name = "xvdj"
+//				ebs_block_device {
+//					device_name = "xvdj"
+//				}
 //			}
-//		}
 //
 // Then "credit_specification" &  "ebs_block_device" would be valid names that could be used to retrieve child Blocks.
 func (b *Block) GetChildBlock(name string) *Block {
@@ -515,14 +536,14 @@ func (b *Block) Children() Blocks {
 // GetAttributes returns a list of Attribute for this Block. Attributes are key value specification on a given
 // Block. For example take the following hcl:
 //
-//		resource "aws_instance" "t3_standard_cpuCredits" {
-//		  	ami           = "fake_ami"
-//  		instance_type = "t3.medium"
+//			resource "aws_instance" "t3_standard_cpuCredits" {
+//			  	ami           = "fake_ami"
+//	 		instance_type = "t3.medium"
 //
-//  		credit_specification {
-//    			cpu_credits = "standard"
-//  		}
-//		}
+//	 		credit_specification {
+//	   			cpu_credits = "standard"
+//	 		}
+//			}
 //
 // ami & instance_type are the Attributes of this Block and credit_specification is a child Block.
 func (b *Block) GetAttributes() []*Attribute {
@@ -541,14 +562,14 @@ func (b *Block) GetAttributes() []*Attribute {
 // GetAttribute returns the given attribute with the provided name. It will return nil if the attribute is not found.
 // If we take the following Block example:
 //
-//		resource "aws_instance" "t3_standard_cpuCredits" {
-//		  	ami           = "fake_ami"
-//  		instance_type = "t3.medium"
+//			resource "aws_instance" "t3_standard_cpuCredits" {
+//			  	ami           = "fake_ami"
+//	 		instance_type = "t3.medium"
 //
-//  		credit_specification {
-//    			cpu_credits = "standard"
-//  		}
-//		}
+//	 		credit_specification {
+//	   			cpu_credits = "standard"
+//	 		}
+//			}
 //
 // ami & instance_type are both valid Attribute names that can be used to lookup Block Attributes.
 func (b *Block) GetAttribute(name string) *Attribute {
@@ -602,14 +623,14 @@ func (b *Block) getHCLAttributes() hcl.Attributes {
 // Values returns the Block as a cty.Value with all the Attributes evaluated with the Block Context.
 // This means that any variables or references will be replaced by their actual value. For example:
 //
-//		variable "instance_type" {
-//			default = "t3.medium"
-//		}
+//			variable "instance_type" {
+//				default = "t3.medium"
+//			}
 //
-//		resource "aws_instance" "t3_standard_cpucredits" {
-//		  	ami           = "fake_ami"
-//  		instance_type = var.instance_type
-//		}
+//			resource "aws_instance" "t3_standard_cpucredits" {
+//			  	ami           = "fake_ami"
+//	 		instance_type = var.instance_type
+//			}
 //
 // Would evaluate to a cty.Value of type Object with the instance_type Attribute holding the value "t3.medium".
 func (b *Block) Values() cty.Value {
@@ -645,10 +666,10 @@ func (b *Block) LocalName() string {
 //
 // The following resource residing in a module named "web_app":
 //
-//		resource "aws_instance" "t3_standard" {
-//		  	ami           = "fake_ami"
-//  		instance_type = var.instance_type
-//		}
+//			resource "aws_instance" "t3_standard" {
+//			  	ami           = "fake_ami"
+//	 		instance_type = var.instance_type
+//			}
 //
 // Would have its FullName as module.web_app.aws_instance.t3_standard
 // FullName is what Terraform uses in its JSON output file.
@@ -0,0 +1,76 @@
+package hcl
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Outputs returns the values of the output blocks of the Module that could be evaluated. Outputs
+// that are null or aren't wholly known, e.g. because they reference an ID that's only known after
+// apply, aren't returned.
+func (m *Module) Outputs() map[string]cty.Value {
+	outputs := map[string]cty.Value{}
+
+	for _, b := range m.Blocks.OfType("output") {
+		attr := b.GetAttribute("value")
+		if attr == nil {
+			continue
+		}
+
+		val, _ := attr.Value().UnmarkDeep()
+		if val == cty.NilVal || val.IsNull() || !val.IsWhollyKnown() {
+			continue
+		}
+
+		outputs[b.Label()] = val
+	}
+
+	return outputs
+}
+
+// DependencyOutputsSource sets input variables to the outputs of the projects that a project
+// depends on.
+type DependencyOutputsSource struct {
+	values map[string]interface{}
+}
+
+// NewDependencyOutputsSource returns a VariableSource for the given JSON compatible values, e.g.
+// the outputs read from a Terraform state file or mock outputs from the config file.
+func NewDependencyOutputsSource(values map[string]interface{}) *DependencyOutputsSource {
+	return &DependencyOutputsSource{values: values}
+}
+
+// Name describes the source.
+func (s *DependencyOutputsSource) Name() string {
+	return "project dependency outputs"
+}
+
+// Load converts the values to cty values, with the types implied by their JSON, e.g. lists are
+// tuples and maps are objects, the same as the outputs of a terraform_remote_state data source.
+func (s *DependencyOutputsSource) Load() (map[string]cty.Value, error) {
+	vars := make(map[string]cty.Value, len(s.values))
+
+	for k, v := range s.values {
+		// the values from the config file can be map[interface{}]interface{}, which the std lib
+		// json can't marshal.
+		b, err := jsoniter.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for variable %s: %w", k, err)
+		}
+
+		ty, err := ctyjson.ImpliedType(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for variable %s: %w", k, err)
+		}
+
+		vars[k], err = ctyjson.Unmarshal(b, ty)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for variable %s: %w", k, err)
+		}
+	}
+
+	return vars, nil
+}
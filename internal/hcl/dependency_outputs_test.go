@@ -0,0 +1,68 @@
+package hcl
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestModuleOutputs(t *testing.T) {
+	path := createTestFile("test.tf", `
+variable "instance_type" {
+	default = "t3.micro"
+}
+
+resource "aws_vpc" "main" {
+	cidr_block = "10.0.0.0/16"
+}
+
+output "instance_type" {
+	value = var.instance_type
+}
+
+output "azs" {
+	value = ["us-east-1a", "us-east-1b"]
+}
+
+output "vpc_id" {
+	value = aws_vpc.main.id
+}
+
+output "empty" {
+	value = null
+}
+`)
+
+	module, err := New(filepath.Dir(path)).ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	outputs := module.Outputs()
+	assert.Equal(t, cty.StringVal("t3.micro"), outputs["instance_type"])
+	assert.Equal(t, cty.TupleVal([]cty.Value{cty.StringVal("us-east-1a"), cty.StringVal("us-east-1b")}), outputs["azs"])
+	assert.NotContains(t, outputs, "empty")
+}
+
+func TestDependencyOutputsSource(t *testing.T) {
+	s := NewDependencyOutputsSource(map[string]interface{}{
+		"vpc_id":  "vpc-123",
+		"count":   2,
+		"subnets": []interface{}{"a", "b"},
+		"tags":    map[interface{}]interface{}{"team": "network"},
+	})
+
+	vars, err := s.Load()
+	require.NoError(t, err)
+	// numbers are parsed with a higher precision so they're compared by value.
+	assert.True(t, vars["count"].Equals(cty.NumberIntVal(2)).True())
+	delete(vars, "count")
+
+	assert.Equal(t, map[string]cty.Value{
+		"vpc_id":  cty.StringVal("vpc-123"),
+		"subnets": cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+		"tags":    cty.ObjectVal(map[string]cty.Value{"team": cty.StringVal("network")}),
+	}, vars)
+}
@@ -0,0 +1,201 @@
+package hcl
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// The reasons that an Attribute could not be evaluated to a known value.
+const (
+	ReasonMissingVariable      = "missing_variable"
+	ReasonUnsupportedFunction  = "unsupported_function"
+	ReasonUnresolvedDataSource = "unresolved_data_source"
+	ReasonUnresolvedReference  = "unresolved_reference"
+	ReasonEphemeralResource    = "ephemeral_resource"
+	ReasonEvaluationError      = "evaluation_error"
+	ReasonUnknownValue         = "unknown_value"
+)
+
+// unknownFunctionDiagSummary is the summary of the hcl.Diagnostic returned when an expression calls a
+// function that isn't in the Context.
+const unknownFunctionDiagSummary = "Call to unknown function"
+
+// UnresolvedAttribute is an Attribute that evaluated to an unknown value, with the reason why. These are used to
+// debug why a resource was skipped or has the wrong cost when parsing HCL.
+type UnresolvedAttribute struct {
+	// Address is the full name of the Block the Attribute belongs to, e.g. module.web.aws_instance.this.
+	Address string `json:"address"`
+	// Attribute is the name of the Attribute, prefixed with any nested Block types, e.g. root_block_device.volume_size.
+	Attribute string `json:"attribute"`
+	Filename  string `json:"filename"`
+	Line      int    `json:"line"`
+	Reason    string `json:"reason"`
+	// Reference is the value that couldn't be resolved, e.g. var.instance_type or data.aws_ami.ubuntu.
+	Reference string `json:"reference,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// UnresolvedAttributes returns all the Attributes in the Module and its child Modules that evaluated to an
+// unknown value, sorted by Address and Attribute.
+func (m *Module) UnresolvedAttributes() []UnresolvedAttribute {
+	var unresolved []UnresolvedAttribute
+
+	for _, b := range m.Blocks {
+		if _, ok := skippedBlockTypes[b.Type()]; ok || b.Type() == "variable" {
+			continue
+		}
+
+		unresolved = append(unresolved, blockUnresolvedAttributes(b.FullName(), "", b)...)
+	}
+
+	for _, child := range m.Modules {
+		unresolved = append(unresolved, child.UnresolvedAttributes()...)
+	}
+
+	sort.SliceStable(unresolved, func(i, j int) bool {
+		if unresolved[i].Address != unresolved[j].Address {
+			return unresolved[i].Address < unresolved[j].Address
+		}
+
+		return unresolved[i].Attribute < unresolved[j].Attribute
+	})
+
+	return unresolved
+}
+
+// SkippedBlock is a Block that was parsed but not evaluated because it doesn't create any infrastructure,
+// e.g. a check block.
+type SkippedBlock struct {
+	Address  string `json:"address"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	Reason   string `json:"reason"`
+}
+
+// SkippedBlocks returns all the Blocks in the Module and its child Modules that weren't evaluated, sorted
+// by Address.
+func (m *Module) SkippedBlocks() []SkippedBlock {
+	var skipped []SkippedBlock
+
+	for _, b := range m.Blocks {
+		reason, ok := skippedBlockTypes[b.Type()]
+		if !ok {
+			continue
+		}
+
+		r := b.hclBlock.DefRange
+		skipped = append(skipped, SkippedBlock{
+			Address:  b.FullName(),
+			Filename: r.Filename,
+			Line:     r.Start.Line,
+			Reason:   reason,
+		})
+	}
+
+	for _, child := range m.Modules {
+		skipped = append(skipped, child.SkippedBlocks()...)
+	}
+
+	sort.SliceStable(skipped, func(i, j int) bool {
+		return skipped[i].Address < skipped[j].Address
+	})
+
+	return skipped
+}
+
+func blockUnresolvedAttributes(address string, prefix string, b *Block) []UnresolvedAttribute {
+	var unresolved []UnresolvedAttribute
+
+	for _, attr := range b.GetAttributes() {
+		reason, reference, detail, ok := unresolvedReason(attr)
+		if !ok {
+			continue
+		}
+
+		r := attr.HCLAttr.Range
+		unresolved = append(unresolved, UnresolvedAttribute{
+			Address:   address,
+			Attribute: prefix + attr.Name(),
+			Filename:  r.Filename,
+			Line:      r.Start.Line,
+			Reason:    reason,
+			Reference: reference,
+			Detail:    detail,
+		})
+	}
+
+	for _, child := range b.Children() {
+		// dynamic Blocks are templates that are expanded into their content, so the
+		// iterator references in them can't be evaluated.
+		if child.Type() == "dynamic" {
+			continue
+		}
+
+		unresolved = append(unresolved, blockUnresolvedAttributes(address, prefix+child.Type()+".", child)...)
+	}
+
+	return unresolved
+}
+
+// unresolvedReason evaluates the Attribute and returns the reason that it is unknown and the reference that
+// caused it. It returns false if the Attribute evaluates to a known value.
+func unresolvedReason(attr *Attribute) (reason string, reference string, detail string, ok bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			reason, reference, detail, ok = ReasonEvaluationError, "", "panic evaluating expression", true
+		}
+	}()
+
+	ctx := attr.Ctx.Inner()
+
+	val, diags := attr.HCLAttr.Expr.Value(ctx)
+	if !diags.HasErrors() && val.IsWhollyKnown() {
+		return "", "", "", false
+	}
+
+	for _, diag := range diags {
+		if diag.Severity == hcl.DiagError && diag.Summary == unknownFunctionDiagSummary {
+			return ReasonUnsupportedFunction, "", providerFunctionDisplayNames(diag.Detail), true
+		}
+	}
+
+	for _, traversal := range attr.HCLAttr.Expr.Variables() {
+		v, tDiags := traversal.TraverseAbs(ctx)
+		if !tDiags.HasErrors() && v.IsWhollyKnown() {
+			continue
+		}
+
+		key := traversalKey(traversal)
+		if key == "" {
+			key = traversal.RootName()
+		}
+
+		switch traversal.RootName() {
+		case "var":
+			return ReasonMissingVariable, key, "", true
+		case "data":
+			return ReasonUnresolvedDataSource, key, "", true
+		case "ephemeral":
+			return ReasonEphemeralResource, key, "", true
+		default:
+			return ReasonUnresolvedReference, key, "", true
+		}
+	}
+
+	if diags.HasErrors() {
+		return ReasonEvaluationError, "", diagsDetail(diags), true
+	}
+
+	return ReasonUnknownValue, "", "", true
+}
+
+func diagsDetail(diags hcl.Diagnostics) string {
+	var details []string
+	for _, diag := range diags.Errs() {
+		details = append(details, diag.Error())
+	}
+
+	return providerFunctionDisplayNames(strings.Join(details, "; "))
+}
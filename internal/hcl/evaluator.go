@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"reflect"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -46,6 +45,9 @@ type Evaluator struct {
 	moduleMetadata *modules.Manifest
 	// visitedModules is a lookup map to hold information by the Evaluator of modules that it has already evaluated.
 	visitedModules map[string]struct{}
+	// moduleCache holds the evaluated Modules of module calls so that calls to the same module with the same
+	// inputs are only evaluated once. It is shared between the Evaluator and its child Evaluators.
+	moduleCache *moduleCache
 	// module defines the input and module path for the Evaluator. It is the root module of the config.
 	module Module
 	// workingDir is the current directory the evaluator is running within. This is used to set Context information on
@@ -56,8 +58,16 @@ type Evaluator struct {
 	// blockBuilder handles generating blocks in the evaluation step.
 	blockBuilder BlockBuilder
 	newSpinner   ui.SpinnerFunc
+	// onModuleEvaluated is called with each child Module once its evaluation has finished. It is shared
+	// between the Evaluator and its child Evaluators.
+	onModuleEvaluated ModuleEvaluatedFunc
 }
 
+// ModuleEvaluatedFunc is called with a child Module once it has been evaluated. Each module call is only
+// evaluated once, so the Blocks of the Module don't change after this is called. The Modules of
+// the Module have already been passed to the func.
+type ModuleEvaluatedFunc func(module *Module)
+
 // NewEvaluator returns an Evaluator with Context initialised with top level variables.
 // This Context is then passed to all Blocks as child Context so that variables built in Evaluation
 // are propagated to the Block Attributes.
@@ -67,18 +77,28 @@ func NewEvaluator(
 	inputVars map[string]cty.Value,
 	moduleMetadata *modules.Manifest,
 	visitedModules map[string]struct{},
+	cache *moduleCache,
 	workspace string,
 	blockBuilder BlockBuilder,
 	spinFunc ui.SpinnerFunc,
 ) *Evaluator {
+	functions := expFunctions(module.ModulePath)
+	for name, f := range providerFunctions(module.Blocks) {
+		functions[name] = f
+	}
+
 	ctx := NewContext(&hcl.EvalContext{
-		Functions: expFunctions(module.ModulePath),
+		Functions: functions,
 	}, nil)
 
 	if visitedModules == nil {
 		visitedModules = make(map[string]struct{})
 	}
 
+	if cache == nil {
+		cache = newModuleCache()
+	}
+
 	// set the global evaluation parameters.
 	ctx.SetByDot(cty.StringVal(workspace), "terraform.workspace")
 	ctx.SetByDot(cty.StringVal(module.RootPath), "path.root")
@@ -95,6 +115,7 @@ func NewEvaluator(
 		inputVars:      inputVars,
 		moduleMetadata: moduleMetadata,
 		visitedModules: visitedModules,
+		moduleCache:    cache,
 		workspace:      workspace,
 		blockBuilder:   blockBuilder,
 		newSpinner:     spinFunc,
@@ -126,18 +147,17 @@ func (e *Evaluator) Run() (*Module, error) {
 		defer spin.Success()
 	}
 
-	var lastContext hcl.EvalContext
 	// first we need to evaluate the top level Context - so this can be passed to any child modules that are found.
-	e.evaluate(lastContext)
+	e.evaluate()
 
 	// let's load the modules now we have our top level context.
 	e.moduleCalls = e.loadModules()
-	e.evaluate(lastContext)
+	e.evaluate()
 
 	// expand out resources and modules via count and evaluate again so that we can include
 	// any module outputs and or count references.
 	e.module.Blocks = e.expandBlocks(e.module.Blocks)
-	e.evaluate(lastContext)
+	e.evaluate()
 
 	// returns all the evaluated Blocks under their given Module.
 	return e.collectModules(), nil
@@ -148,85 +168,80 @@ func (e *Evaluator) collectModules() *Module {
 		e.module.Modules = append(e.module.Modules, definition.Module)
 	}
 
+	e.module.ctx = e.ctx
+
 	return &e.module
 }
 
-func (e *Evaluator) evaluate(lastContext hcl.EvalContext) {
-	// TODO: we need to work out why the evaluation step takes place in a loop. I have been unable to decipher
-	// why we need to evaluate the context at least twice. No tests that we've seen can replicated the scenario
-	// where lastContext.Variables change after the first `evaluateStep`. We should reach out to tfsec guys and
-	// work out why this loop was put in there. If they can't come up with a good reason we can nuke it and
-	// make the evaluate method much easier to understand.
-	for i := 0; i < maxContextIterations; i++ {
-		e.evaluateStep(i)
-
-		if reflect.DeepEqual(lastContext.Variables, e.ctx.Inner().Variables) {
-			break
-		}
-
-		if len(e.ctx.Inner().Variables) != len(lastContext.Variables) {
-			lastContext.Variables = make(map[string]cty.Value, len(e.ctx.Inner().Variables))
-		}
-
-		for k, v := range e.ctx.Inner().Variables {
-			lastContext.Variables[k] = v
-		}
+// evaluateModuleCall runs a child Evaluator on the module call's Blocks. It passes the Evaluator the top level
+// module Attributes as input variables and sets the module outputs on the Context.
+func (e *Evaluator) evaluateModuleCall(moduleCall *ModuleCall) {
+	if _, ok := e.visitedModules[moduleCall.Definition.FullName()]; ok {
+		return
 	}
-}
 
-// evaluateStep gets the values for all the Block types in the current Module that affect Context.
-// It then sets these values on the Context so that they can be used in Block Attribute evaluation.
-func (e *Evaluator) evaluateStep(i int) {
-	log.Debugf("Starting context evaluation for module %s iteration %d", e.module.ModulePath, i+1)
+	e.visitedModules[moduleCall.Definition.FullName()] = struct{}{}
 
-	e.ctx.Set(e.getValuesByBlockType("variable"), "var")
-	e.ctx.Set(e.getValuesByBlockType("locals"), "local")
-	e.ctx.Set(e.getValuesByBlockType("provider"), "provider")
+	vars := moduleCall.Definition.Values().AsValueMap()
+	if cached, ok := e.moduleCache.get(moduleCall.Path, vars); ok {
+		log.Debugf("Using cached evaluation of module %s for %s", moduleCall.Path, moduleCall.Definition.FullName())
 
-	resources := e.getValuesByBlockType("resource")
-	for key, resource := range resources.AsValueMap() {
-		e.ctx.Set(resource, key)
+		moduleCall.Module = cloneModule(cached.module, moduleCall.Definition, &e.module)
+		e.ctx.Set(cached.outputs, "module", moduleCall.Name)
+		e.clonedModuleEvaluated(moduleCall.Module)
+		return
 	}
 
-	e.ctx.Set(e.getValuesByBlockType("data"), "data")
-	e.ctx.Set(e.getValuesByBlockType("output"), "output")
+	moduleEvaluator := NewEvaluator(
+		Module{
+			Name:       moduleCall.Definition.FullName(),
+			Source:     moduleCall.Module.Source,
+			Blocks:     moduleCall.Module.Blocks,
+			RootPath:   e.module.RootPath,
+			ModulePath: moduleCall.Path,
+			Modules:    nil,
+			Parent:     &e.module,
+		},
+		e.workingDir,
+		vars,
+		e.moduleMetadata,
+		e.visitedModules,
+		e.moduleCache,
+		e.workspace,
+		e.blockBuilder,
+		nil,
+	)
+	moduleEvaluator.onModuleEvaluated = e.onModuleEvaluated
+
+	moduleCall.Module, _ = moduleEvaluator.Run()
+	outputs := moduleEvaluator.exportOutputs()
+	e.ctx.Set(outputs, "module", moduleCall.Name)
+
+	e.moduleCache.set(moduleCall.Path, vars, moduleCall.Module, outputs)
+	e.moduleEvaluated(moduleCall.Module)
+}
 
-	e.evaluateModules()
+// moduleEvaluated passes an evaluated child module to the onModuleEvaluated func, if there is one.
+func (e *Evaluator) moduleEvaluated(module *Module) {
+	if e.onModuleEvaluated == nil || module == nil {
+		return
+	}
+
+	e.onModuleEvaluated(module)
 }
 
-// evaluateModules loops over each of the moduleCalls in this Module and set a child Evaluator
-// to run on the child Module Blocks. It passes the Evaluator the top level module Attributes as input variables.
-func (e *Evaluator) evaluateModules() {
-	for _, moduleCall := range e.moduleCalls {
-		if _, ok := e.visitedModules[moduleCall.Definition.FullName()]; ok {
-			continue
-		}
+// clonedModuleEvaluated passes a module cloned from the moduleCache to the onModuleEvaluated func.
+// The child modules of the clone weren't evaluated by a child Evaluator, so they are passed first.
+func (e *Evaluator) clonedModuleEvaluated(module *Module) {
+	if e.onModuleEvaluated == nil || module == nil {
+		return
+	}
 
-		e.visitedModules[moduleCall.Definition.FullName()] = struct{}{}
-
-		vars := moduleCall.Definition.Values().AsValueMap()
-		moduleEvaluator := NewEvaluator(
-			Module{
-				Name:       moduleCall.Definition.FullName(),
-				Source:     moduleCall.Module.Source,
-				Blocks:     moduleCall.Module.Blocks,
-				RootPath:   e.module.RootPath,
-				ModulePath: moduleCall.Path,
-				Modules:    nil,
-				Parent:     &e.module,
-			},
-			e.workingDir,
-			vars,
-			e.moduleMetadata,
-			e.visitedModules,
-			e.workspace,
-			e.blockBuilder,
-			nil,
-		)
-
-		moduleCall.Module, _ = moduleEvaluator.Run()
-		e.ctx.Set(moduleEvaluator.exportOutputs(), "module", moduleCall.Name)
+	for _, child := range module.Modules {
+		e.clonedModuleEvaluated(child)
 	}
+
+	e.onModuleEvaluated(module)
 }
 
 // exportOutputs exports module outputs so that it can be used in Context evaluation.
@@ -399,58 +414,6 @@ func (e *Evaluator) evaluateOutput(b *Block) (cty.Value, error) {
 	return attribute.Value(), nil
 }
 
-func (e *Evaluator) getValuesByBlockType(blockType string) cty.Value {
-	blocksOfType := e.module.Blocks.OfType(blockType)
-	values := make(map[string]cty.Value)
-
-	for _, b := range blocksOfType {
-		switch b.Type() {
-		case "variable": // variables are special in that their value comes from the "default" attribute
-			val, err := e.evaluateVariable(b)
-			if err != nil {
-				continue
-			}
-			values[b.Label()] = val
-		case "output":
-			val, err := e.evaluateOutput(b)
-			if err != nil {
-				continue
-			}
-			values[b.Label()] = val
-		case "locals":
-			for key, val := range b.Values().AsValueMap() {
-				values[key] = val
-			}
-		case "provider", "module":
-			if b.Label() == "" {
-				continue
-			}
-			values[b.Label()] = b.Values()
-		case "resource", "data":
-			if len(b.Labels()) < 2 {
-				continue
-			}
-
-			blockMap, ok := values[b.Labels()[0]]
-			if !ok {
-				values[b.Labels()[0]] = cty.ObjectVal(make(map[string]cty.Value))
-				blockMap = values[b.Labels()[0]]
-			}
-
-			valueMap := blockMap.AsValueMap()
-			if valueMap == nil {
-				valueMap = make(map[string]cty.Value)
-			}
-
-			valueMap[b.Labels()[1]] = b.Values()
-			values[b.Labels()[0]] = cty.ObjectVal(valueMap)
-		}
-
-	}
-
-	return cty.ObjectVal(values)
-}
-
 // loadModule takes in a module "x" {} block and loads resources etc. into e.moduleBlocks.
 // Additionally, it returns variables to add to ["module.x.*"] variables
 func (e *Evaluator) loadModule(b *Block) (*ModuleCall, error) {
@@ -473,6 +436,10 @@ func (e *Evaluator) loadModule(b *Block) (*ModuleCall, error) {
 		return nil, fmt.Errorf("could not read module source attribute at %s", b.FullName())
 	}
 
+	if err := e.blockBuilder.DirectoryLimits.checkModuleDepth(&e.module, b.FullName()); err != nil {
+		return nil, err
+	}
+
 	var modulePath string
 
 	if e.moduleMetadata != nil {
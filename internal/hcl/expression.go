@@ -0,0 +1,68 @@
+package hcl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/infracost/infracost/internal/hcl/funcs"
+)
+
+// EvaluateExpression parses src as a HCL expression, e.g. module.vpc.vpc_id or var.instance_type, and evaluates it
+// against the Module in the same way as terraform console. This is used to debug why an attribute resolved to the
+// value that it did.
+func (m *Module) EvaluateExpression(src string) (cty.Value, error) {
+	if m.ctx == nil {
+		return cty.NilVal, errors.New("module has not been evaluated")
+	}
+
+	expr, diags := hclsyntax.ParseExpression(funcs.RewriteProviderFunctionCalls([]byte(src)), "<expression>", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("could not parse expression: %s", providerFunctionDisplayNames(diags.Error()))
+	}
+
+	val, diags := expr.Value(m.ctx.Inner())
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("could not evaluate expression: %s", providerFunctionDisplayNames(diags.Error()))
+	}
+
+	return val, nil
+}
+
+// FormatValue returns val as a HCL string. Unknown values, e.g. attributes that are only known after apply, are
+// shown as (known after apply).
+func FormatValue(val cty.Value) string {
+	val, _ = val.UnmarkDeep()
+
+	if !val.IsKnown() {
+		return "(known after apply)"
+	}
+
+	if val.IsWhollyKnown() {
+		return strings.TrimSpace(string(hclwrite.TokensForValue(val).Bytes()))
+	}
+
+	ty := val.Type()
+	if ty.IsListType() || ty.IsSetType() || ty.IsTupleType() {
+		var elems []string
+		for it := val.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			elems = append(elems, FormatValue(v))
+		}
+
+		return "[" + strings.Join(elems, ", ") + "]"
+	}
+
+	var attrs []string
+	for it := val.ElementIterator(); it.Next(); {
+		k, v := it.Element()
+		attrs = append(attrs, fmt.Sprintf("%s = %s", k.AsString(), FormatValue(v)))
+	}
+
+	return "{ " + strings.Join(attrs, ", ") + " }"
+}
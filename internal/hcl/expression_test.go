@@ -0,0 +1,56 @@
+package hcl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_EvaluateExpression(t *testing.T) {
+	path := createTestFileWithModule(`
+variable "instance_type" {
+	default = "t3.micro"
+}
+
+module "vpc" {
+	source = "../module"
+	cidr_block = "10.0.0.0/16"
+}
+`,
+		`
+variable "cidr_block" {}
+
+output "cidr_block" {
+	value = var.cidr_block
+}
+`,
+		"module",
+	)
+
+	parser := New(path, OptionStopOnHCLError())
+	rootModule, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	val, err := rootModule.EvaluateExpression("module.vpc.cidr_block")
+	require.NoError(t, err)
+	assert.Equal(t, `"10.0.0.0/16"`, FormatValue(val))
+
+	val, err = rootModule.EvaluateExpression(`upper(var.instance_type)`)
+	require.NoError(t, err)
+	assert.Equal(t, `"T3.MICRO"`, FormatValue(val))
+
+	_, err = rootModule.EvaluateExpression("var.")
+	assert.Error(t, err)
+}
+
+func Test_FormatValue(t *testing.T) {
+	assert.Equal(t, "(known after apply)", FormatValue(cty.UnknownVal(cty.String)))
+	assert.Equal(t, `{ id = (known after apply), name = "web" }`, FormatValue(cty.ObjectVal(map[string]cty.Value{
+		"id":   cty.UnknownVal(cty.String),
+		"name": cty.StringVal("web"),
+	})))
+	assert.Equal(t, `["a", (known after apply)]`, FormatValue(cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.UnknownVal(cty.String)})))
+}
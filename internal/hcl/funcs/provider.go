@@ -0,0 +1,357 @@
+package funcs
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// The functions in this file are implementations of provider-defined functions, which are called
+// with provider::<provider>::<name>(...) in Terraform 1.8 and later. They behave like the functions
+// of the same name in each provider so that values using them can be evaluated without running the
+// provider plugin.
+
+var providerFunctionCallReg = regexp.MustCompile(`\bprovider::([A-Za-z][\w-]*)::([A-Za-z_][\w]*)`)
+
+// RewriteProviderFunctionCalls replaces the provider-defined function calls in src, which the
+// version of HCL we use can't parse, with provider__<provider>__<name>(...). The names are the same
+// length as the calls so the ranges of the blocks and attributes in src don't change.
+func RewriteProviderFunctionCalls(src []byte) []byte {
+	if !bytes.Contains(src, []byte("provider::")) {
+		return src
+	}
+
+	return providerFunctionCallReg.ReplaceAllFunc(src, func(m []byte) []byte {
+		return bytes.ReplaceAll(m, []byte("::"), []byte("__"))
+	})
+}
+
+var awsARNType = cty.Object(map[string]cty.Type{
+	"partition":  cty.String,
+	"service":    cty.String,
+	"region":     cty.String,
+	"account_id": cty.String,
+	"resource":   cty.String,
+})
+
+// AWSARNParseFunc constructs a function that parses an ARN into its parts, like
+// provider::aws::arn_parse.
+var AWSARNParseFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "arn",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(awsARNType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		parts, err := splitARN(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+
+		return cty.ObjectVal(map[string]cty.Value{
+			"partition":  cty.StringVal(parts[1]),
+			"service":    cty.StringVal(parts[2]),
+			"region":     cty.StringVal(parts[3]),
+			"account_id": cty.StringVal(parts[4]),
+			"resource":   cty.StringVal(parts[5]),
+		}), nil
+	},
+})
+
+// AWSARNBuildFunc constructs a function that builds an ARN from its parts, like
+// provider::aws::arn_build.
+var AWSARNBuildFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "partition", Type: cty.String},
+		{Name: "service", Type: cty.String},
+		{Name: "region", Type: cty.String},
+		{Name: "account_id", Type: cty.String},
+		{Name: "resource", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		parts := []string{"arn"}
+		for _, arg := range args {
+			parts = append(parts, arg.AsString())
+		}
+
+		return cty.StringVal(strings.Join(parts, ":")), nil
+	},
+})
+
+// AWSTrimIAMRolePathFunc constructs a function that removes the path from an IAM role ARN, like
+// provider::aws::trim_iam_role_path.
+var AWSTrimIAMRolePathFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "arn",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		parts, err := splitARN(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+
+		if parts[2] != "iam" || !strings.HasPrefix(parts[5], "role/") {
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q is not an IAM role ARN", args[0].AsString())
+		}
+
+		name := parts[5][strings.LastIndex(parts[5], "/")+1:]
+		parts[5] = "role/" + name
+
+		return cty.StringVal(strings.Join(parts, ":")), nil
+	},
+})
+
+func splitARN(arn string) ([]string, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[1] == "" || parts[2] == "" || parts[5] == "" {
+		return nil, fmt.Errorf("%q is not a valid ARN", arn)
+	}
+
+	return parts, nil
+}
+
+// GoogleProjectFromIDFunc constructs a function that returns the project of a resource ID or self
+// link, like provider::google::project_from_id.
+var GoogleProjectFromIDFunc = makeGoogleIDSegmentFunc("projects")
+
+// GoogleRegionFromIDFunc constructs a function that returns the region of a resource ID or self
+// link, like provider::google::region_from_id.
+var GoogleRegionFromIDFunc = makeGoogleIDSegmentFunc("regions")
+
+// GoogleZoneFromIDFunc constructs a function that returns the zone of a resource ID or self link,
+// like provider::google::zone_from_id.
+var GoogleZoneFromIDFunc = makeGoogleIDSegmentFunc("zones")
+
+// GoogleLocationFromIDFunc constructs a function that returns the location of a resource ID or
+// self link, like provider::google::location_from_id.
+var GoogleLocationFromIDFunc = makeGoogleIDSegmentFunc("locations")
+
+// GoogleNameFromIDFunc constructs a function that returns the name of a resource from its ID or
+// self link, like provider::google::name_from_id.
+var GoogleNameFromIDFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "id",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		id := strings.TrimSuffix(args[0].AsString(), "/")
+		if !strings.Contains(id, "/") {
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q is not a valid resource ID", args[0].AsString())
+		}
+
+		return cty.StringVal(id[strings.LastIndex(id, "/")+1:]), nil
+	},
+})
+
+// GoogleRegionFromZoneFunc constructs a function that returns the region of a zone, like
+// provider::google::region_from_zone.
+var GoogleRegionFromZoneFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "zone",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		zone := args[0].AsString()
+
+		i := strings.LastIndex(zone, "-")
+		if i <= 0 {
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q is not a valid zone", zone)
+		}
+
+		return cty.StringVal(zone[:i]), nil
+	},
+})
+
+func makeGoogleIDSegmentFunc(collection string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "id",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			segments := strings.Split(args[0].AsString(), "/")
+
+			for i := 0; i < len(segments)-1; i++ {
+				if segments[i] == collection && segments[i+1] != "" {
+					return cty.StringVal(segments[i+1]), nil
+				}
+			}
+
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q doesn't contain %s", args[0].AsString(), collection)
+		},
+	})
+}
+
+var azureResourceIDType = cty.Object(map[string]cty.Type{
+	"subscription_id":     cty.String,
+	"resource_group_name": cty.String,
+	"resource_provider":   cty.String,
+	"resource_type":       cty.String,
+	"resource_name":       cty.String,
+	"full_resource_type":  cty.String,
+	"parent_resources":    cty.Map(cty.String),
+})
+
+// AzureRMParseResourceIDFunc constructs a function that parses an Azure resource ID into its parts,
+// like provider::azurerm::parse_resource_id.
+var AzureRMParseResourceIDFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "id",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(azureResourceIDType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		id := args[0].AsString()
+		segments := strings.Split(strings.Trim(id, "/"), "/")
+
+		// /subscriptions/{id}/resourceGroups/{name}/providers/{namespace}/{type}/{name}[/{type}/{name}...]
+		if len(segments) < 8 || len(segments)%2 != 0 ||
+			!strings.EqualFold(segments[0], "subscriptions") ||
+			!strings.EqualFold(segments[2], "resourceGroups") ||
+			!strings.EqualFold(segments[4], "providers") {
+			return cty.UnknownVal(retType), fmt.Errorf("%q is not a valid Azure resource ID", id)
+		}
+
+		types := []string{segments[5]}
+		parents := map[string]cty.Value{}
+		for i := 6; i < len(segments)-2; i += 2 {
+			types = append(types, segments[i])
+			parents[segments[i]] = cty.StringVal(segments[i+1])
+		}
+		types = append(types, segments[len(segments)-2])
+
+		parentResources := cty.MapValEmpty(cty.String)
+		if len(parents) > 0 {
+			parentResources = cty.MapVal(parents)
+		}
+
+		return cty.ObjectVal(map[string]cty.Value{
+			"subscription_id":     cty.StringVal(segments[1]),
+			"resource_group_name": cty.StringVal(segments[3]),
+			"resource_provider":   cty.StringVal(segments[5]),
+			"resource_type":       cty.StringVal(segments[len(segments)-2]),
+			"resource_name":       cty.StringVal(segments[len(segments)-1]),
+			"full_resource_type":  cty.StringVal(strings.Join(types, "/")),
+			"parent_resources":    parentResources,
+		}), nil
+	},
+})
+
+// TerraformEncodeTFVarsFunc constructs a function that encodes an object as the contents of a
+// .tfvars file, like provider::terraform::encode_tfvars.
+var TerraformEncodeTFVarsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "value",
+			Type: cty.DynamicPseudoType,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		v := args[0]
+		if !v.IsWhollyKnown() {
+			return cty.UnknownVal(cty.String), nil
+		}
+
+		if v.IsNull() || !(v.Type().IsObjectType() || v.Type().IsMapType()) {
+			return cty.UnknownVal(cty.String), fmt.Errorf("value must be an object")
+		}
+
+		values := v.AsValueMap()
+		names := make([]string, 0, len(values))
+		for name := range values {
+			if !hclsyntax.ValidIdentifier(name) {
+				return cty.UnknownVal(cty.String), fmt.Errorf("%q is not a valid variable name", name)
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		f := hclwrite.NewEmptyFile()
+		for _, name := range names {
+			f.Body().SetAttributeValue(name, values[name])
+		}
+
+		return cty.StringVal(string(f.Bytes())), nil
+	},
+})
+
+// TerraformDecodeTFVarsFunc constructs a function that decodes the contents of a .tfvars file into
+// an object, like provider::terraform::decode_tfvars.
+var TerraformDecodeTFVarsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "src",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		f, diags := hclsyntax.ParseConfig([]byte(args[0].AsString()), "<decode_tfvars argument>", hcl.InitialPos)
+		if diags.HasErrors() {
+			return cty.DynamicVal, fmt.Errorf("invalid tfvars: %s", diags.Error())
+		}
+
+		attrs, diags := f.Body.JustAttributes()
+		if diags.HasErrors() {
+			return cty.DynamicVal, fmt.Errorf("invalid tfvars: %s", diags.Error())
+		}
+
+		values := make(map[string]cty.Value, len(attrs))
+		for name, attr := range attrs {
+			v, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				return cty.DynamicVal, fmt.Errorf("invalid value for %s: %s", name, diags.Error())
+			}
+			values[name] = v
+		}
+
+		return cty.ObjectVal(values), nil
+	},
+})
+
+// TerraformEncodeExprFunc constructs a function that encodes a value as a Terraform expression,
+// like provider::terraform::encode_expr.
+var TerraformEncodeExprFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:      "value",
+			Type:      cty.DynamicPseudoType,
+			AllowNull: true,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if !args[0].IsWhollyKnown() {
+			return cty.UnknownVal(cty.String), nil
+		}
+
+		return cty.StringVal(string(hclwrite.TokensForValue(args[0]).Bytes())), nil
+	},
+})
@@ -0,0 +1,154 @@
+package funcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+func TestProviderFunctions(t *testing.T) {
+	tests := []struct {
+		Name string
+		Func function.Function
+		Args []cty.Value
+		Want cty.Value
+		Err  bool
+	}{
+		{
+			"arn_parse",
+			AWSARNParseFunc,
+			[]cty.Value{cty.StringVal("arn:aws:iam::444455556666:role/example")},
+			cty.ObjectVal(map[string]cty.Value{
+				"partition":  cty.StringVal("aws"),
+				"service":    cty.StringVal("iam"),
+				"region":     cty.StringVal(""),
+				"account_id": cty.StringVal("444455556666"),
+				"resource":   cty.StringVal("role/example"),
+			}),
+			false,
+		},
+		{
+			"arn_parse",
+			AWSARNParseFunc,
+			[]cty.Value{cty.StringVal("not-an-arn")},
+			cty.NilVal,
+			true,
+		},
+		{
+			"arn_build",
+			AWSARNBuildFunc,
+			[]cty.Value{cty.StringVal("aws"), cty.StringVal("ec2"), cty.StringVal("us-east-1"), cty.StringVal("444455556666"), cty.StringVal("instance/i-123")},
+			cty.StringVal("arn:aws:ec2:us-east-1:444455556666:instance/i-123"),
+			false,
+		},
+		{
+			"trim_iam_role_path",
+			AWSTrimIAMRolePathFunc,
+			[]cty.Value{cty.StringVal("arn:aws:iam::444455556666:role/with/path/example")},
+			cty.StringVal("arn:aws:iam::444455556666:role/example"),
+			false,
+		},
+		{
+			"trim_iam_role_path",
+			AWSTrimIAMRolePathFunc,
+			[]cty.Value{cty.StringVal("arn:aws:iam::444455556666:user/example")},
+			cty.NilVal,
+			true,
+		},
+		{
+			"project_from_id",
+			GoogleProjectFromIDFunc,
+			[]cty.Value{cty.StringVal("https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/web")},
+			cty.StringVal("my-project"),
+			false,
+		},
+		{
+			"zone_from_id",
+			GoogleZoneFromIDFunc,
+			[]cty.Value{cty.StringVal("projects/my-project/zones/us-central1-a/instances/web")},
+			cty.StringVal("us-central1-a"),
+			false,
+		},
+		{
+			"region_from_id",
+			GoogleRegionFromIDFunc,
+			[]cty.Value{cty.StringVal("projects/my-project/zones/us-central1-a/instances/web")},
+			cty.NilVal,
+			true,
+		},
+		{
+			"name_from_id",
+			GoogleNameFromIDFunc,
+			[]cty.Value{cty.StringVal("projects/my-project/zones/us-central1-a/instances/web")},
+			cty.StringVal("web"),
+			false,
+		},
+		{
+			"region_from_zone",
+			GoogleRegionFromZoneFunc,
+			[]cty.Value{cty.StringVal("europe-west1-b")},
+			cty.StringVal("europe-west1"),
+			false,
+		},
+		{
+			"parse_resource_id",
+			AzureRMParseResourceIDFunc,
+			[]cty.Value{cty.StringVal("/subscriptions/0000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/web")},
+			cty.ObjectVal(map[string]cty.Value{
+				"subscription_id":     cty.StringVal("0000"),
+				"resource_group_name": cty.StringVal("rg"),
+				"resource_provider":   cty.StringVal("Microsoft.Network"),
+				"resource_type":       cty.StringVal("subnets"),
+				"resource_name":       cty.StringVal("web"),
+				"full_resource_type":  cty.StringVal("Microsoft.Network/virtualNetworks/subnets"),
+				"parent_resources":    cty.MapVal(map[string]cty.Value{"virtualNetworks": cty.StringVal("vnet")}),
+			}),
+			false,
+		},
+		{
+			"encode_tfvars",
+			TerraformEncodeTFVarsFunc,
+			[]cty.Value{cty.ObjectVal(map[string]cty.Value{"instance_type": cty.StringVal("t3.micro"), "count": cty.NumberIntVal(2)})},
+			cty.StringVal("count         = 2\ninstance_type = \"t3.micro\"\n"),
+			false,
+		},
+		{
+			"decode_tfvars",
+			TerraformDecodeTFVarsFunc,
+			[]cty.Value{cty.StringVal("instance_type = \"t3.micro\"\nzones = [\"a\"]\n")},
+			cty.ObjectVal(map[string]cty.Value{
+				"instance_type": cty.StringVal("t3.micro"),
+				"zones":         cty.TupleVal([]cty.Value{cty.StringVal("a")}),
+			}),
+			false,
+		},
+		{
+			"encode_expr",
+			TerraformEncodeExprFunc,
+			[]cty.Value{cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")})},
+			cty.StringVal(`["a", "b"]`),
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s(%#v)", test.Name, test.Args), func(t *testing.T) {
+			got, err := test.Func.Call(test.Args)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package hcl
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// graphNode is a single addressable value in a Module, e.g. var.foo, local.bar or aws_instance.web. All the
+// expanded count and for_each Blocks of a resource, data or module Block share the same node since references
+// to them can't be resolved to a single instance until they're evaluated.
+type graphNode struct {
+	key string
+	// dependsOn are the keys of the nodes that this node references.
+	dependsOn map[string]struct{}
+	// evaluate sets the value of the node on the Evaluator Context.
+	evaluate func()
+}
+
+// evaluationGraph is a dependency graph of the values in a Module. It is used to evaluate the values in an
+// order where every value is evaluated after the values that it references.
+type evaluationGraph struct {
+	// nodes are kept in the order they're added so that the evaluation order is stable.
+	nodes []*graphNode
+	index map[string]*graphNode
+}
+
+func newEvaluationGraph() *evaluationGraph {
+	return &evaluationGraph{
+		index: make(map[string]*graphNode),
+	}
+}
+
+// add adds the node to the graph. If a node with the same key already exists the dependencies and evaluate
+// funcs are merged, this happens for expanded Blocks.
+func (g *evaluationGraph) add(key string, traversals []hcl.Traversal, evaluate func()) {
+	n, ok := g.index[key]
+	if !ok {
+		n = &graphNode{key: key, dependsOn: make(map[string]struct{})}
+		g.index[key] = n
+		g.nodes = append(g.nodes, n)
+	}
+
+	for _, t := range traversals {
+		if dep := traversalKey(t); dep != "" && dep != key {
+			n.dependsOn[dep] = struct{}{}
+		}
+	}
+
+	if n.evaluate == nil {
+		n.evaluate = evaluate
+		return
+	}
+
+	prev := n.evaluate
+	n.evaluate = func() {
+		prev()
+		evaluate()
+	}
+}
+
+// sort returns the nodes in topological order, so every node comes after the nodes it depends on, and the nodes
+// that are part of a dependency cycle, or depend on a node that is, which can't be ordered. References to values
+// that aren't in the graph, e.g. a variable that isn't defined, are ignored.
+func (g *evaluationGraph) sort() ([]*graphNode, []*graphNode) {
+	inDegree := make(map[string]int, len(g.nodes))
+	dependents := make(map[string][]*graphNode, len(g.nodes))
+
+	for _, n := range g.nodes {
+		inDegree[n.key] += 0
+
+		deps := make([]string, 0, len(n.dependsOn))
+		for dep := range n.dependsOn {
+			deps = append(deps, dep)
+		}
+		sort.Strings(deps)
+
+		for _, dep := range deps {
+			if _, ok := g.index[dep]; !ok {
+				continue
+			}
+
+			inDegree[n.key]++
+			dependents[dep] = append(dependents[dep], n)
+		}
+	}
+
+	var queue []*graphNode
+	for _, n := range g.nodes {
+		if inDegree[n.key] == 0 {
+			queue = append(queue, n)
+		}
+	}
+
+	sorted := make([]*graphNode, 0, len(g.nodes))
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		sorted = append(sorted, n)
+
+		for _, dependent := range dependents[n.key] {
+			inDegree[dependent.key]--
+			if inDegree[dependent.key] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	var cyclic []*graphNode
+	for _, n := range g.nodes {
+		if inDegree[n.key] > 0 {
+			cyclic = append(cyclic, n)
+		}
+	}
+
+	return sorted, cyclic
+}
+
+// buildGraph adds a node for each value in the Module that can be referenced by other Blocks.
+func (e *Evaluator) buildGraph() *evaluationGraph {
+	g := newEvaluationGraph()
+
+	for _, b := range e.module.Blocks {
+		b := b
+
+		switch b.Type() {
+		case "variable":
+			if b.Label() == "" {
+				continue
+			}
+
+			g.add("var."+b.Label(), blockTraversals(b), func() {
+				val, err := e.evaluateVariable(b)
+				if err != nil {
+					return
+				}
+
+				e.ctx.Set(cty.ObjectVal(map[string]cty.Value{b.Label(): val}), "var")
+			})
+		case "locals":
+			for _, attr := range b.GetAttributes() {
+				attr := attr
+
+				g.add("local."+attr.Name(), attr.HCLAttr.Expr.Variables(), func() {
+					e.ctx.Set(cty.ObjectVal(map[string]cty.Value{attr.Name(): attr.Value()}), "local")
+				})
+			}
+		case "provider":
+			if b.Label() == "" {
+				continue
+			}
+
+			g.add("provider."+b.Label(), blockTraversals(b), func() {
+				e.ctx.Set(cty.ObjectVal(map[string]cty.Value{b.Label(): b.Values()}), "provider")
+			})
+		case "resource":
+			if len(b.Labels()) < 2 {
+				continue
+			}
+
+			g.add(b.TypeLabel()+"."+stripIndex(b.NameLabel()), blockTraversals(b), func() {
+				e.ctx.Set(cty.ObjectVal(map[string]cty.Value{b.NameLabel(): b.Values()}), b.TypeLabel())
+			})
+		case "data":
+			if len(b.Labels()) < 2 {
+				continue
+			}
+
+			g.add("data."+b.TypeLabel()+"."+stripIndex(b.NameLabel()), blockTraversals(b), func() {
+				e.ctx.Set(cty.ObjectVal(map[string]cty.Value{
+					b.TypeLabel(): cty.ObjectVal(map[string]cty.Value{b.NameLabel(): b.Values()}),
+				}), "data")
+			})
+		case "output":
+			if b.Label() == "" {
+				continue
+			}
+
+			g.add("output."+b.Label(), blockTraversals(b), func() {
+				val, err := e.evaluateOutput(b)
+				if err != nil {
+					return
+				}
+
+				e.ctx.Set(cty.ObjectVal(map[string]cty.Value{b.Label(): val}), "output")
+			})
+		case "module":
+			if b.Label() == "" {
+				continue
+			}
+
+			// module Blocks are only used for their dependencies, the module calls are evaluated below.
+			g.add("module."+stripIndex(b.Label()), blockTraversals(b), func() {})
+		}
+	}
+
+	for _, moduleCall := range e.moduleCalls {
+		moduleCall := moduleCall
+
+		g.add("module."+stripIndex(moduleCall.Name), blockTraversals(moduleCall.Definition), func() {
+			e.evaluateModuleCall(moduleCall)
+		})
+	}
+
+	return g
+}
+
+// evaluate builds the dependency graph of the values in the Module and sets them on the Context in dependency
+// order, so values that reference values defined later in the config, e.g. late binding locals, are evaluated
+// in a single pass. Values that are part of a dependency cycle can't be ordered, so they're evaluated
+// repeatedly until their values stop changing.
+func (e *Evaluator) evaluate() {
+	sorted, cyclic := e.buildGraph().sort()
+
+	for _, n := range sorted {
+		n.evaluate()
+	}
+
+	if len(cyclic) == 0 {
+		return
+	}
+
+	keys := make([]string, len(cyclic))
+	for i, n := range cyclic {
+		keys[i] = n.key
+	}
+	log.Debugf("Found dependency cycle in module %s between: %s", e.module.ModulePath, strings.Join(keys, ", "))
+
+	var lastVariables map[string]cty.Value
+	for i := 0; i < maxContextIterations; i++ {
+		for _, n := range cyclic {
+			n.evaluate()
+		}
+
+		if reflect.DeepEqual(lastVariables, e.ctx.Inner().Variables) {
+			break
+		}
+
+		lastVariables = make(map[string]cty.Value, len(e.ctx.Inner().Variables))
+		for k, v := range e.ctx.Inner().Variables {
+			lastVariables[k] = v
+		}
+	}
+}
+
+// blockTraversals returns all the traversals in the Block's Attributes and child Blocks.
+func blockTraversals(b *Block) []hcl.Traversal {
+	var traversals []hcl.Traversal
+
+	for _, attr := range b.GetAttributes() {
+		traversals = append(traversals, attr.HCLAttr.Expr.Variables()...)
+	}
+
+	for _, child := range b.Children() {
+		traversals = append(traversals, blockTraversals(child)...)
+	}
+
+	return traversals
+}
+
+// traversalKey returns the key of the graph node that the traversal references, e.g. var.foo for
+// var.foo.bar or aws_instance.web for aws_instance.web[0].id. It returns "" for traversals that don't
+// reference a node, e.g. count.index or each.value.
+func traversalKey(t hcl.Traversal) string {
+	if len(t) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for _, step := range t {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			parts = append(parts, s.Name)
+		case hcl.TraverseAttr:
+			parts = append(parts, s.Name)
+		default:
+			// stop at the first index, since all the instances of a Block share a node.
+			return nodeKey(parts)
+		}
+	}
+
+	return nodeKey(parts)
+}
+
+func nodeKey(parts []string) string {
+	if len(parts) == 0 {
+		return ""
+	}
+
+	size := 2
+	switch parts[0] {
+	case "count", "each", "path", "terraform", "self":
+		return ""
+	case "data", "ephemeral":
+		size = 3
+	}
+
+	if len(parts) < size {
+		return ""
+	}
+
+	return strings.Join(parts[:size], ".")
+}
+
+// stripIndex removes the count or for_each index from a Block label, e.g. web[0] becomes web.
+func stripIndex(label string) string {
+	if i := strings.Index(label, "["); i != -1 {
+		return label[:i]
+	}
+
+	return label
+}
@@ -0,0 +1,213 @@
+package hcl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// HardenedLimits are the limits on the Terraform files parsed in hardened mode, so that a single
+// pathological file can't crash or hang a long-running process like the language server. Limits
+// that are zero aren't checked.
+type HardenedLimits struct {
+	// MaxFileSize is the largest file in bytes that is parsed.
+	MaxFileSize int
+	// MaxNestingDepth is the deepest that brackets, braces and parentheses can be nested in a file.
+	// The parser is recursive, so deeply nested expressions could otherwise overflow the stack.
+	MaxNestingDepth int
+	// FileTimeout is how long each file can take to parse.
+	FileTimeout time.Duration
+	// EvaluationTimeout is how long the evaluation of the parsed files can take.
+	EvaluationTimeout time.Duration
+}
+
+// DefaultHardenedLimits are generous enough for any real Terraform file.
+var DefaultHardenedLimits = HardenedLimits{
+	MaxFileSize:       10 * 1024 * 1024,
+	MaxNestingDepth:   256,
+	FileTimeout:       10 * time.Second,
+	EvaluationTimeout: 2 * time.Minute,
+}
+
+// FileDiagnostic is a problem with a Terraform file that meant it was skipped when it was parsed in
+// hardened mode, e.g. it was too large or the parser panicked.
+type FileDiagnostic struct {
+	Filename string
+	// Line is the line of the file that the problem is on, or zero if it's for the whole file.
+	Line    int
+	Message string
+}
+
+func (d FileDiagnostic) Error() string {
+	if d.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", d.Filename, d.Line, d.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", d.Filename, d.Message)
+}
+
+// parseFileHardened parses a file in a goroutine, so it can be abandoned if it takes longer than the
+// file timeout, and converts a panic into a FileDiagnostic. A new hclparse.Parser is used for each
+// file since an abandoned goroutine could otherwise still be writing to it.
+func parseFileHardened(path string, src []byte, isJSON bool, limits HardenedLimits) (*hcl.File, error) {
+	if limits.MaxFileSize > 0 && len(src) > limits.MaxFileSize {
+		return nil, FileDiagnostic{
+			Filename: path,
+			Message:  fmt.Sprintf("file is %d bytes which is over the limit of %d bytes", len(src), limits.MaxFileSize),
+		}
+	}
+
+	if limits.MaxNestingDepth > 0 {
+		if depth := nestingDepth(src); depth > limits.MaxNestingDepth {
+			return nil, FileDiagnostic{
+				Filename: path,
+				Message:  fmt.Sprintf("expressions are nested %d deep which is over the limit of %d", depth, limits.MaxNestingDepth),
+			}
+		}
+	}
+
+	type result struct {
+		file *hcl.File
+		err  error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{err: FileDiagnostic{Filename: path, Message: fmt.Sprintf("parser panicked: %v", r)}}
+			}
+		}()
+
+		var file *hcl.File
+		var diag hcl.Diagnostics
+		if isJSON {
+			file, diag = hclparse.NewParser().ParseJSON(src, path)
+		} else {
+			file, diag = hclparse.NewParser().ParseHCL(src, path)
+		}
+
+		if diag.HasErrors() {
+			done <- result{err: diagnosticsToFileDiagnostic(path, diag)}
+			return
+		}
+
+		done <- result{file: file}
+	}()
+
+	ctx := context.Background()
+	if limits.FileTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.FileTimeout)
+		defer cancel()
+	}
+
+	select {
+	case r := <-done:
+		return r.file, r.err
+	case <-ctx.Done():
+		return nil, FileDiagnostic{
+			Filename: path,
+			Message:  fmt.Sprintf("parsing took longer than %s", limits.FileTimeout),
+		}
+	}
+}
+
+// diagnosticsToFileDiagnostic returns a FileDiagnostic for the first error in the HCL diagnostics.
+func diagnosticsToFileDiagnostic(path string, diags hcl.Diagnostics) FileDiagnostic {
+	d := FileDiagnostic{Filename: path, Message: diags.Error()}
+
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+
+		d.Message = diag.Summary
+		if diag.Detail != "" {
+			d.Message += "; " + diag.Detail
+		}
+		if diag.Subject != nil {
+			d.Line = diag.Subject.Start.Line
+		}
+		break
+	}
+
+	return d
+}
+
+// nestingDepth returns the deepest that brackets, braces and parentheses are nested in the source,
+// ignoring the ones in quoted strings and comments.
+func nestingDepth(src []byte) int {
+	depth, maxDepth := 0, 0
+	inString, inComment := false, false
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+
+		switch {
+		case inComment:
+			if c == '\n' {
+				inComment = false
+			}
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' || c == '\n' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '#', c == '/' && i+1 < len(src) && src[i+1] == '/':
+			inComment = true
+		case c == '(', c == '[', c == '{':
+			depth++
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+		case c == ')', c == ']', c == '}':
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+
+	return maxDepth
+}
+
+// runHardened runs f, converting a panic into an error and returning an error if it takes longer
+// than the timeout. f keeps running in the background after a timeout since it can't be stopped.
+func runHardened(name string, timeout time.Duration, f func() (*Module, error)) (*Module, error) {
+	type result struct {
+		module *Module
+		err    error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{err: fmt.Errorf("%s panicked: %v", name, r)}
+			}
+		}()
+
+		m, err := f()
+		done <- result{module: m, err: err}
+	}()
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	select {
+	case r := <-done:
+		return r.module, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s took longer than %s", name, timeout)
+	}
+}
@@ -0,0 +1,72 @@
+package hcl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NestingDepth(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want int
+	}{
+		{name: "empty", src: ``, want: 0},
+		{name: "block", src: `resource "a" "b" { tags = { a = [1, (2)] } }`, want: 4},
+		{name: "strings are ignored", src: `locals { a = "{{[[((\"" }`, want: 1},
+		{name: "comments are ignored", src: "locals {\n  # {{{\n  // [[[\n}", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nestingDepth([]byte(tt.src)))
+		})
+	}
+}
+
+func Test_ParseFileHardened(t *testing.T) {
+	limits := HardenedLimits{MaxFileSize: 100, MaxNestingDepth: 3}
+
+	_, err := parseFileHardened("main.tf", []byte(`locals { a = [1] }`), false, limits)
+	require.NoError(t, err)
+
+	_, err = parseFileHardened("main.tf", []byte(strings.Repeat("#", 101)), false, limits)
+	assert.Equal(t, FileDiagnostic{Filename: "main.tf", Message: "file is 101 bytes which is over the limit of 100 bytes"}, err)
+
+	_, err = parseFileHardened("main.tf", []byte(`locals { a = [[[1]]] }`), false, limits)
+	assert.Equal(t, FileDiagnostic{Filename: "main.tf", Message: "expressions are nested 4 deep which is over the limit of 3"}, err)
+
+	_, err = parseFileHardened("main.tf", []byte("locals {\n  a = \n}"), false, limits)
+	require.Error(t, err)
+	assert.Equal(t, 2, err.(FileDiagnostic).Line)
+}
+
+func Test_OptionHardenedSkipsInvalidFiles(t *testing.T) {
+	path := createTestFile("main.tf", `
+resource "cats_cat" "mittens" {
+	name = "mittens"
+}
+`)
+	dir := filepath.Dir(path)
+
+	nested := `locals { a = ` + strings.Repeat("[", 20) + strings.Repeat("]", 20) + ` }`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested.tf"), []byte(nested), os.ModePerm))
+
+	limits := DefaultHardenedLimits
+	limits.MaxNestingDepth = 10
+	parser := New(dir, OptionHardened(limits))
+
+	module, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, module.Blocks.OfType("resource"), 1)
+
+	assert.Equal(t, []FileDiagnostic{
+		{Filename: filepath.Join(dir, "nested.tf"), Message: "expressions are nested 21 deep which is over the limit of 10"},
+	}, parser.FileDiagnostics())
+}
@@ -0,0 +1,127 @@
+package hcl
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+)
+
+// IgnoreFiles are the files that list the paths in a Terraform directory that aren't parsed or
+// searched for projects. .terraformignore is the file Terraform uses to exclude paths from remote
+// runs, and .infracostignore can be used for paths that only Infracost should skip.
+var IgnoreFiles = []string{".terraformignore", ".infracostignore"}
+
+// IgnoreRules are the patterns from the ignore files of a directory. The patterns use the same
+// syntax as .gitignore files: a leading ! includes a path that a previous pattern ignored, a
+// trailing / only matches directories, patterns that contain a / are relative to the directory and
+// other patterns match at any depth. ** matches any number of directories.
+type IgnoreRules struct {
+	dir   string
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	pattern string
+	negate  bool
+	dirOnly bool
+}
+
+// LoadIgnoreRules returns the rules from the IgnoreFiles in dir, or nil if it doesn't have any.
+func LoadIgnoreRules(dir string) (*IgnoreRules, error) {
+	var rules []ignoreRule
+
+	for _, name := range IgnoreFiles {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, parseIgnoreRules(b)...)
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	return &IgnoreRules{dir: dir, rules: rules}, nil
+}
+
+func parseIgnoreRules(b []byte) []ignoreRule {
+	var rules []ignoreRule
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var r ignoreRule
+		if strings.HasPrefix(line, "!") {
+			r.negate = true
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			r.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		if !strings.Contains(line, "/") {
+			line = "**/" + line
+		}
+		r.pattern = strings.TrimPrefix(line, "/")
+
+		if r.pattern != "" {
+			rules = append(rules, r)
+		}
+	}
+
+	return rules
+}
+
+// Ignored returns true if the path, or one of the directories it's in, is ignored by the rules.
+// Paths outside the directory of the rules are never ignored.
+func (r *IgnoreRules) Ignored(path string, isDir bool) bool {
+	if r == nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(r.dir, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i := range parts {
+		if r.match(strings.Join(parts[:i+1], "/"), isDir || i < len(parts)-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match returns true if the last of the rules that matches the slash-separated path ignores it.
+func (r *IgnoreRules) match(path string, isDir bool) bool {
+	ignored := false
+
+	for _, rule := range r.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		if ok, _ := doublestar.Match(rule.pattern, path); ok {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
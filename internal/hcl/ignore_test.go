@@ -0,0 +1,82 @@
+package hcl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IgnoreRules(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".terraformignore"), []byte(`
+# comments and blank lines are skipped
+*.bak
+fixtures/
+`), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".infracostignore"), []byte(`
+/examples
+test/**/*.tf
+!test/keep/main.tf
+`), os.ModePerm))
+
+	rules, err := LoadIgnoreRules(dir)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{path: "main.tf", want: false},
+		{path: "main.tf.bak", want: true},
+		{path: "modules/db/main.tf.bak", want: true},
+		{path: "fixtures", isDir: true, want: true},
+		{path: "fixtures/main.tf", want: true},
+		{path: "modules/fixtures/main.tf", want: true},
+		{path: "fixtures", isDir: false, want: false},
+		{path: "examples/basic/main.tf", want: true},
+		{path: "modules/examples/main.tf", want: false},
+		{path: "test/unit/main.tf", want: true},
+		{path: "test/keep/main.tf", want: false},
+		{path: "../main.tf.bak", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, rules.Ignored(filepath.Join(dir, tt.path), tt.isDir))
+		})
+	}
+}
+
+func Test_LoadIgnoreRulesWithoutFiles(t *testing.T) {
+	rules, err := LoadIgnoreRules(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+	assert.False(t, rules.Ignored("main.tf", false))
+}
+
+func Test_ParseDirectorySkipsIgnoredFiles(t *testing.T) {
+	path := createTestFile("main.tf", `
+resource "cats_cat" "mittens" {
+	name = "mittens"
+}
+`)
+	dir := filepath.Dir(path)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "test_fixture.tf"), []byte(`
+resource "cats_cat" "fixture" {
+	name = "fixture"
+}
+`), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".infracostignore"), []byte("test_*.tf\n"), os.ModePerm))
+
+	module, err := New(dir).ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	resources := module.Blocks.OfType("resource")
+	require.Len(t, resources, 1)
+	assert.Equal(t, "cats_cat.mittens", resources[0].FullName())
+}
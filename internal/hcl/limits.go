@@ -0,0 +1,58 @@
+package hcl
+
+import (
+	"fmt"
+)
+
+// DirectoryLimits are the limits on the size of the Terraform directories that are parsed, so that
+// running against a huge tree by accident, e.g. one with vendored dependencies, is stopped with a
+// clear message instead of using all the memory. Limits that are zero aren't checked.
+type DirectoryLimits struct {
+	// MaxFiles is the most Terraform files that a directory can have. A directory with more files is
+	// skipped.
+	MaxFiles int
+	// MaxFileSize is the largest Terraform file in bytes that is read. Larger files are skipped.
+	MaxFileSize int64
+	// MaxModuleDepth is the deepest that module calls can be nested. Modules that are deeper are
+	// skipped.
+	MaxModuleDepth int
+}
+
+// DefaultDirectoryLimits are generous enough for any real Terraform project.
+var DefaultDirectoryLimits = DirectoryLimits{
+	MaxFiles:       1000,
+	MaxFileSize:    10 * 1024 * 1024,
+	MaxModuleDepth: 20,
+}
+
+func (l DirectoryLimits) checkFileCount(dir string, count int) error {
+	if l.MaxFiles > 0 && count > l.MaxFiles {
+		return fmt.Errorf("%s has %d Terraform files which is over the limit of %d, set INFRACOST_MAX_FILES to change it", dir, count, l.MaxFiles)
+	}
+
+	return nil
+}
+
+func (l DirectoryLimits) checkFileSize(path string, size int64) error {
+	if l.MaxFileSize > 0 && size > l.MaxFileSize {
+		return FileDiagnostic{
+			Filename: path,
+			Message:  fmt.Sprintf("file is %d bytes which is over the limit of %d bytes, set INFRACOST_MAX_FILE_SIZE to change it", size, l.MaxFileSize),
+		}
+	}
+
+	return nil
+}
+
+func (l DirectoryLimits) checkModuleDepth(parent *Module, name string) error {
+	depth := 1
+	for m := parent; m != nil && m.Parent != nil; m = m.Parent {
+		depth++
+	}
+
+	if l.MaxModuleDepth > 0 && depth > l.MaxModuleDepth {
+		return fmt.Errorf("module %s is nested %d deep which is over the limit of %d, set INFRACOST_MAX_MODULE_DEPTH to change it", name, depth, l.MaxModuleDepth)
+	}
+
+	return nil
+}
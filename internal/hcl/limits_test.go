@@ -0,0 +1,71 @@
+package hcl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DirectoryLimitsMaxFiles(t *testing.T) {
+	path := createTestFile("main.tf", `locals {}`)
+	dir := filepath.Dir(path)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.tf.json"), []byte(`{}`), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte(`# ignored`), os.ModePerm))
+
+	parser := New(dir, OptionWithDirectoryLimits(DirectoryLimits{MaxFiles: 2}))
+	_, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	parser = New(dir, OptionWithDirectoryLimits(DirectoryLimits{MaxFiles: 1}))
+	_, err = parser.ParseDirectory(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, dir+" has 2 Terraform files which is over the limit of 1, set INFRACOST_MAX_FILES to change it", err.Error())
+}
+
+func Test_DirectoryLimitsMaxFileSize(t *testing.T) {
+	path := createTestFile("main.tf", `
+resource "cats_cat" "mittens" {
+	name = "mittens"
+}
+`)
+	dir := filepath.Dir(path)
+	large := filepath.Join(dir, "large.tf")
+	require.NoError(t, os.WriteFile(large, []byte(strings.Repeat("# padding\n", 100)), os.ModePerm))
+
+	parser := New(dir, OptionWithDirectoryLimits(DirectoryLimits{MaxFileSize: 500}))
+	module, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, module.Blocks.OfType("resource"), 1)
+
+	assert.Equal(t, []FileDiagnostic{
+		{Filename: large, Message: "file is 1000 bytes which is over the limit of 500 bytes, set INFRACOST_MAX_FILE_SIZE to change it"},
+	}, parser.FileDiagnostics())
+}
+
+func Test_DirectoryLimitsMaxModuleDepth(t *testing.T) {
+	// the module calls itself, so without a limit it would be loaded forever.
+	path := createTestFile("main.tf", `
+module "self" {
+	source = "./"
+}
+
+resource "cats_cat" "mittens" {
+	name = "mittens"
+}
+`)
+
+	parser := New(filepath.Dir(path), OptionWithDirectoryLimits(DirectoryLimits{MaxModuleDepth: 3}))
+	module, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	depth := 0
+	for m := module; len(m.Modules) > 0; m = m.Modules[0] {
+		depth++
+	}
+	assert.Equal(t, 3, depth)
+}
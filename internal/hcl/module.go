@@ -23,4 +23,8 @@ type Module struct {
 
 	Modules []*Module
 	Parent  *Module
+
+	// ctx is the Context the Module was evaluated with. It's used to evaluate expressions against the
+	// Module after the Evaluator has run.
+	ctx *Context
 }
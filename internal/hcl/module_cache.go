@@ -0,0 +1,149 @@
+package hcl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// moduleCache memoizes the evaluated Modules of module calls, so that a module that is called
+// many times with the same source and inputs is only evaluated once. This is common with
+// for_each over environments, where evaluating every call separately gets very slow for large
+// configs.
+type moduleCache struct {
+	entries map[string]moduleCacheEntry
+}
+
+type moduleCacheEntry struct {
+	// module is the evaluated Module of the first call with the inputs.
+	module *Module
+	// outputs are the exported outputs of the module which are set on the calling Context.
+	outputs cty.Value
+}
+
+func newModuleCache() *moduleCache {
+	return &moduleCache{
+		entries: make(map[string]moduleCacheEntry),
+	}
+}
+
+// get returns the cached entry for the module at modulePath called with vars. It returns false if
+// there is no entry or the vars can't be used as a cache key.
+func (c *moduleCache) get(modulePath string, vars map[string]cty.Value) (moduleCacheEntry, bool) {
+	key, ok := moduleCacheKey(modulePath, vars)
+	if !ok {
+		return moduleCacheEntry{}, false
+	}
+
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+// set adds the evaluated module and its outputs to the cache. It does nothing if the vars can't
+// be used as a cache key.
+func (c *moduleCache) set(modulePath string, vars map[string]cty.Value, module *Module, outputs cty.Value) {
+	key, ok := moduleCacheKey(modulePath, vars)
+	if !ok {
+		return
+	}
+
+	c.entries[key] = moduleCacheEntry{
+		module:  module,
+		outputs: outputs,
+	}
+}
+
+// moduleCacheKey returns a hash of the module path and the input vars. Vars that contain unknown
+// or marked values can't be serialized, so module calls with these are never cached.
+func moduleCacheKey(modulePath string, vars map[string]cty.Value) (string, bool) {
+	val := cty.ObjectVal(vars)
+	if !val.IsWhollyKnown() || val.ContainsMarked() {
+		return "", false
+	}
+
+	ty, err := ctyjson.MarshalType(val.Type())
+	if err != nil {
+		return "", false
+	}
+
+	b, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return "", false
+	}
+
+	h := sha256.New()
+	h.Write([]byte(modulePath))
+	h.Write([]byte{0})
+	h.Write(ty)
+	h.Write([]byte{0})
+	h.Write(b)
+
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// cloneModule returns a copy of an evaluated Module for another call of the same module with the
+// same inputs. The Blocks share their hcl.Block and evaluated Context with the original since these
+// are the same for both calls, but they're attached to the new module Block so their addresses are
+// those of the new call.
+//
+// The generated id and arn attributes are also shared with the original. References are resolved
+// by address before falling back to ids, so this only affects references that are passed into the
+// module as ids.
+func cloneModule(m *Module, definition *Block, parent *Module) *Module {
+	clone := &Module{
+		Name:       definition.FullName(),
+		Source:     m.Source,
+		RootPath:   m.RootPath,
+		ModulePath: m.ModulePath,
+		Parent:     parent,
+	}
+
+	cloned := make(map[*Block]*Block)
+
+	clone.Blocks = make(Blocks, len(m.Blocks))
+	for i, b := range m.Blocks {
+		clone.Blocks[i] = cloneModuleBlock(b, definition, cloned)
+	}
+
+	for _, child := range m.Modules {
+		childDefinition := moduleDefinition(child)
+		if childDefinition == nil {
+			continue
+		}
+
+		clone.Modules = append(clone.Modules, cloneModule(child, cloneModuleBlock(childDefinition, definition, cloned), clone))
+	}
+
+	return clone
+}
+
+// cloneModuleBlock copies the Block and its children and sets their module Block. The cloned map
+// is used so Blocks that are referenced more than once are only cloned once.
+func cloneModuleBlock(b *Block, moduleBlock *Block, cloned map[*Block]*Block) *Block {
+	if c, ok := cloned[b]; ok {
+		return c
+	}
+
+	c := *b
+	c.moduleBlock = moduleBlock
+	c.childBlocks = make(Blocks, len(b.childBlocks))
+	for i, child := range b.childBlocks {
+		c.childBlocks[i] = cloneModuleBlock(child, moduleBlock, cloned)
+	}
+
+	cloned[b] = &c
+	return &c
+}
+
+// moduleDefinition returns the module Block that the Module was called from.
+func moduleDefinition(m *Module) *Block {
+	for _, b := range m.Blocks {
+		if b.moduleBlock != nil {
+			return b.moduleBlock
+		}
+	}
+
+	return nil
+}
@@ -37,8 +37,9 @@ func (c *Cache) loadFromManifest(manifest *Manifest) {
 }
 
 // lookupModule looks up a module in the cache by its key and checks that the
-// source and version are compatible with the module in the cache.
-func (c *Cache) lookupModule(key string, moduleCall *tfconfig.ModuleCall) (*ManifestModule, error) {
+// source and version are compatible with the module in the cache. The registryHost is used to
+// normalize registry sources that don't specify a hostname.
+func (c *Cache) lookupModule(key string, moduleCall *tfconfig.ModuleCall, registryHost string) (*ManifestModule, error) {
 	manifestModule, ok := c.keyMap[key]
 
 	if !ok {
@@ -51,7 +52,7 @@ func (c *Cache) lookupModule(key string, moduleCall *tfconfig.ModuleCall) (*Mani
 	var registrySource = ""
 	moduleAddr, submodulePath, err := splitModuleSubDir(moduleCall.Source)
 	if err == nil {
-		registryModuleAddr, err := normalizeRegistrySource(moduleAddr)
+		registryModuleAddr, err := normalizeRegistrySource(moduleAddr, registryHost)
 		if err == nil {
 			registrySource = joinModuleSubDir(registryModuleAddr, submodulePath)
 		}
@@ -58,7 +58,7 @@ func TestLookupModule(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		actual, err := cache.lookupModule(test.key, test.moduleCall)
+		actual, err := cache.lookupModule(test.key, test.moduleCall, defaultRegistryHost)
 
 		actualErr := ""
 		if err != nil {
@@ -0,0 +1,494 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	getter "github.com/hashicorp/go-getter"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// cloudGetters returns the go-getter Getters used to download modules, with the s3 and gcs Getters
+// replaced by ones that use the cloud credentials in env rather than the process environment. This
+// lets s3:: and gcs:: module sources use the credentials configured for the project. Getters that
+// have been replaced by the network policy are kept so that the policy still blocks them.
+func cloudGetters(env map[string]string) map[string]getter.Getter {
+	getters := make(map[string]getter.Getter, len(getter.Getters))
+	for name, g := range getter.Getters {
+		getters[name] = g
+	}
+
+	if len(env) == 0 {
+		return getters
+	}
+
+	if _, ok := getters["s3"].(*getter.S3Getter); ok {
+		getters["s3"] = &s3Getter{env: env}
+	}
+
+	if _, ok := getters["gcs"].(*getter.GCSGetter); ok {
+		getters["gcs"] = &gcsGetter{env: env}
+	}
+
+	return getters
+}
+
+// s3Getter is a go-getter Getter that downloads modules from an S3 bucket, the same as the go-getter
+// S3Getter, using the AWS credentials from env. The credentials are passed to the AWS config rather than
+// added to the source URL, so that they can't end up in error messages or logs that include the URL.
+// Credentials in the source URL itself are still used and take precedence over env.
+type s3Getter struct {
+	client *getter.Client
+	env    map[string]string
+}
+
+func (g *s3Getter) SetClient(c *getter.Client) {
+	g.client = c
+}
+
+func (g *s3Getter) context() context.Context {
+	if g.client == nil || g.client.Ctx == nil {
+		return context.Background()
+	}
+
+	return g.client.Ctx
+}
+
+func (g *s3Getter) ClientMode(u *url.URL) (getter.ClientMode, error) {
+	ctx := g.context()
+
+	region, bucket, path, _, err := parseS3URL(u)
+	if err != nil {
+		return 0, err
+	}
+
+	client, err := g.newS3Client(ctx, region, u)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(path),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, obj := range resp.Contents {
+		if aws.ToString(obj.Key) == path {
+			return getter.ClientModeFile, nil
+		}
+
+		if strings.HasPrefix(aws.ToString(obj.Key), path+"/") {
+			return getter.ClientModeDir, nil
+		}
+	}
+
+	// There was no match so let the download fail with the S3 error.
+	return getter.ClientModeFile, nil
+}
+
+func (g *s3Getter) Get(dst string, u *url.URL) error {
+	ctx := g.context()
+
+	region, bucket, path, _, err := parseS3URL(u)
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(dst)
+	if err != nil {
+		return err
+	}
+
+	client, err := g.newS3Client(ctx, region, u)
+	if err != nil {
+		return err
+	}
+
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(path),
+	})
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range resp.Contents {
+			key := aws.ToString(obj.Key)
+			if strings.HasSuffix(key, "/") || !inPrefixDir(key, path) {
+				continue
+			}
+
+			objDst, err := objectDst(dst, path, key)
+			if err != nil {
+				return err
+			}
+
+			err = getS3Object(ctx, client, objDst, bucket, key, "")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *s3Getter) GetFile(dst string, u *url.URL) error {
+	ctx := g.context()
+
+	region, bucket, path, version, err := parseS3URL(u)
+	if err != nil {
+		return err
+	}
+
+	client, err := g.newS3Client(ctx, region, u)
+	if err != nil {
+		return err
+	}
+
+	return getS3Object(ctx, client, dst, bucket, path, version)
+}
+
+// newS3Client returns an S3 client for the source URL. It uses the aws_access_key_id, aws_access_key_secret,
+// aws_access_token or aws_profile query parameters if the source has them, otherwise the credentials in env.
+func (g *s3Getter) newS3Client(ctx context.Context, region string, u *url.URL) (*s3.Client, error) {
+	q := u.Query()
+
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(region),
+	}
+
+	switch {
+	case q.Get("aws_access_key_id") != "" || q.Get("aws_access_key_secret") != "" || q.Get("aws_access_token") != "":
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(q.Get("aws_access_key_id"), q.Get("aws_access_key_secret"), q.Get("aws_access_token")),
+		))
+	case q.Get("aws_profile") != "":
+		opts = append(opts, awsconfig.WithSharedConfigProfile(q.Get("aws_profile")))
+	case g.env["AWS_ACCESS_KEY_ID"] != "" && g.env["AWS_SECRET_ACCESS_KEY"] != "":
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(g.env["AWS_ACCESS_KEY_ID"], g.env["AWS_SECRET_ACCESS_KEY"], g.env["AWS_SESSION_TOKEN"]),
+		))
+	case g.env["AWS_PROFILE"] != "":
+		opts = append(opts, awsconfig.WithSharedConfigProfile(g.env["AWS_PROFILE"]))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load AWS config: %w", err)
+	}
+
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if !strings.Contains(u.Host, "amazonaws.com") {
+			// S3 compatible services don't usually support virtual hosted buckets.
+			o.EndpointResolver = s3.EndpointResolverFromURL(u.Scheme + "://" + u.Host)
+			o.UsePathStyle = true
+		}
+	}), nil
+}
+
+// getS3Object downloads the object to dst. If the version is set it's the version of the object to download.
+func getS3Object(ctx context.Context, client *s3.Client, dst string, bucket string, key string, version string) error {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	if version != "" {
+		input.VersionId = aws.String(version)
+	}
+
+	resp, err := client.GetObject(ctx, input)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return writeObject(dst, resp.Body)
+}
+
+// urlWithoutQuery returns the URL without the query string, which can have credentials in it, so that it can
+// be shown in errors.
+func urlWithoutQuery(u *url.URL) string {
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+}
+
+// parseS3URL returns the region, bucket, path and version of an S3 URL. Both AWS URLs, e.g.
+// https://s3-eu-west-1.amazonaws.com/bucket/module.zip or https://bucket.s3.eu-west-1.amazonaws.com/module.zip,
+// and URLs of S3 compatible services, e.g. https://minio.example.com/bucket/module.zip?region=eu-west-1, are
+// supported.
+func parseS3URL(u *url.URL) (string, string, string, string, error) {
+	var region, bucket, path string
+
+	if !strings.Contains(u.Host, "amazonaws.com") {
+		parts := strings.SplitN(u.Path, "/", 3)
+		if len(parts) != 3 {
+			return "", "", "", "", fmt.Errorf("URL %s is not a valid S3 compliant URL", urlWithoutQuery(u))
+		}
+
+		region = u.Query().Get("region")
+		if region == "" {
+			region = "us-east-1"
+		}
+
+		return region, parts[1], parts[2], u.Query().Get("version"), nil
+	}
+
+	hostParts := strings.Split(u.Host, ".")
+	switch len(hostParts) {
+	case 3:
+		// Path-style, e.g. s3-eu-west-1.amazonaws.com/bucket/path
+		region = strings.TrimPrefix(strings.TrimPrefix(hostParts[0], "s3-"), "s3")
+		if region == "" {
+			region = "us-east-1"
+		}
+
+		parts := strings.SplitN(u.Path, "/", 3)
+		if len(parts) != 3 {
+			return "", "", "", "", fmt.Errorf("URL %s is not a valid S3 URL", urlWithoutQuery(u))
+		}
+		bucket, path = parts[1], parts[2]
+	case 4, 5:
+		// Virtual-hosted-style, e.g. bucket.s3-eu-west-1.amazonaws.com/path or bucket.s3.eu-west-1.amazonaws.com/path
+		if len(hostParts) == 4 {
+			region = strings.TrimPrefix(strings.TrimPrefix(hostParts[1], "s3-"), "s3")
+		} else {
+			region = hostParts[2]
+		}
+		if region == "" {
+			return "", "", "", "", fmt.Errorf("URL %s is not a valid S3 URL", urlWithoutQuery(u))
+		}
+
+		bucket, path = hostParts[0], strings.TrimPrefix(u.Path, "/")
+	default:
+		return "", "", "", "", fmt.Errorf("URL %s is not a valid S3 URL", urlWithoutQuery(u))
+	}
+
+	return region, bucket, path, u.Query().Get("version"), nil
+}
+
+// gcsGetter is a go-getter Getter that downloads modules from a GCS bucket, the same as the go-getter
+// GCSGetter, using the GOOGLE_OAUTH_ACCESS_TOKEN or GOOGLE_APPLICATION_CREDENTIALS from env.
+type gcsGetter struct {
+	client *getter.Client
+	env    map[string]string
+}
+
+func (g *gcsGetter) SetClient(c *getter.Client) {
+	g.client = c
+}
+
+func (g *gcsGetter) context() context.Context {
+	if g.client == nil || g.client.Ctx == nil {
+		return context.Background()
+	}
+
+	return g.client.Ctx
+}
+
+func (g *gcsGetter) ClientMode(u *url.URL) (getter.ClientMode, error) {
+	ctx := g.context()
+
+	bucket, object, _, err := parseGCSURL(u)
+	if err != nil {
+		return 0, err
+	}
+
+	client, err := g.newStorageClient(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	iter := client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: object})
+	for {
+		obj, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		// Any other object with the prefix means the object is a directory.
+		if strings.HasSuffix(obj.Name, "/") || obj.Name != object {
+			return getter.ClientModeDir, nil
+		}
+	}
+
+	return getter.ClientModeFile, nil
+}
+
+func (g *gcsGetter) Get(dst string, u *url.URL) error {
+	ctx := g.context()
+
+	bucket, object, _, err := parseGCSURL(u)
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(dst)
+	if err != nil {
+		return err
+	}
+
+	client, err := g.newStorageClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	iter := client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: object})
+	for {
+		obj, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if strings.HasSuffix(obj.Name, "/") || !inPrefixDir(obj.Name, object) {
+			continue
+		}
+
+		objDst, err := objectDst(dst, object, obj.Name)
+		if err != nil {
+			return err
+		}
+
+		err = getGCSObject(ctx, client, objDst, bucket, obj.Name, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *gcsGetter) GetFile(dst string, u *url.URL) error {
+	ctx := g.context()
+
+	bucket, object, fragment, err := parseGCSURL(u)
+	if err != nil {
+		return err
+	}
+
+	client, err := g.newStorageClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return getGCSObject(ctx, client, dst, bucket, object, fragment)
+}
+
+func (g *gcsGetter) newStorageClient(ctx context.Context) (*storage.Client, error) {
+	var opts []option.ClientOption
+
+	if token := g.env["GOOGLE_OAUTH_ACCESS_TOKEN"]; token != "" {
+		opts = append(opts, option.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})))
+	} else if credsFile := g.env["GOOGLE_APPLICATION_CREDENTIALS"]; credsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credsFile))
+	}
+
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create GCS client: %w", err)
+	}
+
+	return client, nil
+}
+
+// getGCSObject downloads the object to dst. If the fragment is set it's the generation of the object to download.
+func getGCSObject(ctx context.Context, client *storage.Client, dst string, bucket string, object string, fragment string) error {
+	obj := client.Bucket(bucket).Object(object)
+	if fragment != "" {
+		generation, err := strconv.ParseInt(fragment, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid GCS object generation '%s': %w", fragment, err)
+		}
+		obj = obj.Generation(generation)
+	}
+
+	r, err := obj.NewReader(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return writeObject(dst, r)
+}
+
+// objectDst returns the path that the object with the given name is downloaded to when downloading all the
+// objects with the prefix to the dst directory. It returns an error if the path would be outside of dst, e.g.
+// for an object named prefix/../../main.tf.
+func objectDst(dst string, prefix string, name string) (string, error) {
+	rel, err := filepath.Rel(prefix, name)
+	if err != nil {
+		return "", err
+	}
+
+	dst = filepath.Clean(dst)
+	p := filepath.Join(dst, rel)
+	if p != dst && !strings.HasPrefix(p, dst+string(filepath.Separator)) {
+		return "", fmt.Errorf("Object %s would be downloaded outside of the module directory", name)
+	}
+
+	return p, nil
+}
+
+// inPrefixDir returns true if the object is in the directory of the prefix. Listing objects by prefix also
+// returns objects that only share the start of the name, e.g. modules/vpc-other/main.tf for modules/vpc.
+func inPrefixDir(name string, prefix string) bool {
+	return strings.HasPrefix(name, strings.TrimSuffix(prefix, "/")+"/")
+}
+
+// writeObject writes the object contents in r to dst, creating any parent directories.
+func writeObject(dst string, r io.Reader) error {
+	err := os.MkdirAll(filepath.Dir(dst), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+// parseGCSURL returns the bucket, object and fragment of a GCS URL, e.g.
+// https://www.googleapis.com/storage/v1/bucket/path/to/module.zip.
+func parseGCSURL(u *url.URL) (string, string, string, error) {
+	if !strings.HasSuffix(u.Host, "googleapis.com") || len(strings.Split(u.Host, ".")) != 3 {
+		return "", "", "", fmt.Errorf("URL %s is not a valid GCS URL", u)
+	}
+
+	parts := strings.SplitN(u.Path, "/", 5)
+	if len(parts) != 5 {
+		return "", "", "", fmt.Errorf("URL %s is not a valid GCS URL", u)
+	}
+
+	return parts[3], parts[4], u.Fragment, nil
+}
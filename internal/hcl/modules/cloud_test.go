@@ -0,0 +1,219 @@
+package modules
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	getter "github.com/hashicorp/go-getter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudGetters(t *testing.T) {
+	getters := cloudGetters(nil)
+	assert.Same(t, getter.Getters["s3"], getters["s3"])
+	assert.Same(t, getter.Getters["gcs"], getters["gcs"])
+
+	getters = cloudGetters(map[string]string{"AWS_PROFILE": "modules"})
+	assert.IsType(t, &s3Getter{}, getters["s3"])
+	assert.IsType(t, &gcsGetter{}, getters["gcs"])
+	assert.Same(t, getter.Getters["git"], getters["git"])
+
+	// getters that the network policy has replaced aren't replaced again.
+	blocked := getter.Getters["s3"]
+	getter.Getters["s3"] = new(getter.MockGetter)
+	defer func() {
+		getter.Getters["s3"] = blocked
+	}()
+
+	getters = cloudGetters(map[string]string{"AWS_PROFILE": "modules"})
+	assert.IsType(t, &getter.MockGetter{}, getters["s3"])
+}
+
+func TestDownloadS3WithCredentials(t *testing.T) {
+	credsFile := filepath.Join(t.TempDir(), "credentials")
+	err := os.WriteFile(credsFile, []byte("[modules]\naws_access_key_id = AKIAPROFILE\naws_secret_access_key = secret\n"), 0600)
+	require.NoError(t, err)
+
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credsFile)
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+
+	tests := []struct {
+		name        string
+		query       string
+		env         map[string]string
+		expectedKey string
+	}{
+		{
+			name: "access keys",
+			env: map[string]string{
+				"AWS_ACCESS_KEY_ID":     "AKIATEST",
+				"AWS_SECRET_ACCESS_KEY": "secret",
+			},
+			expectedKey: "AKIATEST",
+		},
+		{
+			name:        "profile",
+			env:         map[string]string{"AWS_PROFILE": "modules"},
+			expectedKey: "AKIAPROFILE",
+		},
+		{
+			name: "access keys take precedence over profile",
+			env: map[string]string{
+				"AWS_PROFILE":           "modules",
+				"AWS_ACCESS_KEY_ID":     "AKIATEST",
+				"AWS_SECRET_ACCESS_KEY": "secret",
+				"AWS_SESSION_TOKEN":     "token",
+			},
+			expectedKey: "AKIATEST",
+		},
+		{
+			name:  "source credentials are kept",
+			query: "?aws_access_key_id=AKIASOURCE&aws_access_key_secret=secret",
+			env: map[string]string{
+				"AWS_ACCESS_KEY_ID":     "AKIATEST",
+				"AWS_SECRET_ACCESS_KEY": "secret",
+			},
+			expectedKey: "AKIASOURCE",
+		},
+		{
+			name:        "source profile is kept",
+			query:       "?aws_profile=modules",
+			env:         map[string]string{"AWS_PROFILE": "missing"},
+			expectedKey: "AKIAPROFILE",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, requests := newTestS3Server(t)
+
+			dest := filepath.Join(t.TempDir(), "module")
+			err := download(context.Background(), "s3::"+server.URL+"/bucket/module.zip"+test.query, dest, test.env)
+			require.NoError(t, err)
+
+			assert.FileExists(t, filepath.Join(dest, "main.tf"))
+			require.NotEmpty(t, *requests)
+			for _, r := range *requests {
+				assert.Contains(t, r.Header.Get("Authorization"), "Credential="+test.expectedKey+"/")
+				// the env credentials must not be added to the URL since it can be logged.
+				assert.NotContains(t, r.URL.RawQuery, "AKIATEST")
+				assert.NotContains(t, r.URL.RawQuery, "aws_access_key_secret")
+			}
+		})
+	}
+}
+
+// newTestS3Server returns an S3 compatible server with a bucket that has a module.zip object, and the
+// requests that have been made to it.
+func newTestS3Server(t *testing.T) (*httptest.Server, *[]*http.Request) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("main.tf")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`resource "aws_instance" "web" {}`))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+
+		switch {
+		case r.URL.Path == "/bucket" || r.URL.Path == "/bucket/":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<ListBucketResult><Name>bucket</Name><Contents><Key>module.zip</Key></Contents></ListBucketResult>`))
+		case r.URL.Path == "/bucket/module.zip":
+			_, _ = w.Write(buf.Bytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestParseS3URL(t *testing.T) {
+	tests := []struct {
+		source  string
+		region  string
+		bucket  string
+		path    string
+		version string
+	}{
+		{source: "https://s3.amazonaws.com/bucket/modules/vpc.zip", region: "us-east-1", bucket: "bucket", path: "modules/vpc.zip"},
+		{source: "https://s3-eu-west-1.amazonaws.com/bucket/vpc.zip?version=2", region: "eu-west-1", bucket: "bucket", path: "vpc.zip", version: "2"},
+		{source: "https://bucket.s3-eu-west-1.amazonaws.com/vpc.zip", region: "eu-west-1", bucket: "bucket", path: "vpc.zip"},
+		{source: "https://bucket.s3.eu-west-1.amazonaws.com/vpc.zip", region: "eu-west-1", bucket: "bucket", path: "vpc.zip"},
+		{source: "https://minio.example.com/bucket/vpc.zip?region=eu-west-2", region: "eu-west-2", bucket: "bucket", path: "vpc.zip"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			u, err := url.Parse(test.source)
+			require.NoError(t, err)
+
+			region, bucket, path, version, err := parseS3URL(u)
+			require.NoError(t, err)
+			assert.Equal(t, test.region, region)
+			assert.Equal(t, test.bucket, bucket)
+			assert.Equal(t, test.path, path)
+			assert.Equal(t, test.version, version)
+		})
+	}
+
+	u, err := url.Parse("https://minio.example.com/vpc.zip?aws_access_key_secret=secret")
+	require.NoError(t, err)
+
+	_, _, _, _, err = parseS3URL(u)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestObjectDst(t *testing.T) {
+	dst := filepath.Join(t.TempDir(), "module")
+
+	p, err := objectDst(dst, "modules/vpc", "modules/vpc/nested/main.tf")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dst, "nested", "main.tf"), p)
+
+	_, err = objectDst(dst, "modules/vpc", "modules/vpc/../../../main.tf")
+	assert.EqualError(t, err, "Object modules/vpc/../../../main.tf would be downloaded outside of the module directory")
+
+	assert.True(t, inPrefixDir("modules/vpc/main.tf", "modules/vpc"))
+	assert.True(t, inPrefixDir("modules/vpc/main.tf", "modules/vpc/"))
+	assert.False(t, inPrefixDir("modules/vpc-other/main.tf", "modules/vpc"))
+}
+
+func TestParseGCSURL(t *testing.T) {
+	u, err := url.Parse("https://www.googleapis.com/storage/v1/bucket/modules/vpc.zip#12345")
+	require.NoError(t, err)
+
+	bucket, object, fragment, err := parseGCSURL(u)
+	require.NoError(t, err)
+	assert.Equal(t, "bucket", bucket)
+	assert.Equal(t, "modules/vpc.zip", object)
+	assert.Equal(t, "12345", fragment)
+
+	u, err = url.Parse("https://example.com/storage/v1/bucket/modules/vpc.zip")
+	require.NoError(t, err)
+
+	_, _, _, err = parseGCSURL(u)
+	assert.EqualError(t, err, "URL https://example.com/storage/v1/bucket/modules/vpc.zip is not a valid GCS URL")
+}
+
+func TestGCSGetterCredentialsFile(t *testing.T) {
+	g := &gcsGetter{env: map[string]string{"GOOGLE_APPLICATION_CREDENTIALS": filepath.Join(t.TempDir(), "missing.json")}}
+
+	_, err := g.newStorageClient(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing.json")
+}
@@ -1,6 +1,7 @@
 package modules
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,46 +14,86 @@ import (
 
 // PackageFetcher downloads modules from a remote source to the given destination
 // This supports all the non-local and non-Terraform registry sources listed here: https://www.terraform.io/language/modules/sources
+//
+// Each source is downloaded to a location that's shared with the PackageFetchers of other projects, see
+// SharedDownloads, and copied from there to the destination.
 type PackageFetcher struct {
-	cache map[string]string
+	shared   *SharedDownloads
+	acquired []*sharedDownload
+	gitSSH   GitSSHConfig
+	env      map[string]string
 }
 
 // NewPackageFetcher constructs a new package fetcher
 func NewPackageFetcher() *PackageFetcher {
 	return &PackageFetcher{
-		cache: make(map[string]string),
+		shared: sharedDownloads,
 	}
 }
 
 // fetch downloads the remote module using the go-getter library
 // See: https://github.com/hashicorp/go-getter
-func (r *PackageFetcher) fetch(moduleAddr string, dest string) error {
-	if prevDest, ok := r.cache[moduleAddr]; ok {
-		log.Debugf("Module %s already downloaded, copying from '%s' to '%s'", moduleAddr, prevDest, dest)
+//
+// Git sources are shallow cloned instead, and if submodulePath is set only that directory of the
+// repository is checked out, see gitDownload.
+func (r *PackageFetcher) fetch(ctx context.Context, moduleAddr string, submodulePath string, dest string) error {
+	source := moduleAddr
+	gitURL, isGit := gitSource(moduleAddr)
+	if isGit {
+		// Each subdirectory of a git repository is a separate sparse checkout.
+		source = joinModuleSubDir(moduleAddr, submodulePath)
+	}
 
-		err := os.Mkdir(dest, os.ModePerm)
-		if err != nil {
-			return fmt.Errorf("Failed to create directory '%s': %w", dest, err)
+	d, err := r.shared.acquire(ctx, source, func(sharedDest string) error {
+		log.Debugf("Downloading module %s to shared location '%s'", source, sharedDest)
+		if isGit {
+			return gitDownload(ctx, gitURL, submodulePath, sharedDest, r.gitSSH)
 		}
 
-		// Skip dotfiles and create new symlinks to be consistent with what Terraform init does
-		opt := copy.Options{
-			Skip: func(src string) (bool, error) {
-				return strings.HasPrefix(filepath.Base(src), "."), nil
-			},
-			OnSymlink: func(src string) copy.SymlinkAction {
-				return copy.Shallow
-			},
-		}
+		return download(ctx, moduleAddr, sharedDest, r.env)
+	})
+	if err != nil {
+		return err
+	}
+	r.acquired = append(r.acquired, d)
 
-		err = copy.Copy(prevDest, dest, opt)
-		if err != nil {
-			return fmt.Errorf("Failed to copy module from '%s' to '%s': %w", prevDest, dest, err)
-		}
+	log.Debugf("Copying module %s from '%s' to '%s'", moduleAddr, d.dir, dest)
+
+	err = os.MkdirAll(dest, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("Failed to create directory '%s': %w", dest, err)
+	}
+
+	// Skip dotfiles and create new symlinks to be consistent with what Terraform init does
+	opt := copy.Options{
+		Skip: func(src string) (bool, error) {
+			return strings.HasPrefix(filepath.Base(src), "."), nil
+		},
+		OnSymlink: func(src string) copy.SymlinkAction {
+			return copy.Shallow
+		},
+	}
 
-		return nil
+	err = copy.Copy(d.dir, dest, opt)
+	if err != nil {
+		return fmt.Errorf("Failed to copy module from '%s' to '%s': %w", d.dir, dest, err)
 	}
 
+	return nil
+}
+
+// release releases the shared downloads of the modules that have been fetched. It's called once the
+// modules have been copied to their destinations.
+func (r *PackageFetcher) release() {
+	for _, d := range r.acquired {
+		r.shared.release(d)
+	}
+	r.acquired = nil
+}
+
+// download downloads the module with go-getter. The env contains the cloud credentials that s3:: and gcs::
+// sources are downloaded with, see cloudGetters.
+func download(ctx context.Context, moduleAddr string, dest string, env map[string]string) error {
 	decompressors := map[string]getter.Decompressor{}
 	for k, decompressor := range getter.Decompressors {
 		decompressors[k] = decompressor
@@ -63,17 +104,16 @@ func (r *PackageFetcher) fetch(moduleAddr string, dest string) error {
 	decompressors["tar.tbz2"] = new(getter.TarBzip2Decompressor)
 
 	client := getter.Client{
+		Ctx:           ctx,
 		Src:           moduleAddr,
 		Dst:           dest,
 		Pwd:           dest,
 		Mode:          getter.ClientModeDir,
 		Decompressors: decompressors,
-		// We don't need to specify any of the Getters, since Terraform uses the same as the default Getter values,
-		// but if we do need to at some point we can specify them here:
-		// Getters: getters,
+		// Terraform uses the same as the default Getter values, but the cloud storage ones are replaced so they
+		// use the project's credentials.
+		Getters: cloudGetters(env),
 	}
 
-	r.cache[moduleAddr] = dest
-
 	return client.Get()
 }
@@ -0,0 +1,29 @@
+package modules
+
+import (
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+
+	"github.com/infracost/infracost/internal/hcl/funcs"
+)
+
+// moduleFS is the filesystem used to inspect the module calls in a directory. It rewrites the
+// provider-defined function calls in the files it reads, since tfconfig can't parse them.
+type moduleFS struct {
+	tfconfig.FS
+}
+
+var moduleFs = moduleFS{FS: tfconfig.NewOsFs()}
+
+func (fs moduleFS) ReadFile(name string) ([]byte, error) {
+	b, err := fs.FS.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return funcs.RewriteProviderFunctionCalls(b), nil
+}
+
+// loadModule inspects the Terraform module in dir.
+func loadModule(dir string) (*tfconfig.Module, tfconfig.Diagnostics) {
+	return tfconfig.LoadModuleFromFilesystem(moduleFs, dir)
+}
@@ -0,0 +1,383 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	getter "github.com/hashicorp/go-getter"
+	log "github.com/sirupsen/logrus"
+)
+
+// gitSource returns the git URL of the module address if it's downloaded with git, e.g. git::https://example.com/repo.git
+// or github.com/org/repo. Sources with a subdirectory in them, such as the X-Terraform-Get sources of registry modules,
+// aren't returned since go-getter copies the subdirectory to the root of the destination for these.
+func gitSource(moduleAddr string) (*url.URL, bool) {
+	detected, err := getter.Detect(moduleAddr, "", getter.Detectors)
+	if err != nil || !strings.HasPrefix(detected, "git::") {
+		return nil, false
+	}
+
+	src, subDir := getter.SourceDirSubdir(strings.TrimPrefix(detected, "git::"))
+	if subDir != "" {
+		return nil, false
+	}
+
+	u, err := url.Parse(src)
+	if err != nil {
+		return nil, false
+	}
+
+	return u, true
+}
+
+// GitSSHConfig configures the SSH command git uses to download git::ssh:// module sources.
+type GitSSHConfig struct {
+	// KeyFile is the path to the private key that's used to authenticate. The sshkey parameter of a source
+	// takes precedence over it.
+	KeyFile string
+	// KnownHostsFile is the path to the known_hosts file that host keys are checked against. Unknown hosts
+	// are rejected rather than added to the user's known_hosts file.
+	KnownHostsFile string
+}
+
+// gitDownload downloads the git repository to dest. Only the commit at the ref is fetched, and if submodulePath is set
+// only that directory and the directories of any local modules it calls are checked out. This is much faster than the
+// full clone go-getter does for repositories that have a long history or that contain many modules.
+func gitDownload(ctx context.Context, u *url.URL, submodulePath string, dest string, ssh GitSSHConfig) error {
+	u, ref, sshKey, depth, err := parseGitURL(u)
+	if err != nil {
+		return err
+	}
+
+	g := &gitCmd{ctx: ctx, dir: dest, ssh: ssh}
+
+	if sshKey != "" {
+		keyFile, err := writeSSHKey(sshKey)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(keyFile)
+
+		g.ssh.KeyFile = keyFile
+	}
+
+	err = os.MkdirAll(dest, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("Failed to create directory '%s': %w", dest, err)
+	}
+
+	err = g.run("init", "--quiet")
+	if err != nil {
+		return err
+	}
+
+	err = g.run("remote", "add", "origin", u.String())
+	if err != nil {
+		return err
+	}
+
+	var sparseDirs []string
+	if submodulePath != "" {
+		sparseDirs = []string{path.Clean(filepath.ToSlash(submodulePath))}
+
+		err = g.run("config", "core.sparseCheckout", "true")
+		if err != nil {
+			return err
+		}
+
+		err = writeSparseCheckout(dest, sparseDirs)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = g.fetch(ref, depth)
+	if err != nil {
+		return err
+	}
+
+	if sparseDirs != nil {
+		err = g.expandSparseCheckout(sparseDirs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dest, ".gitmodules")); err == nil {
+		args := []string{"submodule", "update", "--init", "--recursive", "--depth", strconv.Itoa(depth)}
+		if sparseDirs != nil {
+			args = append(append(args, "--"), sparseDirs...)
+		}
+
+		err = g.run(args...)
+		if err != nil {
+			// The commit of a submodule can't always be fetched shallowly, so try again with the full history.
+			log.Debugf("Shallow update of git submodules failed, updating them with full history: %s", err)
+			args = append(args[:4:4], args[6:]...)
+
+			err = g.run(args...)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseGitURL removes the go-getter query parameters from the git URL and returns them.
+func parseGitURL(u *url.URL) (*url.URL, string, string, int, error) {
+	clean := *u
+	q := clean.Query()
+
+	ref := q.Get("ref")
+	sshKey := q.Get("sshkey")
+
+	depth := 1
+	if v := q.Get("depth"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, "", "", 0, fmt.Errorf("Invalid git depth '%s'", v)
+		}
+		depth = n
+	}
+
+	q.Del("ref")
+	q.Del("sshkey")
+	q.Del("depth")
+	clean.RawQuery = q.Encode()
+
+	return &clean, ref, sshKey, depth, nil
+}
+
+// writeSSHKey writes the base64 encoded SSH key from the sshkey parameter of the source to a temporary file.
+func writeSSHKey(sshKey string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(sshKey)
+	if err != nil {
+		return "", fmt.Errorf("Invalid sshkey for git module source: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "infracost-ssh-key-")
+	if err != nil {
+		return "", fmt.Errorf("Failed to create SSH key file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(raw)
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("Failed to write SSH key file: %w", err)
+	}
+
+	return f.Name(), nil
+}
+
+// writeSparseCheckout sets the directories that are checked out from the repository.
+func writeSparseCheckout(repoDir string, dirs []string) error {
+	var patterns strings.Builder
+	for _, dir := range dirs {
+		fmt.Fprintf(&patterns, "/%s/\n", dir)
+	}
+
+	p := filepath.Join(repoDir, ".git", "info", "sparse-checkout")
+	err := os.MkdirAll(filepath.Dir(p), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("Failed to create git info directory: %w", err)
+	}
+
+	err = os.WriteFile(p, []byte(patterns.String()), 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to write git sparse checkout patterns: %w", err)
+	}
+
+	return nil
+}
+
+type gitCmd struct {
+	ctx context.Context
+	dir string
+	ssh GitSSHConfig
+}
+
+func (g *gitCmd) run(args ...string) error {
+	cmd := exec.CommandContext(g.ctx, "git", append([]string{"-c", "advice.detachedHead=false"}, args...)...)
+	cmd.Dir = g.dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	if sshCmd := g.sshCommand(os.Getenv("GIT_SSH_COMMAND")); sshCmd != "" {
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND="+sshCmd)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// sshCommand returns the GIT_SSH_COMMAND that uses the configured key and known_hosts files, appending them
+// to the existing command if there is one. It returns an empty string if neither is configured.
+func (g *gitCmd) sshCommand(existing string) string {
+	if g.ssh.KeyFile == "" && g.ssh.KnownHostsFile == "" {
+		return ""
+	}
+
+	args := []string{existing}
+	if existing == "" {
+		args[0] = "ssh"
+	}
+
+	if g.ssh.KeyFile != "" {
+		args = append(args, "-i", shellQuote(filepath.ToSlash(g.ssh.KeyFile)), "-o", "IdentitiesOnly=yes")
+	}
+
+	if g.ssh.KnownHostsFile != "" {
+		args = append(args,
+			"-o", "UserKnownHostsFile="+shellQuote(filepath.ToSlash(g.ssh.KnownHostsFile)),
+			"-o", "StrictHostKeyChecking=yes",
+		)
+	}
+
+	return strings.Join(args, " ")
+}
+
+// shellQuote quotes s so it's a single argument in the GIT_SSH_COMMAND, which git runs with the shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fetch checks out the ref, or the default branch if the ref is empty. The commit is fetched on its own
+// first, and if the remote doesn't allow that, e.g. because the ref is a commit that's not at the tip of
+// a branch, then the full history is fetched instead.
+func (g *gitCmd) fetch(ref string, depth int) error {
+	fetchRef := ref
+	if fetchRef == "" {
+		fetchRef = "HEAD"
+	}
+
+	err := g.run("fetch", "--no-tags", "--depth", strconv.Itoa(depth), "origin", fetchRef)
+	if err == nil {
+		return g.run("checkout", "--quiet", "FETCH_HEAD")
+	}
+
+	log.Debugf("Shallow fetch of git ref %s failed, fetching full history: %s", fetchRef, err)
+
+	if ref == "" {
+		err = g.run("fetch", "origin", "HEAD")
+		if err != nil {
+			return err
+		}
+
+		return g.run("checkout", "--quiet", "FETCH_HEAD")
+	}
+
+	err = g.run("fetch", "--tags", "origin", "+refs/heads/*:refs/remotes/origin/*")
+	if err != nil {
+		return err
+	}
+
+	return g.run("checkout", "--quiet", ref)
+}
+
+// expandSparseCheckout adds the directories of the local modules that are called from the checked out
+// directories to the sparse checkout, e.g. a module in modules/web that calls ../shared, until all the
+// local modules the submodule depends on are checked out.
+func (g *gitCmd) expandSparseCheckout(dirs []string) error {
+	queue := append([]string{}, dirs...)
+
+	for len(queue) > 0 {
+		var added []string
+
+		for _, dir := range queue {
+			for _, called := range localModuleCalls(g.dir, dir) {
+				if sparseCheckoutContains(dirs, called) {
+					continue
+				}
+
+				log.Debugf("Adding local module directory %s to git sparse checkout", called)
+				dirs = append(dirs, called)
+				added = append(added, called)
+			}
+		}
+
+		if len(added) == 0 {
+			return nil
+		}
+
+		err := writeSparseCheckout(g.dir, dirs)
+		if err != nil {
+			return err
+		}
+
+		err = g.run("read-tree", "-mu", "HEAD")
+		if err != nil {
+			return err
+		}
+
+		queue = added
+	}
+
+	return nil
+}
+
+// localModuleCalls returns the directories, relative to the repository root, of the local modules called
+// by the modules in dir and its subdirectories. Modules outside the repository are ignored.
+func localModuleCalls(repoDir string, dir string) []string {
+	var called []string
+
+	_ = filepath.WalkDir(filepath.Join(repoDir, filepath.FromSlash(dir)), func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") && p != repoDir {
+			return filepath.SkipDir
+		}
+
+		mod, _ := loadModule(p)
+		if mod == nil {
+			return nil
+		}
+
+		for _, call := range mod.ModuleCalls {
+			if !strings.HasPrefix(call.Source, "./") && !strings.HasPrefix(call.Source, "../") {
+				continue
+			}
+
+			rel, err := filepath.Rel(repoDir, filepath.Join(p, filepath.FromSlash(call.Source)))
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+
+			called = append(called, filepath.ToSlash(rel))
+		}
+
+		return nil
+	})
+
+	return called
+}
+
+func sparseCheckoutContains(dirs []string, dir string) bool {
+	for _, d := range dirs {
+		if d == "." || dir == d || strings.HasPrefix(dir, d+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,171 @@
+package modules
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitSource(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"git::https://example.com/repo.git?ref=v1.0.0", "https://example.com/repo.git?ref=v1.0.0"},
+		{"github.com/org/repo?ref=v1.0.0", "https://github.com/org/repo.git?ref=v1.0.0"},
+		{"git@github.com:org/repo.git", "ssh://git@github.com/org/repo.git"},
+		{"https://example.com/module.zip", ""},
+		{"git::https://example.com/repo.git//modules/web?ref=v1.0.0", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			u, ok := gitSource(test.source)
+			if test.expected == "" {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, test.expected, u.String())
+		})
+	}
+}
+
+func TestGitDownload(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "init.defaultBranch=main"}, args...)...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	files := map[string]string{
+		"modules/web/main.tf":       `module "shared" { source = "../shared" }`,
+		"modules/shared/main.tf":    `module "tags" { source = "../../tags" }`,
+		"tags/main.tf":              `output "tags" { value = {} }`,
+		"modules/unused/main.tf":    `resource "aws_instance" "web" {}`,
+		"examples/complete/main.tf": `module "web" { source = "../../modules/web" }`,
+	}
+	for name, contents := range files {
+		p := filepath.Join(repo, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, []byte(contents), 0600))
+	}
+
+	git("init", "--quiet")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "v1")
+	git("tag", "v1.0.0")
+	first := git("rev-parse", "HEAD")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "modules", "web", "main.tf"), []byte(`resource "aws_instance" "v2" {}`), 0600))
+	git("commit", "--quiet", "-am", "v2")
+
+	tests := []struct {
+		name          string
+		ref           string
+		submodulePath string
+		exists        []string
+		notExists     []string
+		webContents   string
+	}{
+		{
+			name:        "default branch",
+			exists:      []string{"modules/web", "modules/unused", "examples/complete"},
+			webContents: `resource "aws_instance" "v2" {}`,
+		},
+		{
+			name:          "tag with subdirectory",
+			ref:           "v1.0.0",
+			submodulePath: "modules/web",
+			exists:        []string{"modules/web", "modules/shared", "tags"},
+			notExists:     []string{"modules/unused", "examples"},
+			webContents:   `module "shared" { source = "../shared" }`,
+		},
+		{
+			name:          "commit with subdirectory",
+			ref:           first,
+			submodulePath: "modules/web",
+			exists:        []string{"modules/web", "modules/shared", "tags"},
+			notExists:     []string{"modules/unused", "examples"},
+			webContents:   `module "shared" { source = "../shared" }`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := "git::file://" + filepath.ToSlash(repo)
+			if test.ref != "" {
+				source += "?ref=" + test.ref
+			}
+
+			u, ok := gitSource(source)
+			require.True(t, ok)
+
+			dest := filepath.Join(t.TempDir(), "module")
+			require.NoError(t, gitDownload(context.Background(), u, test.submodulePath, dest, GitSSHConfig{}))
+
+			for _, p := range test.exists {
+				assert.DirExists(t, filepath.Join(dest, p))
+			}
+			for _, p := range test.notExists {
+				assert.NoDirExists(t, filepath.Join(dest, p))
+			}
+
+			b, err := os.ReadFile(filepath.Join(dest, "modules", "web", "main.tf"))
+			require.NoError(t, err)
+			assert.Equal(t, test.webContents, string(b))
+
+			assert.FileExists(t, filepath.Join(dest, ".git", "shallow"))
+		})
+	}
+}
+
+func TestGitSSHCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		ssh      GitSSHConfig
+		existing string
+		expected string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:     "key file",
+			ssh:      GitSSHConfig{KeyFile: "/keys/id_ed25519"},
+			expected: "ssh -i '/keys/id_ed25519' -o IdentitiesOnly=yes",
+		},
+		{
+			name:     "known hosts",
+			ssh:      GitSSHConfig{KnownHostsFile: "/etc/ssh/known hosts"},
+			expected: "ssh -o UserKnownHostsFile='/etc/ssh/known hosts' -o StrictHostKeyChecking=yes",
+		},
+		{
+			name:     "appended to existing command",
+			ssh:      GitSSHConfig{KeyFile: "/keys/it's", KnownHostsFile: "/etc/ssh/known_hosts"},
+			existing: "ssh -p 2222",
+			expected: `ssh -p 2222 -i '/keys/it'\''s' -o IdentitiesOnly=yes -o UserKnownHostsFile='/etc/ssh/known_hosts' -o StrictHostKeyChecking=yes`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := &gitCmd{ssh: test.ssh}
+			assert.Equal(t, test.expected, g.sshCommand(test.existing))
+		})
+	}
+}
@@ -1,10 +1,11 @@
 package modules
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 
@@ -22,20 +23,30 @@ var (
 	manifestPath = ".infracost/terraform_modules/manifest.json"
 	// tfManifestPath is the name of the terraform module manifest file which stores the metadata of the modules
 	tfManifestPath = ".terraform/modules/modules.json"
+	// lockFilePath is the name of the module lock file which stores the digests of the downloaded module versions
+	lockFilePath = ".infracost/modules.lock.json"
 )
 
 // ModuleLoader handles the loading of Terraform modules. It supports local, registry and other remote modules.
 //
 // The path should be the root directory of the Terraform project. We use a distinct module loader per Terraform project,
 // because at the moment the cache is per project. The cache reads the manifest.json file from the path's
-// .infracost/terraform_modules directory. We could implement a global cache in the future, but for now have decided
-// to go with the same approach as Terraform.
+// .infracost/terraform_modules directory, the same approach as Terraform. Remote modules are only downloaded once
+// per run though, and copied to each project that uses them, see SharedDownloads.
 type ModuleLoader struct {
 	Path           string
 	cache          *Cache
 	packageFetcher *PackageFetcher
 	registryLoader *RegistryLoader
+	registryHost   string
+	gitSSH         GitSSHConfig
+	env            map[string]string
 	newSpinner     ui.SpinnerFunc
+	writeWarning   ui.WriteWarningFunc
+	lock           *LockFile
+	lockChanged    bool
+	maxDepth       int
+	cacheDir       string
 }
 
 // LoaderOption defines a function that can set properties on an ModuleLoader.
@@ -48,32 +59,91 @@ func LoaderWithSpinner(f ui.SpinnerFunc) LoaderOption {
 	}
 }
 
+// LoaderWithWarningFunc sets the function used to show warnings, e.g. for module sources that aren't
+// allowed by a SourcePolicy in SourcePolicyModeWarn.
+func LoaderWithWarningFunc(f ui.WriteWarningFunc) LoaderOption {
+	return func(l *ModuleLoader) {
+		l.writeWarning = f
+	}
+}
+
+// LoaderWithRegistryHost sets the registry host used to resolve registry module sources that don't
+// specify a hostname. This allows projects using OpenTofu to resolve modules from the OpenTofu registry.
+func LoaderWithRegistryHost(host string) LoaderOption {
+	return func(l *ModuleLoader) {
+		l.registryHost = host
+	}
+}
+
+// LoaderWithGitSSH sets the SSH key and known_hosts file used to download git::ssh:// module sources.
+func LoaderWithGitSSH(c GitSSHConfig) LoaderOption {
+	return func(l *ModuleLoader) {
+		l.gitSSH = c
+	}
+}
+
+// LoaderWithEnv sets the environment variables with the cloud credentials used to download s3:: and gcs::
+// module sources, e.g. AWS_PROFILE or GOOGLE_APPLICATION_CREDENTIALS. Sources use the credentials from the
+// process environment if it's not set.
+func LoaderWithEnv(env map[string]string) LoaderOption {
+	return func(l *ModuleLoader) {
+		l.env = env
+	}
+}
+
+// LoaderWithMaxDepth sets how deep module calls can be nested. Module calls that are deeper are skipped
+// with a warning, so a module that calls itself can't be loaded forever. Zero means there's no limit.
+func LoaderWithMaxDepth(depth int) LoaderOption {
+	return func(l *ModuleLoader) {
+		l.maxDepth = depth
+	}
+}
+
+// LoaderWithCacheDir sets the directory that the downloaded modules, the module manifest and the lock
+// file are written to instead of the .infracost directory of the project, so the project directory
+// isn't written to, e.g. when it's read-only.
+func LoaderWithCacheDir(dir string) LoaderOption {
+	return func(l *ModuleLoader) {
+		l.cacheDir = dir
+	}
+}
+
 // NewModuleLoader constructs a new module loader
 func NewModuleLoader(path string, opts ...LoaderOption) *ModuleLoader {
-	fetcher := NewPackageFetcher()
-
 	m := &ModuleLoader{
-		Path:           path,
-		cache:          NewCache(),
-		packageFetcher: fetcher,
-		registryLoader: NewRegistryLoader(fetcher),
+		Path:         path,
+		cache:        NewCache(),
+		registryHost: defaultRegistryHost,
 	}
 
 	for _, opt := range opts {
 		opt(m)
 	}
 
+	fetcher := NewPackageFetcher()
+	fetcher.gitSSH = m.gitSSH
+	fetcher.env = m.env
+
+	m.packageFetcher = fetcher
+	m.registryLoader = NewRegistryLoader(fetcher, m.registryHost)
+
 	return m
 }
 
 // downloadDir returns the path to the directory where remote modules are downloaded relative to the current working directory
 func (m *ModuleLoader) downloadDir() string {
-	return filepath.Join(m.Path, downloadDir)
+	return m.cachePath(downloadDir)
+}
+
+// DownloadDir returns the directory that the remote modules of the Terraform project at path are downloaded to,
+// along with the manifest.json file that records them.
+func DownloadDir(path string) string {
+	return filepath.Join(path, downloadDir)
 }
 
 // manifestFilePath is the path to the module manifest file relative to the current working directory
 func (m *ModuleLoader) manifestFilePath() string {
-	return filepath.Join(m.Path, manifestPath)
+	return m.cachePath(manifestPath)
 }
 
 // tfManifestFilePath is the path to the terraform module manifest file relative to the current working directory.
@@ -81,10 +151,41 @@ func (m *ModuleLoader) tfManifestFilePath() string {
 	return filepath.Join(m.Path, tfManifestPath)
 }
 
+// lockFilePath is the path to the module lock file relative to the current working directory.
+func (m *ModuleLoader) lockFilePath() string {
+	return m.cachePath(lockFilePath)
+}
+
+// cachePath returns the path of a file in the .infracost directory of the project, or in the cache
+// directory if it's set.
+func (m *ModuleLoader) cachePath(rel string) string {
+	if m.cacheDir != "" {
+		return filepath.Join(m.cacheDir, strings.TrimPrefix(rel, ".infracost/"))
+	}
+
+	return filepath.Join(m.Path, rel)
+}
+
+// relToPath returns the target path relative to the project path, which is how the module directories
+// are recorded in the manifest. The cache directory can be absolute when the project path isn't.
+func (m *ModuleLoader) relToPath(target string) (string, error) {
+	base := m.Path
+	if filepath.IsAbs(target) && !filepath.IsAbs(base) {
+		abs, err := filepath.Abs(base)
+		if err != nil {
+			return "", err
+		}
+		base = abs
+	}
+
+	return filepath.Rel(base, target)
+}
+
 // Load loads the modules from the given path.
 // For each module it checks if the module has already been downloaded, by checking if iut exists in the manifest
 // If not then it downloads the module from the registry or from a remote source and updates the module manifest with the latest metadata.
-func (m *ModuleLoader) Load() (*Manifest, error) {
+// The ctx is checked before loading each module and is used to cancel any in-flight downloads.
+func (m *ModuleLoader) Load(ctx context.Context) (*Manifest, error) {
 	if m.newSpinner != nil {
 		spin := m.newSpinner("Downloading Terraform modules")
 		defer spin.Success()
@@ -116,7 +217,16 @@ func (m *ModuleLoader) Load() (*Manifest, error) {
 
 	m.cache.loadFromManifest(manifest)
 
-	metadatas, err := m.loadModules(m.Path, "")
+	m.lock, err = readLockFile(m.lockFilePath())
+	if err != nil {
+		log.Debugf("Error reading module lock file: %s", err)
+	}
+
+	// the remote modules have been copied to the project once they're loaded, so the project no longer
+	// needs the shared downloads.
+	defer m.packageFetcher.release()
+
+	metadatas, err := m.loadModules(ctx, m.Path, "", 1)
 	if err != nil {
 		return nil, err
 	}
@@ -128,27 +238,48 @@ func (m *ModuleLoader) Load() (*Manifest, error) {
 		log.Debugf("Error writing module manifest: %s", err)
 	}
 
+	if m.lockChanged {
+		err = writeLockFile(m.lock, m.lockFilePath())
+		if err != nil {
+			log.Debugf("Error writing module lock file: %s", err)
+		}
+	}
+
 	return manifest, nil
 }
 
-// loadModules recursively loads the modules from the given path.
-func (m *ModuleLoader) loadModules(path string, prefix string) ([]*ManifestModule, error) {
+// loadModules recursively loads the modules from the given path. depth is how deep the module calls
+// in the path are nested, starting at 1 for the root module.
+func (m *ModuleLoader) loadModules(ctx context.Context, path string, prefix string, depth int) ([]*ManifestModule, error) {
 	manifestModules := make([]*ManifestModule, 0)
 
-	module, diags := tfconfig.LoadModule(path)
+	module, diags := loadModule(path)
 	if diags.HasErrors() {
 		return nil, diags.Err()
 	}
 
 	for _, moduleCall := range module.ModuleCalls {
-		metadata, err := m.loadModule(moduleCall, path, prefix)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if m.maxDepth > 0 && depth > m.maxDepth {
+			msg := fmt.Sprintf("Skipping module %s%s since it is nested %d deep which is over the limit of %d, set INFRACOST_MAX_MODULE_DEPTH to change it", prefix, moduleCall.Name, depth, m.maxDepth)
+			log.Warn(msg)
+			if m.writeWarning != nil {
+				m.writeWarning(msg)
+			}
+			continue
+		}
+
+		metadata, err := m.loadModule(ctx, moduleCall, path, prefix)
 		if err != nil {
 			return nil, err
 		}
 
 		manifestModules = append(manifestModules, metadata)
 
-		nestedManifestModules, err := m.loadModules(filepath.Join(m.Path, metadata.Dir), metadata.Key+".")
+		nestedManifestModules, err := m.loadModules(ctx, filepath.Join(m.Path, metadata.Dir), metadata.Key+".", depth+1)
 		if err != nil {
 			return nil, err
 		}
@@ -165,17 +296,24 @@ func (m *ModuleLoader) loadModules(path string, prefix string) ([]*ManifestModul
 // 2. Checks if the module is a local module.
 // 3. Checks if the module is a registry module and downloads it.
 // 4. Checks if the module is a remote module and downloads it.
-func (m *ModuleLoader) loadModule(moduleCall *tfconfig.ModuleCall, parentPath string, prefix string) (*ManifestModule, error) {
+func (m *ModuleLoader) loadModule(ctx context.Context, moduleCall *tfconfig.ModuleCall, parentPath string, prefix string) (*ManifestModule, error) {
 	key := prefix + moduleCall.Name
 
-	manifestModule, err := m.cache.lookupModule(key, moduleCall)
+	if !m.isLocalModule(moduleCall) {
+		err := m.checkSourcePolicy(key, moduleCall.Source)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	manifestModule, err := m.cache.lookupModule(key, moduleCall, m.registryHost)
 	if err == nil {
 		log.Debugf("Module %s already loaded", key)
 
 		// Test if we can actually load the module. If not, then we should try re-loading it.
 		// This can happen if the directory the module was downloaded to has been deleted and moved
 		// so the existing manifest.json is out-of-date.
-		_, diags := tfconfig.LoadModule(path.Join(m.Path, manifestModule.Dir))
+		_, diags := loadModule(filepath.Join(m.Path, manifestModule.Dir))
 		if !diags.HasErrors() {
 			return manifestModule, err
 		}
@@ -197,7 +335,7 @@ func (m *ModuleLoader) loadModule(moduleCall *tfconfig.ModuleCall, parentPath st
 		}
 
 		log.Debugf("Loading local module %s from %s", key, dir)
-		manifestModule.Dir = path.Clean(dir)
+		manifestModule.Dir = filepath.Clean(dir)
 		return manifestModule, nil
 	}
 
@@ -215,16 +353,16 @@ func (m *ModuleLoader) loadModule(moduleCall *tfconfig.ModuleCall, parentPath st
 		return nil, err
 	}
 
-	moduleDownloadDir, err := filepath.Rel(m.Path, dest)
+	moduleDownloadDir, err := m.relToPath(dest)
 	if err != nil {
 		return nil, err
 	}
-	manifestModule.Dir = path.Clean(filepath.Join(moduleDownloadDir, submodulePath))
+	manifestModule.Dir = filepath.Clean(filepath.Join(moduleDownloadDir, submodulePath))
 
-	lookupResult, err := m.registryLoader.lookupModule(moduleAddr, moduleCall.Version)
+	lookupResult, err := m.registryLoader.lookupModule(ctx, moduleAddr, moduleCall.Version)
 	if err == nil {
 		log.Debugf("Downloading module %s from registry URL %s", key, lookupResult.DownloadURL)
-		err = m.registryLoader.downloadModule(lookupResult.DownloadURL, dest)
+		err = m.registryLoader.downloadModule(ctx, lookupResult.DownloadURL, dest)
 		if err != nil {
 			return nil, err
 		}
@@ -234,12 +372,24 @@ func (m *ModuleLoader) loadModule(moduleCall *tfconfig.ModuleCall, parentPath st
 		manifestModule.Source = joinModuleSubDir(lookupResult.Source, submodulePath)
 
 		manifestModule.Version = lookupResult.Version
+
+		err = m.verifyModule(key, manifestModule.Source, manifestModule.Version, true, dest)
+		if err != nil {
+			return nil, err
+		}
+
 		return manifestModule, nil
 	}
 
 	log.Debugf("Module %s not recognized as registry module, treating as remote module: %s", key, err.Error())
 	log.Debugf("Downloading module %s from remote %s", key, moduleCall.Source)
-	err = m.packageFetcher.fetch(moduleAddr, dest)
+	err = m.packageFetcher.fetch(ctx, moduleAddr, submodulePath, dest)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := sourceRef(moduleAddr)
+	err = m.verifyModule(key, moduleCall.Source, ref, commitRefReg.MatchString(ref), dest)
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +397,91 @@ func (m *ModuleLoader) loadModule(moduleCall *tfconfig.ModuleCall, parentPath st
 	return manifestModule, nil
 }
 
+// verifyModule checks the digest of the module downloaded to dir against the digest of the same version in
+// the lock file, and adds the version to the lock file if it's not there. Sources without a version aren't
+// checked since their contents are expected to change.
+//
+// If the version is immutable, i.e. a registry version or a git commit, a mismatch returns a LockMismatchError.
+// Other versions, such as git branches, can move so a mismatch is shown as a warning and the lock file is updated.
+func (m *ModuleLoader) verifyModule(key string, source string, version string, immutable bool, dir string) error {
+	if version == "" || m.lock == nil {
+		return nil
+	}
+
+	digest, err := hashModuleDir(dir)
+	if err != nil {
+		return err
+	}
+
+	locked := m.lock.lookup(source, version)
+	if locked == nil {
+		m.lock.Modules = append(m.lock.Modules, &LockedModule{Source: source, Version: version, Digest: digest})
+		m.lockChanged = true
+		return nil
+	}
+
+	if locked.Digest == digest {
+		return nil
+	}
+
+	mismatch := &LockMismatchError{
+		Module:   key,
+		Source:   source,
+		Version:  version,
+		Expected: locked.Digest,
+		Actual:   digest,
+		LockFile: m.lockFilePath(),
+	}
+
+	if immutable {
+		return fmt.Errorf("%w. If this change is expected remove the module from the lock file", mismatch)
+	}
+
+	msg := fmt.Sprintf("%s. The lock file has been updated since version %s can change", mismatch, version)
+	log.Warn(msg)
+	if m.writeWarning != nil {
+		m.writeWarning(msg)
+	}
+
+	locked.Digest = digest
+	m.lockChanged = true
+
+	return nil
+}
+
+// sourceRef returns the ref parameter of the remote module source, or an empty string if it doesn't have one.
+func sourceRef(moduleAddr string) string {
+	i := strings.Index(moduleAddr, "?")
+	if i == -1 {
+		return ""
+	}
+
+	q, err := url.ParseQuery(moduleAddr[i+1:])
+	if err != nil {
+		return ""
+	}
+
+	return q.Get("ref")
+}
+
+// checkSourcePolicy checks the module source against the SourcePolicy. Sources that aren't allowed return
+// an error, or are shown as a warning if the policy is in SourcePolicyModeWarn.
+func (m *ModuleLoader) checkSourcePolicy(key string, source string) error {
+	policy := currentSourcePolicy()
+
+	err := policy.check(key, source, m.registryHost)
+	if err == nil || policy.Mode != SourcePolicyModeWarn {
+		return err
+	}
+
+	log.Warn(err.Error())
+	if m.writeWarning != nil {
+		m.writeWarning(err.Error())
+	}
+
+	return nil
+}
+
 // isLocalModule checks if the module is a local module by checking
 // if the module source starts with any known local prefixes
 func (m *ModuleLoader) isLocalModule(moduleCall *tfconfig.ModuleCall) bool {
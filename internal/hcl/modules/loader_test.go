@@ -1,6 +1,7 @@
 package modules
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"sort"
@@ -17,7 +18,7 @@ func testLoaderE2E(t *testing.T, path string, expectedModules []*ManifestModule,
 
 	moduleLoader := NewModuleLoader(path)
 
-	manifest, err := moduleLoader.Load()
+	manifest, err := moduleLoader.Load(context.Background())
 	if !assert.NoError(t, err) {
 		t.FailNow()
 	}
@@ -76,6 +77,16 @@ func TestNestedModules(t *testing.T) {
 	}, true)
 }
 
+func TestLoadCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	moduleLoader := NewModuleLoader("./testdata/nested_modules")
+
+	_, err := moduleLoader.Load(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestSubmodules(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping test in short mode")
@@ -188,3 +199,33 @@ func TestWithCachedModules(t *testing.T) {
 	assert.Equal(t, string(regModContents), "// Placeholder file\n")
 	assert.Equal(t, string(gitModContents), "// Placeholder file\n")
 }
+
+func TestLoaderWithCacheDir(t *testing.T) {
+	path := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(path, "modules", "local"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(path, "main.tf"), []byte(`
+module "local" {
+  source = "./modules/local"
+}
+`), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(path, "modules", "local", "main.tf"), []byte(""), 0600))
+
+	cacheDir := t.TempDir()
+	moduleLoader := NewModuleLoader(path, LoaderWithCacheDir(cacheDir))
+
+	manifest, err := moduleLoader.Load(context.Background())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, []*ManifestModule{{Key: "local", Source: "./modules/local", Dir: "modules/local"}}, manifest.Modules)
+	assert.FileExists(t, filepath.Join(cacheDir, "terraform_modules", "manifest.json"))
+	assert.NoDirExists(t, filepath.Join(path, ".infracost"))
+
+	assert.Equal(t, filepath.Join(cacheDir, "terraform_modules"), moduleLoader.downloadDir())
+
+	rel, err := NewModuleLoader("testdata/nested_modules", LoaderWithCacheDir(cacheDir)).relToPath(filepath.Join(cacheDir, "terraform_modules", "git-module"))
+	assert.NoError(t, err)
+	abs, _ := filepath.Abs("testdata/nested_modules")
+	assert.Equal(t, filepath.Join(abs, rel), filepath.Join(cacheDir, "terraform_modules", "git-module"))
+}
@@ -0,0 +1,173 @@
+package modules
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// commitRefReg matches git refs that are full commit hashes. Their contents, like registry module
+// versions, can't change without the commit changing.
+var commitRefReg = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// LockFile is a struct that represents the JSON found in the modules.lock.json file in the .infracost dir.
+// It records the digest of the contents of each version of the modules that have been downloaded, so that
+// a module whose contents change without its version changing, e.g. because a registry or repository has
+// been tampered with, is detected the next time it's downloaded.
+type LockFile struct {
+	Modules []*LockedModule `json:"modules"`
+}
+
+// LockedModule represents a single module version in the modules.lock.json file.
+type LockedModule struct {
+	// Source is the module source including any subdirectory, with the registry host for registry modules.
+	Source string `json:"source"`
+	// Version is the registry version of registry modules, or the ref parameter of other sources.
+	Version string `json:"version"`
+	// Digest is the SHA256 digest of the module's files, see hashModuleDir.
+	Digest string `json:"digest"`
+}
+
+// LockMismatchError is returned when a downloaded module's digest doesn't match the digest recorded
+// for the same version in the lock file.
+type LockMismatchError struct {
+	Module   string
+	Source   string
+	Version  string
+	Expected string
+	Actual   string
+	LockFile string
+}
+
+func (e *LockMismatchError) Error() string {
+	return fmt.Sprintf(
+		"Module %s source %s version %s has changed: its digest %s doesn't match %s in %s",
+		e.Module, e.Source, e.Version, e.Actual, e.Expected, e.LockFile,
+	)
+}
+
+// readLockFile reads the lock file from the given path. A missing lock file is returned as an empty LockFile.
+func readLockFile(path string) (*LockFile, error) {
+	var lock LockFile
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &lock, nil
+	}
+	if err != nil {
+		return &lock, fmt.Errorf("Failed to read module lock file: %w", err)
+	}
+
+	err = json.Unmarshal(data, &lock)
+	if err != nil {
+		return &LockFile{}, fmt.Errorf("Failed to unmarshal module lock file: %w", err)
+	}
+
+	return &lock, nil
+}
+
+// writeLockFile writes the lock file to the given path. The modules are sorted so the file is stable.
+func writeLockFile(lock *LockFile, path string) error {
+	sort.Slice(lock.Modules, func(i, j int) bool {
+		if lock.Modules[i].Source != lock.Modules[j].Source {
+			return lock.Modules[i].Source < lock.Modules[j].Source
+		}
+
+		return lock.Modules[i].Version < lock.Modules[j].Version
+	})
+
+	b, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to marshal module lock file: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("Failed to create directories for module lock file: %w", err)
+	}
+
+	err = os.WriteFile(path, append(b, '\n'), 0644) // nolint:gosec
+	if err != nil {
+		return fmt.Errorf("Failed to write module lock file: %w", err)
+	}
+
+	return nil
+}
+
+// lookup returns the locked module with the source and version, or nil if it's not in the lock file.
+func (l *LockFile) lookup(source string, version string) *LockedModule {
+	for _, m := range l.Modules {
+		if m.Source == source && m.Version == version {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// hashModuleDir returns the SHA256 digest of the files in dir. Dotfiles are skipped, the same as when
+// modules are copied, so the digest doesn't depend on e.g. the .git directory of git sources.
+func hashModuleDir(dir string) (string, error) {
+	h := sha256.New()
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(h, "symlink:%s\x00", filepath.ToSlash(target))
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		fh := sha256.New()
+		_, err = io.Copy(fh, f)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(h, "%x\x00", fh.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to hash module directory '%s': %w", dir, err)
+	}
+
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
@@ -0,0 +1,94 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashModuleDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "modules", "web"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`module "web" { source = "./modules/web" }`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "modules", "web", "main.tf"), []byte(`resource "aws_instance" "web" {}`), 0600))
+
+	digest, err := hashModuleDir(dir)
+	require.NoError(t, err)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, digest)
+
+	// dotfiles aren't part of the module.
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref: refs/heads/main"), 0600))
+
+	unchanged, err := hashModuleDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, digest, unchanged)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "modules", "web", "main.tf"), []byte(`resource "aws_instance" "changed" {}`), 0600))
+
+	changed, err := hashModuleDir(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, changed)
+}
+
+func TestLoaderVerifyModule(t *testing.T) {
+	var warnings []string
+	loader := NewModuleLoader(t.TempDir(), LoaderWithWarningFunc(func(msg string) {
+		warnings = append(warnings, msg)
+	}))
+
+	lock, err := readLockFile(loader.lockFilePath())
+	require.NoError(t, err)
+	loader.lock = lock
+
+	moduleDir := t.TempDir()
+	write := func(contents string) {
+		require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "main.tf"), []byte(contents), 0600))
+	}
+
+	write(`resource "aws_instance" "web" {}`)
+	require.NoError(t, loader.verifyModule("vpc", "registry.terraform.io/org/vpc/aws", "1.0.0", true, moduleDir))
+	require.NoError(t, loader.verifyModule("web", "git::https://example.com/web.git?ref=main", "main", false, moduleDir))
+	require.NoError(t, loader.verifyModule("db", "git::https://example.com/db.git", "", false, moduleDir))
+	assert.True(t, loader.lockChanged)
+	assert.Len(t, loader.lock.Modules, 2)
+
+	require.NoError(t, writeLockFile(loader.lock, loader.lockFilePath()))
+	lock, err = readLockFile(loader.lockFilePath())
+	require.NoError(t, err)
+	assert.Equal(t, "git::https://example.com/web.git?ref=main", lock.Modules[0].Source)
+	assert.Equal(t, "registry.terraform.io/org/vpc/aws", lock.Modules[1].Source)
+	original := lock.Modules[1].Digest
+
+	loader.lock = lock
+	loader.lockChanged = false
+	require.NoError(t, loader.verifyModule("vpc", "registry.terraform.io/org/vpc/aws", "1.0.0", true, moduleDir))
+	assert.False(t, loader.lockChanged)
+
+	write(`resource "aws_instance" "tampered" {}`)
+
+	err = loader.verifyModule("vpc", "registry.terraform.io/org/vpc/aws", "1.0.0", true, moduleDir)
+	var mismatch *LockMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, original, mismatch.Expected)
+	assert.Equal(t, original, lock.lookup("registry.terraform.io/org/vpc/aws", "1.0.0").Digest)
+	assert.Empty(t, warnings)
+
+	// versions that can move, like git branches, are updated with a warning.
+	require.NoError(t, loader.verifyModule("web", "git::https://example.com/web.git?ref=main", "main", false, moduleDir))
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "Module web source git::https://example.com/web.git?ref=main version main has changed")
+	assert.True(t, loader.lockChanged)
+	assert.Equal(t, mismatch.Actual, lock.lookup("git::https://example.com/web.git?ref=main", "main").Digest)
+}
+
+func TestSourceRef(t *testing.T) {
+	assert.Equal(t, "v1.0.0", sourceRef("git::https://example.com/repo.git?ref=v1.0.0"))
+	assert.Equal(t, "main", sourceRef("github.com/org/repo?depth=1&ref=main"))
+	assert.Equal(t, "", sourceRef("https://example.com/module.zip"))
+	assert.True(t, commitRefReg.MatchString("0123456789abcdef0123456789abcdef01234567"))
+	assert.False(t, commitRefReg.MatchString("v1.0.0"))
+}
@@ -40,6 +40,18 @@ func readManifest(path string) (*Manifest, error) {
 	return &manifest, err
 }
 
+// ReadProjectManifest reads the manifest of the modules that have been downloaded for the project at
+// the given path. If Infracost hasn't downloaded the modules then the Terraform module manifest is
+// read instead, so modules downloaded by terraform init are found too.
+func ReadProjectManifest(projectPath string) (*Manifest, error) {
+	manifest, err := readManifest(filepath.Join(projectPath, manifestPath))
+	if err == nil {
+		return manifest, nil
+	}
+
+	return readManifest(filepath.Join(projectPath, tfManifestPath))
+}
+
 // writeManifest writes the manifest file to the given path
 func writeManifest(manifest *Manifest, path string) error {
 	b, err := json.Marshal(manifest)
@@ -0,0 +1,172 @@
+package modules
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	getter "github.com/hashicorp/go-getter"
+)
+
+// Modes of the SourcePolicy that set what happens when a module source isn't allowed.
+const (
+	// SourcePolicyModeError fails loading the project's modules.
+	SourcePolicyModeError = "error"
+	// SourcePolicyModeWarn shows a warning and downloads the module anyway.
+	SourcePolicyModeWarn = "warn"
+)
+
+var (
+	sourcePolicyMu sync.RWMutex
+	sourcePolicy   SourcePolicy
+)
+
+// SourcePolicy restricts the remote module sources that are downloaded. Local module sources are
+// always allowed since they're part of the project.
+//
+// The Allow and Deny patterns are matched against the source as it's written, e.g.
+// git::https://github.com/org/repo.git?ref=v1.0.0, and against the normalized address of the source,
+// which is the registry host, namespace, name and provider of registry modules, e.g.
+// registry.terraform.io/terraform-aws-modules/vpc/aws, or the host and path of other sources, e.g.
+// github.com/org/repo.git. A * in a pattern matches any characters.
+type SourcePolicy struct {
+	// Allow is the list of patterns that sources must match. If it's empty all sources are allowed
+	// unless they match a Deny pattern.
+	Allow []string
+	// Deny is the list of patterns that sources must not match. It takes precedence over Allow.
+	Deny []string
+	// Mode is SourcePolicyModeError or SourcePolicyModeWarn. It defaults to SourcePolicyModeError.
+	Mode string
+}
+
+// SourcePolicyModes returns the supported SourcePolicy modes.
+func SourcePolicyModes() []string {
+	return []string{SourcePolicyModeError, SourcePolicyModeWarn}
+}
+
+// IsValidSourcePolicyMode returns true if the mode is one of the supported SourcePolicy modes.
+func IsValidSourcePolicyMode(mode string) bool {
+	for _, m := range SourcePolicyModes() {
+		if m == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetSourcePolicy sets the policy that the module sources of all the ModuleLoaders are checked against.
+func SetSourcePolicy(p SourcePolicy) error {
+	if p.Mode == "" {
+		p.Mode = SourcePolicyModeError
+	}
+
+	if !IsValidSourcePolicyMode(p.Mode) {
+		return fmt.Errorf("Invalid module_source_policy_mode '%s', must be one of: %s", p.Mode, strings.Join(SourcePolicyModes(), ", "))
+	}
+
+	sourcePolicyMu.Lock()
+	sourcePolicy = p
+	sourcePolicyMu.Unlock()
+
+	return nil
+}
+
+func currentSourcePolicy() SourcePolicy {
+	sourcePolicyMu.RLock()
+	defer sourcePolicyMu.RUnlock()
+
+	return sourcePolicy
+}
+
+// SourcePolicyError is returned for module sources that the SourcePolicy doesn't allow.
+type SourcePolicyError struct {
+	Module  string
+	Source  string
+	Pattern string
+}
+
+func (e *SourcePolicyError) Error() string {
+	if e.Pattern != "" {
+		return fmt.Sprintf("Module %s source %s is blocked by module_source_deny pattern %s", e.Module, e.Source, e.Pattern)
+	}
+
+	return fmt.Sprintf("Module %s source %s doesn't match any module_source_allow pattern", e.Module, e.Source)
+}
+
+// check returns a SourcePolicyError if the source of the module isn't allowed. The registryHost is used
+// to normalize registry sources that don't include a hostname.
+func (p SourcePolicy) check(module string, source string, registryHost string) error {
+	if len(p.Allow) == 0 && len(p.Deny) == 0 {
+		return nil
+	}
+
+	addrs := sourceAddresses(source, registryHost)
+
+	for _, pattern := range p.Deny {
+		if matchSourcePattern(pattern, addrs) {
+			return &SourcePolicyError{Module: module, Source: source, Pattern: pattern}
+		}
+	}
+
+	if len(p.Allow) == 0 {
+		return nil
+	}
+
+	for _, pattern := range p.Allow {
+		if matchSourcePattern(pattern, addrs) {
+			return nil
+		}
+	}
+
+	return &SourcePolicyError{Module: module, Source: source}
+}
+
+// sourceAddresses returns the source and its normalized address.
+func sourceAddresses(source string, registryHost string) []string {
+	addrs := []string{source}
+
+	moduleAddr, _ := getter.SourceDirSubdir(source)
+
+	if registrySource, err := normalizeRegistrySource(moduleAddr, registryHost); err == nil {
+		return append(addrs, registrySource)
+	}
+
+	detected, err := getter.Detect(moduleAddr, "", getter.Detectors)
+	if err != nil {
+		return addrs
+	}
+
+	if i := strings.Index(detected, "::"); i != -1 {
+		detected = detected[i+2:]
+	}
+
+	u, err := url.Parse(detected)
+	if err != nil || u.Host == "" {
+		return addrs
+	}
+
+	return append(addrs, u.Host+u.Path)
+}
+
+func matchSourcePattern(pattern string, addrs []string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	re, err := regexp.Compile("^" + strings.Join(parts, ".*") + "$")
+	if err != nil {
+		return false
+	}
+
+	for _, addr := range addrs {
+		if re.MatchString(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package modules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourcePolicyCheck(t *testing.T) {
+	policy := SourcePolicy{
+		Allow: []string{
+			"registry.terraform.io/my-org/*",
+			"github.com/my-org/*",
+		},
+		Deny: []string{
+			"github.com/my-org/deprecated*",
+		},
+	}
+
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{source: "my-org/vpc/aws"},
+		{source: "registry.terraform.io/my-org/vpc/aws"},
+		{source: "github.com/my-org/modules//vpc?ref=v1.0.0"},
+		{source: "git::https://github.com/my-org/modules.git?ref=v1.0.0"},
+		{source: "git@github.com:my-org/modules.git"},
+		{
+			source:   "terraform-aws-modules/vpc/aws",
+			expected: "Module vpc source terraform-aws-modules/vpc/aws doesn't match any module_source_allow pattern",
+		},
+		{
+			source:   "app.terraform.io/my-org/vpc/aws",
+			expected: "Module vpc source app.terraform.io/my-org/vpc/aws doesn't match any module_source_allow pattern",
+		},
+		{
+			source:   "git::https://example.com/my-org/modules.git",
+			expected: "Module vpc source git::https://example.com/my-org/modules.git doesn't match any module_source_allow pattern",
+		},
+		{
+			source:   "github.com/my-org/deprecated-modules",
+			expected: "Module vpc source github.com/my-org/deprecated-modules is blocked by module_source_deny pattern github.com/my-org/deprecated*",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			err := policy.check("vpc", test.source, defaultRegistryHost)
+			if test.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+
+	assert.NoError(t, SourcePolicy{}.check("vpc", "git::https://example.com/modules.git", defaultRegistryHost))
+	assert.NoError(t, SourcePolicy{Allow: []string{"registry.opentofu.org/my-org/*"}}.check("vpc", "my-org/vpc/aws", OpenTofuRegistryHost))
+}
+
+func TestLoaderSourcePolicy(t *testing.T) {
+	defer func() {
+		require.NoError(t, SetSourcePolicy(SourcePolicy{}))
+	}()
+
+	assert.EqualError(t, SetSourcePolicy(SourcePolicy{Mode: "ignore"}), "Invalid module_source_policy_mode 'ignore', must be one of: error, warn")
+
+	require.NoError(t, SetSourcePolicy(SourcePolicy{Deny: []string{"github.com/*"}}))
+
+	var warnings []string
+	loader := NewModuleLoader(t.TempDir(), LoaderWithWarningFunc(func(msg string) {
+		warnings = append(warnings, msg)
+	}))
+
+	_, err := loader.loadModule(context.Background(), &tfconfig.ModuleCall{Name: "vpc", Source: "github.com/org/vpc"}, loader.Path, "")
+	assert.EqualError(t, err, "Module vpc source github.com/org/vpc is blocked by module_source_deny pattern github.com/*")
+	assert.Empty(t, warnings)
+
+	require.NoError(t, SetSourcePolicy(SourcePolicy{Deny: []string{"github.com/*"}, Mode: SourcePolicyModeWarn}))
+
+	assert.NoError(t, loader.checkSourcePolicy("vpc", "github.com/org/vpc"))
+	assert.Equal(t, []string{"Module vpc source github.com/org/vpc is blocked by module_source_deny pattern github.com/*"}, warnings)
+}
@@ -1,6 +1,7 @@
 package modules
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,9 +12,16 @@ import (
 	"strings"
 
 	goversion "github.com/hashicorp/go-version"
+
+	"github.com/infracost/infracost/internal/httpclient"
 )
 
-var defaultRegistryHost = "registry.terraform.io"
+var (
+	defaultRegistryHost = "registry.terraform.io"
+	// OpenTofuRegistryHost is the public OpenTofu module registry. It implements the same
+	// module registry protocol as the Terraform registry.
+	OpenTofuRegistryHost = "registry.opentofu.org"
+)
 
 // validRegistryName is a regexp that matches valid registry identifier for namespaces, module names and targets
 var validRegistryName = regexp.MustCompile("^[0-9A-Za-z-_]+$")
@@ -29,19 +37,27 @@ type RegistryLookupResult struct {
 // RegistryLoader is a loader that can lookup modules from a Terraform Registry and download them to the given destination
 type RegistryLoader struct {
 	packageFetcher *PackageFetcher
+	defaultHost    string
 }
 
-// NewRegistryLoader constructs a registry loader
-func NewRegistryLoader(packageFetcher *PackageFetcher) *RegistryLoader {
+// NewRegistryLoader constructs a registry loader. Module sources that don't specify
+// a registry hostname are resolved against the defaultHost, if defaultHost is blank
+// the public Terraform registry is used.
+func NewRegistryLoader(packageFetcher *PackageFetcher, defaultHost string) *RegistryLoader {
+	if defaultHost == "" {
+		defaultHost = defaultRegistryHost
+	}
+
 	return &RegistryLoader{
 		packageFetcher: packageFetcher,
+		defaultHost:    defaultHost,
 	}
 }
 
 // lookupModule lookups the matching version and download URL for the module.
 // It calls the registry versions endpoint and tries to find a matching version.
-func (r *RegistryLoader) lookupModule(moduleAddr string, versionConstraints string) (*RegistryLookupResult, error) {
-	registrySource, err := normalizeRegistrySource(moduleAddr)
+func (r *RegistryLoader) lookupModule(ctx context.Context, moduleAddr string, versionConstraints string) (*RegistryLookupResult, error) {
+	registrySource, err := normalizeRegistrySource(moduleAddr, r.defaultHost)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +75,7 @@ func (r *RegistryLoader) lookupModule(moduleAddr string, versionConstraints stri
 	// We now need to check the registry to see if the module exists and if it has a version
 	moduleURL := fmt.Sprintf("https://%s/v1/modules/%s/%s/%s", host, namespace, moduleName, target)
 
-	versions, err := r.fetchModuleVersions(moduleURL)
+	versions, err := r.fetchModuleVersions(ctx, moduleURL)
 	if err != nil {
 		return nil, err
 	}
@@ -81,9 +97,14 @@ func (r *RegistryLoader) lookupModule(moduleAddr string, versionConstraints stri
 }
 
 // fetchModuleVersions fetches the list of versions from the registry endpoint for the given module URL
-func (r *RegistryLoader) fetchModuleVersions(moduleURL string) ([]string, error) {
-	httpClient := &http.Client{}
-	resp, err := httpClient.Get(moduleURL + "/versions")
+func (r *RegistryLoader) fetchModuleVersions(ctx context.Context, moduleURL string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, moduleURL+"/versions", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create registry module versions request: %w", err)
+	}
+
+	httpClient := httpclient.NewClient(httpclient.PurposeModuleRegistry)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to fetch registry module versions: %w", err)
 	}
@@ -126,9 +147,14 @@ func (r *RegistryLoader) fetchModuleVersions(moduleURL string) ([]string, error)
 
 // downloadModule downloads the module to the loader's destination
 // It first calls the download URL to get the X-Terraform-Get header which contains a source we can use with go-getter to download the module
-func (r *RegistryLoader) downloadModule(downloadURL string, dest string) error {
-	httpClient := &http.Client{}
-	resp, err := httpClient.Get(downloadURL)
+func (r *RegistryLoader) downloadModule(ctx context.Context, downloadURL string, dest string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to create registry module download request: %w", err)
+	}
+
+	httpClient := httpclient.NewClient(httpclient.PurposeModuleRegistry)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("Failed to download registry module: %w", err)
 	}
@@ -139,7 +165,7 @@ func (r *RegistryLoader) downloadModule(downloadURL string, dest string) error {
 		return errors.New("download URL has no X-Terraform-Get header")
 	}
 
-	return r.packageFetcher.fetch(source, dest)
+	return r.packageFetcher.fetch(ctx, source, "", dest)
 }
 
 // findLatestMatchingVersion returns the latest version from a list of versions that matches the given constraint.
@@ -188,8 +214,9 @@ func findLatestMatchingVersion(versions []string, constraints string) (string, e
 
 // normalizeRegistrySource validates a module source address and normalizes it into the host/namespace/module/target format
 // This does not mean that the module address is a registry module, it could still be a remote module.
-// To work that out we need to try looking up the module using the `lookupModule` function
-func normalizeRegistrySource(moduleAddr string) (string, error) {
+// To work that out we need to try looking up the module using the `lookupModule` function.
+// If the module address doesn't specify a registry then the defaultHost is used.
+func normalizeRegistrySource(moduleAddr string, defaultHost string) (string, error) {
 	// Modules are in the format (registry)/namspace/module/target
 	// So we expect them to only have 3 or 4 parts depending on if they explicitly specify the registry
 	parts := strings.Split(moduleAddr, "/")
@@ -209,7 +236,7 @@ func normalizeRegistrySource(moduleAddr string) (string, error) {
 
 		parts = parts[1:]
 	} else {
-		host = defaultRegistryHost
+		host = defaultHost
 	}
 
 	// GitHub and BitBucket hosts aren't supported as registries
@@ -70,3 +70,30 @@ func TestNormalizeHost(t *testing.T) {
 		assert.Equal(t, test.expected, actual)
 	}
 }
+
+func TestNormalizeRegistrySource(t *testing.T) {
+	tests := []struct {
+		moduleAddr   string
+		defaultHost  string
+		expected     string
+		returnsError bool
+	}{
+		{"terraform-aws-modules/vpc/aws", defaultRegistryHost, "registry.terraform.io/terraform-aws-modules/vpc/aws", false},
+		{"terraform-aws-modules/vpc/aws", OpenTofuRegistryHost, "registry.opentofu.org/terraform-aws-modules/vpc/aws", false},
+		{"registry.terraform.io/terraform-aws-modules/vpc/aws", OpenTofuRegistryHost, "registry.terraform.io/terraform-aws-modules/vpc/aws", false},
+		{"app.terraform.io/my-org/vpc/aws", defaultRegistryHost, "app.terraform.io/my-org/vpc/aws", false},
+		{"github.com/my-org/vpc/aws", defaultRegistryHost, "", true},
+		{"my-org/vpc", defaultRegistryHost, "", true},
+	}
+
+	for _, test := range tests {
+		actual, err := normalizeRegistrySource(test.moduleAddr, test.defaultHost)
+		if test.returnsError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, test.expected, actual)
+	}
+}
@@ -0,0 +1,166 @@
+package modules
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// sharedDownloads is used by the PackageFetchers of all the ModuleLoaders, so that a remote module source
+// that's used by many projects is only downloaded once per run.
+var sharedDownloads = NewSharedDownloads()
+
+// CloseSharedDownloads removes the modules that have been downloaded to the shared location in this run.
+// It should be called once all the projects in the run have been loaded.
+func CloseSharedDownloads() {
+	sharedDownloads.Close()
+}
+
+// SharedDownloads downloads remote module sources to a shared temporary directory. Each source is
+// downloaded once, and the projects that use it copy it from the shared directory. The downloads are
+// reference counted so that a download that's still being copied isn't removed when the SharedDownloads
+// is closed.
+type SharedDownloads struct {
+	mu        sync.Mutex
+	dir       string
+	downloads map[string]*sharedDownload
+}
+
+type sharedDownload struct {
+	source string
+	dir    string
+	refs   int
+	closed bool
+	done   chan struct{}
+	err    error
+}
+
+// NewSharedDownloads returns an empty SharedDownloads. The shared directory isn't created until the
+// first source is downloaded.
+func NewSharedDownloads() *SharedDownloads {
+	return &SharedDownloads{
+		downloads: make(map[string]*sharedDownload),
+	}
+}
+
+// acquire returns the shared download of the source, calling download to download it if this is the
+// first reference to it. If the source is already being downloaded then acquire waits for that download
+// to finish. Each call to acquire that doesn't return an error must be paired with a call to release.
+func (s *SharedDownloads) acquire(ctx context.Context, source string, download func(dest string) error) (*sharedDownload, error) {
+	s.mu.Lock()
+
+	if s.dir == "" {
+		dir, err := os.MkdirTemp("", "infracost-modules-")
+		if err != nil {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("Failed to create shared module directory: %w", err)
+		}
+		s.dir = dir
+	}
+
+	d, ok := s.downloads[source]
+	if ok {
+		d.refs++
+		s.mu.Unlock()
+
+		select {
+		case <-d.done:
+		case <-ctx.Done():
+			s.release(d)
+			return nil, ctx.Err()
+		}
+
+		if d.err != nil {
+			s.release(d)
+			return nil, d.err
+		}
+
+		log.Debugf("Module %s already downloaded to '%s'", source, d.dir)
+		return d, nil
+	}
+
+	d = &sharedDownload{
+		source: source,
+		dir:    filepath.Join(s.dir, fmt.Sprintf("%x", sha256.Sum256([]byte(source)))[:16]),
+		refs:   1,
+		done:   make(chan struct{}),
+	}
+	s.downloads[source] = d
+	s.mu.Unlock()
+
+	d.err = download(d.dir)
+	if d.err != nil {
+		// remove the failed download so the next project that uses the source tries again.
+		s.mu.Lock()
+		delete(s.downloads, source)
+		s.mu.Unlock()
+	}
+	close(d.done)
+
+	if d.err != nil {
+		s.release(d)
+		return nil, d.err
+	}
+
+	return d, nil
+}
+
+// release removes a reference to the download. The download is removed if it has no references left and
+// the SharedDownloads has been closed, or if it failed.
+func (s *SharedDownloads) release(d *sharedDownload) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d.refs--
+	if d.refs > 0 || (!d.closed && d.err == nil) {
+		return
+	}
+
+	removeDownload(d)
+
+	// the shared directory is left behind if there were downloads in use when it was closed, so try
+	// removing it again now that it might be empty.
+	if d.closed {
+		_ = os.Remove(filepath.Dir(d.dir))
+	}
+}
+
+// Close removes all the downloads that have no references. Any downloads that are still referenced are
+// removed once they're released. Sources that are used after Close are downloaded again.
+func (s *SharedDownloads) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inUse := false
+	for _, d := range s.downloads {
+		d.closed = true
+		if d.refs > 0 {
+			inUse = true
+			continue
+		}
+
+		removeDownload(d)
+	}
+
+	if s.dir != "" && !inUse {
+		err := os.RemoveAll(s.dir)
+		if err != nil {
+			log.Debugf("Error removing shared module directory '%s': %s", s.dir, err)
+		}
+	}
+
+	s.dir = ""
+	s.downloads = make(map[string]*sharedDownload)
+}
+
+func removeDownload(d *sharedDownload) {
+	err := os.RemoveAll(d.dir)
+	if err != nil {
+		log.Debugf("Error removing shared download of module %s from '%s': %s", d.source, d.dir, err)
+	}
+}
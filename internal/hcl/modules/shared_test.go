@@ -0,0 +1,88 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedDownloads(t *testing.T) {
+	s := NewSharedDownloads()
+
+	var calls int32
+	download := func(dest string) error {
+		atomic.AddInt32(&calls, 1)
+
+		err := os.MkdirAll(dest, os.ModePerm)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(filepath.Join(dest, "main.tf"), []byte(`resource "aws_instance" "web" {}`), 0600)
+	}
+
+	var wg sync.WaitGroup
+	downloads := make([]*sharedDownload, 10)
+	for i := range downloads {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			d, err := s.acquire(context.Background(), "git::https://example.com/module.git?ref=v1.0.0", download)
+			assert.NoError(t, err)
+			downloads[i] = d
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls)
+	for _, d := range downloads {
+		assert.Same(t, downloads[0], d)
+	}
+	assert.FileExists(t, filepath.Join(downloads[0].dir, "main.tf"))
+
+	other, err := s.acquire(context.Background(), "git::https://example.com/module.git?ref=v2.0.0", download)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls)
+	assert.NotEqual(t, downloads[0].dir, other.dir)
+
+	// releasing the downloads before the run is closed keeps them for other projects.
+	for _, d := range downloads {
+		s.release(d)
+	}
+	assert.DirExists(t, downloads[0].dir)
+
+	// downloads that are still in use are removed when they're released.
+	s.Close()
+	assert.NoDirExists(t, downloads[0].dir)
+	assert.DirExists(t, other.dir)
+
+	s.release(other)
+	assert.NoDirExists(t, other.dir)
+	assert.NoDirExists(t, filepath.Dir(other.dir))
+}
+
+func TestSharedDownloadsError(t *testing.T) {
+	s := NewSharedDownloads()
+	defer s.Close()
+
+	_, err := s.acquire(context.Background(), "https://example.com/module.zip", func(dest string) error {
+		return errors.New("download failed")
+	})
+	assert.EqualError(t, err, "download failed")
+
+	// failed downloads are tried again.
+	d, err := s.acquire(context.Background(), "https://example.com/module.zip", func(dest string) error {
+		return os.MkdirAll(dest, os.ModePerm)
+	})
+	require.NoError(t, err)
+	assert.DirExists(t, d.dir)
+	s.release(d)
+}
@@ -1,11 +1,10 @@
 package hcl
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 
@@ -15,7 +14,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 
+	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/extclient"
+	"github.com/infracost/infracost/internal/hcl/funcs"
 	"github.com/infracost/infracost/internal/hcl/modules"
 	"github.com/infracost/infracost/internal/ui"
 )
@@ -26,13 +27,18 @@ var log = logrus.StandardLogger().WithField("parser", "terraform_hcl")
 type Option func(p *Parser)
 
 // OptionWithTFVarsPaths takes a slice of paths and sets them on the parser relative
-// to the Parser initialPath. Paths that don't exist will be ignored.
+// to the Parser initialPath. Absolute paths, including Windows drive letter and UNC
+// paths, are used as is. Paths that don't exist will be ignored.
 func OptionWithTFVarsPaths(paths []string) Option {
 	return func(p *Parser) {
 		var relative []string
 
 		for _, name := range paths {
-			tfvp := path.Join(p.initialPath, name)
+			tfvp := filepath.FromSlash(name)
+			if !filepath.IsAbs(tfvp) {
+				tfvp = filepath.Join(p.initialPath, tfvp)
+			}
+
 			_, err := os.Stat(tfvp)
 			if err != nil {
 				log.Warnf("passed tfvar file does not exist at %s", tfvp)
@@ -131,18 +137,137 @@ func OptionWithRemoteVarLoader(host, token, localWorkspace string) Option {
 	}
 }
 
+// OptionWithVariableSources sets the sources that input variables are loaded from other than tfvars
+// files, e.g. Azure DevOps variable groups. They're loaded after Terraform Cloud variables, with
+// later sources overriding earlier ones, and before var files.
+func OptionWithVariableSources(sources ...VariableSource) Option {
+	return func(p *Parser) {
+		p.variableSources = append(p.variableSources, sources...)
+	}
+}
+
 func OptionWithWorkspaceName(workspaceName string) Option {
 	return func(p *Parser) {
 		p.workspaceName = workspaceName
 	}
 }
 
+// OptionWithModuleRegistryHost sets the default registry host used by the ModuleLoader to resolve
+// registry module sources that don't include a hostname, e.g. registry.opentofu.org for OpenTofu projects.
+func OptionWithModuleRegistryHost(host string) Option {
+	return func(p *Parser) {
+		p.moduleRegistryHost = host
+	}
+}
+
+// OptionWithModuleGitSSH sets the SSH private key and known_hosts files used by the ModuleLoader to
+// download git::ssh:// module sources. Either can be empty to use the default SSH configuration.
+func OptionWithModuleGitSSH(keyFile string, knownHostsFile string) Option {
+	return func(p *Parser) {
+		p.moduleGitSSH = modules.GitSSHConfig{KeyFile: keyFile, KnownHostsFile: knownHostsFile}
+	}
+}
+
+// OptionWithModuleEnv sets the environment variables with the cloud credentials used by the ModuleLoader
+// to download s3:: and gcs:: module sources.
+func OptionWithModuleEnv(env map[string]string) Option {
+	return func(p *Parser) {
+		p.moduleEnv = env
+	}
+}
+
+// OptionWithModuleCacheDir sets the directory that the ModuleLoader downloads modules to instead of the
+// .infracost directory of the project, so the project directory isn't written to.
+func OptionWithModuleCacheDir(dir string) Option {
+	return func(p *Parser) {
+		p.moduleCacheDir = dir
+	}
+}
+
+// OptionStrictVariableValidation makes the Parser return an error when a variable value doesn't meet the
+// condition of one of its validation blocks. By default these are shown as warnings.
+func OptionStrictVariableValidation() Option {
+	return func(p *Parser) {
+		p.strictVarValidation = true
+	}
+}
+
 func OptionWithBlockBuilder(blockBuilder BlockBuilder) Option {
 	return func(p *Parser) {
 		p.blockBuilder = blockBuilder
 	}
 }
 
+// OptionWithFileOverrides sets the contents to parse for some of the Terraform files in the root
+// directory instead of reading them from disk, keyed by their path. This is used to estimate
+// files that are being edited but haven't been saved yet.
+func OptionWithFileOverrides(files map[string][]byte) Option {
+	return func(p *Parser) {
+		p.fileOverrides = make(map[string][]byte, len(files))
+		for path, src := range files {
+			p.fileOverrides[filepath.Clean(path)] = src
+		}
+	}
+}
+
+// OptionWithProviderSchemas sets the provider schemas that resources are checked against after they're
+// evaluated. Attributes and blocks that aren't in the schema of their resource type, or have a value of the
+// wrong type, are shown as warnings since they're usually typos that stop the resource being priced correctly.
+func OptionWithProviderSchemas(schemas *ProviderSchemas) Option {
+	return func(p *Parser) {
+		p.providerSchemas = schemas
+	}
+}
+
+// OptionHardened parses the Terraform files in hardened mode with the given limits. Files that are
+// over the limits, take too long to parse or make the parser panic are skipped, and can be found with
+// Parser.FileDiagnostics. A panic or timeout while evaluating the files is returned as an error. This
+// is used by long-running processes so that a single pathological file can't take them down.
+func OptionHardened(limits HardenedLimits) Option {
+	return func(p *Parser) {
+		p.hardenedLimits = &limits
+	}
+}
+
+// OptionWithDirectoryLimits sets the limits on the number and size of the Terraform files in each
+// directory and how deep modules can be nested, replacing DefaultDirectoryLimits.
+func OptionWithDirectoryLimits(limits DirectoryLimits) Option {
+	return func(p *Parser) {
+		p.directoryLimits = limits
+	}
+}
+
+// The phases of parsing a directory that are passed to a PhaseFunc.
+const (
+	// PhaseParse is when the files of the directory are read and parsed into blocks.
+	PhaseParse = "parse"
+	// PhaseModules is when the modules are loaded, downloading the remote modules.
+	PhaseModules = "modules"
+	// PhaseEvaluate is when the blocks of the directory and its modules are evaluated.
+	PhaseEvaluate = "evaluate"
+)
+
+// PhaseFunc is called with the name of each phase of parsing a directory when it starts, which is
+// also when the previous phase ends.
+type PhaseFunc func(phase string)
+
+// OptionWithPhaseFunc sets a PhaseFunc onto the Parser, so the time and memory used by each phase can
+// be measured, e.g. by the benchmark command.
+func OptionWithPhaseFunc(f PhaseFunc) Option {
+	return func(p *Parser) {
+		p.phaseFunc = f
+	}
+}
+
+// OptionWithModuleEvaluatedFunc sets a ModuleEvaluatedFunc onto the Parser, which is called with each
+// child module as soon as it has been evaluated, before the rest of the directory is evaluated.
+// The func can be called from a different goroutine than ParseDirectory.
+func OptionWithModuleEvaluatedFunc(f ModuleEvaluatedFunc) Option {
+	return func(p *Parser) {
+		p.onModuleEvaluated = f
+	}
+}
+
 // OptionWithSpinner sets a SpinnerFunc onto the Parser. With this option enabled
 // the Parser will send progress to the Spinner. This is disabled by default as
 // we run the Parser concurrently underneath DirProvider and don't want to mess with its output.
@@ -169,26 +294,47 @@ type Parser struct {
 	tfvarsPaths           []string
 	inputVars             map[string]cty.Value
 	stopOnHCLError        bool
+	strictVarValidation   bool
 	workspaceName         string
+	moduleRegistryHost    string
+	moduleGitSSH          modules.GitSSHConfig
+	moduleEnv             map[string]string
+	moduleCacheDir        string
 	moduleLoader          *modules.ModuleLoader
 	blockBuilder          BlockBuilder
 	newSpinner            ui.SpinnerFunc
 	writeWarning          ui.WriteWarningFunc
 	remoteVariablesLoader *RemoteVariablesLoader
+	variableSources       []VariableSource
+	fileOverrides         map[string][]byte
+	providerSchemas       *ProviderSchemas
+	hardenedLimits        *HardenedLimits
+	directoryLimits       DirectoryLimits
+	ignoreRules           *IgnoreRules
+	fileDiagnostics       []FileDiagnostic
+	phaseFunc             PhaseFunc
+	onModuleEvaluated     ModuleEvaluatedFunc
 }
 
 // New creates a new Parser with the provided options, it inits the workspace as under the default name
 // this can be changed using Option.
 func New(initialPath string, options ...Option) *Parser {
 	p := &Parser{
-		initialPath:   initialPath,
-		workspaceName: "default",
-		blockBuilder:  BlockBuilder{SetAttributes: []SetAttributesFunc{SetUUIDAttributes}},
+		initialPath:     initialPath,
+		workspaceName:   "default",
+		blockBuilder:    BlockBuilder{SetAttributes: []SetAttributesFunc{SetUUIDAttributes}},
+		directoryLimits: DefaultDirectoryLimits,
 	}
 
+	ignoreRules, err := LoadIgnoreRules(initialPath)
+	if err != nil {
+		log.Warnf("Error reading ignore files in %s: %s", initialPath, err)
+	}
+	p.ignoreRules = ignoreRules
+
 	var defaultVarFiles []string
 
-	defaultTfFile := path.Join(initialPath, "terraform.tfvars")
+	defaultTfFile := filepath.Join(initialPath, "terraform.tfvars")
 	if _, err := os.Stat(defaultTfFile); err == nil {
 		defaultVarFiles = append(defaultVarFiles, defaultTfFile)
 	}
@@ -201,8 +347,12 @@ func New(initialPath string, options ...Option) *Parser {
 	infos, _ := os.ReadDir(initialPath)
 	for _, info := range infos {
 		name := info.Name()
+		if ignoreRules.Ignored(filepath.Join(initialPath, name), false) {
+			continue
+		}
+
 		if strings.HasSuffix(name, autoVarsSuffix) || strings.HasSuffix(name, autoVarsSuffix+".json") {
-			defaultVarFiles = append(defaultVarFiles, path.Join(initialPath, name))
+			defaultVarFiles = append(defaultVarFiles, filepath.Join(initialPath, name))
 		}
 	}
 
@@ -212,11 +362,41 @@ func New(initialPath string, options ...Option) *Parser {
 		option(p)
 	}
 
+	// the files of the modules are also loaded with the hardened limits
+	if p.hardenedLimits != nil {
+		p.blockBuilder.HardenedLimits = p.hardenedLimits
+	}
+	p.blockBuilder.DirectoryLimits = p.directoryLimits
+
 	var loaderOpts []modules.LoaderOption
 	if p.newSpinner != nil {
 		loaderOpts = append(loaderOpts, modules.LoaderWithSpinner(p.newSpinner))
 	}
 
+	if p.moduleRegistryHost != "" {
+		loaderOpts = append(loaderOpts, modules.LoaderWithRegistryHost(p.moduleRegistryHost))
+	}
+
+	if p.writeWarning != nil {
+		loaderOpts = append(loaderOpts, modules.LoaderWithWarningFunc(p.writeWarning))
+	}
+
+	if p.directoryLimits.MaxModuleDepth > 0 {
+		loaderOpts = append(loaderOpts, modules.LoaderWithMaxDepth(p.directoryLimits.MaxModuleDepth))
+	}
+
+	if len(p.moduleEnv) > 0 {
+		loaderOpts = append(loaderOpts, modules.LoaderWithEnv(p.moduleEnv))
+	}
+
+	if p.moduleGitSSH != (modules.GitSSHConfig{}) {
+		loaderOpts = append(loaderOpts, modules.LoaderWithGitSSH(p.moduleGitSSH))
+	}
+
+	if p.moduleCacheDir != "" {
+		loaderOpts = append(loaderOpts, modules.LoaderWithCacheDir(p.moduleCacheDir))
+	}
+
 	p.moduleLoader = modules.NewModuleLoader(initialPath, loaderOpts...)
 	return p
 }
@@ -226,15 +406,23 @@ func New(initialPath string, options ...Option) *Parser {
 // It instead leaves ModuleLoader to fetch these Modules on demand. See ModuleLoader.Load for more information.
 //
 // ParseDirectory returns the root Module that represents the top of the Terraform Config tree.
-func (p *Parser) ParseDirectory() (*Module, error) {
+func (p *Parser) ParseDirectory(ctx context.Context) (*Module, error) {
 	log.Debugf("Beginning parse for directory '%s'...", p.initialPath)
+	p.startPhase(PhaseParse)
 
 	// load the initial root directory into a list of hcl files
 	// at this point these files have no schema associated with them.
-	files, err := loadDirectory(p.initialPath, p.stopOnHCLError)
+	files, diagnostics, err := loadDirectory(p.initialPath, p.stopOnHCLError, p.fileOverrides, p.hardenedLimits, p.directoryLimits, p.ignoreRules)
 	if err != nil {
 		return nil, err
 	}
+	p.fileDiagnostics = diagnostics
+
+	if p.writeWarning != nil {
+		for _, d := range diagnostics {
+			p.writeWarning(fmt.Sprintf("Skipping %s", d))
+		}
+	}
 
 	// load the files into given hcl block types. These are then wrapped with *Block structs.
 	blocks, err := p.parseDirectoryFiles(files)
@@ -243,7 +431,12 @@ func (p *Parser) ParseDirectory() (*Module, error) {
 	}
 
 	if len(blocks) == 0 {
-		return nil, errors.New("No valid terraform files found given path, try a different directory")
+		return nil, clierror.New(
+			clierror.CodeNoTerraformFiles,
+			clierror.CategoryUser,
+			"No valid terraform files found given path",
+			"Try a different directory, or set --path to a Terraform plan JSON file.",
+		)
 	}
 
 	log.Debug("Loading TFVars...")
@@ -253,12 +446,18 @@ func (p *Parser) ParseDirectory() (*Module, error) {
 	}
 
 	// load the modules. This downloads any remote modules to the local file system
-	modulesManifest, err := p.moduleLoader.Load()
+	p.startPhase(PhaseModules)
+	modulesManifest, err := p.moduleLoader.Load(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Error loading Terraform modules: %s", err)
+		return nil, fmt.Errorf("Error loading Terraform modules: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
 	log.Debug("Evaluating expressions...")
+	p.startPhase(PhaseEvaluate)
 	workingDir, err := os.Getwd()
 	if err != nil {
 		return nil, fmt.Errorf("Error could not evaluate current working directory %w", err)
@@ -277,10 +476,12 @@ func (p *Parser) ParseDirectory() (*Module, error) {
 		inputVars,
 		modulesManifest,
 		nil,
+		nil,
 		p.workspaceName,
 		p.blockBuilder,
 		p.newSpinner,
 	)
+	evaluator.onModuleEvaluated = p.onModuleEvaluated
 
 	if v := evaluator.MissingVars(); len(v) > 0 {
 		if p.writeWarning != nil {
@@ -294,14 +495,84 @@ func (p *Parser) ParseDirectory() (*Module, error) {
 		}
 	}
 
-	root, err := evaluator.Run()
+	if err := p.validateVars(evaluator); err != nil {
+		return nil, err
+	}
+
+	var root *Module
+	if p.hardenedLimits != nil {
+		root, err = runHardened("Evaluating Terraform directory", p.hardenedLimits.EvaluationTimeout, evaluator.Run)
+	} else {
+		root, err = evaluator.Run()
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	p.validateProviderSchemas(root)
+
+	for _, b := range root.SkippedBlocks() {
+		log.Debugf("Skipping %s at %s:%d: %s", b.Address, b.Filename, b.Line, b.Reason)
+	}
+
 	return root, nil
 }
 
+func (p *Parser) startPhase(phase string) {
+	if p.phaseFunc != nil {
+		p.phaseFunc(phase)
+	}
+}
+
+// FileDiagnostics returns the problems with the files that were skipped when the directory was parsed
+// in hardened mode.
+func (p *Parser) FileDiagnostics() []FileDiagnostic {
+	return p.fileDiagnostics
+}
+
+// validateVars checks the root module variable values against their validation blocks. Invalid values
+// are shown as warnings, or returned as an error if the Parser uses strict variable validation.
+func (p *Parser) validateVars(evaluator *Evaluator) error {
+	errs := evaluator.ValidateVars()
+	if len(errs) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+
+	if p.strictVarValidation {
+		return clierror.New(
+			clierror.CodeInvalidVariable,
+			clierror.CategoryUser,
+			strings.Join(msgs, "\n"),
+			"Fix the variable values in your tfvars files or --terraform-var flags.",
+		)
+	}
+
+	if p.writeWarning != nil {
+		for _, msg := range msgs {
+			p.writeWarning(msg)
+		}
+	}
+
+	return nil
+}
+
+// validateProviderSchemas shows a warning for each resource attribute or block in the root Module and its
+// local child Modules that doesn't match the provider schemas.
+func (p *Parser) validateProviderSchemas(root *Module) {
+	if p.providerSchemas == nil || p.writeWarning == nil {
+		return
+	}
+
+	for _, err := range root.SchemaErrors(p.providerSchemas) {
+		p.writeWarning(err.Error())
+	}
+}
+
 func (p *Parser) parseDirectoryFiles(files []*hcl.File) (Blocks, error) {
 	var blocks Blocks
 
@@ -350,6 +621,18 @@ func (p *Parser) loadVars(blocks Blocks, filenames []string) (map[string]cty.Val
 		}
 	}
 
+	for _, source := range p.variableSources {
+		sourceVars, err := source.Load()
+		if err != nil {
+			log.Warnf("could not load vars from %s: %s", source.Name(), err)
+			return combinedVars, fmt.Errorf("could not load vars from %s: %w", source.Name(), err)
+		}
+
+		for k, v := range sourceVars {
+			combinedVars[k] = v
+		}
+	}
+
 	for _, name := range p.defaultVarFiles {
 		err := loadAndCombineVars(name, combinedVars)
 		if err != nil {
@@ -409,38 +692,98 @@ func loadVarFile(filename string) (map[string]cty.Value, error) {
 	return inputVars, nil
 }
 
-func loadDirectory(fullPath string, stopOnHCLError bool) ([]*hcl.File, error) {
+// loadDirectory parses the Terraform files in fullPath. Files in overrides are parsed from the
+// given source instead of being read from disk. Calls to provider-defined functions are rewritten
+// before the files are parsed, see funcs.RewriteProviderFunctionCalls.
+//
+// If limits is set the files are parsed in hardened mode, see HardenedLimits. Files that can't be
+// parsed are skipped and returned as FileDiagnostics, unless stopOnHCLError is set.
+//
+// A directory with more files than dirLimits allows returns an error, and files that are larger than
+// it allows are always skipped and returned as FileDiagnostics, since they're never expected. Files
+// that are ignored by the ignore rules aren't parsed or counted.
+func loadDirectory(fullPath string, stopOnHCLError bool, overrides map[string][]byte, limits *HardenedLimits, dirLimits DirectoryLimits, ignoreRules *IgnoreRules) ([]*hcl.File, []FileDiagnostic, error) {
 	hclParser := hclparse.NewParser()
 
 	fileInfos, err := ioutil.ReadDir(fullPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	var tfFileInfos []os.FileInfo
 	for _, info := range fileInfos {
-		if info.IsDir() {
+		// this is not a file we can parse:
+		if info.IsDir() || (!strings.HasSuffix(info.Name(), ".tf") && !strings.HasSuffix(info.Name(), ".tf.json")) {
 			continue
 		}
 
-		var parseFunc func(filename string) (*hcl.File, hcl.Diagnostics)
-		if strings.HasSuffix(info.Name(), ".tf") {
-			parseFunc = hclParser.ParseHCLFile
+		if ignoreRules.Ignored(filepath.Join(fullPath, info.Name()), false) {
+			log.Debugf("skipping file: %s is ignored", filepath.Join(fullPath, info.Name()))
+			continue
 		}
 
-		if strings.HasSuffix(info.Name(), ".tf.json") {
-			parseFunc = hclParser.ParseJSONFile
+		tfFileInfos = append(tfFileInfos, info)
+	}
+
+	if err := dirLimits.checkFileCount(fullPath, len(tfFileInfos)); err != nil {
+		return nil, nil, err
+	}
+
+	var hardenedFiles []*hcl.File
+	var diagnostics []FileDiagnostic
+
+	for _, info := range tfFileInfos {
+		path := filepath.Join(fullPath, info.Name())
+		isJSON := strings.HasSuffix(info.Name(), ".tf.json")
+
+		src, ok := overrides[filepath.Clean(path)]
+		if !ok {
+			// the size is checked before the file is read so that a huge file isn't read into memory.
+			if err := dirLimits.checkFileSize(path, info.Size()); err != nil {
+				log.Warnf("skipping file: %s", err)
+				diagnostics = append(diagnostics, err.(FileDiagnostic))
+				continue
+			}
+
+			src, err = os.ReadFile(path)
+			if err != nil {
+				if stopOnHCLError {
+					return nil, nil, err
+				}
+
+				log.Warnf("skipping file: %s could not be read: %s", path, err)
+				continue
+			}
 		}
 
-		// this is not a file we can parse:
-		if parseFunc == nil {
+		src = funcs.RewriteProviderFunctionCalls(src)
+
+		if limits != nil {
+			file, err := parseFileHardened(path, src, isJSON, *limits)
+			if err != nil {
+				if stopOnHCLError {
+					return nil, nil, err
+				}
+
+				log.Warnf("skipping file: %s", err)
+				diagnostics = append(diagnostics, err.(FileDiagnostic))
+				continue
+			}
+
+			hardenedFiles = append(hardenedFiles, file)
 			continue
 		}
 
-		path := filepath.Join(fullPath, info.Name())
-		_, diag := parseFunc(path)
+		var diag hcl.Diagnostics
+		if isJSON {
+			_, diag = hclParser.ParseJSON(src, path)
+		} else {
+			_, diag = hclParser.ParseHCL(src, path)
+		}
+
 		if diag != nil && diag.HasErrors() {
 			if stopOnHCLError {
-				return nil, diag
+				return nil, nil, diag
 			}
 
 			log.Warnf("skipping file: %s hcl parsing err: %s", path, diag.Error())
@@ -448,10 +791,11 @@ func loadDirectory(fullPath string, stopOnHCLError bool) ([]*hcl.File, error) {
 		}
 	}
 
-	files := make([]*hcl.File, 0, len(hclParser.Files()))
+	files := make([]*hcl.File, 0, len(hclParser.Files())+len(hardenedFiles))
 	for _, file := range hclParser.Files() {
 		files = append(files, file)
 	}
+	files = append(files, hardenedFiles...)
 
-	return files, nil
+	return files, diagnostics, nil
 }
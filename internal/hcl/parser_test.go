@@ -1,6 +1,7 @@
 package hcl
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -45,7 +46,7 @@ data "cats_cat" "the-cats-mother" {
 `)
 
 	parser := New(filepath.Dir(path), OptionStopOnHCLError())
-	module, err := parser.ParseDirectory()
+	module, err := parser.ParseDirectory(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -128,7 +129,7 @@ output "mod_result" {
 	)
 
 	parser := New(path, OptionStopOnHCLError())
-	rootModule, err := parser.ParseDirectory()
+	rootModule, err := parser.ParseDirectory(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -187,7 +188,7 @@ output "mod_result" {
 	)
 
 	parser := New(path, OptionStopOnHCLError())
-	rootModule, err := parser.ParseDirectory()
+	rootModule, err := parser.ParseDirectory(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -220,6 +221,401 @@ output "mod_result" {
 	assert.Equal(t, "ok", childValAttr.Value().AsString())
 }
 
+func Test_ModulesWithSameInputsAreCached(t *testing.T) {
+	path := createTestFileWithModule(`
+module "same" {
+	for_each = toset(["dev", "prod"])
+	source = "../module"
+	size = "small"
+}
+
+module "different" {
+	source = "../module"
+	size = "large"
+}
+
+output "result" {
+	value = module.different.mod_result
+}
+`,
+		`
+variable "size" {
+	default = "?"
+}
+
+resource "cats_cat" "mittens" {
+	size = var.size
+}
+
+output "mod_result" {
+	value = var.size
+}
+`,
+		"module",
+	)
+
+	parser := New(path, OptionStopOnHCLError())
+	rootModule, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rootModule.Modules, 3)
+
+	resources := map[string]string{}
+	blocks := map[string]*Block{}
+	for _, m := range rootModule.Modules {
+		for _, b := range m.Blocks.OfType("resource") {
+			resources[b.FullName()] = b.GetAttribute("size").Value().AsString()
+			blocks[b.FullName()] = b
+		}
+	}
+
+	assert.Equal(t, map[string]string{
+		`module.same["dev"].cats_cat.mittens`:  "small",
+		`module.same["prod"].cats_cat.mittens`: "small",
+		"module.different.cats_cat.mittens":    "large",
+	}, resources)
+
+	// the second call to the module with the same inputs should reuse the evaluated blocks of the first.
+	assert.Same(t, blocks[`module.same["dev"].cats_cat.mittens`].hclBlock, blocks[`module.same["prod"].cats_cat.mittens`].hclBlock)
+	assert.NotSame(t, blocks[`module.same["dev"].cats_cat.mittens`].hclBlock, blocks["module.different.cats_cat.mittens"].hclBlock)
+
+	rootOutputs := rootModule.Blocks.OfType("output")
+	require.Len(t, rootOutputs, 1)
+	assert.Equal(t, "large", rootOutputs[0].GetAttribute("value").Value().AsString())
+}
+
+func Test_OptionWithModuleEvaluatedFunc(t *testing.T) {
+	path := createTestFileWithModule(`
+module "same" {
+	for_each = toset(["dev", "prod"])
+	source = "../module"
+	size = "small"
+}
+
+module "different" {
+	source = "../module"
+	size = "large"
+}
+`,
+		`
+variable "size" {
+	default = "?"
+}
+
+resource "cats_cat" "mittens" {
+	size = var.size
+}
+`,
+		"module",
+	)
+
+	// the modules are passed to the func before the root module has been evaluated, so the values
+	// of their resources are read when they're passed.
+	resources := map[string]string{}
+	var evaluated []string
+	parser := New(path, OptionStopOnHCLError(), OptionWithModuleEvaluatedFunc(func(module *Module) {
+		evaluated = append(evaluated, module.Name)
+		for _, b := range module.Blocks.OfType("resource") {
+			resources[b.FullName()] = b.GetAttribute("size").Value().AsString()
+		}
+	}))
+
+	rootModule, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rootModule.Modules, 3)
+
+	assert.ElementsMatch(t, []string{`module.same["dev"]`, `module.same["prod"]`, "module.different"}, evaluated)
+	assert.Equal(t, map[string]string{
+		`module.same["dev"].cats_cat.mittens`:  "small",
+		`module.same["prod"].cats_cat.mittens`: "small",
+		"module.different.cats_cat.mittens":    "large",
+	}, resources)
+}
+
+func Test_ValuesAreEvaluatedInDependencyOrder(t *testing.T) {
+	path := createTestFileWithModule(`
+resource "cats_cat" "mittens" {
+	name = local.full_name
+	size = module.sizer.size
+}
+
+module "sizer" {
+	source = "../module"
+	name = local.full_name
+}
+
+locals {
+	full_name = "${local.first_name}-${var.last_name}"
+	first_name = cats_kitten.boots.name
+	cycle_a = local.cycle_b
+	cycle_b = local.cycle_a
+}
+
+resource "cats_kitten" "boots" {
+	name = "boots"
+}
+
+variable "last_name" {
+	default = "smith"
+}
+`,
+		`
+variable "name" {}
+
+output "size" {
+	value = "${var.name}-large"
+}
+`,
+		"module",
+	)
+
+	parser := New(path, OptionStopOnHCLError())
+	module, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	resources := module.Blocks.OfType("resource")
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].TypeLabel() < resources[j].TypeLabel()
+	})
+	require.Len(t, resources, 2)
+
+	assert.Equal(t, "boots-smith", resources[0].GetAttribute("name").Value().AsString())
+	assert.Equal(t, "boots-smith-large", resources[0].GetAttribute("size").Value().AsString())
+}
+
+func Test_UnresolvedAttributes(t *testing.T) {
+	path := createTestFile("test.tf", `
+variable "size" {}
+
+data "cats_owner" "owner" {
+	name = "jane"
+}
+
+resource "cats_cat" "mittens" {
+	name = "mittens"
+	size = var.size
+	owner = data.cats_owner.owner.address
+	mood = purr("loud")
+
+	collar {
+		color = local.collar_color
+	}
+}
+`)
+
+	parser := New(filepath.Dir(path), OptionStopOnHCLError())
+	module, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	unresolved := module.UnresolvedAttributes()
+	for i := range unresolved {
+		unresolved[i].Filename = filepath.Base(unresolved[i].Filename)
+		unresolved[i].Detail = ""
+	}
+
+	assert.Equal(t, []UnresolvedAttribute{
+		{Address: "cats_cat.mittens", Attribute: "collar.color", Filename: "test.tf", Line: 15, Reason: ReasonUnresolvedReference, Reference: "local.collar_color"},
+		{Address: "cats_cat.mittens", Attribute: "mood", Filename: "test.tf", Line: 12, Reason: ReasonUnsupportedFunction},
+		{Address: "cats_cat.mittens", Attribute: "owner", Filename: "test.tf", Line: 11, Reason: ReasonUnresolvedDataSource, Reference: "data.cats_owner.owner"},
+		{Address: "cats_cat.mittens", Attribute: "size", Filename: "test.tf", Line: 10, Reason: ReasonMissingVariable, Reference: "var.size"},
+	}, unresolved)
+}
+
+func Test_ProviderFunctions(t *testing.T) {
+	path := createTestFile("test.tf", `
+terraform {
+	required_providers {
+		amazon = {
+			source = "hashicorp/aws"
+		}
+	}
+}
+
+locals {
+	role = provider::aws::arn_parse("arn:aws:iam::444455556666:role/example")
+}
+
+resource "cats_cat" "mittens" {
+	account = local.role.account_id
+	region  = "${provider::google::region_from_zone("europe-west1-b")}"
+	service = provider::amazon::arn_parse("arn:aws:ec2:us-east-1:444455556666:instance/i-123").service
+	mood    = provider::cats::purr("loud")
+}
+`)
+
+	parser := New(filepath.Dir(path), OptionStopOnHCLError())
+	module, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	resources := module.Blocks.OfType("resource")
+	require.Len(t, resources, 1)
+	assert.Equal(t, "444455556666", resources[0].GetAttribute("account").Value().AsString())
+	assert.Equal(t, "europe-west1", resources[0].GetAttribute("region").Value().AsString())
+	assert.Equal(t, "ec2", resources[0].GetAttribute("service").Value().AsString())
+	assert.Equal(t, 18, resources[0].GetAttribute("mood").HCLAttr.Range.Start.Line)
+
+	unresolved := module.UnresolvedAttributes()
+	require.Len(t, unresolved, 1)
+	assert.Equal(t, "mood", unresolved[0].Attribute)
+	assert.Equal(t, ReasonUnsupportedFunction, unresolved[0].Reason)
+	assert.Contains(t, unresolved[0].Detail, "provider::cats::purr")
+
+	val, err := module.EvaluateExpression(`provider::aws::arn_build("aws", "s3", "", "", "bucket")`)
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:s3:::bucket", val.AsString())
+}
+
+func Test_SkippedBlocks(t *testing.T) {
+	path := createTestFile("test.tf", `
+ephemeral "aws_secretsmanager_secret_version" "db" {
+	secret_id = "db-password"
+}
+
+resource "cats_cat" "mittens" {
+	name     = "mittens"
+	password = ephemeral.aws_secretsmanager_secret_version.db.secret_string
+}
+
+check "mittens_alive" {
+	data "http" "health" {
+		url = "https://example.com/${cats_cat.mittens.name}"
+	}
+
+	assert {
+		condition     = data.http.health.status_code == 200
+		error_message = "mittens is not alive"
+	}
+}
+`)
+
+	parser := New(filepath.Dir(path), OptionStopOnHCLError())
+	module, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	resources := module.Blocks.OfType("resource")
+	require.Len(t, resources, 1)
+	assert.Equal(t, "mittens", resources[0].GetAttribute("name").Value().AsString())
+
+	assert.Equal(t, []SkippedBlock{
+		{
+			Address:  "check.mittens_alive",
+			Filename: path,
+			Line:     11,
+			Reason:   "check blocks only run assertions against the infrastructure",
+		},
+		{
+			Address:  "ephemeral.aws_secretsmanager_secret_version.db",
+			Filename: path,
+			Line:     2,
+			Reason:   "ephemeral resources aren't persisted to the plan or state",
+		},
+	}, module.SkippedBlocks())
+
+	assert.Equal(t, []UnresolvedAttribute{
+		{
+			Address:   "cats_cat.mittens",
+			Attribute: "password",
+			Filename:  path,
+			Line:      8,
+			Reason:    ReasonEphemeralResource,
+			Reference: "ephemeral.aws_secretsmanager_secret_version.db",
+		},
+	}, module.UnresolvedAttributes())
+}
+
+func Test_VariableValidation(t *testing.T) {
+	path := createTestFile("test.tf", `
+variable "instance_type" {
+	default = "t3.mega"
+
+	validation {
+		condition = contains(["t3.micro", "t3.large"], var.instance_type)
+		error_message = "instance_type must be t3.micro or t3.large, got ${var.instance_type}."
+	}
+}
+
+variable "size" {
+	default = 10
+
+	validation {
+		condition = var.size > 5
+		error_message = "size must be over 5."
+	}
+}
+
+variable "unknown" {
+	validation {
+		condition = length(var.unknown) > 0
+		error_message = "unknown can't be empty."
+	}
+}
+
+resource "cats_cat" "mittens" {
+	instance_type = var.instance_type
+}
+`)
+
+	var warnings []string
+	parser := New(filepath.Dir(path), OptionStopOnHCLError(), OptionWithWarningFunc(func(msg string) {
+		warnings = append(warnings, msg)
+	}))
+	_, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[1], `Invalid value for variable "instance_type"`)
+	assert.Contains(t, warnings[1], "instance_type must be t3.micro or t3.large, got t3.mega.")
+
+	parser = New(filepath.Dir(path), OptionStopOnHCLError(), OptionStrictVariableValidation())
+	_, err = parser.ParseDirectory(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "instance_type must be t3.micro or t3.large, got t3.mega.")
+	assert.NotContains(t, err.Error(), "size must be over 5.")
+}
+
+func Test_OptionWithTFVarsPaths(t *testing.T) {
+	dir := t.TempDir()
+	otherDir := t.TempDir()
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "vars"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vars", "dev.tfvars"), []byte(`env = "dev"`), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "shared.tfvars"), []byte(`team = "platform"`), os.ModePerm))
+
+	p := New(dir, OptionWithTFVarsPaths([]string{
+		"vars/dev.tfvars",
+		filepath.Join(otherDir, "shared.tfvars"),
+		"missing.tfvars",
+	}))
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "vars", "dev.tfvars"),
+		filepath.Join(otherDir, "shared.tfvars"),
+	}, p.tfvarsPaths)
+}
+
+func Test_OptionWithFileOverrides(t *testing.T) {
+	dir := t.TempDir()
+	mainTf := filepath.Join(dir, "main.tf")
+	require.NoError(t, os.WriteFile(mainTf, []byte(`
+resource "cats_cat" "mittens" {
+	name = "mittens"
+}
+`), os.ModePerm))
+
+	parser := New(dir, OptionStopOnHCLError(), OptionWithFileOverrides(map[string][]byte{
+		mainTf: []byte(`
+resource "cats_cat" "mittens" {
+	name = "unsaved"
+}
+`),
+	}))
+	module, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	resources := module.Blocks.OfType("resource")
+	require.Len(t, resources, 1)
+	assert.Equal(t, "unsaved", resources[0].GetAttribute("name").Value().AsString())
+}
+
 func createTestFile(filename, contents string) string {
 	dir, err := ioutil.TempDir(os.TempDir(), "infracost")
 	if err != nil {
@@ -232,6 +628,47 @@ func createTestFile(filename, contents string) string {
 	return path
 }
 
+func Test_ExpandedBlocksHaveCanonicalAddresses(t *testing.T) {
+	path := createTestFileWithModule(`
+module "keyed" {
+	for_each = toset(["a.b", "c\"]d", "$${e}"])
+	source = "../module"
+}
+
+module "counted" {
+	count = 2
+	source = "../module"
+}
+`,
+		`
+resource "cats_cat" "mittens" {
+	count = 1
+}
+`,
+		"module",
+	)
+
+	parser := New(path, OptionStopOnHCLError())
+	rootModule, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	var names []string
+	for _, m := range rootModule.Modules {
+		for _, b := range m.Blocks.OfType("resource") {
+			names = append(names, b.FullName())
+		}
+	}
+
+	// these are the same as the addresses Terraform uses in its state and plan JSON.
+	assert.ElementsMatch(t, []string{
+		`module.keyed["a.b"].cats_cat.mittens[0]`,
+		`module.keyed["c\"]d"].cats_cat.mittens[0]`,
+		`module.keyed["$${e}"].cats_cat.mittens[0]`,
+		`module.counted[0].cats_cat.mittens[0]`,
+		`module.counted[1].cats_cat.mittens[0]`,
+	}, names)
+}
+
 func createTestFileWithModule(contents string, moduleContents string, moduleName string) string {
 	dir, err := ioutil.TempDir(os.TempDir(), "infracost")
 	if err != nil {
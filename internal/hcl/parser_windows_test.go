@@ -0,0 +1,40 @@
+//go:build windows
+// +build windows
+
+package hcl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OptionWithTFVarsPathsWindows(t *testing.T) {
+	dir := t.TempDir()
+	otherDir := t.TempDir()
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "vars"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vars", "dev.tfvars"), []byte(`env = "dev"`), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "shared.tfvars"), []byte(`team = "platform"`), os.ModePerm))
+
+	// Use the \\?\ prefixed UNC form of the drive letter path, e.g. \\?\C:\Users\...
+	uncPath := `\\?\` + filepath.Join(otherDir, "shared.tfvars")
+
+	p := New(dir, OptionWithTFVarsPaths([]string{
+		`vars\dev.tfvars`,
+		"vars/dev.tfvars",
+		strings.ToLower(filepath.Join(otherDir, "shared.tfvars")),
+		uncPath,
+	}))
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "vars", "dev.tfvars"),
+		filepath.Join(dir, "vars", "dev.tfvars"),
+		strings.ToLower(filepath.Join(otherDir, "shared.tfvars")),
+		uncPath,
+	}, p.tfvarsPaths)
+}
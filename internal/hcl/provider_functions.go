@@ -0,0 +1,109 @@
+package hcl
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/infracost/infracost/internal/hcl/funcs"
+)
+
+// Provider-defined functions are rewritten to names HCL can parse before the files are parsed, see
+// funcs.RewriteProviderFunctionCalls, and are added to the evaluation context with those names.
+var providerFunctionNameReg = regexp.MustCompile(`\bprovider__([A-Za-z][\w-]*?)__([A-Za-z_][\w]*)`)
+
+// providerFunctionsByType are the provider-defined functions that can be evaluated, keyed by the provider
+// type and function name. Calls to other provider-defined functions evaluate to unknown values, and are
+// reported as unsupported functions in the eval report.
+var providerFunctionsByType = map[string]map[string]function.Function{
+	"aws": {
+		"arn_parse":          funcs.AWSARNParseFunc,
+		"arn_build":          funcs.AWSARNBuildFunc,
+		"trim_iam_role_path": funcs.AWSTrimIAMRolePathFunc,
+	},
+	"azurerm": {
+		"parse_resource_id": funcs.AzureRMParseResourceIDFunc,
+	},
+	"google": {
+		"location_from_id": funcs.GoogleLocationFromIDFunc,
+		"name_from_id":     funcs.GoogleNameFromIDFunc,
+		"project_from_id":  funcs.GoogleProjectFromIDFunc,
+		"region_from_id":   funcs.GoogleRegionFromIDFunc,
+		"region_from_zone": funcs.GoogleRegionFromZoneFunc,
+		"zone_from_id":     funcs.GoogleZoneFromIDFunc,
+	},
+	"terraform": {
+		"decode_tfvars": funcs.TerraformDecodeTFVarsFunc,
+		"encode_expr":   funcs.TerraformEncodeExprFunc,
+		"encode_tfvars": funcs.TerraformEncodeTFVarsFunc,
+	},
+}
+
+// providerFunctionDisplayNames replaces the rewritten names of provider-defined functions in s, e.g. in a
+// diagnostic, with the names used in the Terraform code.
+func providerFunctionDisplayNames(s string) string {
+	if !strings.Contains(s, "provider__") {
+		return s
+	}
+
+	return providerFunctionNameReg.ReplaceAllString(s, "provider::$1::$2")
+}
+
+// providerFunctions returns the provider-defined functions that can be called in the Module Blocks. These
+// are called using the local names of the providers, which are the provider types unless the Module's
+// required_providers block gives the providers different names.
+func providerFunctions(blocks Blocks) map[string]function.Function {
+	localNames := map[string]string{}
+	for providerType := range providerFunctionsByType {
+		localNames[providerType] = providerType
+	}
+
+	for localName, source := range requiredProviderSources(blocks) {
+		providerType := source[strings.LastIndex(source, "/")+1:]
+		if providerType == "google-beta" {
+			providerType = "google"
+		}
+
+		if _, ok := providerFunctionsByType[providerType]; ok {
+			localNames[localName] = providerType
+		}
+	}
+
+	functions := map[string]function.Function{}
+	for localName, providerType := range localNames {
+		for name, f := range providerFunctionsByType[providerType] {
+			functions["provider__"+localName+"__"+name] = f
+		}
+	}
+
+	return functions
+}
+
+// requiredProviderSources returns the source of each provider in the required_providers blocks, keyed
+// by the provider's local name.
+func requiredProviderSources(blocks Blocks) map[string]string {
+	sources := map[string]string{}
+
+	for _, tf := range blocks.OfType("terraform") {
+		for _, rp := range tf.Children().OfType("required_providers") {
+			for _, attr := range rp.GetAttributes() {
+				// required_providers can only contain literal values so they're evaluated without a context.
+				val, diags := attr.HCLAttr.Expr.Value(nil)
+				if diags.HasErrors() || val.IsNull() || !val.IsWhollyKnown() || !val.Type().IsObjectType() || !val.Type().HasAttribute("source") {
+					continue
+				}
+
+				source := val.GetAttr("source")
+				if source.Type() != cty.String {
+					continue
+				}
+
+				sources[attr.Name()] = strings.ToLower(source.AsString())
+			}
+		}
+	}
+
+	return sources
+}
@@ -0,0 +1,434 @@
+package hcl
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyJson "github.com/zclconf/go-cty/cty/json"
+)
+
+//go:embed provider_schemas/*.json
+var bundledProviderSchemaFiles embed.FS
+
+var (
+	bundledProviderSchemas     *ProviderSchemas
+	bundledProviderSchemasOnce sync.Once
+)
+
+// metaAttributes and metaBlocks are the arguments that Terraform allows in any resource block. id and arn
+// are also allowed since the BlockBuilder adds them to every resource so they can be referenced.
+var (
+	metaAttributes = map[string]bool{"count": true, "for_each": true, "provider": true, "depends_on": true, "id": true, "arn": true}
+	metaBlocks     = map[string]bool{"lifecycle": true, "provisioner": true, "connection": true, "dynamic": true}
+)
+
+// ProviderSchemas are the schemas of the resource types of Terraform providers. They're used to find
+// attributes that don't exist or have the wrong type, e.g. because of a typo, since these are silently
+// ignored when pricing resources.
+type ProviderSchemas struct {
+	resources map[string]*SchemaBlock
+}
+
+// SchemaBlock is the schema of a resource or a nested block in a resource.
+type SchemaBlock struct {
+	Attributes map[string]*SchemaAttribute
+	BlockTypes map[string]*SchemaBlock
+}
+
+// SchemaAttribute is the schema of an attribute. Type is cty.DynamicPseudoType for attributes with a
+// nested type since their values aren't checked. Default is the value the provider uses when the
+// attribute isn't set, or cty.NilVal if it's not known.
+type SchemaAttribute struct {
+	Type    cty.Type
+	Default cty.Value
+}
+
+// providerSchemasJSON is the output of terraform providers schema -json. The bundled schemas use the
+// same format, with an extra default property on attributes since the output doesn't include defaults.
+type providerSchemasJSON struct {
+	ProviderSchemas map[string]struct {
+		ResourceSchemas map[string]struct {
+			Block schemaBlockJSON `json:"block"`
+		} `json:"resource_schemas"`
+	} `json:"provider_schemas"`
+}
+
+type schemaBlockJSON struct {
+	Attributes map[string]struct {
+		Type       json.RawMessage `json:"type"`
+		NestedType json.RawMessage `json:"nested_type"`
+		Default    json.RawMessage `json:"default"`
+	} `json:"attributes"`
+	BlockTypes map[string]struct {
+		Block schemaBlockJSON `json:"block"`
+	} `json:"block_types"`
+}
+
+// LoadProviderSchemas parses the output of terraform providers schema -json.
+func LoadProviderSchemas(b []byte) (*ProviderSchemas, error) {
+	var j providerSchemasJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return nil, fmt.Errorf("Error parsing provider schemas: %w", err)
+	}
+
+	s := &ProviderSchemas{resources: map[string]*SchemaBlock{}}
+
+	for provider, p := range j.ProviderSchemas {
+		for resourceType, r := range p.ResourceSchemas {
+			block, err := newSchemaBlock(r.Block)
+			if err != nil {
+				return nil, fmt.Errorf("Error parsing %s schema of %s: %w", resourceType, provider, err)
+			}
+
+			s.resources[resourceType] = block
+		}
+	}
+
+	return s, nil
+}
+
+// LoadProviderSchemasFile reads a file with the output of terraform providers schema -json.
+func LoadProviderSchemasFile(filename string) (*ProviderSchemas, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading provider schemas file: %w", err)
+	}
+
+	return LoadProviderSchemas(b)
+}
+
+// BundledProviderSchemas returns the schemas that are bundled with Infracost. These only have the
+// resource types whose attributes are most often used for pricing, so the schemas generated for a
+// project with terraform providers schema -json should be merged over them when they're available.
+func BundledProviderSchemas() *ProviderSchemas {
+	bundledProviderSchemasOnce.Do(func() {
+		bundledProviderSchemas = &ProviderSchemas{resources: map[string]*SchemaBlock{}}
+
+		entries, err := bundledProviderSchemaFiles.ReadDir("provider_schemas")
+		if err != nil {
+			log.Debugf("could not read bundled provider schemas: %s", err)
+			return
+		}
+
+		for _, entry := range entries {
+			b, err := bundledProviderSchemaFiles.ReadFile(path.Join("provider_schemas", entry.Name()))
+			if err != nil {
+				log.Debugf("could not read bundled provider schema %s: %s", entry.Name(), err)
+				continue
+			}
+
+			s, err := LoadProviderSchemas(b)
+			if err != nil {
+				log.Debugf("could not load bundled provider schema %s: %s", entry.Name(), err)
+				continue
+			}
+
+			bundledProviderSchemas = bundledProviderSchemas.Merge(s)
+		}
+	})
+
+	return bundledProviderSchemas
+}
+
+func newSchemaBlock(j schemaBlockJSON) (*SchemaBlock, error) {
+	b := &SchemaBlock{
+		Attributes: make(map[string]*SchemaAttribute, len(j.Attributes)),
+		BlockTypes: make(map[string]*SchemaBlock, len(j.BlockTypes)),
+	}
+
+	for name, a := range j.Attributes {
+		attr := &SchemaAttribute{Type: cty.DynamicPseudoType, Default: cty.NilVal}
+
+		if len(a.Type) > 0 {
+			t, err := ctyJson.UnmarshalType(a.Type)
+			if err != nil {
+				return nil, fmt.Errorf("invalid type of attribute %s: %w", name, err)
+			}
+			attr.Type = t
+		}
+
+		if len(a.Default) > 0 {
+			v, err := ctyJson.Unmarshal(a.Default, attr.Type)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default of attribute %s: %w", name, err)
+			}
+			attr.Default = v
+		}
+
+		b.Attributes[name] = attr
+	}
+
+	for name, bt := range j.BlockTypes {
+		child, err := newSchemaBlock(bt.Block)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		b.BlockTypes[name] = child
+	}
+
+	return b, nil
+}
+
+// Merge returns the schemas of s and other. The schemas in other are used for resource types that
+// are in both.
+func (s *ProviderSchemas) Merge(other *ProviderSchemas) *ProviderSchemas {
+	merged := &ProviderSchemas{resources: map[string]*SchemaBlock{}}
+
+	for _, schemas := range []*ProviderSchemas{s, other} {
+		if schemas == nil {
+			continue
+		}
+
+		for resourceType, block := range schemas.resources {
+			merged.resources[resourceType] = block
+		}
+	}
+
+	return merged
+}
+
+// ResourceSchema returns the schema of the resource type, or nil if it's not known.
+func (s *ProviderSchemas) ResourceSchema(resourceType string) *SchemaBlock {
+	if s == nil {
+		return nil
+	}
+
+	return s.resources[resourceType]
+}
+
+// Conform converts the primitive attribute values of a block to the types in the schema, e.g. "100" to
+// 100 for a number attribute, and sets the default of attributes that aren't set. Values that can't be
+// converted and attributes that aren't in the schema are left as they are. It returns val if b is nil.
+func (b *SchemaBlock) Conform(val cty.Value) cty.Value {
+	if b == nil || val == cty.NilVal || val.IsNull() || !val.IsKnown() || !val.Type().IsObjectType() {
+		return val
+	}
+
+	values := val.AsValueMap()
+	if values == nil {
+		values = map[string]cty.Value{}
+	}
+
+	for name, attr := range b.Attributes {
+		v, ok := values[name]
+		if !ok || v.IsNull() {
+			if attr.Default != cty.NilVal {
+				values[name] = attr.Default
+			}
+			continue
+		}
+
+		// collections aren't converted since converting a list to a set would reorder it.
+		if !attr.Type.IsPrimitiveType() || !v.IsWhollyKnown() {
+			continue
+		}
+
+		if converted, err := convert.Convert(v, attr.Type); err == nil {
+			values[name] = converted
+		}
+	}
+
+	return cty.ObjectVal(values)
+}
+
+// SchemaError is a resource attribute or block that isn't in the provider schema of the resource type,
+// or an attribute whose value can't be converted to the type in the schema.
+type SchemaError struct {
+	Address string
+	// Attribute is the name of the attribute or block, prefixed with any nested blocks, e.g.
+	// root_block_device.volume_size.
+	Attribute string
+	Message   string
+	Filename  string
+	Line      int
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("Invalid %q in %s at %s:%d: %s", e.Attribute, e.Address, e.Filename, e.Line, e.Message)
+}
+
+// SchemaErrors checks the resources in the Module and its local child Modules against the provider
+// schemas. Resources in remote modules aren't checked since they can't be fixed in the project, and
+// could be written for a different version of the provider. Values that aren't known are skipped.
+func (m *Module) SchemaErrors(schemas *ProviderSchemas) []*SchemaError {
+	var errs []*SchemaError
+	seen := map[string]bool{}
+
+	var walk func(m *Module)
+	walk = func(m *Module) {
+		for _, b := range m.Blocks.OfType("resource") {
+			block := schemas.ResourceSchema(b.TypeLabel())
+			if block == nil {
+				continue
+			}
+
+			for _, err := range blockSchemaErrors(stripResourceIndex(b.FullName()), "", b, block, true) {
+				// count and for_each copies of a block have the same errors.
+				key := fmt.Sprintf("%s:%d:%s", err.Filename, err.Line, err.Attribute)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				errs = append(errs, err)
+			}
+		}
+
+		for _, child := range m.Modules {
+			if isLocalModuleSource(child.Source) {
+				walk(child)
+			}
+		}
+	}
+	walk(m)
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Filename != errs[j].Filename {
+			return errs[i].Filename < errs[j].Filename
+		}
+
+		return errs[i].Line < errs[j].Line
+	})
+
+	return errs
+}
+
+func blockSchemaErrors(address string, prefix string, b *Block, schema *SchemaBlock, isResource bool) []*SchemaError {
+	var errs []*SchemaError
+
+	for _, attr := range b.GetAttributes() {
+		name := attr.Name()
+		r := attr.HCLAttr.Range
+
+		// attributes added by the BlockBuilder don't have a file.
+		if r.Filename == "" || (isResource && metaAttributes[name]) {
+			continue
+		}
+
+		s, ok := schema.Attributes[name]
+		if !ok {
+			errs = append(errs, &SchemaError{
+				Address:   address,
+				Attribute: prefix + name,
+				Message:   "unsupported attribute" + didYouMean(name, schema.Attributes),
+				Filename:  r.Filename,
+				Line:      r.Start.Line,
+			})
+			continue
+		}
+
+		v := attr.Value()
+		if s.Type == cty.DynamicPseudoType || v.IsNull() || !v.IsWhollyKnown() {
+			continue
+		}
+
+		if _, err := convert.Convert(v, s.Type); err != nil {
+			errs = append(errs, &SchemaError{
+				Address:   address,
+				Attribute: prefix + name,
+				Message:   "value must be " + s.Type.FriendlyName(),
+				Filename:  r.Filename,
+				Line:      r.Start.Line,
+			})
+		}
+	}
+
+	for _, child := range b.Children() {
+		name := child.Type()
+		if name == "dynamic" || (isResource && metaBlocks[name]) {
+			continue
+		}
+
+		s, ok := schema.BlockTypes[name]
+		if !ok {
+			r := child.hclBlock.DefRange
+			errs = append(errs, &SchemaError{
+				Address:   address,
+				Attribute: prefix + name,
+				Message:   "unsupported block type" + didYouMean(name, schema.BlockTypes),
+				Filename:  r.Filename,
+				Line:      r.Start.Line,
+			})
+			continue
+		}
+
+		errs = append(errs, blockSchemaErrors(address, prefix+name+".", child, s, false)...)
+	}
+
+	return errs
+}
+
+// didYouMean returns a suggestion of the name in names that's closest to name, if there's one that's
+// likely to be what was meant.
+func didYouMean[T any](name string, names map[string]T) string {
+	best := ""
+	bestDistance := 0
+
+	for candidate := range names {
+		d := editDistance(name, candidate)
+		if best == "" || d < bestDistance || (d == bestDistance && candidate < best) {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	if best == "" || bestDistance > 2 || bestDistance >= len(name) {
+		return ""
+	}
+
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func isLocalModuleSource(source string) bool {
+	return source == "" || strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
+
+// stripResourceIndex removes the count or for_each index from the end of a resource address. Any
+// module indexes are kept.
+func stripResourceIndex(address string) string {
+	if strings.HasSuffix(address, "]") {
+		if i := strings.LastIndex(address, "["); i > 0 {
+			return address[:i]
+		}
+	}
+
+	return address
+}
@@ -0,0 +1,135 @@
+package hcl
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const testProviderSchemas = `{
+	"format_version": "1.0",
+	"provider_schemas": {
+		"registry.terraform.io/infracost/cats": {
+			"resource_schemas": {
+				"cats_cat": {
+					"version": 0,
+					"block": {
+						"attributes": {
+							"name": {"type": "string", "required": true},
+							"lives": {"type": "number", "optional": true, "default": 9},
+							"indoor": {"type": "bool", "optional": true},
+							"toys": {"type": ["list", "string"], "optional": true}
+						},
+						"block_types": {
+							"collar": {
+								"nesting_mode": "list",
+								"block": {
+									"attributes": {
+										"size": {"type": "number", "optional": true}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+func Test_ProviderSchemaErrors(t *testing.T) {
+	path := createTestFile("test.tf", `
+variable "size" {
+	default = "large"
+}
+
+resource "cats_cat" "mittens" {
+	count = 2
+
+	name   = "mittens"
+	livs   = 3
+	indoor = "sometimes"
+
+	collar {
+		size = var.size
+	}
+
+	colar {
+		size = 2
+	}
+
+	lifecycle {
+		ignore_changes = [name]
+	}
+}
+
+resource "dogs_dog" "rex" {
+	nmae = "rex"
+}
+`)
+
+	schemas, err := LoadProviderSchemas([]byte(testProviderSchemas))
+	require.NoError(t, err)
+
+	var warnings []string
+	parser := New(filepath.Dir(path), OptionStopOnHCLError(), OptionWithProviderSchemas(schemas), OptionWithWarningFunc(func(msg string) {
+		warnings = append(warnings, msg)
+	}))
+	_, err = parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`Invalid "livs" in cats_cat.mittens at ` + path + `:10: unsupported attribute, did you mean "lives"?`,
+		`Invalid "indoor" in cats_cat.mittens at ` + path + `:11: value must be bool`,
+		`Invalid "collar.size" in cats_cat.mittens at ` + path + `:14: value must be number`,
+		`Invalid "colar" in cats_cat.mittens at ` + path + `:17: unsupported block type, did you mean "collar"?`,
+	}, warnings)
+}
+
+func TestSchemaBlock_Conform(t *testing.T) {
+	schemas, err := LoadProviderSchemas([]byte(testProviderSchemas))
+	require.NoError(t, err)
+
+	block := schemas.ResourceSchema("cats_cat")
+	require.NotNil(t, block)
+
+	val := block.Conform(cty.ObjectVal(map[string]cty.Value{
+		"name":   cty.StringVal("mittens"),
+		"indoor": cty.StringVal("true"),
+		"toys":   cty.TupleVal([]cty.Value{cty.StringVal("mouse")}),
+		"size":   cty.StringVal("large"),
+	}))
+
+	expected := cty.ObjectVal(map[string]cty.Value{
+		"name":   cty.StringVal("mittens"),
+		"lives":  cty.NumberIntVal(9),
+		"indoor": cty.True,
+		"toys":   cty.TupleVal([]cty.Value{cty.StringVal("mouse")}),
+		"size":   cty.StringVal("large"),
+	})
+	assert.True(t, val.Equals(expected).True(), "got %#v", val)
+
+	var nilBlock *SchemaBlock
+	assert.Equal(t, cty.StringVal("x"), nilBlock.Conform(cty.StringVal("x")))
+}
+
+func TestBundledProviderSchemas(t *testing.T) {
+	schemas := BundledProviderSchemas()
+
+	instance := schemas.ResourceSchema("aws_instance")
+	require.NotNil(t, instance)
+	assert.Equal(t, cty.String, instance.Attributes["instance_type"].Type)
+	assert.Equal(t, cty.Number, instance.BlockTypes["root_block_device"].Attributes["volume_size"].Type)
+
+	lambda := schemas.ResourceSchema("aws_lambda_function")
+	require.NotNil(t, lambda)
+	assert.True(t, lambda.Attributes["memory_size"].Default.RawEquals(cty.NumberIntVal(128)))
+
+	merged := schemas.Merge(&ProviderSchemas{resources: map[string]*SchemaBlock{"aws_instance": {}}})
+	assert.Empty(t, merged.ResourceSchema("aws_instance").Attributes)
+	assert.NotNil(t, merged.ResourceSchema("aws_lambda_function"))
+}
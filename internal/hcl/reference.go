@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/zclconf/go-cty/cty"
+
+	"github.com/infracost/infracost/internal/address"
 )
 
 type Reference struct {
@@ -45,7 +47,8 @@ func newReference(parts []string) (*Reference, error) {
 	}
 
 	if strings.Contains(ref.nameLabel, "[") {
-		bits := strings.Split(ref.nameLabel, "[")
+		// the key can have [ in it, e.g. aws_instance.web["a[0]"].
+		bits := strings.SplitN(ref.nameLabel, "[", 2)
 		ref.nameLabel = bits[0]
 		ref.key = "[" + bits[1]
 	}
@@ -66,9 +69,9 @@ func (r *Reference) SetKey(key cty.Value) {
 	case cty.Number:
 		f := key.AsBigFloat()
 		f64, _ := f.Float64()
-		r.key = fmt.Sprintf("[%d]", int(f64))
+		r.key = address.IntKey(int(f64)).String()
 	case cty.String:
-		r.key = fmt.Sprintf("[%q]", key.AsString())
+		r.key = address.StringKey(key.AsString()).String()
 	}
 }
 
@@ -170,8 +173,18 @@ var TypeTerraform = Type{
 	name: "terraform",
 }
 
+var TypeCheck = Type{
+	name: "check",
+}
+
+var TypeEphemeral = Type{
+	name: "ephemeral",
+}
+
 var ValidTypes = []Type{
+	TypeCheck,
 	TypeData,
+	TypeEphemeral,
 	TypeLocal,
 	TypeModule,
 	TypeOutput,
@@ -40,6 +40,18 @@ func Test_ReferenceParsing(t *testing.T) {
 			input:    []string{"output", "something"},
 			expected: "output.something",
 		},
+		{
+			input:    []string{"check", "health"},
+			expected: "check.health",
+		},
+		{
+			input:    []string{"ephemeral", "aws_secretsmanager_secret_version", "db"},
+			expected: "ephemeral.aws_secretsmanager_secret_version.db",
+		},
+		{
+			input:    []string{"aws_instance", `web["a[0]"]`},
+			expected: `aws_instance.web["a[0]"]`,
+		},
 	}
 
 	for _, test := range cases {
@@ -0,0 +1,353 @@
+package hcl
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/infracost/infracost/internal/httpclient"
+)
+
+const (
+	azureDevOpsAPIVersion    = "7.0"
+	gcpSecretManagerEndpoint = "https://secretmanager.googleapis.com"
+	gcpRuntimeConfigEndpoint = "https://runtimeconfig.googleapis.com"
+	gcpCloudPlatformScope    = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// VariableSource loads the values of input variables from a system other than tfvars files, e.g.
+// Azure DevOps variable groups or Google Secret Manager.
+type VariableSource interface {
+	// Name describes the source in log and error messages.
+	Name() string
+	// Load returns the values of the variables by name.
+	Load() (map[string]cty.Value, error)
+}
+
+// AzureDevOpsVariableGroupSource loads the variables of Azure DevOps Pipelines variable groups.
+type AzureDevOpsVariableGroupSource struct {
+	baseURL   string
+	project   string
+	groups    []string
+	token     string
+	tokenType string
+	client    *http.Client
+}
+
+type azureDevOpsVariableGroupsResponse struct {
+	Value []struct {
+		Name      string `json:"name"`
+		Variables map[string]struct {
+			Value    *string `json:"value"`
+			IsSecret bool    `json:"isSecret"`
+		} `json:"variables"`
+	} `json:"value"`
+}
+
+// NewAzureDevOpsVariableGroupSource returns a VariableSource for the variable groups of an Azure DevOps
+// project. The organization can be its name or URL. The access token is read from AZURE_DEVOPS_EXT_PAT,
+// or SYSTEM_ACCESSTOKEN when running in Azure Pipelines, in env or the process environment.
+func NewAzureDevOpsVariableGroupSource(organization, project string, groups []string, env map[string]string) *AzureDevOpsVariableGroupSource {
+	baseURL := strings.TrimSuffix(organization, "/")
+	if !strings.HasPrefix(baseURL, "https://") && !strings.HasPrefix(baseURL, "http://") {
+		baseURL = "https://dev.azure.com/" + baseURL
+	}
+
+	s := &AzureDevOpsVariableGroupSource{
+		baseURL: baseURL,
+		project: project,
+		groups:  groups,
+		client:  newRemoteVariablesClient(),
+	}
+
+	// PATs are sent with basic auth and the pipeline's job access token as a bearer token.
+	if token := envValue(env, "AZURE_DEVOPS_EXT_PAT"); token != "" {
+		s.token = base64.StdEncoding.EncodeToString([]byte(":" + token))
+		s.tokenType = "Basic"
+	} else if token := envValue(env, "SYSTEM_ACCESSTOKEN"); token != "" {
+		s.token = token
+		s.tokenType = "Bearer"
+	}
+
+	return s
+}
+
+// Name returns the organization and project of the variable groups.
+func (s *AzureDevOpsVariableGroupSource) Name() string {
+	return fmt.Sprintf("Azure DevOps variable groups in %s/%s", s.baseURL, s.project)
+}
+
+// Load returns the variables of the groups, with the variables of later groups overriding earlier
+// ones. Secret variables are skipped since their values aren't returned by the API.
+func (s *AzureDevOpsVariableGroupSource) Load() (map[string]cty.Value, error) {
+	if s.token == "" {
+		return nil, errors.New("AZURE_DEVOPS_EXT_PAT or SYSTEM_ACCESSTOKEN must be set to load variable groups")
+	}
+
+	vars := map[string]cty.Value{}
+
+	for _, group := range s.groups {
+		endpoint := fmt.Sprintf("%s/%s/_apis/distributedtask/variablegroups?groupName=%s&api-version=%s", s.baseURL, url.PathEscape(s.project), url.QueryEscape(group), azureDevOpsAPIVersion)
+		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("%s %s", s.tokenType, s.token))
+
+		body, err := doRemoteVariablesRequest(s.client, req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to fetch variable group %s", group)
+		}
+
+		var resp azureDevOpsVariableGroupsResponse
+		if json.Unmarshal(body, &resp) != nil {
+			return nil, errors.Errorf("unable to parse variable group %s response", group)
+		}
+
+		found := false
+		for _, g := range resp.Value {
+			if !strings.EqualFold(g.Name, group) {
+				continue
+			}
+
+			found = true
+			for k, v := range g.Variables {
+				if v.IsSecret || v.Value == nil {
+					log.Debugf("Skipping secret variable %s in variable group %s", k, group)
+					continue
+				}
+
+				vars[k] = cty.StringVal(*v.Value)
+			}
+		}
+
+		if !found {
+			return nil, errors.Errorf("variable group %s not found", group)
+		}
+	}
+
+	return vars, nil
+}
+
+// GCPSecretManagerSource loads the latest version of Google Secret Manager secrets.
+type GCPSecretManagerSource struct {
+	baseURL string
+	project string
+	secrets []string
+	env     map[string]string
+	client  *http.Client
+}
+
+type gcpSecretVersionResponse struct {
+	Payload struct {
+		Data string `json:"data"`
+	} `json:"payload"`
+}
+
+// NewGCPSecretManagerSource returns a VariableSource for secrets in a Google Cloud project. The
+// credentials are read from GOOGLE_OAUTH_ACCESS_TOKEN or GOOGLE_APPLICATION_CREDENTIALS in env,
+// otherwise the application default credentials are used.
+func NewGCPSecretManagerSource(project string, secrets []string, env map[string]string) *GCPSecretManagerSource {
+	return &GCPSecretManagerSource{
+		baseURL: gcpSecretManagerEndpoint,
+		project: project,
+		secrets: secrets,
+		env:     env,
+	}
+}
+
+// Name returns the project of the secrets.
+func (s *GCPSecretManagerSource) Name() string {
+	return fmt.Sprintf("Google Secret Manager in project %s", s.project)
+}
+
+// Load returns the value of the latest version of each secret.
+func (s *GCPSecretManagerSource) Load() (map[string]cty.Value, error) {
+	client, err := gcpClient(s.client, s.env)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]cty.Value{}
+
+	for _, secret := range s.secrets {
+		endpoint := fmt.Sprintf("%s/v1/projects/%s/secrets/%s/versions/latest:access", s.baseURL, url.PathEscape(s.project), url.PathEscape(secret))
+		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := doRemoteVariablesRequest(client, req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to access secret %s", secret)
+		}
+
+		var resp gcpSecretVersionResponse
+		if json.Unmarshal(body, &resp) != nil {
+			return nil, errors.Errorf("unable to parse secret %s response", secret)
+		}
+
+		data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+		if err != nil {
+			return nil, errors.Errorf("unable to decode secret %s", secret)
+		}
+
+		vars[secret] = cty.StringVal(string(data))
+	}
+
+	return vars, nil
+}
+
+// GCPRuntimeConfigSource loads variables from a Google Cloud Runtime Configurator config.
+type GCPRuntimeConfigSource struct {
+	baseURL   string
+	project   string
+	config    string
+	variables []string
+	env       map[string]string
+	client    *http.Client
+}
+
+type gcpRuntimeConfigVariableResponse struct {
+	Text  *string `json:"text"`
+	Value *string `json:"value"`
+}
+
+// NewGCPRuntimeConfigSource returns a VariableSource for the variables of a Runtime Configurator
+// config, using the same credentials as NewGCPSecretManagerSource.
+func NewGCPRuntimeConfigSource(project, config string, variables []string, env map[string]string) *GCPRuntimeConfigSource {
+	return &GCPRuntimeConfigSource{
+		baseURL:   gcpRuntimeConfigEndpoint,
+		project:   project,
+		config:    config,
+		variables: variables,
+		env:       env,
+	}
+}
+
+// Name returns the project and name of the config.
+func (s *GCPRuntimeConfigSource) Name() string {
+	return fmt.Sprintf("Google Runtime Configurator config %s in project %s", s.config, s.project)
+}
+
+// Load returns the value of each variable. Variables can have a text or a base64 encoded value.
+func (s *GCPRuntimeConfigSource) Load() (map[string]cty.Value, error) {
+	client, err := gcpClient(s.client, s.env)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]cty.Value{}
+
+	for _, variable := range s.variables {
+		endpoint := fmt.Sprintf("%s/v1beta1/projects/%s/configs/%s/variables/%s", s.baseURL, url.PathEscape(s.project), url.PathEscape(s.config), variable)
+		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := doRemoteVariablesRequest(client, req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to fetch runtime config variable %s", variable)
+		}
+
+		var resp gcpRuntimeConfigVariableResponse
+		if json.Unmarshal(body, &resp) != nil {
+			return nil, errors.Errorf("unable to parse runtime config variable %s response", variable)
+		}
+
+		switch {
+		case resp.Text != nil:
+			vars[variable] = cty.StringVal(*resp.Text)
+		case resp.Value != nil:
+			data, err := base64.StdEncoding.DecodeString(*resp.Value)
+			if err != nil {
+				return nil, errors.Errorf("unable to decode runtime config variable %s", variable)
+			}
+			vars[variable] = cty.StringVal(string(data))
+		}
+	}
+
+	return vars, nil
+}
+
+func newRemoteVariablesClient() *http.Client {
+	client := httpclient.NewClient(httpclient.PurposeRemoteVariables)
+	client.Timeout = time.Second * 10
+
+	return client
+}
+
+// gcpClient returns client if it's set, otherwise a client authenticated with the Google Cloud
+// credentials from env or the application default credentials.
+func gcpClient(client *http.Client, env map[string]string) (*http.Client, error) {
+	if client != nil {
+		return client, nil
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, newRemoteVariablesClient())
+
+	if token := envValue(env, "GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})), nil
+	}
+
+	var creds *google.Credentials
+	if credsFile := envValue(env, "GOOGLE_APPLICATION_CREDENTIALS"); credsFile != "" {
+		b, err := os.ReadFile(credsFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read Google credentials file")
+		}
+
+		creds, err = google.CredentialsFromJSON(ctx, b, gcpCloudPlatformScope)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse Google credentials file")
+		}
+	} else {
+		var err error
+		creds, err = google.FindDefaultCredentials(ctx, gcpCloudPlatformScope)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to find Google credentials")
+		}
+	}
+
+	return oauth2.NewClient(ctx, creds.TokenSource), nil
+}
+
+func doRemoteVariablesRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	log.Debugf("Loading remote variables: %s %s", req.Method, req.URL.Redacted())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, errors.Errorf("access denied: %s", resp.Status)
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("invalid response: %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// envValue returns the value of key in env, or the process environment if it's not set there.
+func envValue(env map[string]string, key string) string {
+	if v, ok := env[key]; ok {
+		return v
+	}
+
+	return os.Getenv(key)
+}
@@ -0,0 +1,134 @@
+package hcl
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestAzureDevOpsVariableGroupSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/myorg/infra/_apis/distributedtask/variablegroups", r.URL.Path)
+		assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte(":pat")), r.Header.Get("Authorization"))
+
+		switch r.URL.Query().Get("groupName") {
+		case "common":
+			fmt.Fprint(w, `{"count":1,"value":[{"name":"common","variables":{"region":{"value":"us-east-1"},"instance_type":{"value":"t3.micro"},"password":{"isSecret":true}}}]}`)
+		case "prod":
+			fmt.Fprint(w, `{"count":1,"value":[{"name":"prod","variables":{"instance_type":{"value":"m5.large"}}}]}`)
+		default:
+			fmt.Fprint(w, `{"count":0,"value":[]}`)
+		}
+	}))
+	defer server.Close()
+
+	s := NewAzureDevOpsVariableGroupSource(server.URL+"/myorg/", "infra", []string{"common", "prod"}, map[string]string{"AZURE_DEVOPS_EXT_PAT": "pat"})
+	vars, err := s.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cty.Value{
+		"region":        cty.StringVal("us-east-1"),
+		"instance_type": cty.StringVal("m5.large"),
+	}, vars)
+
+	s = NewAzureDevOpsVariableGroupSource(server.URL+"/myorg", "infra", []string{"missing"}, map[string]string{"AZURE_DEVOPS_EXT_PAT": "pat"})
+	_, err = s.Load()
+	assert.EqualError(t, err, "variable group missing not found")
+
+	s = NewAzureDevOpsVariableGroupSource("myorg", "infra", []string{"common"}, map[string]string{"AZURE_DEVOPS_EXT_PAT": "", "SYSTEM_ACCESSTOKEN": ""})
+	assert.Equal(t, "https://dev.azure.com/myorg", s.baseURL)
+	_, err = s.Load()
+	assert.Error(t, err)
+}
+
+func TestGCPSecretManagerSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/projects/my-project/secrets/db_password/versions/latest:access":
+			fmt.Fprintf(w, `{"name":"projects/1/secrets/db_password/versions/2","payload":{"data":%q}}`, base64.StdEncoding.EncodeToString([]byte("hunter2")))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	s := NewGCPSecretManagerSource("my-project", []string{"db_password"}, nil)
+	s.baseURL = server.URL
+	s.client = server.Client()
+
+	vars, err := s.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cty.Value{"db_password": cty.StringVal("hunter2")}, vars)
+
+	s.secrets = []string{"missing"}
+	_, err = s.Load()
+	assert.EqualError(t, err, "unable to access secret missing: invalid response: 404 Not Found")
+}
+
+func TestGCPRuntimeConfigSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1beta1/projects/my-project/configs/tf-vars/variables/instance_type":
+			fmt.Fprint(w, `{"name":"projects/my-project/configs/tf-vars/variables/instance_type","text":"n2-standard-4"}`)
+		case "/v1beta1/projects/my-project/configs/tf-vars/variables/disk_size":
+			fmt.Fprintf(w, `{"name":"projects/my-project/configs/tf-vars/variables/disk_size","value":%q}`, base64.StdEncoding.EncodeToString([]byte("100")))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	s := NewGCPRuntimeConfigSource("my-project", "tf-vars", []string{"instance_type", "disk_size"}, nil)
+	s.baseURL = server.URL
+	s.client = server.Client()
+
+	vars, err := s.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cty.Value{
+		"instance_type": cty.StringVal("n2-standard-4"),
+		"disk_size":     cty.StringVal("100"),
+	}, vars)
+}
+
+type staticVariableSource map[string]cty.Value
+
+func (s staticVariableSource) Name() string { return "static" }
+
+func (s staticVariableSource) Load() (map[string]cty.Value, error) { return s, nil }
+
+func TestOptionWithVariableSources(t *testing.T) {
+	path := createTestFile("test.tf", `
+variable "instance_type" {}
+variable "region" {}
+
+resource "aws_instance" "web" {
+	instance_type     = var.instance_type
+	availability_zone = "${var.region}a"
+}
+`)
+	dir := filepath.Dir(path)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "prod.tfvars"), []byte(`instance_type = "m5.xlarge"`), os.ModePerm))
+
+	parser := New(dir,
+		OptionWithTFVarsPaths([]string{"prod.tfvars"}),
+		OptionWithVariableSources(
+			staticVariableSource{"instance_type": cty.StringVal("t3.micro"), "region": cty.StringVal("us-east-1")},
+		),
+	)
+	module, err := parser.ParseDirectory(context.Background())
+	require.NoError(t, err)
+
+	resources := module.Blocks.OfType("resource")
+	require.Len(t, resources, 1)
+	// var files take precedence over the remote variables.
+	assert.Equal(t, "m5.xlarge", resources[0].GetAttribute("instance_type").Value().AsString())
+	assert.Equal(t, "us-east-1a", resources[0].GetAttribute("availability_zone").Value().AsString())
+}
@@ -0,0 +1,407 @@
+package hcl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/infracost/infracost/internal/hcl/funcs"
+)
+
+const (
+	stackFileSuffix      = ".tfstack.hcl"
+	deploymentFileSuffix = ".tfdeploy.hcl"
+)
+
+// componentRefReg matches references to other components of a Stack, e.g. component.vpc.id, which are
+// rewritten to module references in the generated root module.
+var componentRefReg = regexp.MustCompile(`(^|[^\w.])component\.`)
+
+// Stack is a Terraform Stack. It's made up of the components in its .tfstack.hcl files, which are
+// deployed once for each deployment in its .tfdeploy.hcl files.
+//
+// Stacks are estimated by writing a Terraform root module for each deployment, where each component is a
+// module call, and parsing this like any other Terraform directory.
+type Stack struct {
+	Path        string
+	Deployments []StackDeployment
+
+	requiredProviders []string
+	providers         []stackProvider
+	variables         []string
+	locals            []string
+	components        []stackComponent
+}
+
+// StackDeployment is a deployment of a Stack with the input values for the Stack variables.
+type StackDeployment struct {
+	Name   string
+	Inputs map[string]cty.Value
+}
+
+type stackProvider struct {
+	providerType string
+	name         string
+	attributes   []stackAttribute
+}
+
+type stackComponent struct {
+	name       string
+	source     string
+	version    string
+	forEach    string
+	attributes []stackAttribute
+}
+
+// stackAttribute is an attribute of the generated root module with the source of its expression.
+type stackAttribute struct {
+	name string
+	expr string
+}
+
+// IsStackDir returns true if the directory at path contains any Terraform Stack files.
+func IsStackDir(path string) bool {
+	matches, err := filepath.Glob(filepath.Join(path, "*"+stackFileSuffix))
+	return err == nil && len(matches) > 0
+}
+
+// LoadStack parses the Terraform Stack files in the directory at path. The deployments of the Stack are
+// sorted by name.
+func LoadStack(path string) (*Stack, error) {
+	s := &Stack{Path: path}
+
+	stackFiles, err := filepath.Glob(filepath.Join(path, "*"+stackFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, filename := range stackFiles {
+		src, body, err := parseStackFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		s.loadStackBlocks(src, body)
+	}
+
+	deploymentFiles, err := filepath.Glob(filepath.Join(path, "*"+deploymentFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	var deploymentBlocks hclsyntax.Blocks
+	for _, filename := range deploymentFiles {
+		_, body, err := parseStackFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		deploymentBlocks = append(deploymentBlocks, body.Blocks...)
+	}
+
+	s.loadDeploymentBlocks(deploymentBlocks)
+
+	sort.Slice(s.Deployments, func(i, j int) bool {
+		return s.Deployments[i].Name < s.Deployments[j].Name
+	})
+
+	return s, nil
+}
+
+func parseStackFile(filename string) ([]byte, *hclsyntax.Body, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read Stack file %s: %w", filename, err)
+	}
+
+	src = funcs.RewriteProviderFunctionCalls(src)
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, fmt.Errorf("could not parse Stack file %s: %w", filename, diags)
+	}
+
+	return src, file.Body.(*hclsyntax.Body), nil
+}
+
+func (s *Stack) loadStackBlocks(src []byte, body *hclsyntax.Body) {
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "required_providers":
+			s.requiredProviders = append(s.requiredProviders, sourceText(src, block.Body.Range()))
+		case "variable":
+			s.variables = append(s.variables, sourceText(src, block.Range()))
+		case "locals":
+			s.locals = append(s.locals, componentRefReg.ReplaceAllString(sourceText(src, block.Range()), "${1}module."))
+		case "provider":
+			if len(block.Labels) < 2 {
+				continue
+			}
+
+			// provider configurations with for_each, e.g. one for each region, can't be written as a
+			// single provider block so the default region is used instead.
+			if _, ok := block.Body.Attributes["for_each"]; ok {
+				log.Debugf("Skipping Stack provider %s with for_each", strings.Join(block.Labels, "."))
+				continue
+			}
+
+			p := stackProvider{providerType: block.Labels[0], name: block.Labels[1]}
+			for _, config := range block.Body.Blocks {
+				if config.Type == "config" {
+					p.attributes = append(p.attributes, stackAttributes(src, config.Body)...)
+				}
+			}
+
+			s.providers = append(s.providers, p)
+		case "component":
+			if len(block.Labels) == 0 {
+				continue
+			}
+
+			s.components = append(s.components, loadStackComponent(src, block))
+		}
+	}
+}
+
+func loadStackComponent(src []byte, block *hclsyntax.Block) stackComponent {
+	c := stackComponent{name: block.Labels[0]}
+
+	if attr, ok := block.Body.Attributes["source"]; ok {
+		v, diags := attr.Expr.Value(nil)
+		if !diags.HasErrors() && v.Type() == cty.String && v.IsKnown() && !v.IsNull() {
+			c.source = v.AsString()
+		}
+	}
+
+	if attr, ok := block.Body.Attributes["version"]; ok {
+		c.version = sourceText(src, attr.Expr.Range())
+	}
+
+	if attr, ok := block.Body.Attributes["for_each"]; ok {
+		c.forEach = componentRefReg.ReplaceAllString(sourceText(src, attr.Expr.Range()), "${1}module.")
+	}
+
+	attr, ok := block.Body.Attributes["inputs"]
+	if !ok {
+		return c
+	}
+
+	inputs, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		log.Debugf("Skipping inputs of Stack component %s since they aren't an object", c.name)
+		return c
+	}
+
+	for _, item := range inputs.Items {
+		key, diags := item.KeyExpr.Value(nil)
+		if diags.HasErrors() || key.Type() != cty.String || !key.IsKnown() || key.IsNull() {
+			continue
+		}
+
+		c.attributes = append(c.attributes, stackAttribute{
+			name: key.AsString(),
+			expr: componentRefReg.ReplaceAllString(sourceText(src, item.ValueExpr.Range()), "${1}module."),
+		})
+	}
+
+	return c
+}
+
+func (s *Stack) loadDeploymentBlocks(blocks hclsyntax.Blocks) {
+	ctx := &hcl.EvalContext{
+		Functions: expFunctions(s.Path),
+		Variables: map[string]cty.Value{},
+	}
+
+	// deployment inputs can reference locals in the deployment files. These can reference each
+	// other so they're evaluated until they can't be resolved any further.
+	var locals []*hclsyntax.Attribute
+	for _, block := range blocks {
+		if block.Type == "locals" {
+			locals = append(locals, bodyAttributes(block.Body)...)
+		}
+	}
+
+	for i := 0; i < len(locals); i++ {
+		values := map[string]cty.Value{}
+		for _, attr := range locals {
+			v, _ := attr.Expr.Value(ctx)
+			values[attr.Name] = v
+		}
+		ctx.Variables["local"] = cty.ObjectVal(values)
+	}
+
+	for _, block := range blocks {
+		if block.Type != "deployment" || len(block.Labels) == 0 {
+			continue
+		}
+
+		d := StackDeployment{Name: block.Labels[0], Inputs: map[string]cty.Value{}}
+
+		if attr, ok := block.Body.Attributes["inputs"]; ok {
+			if inputs, ok := attr.Expr.(*hclsyntax.ObjectConsExpr); ok {
+				for _, item := range inputs.Items {
+					key, diags := item.KeyExpr.Value(nil)
+					if diags.HasErrors() || key.Type() != cty.String || !key.IsKnown() || key.IsNull() {
+						continue
+					}
+
+					// inputs that are only known when the deployment is applied, e.g. identity tokens, are left
+					// unset so they're reported as missing variables.
+					v, diags := item.ValueExpr.Value(ctx)
+					if diags.HasErrors() || !v.IsWhollyKnown() {
+						log.Debugf("Skipping input %s of Stack deployment %s since it can't be evaluated", key.AsString(), d.Name)
+						continue
+					}
+
+					d.Inputs[key.AsString()] = v
+				}
+			}
+		}
+
+		s.Deployments = append(s.Deployments, d)
+	}
+}
+
+// WriteDeployment writes the Terraform root module for the deployment of the Stack to dir, with the
+// deployment inputs in a terraform.tfvars file.
+func (s *Stack) WriteDeployment(dir string, d StackDeployment) error {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("could not create directory for Stack deployment %s: %w", d.Name, err)
+	}
+
+	stackRel, err := filepath.Rel(dir, s.Path)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "main.tf"), s.rootModule(stackRel), 0600)
+	if err != nil {
+		return fmt.Errorf("could not write root module for Stack deployment %s: %w", d.Name, err)
+	}
+
+	names := make([]string, 0, len(d.Inputs))
+	for name := range d.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	f := hclwrite.NewEmptyFile()
+	for _, name := range names {
+		f.Body().SetAttributeValue(name, d.Inputs[name])
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "terraform.tfvars"), f.Bytes(), 0600)
+	if err != nil {
+		return fmt.Errorf("could not write inputs for Stack deployment %s: %w", d.Name, err)
+	}
+
+	return nil
+}
+
+// rootModule returns the source of a Terraform root module with a module call for each component of the
+// Stack. Local component sources are made relative to stackRel, the path of the Stack from the
+// directory the root module is written to.
+func (s *Stack) rootModule(stackRel string) []byte {
+	var b strings.Builder
+
+	if len(s.requiredProviders) > 0 {
+		b.WriteString("terraform {\n  required_providers ")
+		for _, rp := range s.requiredProviders {
+			b.WriteString(rp)
+			b.WriteString("\n")
+		}
+		b.WriteString("}\n\n")
+	}
+
+	// the first configuration of each provider is used as the default, since the provider
+	// configurations passed to the components aren't used to find the region of their resources.
+	seen := map[string]bool{}
+	for _, p := range s.providers {
+		fmt.Fprintf(&b, "provider %q {\n", p.providerType)
+		if seen[p.providerType] {
+			fmt.Fprintf(&b, "  alias = %q\n", p.name)
+		}
+		seen[p.providerType] = true
+
+		writeStackAttributes(&b, p.attributes)
+		b.WriteString("}\n\n")
+	}
+
+	for _, v := range s.variables {
+		b.WriteString(v)
+		b.WriteString("\n\n")
+	}
+
+	for _, l := range s.locals {
+		b.WriteString(l)
+		b.WriteString("\n\n")
+	}
+
+	for _, c := range s.components {
+		source := c.source
+		if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+			source = filepath.Join(stackRel, filepath.FromSlash(source))
+		}
+
+		fmt.Fprintf(&b, "module %q {\n", c.name)
+		fmt.Fprintf(&b, "  source = %q\n", source)
+		if c.version != "" {
+			fmt.Fprintf(&b, "  version = %s\n", c.version)
+		}
+		if c.forEach != "" {
+			fmt.Fprintf(&b, "  for_each = %s\n", c.forEach)
+		}
+
+		writeStackAttributes(&b, c.attributes)
+		b.WriteString("}\n\n")
+	}
+
+	return hclwrite.Format([]byte(strings.TrimRight(b.String(), "\n") + "\n"))
+}
+
+func writeStackAttributes(b *strings.Builder, attributes []stackAttribute) {
+	for _, attr := range attributes {
+		fmt.Fprintf(b, "  %s = %s\n", attr.name, attr.expr)
+	}
+}
+
+func stackAttributes(src []byte, body *hclsyntax.Body) []stackAttribute {
+	attrs := bodyAttributes(body)
+
+	attributes := make([]stackAttribute, len(attrs))
+	for i, attr := range attrs {
+		attributes[i] = stackAttribute{
+			name: attr.Name,
+			expr: componentRefReg.ReplaceAllString(sourceText(src, attr.Expr.Range()), "${1}module."),
+		}
+	}
+
+	return attributes
+}
+
+// bodyAttributes returns the attributes of body in the order they're defined.
+func bodyAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte
+	})
+
+	return attrs
+}
+
+func sourceText(src []byte, r hcl.Range) string {
+	return string(src[r.Start.Byte:r.End.Byte])
+}
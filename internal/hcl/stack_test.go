@@ -0,0 +1,166 @@
+package hcl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestLoadStack(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"components.tfstack.hcl": `
+required_providers {
+	aws = {
+		source = "hashicorp/aws"
+	}
+}
+
+variable "region" {
+	type = string
+}
+
+variable "instance_type" {
+	type    = string
+	default = "t3.micro"
+}
+
+provider "aws" "this" {
+	config {
+		region = var.region
+	}
+}
+
+component "network" {
+	source = "./modules/network"
+}
+
+component "web" {
+	source = "./modules/web"
+
+	inputs = {
+		instance_type = var.instance_type
+		subnet_id     = component.network.subnet_id
+	}
+
+	providers = {
+		aws = provider.aws.this
+	}
+}
+`,
+		"deployments.tfdeploy.hcl": `
+identity_token "aws" {
+	audience = ["aws.workload.identity"]
+}
+
+locals {
+	region = "eu-west-1"
+}
+
+deployment "production" {
+	inputs = {
+		region        = local.region
+		instance_type = "m5.large"
+		role_arn      = identity_token.aws.jwt
+	}
+}
+
+deployment "development" {
+	inputs = {
+		region = local.region
+	}
+}
+`,
+		"modules/network/main.tf": `
+output "subnet_id" {
+	value = "subnet-123"
+}
+`,
+		"modules/web/main.tf": `
+variable "instance_type" {}
+variable "subnet_id" {}
+
+resource "aws_instance" "web" {
+	instance_type = var.instance_type
+	subnet_id     = var.subnet_id
+}
+`,
+	}
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	assert.True(t, IsStackDir(dir))
+	assert.False(t, IsStackDir(filepath.Join(dir, "modules", "web")))
+
+	stack, err := LoadStack(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []StackDeployment{
+		{
+			Name:   "development",
+			Inputs: map[string]cty.Value{"region": cty.StringVal("eu-west-1")},
+		},
+		{
+			Name: "production",
+			Inputs: map[string]cty.Value{
+				"region":        cty.StringVal("eu-west-1"),
+				"instance_type": cty.StringVal("m5.large"),
+			},
+		},
+	}, stack.Deployments)
+
+	tests := []struct {
+		deployment   string
+		instanceType string
+	}{
+		{"development", "t3.micro"},
+		{"production", "m5.large"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.deployment, func(t *testing.T) {
+			var deployment StackDeployment
+			for _, d := range stack.Deployments {
+				if d.Name == test.deployment {
+					deployment = d
+				}
+			}
+
+			deploymentDir := filepath.Join(dir, ".infracost", "stacks", test.deployment)
+			require.NoError(t, stack.WriteDeployment(deploymentDir, deployment))
+
+			parser := New(deploymentDir, OptionStopOnHCLError())
+			module, err := parser.ParseDirectory(context.Background())
+			require.NoError(t, err)
+
+			providers := module.Blocks.OfType("provider")
+			require.Len(t, providers, 1)
+			assert.Equal(t, "eu-west-1", providers[0].GetAttribute("region").Value().AsString())
+
+			require.Len(t, module.Modules, 2)
+
+			var web *Module
+			for _, m := range module.Modules {
+				if m.Name == "module.web" {
+					web = m
+				}
+			}
+			require.NotNil(t, web)
+
+			resources := web.Blocks.OfType("resource")
+			require.Len(t, resources, 1)
+			assert.Equal(t, test.instanceType, resources[0].GetAttribute("instance_type").Value().AsString())
+			assert.Equal(t, "subnet-123", resources[0].GetAttribute("subnet_id").Value().AsString())
+		})
+	}
+}
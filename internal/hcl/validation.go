@@ -0,0 +1,98 @@
+package hcl
+
+import (
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// VariableValidationError is a variable value that doesn't meet the condition of one of the
+// variable's validation blocks.
+type VariableValidationError struct {
+	Variable string
+	// Message is the error_message of the validation block.
+	Message  string
+	Filename string
+	Line     int
+}
+
+func (e *VariableValidationError) Error() string {
+	return fmt.Sprintf("Invalid value for variable %q at %s:%d: %s", e.Variable, e.Filename, e.Line, e.Message)
+}
+
+// ValidateVars runs the condition of each validation block in the Module variables against the variable
+// values. It returns an error for each condition that isn't met. Conditions that can't be evaluated, e.g.
+// because the variable has no value or uses an unsupported function, are skipped since we can't tell if
+// the value is invalid.
+func (e *Evaluator) ValidateVars() []*VariableValidationError {
+	var errs []*VariableValidationError
+
+	for _, b := range e.module.Blocks.OfType("variable") {
+		validations := b.Children().OfType("validation")
+		if len(validations) == 0 {
+			continue
+		}
+
+		val, err := e.evaluateVariable(b)
+		if err != nil || !val.IsWhollyKnown() {
+			continue
+		}
+
+		// validation conditions can only reference the variable they belong to.
+		ctx := e.ctx.NewChild()
+		ctx.Set(cty.ObjectVal(map[string]cty.Value{b.Label(): val}), "var")
+
+		for _, validation := range validations {
+			if ok := validationConditionMet(validation, ctx); ok {
+				continue
+			}
+
+			r := validation.hclBlock.DefRange
+			errs = append(errs, &VariableValidationError{
+				Variable: b.Label(),
+				Message:  validationErrorMessage(validation, ctx),
+				Filename: r.Filename,
+				Line:     r.Start.Line,
+			})
+		}
+	}
+
+	return errs
+}
+
+// validationConditionMet returns false only if the condition evaluates to false. Conditions that
+// can't be evaluated to a known bool are treated as met.
+func validationConditionMet(validation *Block, ctx *Context) bool {
+	attr := validation.GetAttribute("condition")
+	if attr == nil {
+		return true
+	}
+
+	val, diags := attr.HCLAttr.Expr.Value(ctx.Inner())
+	if diags.HasErrors() {
+		log.Debugf("could not evaluate variable validation condition: %s", diags.Error())
+		return true
+	}
+
+	if val.IsNull() || !val.IsKnown() || val.Type() != cty.Bool {
+		return true
+	}
+
+	return val.True()
+}
+
+func validationErrorMessage(validation *Block, ctx *Context) string {
+	msg := "the value does not meet the variable's validation condition"
+
+	attr := validation.GetAttribute("error_message")
+	if attr == nil {
+		return msg
+	}
+
+	val, diags := attr.HCLAttr.Expr.Value(ctx.Inner())
+	if diags.HasErrors() || val.IsNull() || !val.IsKnown() || val.Type() != cty.String {
+		return msg
+	}
+
+	return val.AsString()
+}
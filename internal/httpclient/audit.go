@@ -0,0 +1,194 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Purposes of outbound requests that are recorded in the audit log.
+const (
+	PurposePricing         = "pricing"
+	PurposeTelemetry       = "telemetry"
+	PurposeDashboard       = "dashboard"
+	PurposeModuleRegistry  = "module_registry"
+	PurposeModuleDownload  = "module_download"
+	PurposeRemoteVariables = "remote_variables"
+	PurposeComment         = "comment"
+	PurposeUpdate          = "update"
+	PurposeTerraform       = "terraform"
+	PurposeCloudUsage      = "cloud_usage"
+	PurposeNotification    = "notification"
+	PurposeReportStorage   = "report_storage"
+	PurposeOther           = "other"
+)
+
+// maxPendingAuditRecords is the number of requests made before the audit log is enabled, e.g.
+// by the update check while the flags are parsed, that are kept to be written once it is.
+const maxPendingAuditRecords = 100
+
+// AuditRecord is a line in the audit log. The endpoint doesn't include the query string or any
+// credentials in the URL, and no headers or bodies are recorded.
+type AuditRecord struct {
+	Time          time.Time `json:"time"`
+	Purpose       string    `json:"purpose"`
+	Method        string    `json:"method"`
+	Endpoint      string    `json:"endpoint"`
+	StatusCode    int       `json:"statusCode,omitempty"`
+	RequestBytes  int64     `json:"requestBytes"`
+	ResponseBytes int64     `json:"responseBytes"`
+	DurationMs    int64     `json:"durationMs"`
+	Error         string    `json:"error,omitempty"`
+}
+
+type auditLog struct {
+	mu      sync.Mutex
+	w       io.WriteCloser
+	enc     *json.Encoder
+	pending []AuditRecord
+}
+
+var audit = &auditLog{}
+
+// EnableAuditLog writes a JSON line for every outbound request to the file at path, including
+// any requests that were made before it was enabled.
+func EnableAuditLog(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("Error opening audit log %s: %w", path, err)
+	}
+
+	audit.mu.Lock()
+	defer audit.mu.Unlock()
+
+	if audit.w != nil {
+		_ = audit.w.Close()
+	}
+
+	audit.w = f
+	audit.enc = json.NewEncoder(f)
+
+	for _, rec := range audit.pending {
+		_ = audit.enc.Encode(rec)
+	}
+	audit.pending = nil
+
+	return nil
+}
+
+// CloseAuditLog closes the audit log file if it is enabled.
+func CloseAuditLog() error {
+	audit.mu.Lock()
+	defer audit.mu.Unlock()
+
+	if audit.w == nil {
+		return nil
+	}
+
+	err := audit.w.Close()
+	audit.w = nil
+	audit.enc = nil
+
+	return err
+}
+
+func (a *auditLog) record(rec AuditRecord) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.enc == nil {
+		if len(a.pending) < maxPendingAuditRecords {
+			a.pending = append(a.pending, rec)
+		}
+		return
+	}
+
+	_ = a.enc.Encode(rec)
+}
+
+// auditTransport records each request it sends in the audit log.
+type auditTransport struct {
+	purpose string
+	base    http.RoundTripper
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	endpoint := redactedEndpoint(req)
+
+	rec := AuditRecord{
+		Time:     start.UTC(),
+		Purpose:  t.purpose,
+		Method:   req.Method,
+		Endpoint: endpoint,
+	}
+	if req.ContentLength > 0 {
+		rec.RequestBytes = req.ContentLength
+	}
+
+	if err := CheckAllowed(t.purpose, endpoint); err != nil {
+		rec.Error = err.Error()
+		audit.record(rec)
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		rec.DurationMs = time.Since(start).Milliseconds()
+		rec.Error = strings.ReplaceAll(err.Error(), req.URL.String(), endpoint)
+		audit.record(rec)
+		return resp, err
+	}
+
+	rec.StatusCode = resp.StatusCode
+	resp.Body = &auditBody{ReadCloser: resp.Body, rec: rec, start: start}
+
+	return resp, nil
+}
+
+// auditBody counts the bytes of a response and records the request once the body is closed,
+// so that the duration includes reading the response.
+type auditBody struct {
+	io.ReadCloser
+	rec   AuditRecord
+	start time.Time
+	once  sync.Once
+}
+
+func (b *auditBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.rec.ResponseBytes += int64(n)
+	return n, err
+}
+
+func (b *auditBody) Close() error {
+	err := b.ReadCloser.Close()
+
+	b.once.Do(func() {
+		b.rec.DurationMs = time.Since(b.start).Milliseconds()
+		audit.record(b.rec)
+	})
+
+	return err
+}
+
+// redactedEndpoint returns the URL of the request without credentials, the query string or the
+// fragment, since these can contain tokens.
+func redactedEndpoint(req *http.Request) string {
+	return redactedURL(req.URL)
+}
+
+func redactedURL(reqURL *url.URL) string {
+	u := *reqURL
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String()
+}
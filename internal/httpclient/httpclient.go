@@ -0,0 +1,82 @@
+// Package httpclient configures the HTTP transport used for all outbound requests, so that the
+// pricing API client, module downloads, remote variables and comment posters all use the same
+// proxy and TLS settings and are recorded in the audit log.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	getter "github.com/hashicorp/go-getter"
+	log "github.com/sirupsen/logrus"
+)
+
+// NewTLSConfig returns the TLS config for outbound requests. If caCertFile is set then its
+// certificates are trusted as well as the system certificates, which is needed on networks that
+// intercept TLS traffic.
+func NewTLSConfig(caCertFile string, insecureSkipVerify *bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{} // nolint: gosec
+
+	if caCertFile != "" {
+		rootCAs, _ := x509.SystemCertPool()
+		if rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		caCerts, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return tlsConfig, fmt.Errorf("Error reading CA cert file %s: %w", caCertFile, err)
+		}
+
+		if !rootCAs.AppendCertsFromPEM(caCerts) {
+			log.Warningf("No CA certs appended, only using system certs")
+		} else {
+			log.Debugf("Loaded CA certs from %s", caCertFile)
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if insecureSkipVerify != nil {
+		tlsConfig.InsecureSkipVerify = *insecureSkipVerify
+	}
+
+	return tlsConfig, nil
+}
+
+// Configure sets the TLS config of http.DefaultTransport and makes sure it uses the proxy from
+// HTTPS_PROXY, HTTP_PROXY and NO_PROXY. The clients returned by NewClient and http.DefaultClient use
+// the default transport. go-getter creates its own HTTP clients for downloading modules, so its
+// HTTP getters are replaced with ones that use the default transport too. Configure should be
+// called once at startup before any requests are made.
+func Configure(caCertFile string, insecureSkipVerify *bool) {
+	tlsConfig, err := NewTLSConfig(caCertFile, insecureSkipVerify)
+	if err != nil {
+		log.Errorf("%s", err)
+	}
+
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.Proxy = http.ProxyFromEnvironment
+		t.TLSClientConfig = tlsConfig
+	}
+
+	// Requests made with the default client, e.g. by libraries that don't let us pass a client,
+	// are still recorded in the audit log.
+	http.DefaultClient.Transport = &auditTransport{purpose: PurposeOther, base: http.DefaultTransport}
+
+	httpGetter := &getter.HttpGetter{
+		Netrc:  true,
+		Client: NewClient(PurposeModuleDownload),
+	}
+	getter.Getters["http"] = httpGetter
+	getter.Getters["https"] = httpGetter
+}
+
+// NewClient returns an HTTP client that uses the configured transport. The purpose is recorded
+// with each request in the audit log.
+func NewClient(purpose string) *http.Client {
+	return &http.Client{Transport: &auditTransport{purpose: purpose, base: http.DefaultTransport}}
+}
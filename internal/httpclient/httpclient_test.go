@@ -0,0 +1,50 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	getter "github.com/hashicorp/go-getter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	insecure := true
+	tlsConfig, err := NewTLSConfig("", &insecure)
+	require.NoError(t, err)
+	assert.True(t, tlsConfig.InsecureSkipVerify)
+	assert.Nil(t, tlsConfig.RootCAs)
+
+	_, err = NewTLSConfig(filepath.Join(t.TempDir(), "missing.pem"), nil)
+	assert.ErrorContains(t, err, "Error reading CA cert file")
+}
+
+func TestConfigure(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(PurposeOther).Get(srv.URL)
+	require.Error(t, err, "the test server's certificate shouldn't be trusted before it's configured")
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	b := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCertFile, b, 0600))
+
+	Configure(caCertFile, nil)
+
+	resp, err := NewClient(PurposeOther).Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	httpGetter, ok := getter.Getters["https"].(*getter.HttpGetter)
+	require.True(t, ok)
+	assert.Equal(t, &auditTransport{purpose: PurposeModuleDownload, base: http.DefaultTransport}, httpGetter.Client.Transport)
+}
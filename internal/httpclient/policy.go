@@ -0,0 +1,138 @@
+package httpclient
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	getter "github.com/hashicorp/go-getter"
+)
+
+// Network policies that limit which outbound requests the CLI makes.
+const (
+	// NetworkPolicyAll allows all outbound requests.
+	NetworkPolicyAll = "all"
+	// NetworkPolicyPricingOnly only allows requests to the Cloud Pricing API. Modules aren't
+	// downloaded, Terraform isn't run and no telemetry is sent.
+	NetworkPolicyPricingOnly = "pricing_only"
+	// NetworkPolicyNone blocks all outbound requests, so prices have to come from a backend that
+	// doesn't need the network, e.g. --pricing-mock.
+	NetworkPolicyNone = "none"
+)
+
+var (
+	networkPolicyMu sync.RWMutex
+	networkPolicy   = NetworkPolicyAll
+)
+
+// NetworkPolicies returns the supported network policies, from least to most strict.
+func NetworkPolicies() []string {
+	return []string{NetworkPolicyAll, NetworkPolicyPricingOnly, NetworkPolicyNone}
+}
+
+// IsValidNetworkPolicy returns true if the policy is one of the supported network policies.
+func IsValidNetworkPolicy(policy string) bool {
+	for _, p := range NetworkPolicies() {
+		if p == policy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetNetworkPolicy sets the policy that all outbound requests are checked against. An empty
+// policy is the same as NetworkPolicyAll. If the policy blocks module downloads then go-getter's
+// getters, which include ones that run git and other binaries, are replaced with ones that fail.
+func SetNetworkPolicy(policy string) error {
+	if policy == "" {
+		policy = NetworkPolicyAll
+	}
+
+	if !IsValidNetworkPolicy(policy) {
+		return fmt.Errorf("Invalid network_policy '%s', must be one of: %s", policy, joinPolicies())
+	}
+
+	networkPolicyMu.Lock()
+	networkPolicy = policy
+	networkPolicyMu.Unlock()
+
+	if !Allowed(PurposeModuleDownload) {
+		for name := range getter.Getters {
+			if name != "file" {
+				getter.Getters[name] = &blockedGetter{}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Allowed returns true if the network policy allows requests with the given purpose.
+func Allowed(purpose string) bool {
+	networkPolicyMu.RLock()
+	defer networkPolicyMu.RUnlock()
+
+	switch networkPolicy {
+	case NetworkPolicyNone:
+		return false
+	case NetworkPolicyPricingOnly:
+		return purpose == PurposePricing
+	}
+
+	return true
+}
+
+// CheckAllowed returns an error if the network policy doesn't allow requests with the given
+// purpose.
+func CheckAllowed(purpose string, target string) error {
+	if Allowed(purpose) {
+		return nil
+	}
+
+	networkPolicyMu.RLock()
+	defer networkPolicyMu.RUnlock()
+
+	return &NetworkPolicyError{Policy: networkPolicy, Purpose: purpose, Target: target}
+}
+
+// NetworkPolicyError is returned for requests that the network policy blocks.
+type NetworkPolicyError struct {
+	Policy  string
+	Purpose string
+	Target  string
+}
+
+func (e *NetworkPolicyError) Error() string {
+	return fmt.Sprintf("%s request to %s blocked by network_policy %s", e.Purpose, e.Target, e.Policy)
+}
+
+func joinPolicies() string {
+	s := ""
+	for i, p := range NetworkPolicies() {
+		if i > 0 {
+			s += ", "
+		}
+		s += p
+	}
+
+	return s
+}
+
+// blockedGetter is a go-getter Getter that fails every download since the network policy
+// blocks module downloads.
+type blockedGetter struct{}
+
+func (g *blockedGetter) ClientMode(u *url.URL) (getter.ClientMode, error) {
+	return getter.ClientModeDir, nil
+}
+
+func (g *blockedGetter) Get(dst string, u *url.URL) error {
+	return CheckAllowed(PurposeModuleDownload, redactedURL(u))
+}
+
+func (g *blockedGetter) GetFile(dst string, u *url.URL) error {
+	return CheckAllowed(PurposeModuleDownload, redactedURL(u))
+}
+
+func (g *blockedGetter) SetClient(c *getter.Client) {}
@@ -0,0 +1,118 @@
+// Package logredact removes confidential values from log lines and diagnostics, e.g. crash
+// reports and error messages, for teams whose variable names, values or module sources are
+// themselves confidential. The rules are set once from the log_redaction config and apply to
+// everything that's written after that.
+package logredact
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Replacement is what redacted values are replaced with.
+const Replacement = "[REDACTED]"
+
+var (
+	rulesMu sync.RWMutex
+	rules   []*regexp.Regexp
+)
+
+// Configure sets the rules that are redacted. Patterns are regular expressions, and keywords are
+// matched literally. Calling it again replaces the previous rules.
+func Configure(patterns []string, keywords []string) error {
+	compiled := make([]*regexp.Regexp, 0, len(patterns)+len(keywords))
+
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("Invalid log redaction pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	for _, k := range keywords {
+		if k == "" {
+			continue
+		}
+		compiled = append(compiled, regexp.MustCompile(regexp.QuoteMeta(k)))
+	}
+
+	rulesMu.Lock()
+	rules = compiled
+	rulesMu.Unlock()
+
+	return nil
+}
+
+// String returns s with all the matches of the rules replaced.
+func String(s string) string {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	for _, re := range rules {
+		s = re.ReplaceAllLiteralString(s, Replacement)
+	}
+
+	return s
+}
+
+// Bytes returns b with all the matches of the rules replaced.
+func Bytes(b []byte) []byte {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	for _, re := range rules {
+		b = re.ReplaceAllLiteral(b, []byte(Replacement))
+	}
+
+	return b
+}
+
+// Enabled returns true if any rules are set.
+func Enabled() bool {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	return len(rules) > 0
+}
+
+// Formatter redacts the log lines formatted by the underlying formatter.
+type Formatter struct {
+	Formatter logrus.Formatter
+}
+
+func (f *Formatter) Format(entry *logrus.Entry) ([]byte, error) {
+	b, err := f.Formatter.Format(entry)
+	if err != nil {
+		return b, err
+	}
+
+	return Bytes(b), nil
+}
+
+type writer struct {
+	w io.Writer
+}
+
+// NewWriter returns a writer that redacts each write before writing it to w. Matches are only
+// found within a single write, which is a whole message for the ui print functions.
+func NewWriter(w io.Writer) io.Writer {
+	return &writer{w: w}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !Enabled() {
+		return w.w.Write(p)
+	}
+
+	_, err := w.w.Write(Bytes(p))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,47 @@
+package logredact
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NoError(t, Configure([]string{`acme-[a-z]+\.internal`}, []string{"db_root_password", ""}))
+	defer func() { _ = Configure(nil, nil) }()
+
+	assert.Equal(t,
+		"Failed to download git.[REDACTED]/modules/vpc, input variable [REDACTED] is not set",
+		String("Failed to download git.acme-infra.internal/modules/vpc, input variable db_root_password is not set"),
+	)
+
+	require.NoError(t, Configure(nil, nil))
+	assert.Equal(t, "db_root_password", String("db_root_password"))
+}
+
+func TestConfigureInvalidPattern(t *testing.T) {
+	err := Configure([]string{"acme-("}, nil)
+	assert.EqualError(t, err, "Invalid log redaction pattern \"acme-(\": error parsing regexp: missing closing ): `acme-(`")
+}
+
+func TestWriterAndFormatter(t *testing.T) {
+	require.NoError(t, Configure(nil, []string{"secret-module"}))
+	defer func() { _ = Configure(nil, nil) }()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	n, err := w.Write([]byte("Warning: secret-module could not be parsed\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 43, n)
+	assert.Equal(t, "Warning: [REDACTED] could not be parsed\n", buf.String())
+
+	logger := logrus.New()
+	buf.Reset()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&Formatter{Formatter: &logrus.TextFormatter{DisableTimestamp: true}})
+	logger.Infof("loading secret-module")
+	assert.Equal(t, "level=info msg=\"loading [REDACTED]\"\n", buf.String())
+}
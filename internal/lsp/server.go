@@ -0,0 +1,598 @@
+// Package lsp implements a Language Server Protocol server that shows the cost of Terraform
+// resources in editors. It shows the monthly cost of each resource and module block as a code
+// lens, a breakdown of the cost when hovering over a block, and policy failures as diagnostics.
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/version"
+)
+
+// DefaultDebounce is how long the server waits after a document changes before estimating its
+// directory again, so it isn't estimated on every keystroke.
+const DefaultDebounce = 500 * time.Millisecond
+
+var (
+	topLevelBlockReg = regexp.MustCompile(`^[A-Za-z]`)
+	blockEndReg      = regexp.MustCompile(`^}`)
+)
+
+// Estimate is the cost estimate of a Terraform directory.
+type Estimate struct {
+	Currency  string
+	Resources []*schema.Resource
+	// PolicyFailures are the messages of failed policies keyed by the name of the resource they
+	// are for. Failures that aren't for a resource are keyed by an empty string.
+	PolicyFailures map[string][]string
+	// FileErrors are the problems with files in the directory that meant they were skipped when it
+	// was parsed, e.g. a syntax error or a file that made the parser panic.
+	FileErrors []FileError
+}
+
+// FileError is a problem with a file that meant it was skipped when its directory was estimated.
+type FileError struct {
+	Path string
+	// Line is the 1-based line of the file that the problem is on, or zero if it's for the whole
+	// file.
+	Line    int
+	Message string
+}
+
+// EstimateFunc estimates the Terraform directory dir. overrides has the contents of the files in
+// the directory that are open in the editor, keyed by path, which should be used instead of the
+// files on disk since they might not be saved.
+type EstimateFunc func(ctx context.Context, dir string, overrides map[string][]byte) (*Estimate, error)
+
+type document struct {
+	uri  string
+	path string
+	text string
+}
+
+type dirEstimate struct {
+	estimate *Estimate
+	err      error
+}
+
+// Server is a Language Server Protocol server. Only the open documents' directories are
+// estimated, and only the directory of a document that changed is estimated again.
+type Server struct {
+	estimate EstimateFunc
+	debounce time.Duration
+
+	conn *conn
+	ctx  context.Context
+
+	// estimateMu makes sure only one directory is estimated at a time.
+	estimateMu sync.Mutex
+
+	mu        sync.Mutex
+	docs      map[string]*document
+	estimates map[string]*dirEstimate
+	timers    map[string]*time.Timer
+}
+
+// NewServer returns a server that uses estimate to get the cost of the Terraform directories.
+func NewServer(estimate EstimateFunc, debounce time.Duration) *Server {
+	return &Server{
+		estimate:  estimate,
+		debounce:  debounce,
+		docs:      map[string]*document{},
+		estimates: map[string]*dirEstimate{},
+		timers:    map[string]*time.Timer{},
+	}
+}
+
+// Serve reads requests from in and writes responses to out until the client sends the exit
+// notification, in closes or ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.ctx = ctx
+	s.conn = newConn(in, out)
+
+	defer func() {
+		s.mu.Lock()
+		for _, t := range s.timers {
+			t.Stop()
+		}
+		s.mu.Unlock()
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		body, err := s.conn.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var msg message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			if err := s.conn.replyError(nil, codeParseError, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handle handles a message and returns an error if the response couldn't be written.
+func (s *Server) handle(msg message) error {
+	isRequest := msg.ID != nil
+
+	var result interface{}
+	var err error
+
+	switch msg.Method {
+	case "initialize":
+		result = s.initialize()
+	case "shutdown":
+		result = nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			err = s.didOpen(params)
+		}
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			s.didChange(params)
+		}
+	case "textDocument/didSave":
+		var params didSaveParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			s.didSave(params)
+		}
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			err = s.didClose(params)
+		}
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			result = s.hover(params)
+		}
+	case "textDocument/codeLens":
+		var params codeLensParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			result = s.codeLens(params)
+		}
+	default:
+		if isRequest {
+			return s.conn.replyError(msg.ID, codeMethodNotFound, "Method not found: "+msg.Method)
+		}
+		// Notifications that aren't supported, such as initialized and $/cancelRequest, are ignored.
+		return nil
+	}
+
+	if err != nil {
+		log.Debugf("Error handling %s: %s", msg.Method, err)
+
+		if !isRequest {
+			return nil
+		}
+
+		code := codeInternalError
+		var jsonErr *json.UnmarshalTypeError
+		if errors.As(err, &jsonErr) {
+			code = codeInvalidParams
+		}
+		return s.conn.replyError(msg.ID, code, err.Error())
+	}
+
+	if !isRequest {
+		return nil
+	}
+
+	return s.conn.reply(msg.ID, result)
+}
+
+func (s *Server) initialize() initializeResult {
+	var result initializeResult
+
+	result.Capabilities.TextDocumentSync.OpenClose = true
+	result.Capabilities.TextDocumentSync.Change = textDocumentSyncFull
+	result.Capabilities.TextDocumentSync.Save = true
+	result.Capabilities.HoverProvider = true
+	result.ServerInfo = serverInfo{Name: "infracost", Version: version.Version}
+
+	return result
+}
+
+func (s *Server) didOpen(params didOpenParams) error {
+	path, err := uriToPath(params.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.docs[params.TextDocument.URI] = &document{uri: params.TextDocument.URI, path: path, text: params.TextDocument.Text}
+	_, estimated := s.estimates[filepath.Dir(path)]
+	s.mu.Unlock()
+
+	if estimated {
+		// The directory was estimated for another open document, so only the diagnostics of
+		// this document need to be published.
+		s.publishDiagnostics(filepath.Dir(path))
+		return nil
+	}
+
+	s.scheduleEstimate(filepath.Dir(path), 0)
+	return nil
+}
+
+func (s *Server) didChange(params didChangeParams) {
+	if len(params.ContentChanges) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	doc, ok := s.docs[params.TextDocument.URI]
+	if ok {
+		// The server only supports full document sync, so the last change has the whole text.
+		doc.text = params.ContentChanges[len(params.ContentChanges)-1].Text
+	}
+	s.mu.Unlock()
+
+	if ok {
+		s.scheduleEstimate(filepath.Dir(doc.path), s.debounce)
+	}
+}
+
+func (s *Server) didSave(params didSaveParams) {
+	s.mu.Lock()
+	doc, ok := s.docs[params.TextDocument.URI]
+	if ok && params.Text != nil {
+		doc.text = *params.Text
+	}
+	s.mu.Unlock()
+
+	if ok {
+		s.scheduleEstimate(filepath.Dir(doc.path), 0)
+	}
+}
+
+func (s *Server) didClose(params didCloseParams) error {
+	s.mu.Lock()
+	doc, ok := s.docs[params.TextDocument.URI]
+	delete(s.docs, params.TextDocument.URI)
+	s.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: doc.uri, Diagnostics: []diagnostic{}})
+}
+
+func (s *Server) hover(params textDocumentPositionParams) *hover {
+	doc, est := s.documentEstimate(params.TextDocument.URI)
+	if doc == nil || est == nil {
+		return nil
+	}
+
+	addr, line := blockAt(doc, params.Position.Line)
+	if addr == "" {
+		return nil
+	}
+
+	resources := blockResources(est, addr)
+	if len(resources) == 0 {
+		return nil
+	}
+
+	return &hover{
+		Contents: markupContent{Kind: "markdown", Value: output.FormatCostHover(est.Currency, addr, resources)},
+		Range:    lineRange(doc, line),
+	}
+}
+
+func (s *Server) codeLens(params codeLensParams) []codeLens {
+	lenses := []codeLens{}
+
+	doc, est := s.documentEstimate(params.TextDocument.URI)
+	if doc == nil || est == nil {
+		return lenses
+	}
+
+	for _, addr := range sortedBlockAddresses(doc) {
+		loc := terraform.SourceBlockLocations(doc.path, []byte(doc.text))[addr]
+
+		title := output.FormatCostLens(est.Currency, blockResources(est, addr))
+		if title == "" {
+			continue
+		}
+
+		lenses = append(lenses, codeLens{
+			Range:   *lineRange(doc, loc.Line-1),
+			Command: &command{Title: title},
+		})
+	}
+
+	return lenses
+}
+
+// documentEstimate returns a copy of the open document and the estimate of its directory. The
+// directory is estimated if it hasn't been already. The estimate is nil if it failed.
+func (s *Server) documentEstimate(uri string) (*document, *Estimate) {
+	s.mu.Lock()
+	doc, ok := s.docs[uri]
+	var docCopy document
+	if ok {
+		docCopy = *doc
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, nil
+	}
+
+	dir := filepath.Dir(docCopy.path)
+
+	s.mu.Lock()
+	e, ok := s.estimates[dir]
+	s.mu.Unlock()
+
+	if !ok {
+		e = s.runEstimate(dir)
+	}
+
+	return &docCopy, e.estimate
+}
+
+// scheduleEstimate estimates dir after the delay and publishes the diagnostics of its open
+// documents. Any estimate of dir that's already scheduled is replaced.
+func (s *Server) scheduleEstimate(dir string, delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.timers[dir]; ok {
+		t.Stop()
+	}
+
+	s.timers[dir] = time.AfterFunc(delay, func() {
+		s.runEstimate(dir)
+		s.publishDiagnostics(dir)
+	})
+}
+
+func (s *Server) runEstimate(dir string) *dirEstimate {
+	s.estimateMu.Lock()
+	defer s.estimateMu.Unlock()
+
+	overrides := map[string][]byte{}
+
+	s.mu.Lock()
+	for _, doc := range s.docs {
+		if filepath.Dir(doc.path) == dir {
+			overrides[doc.path] = []byte(doc.text)
+		}
+	}
+	s.mu.Unlock()
+
+	est, err := s.safeEstimate(dir, overrides)
+	if err != nil {
+		log.Debugf("Error estimating %s: %s", dir, err)
+	}
+
+	e := &dirEstimate{estimate: est, err: err}
+
+	s.mu.Lock()
+	s.estimates[dir] = e
+	s.mu.Unlock()
+
+	return e
+}
+
+// safeEstimate estimates dir, converting a panic into an error so a pathological file can't take
+// down the server.
+func (s *Server) safeEstimate(dir string, overrides map[string][]byte) (est *Estimate, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			est, err = nil, fmt.Errorf("estimate panicked: %v", r)
+		}
+	}()
+
+	return s.estimate(s.ctx, dir, overrides)
+}
+
+// publishDiagnostics sends the diagnostics for each open document in dir. Policy failures are
+// shown on the block of the resource they're for, or on the first line of the first document if
+// they aren't for a resource. If the directory couldn't be estimated the error is shown on the
+// first line of each document. Files that were skipped when the directory was parsed have their
+// error shown on the line it's on.
+func (s *Server) publishDiagnostics(dir string) {
+	s.mu.Lock()
+	e := s.estimates[dir]
+	var docs []document
+	for _, doc := range s.docs {
+		if filepath.Dir(doc.path) == dir {
+			docs = append(docs, *doc)
+		}
+	}
+	s.mu.Unlock()
+
+	if e == nil || len(docs) == 0 {
+		return
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].path < docs[j].path
+	})
+
+	diagnostics := make(map[string][]diagnostic, len(docs))
+	for _, doc := range docs {
+		diagnostics[doc.uri] = []diagnostic{}
+	}
+
+	if e.err != nil {
+		for _, doc := range docs {
+			diagnostics[doc.uri] = append(diagnostics[doc.uri], diagnostic{
+				Range:    *lineRange(&doc, 0),
+				Severity: severityError,
+				Source:   "infracost",
+				Message:  "Could not estimate costs: " + e.err.Error(),
+			})
+		}
+	} else if e.estimate != nil {
+		for _, fileErr := range e.estimate.FileErrors {
+			for i := range docs {
+				if docs[i].path != fileErr.Path {
+					continue
+				}
+
+				line := 0
+				if fileErr.Line > 0 {
+					line = fileErr.Line - 1
+				}
+
+				diagnostics[docs[i].uri] = append(diagnostics[docs[i].uri], diagnostic{
+					Range:    *lineRange(&docs[i], line),
+					Severity: severityError,
+					Source:   "infracost",
+					Message:  "File was skipped: " + fileErr.Message,
+				})
+			}
+		}
+
+		resourceNames := make([]string, 0, len(e.estimate.PolicyFailures))
+		for name := range e.estimate.PolicyFailures {
+			resourceNames = append(resourceNames, name)
+		}
+		sort.Strings(resourceNames)
+
+		for _, name := range resourceNames {
+			doc, line := &docs[0], 0
+
+			if name != "" {
+				addr := terraform.BlockAddress(name)
+				doc = nil
+				for i := range docs {
+					if loc, ok := terraform.SourceBlockLocations(docs[i].path, []byte(docs[i].text))[addr]; ok {
+						doc, line = &docs[i], loc.Line-1
+						break
+					}
+				}
+				if doc == nil {
+					continue
+				}
+			}
+
+			for _, msg := range e.estimate.PolicyFailures[name] {
+				diagnostics[doc.uri] = append(diagnostics[doc.uri], diagnostic{
+					Range:    *lineRange(doc, line),
+					Severity: severityWarning,
+					Source:   "infracost",
+					Message:  msg,
+				})
+			}
+		}
+	}
+
+	for _, doc := range docs {
+		err := s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: doc.uri, Diagnostics: diagnostics[doc.uri]})
+		if err != nil {
+			log.Debugf("Error publishing diagnostics for %s: %s", doc.uri, err)
+		}
+	}
+}
+
+// blockAt returns the address of the resource or module block that contains the 0-based line,
+// and the line the block starts on. The block is the last one that starts on or before the line,
+// as long as it hasn't ended and no other top-level block starts in between.
+func blockAt(doc *document, line int) (string, int) {
+	locations := terraform.SourceBlockLocations(doc.path, []byte(doc.text))
+
+	addr := ""
+	start := -1
+	for a, loc := range locations {
+		if loc.Line-1 <= line && loc.Line-1 > start {
+			addr, start = a, loc.Line-1
+		}
+	}
+
+	if addr == "" {
+		return "", 0
+	}
+
+	lines := strings.Split(doc.text, "\n")
+	for i := start + 1; i <= line && i < len(lines); i++ {
+		if topLevelBlockReg.MatchString(lines[i]) || (i < line && blockEndReg.MatchString(lines[i])) {
+			return "", 0
+		}
+	}
+
+	return addr, start
+}
+
+func sortedBlockAddresses(doc *document) []string {
+	locations := terraform.SourceBlockLocations(doc.path, []byte(doc.text))
+
+	addrs := make([]string, 0, len(locations))
+	for addr := range locations {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return locations[addrs[i]].Line < locations[addrs[j]].Line
+	})
+
+	return addrs
+}
+
+// blockResources returns the estimated resources defined by the block with the given address.
+func blockResources(est *Estimate, addr string) []*schema.Resource {
+	var resources []*schema.Resource
+
+	for _, r := range est.Resources {
+		if terraform.BlockAddress(r.Name) == addr {
+			resources = append(resources, r)
+		}
+	}
+
+	return resources
+}
+
+// lineRange returns the range of the whole 0-based line of the document.
+func lineRange(doc *document, line int) *lspRange {
+	lines := strings.Split(doc.text, "\n")
+
+	length := 0
+	if line < len(lines) {
+		length = len([]rune(strings.TrimRight(lines[line], "\r")))
+	}
+
+	return &lspRange{
+		Start: position{Line: line},
+		End:   position{Line: line, Character: length},
+	}
+}
@@ -0,0 +1,322 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const testMainTF = `resource "aws_instance" "web" {
+  instance_type = "m5.large"
+}
+
+module "db" {
+  source = "./modules/db"
+}
+`
+
+type testClient struct {
+	t      *testing.T
+	conn   *conn
+	nextID int
+}
+
+func newTestClient(t *testing.T, estimate EstimateFunc) *testClient {
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+
+	s := NewServer(estimate, 10*time.Millisecond)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(context.Background(), serverR, serverW)
+		serverW.Close()
+	}()
+
+	t.Cleanup(func() {
+		clientW.Close()
+		require.NoError(t, <-done)
+	})
+
+	return &testClient{t: t, conn: newConn(clientR, clientW)}
+}
+
+func (c *testClient) notify(method string, params interface{}) {
+	require.NoError(c.t, c.conn.notify(method, params))
+}
+
+// request sends a request and returns the raw result of its response, skipping any
+// notifications sent before it.
+func (c *testClient) request(method string, params interface{}) json.RawMessage {
+	c.nextID++
+	id := json.RawMessage(fmt.Sprint(c.nextID))
+
+	b, err := json.Marshal(params)
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.conn.write(message{JSONRPC: "2.0", ID: id, Method: method, Params: b}))
+
+	for {
+		msg := c.read()
+		if msg.Method != "" {
+			continue
+		}
+
+		var resp struct {
+			ID     json.RawMessage `json:"id"`
+			Result json.RawMessage `json:"result"`
+			Error  *responseError  `json:"error"`
+		}
+		require.NoError(c.t, json.Unmarshal(msg.Raw, &resp))
+		require.Equal(c.t, string(id), string(resp.ID))
+		require.Nil(c.t, resp.Error)
+
+		return resp.Result
+	}
+}
+
+// waitForDiagnostics returns the next diagnostics published for the URI.
+func (c *testClient) waitForDiagnostics(uri string) []diagnostic {
+	for {
+		msg := c.read()
+		if msg.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+
+		var n struct {
+			Params publishDiagnosticsParams `json:"params"`
+		}
+		require.NoError(c.t, json.Unmarshal(msg.Raw, &n))
+
+		if n.Params.URI == uri {
+			return n.Params.Diagnostics
+		}
+	}
+}
+
+type testMessage struct {
+	Method string
+	Raw    []byte
+}
+
+func (c *testClient) read() testMessage {
+	type result struct {
+		body []byte
+		err  error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		body, err := c.conn.read()
+		ch <- result{body, err}
+	}()
+
+	select {
+	case r := <-ch:
+		require.NoError(c.t, r.err)
+
+		var msg message
+		require.NoError(c.t, json.Unmarshal(r.body, &msg))
+		return testMessage{Method: msg.Method, Raw: r.body}
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("Timed out waiting for a message from the server")
+		return testMessage{}
+	}
+}
+
+func testEstimate(calls *int32) EstimateFunc {
+	return func(ctx context.Context, dir string, overrides map[string][]byte) (*Estimate, error) {
+		atomic.AddInt32(calls, 1)
+
+		if _, ok := overrides[filepath.Join(dir, "main.tf")]; !ok {
+			return nil, errors.New("main.tf was not overridden")
+		}
+
+		webCost := decimal.NewFromFloat(70.08)
+		volumeCost := decimal.NewFromFloat(12.5)
+		dbCost := decimal.NewFromFloat(100)
+
+		return &Estimate{
+			Currency: "USD",
+			Resources: []*schema.Resource{
+				{
+					Name:        "aws_instance.web",
+					MonthlyCost: &webCost,
+					CostComponents: []*schema.CostComponent{
+						{Name: "Instance usage (Linux/UNIX, on-demand, m5.large)", Unit: "hours", MonthlyQuantity: decimalPtr(730), MonthlyCost: &webCost},
+					},
+				},
+				{Name: "module.db.aws_db_instance.db", MonthlyCost: &dbCost},
+				{Name: "module.db.aws_ebs_volume.data", MonthlyCost: &volumeCost},
+			},
+			PolicyFailures: map[string][]string{
+				"aws_instance.web": {"Instances must use m6 instance types"},
+				"":                 {"Total cost must be below $100"},
+			},
+		}, nil
+	}
+}
+
+func decimalPtr(f float64) *decimal.Decimal {
+	d := decimal.NewFromFloat(f)
+	return &d
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	uri := "file://" + filepath.ToSlash(filepath.Join(dir, "main.tf"))
+
+	var calls int32
+	c := newTestClient(t, testEstimate(&calls))
+
+	var initResult initializeResult
+	require.NoError(t, json.Unmarshal(c.request("initialize", map[string]interface{}{}), &initResult))
+	assert.True(t, initResult.Capabilities.HoverProvider)
+	assert.Equal(t, textDocumentSyncFull, initResult.Capabilities.TextDocumentSync.Change)
+	assert.Equal(t, "infracost", initResult.ServerInfo.Name)
+
+	c.notify("initialized", map[string]interface{}{})
+	c.notify("textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: uri, Text: testMainTF}})
+
+	diagnostics := c.waitForDiagnostics(uri)
+	assert.Equal(t, []diagnostic{
+		{
+			Range:    lspRange{Start: position{Line: 0}, End: position{Line: 0, Character: 31}},
+			Severity: severityWarning,
+			Source:   "infracost",
+			Message:  "Total cost must be below $100",
+		},
+		{
+			Range:    lspRange{Start: position{Line: 0}, End: position{Line: 0, Character: 31}},
+			Severity: severityWarning,
+			Source:   "infracost",
+			Message:  "Instances must use m6 instance types",
+		},
+	}, diagnostics)
+
+	var lenses []codeLens
+	require.NoError(t, json.Unmarshal(c.request("textDocument/codeLens", codeLensParams{TextDocument: textDocumentIdentifier{URI: uri}}), &lenses))
+	require.Len(t, lenses, 2)
+	assert.Equal(t, 0, lenses[0].Range.Start.Line)
+	assert.Equal(t, "$70.08/mo", lenses[0].Command.Title)
+	assert.Equal(t, 4, lenses[1].Range.Start.Line)
+	assert.Equal(t, "$112.50/mo across 2 resources", lenses[1].Command.Title)
+
+	var h hover
+	require.NoError(t, json.Unmarshal(c.request("textDocument/hover", textDocumentPositionParams{TextDocument: textDocumentIdentifier{URI: uri}, Position: position{Line: 1, Character: 4}}), &h))
+	assert.Equal(t, "markdown", h.Contents.Kind)
+	assert.Contains(t, h.Contents.Value, "**aws_instance.web**: $70.08/mo")
+	assert.Contains(t, h.Contents.Value, "| Instance usage (Linux/UNIX, on-demand, m5.large) | 730 | hours | $70.08 |")
+
+	result := c.request("textDocument/hover", textDocumentPositionParams{TextDocument: textDocumentIdentifier{URI: uri}, Position: position{Line: 3, Character: 0}})
+	assert.Equal(t, "null", string(result))
+
+	c.notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   textDocumentIdentifier{URI: uri},
+		"contentChanges": []map[string]string{{"text": "\n" + testMainTF}},
+	})
+
+	diagnostics = c.waitForDiagnostics(uri)
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, 1, diagnostics[1].Range.Start.Line)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	c.notify("textDocument/didClose", didCloseParams{TextDocument: textDocumentIdentifier{URI: uri}})
+	assert.Empty(t, c.waitForDiagnostics(uri))
+
+	assert.Equal(t, "null", string(c.request("shutdown", nil)))
+	c.notify("exit", nil)
+}
+
+func TestServerEstimateError(t *testing.T) {
+	dir := t.TempDir()
+	uri := "file://" + filepath.ToSlash(filepath.Join(dir, "main.tf"))
+
+	c := newTestClient(t, func(ctx context.Context, dir string, overrides map[string][]byte) (*Estimate, error) {
+		return nil, errors.New("Invalid HCL")
+	})
+
+	c.request("initialize", map[string]interface{}{})
+	c.notify("textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: uri, Text: testMainTF}})
+
+	diagnostics := c.waitForDiagnostics(uri)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, severityError, diagnostics[0].Severity)
+	assert.Equal(t, "Could not estimate costs: Invalid HCL", diagnostics[0].Message)
+
+	var lenses []codeLens
+	require.NoError(t, json.Unmarshal(c.request("textDocument/codeLens", codeLensParams{TextDocument: textDocumentIdentifier{URI: uri}}), &lenses))
+	assert.Empty(t, lenses)
+}
+
+func TestServerEstimatePanic(t *testing.T) {
+	dir := t.TempDir()
+	uri := "file://" + filepath.ToSlash(filepath.Join(dir, "main.tf"))
+
+	c := newTestClient(t, func(ctx context.Context, dir string, overrides map[string][]byte) (*Estimate, error) {
+		panic("index out of range")
+	})
+
+	c.request("initialize", map[string]interface{}{})
+	c.notify("textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: uri, Text: testMainTF}})
+
+	diagnostics := c.waitForDiagnostics(uri)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "Could not estimate costs: estimate panicked: index out of range", diagnostics[0].Message)
+}
+
+func TestServerFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.tf")
+	uri := "file://" + filepath.ToSlash(path)
+
+	c := newTestClient(t, func(ctx context.Context, dir string, overrides map[string][]byte) (*Estimate, error) {
+		return &Estimate{
+			Currency:   "USD",
+			FileErrors: []FileError{{Path: path, Line: 5, Message: "Invalid expression"}},
+		}, nil
+	})
+
+	c.request("initialize", map[string]interface{}{})
+	c.notify("textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: uri, Text: testMainTF}})
+
+	assert.Equal(t, []diagnostic{
+		{
+			Range:    lspRange{Start: position{Line: 4}, End: position{Line: 4, Character: 13}},
+			Severity: severityError,
+			Source:   "infracost",
+			Message:  "File was skipped: Invalid expression",
+		},
+	}, c.waitForDiagnostics(uri))
+}
+
+func TestBlockAt(t *testing.T) {
+	doc := &document{path: "main.tf", text: testMainTF}
+
+	tests := []struct {
+		line      int
+		wantAddr  string
+		wantStart int
+	}{
+		{0, "aws_instance.web", 0},
+		{2, "aws_instance.web", 0},
+		{3, "", 0},
+		{5, "module.db", 4},
+	}
+
+	for _, tt := range tests {
+		addr, start := blockAt(doc, tt.line)
+		assert.Equal(t, tt.wantAddr, addr, "line %d", tt.line)
+		assert.Equal(t, tt.wantStart, start, "line %d", tt.line)
+	}
+}
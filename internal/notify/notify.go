@@ -0,0 +1,129 @@
+// Package notify sends digests of cost changes to chat and webhook destinations, e.g. for
+// scheduled reports that run from cron.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/httpclient"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// Digest is a summary of how costs changed since the previous report.
+type Digest struct {
+	// Title is a one line summary of the change, e.g. "Monthly cost will increase by $40".
+	Title string
+	// Root is the current estimate compared to the previous snapshot.
+	Root output.Root
+}
+
+// Notifier sends a digest to a destination.
+type Notifier interface {
+	Notify(ctx context.Context, d Digest) error
+}
+
+// SlackNotifier posts the digest to a Slack incoming webhook, formatted like the slack-message
+// output format.
+type SlackNotifier struct {
+	WebhookURL string
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, d Digest) error {
+	body, err := output.ToSlackMessage(d.Root, output.Options{})
+	if err != nil {
+		return err
+	}
+
+	return post(ctx, n.WebhookURL, body)
+}
+
+// WebhookNotifier posts the digest as JSON to a URL, for sending it on to email or other
+// destinations.
+type WebhookNotifier struct {
+	URL string
+}
+
+// WebhookPayload is the JSON body posted by WebhookNotifier.
+type WebhookPayload struct {
+	Title                string           `json:"title"`
+	Currency             string           `json:"currency"`
+	PastTotalMonthlyCost *decimal.Decimal `json:"pastTotalMonthlyCost"`
+	TotalMonthlyCost     *decimal.Decimal `json:"totalMonthlyCost"`
+	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
+	Projects             []WebhookProject `json:"projects"`
+}
+
+// WebhookProject is the cost change of a project in WebhookPayload.
+type WebhookProject struct {
+	Name            string           `json:"name"`
+	PastMonthlyCost *decimal.Decimal `json:"pastMonthlyCost"`
+	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
+	DiffMonthlyCost *decimal.Decimal `json:"diffMonthlyCost"`
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, d Digest) error {
+	payload := WebhookPayload{
+		Title:                d.Title,
+		Currency:             d.Root.Currency,
+		PastTotalMonthlyCost: d.Root.PastTotalMonthlyCost,
+		TotalMonthlyCost:     d.Root.TotalMonthlyCost,
+		DiffTotalMonthlyCost: d.Root.DiffTotalMonthlyCost,
+		Projects:             make([]WebhookProject, 0, len(d.Root.Projects)),
+	}
+
+	for _, p := range d.Root.Projects {
+		wp := WebhookProject{Name: p.Name}
+		if p.PastBreakdown != nil {
+			wp.PastMonthlyCost = p.PastBreakdown.TotalMonthlyCost
+		}
+		if p.Breakdown != nil {
+			wp.MonthlyCost = p.Breakdown.TotalMonthlyCost
+		}
+		if p.Diff != nil {
+			wp.DiffMonthlyCost = p.Diff.TotalMonthlyCost
+		}
+		payload.Projects = append(payload.Projects, wp)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return post(ctx, n.URL, body)
+}
+
+func post(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpclient.NewClient(httpclient.PurposeNotification).Do(req)
+	if err != nil {
+		// Don't include the URL in the error since webhook URLs contain secrets.
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("Error sending notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Error sending notification: %s %s", resp.Status, bytes.TrimSpace(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var payload WebhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &payload))
+	}))
+	defer srv.Close()
+
+	past := decimal.NewFromInt(100)
+	cost := decimal.NewFromInt(150)
+	diff := decimal.NewFromInt(50)
+
+	d := Digest{
+		Title: "Monthly cost will increase by $50",
+		Root: output.Root{
+			Currency:             "USD",
+			PastTotalMonthlyCost: &past,
+			TotalMonthlyCost:     &cost,
+			DiffTotalMonthlyCost: &diff,
+			Projects: []output.Project{
+				{
+					Name:          "my-project",
+					PastBreakdown: &output.Breakdown{TotalMonthlyCost: &past},
+					Breakdown:     &output.Breakdown{TotalMonthlyCost: &cost},
+					Diff:          &output.Breakdown{TotalMonthlyCost: &diff},
+				},
+			},
+		},
+	}
+
+	n := &WebhookNotifier{URL: srv.URL}
+	require.NoError(t, n.Notify(context.Background(), d))
+
+	assert.Equal(t, "Monthly cost will increase by $50", payload.Title)
+	require.Len(t, payload.Projects, 1)
+	assert.Equal(t, "my-project", payload.Projects[0].Name)
+	assert.Equal(t, "50", payload.Projects[0].DiffMonthlyCost.String())
+}
+
+func TestNotifierError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("no_service\n"))
+	}))
+	defer srv.Close()
+
+	n := &SlackNotifier{WebhookURL: srv.URL + "/services/SECRET"}
+	err := n.Notify(context.Background(), Digest{Root: output.Root{Currency: "USD"}})
+	assert.EqualError(t, err, "Error sending notification: 404 Not Found no_service")
+}
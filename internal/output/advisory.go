@@ -0,0 +1,72 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Advisory shows how much could be saved each month by replacing a resource with a cheaper alternative.
+type Advisory struct {
+	ResourceName           string           `json:"resourceName"`
+	Alternative            string           `json:"alternative"`
+	MonthlyCost            *decimal.Decimal `json:"monthlyCost"`
+	AlternativeMonthlyCost *decimal.Decimal `json:"alternativeMonthlyCost"`
+	MonthlySavings         *decimal.Decimal `json:"monthlySavings"`
+}
+
+// newAdvisories returns the advisories for the alternatives of the given resources. Alternatives
+// that wouldn't save anything are left out.
+func newAdvisories(resources []*schema.Resource) []Advisory {
+	var advisories []Advisory
+
+	for _, r := range resources {
+		if r.MonthlyCost == nil {
+			continue
+		}
+
+		for _, alt := range r.Alternatives {
+			if alt.MonthlyCost == nil {
+				continue
+			}
+
+			savings := r.MonthlyCost.Sub(*alt.MonthlyCost)
+			if !savings.IsPositive() {
+				continue
+			}
+
+			advisories = append(advisories, Advisory{
+				ResourceName:           r.Name,
+				Alternative:            alt.Name,
+				MonthlyCost:            r.MonthlyCost,
+				AlternativeMonthlyCost: alt.MonthlyCost,
+				MonthlySavings:         decimalPtr(savings),
+			})
+		}
+	}
+
+	sort.SliceStable(advisories, func(i, j int) bool {
+		return advisories[i].ResourceName < advisories[j].ResourceName
+	})
+
+	return advisories
+}
+
+func formatAdvisories(currency string, advisories []Advisory) string {
+	s := fmt.Sprintf("\n%s\n", ui.BoldString("Advisories:"))
+
+	for _, a := range advisories {
+		s += fmt.Sprintf("  %s: %s would cost %s/month %s\n",
+			a.ResourceName,
+			a.Alternative,
+			formatCost2DP(currency, a.AlternativeMonthlyCost),
+			ui.FaintStringf("(saving %s/month)", formatCost2DP(currency, a.MonthlySavings)),
+		)
+	}
+
+	return s
+}
@@ -0,0 +1,34 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestNewAdvisories(t *testing.T) {
+	resources := []*schema.Resource{
+		{
+			Name:        "aws_nat_gateway.main",
+			MonthlyCost: decimalPtr(decimal.NewFromInt(40)),
+			Alternatives: []*schema.Resource{
+				{Name: "NAT instance (t4g.nano)", MonthlyCost: decimalPtr(decimal.NewFromInt(4))},
+				{Name: "More expensive", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+				{Name: "Unpriced"},
+			},
+		},
+		{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+	}
+
+	advisories := newAdvisories(resources)
+	require.Len(t, advisories, 1)
+	assert.Equal(t, "aws_nat_gateway.main", advisories[0].ResourceName)
+	assert.Equal(t, "NAT instance (t4g.nano)", advisories[0].Alternative)
+	assert.Equal(t, "36", advisories[0].MonthlySavings.String())
+
+	assert.Nil(t, newAdvisories(nil))
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Budget shows how much of a project's monthly budget is consumed by its estimated monthly cost.
+type Budget struct {
+	MonthlyBudget  *decimal.Decimal `json:"monthlyBudget"`
+	PercentageUsed *decimal.Decimal `json:"percentageUsed"`
+	Remaining      *decimal.Decimal `json:"remaining"`
+	Exceeded       bool             `json:"exceeded"`
+}
+
+// newBudget returns the budget burn-down for the given monthly budget and estimated monthly cost.
+// It returns nil if there is no budget set.
+func newBudget(monthlyBudget *decimal.Decimal, monthlyCost *decimal.Decimal) *Budget {
+	if monthlyBudget == nil {
+		return nil
+	}
+
+	cost := decimal.Zero
+	if monthlyCost != nil {
+		cost = *monthlyCost
+	}
+
+	var percentageUsed *decimal.Decimal
+	if !monthlyBudget.IsZero() {
+		percentageUsed = decimalPtr(cost.Div(*monthlyBudget).Mul(decimal.NewFromInt(100)).Round(2))
+	}
+
+	remaining := monthlyBudget.Sub(cost)
+
+	return &Budget{
+		MonthlyBudget:  monthlyBudget,
+		PercentageUsed: percentageUsed,
+		Remaining:      decimalPtr(remaining),
+		Exceeded:       remaining.IsNegative(),
+	}
+}
+
+// ExceededBudgets returns the labels of the projects whose estimated monthly cost is over their budget.
+// The monthly cost of resources with a budget suppression isn't counted towards the budget.
+func (r *Root) ExceededBudgets(dashboardEnabled bool) []string {
+	var exceeded []string
+
+	for _, p := range r.Projects {
+		if p.Budget == nil || !p.Budget.Exceeded {
+			continue
+		}
+
+		if p.Budget.Remaining != nil && p.Breakdown != nil {
+			suppressed, _ := suppressedMonthlyCost(p.Breakdown.Resources, schema.BudgetPolicy)
+			if !p.Budget.Remaining.Add(suppressed).IsNegative() {
+				continue
+			}
+		}
+
+		exceeded = append(exceeded, p.Label(dashboardEnabled))
+	}
+
+	return exceeded
+}
+
+// SuppressedBudgets returns a description of each resource whose monthly cost isn't counted towards
+// its project's budget because of a budget suppression, so they can be audited.
+func (r *Root) SuppressedBudgets() []string {
+	var suppressed []string
+
+	for _, p := range r.Projects {
+		if p.Budget == nil || p.Breakdown == nil {
+			continue
+		}
+
+		_, s := suppressedMonthlyCost(p.Breakdown.Resources, schema.BudgetPolicy)
+		suppressed = append(suppressed, s...)
+	}
+
+	return suppressed
+}
+
+func formatBudget(currency string, b *Budget) string {
+	used := ""
+	if b.PercentageUsed != nil {
+		f, _ := b.PercentageUsed.Round(0).Float64()
+		used = fmt.Sprintf("%s%% used, ", humanize.FormatFloat("#,###.", f))
+	}
+
+	if b.Exceeded {
+		return fmt.Sprintf("%s %s",
+			formatCost2DP(currency, b.MonthlyBudget),
+			ui.WarningString(fmt.Sprintf("(%sexceeded by %s)", used, formatCost2DP(currency, decimalPtr(b.Remaining.Neg())))),
+		)
+	}
+
+	return fmt.Sprintf("%s %s",
+		formatCost2DP(currency, b.MonthlyBudget),
+		ui.FaintStringf("(%s%s remaining)", used, formatCost2DP(currency, b.Remaining)),
+	)
+}
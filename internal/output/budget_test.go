@@ -0,0 +1,77 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestNewBudget(t *testing.T) {
+	assert.Nil(t, newBudget(nil, decimalPtr(decimal.NewFromInt(100))))
+
+	b := newBudget(decimalPtr(decimal.NewFromInt(500)), decimalPtr(decimal.NewFromInt(210)))
+	assert.Equal(t, "42", b.PercentageUsed.String())
+	assert.Equal(t, "290", b.Remaining.String())
+	assert.False(t, b.Exceeded)
+
+	b = newBudget(decimalPtr(decimal.NewFromInt(500)), decimalPtr(decimal.NewFromInt(750)))
+	assert.Equal(t, "150", b.PercentageUsed.String())
+	assert.Equal(t, "-250", b.Remaining.String())
+	assert.True(t, b.Exceeded)
+
+	b = newBudget(decimalPtr(decimal.NewFromInt(500)), nil)
+	assert.Equal(t, "0", b.PercentageUsed.String())
+	assert.Equal(t, "500", b.Remaining.String())
+	assert.False(t, b.Exceeded)
+}
+
+func TestExceededBudgets(t *testing.T) {
+	r := Root{
+		Projects: []Project{
+			{Name: "under", Budget: &Budget{Exceeded: false}},
+			{Name: "over", Budget: &Budget{Exceeded: true}},
+			{Name: "none"},
+		},
+	}
+
+	assert.Equal(t, []string{"over"}, r.ExceededBudgets(false))
+}
+
+func TestExceededBudgetsSuppressions(t *testing.T) {
+	resources := []Resource{
+		{
+			Name:         "aws_instance.web",
+			MonthlyCost:  decimalPtr(decimal.NewFromInt(30)),
+			Suppressions: []schema.Suppression{{Policy: "budget", Reason: "approved"}},
+		},
+		{
+			Name:         "aws_instance.db",
+			MonthlyCost:  decimalPtr(decimal.NewFromInt(50)),
+			Suppressions: []schema.Suppression{{Policy: "max-diff"}},
+		},
+	}
+
+	r := Root{
+		Projects: []Project{
+			{
+				Name:      "under without suppressed",
+				Budget:    &Budget{Remaining: decimalPtr(decimal.NewFromInt(-20)), Exceeded: true},
+				Breakdown: &Breakdown{Resources: resources},
+			},
+			{
+				Name:      "over",
+				Budget:    &Budget{Remaining: decimalPtr(decimal.NewFromInt(-40)), Exceeded: true},
+				Breakdown: &Breakdown{Resources: resources},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"over"}, r.ExceededBudgets(false))
+	assert.Equal(t, []string{
+		`budget suppressed on aws_instance.web (reason: "approved")`,
+		`budget suppressed on aws_instance.web (reason: "approved")`,
+	}, r.SuppressedBudgets())
+}
@@ -0,0 +1,258 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// CheckThresholds are the limits that make a cost check fail. Nil thresholds aren't checked.
+type CheckThresholds struct {
+	// MaxDiff is the largest increase in total monthly cost that is allowed.
+	MaxDiff *decimal.Decimal
+	// MaxDiffPercent is the largest percentage increase in total monthly cost that is allowed.
+	MaxDiffPercent *decimal.Decimal
+	// FailOnBudget fails the check if any project's monthly cost is over its budget.
+	FailOnBudget bool
+}
+
+// CheckResult is the outcome of checking the cost estimate against the thresholds, used to
+// publish a commit status or check run.
+type CheckResult struct {
+	Passed   bool
+	Title    string
+	Summary  string
+	Failures []string
+}
+
+// CheckAnnotation is a note on the line of a Terraform file that defines resources whose cost
+// changed. Level is notice for cost decreases and warning for increases.
+type CheckAnnotation struct {
+	Path    string
+	Line    int
+	Level   string
+	Title   string
+	Message string
+}
+
+// ToCheckResult checks the total monthly cost change and project budgets against the thresholds. The
+// cost changes of resources with a max-diff or max-diff-percent suppression aren't counted towards
+// that threshold, and the suppressions are listed in the summary.
+func ToCheckResult(out Root, thresholds CheckThresholds) CheckResult {
+	cost := decimal.Zero
+	if out.TotalMonthlyCost != nil {
+		cost = *out.TotalMonthlyCost
+	}
+
+	pastCost := decimal.Zero
+	if out.PastTotalMonthlyCost != nil {
+		pastCost = *out.PastTotalMonthlyCost
+	}
+
+	diff := cost.Sub(pastCost)
+
+	var failures []string
+	var suppressed []string
+
+	if thresholds.MaxDiff != nil {
+		suppressedDiff, s := out.suppressedDiff(schema.MaxDiffPolicy)
+		suppressed = append(suppressed, s...)
+
+		if maxDiff := diff.Sub(suppressedDiff); maxDiff.GreaterThan(*thresholds.MaxDiff) {
+			failures = append(failures, fmt.Sprintf("Monthly cost increase of %s is over the maximum of %s",
+				formatCost2DP(out.Currency, &maxDiff),
+				formatCost2DP(out.Currency, thresholds.MaxDiff),
+			))
+		}
+	}
+
+	if thresholds.MaxDiffPercent != nil {
+		suppressedDiff, s := out.suppressedDiff(schema.MaxDiffPercentPolicy)
+		suppressed = append(suppressed, s...)
+
+		if percentDiff := diff.Sub(suppressedDiff); percentDiff.IsPositive() {
+			if pastCost.IsZero() {
+				failures = append(failures, fmt.Sprintf("Monthly cost increase of %s is over the maximum of %s%% since there is no previous cost",
+					formatCost2DP(out.Currency, &percentDiff),
+					thresholds.MaxDiffPercent.String(),
+				))
+			} else if percent := percentDiff.Div(pastCost).Mul(decimal.NewFromInt(100)); percent.GreaterThan(*thresholds.MaxDiffPercent) {
+				failures = append(failures, fmt.Sprintf("Monthly cost increase of %s%% is over the maximum of %s%%",
+					percent.Round(1).String(),
+					thresholds.MaxDiffPercent.String(),
+				))
+			}
+		}
+	}
+
+	if thresholds.FailOnBudget {
+		suppressed = append(suppressed, out.SuppressedBudgets()...)
+
+		if exceeded := out.ExceededBudgets(false); len(exceeded) > 0 {
+			failures = append(failures, fmt.Sprintf("Monthly cost is over budget for: %s", strings.Join(exceeded, ", ")))
+		}
+	}
+
+	title := CostChangeTitle(out)
+
+	summary := fmt.Sprintf("%s (%s → %s).\n", title, formatCost2DP(out.Currency, &pastCost), formatCost2DP(out.Currency, &cost))
+	if len(failures) == 0 {
+		summary += "\nAll cost thresholds passed.\n"
+	} else {
+		summary += "\nCost thresholds failed:\n"
+		for _, f := range failures {
+			summary += fmt.Sprintf("- %s\n", f)
+		}
+	}
+
+	if len(suppressed) > 0 {
+		summary += "\nSuppressed:\n"
+		for _, s := range suppressed {
+			summary += fmt.Sprintf("- %s\n", s)
+		}
+	}
+
+	return CheckResult{
+		Passed:   len(failures) == 0,
+		Title:    title,
+		Summary:  summary,
+		Failures: failures,
+	}
+}
+
+// suppressedDiff returns the total monthly cost change of the resources that have a suppression matching
+// the policy, and a description of each suppression.
+func (r *Root) suppressedDiff(policy string) (decimal.Decimal, []string) {
+	total := decimal.Zero
+	var suppressed []string
+
+	for _, p := range r.Projects {
+		if p.Diff == nil {
+			continue
+		}
+
+		cost, s := suppressedMonthlyCost(p.Diff.Resources, policy)
+		total = total.Add(cost)
+		suppressed = append(suppressed, s...)
+	}
+
+	return total, suppressed
+}
+
+// CostChangeTitle returns a sentence describing the change in total monthly cost, e.g.
+// "Monthly cost will increase by $40.56 (+100%) ↑". Missing costs are treated as zero.
+func CostChangeTitle(out Root) string {
+	cost := decimal.Zero
+	if out.TotalMonthlyCost != nil {
+		cost = *out.TotalMonthlyCost
+	}
+
+	pastCost := decimal.Zero
+	if out.PastTotalMonthlyCost != nil {
+		pastCost = *out.PastTotalMonthlyCost
+	}
+
+	sentence := formatCostChangeSentence(out.Currency, &pastCost, &cost, false)
+	return strings.ToUpper(sentence[:1]) + sentence[1:]
+}
+
+// CheckAnnotations returns an annotation for each resource and module block whose monthly cost
+// changed, with paths relative to repoDir. Only projects whose path is a directory of Terraform
+// files can be annotated, since plan JSON files don't include where resources are defined.
+func CheckAnnotations(out Root, repoDir string) []CheckAnnotation {
+	absRepoDir, err := filepath.Abs(repoDir)
+	if err != nil {
+		return nil
+	}
+
+	var annotations []CheckAnnotation
+
+	for _, project := range out.Projects {
+		if project.Metadata == nil || project.Diff == nil {
+			continue
+		}
+
+		info, err := os.Stat(project.Metadata.Path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+
+		locations := terraform.LoadBlockLocations(project.Metadata.Path)
+
+		type blockDiff struct {
+			diff      decimal.Decimal
+			resources []string
+		}
+		blocks := map[string]*blockDiff{}
+		var addrs []string
+
+		for _, r := range project.Diff.Resources {
+			if r.MonthlyCost == nil || r.MonthlyCost.IsZero() {
+				continue
+			}
+
+			addr := terraform.BlockAddress(r.Name)
+			if _, ok := locations[addr]; !ok {
+				continue
+			}
+
+			b, ok := blocks[addr]
+			if !ok {
+				b = &blockDiff{}
+				blocks[addr] = b
+				addrs = append(addrs, addr)
+			}
+			b.diff = b.diff.Add(*r.MonthlyCost)
+			b.resources = append(b.resources, r.Name)
+		}
+
+		for _, addr := range addrs {
+			b := blocks[addr]
+			loc := locations[addr]
+
+			absFilename, err := filepath.Abs(loc.Filename)
+			if err != nil {
+				continue
+			}
+
+			path, err := filepath.Rel(absRepoDir, absFilename)
+			if err != nil || strings.HasPrefix(path, "..") {
+				continue
+			}
+
+			level := "warning"
+			if b.diff.IsNegative() {
+				level = "notice"
+			}
+
+			msg := fmt.Sprintf("Monthly cost change of %s", formatCostChange(out.Currency, &b.diff))
+			if len(b.resources) > 1 || b.resources[0] != addr {
+				msg += fmt.Sprintf(" from %s", strings.Join(b.resources, ", "))
+			}
+
+			annotations = append(annotations, CheckAnnotation{
+				Path:    filepath.ToSlash(path),
+				Line:    loc.Line,
+				Level:   level,
+				Title:   addr,
+				Message: msg,
+			})
+		}
+	}
+
+	sort.SliceStable(annotations, func(i, j int) bool {
+		if annotations[i].Path != annotations[j].Path {
+			return annotations[i].Path < annotations[j].Path
+		}
+		return annotations[i].Line < annotations[j].Line
+	})
+
+	return annotations
+}
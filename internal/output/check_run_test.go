@@ -0,0 +1,121 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestToCheckResult(t *testing.T) {
+	r := Root{
+		Currency:             "USD",
+		PastTotalMonthlyCost: decimalPtr(decimal.NewFromInt(100)),
+		TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(150)),
+		Projects: []Project{
+			{Name: "over", Budget: &Budget{Exceeded: true}},
+		},
+	}
+
+	result := ToCheckResult(r, CheckThresholds{})
+	assert.True(t, result.Passed)
+	assert.Equal(t, "Monthly cost will increase by $50.00 (+50%) ↑", result.Title)
+
+	result = ToCheckResult(r, CheckThresholds{
+		MaxDiff:        decimalPtr(decimal.NewFromInt(60)),
+		MaxDiffPercent: decimalPtr(decimal.NewFromInt(25)),
+		FailOnBudget:   true,
+	})
+	assert.False(t, result.Passed)
+	assert.Equal(t, []string{
+		"Monthly cost increase of 50% is over the maximum of 25%",
+		"Monthly cost is over budget for: over",
+	}, result.Failures)
+}
+
+func TestToCheckResultSuppressions(t *testing.T) {
+	r := Root{
+		Currency:             "USD",
+		PastTotalMonthlyCost: decimalPtr(decimal.NewFromInt(100)),
+		TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(150)),
+		Projects: []Project{
+			{
+				Name:   "prod",
+				Budget: &Budget{Remaining: decimalPtr(decimal.NewFromInt(-20)), Exceeded: true},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name:         "aws_instance.web",
+							MonthlyCost:  decimalPtr(decimal.NewFromInt(30)),
+							Suppressions: []schema.Suppression{{Policy: "budget", Reason: "approved"}},
+						},
+					},
+				},
+				Diff: &Breakdown{
+					Resources: []Resource{
+						{
+							Name:         "aws_instance.web",
+							MonthlyCost:  decimalPtr(decimal.NewFromInt(30)),
+							Suppressions: []schema.Suppression{{Policy: "max-diff"}},
+						},
+						{Name: "aws_instance.db", MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+					},
+				},
+			},
+		},
+	}
+
+	result := ToCheckResult(r, CheckThresholds{
+		MaxDiff:        decimalPtr(decimal.NewFromInt(25)),
+		MaxDiffPercent: decimalPtr(decimal.NewFromInt(25)),
+		FailOnBudget:   true,
+	})
+	assert.False(t, result.Passed)
+	assert.Equal(t, []string{
+		"Monthly cost increase of 50% is over the maximum of 25%",
+	}, result.Failures)
+	assert.Contains(t, result.Summary, "Suppressed:\n- max-diff suppressed on aws_instance.web\n- budget suppressed on aws_instance.web (reason: \"approved\")\n")
+}
+
+func TestCheckAnnotations(t *testing.T) {
+	repoDir := t.TempDir()
+	dir := filepath.Join(repoDir, "infra")
+	require.NoError(t, os.Mkdir(dir, 0700))
+
+	content := `resource "aws_instance" "web" {
+  count = 2
+}
+
+module "db" {
+  source = "./db"
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(content), 0600))
+
+	r := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Metadata: &schema.ProjectMetadata{Path: dir},
+				Diff: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web[0]", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+						{Name: "aws_instance.web[1]", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+						{Name: "module.db.aws_db_instance.this", MonthlyCost: decimalPtr(decimal.NewFromInt(-30))},
+						{Name: "aws_s3_bucket.unknown", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []CheckAnnotation{
+		{Path: "infra/main.tf", Line: 1, Level: "warning", Title: "aws_instance.web", Message: "Monthly cost change of +$20.00 from aws_instance.web[0], aws_instance.web[1]"},
+		{Path: "infra/main.tf", Line: 5, Level: "notice", Title: "module.db", Message: "Monthly cost change of -$30.00 from module.db.aws_db_instance.this"},
+	}, CheckAnnotations(r, repoDir))
+}
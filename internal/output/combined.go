@@ -218,6 +218,8 @@ func Combine(inputs []ReportInput) (Root, error) {
 	combined.DiffTotalMonthlyCost = diffTotalMonthlyCost
 	combined.TimeGenerated = time.Now()
 	combined.Summary = MergeSummaries(summaries)
+	combined.Pricing = newPricing(projects)
+	combined.CommittedSpend = RollupCommittedSpend(combined, combineCommittedSpend(inputs))
 
 	return combined, nil
 }
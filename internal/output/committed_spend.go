@@ -0,0 +1,190 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// CommittedSpend shows how the estimated monthly cost of the projects progresses toward an
+// organization's spend commitment with a cloud provider, e.g. an AWS EDP or Azure MACC.
+type CommittedSpend struct {
+	Name              string           `json:"name"`
+	MonthlyCommitment *decimal.Decimal `json:"monthlyCommitment"`
+	// Providers and Projects are the filters of the costs that count toward the commitment, so the
+	// rollup can be recalculated when outputs are combined.
+	Providers       []string         `json:"providers,omitempty"`
+	Projects        []string         `json:"projects,omitempty"`
+	PastMonthlyCost *decimal.Decimal `json:"pastMonthlyCost"`
+	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
+	DiffMonthlyCost *decimal.Decimal `json:"diffMonthlyCost"`
+	PastPercentage  *decimal.Decimal `json:"pastPercentage"`
+	Percentage      *decimal.Decimal `json:"percentage"`
+	Remaining       *decimal.Decimal `json:"remaining"`
+}
+
+// RollupCommittedSpend returns the commitments with the past and current monthly cost of the
+// projects in the output that count toward them.
+func RollupCommittedSpend(out Root, commitments []CommittedSpend) []CommittedSpend {
+	rollups := make([]CommittedSpend, 0, len(commitments))
+
+	for _, c := range commitments {
+		pastCost := decimal.Zero
+		cost := decimal.Zero
+
+		for _, p := range out.Projects {
+			if !c.matchesProject(p) {
+				continue
+			}
+
+			pastCost = pastCost.Add(c.breakdownCost(p.PastBreakdown))
+			cost = cost.Add(c.breakdownCost(p.Breakdown))
+		}
+
+		c.PastMonthlyCost = decimalPtr(pastCost)
+		c.MonthlyCost = decimalPtr(cost)
+		c.DiffMonthlyCost = decimalPtr(cost.Sub(pastCost))
+		c.PastPercentage = nil
+		c.Percentage = nil
+		c.Remaining = nil
+
+		if c.MonthlyCommitment != nil {
+			if !c.MonthlyCommitment.IsZero() {
+				c.PastPercentage = decimalPtr(pastCost.Div(*c.MonthlyCommitment).Mul(decimal.NewFromInt(100)).Round(2))
+				c.Percentage = decimalPtr(cost.Div(*c.MonthlyCommitment).Mul(decimal.NewFromInt(100)).Round(2))
+			}
+
+			c.Remaining = decimalPtr(c.MonthlyCommitment.Sub(cost))
+		}
+
+		rollups = append(rollups, c)
+	}
+
+	return rollups
+}
+
+// matchesProject returns true if the project's path or name match one of the commitment's project
+// patterns, or the commitment has no patterns.
+func (c CommittedSpend) matchesProject(p Project) bool {
+	if len(c.Projects) == 0 {
+		return true
+	}
+
+	candidates := []string{p.Name}
+	if p.Metadata != nil && p.Metadata.Path != "" {
+		candidates = append(candidates, filepath.Clean(p.Metadata.Path))
+	}
+
+	for _, pattern := range c.Projects {
+		for _, candidate := range candidates {
+			if ok, _ := filepath.Match(pattern, candidate); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// breakdownCost returns the monthly cost of the resources in the breakdown that count toward the
+// commitment.
+func (c CommittedSpend) breakdownCost(b *Breakdown) decimal.Decimal {
+	if b == nil {
+		return decimal.Zero
+	}
+
+	if len(c.Providers) == 0 {
+		if b.TotalMonthlyCost == nil {
+			return decimal.Zero
+		}
+
+		return *b.TotalMonthlyCost
+	}
+
+	total := decimal.Zero
+	for _, r := range b.Resources {
+		if r.MonthlyCost != nil && c.matchesProvider(r) {
+			total = total.Add(*r.MonthlyCost)
+		}
+	}
+
+	return total
+}
+
+func (c CommittedSpend) matchesProvider(r Resource) bool {
+	resourceType := r.ResourceType()
+
+	for _, provider := range c.Providers {
+		if strings.HasPrefix(resourceType, provider+"_") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// combineCommittedSpend returns the commitments of the inputs, keeping the first one when more than
+// one input has a commitment with the same name.
+func combineCommittedSpend(inputs []ReportInput) []CommittedSpend {
+	var commitments []CommittedSpend
+	seen := map[string]bool{}
+
+	for _, input := range inputs {
+		for _, c := range input.Root.CommittedSpend {
+			if seen[c.Name] {
+				continue
+			}
+
+			seen[c.Name] = true
+			commitments = append(commitments, c)
+		}
+	}
+
+	return commitments
+}
+
+func formatCommittedSpend(currency string, commitments []CommittedSpend, showChange bool) string {
+	nameLen := 0
+	for _, c := range commitments {
+		if len(c.Name) > nameLen {
+			nameLen = len(c.Name)
+		}
+	}
+
+	s := ui.BoldString("Committed spend") + "\n"
+
+	for _, c := range commitments {
+		details := []string{formatPercentage(c.Percentage) + " of commitment"}
+		if c.Remaining != nil && !c.Remaining.IsPositive() {
+			details = append(details, "met")
+		}
+
+		if showChange && c.DiffMonthlyCost != nil && !c.DiffMonthlyCost.IsZero() {
+			details = append(details, fmt.Sprintf("%s from this change, was %s", formatCostChange(currency, c.DiffMonthlyCost), formatPercentage(c.PastPercentage)))
+		}
+
+		s += fmt.Sprintf("%-*s  %s of %s per month %s\n",
+			nameLen+1,
+			c.Name+":",
+			formatCost2DP(currency, c.MonthlyCost),
+			formatCost2DP(currency, c.MonthlyCommitment),
+			ui.FaintStringf("(%s)", strings.Join(details, ", ")),
+		)
+	}
+
+	return s
+}
+
+func formatPercentage(d *decimal.Decimal) string {
+	if d == nil {
+		return "-"
+	}
+
+	f, _ := d.Round(0).Float64()
+	return fmt.Sprintf("%s%%", humanize.FormatFloat("#,###.", f))
+}
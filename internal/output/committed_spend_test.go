@@ -0,0 +1,89 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestRollupCommittedSpend(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name:     "infracost/network",
+				Metadata: &schema.ProjectMetadata{Path: "network"},
+				PastBreakdown: &Breakdown{
+					Resources:        []Resource{{Name: "aws_nat_gateway.main", MonthlyCost: decimalPtr(decimal.NewFromInt(100))}},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100)),
+				},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_nat_gateway.main", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+						{Name: "module.vpn.aws_vpn_connection.main", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+						{Name: "cloudflare_record.www", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+					},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(160)),
+				},
+			},
+			{
+				Name:     "infracost/app",
+				Metadata: &schema.ProjectMetadata{Path: "app"},
+				Breakdown: &Breakdown{
+					Resources:        []Resource{{Name: "azurerm_linux_virtual_machine.app", MonthlyCost: decimalPtr(decimal.NewFromInt(200))}},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(200)),
+				},
+			},
+		},
+	}
+
+	rollups := RollupCommittedSpend(out, []CommittedSpend{
+		{Name: "AWS EDP", MonthlyCommitment: decimalPtr(decimal.NewFromInt(120)), Providers: []string{"aws"}},
+		{Name: "App", MonthlyCommitment: decimalPtr(decimal.NewFromInt(400)), Projects: []string{"app"}},
+	})
+	require.Len(t, rollups, 2)
+
+	edp := rollups[0]
+	assert.Equal(t, "100", edp.PastMonthlyCost.String())
+	assert.Equal(t, "150", edp.MonthlyCost.String())
+	assert.Equal(t, "50", edp.DiffMonthlyCost.String())
+	assert.Equal(t, "83.33", edp.PastPercentage.String())
+	assert.Equal(t, "125", edp.Percentage.String())
+	assert.Equal(t, "-30", edp.Remaining.String())
+
+	app := rollups[1]
+	assert.Equal(t, "0", app.PastMonthlyCost.String())
+	assert.Equal(t, "200", app.MonthlyCost.String())
+	assert.Equal(t, "50", app.Percentage.String())
+	assert.Equal(t, "200", app.Remaining.String())
+}
+
+func TestCombineCommittedSpend(t *testing.T) {
+	commitment := CommittedSpend{Name: "AWS EDP", MonthlyCommitment: decimalPtr(decimal.NewFromInt(1000)), Providers: []string{"aws"}}
+
+	inputs := []ReportInput{
+		{Root: Root{
+			Projects: []Project{{
+				Name:      "network",
+				Breakdown: &Breakdown{Resources: []Resource{{Name: "aws_nat_gateway.main", MonthlyCost: decimalPtr(decimal.NewFromInt(100))}}},
+			}},
+			CommittedSpend: RollupCommittedSpend(Root{}, []CommittedSpend{commitment}),
+		}},
+		{Root: Root{
+			Projects: []Project{{
+				Name:      "app",
+				Breakdown: &Breakdown{Resources: []Resource{{Name: "aws_instance.app", MonthlyCost: decimalPtr(decimal.NewFromInt(300))}}},
+			}},
+			CommittedSpend: []CommittedSpend{commitment},
+		}},
+	}
+
+	combined, err := Combine(inputs)
+	require.NoError(t, err)
+	require.Len(t, combined.CommittedSpend, 1)
+	assert.Equal(t, "400", combined.CommittedSpend[0].MonthlyCost.String())
+	assert.Equal(t, "40", combined.CommittedSpend[0].Percentage.String())
+}
@@ -0,0 +1,273 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Comparison is the monthly cost of each resource across a series of saved runs, e.g. the Infracost
+// JSON of several branches or of successive PRs. Resources are aligned across the runs by their
+// project name and address.
+type Comparison struct {
+	Currency  string               `json:"currency"`
+	Runs      []ComparisonRun      `json:"runs"`
+	Resources []ComparisonResource `json:"resources"`
+}
+
+// ComparisonRun is one of the runs being compared, in the order they were given.
+type ComparisonRun struct {
+	// Name is the file name of the run without its extension, or the full path if more than one run
+	// has the same file name.
+	Name             string           `json:"name"`
+	Path             string           `json:"path"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+}
+
+// ComparisonResource is the cost history of a resource across the runs. MonthlyCosts has a cost for
+// each run, which is nil if the resource isn't in that run. The stats only include the runs that
+// have the resource.
+type ComparisonResource struct {
+	Project      string             `json:"project"`
+	Name         string             `json:"name"`
+	MonthlyCosts []*decimal.Decimal `json:"monthlyCosts"`
+	// Change is the cost in the last run minus the cost in the first run, where the resource
+	// counts as costing nothing in a run that it isn't in.
+	Change *decimal.Decimal `json:"change"`
+	Min    *decimal.Decimal `json:"min"`
+	Max    *decimal.Decimal `json:"max"`
+	Mean   *decimal.Decimal `json:"mean"`
+}
+
+// Changed returns true if the resource isn't in all the runs, or its cost isn't the same in all of
+// them.
+func (r ComparisonResource) Changed() bool {
+	for _, c := range r.MonthlyCosts {
+		if c == nil || !c.Equal(*r.MonthlyCosts[0]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type comparisonKey struct {
+	project string
+	name    string
+}
+
+// NewComparison aligns the resources of the runs by their project name and address. The resources
+// are sorted by how much their cost changed from the first to the last run, biggest change first,
+// so the effect of the runs is at the top. Runs must all be in the same currency.
+func NewComparison(inputs []ReportInput) (Comparison, error) {
+	c := Comparison{
+		Runs:      make([]ComparisonRun, 0, len(inputs)),
+		Resources: []ComparisonResource{},
+	}
+
+	names := comparisonRunNames(inputs)
+
+	resources := map[comparisonKey]*ComparisonResource{}
+	var keys []comparisonKey
+
+	for i, input := range inputs {
+		if i == 0 {
+			c.Currency = input.Root.Currency
+		} else if input.Root.Currency != c.Currency {
+			return Comparison{}, fmt.Errorf("Runs must use the same currency, %s is in %s but %s is in %s", names[0], c.Currency, names[i], input.Root.Currency)
+		}
+
+		c.Runs = append(c.Runs, ComparisonRun{
+			Name:             names[i],
+			Path:             input.Metadata["filename"],
+			TotalMonthlyCost: input.Root.TotalMonthlyCost,
+		})
+
+		for _, p := range input.Root.Projects {
+			if p.Breakdown == nil {
+				continue
+			}
+
+			for _, r := range p.Breakdown.Resources {
+				k := comparisonKey{project: p.Name, name: r.Name}
+				res, ok := resources[k]
+				if !ok {
+					res = &ComparisonResource{
+						Project:      p.Name,
+						Name:         r.Name,
+						MonthlyCosts: make([]*decimal.Decimal, len(inputs)),
+					}
+					resources[k] = res
+					keys = append(keys, k)
+				}
+
+				// Free and usage-based resources without usage don't have a cost, but they're still in
+				// the run, which is different to not being in it.
+				cost := decimal.Zero
+				if r.MonthlyCost != nil {
+					cost = *r.MonthlyCost
+				}
+				if res.MonthlyCosts[i] != nil {
+					cost = cost.Add(*res.MonthlyCosts[i])
+				}
+				res.MonthlyCosts[i] = &cost
+			}
+		}
+	}
+
+	for _, k := range keys {
+		res := resources[k]
+		setComparisonStats(res)
+		c.Resources = append(c.Resources, *res)
+	}
+
+	sort.SliceStable(c.Resources, func(i, j int) bool {
+		a, b := c.Resources[i].Change.Abs(), c.Resources[j].Change.Abs()
+		if !a.Equal(b) {
+			return a.GreaterThan(b)
+		}
+		if c.Resources[i].Project != c.Resources[j].Project {
+			return c.Resources[i].Project < c.Resources[j].Project
+		}
+		return c.Resources[i].Name < c.Resources[j].Name
+	})
+
+	return c, nil
+}
+
+func setComparisonStats(r *ComparisonResource) {
+	first, last := decimal.Zero, decimal.Zero
+	if c := r.MonthlyCosts[0]; c != nil {
+		first = *c
+	}
+	if c := r.MonthlyCosts[len(r.MonthlyCosts)-1]; c != nil {
+		last = *c
+	}
+	change := last.Sub(first)
+	r.Change = &change
+
+	var sum decimal.Decimal
+	count := 0
+	for _, c := range r.MonthlyCosts {
+		if c == nil {
+			continue
+		}
+
+		if r.Min == nil || c.LessThan(*r.Min) {
+			r.Min = c
+		}
+		if r.Max == nil || c.GreaterThan(*r.Max) {
+			r.Max = c
+		}
+		sum = sum.Add(*c)
+		count++
+	}
+
+	mean := sum.Div(decimal.NewFromInt(int64(count)))
+	r.Mean = &mean
+}
+
+// comparisonRunNames returns the names of the runs, which are their file names unless more than one
+// run has the same file name, e.g. runs/main/infracost.json and runs/dev/infracost.json.
+func comparisonRunNames(inputs []ReportInput) []string {
+	names := make([]string, len(inputs))
+	seen := map[string]int{}
+
+	for i, input := range inputs {
+		base := filepath.Base(input.Metadata["filename"])
+		names[i] = strings.TrimSuffix(base, filepath.Ext(base))
+		seen[names[i]]++
+	}
+
+	for i, input := range inputs {
+		if seen[names[i]] > 1 {
+			names[i] = input.Metadata["filename"]
+		}
+	}
+
+	return names
+}
+
+// ToComparisonJSON returns the comparison as indented JSON.
+func ToComparisonJSON(c Comparison) ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// ToComparisonTable returns a table of the monthly cost of each resource in each run, and the change
+// from the first run to the last.
+func ToComparisonTable(c Comparison) []byte {
+	if len(c.Resources) == 0 {
+		return []byte("No resources were found in the runs\n")
+	}
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+	t.Style().Format.Footer = text.FormatDefault
+
+	header := table.Row{ui.UnderlineString("Project"), ui.UnderlineString("Resource")}
+	configs := []table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+	}
+	for i, run := range c.Runs {
+		header = append(header, ui.UnderlineString(run.Name))
+		configs = append(configs, table.ColumnConfig{Number: i + 3, Align: text.AlignRight, AlignHeader: text.AlignRight, AlignFooter: text.AlignRight})
+	}
+	header = append(header, ui.UnderlineString("Change"))
+	configs = append(configs, table.ColumnConfig{Number: len(c.Runs) + 3, Align: text.AlignRight, AlignHeader: text.AlignRight, AlignFooter: text.AlignRight})
+
+	t.AppendHeader(header)
+	t.SetColumnConfigs(configs)
+
+	for _, r := range c.Resources {
+		row := table.Row{r.Project, r.Name}
+		for _, cost := range r.MonthlyCosts {
+			row = append(row, formatCost2DP(c.Currency, cost))
+		}
+		row = append(row, formatComparisonChange(c.Currency, r.Change))
+		t.AppendRow(row)
+	}
+
+	totals := table.Row{"", ui.BoldString("Total")}
+	for _, run := range c.Runs {
+		totals = append(totals, ui.BoldString(formatCost2DP(c.Currency, run.TotalMonthlyCost)))
+	}
+	totals = append(totals, ui.BoldString(formatComparisonChange(c.Currency, comparisonTotalChange(c.Runs))))
+	t.AppendFooter(totals)
+
+	var b strings.Builder
+	b.WriteString(t.Render())
+	fmt.Fprintf(&b, "\n\n%d resources compared across %d runs\n", len(c.Resources), len(c.Runs))
+
+	return []byte(b.String())
+}
+
+func comparisonTotalChange(runs []ComparisonRun) *decimal.Decimal {
+	first, last := decimal.Zero, decimal.Zero
+	if c := runs[0].TotalMonthlyCost; c != nil {
+		first = *c
+	}
+	if c := runs[len(runs)-1].TotalMonthlyCost; c != nil {
+		last = *c
+	}
+
+	change := last.Sub(first)
+	return &change
+}
+
+func formatComparisonChange(currency string, d *decimal.Decimal) string {
+	abs := d.Abs()
+	return fmt.Sprintf("%s%s", getSym(*d), formatCost2DP(currency, &abs))
+}
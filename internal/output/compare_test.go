@@ -0,0 +1,63 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testComparisonInput(filename, currency string, costs map[string]int64) ReportInput {
+	var resources []Resource
+	for name, cost := range costs {
+		resources = append(resources, Resource{Name: name, MonthlyCost: decimalPtr(decimal.NewFromInt(cost))})
+	}
+
+	return ReportInput{
+		Metadata: map[string]string{"filename": filename},
+		Root: Root{
+			Currency: currency,
+			Projects: []Project{{Name: "infra", Breakdown: &Breakdown{Resources: resources}}},
+		},
+	}
+}
+
+func TestNewComparison(t *testing.T) {
+	c, err := NewComparison([]ReportInput{
+		testComparisonInput("runs/main/infracost.json", "USD", map[string]int64{"aws_instance.web": 100, "aws_eip.ip": 4}),
+		testComparisonInput("runs/dev/infracost.json", "USD", map[string]int64{"aws_instance.web": 50}),
+		testComparisonInput("runs/pr-1.json", "USD", map[string]int64{"aws_instance.web": 150, "aws_eip.ip": 4}),
+	})
+	require.NoError(t, err)
+
+	// Runs with the same file name use their path.
+	assert.Equal(t, "runs/main/infracost.json", c.Runs[0].Name)
+	assert.Equal(t, "runs/dev/infracost.json", c.Runs[1].Name)
+	assert.Equal(t, "pr-1", c.Runs[2].Name)
+
+	require.Len(t, c.Resources, 2)
+
+	web := c.Resources[0]
+	assert.Equal(t, "aws_instance.web", web.Name)
+	assert.Equal(t, "50", web.Change.String())
+	assert.Equal(t, "50", web.Min.String())
+	assert.Equal(t, "150", web.Max.String())
+	assert.Equal(t, "100", web.Mean.String())
+	assert.True(t, web.Changed())
+
+	eip := c.Resources[1]
+	assert.Equal(t, "aws_eip.ip", eip.Name)
+	assert.Nil(t, eip.MonthlyCosts[1])
+	assert.Equal(t, "0", eip.Change.String())
+	assert.Equal(t, "4", eip.Mean.String())
+	assert.True(t, eip.Changed())
+}
+
+func TestNewComparisonCurrencyMismatch(t *testing.T) {
+	_, err := NewComparison([]ReportInput{
+		testComparisonInput("main.json", "USD", map[string]int64{"aws_instance.web": 100}),
+		testComparisonInput("dev.json", "EUR", map[string]int64{"aws_instance.web": 90}),
+	})
+	assert.EqualError(t, err, "Runs must use the same currency, main is in USD but dev is in EUR")
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Confidence is how much the estimate of a project can be trusted. Score is from 0 to 100, where high
+// confidence resources count fully, medium confidence resources count half and low confidence
+// resources don't count.
+type Confidence struct {
+	Level           string `json:"level"`
+	Score           int64  `json:"score"`
+	HighResources   int    `json:"highResources"`
+	MediumResources int    `json:"mediumResources"`
+	LowResources    int    `json:"lowResources"`
+}
+
+// newConfidence returns the confidence of the breakdown from the confidence levels of its resources.
+// It returns nil if none of the resources have a confidence level.
+func newConfidence(breakdown *Breakdown) *Confidence {
+	if breakdown == nil {
+		return nil
+	}
+
+	c := &Confidence{}
+	for _, r := range breakdown.Resources {
+		switch r.Confidence {
+		case schema.ConfidenceHigh:
+			c.HighResources++
+		case schema.ConfidenceMedium:
+			c.MediumResources++
+		case schema.ConfidenceLow:
+			c.LowResources++
+		}
+	}
+
+	total := c.HighResources + c.MediumResources + c.LowResources
+	if total == 0 {
+		return nil
+	}
+
+	points := decimal.NewFromInt(int64(100*c.HighResources + 50*c.MediumResources))
+	c.Score = points.Div(decimal.NewFromInt(int64(total))).Round(0).IntPart()
+
+	switch {
+	case c.Score >= 80:
+		c.Level = schema.ConfidenceHigh
+	case c.Score >= 50:
+		c.Level = schema.ConfidenceMedium
+	default:
+		c.Level = schema.ConfidenceLow
+	}
+
+	return c
+}
+
+func formatConfidence(c *Confidence) string {
+	return fmt.Sprintf("%s %s",
+		c.Level,
+		ui.FaintStringf("(score %d/100: %d high, %d medium, %d low confidence resources)", c.Score, c.HighResources, c.MediumResources, c.LowResources),
+	)
+}
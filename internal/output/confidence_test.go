@@ -0,0 +1,26 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfidence(t *testing.T) {
+	assert.Nil(t, newConfidence(nil))
+	assert.Nil(t, newConfidence(&Breakdown{Resources: []Resource{{Name: "aws_instance.web"}}}))
+
+	c := newConfidence(&Breakdown{Resources: []Resource{
+		{Name: "aws_instance.web", Confidence: "high"},
+		{Name: "aws_instance.worker", Confidence: "high"},
+		{Name: "aws_instance.batch", Confidence: "medium"},
+		{Name: "aws_vpc.main"},
+	}})
+	assert.Equal(t, &Confidence{Level: "high", Score: 83, HighResources: 2, MediumResources: 1}, c)
+
+	c = newConfidence(&Breakdown{Resources: []Resource{
+		{Name: "aws_instance.web", Confidence: "medium"},
+		{Name: "aws_s3_bucket.logs", Confidence: "low"},
+	}})
+	assert.Equal(t, &Confidence{Level: "low", Score: 25, MediumResources: 1, LowResources: 1}, c)
+}
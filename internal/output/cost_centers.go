@@ -0,0 +1,209 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// UnallocatedCostCenter is the cost center of the resources that the cost center rules in the config
+// file don't allocate to any cost center.
+const UnallocatedCostCenter = "unallocated"
+
+// CostCenter is the monthly cost allocated to a cost center, and the share of each resource that
+// makes it up.
+type CostCenter struct {
+	Name        string
+	MonthlyCost decimal.Decimal
+	Resources   []CostCenterResource
+}
+
+// CostCenterResource is the share of the monthly cost of a resource that is allocated to a cost
+// center.
+type CostCenterResource struct {
+	Project     string
+	Name        string
+	Owner       string
+	Percentage  decimal.Decimal
+	MonthlyCost decimal.Decimal
+}
+
+// NewCostCenters groups the monthly cost of the resources of the projects by cost center. Resources
+// that are split are included in each of their cost centers with their share of the cost. Cost
+// centers with the highest costs are first, and the unallocated resources are last.
+func NewCostCenters(out Root, dashboardEnabled bool) []CostCenter {
+	centers := map[string]*CostCenter{}
+	hundred := decimal.NewFromInt(100)
+
+	add := func(name string, r CostCenterResource) {
+		c, ok := centers[name]
+		if !ok {
+			c = &CostCenter{Name: name}
+			centers[name] = c
+		}
+
+		c.MonthlyCost = c.MonthlyCost.Add(r.MonthlyCost)
+		c.Resources = append(c.Resources, r)
+	}
+
+	for _, p := range out.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			if r.MonthlyCost == nil {
+				continue
+			}
+
+			if len(r.CostCenters) == 0 {
+				add(UnallocatedCostCenter, CostCenterResource{
+					Project:     p.Label(dashboardEnabled),
+					Name:        r.Name,
+					Owner:       r.Owner,
+					Percentage:  hundred,
+					MonthlyCost: *r.MonthlyCost,
+				})
+				continue
+			}
+
+			for name, percentage := range r.CostCenters {
+				add(name, CostCenterResource{
+					Project:     p.Label(dashboardEnabled),
+					Name:        r.Name,
+					Owner:       r.Owner,
+					Percentage:  percentage,
+					MonthlyCost: r.MonthlyCost.Mul(percentage).Div(hundred),
+				})
+			}
+		}
+	}
+
+	costCenters := make([]CostCenter, 0, len(centers))
+	for _, c := range centers {
+		sort.Slice(c.Resources, func(i, j int) bool {
+			if !c.Resources[i].MonthlyCost.Equal(c.Resources[j].MonthlyCost) {
+				return c.Resources[i].MonthlyCost.GreaterThan(c.Resources[j].MonthlyCost)
+			}
+			if c.Resources[i].Project != c.Resources[j].Project {
+				return c.Resources[i].Project < c.Resources[j].Project
+			}
+			return c.Resources[i].Name < c.Resources[j].Name
+		})
+
+		costCenters = append(costCenters, *c)
+	}
+
+	sort.Slice(costCenters, func(i, j int) bool {
+		if (costCenters[i].Name == UnallocatedCostCenter) != (costCenters[j].Name == UnallocatedCostCenter) {
+			return costCenters[j].Name == UnallocatedCostCenter
+		}
+		if !costCenters[i].MonthlyCost.Equal(costCenters[j].MonthlyCost) {
+			return costCenters[i].MonthlyCost.GreaterThan(costCenters[j].MonthlyCost)
+		}
+		return costCenters[i].Name < costCenters[j].Name
+	})
+
+	return costCenters
+}
+
+// ToCostCenters returns a table of the resources allocated to each cost center, followed by the
+// total monthly cost of all the cost centers.
+func ToCostCenters(out Root, opts Options) ([]byte, error) {
+	costCenters := NewCostCenters(out, opts.DashboardEnabled)
+
+	if len(costCenters) == 0 {
+		return []byte("No cloud resources were detected\n"), nil
+	}
+
+	var b strings.Builder
+	total := decimal.Zero
+
+	for i, c := range costCenters {
+		if i != 0 {
+			b.WriteString("──────────────────────────────────\n")
+		}
+
+		fmt.Fprintf(&b, "%s %s\n\n", ui.BoldString("Cost center:"), c.Name)
+		b.WriteString(costCenterTable(out.Currency, c))
+		fmt.Fprintf(&b, "\n\n%s %s\n\n", ui.BoldString("Monthly cost:"), formatCost2DP(out.Currency, &c.MonthlyCost))
+
+		total = total.Add(c.MonthlyCost)
+	}
+
+	b.WriteString("──────────────────────────────────\n")
+	fmt.Fprintf(&b, "%s %s across %d cost centers\n",
+		ui.BoldString("Total monthly cost:"),
+		formatCost2DP(out.Currency, &total),
+		len(costCenters),
+	)
+
+	return []byte(b.String()), nil
+}
+
+func costCenterTable(currency string, c CostCenter) string {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Resource"),
+		ui.UnderlineString("Project"),
+		ui.UnderlineString("Share"),
+		ui.UnderlineString("Monthly Cost"),
+	})
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 4, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	for _, r := range c.Resources {
+		cost := r.MonthlyCost
+		t.AppendRow(table.Row{r.Name, r.Project, r.Percentage.String() + "%", formatCost2DP(currency, &cost)})
+	}
+
+	return t.Render()
+}
+
+// ToCSV returns a row for the share of each resource that is allocated to each cost center, so it can
+// be imported into a spreadsheet or billing system for chargeback. The owner column is the owners of
+// the resource from the CODEOWNERS file, so the rows can also be grouped by owner.
+func ToCSV(out Root, opts Options) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+
+	err := w.Write([]string{"cost_center", "project", "resource", "owner", "percentage", "monthly_cost", "currency"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range NewCostCenters(out, opts.DashboardEnabled) {
+		for _, r := range c.Resources {
+			err = w.Write([]string{c.Name, r.Project, r.Name, r.Owner, r.Percentage.String(), r.MonthlyCost.StringFixed(2), out.Currency})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
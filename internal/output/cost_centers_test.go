@@ -0,0 +1,75 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCostCentersRoot() Root {
+	return Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name: "infra",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name:        "aws_db_instance.shared",
+							MonthlyCost: decimalPtr(decimal.NewFromInt(200)),
+							CostCenters: map[string]decimal.Decimal{"team-a": decimal.NewFromInt(60), "team-b": decimal.NewFromInt(40)},
+						},
+						{
+							Name:        "aws_instance.web",
+							MonthlyCost: decimalPtr(decimal.NewFromInt(50)),
+							CostCenters: map[string]decimal.Decimal{"team-b": decimal.NewFromInt(100)},
+							Owner:       "@org/web",
+						},
+						{
+							Name:        "aws_instance.worker",
+							MonthlyCost: decimalPtr(decimal.NewFromInt(500)),
+						},
+						{
+							Name: "aws_vpc.main",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewCostCenters(t *testing.T) {
+	costCenters := NewCostCenters(testCostCentersRoot(), false)
+
+	require.Len(t, costCenters, 3)
+
+	assert.Equal(t, "team-b", costCenters[0].Name)
+	assert.Equal(t, "130", costCenters[0].MonthlyCost.String())
+	require.Len(t, costCenters[0].Resources, 2)
+	assert.Equal(t, "aws_db_instance.shared", costCenters[0].Resources[0].Name)
+	assert.Equal(t, "80", costCenters[0].Resources[0].MonthlyCost.String())
+	assert.Equal(t, "aws_instance.web", costCenters[0].Resources[1].Name)
+
+	assert.Equal(t, "team-a", costCenters[1].Name)
+	assert.Equal(t, "120", costCenters[1].MonthlyCost.String())
+
+	// unallocated resources are last even though they cost the most.
+	assert.Equal(t, UnallocatedCostCenter, costCenters[2].Name)
+	assert.Equal(t, "500", costCenters[2].MonthlyCost.String())
+	require.Len(t, costCenters[2].Resources, 1)
+}
+
+func TestToCSV(t *testing.T) {
+	b, err := ToCSV(testCostCentersRoot(), Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, `cost_center,project,resource,owner,percentage,monthly_cost,currency
+team-b,infra,aws_db_instance.shared,,40,80.00,USD
+team-b,infra,aws_instance.web,@org/web,100,50.00,USD
+team-a,infra,aws_db_instance.shared,,60,120.00,USD
+unallocated,infra,aws_instance.worker,,100,500.00,USD
+`, string(b))
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+)
+
+// CostComments returns the comment to write above each resource and module block in the
+// projects' Terraform files with its monthly cost, e.g. # infracost: ~$214/mo, keyed by
+// filename and the line the block starts on. Blocks that don't have a cost get an empty
+// comment so any previous comment is removed. Only projects whose path is a directory of
+// Terraform files can be annotated, since plan JSON files don't include where resources are
+// defined.
+func CostComments(out Root) map[string]map[int]string {
+	comments := map[string]map[int]string{}
+
+	for _, project := range out.Projects {
+		if project.Metadata == nil || project.Breakdown == nil {
+			continue
+		}
+
+		info, err := os.Stat(project.Metadata.Path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+
+		costs := map[string]decimal.Decimal{}
+		for _, r := range project.Breakdown.Resources {
+			if r.MonthlyCost == nil {
+				continue
+			}
+
+			addr := terraform.BlockAddress(r.Name)
+			costs[addr] = costs[addr].Add(*r.MonthlyCost)
+		}
+
+		for addr, loc := range terraform.LoadBlockLocations(project.Metadata.Path) {
+			if _, ok := comments[loc.Filename]; !ok {
+				comments[loc.Filename] = map[int]string{}
+			}
+
+			comment := ""
+			if cost, ok := costs[addr]; ok && !cost.IsZero() {
+				comment = fmt.Sprintf("%s ~%s/mo", terraform.CostCommentPrefix, formatCost(out.Currency, &cost))
+			}
+			comments[loc.Filename][loc.Line] = comment
+		}
+	}
+
+	return comments
+}
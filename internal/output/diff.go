@@ -22,13 +22,15 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 
 	noDiffProjects := make([]string, 0)
 
+	out = applyVerbosity(out, opts.Verbosity)
+
 	for i, project := range out.Projects {
 		if project.Diff == nil {
 			continue
 		}
 
 		// Check whether there is any diff or not
-		if len(project.Diff.Resources) == 0 {
+		if len(project.Diff.Resources) == 0 && project.hiddenDiffResourceCount == 0 {
 			noDiffProjects = append(noDiffProjects, project.Label(opts.DashboardEnabled))
 			continue
 		}
@@ -46,10 +48,16 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 			oldResource := findResourceByName(project.PastBreakdown.Resources, diffResource.Name)
 			newResource := findResourceByName(project.Breakdown.Resources, diffResource.Name)
 
-			s += resourceToDiff(out.Currency, diffResource, oldResource, newResource, true)
+			cause := explainResourceDiff(out.Currency, diffResource, oldResource, newResource, project.PastBreakdown.Resources, project.Breakdown.Resources)
+
+			s += resourceToDiff(out.Currency, diffResource, oldResource, newResource, cause, true)
 			s += "\n"
 		}
 
+		if project.hiddenDiffResourceCount > 0 {
+			s += fmt.Sprintf("%s\n\n", ui.FaintString(hiddenResourcesMessage(len(project.Diff.Resources), project.hiddenDiffResourceCount)))
+		}
+
 		var oldCost *decimal.Decimal
 		if project.PastBreakdown != nil {
 			oldCost = project.PastBreakdown.TotalMonthlyCost
@@ -74,6 +82,24 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 			)
 		}
 
+		if project.Budget != nil {
+			s += fmt.Sprintf("\nBudget:  %s",
+				formatBudget(out.Currency, project.Budget),
+			)
+		}
+
+		if project.Lifetime != nil {
+			s += fmt.Sprintf("\nLifetime cost: %s",
+				formatLifetime(out.Currency, project.Lifetime),
+			)
+		}
+
+		if project.Confidence != nil {
+			s += fmt.Sprintf("\nConfidence: %s",
+				formatConfidence(project.Confidence),
+			)
+		}
+
 		s += "\n\n"
 	}
 
@@ -84,6 +110,12 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 		s += "\n\n"
 	}
 
+	if len(out.CommittedSpend) > 0 {
+		s += "──────────────────────────────────\n"
+		s += formatCommittedSpend(out.Currency, out.CommittedSpend, true)
+		s += "\n"
+	}
+
 	s += "──────────────────────────────────\n"
 	if len(noDiffProjects) != len(out.Projects) {
 		s += fmt.Sprintf("Key: %s changed, %s added, %s removed\n",
@@ -104,7 +136,7 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 	return []byte(s), nil
 }
 
-func resourceToDiff(currency string, diffResource Resource, oldResource *Resource, newResource *Resource, isTopLevel bool) string {
+func resourceToDiff(currency string, diffResource Resource, oldResource *Resource, newResource *Resource, cause string, isTopLevel bool) string {
 	s := ""
 
 	op := UPDATED
@@ -140,6 +172,10 @@ func resourceToDiff(currency string, diffResource Resource, oldResource *Resourc
 				ui.FaintString(formatCostChangeDetails(currency, oldCost, newCost)),
 			)
 		}
+
+		if cause != "" {
+			s += fmt.Sprintf("  %s\n", cause)
+		}
 	}
 
 	for _, diffComponent := range diffResource.CostComponents {
@@ -169,7 +205,7 @@ func resourceToDiff(currency string, diffResource Resource, oldResource *Resourc
 		}
 
 		s += "\n"
-		s += ui.Indent(resourceToDiff(currency, diffSubResource, oldSubResource, newSubResource, false), "    ")
+		s += ui.Indent(resourceToDiff(currency, diffSubResource, oldSubResource, newSubResource, "", false), "    ")
 	}
 
 	return s
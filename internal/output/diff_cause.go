@@ -0,0 +1,201 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// diffCause is the root cause of the change in cost of a cost component.
+type diffCause struct {
+	delta       decimal.Decimal
+	explanation string
+}
+
+// explainResourceDiff returns a one-line explanation of why the cost of a top-level resource changed. The
+// change is attributed to an attribute change, a count change, a price change (e.g. from a change of region)
+// or a usage change. If more than one cost component changed, the one with the largest change in monthly
+// cost is used to explain it. It returns an empty string for resources that are simply added or removed.
+func explainResourceDiff(currency string, diffResource Resource, oldResource *Resource, newResource *Resource, oldResources []Resource, newResources []Resource) string {
+	if oldResource == nil || newResource == nil {
+		return explainCountChange(diffResource.Name, oldResources, newResources)
+	}
+
+	causes := diffCauses(currency, diffResource, oldResource, newResource)
+	if len(causes) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(causes, func(i, j int) bool {
+		return causes[i].delta.Abs().GreaterThan(causes[j].delta.Abs())
+	})
+
+	s := causes[0].explanation
+	if len(causes) == 2 {
+		s += " (+1 more change)"
+	} else if len(causes) > 2 {
+		s += fmt.Sprintf(" (+%d more changes)", len(causes)-1)
+	}
+
+	return s
+}
+
+// explainCountChange explains an added or removed resource as a change to the count or for_each of the
+// resource block if there are other instances of it in the past or current resources.
+func explainCountChange(name string, oldResources []Resource, newResources []Resource) string {
+	block := resourceBlockName(name)
+	if block == name {
+		return ""
+	}
+
+	oldCount := countResourceInstances(block, oldResources)
+	newCount := countResourceInstances(block, newResources)
+	if oldCount == 0 || newCount == 0 || oldCount == newCount {
+		return ""
+	}
+
+	return fmt.Sprintf("Count change: %s instances %d → %d", block, oldCount, newCount)
+}
+
+// resourceBlockName returns the name of a resource without its count or for_each index, e.g.
+// aws_instance.web for aws_instance.web[1].
+func resourceBlockName(name string) string {
+	if !strings.HasSuffix(name, "]") {
+		return name
+	}
+
+	i := strings.LastIndex(name, "[")
+	if i == -1 {
+		return name
+	}
+
+	return name[:i]
+}
+
+func countResourceInstances(block string, resources []Resource) int {
+	count := 0
+	for _, r := range resources {
+		if r.Name != block && resourceBlockName(r.Name) == block {
+			count++
+		}
+	}
+
+	return count
+}
+
+// diffCauses returns the causes of the changes to the cost components of the resource and its sub resources.
+func diffCauses(currency string, diffResource Resource, oldResource *Resource, newResource *Resource) []diffCause {
+	causes := make([]diffCause, 0)
+
+	for _, diffComponent := range diffResource.CostComponents {
+		var oldComponent, newComponent *CostComponent
+
+		if oldResource != nil {
+			oldComponent = findMatchingCostComponent(oldResource.CostComponents, diffComponent.Name)
+		}
+
+		if newResource != nil {
+			newComponent = findMatchingCostComponent(newResource.CostComponents, diffComponent.Name)
+		}
+
+		explanation := explainCostComponentDiff(currency, diffComponent, oldComponent, newComponent)
+		if explanation == "" {
+			continue
+		}
+
+		var delta decimal.Decimal
+		if diffComponent.MonthlyCost != nil {
+			delta = *diffComponent.MonthlyCost
+		}
+
+		causes = append(causes, diffCause{delta: delta, explanation: explanation})
+	}
+
+	for _, diffSubResource := range diffResource.SubResources {
+		var oldSubResource, newSubResource *Resource
+
+		if oldResource != nil {
+			oldSubResource = findResourceByName(oldResource.SubResources, diffSubResource.Name)
+		}
+
+		if newResource != nil {
+			newSubResource = findResourceByName(newResource.SubResources, diffSubResource.Name)
+		}
+
+		causes = append(causes, diffCauses(currency, diffSubResource, oldSubResource, newSubResource)...)
+	}
+
+	return causes
+}
+
+// explainCostComponentDiff returns the explanation of a change to a cost component. Changes to the name
+// of the cost component come from attribute changes, e.g. the instance type, since they change the labels
+// in the name. Otherwise a change to the price with the same name is a price change, e.g. from a change of
+// region, and a change to the quantity with the same price is a usage change.
+func explainCostComponentDiff(currency string, diffComponent CostComponent, oldComponent *CostComponent, newComponent *CostComponent) string {
+	baseName := strings.SplitN(diffComponent.Name, " (", 2)[0]
+
+	switch {
+	case oldComponent == nil:
+		return fmt.Sprintf("Attribute change: %s added", diffComponent.Name)
+	case newComponent == nil:
+		return fmt.Sprintf("Attribute change: %s removed", diffComponent.Name)
+	case oldComponent.Name != newComponent.Name:
+		return fmt.Sprintf("Attribute change: %s %s", baseName, changedLabels(diffComponent.Name))
+	case !oldComponent.Price.Equal(newComponent.Price):
+		return fmt.Sprintf("Price change: %s %s → %s per %s",
+			baseName,
+			formatPrice(currency, oldComponent.Price),
+			formatPrice(currency, newComponent.Price),
+			diffComponent.Unit,
+		)
+	case !quantityEqual(oldComponent.MonthlyQuantity, newComponent.MonthlyQuantity):
+		return fmt.Sprintf("Usage change: %s %s → %s %s",
+			baseName,
+			formatQuantity(oldComponent.MonthlyQuantity),
+			formatQuantity(newComponent.MonthlyQuantity),
+			diffComponent.Unit,
+		)
+	}
+
+	return ""
+}
+
+// changedLabels returns the labels of a diff cost component name that have changed, e.g. t3.small → t3.medium
+// for "Instance usage (Linux/UNIX, on-demand, t3.small → t3.medium)".
+func changedLabels(name string) string {
+	i := strings.Index(name, " (")
+	if i == -1 {
+		return ""
+	}
+
+	labels := name[i+1:]
+
+	// The labels are shown as (old, labels) → (new, labels) when the number of labels changed.
+	if strings.Contains(labels, ") → (") {
+		return labels
+	}
+
+	changed := make([]string, 0)
+	for _, label := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(labels, "("), ")"), ", ") {
+		if strings.Contains(label, " → ") {
+			changed = append(changed, label)
+		}
+	}
+
+	if len(changed) == 0 {
+		return labels
+	}
+
+	return strings.Join(changed, ", ")
+}
+
+func quantityEqual(a *decimal.Decimal, b *decimal.Decimal) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(*b)
+}
@@ -0,0 +1,128 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainCostComponentDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		diff     CostComponent
+		old      *CostComponent
+		new      *CostComponent
+		expected string
+	}{
+		{
+			name:     "instance type",
+			diff:     CostComponent{Name: "Instance usage (Linux/UNIX, on-demand, t3.small → t3.medium)", Unit: "hours"},
+			old:      &CostComponent{Name: "Instance usage (Linux/UNIX, on-demand, t3.small)", Price: decimal.RequireFromString("0.0208")},
+			new:      &CostComponent{Name: "Instance usage (Linux/UNIX, on-demand, t3.medium)", Price: decimal.RequireFromString("0.0416")},
+			expected: "Attribute change: Instance usage t3.small → t3.medium",
+		},
+		{
+			name:     "label count",
+			diff:     CostComponent{Name: "Storage (gp2) → (gp3, 125 MB/s)", Unit: "GB"},
+			old:      &CostComponent{Name: "Storage (gp2)"},
+			new:      &CostComponent{Name: "Storage (gp3, 125 MB/s)"},
+			expected: "Attribute change: Storage (gp2) → (gp3, 125 MB/s)",
+		},
+		{
+			name:     "added",
+			diff:     CostComponent{Name: "Provisioned IOPS", Unit: "IOPS"},
+			new:      &CostComponent{Name: "Provisioned IOPS"},
+			expected: "Attribute change: Provisioned IOPS added",
+		},
+		{
+			name:     "removed",
+			diff:     CostComponent{Name: "Provisioned IOPS", Unit: "IOPS"},
+			old:      &CostComponent{Name: "Provisioned IOPS"},
+			expected: "Attribute change: Provisioned IOPS removed",
+		},
+		{
+			name:     "price",
+			diff:     CostComponent{Name: "Storage (general purpose SSD, gp2)", Unit: "GB"},
+			old:      &CostComponent{Name: "Storage (general purpose SSD, gp2)", Price: decimal.RequireFromString("0.1"), MonthlyQuantity: decimalPtr(decimal.RequireFromString("8"))},
+			new:      &CostComponent{Name: "Storage (general purpose SSD, gp2)", Price: decimal.RequireFromString("0.12"), MonthlyQuantity: decimalPtr(decimal.RequireFromString("8"))},
+			expected: "Price change: Storage $0.10 → $0.12 per GB",
+		},
+		{
+			name:     "usage",
+			diff:     CostComponent{Name: "Requests", Unit: "1M requests"},
+			old:      &CostComponent{Name: "Requests", Price: decimal.RequireFromString("0.2")},
+			new:      &CostComponent{Name: "Requests", Price: decimal.RequireFromString("0.2"), MonthlyQuantity: decimalPtr(decimal.RequireFromString("100"))},
+			expected: "Usage change: Requests - → 100 1M requests",
+		},
+		{
+			name: "unchanged",
+			diff: CostComponent{Name: "Requests", Unit: "1M requests"},
+			old:  &CostComponent{Name: "Requests", MonthlyQuantity: decimalPtr(decimal.RequireFromString("100"))},
+			new:  &CostComponent{Name: "Requests", MonthlyQuantity: decimalPtr(decimal.RequireFromString("100"))},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, explainCostComponentDiff("USD", test.diff, test.old, test.new))
+		})
+	}
+}
+
+func TestExplainResourceDiff(t *testing.T) {
+	oldResources := []Resource{
+		{Name: "aws_instance.web[0]"},
+		{Name: "aws_instance.web[1]"},
+		{Name: "aws_instance.db"},
+		{
+			Name: "aws_instance.app",
+			CostComponents: []CostComponent{
+				{Name: "Instance usage (Linux/UNIX, on-demand, t3.small)", MonthlyCost: decimalPtr(decimal.RequireFromString("15"))},
+			},
+			SubResources: []Resource{
+				{
+					Name:           "root_block_device",
+					CostComponents: []CostComponent{{Name: "Storage (general purpose SSD, gp2)", MonthlyQuantity: decimalPtr(decimal.RequireFromString("8")), MonthlyCost: decimalPtr(decimal.RequireFromString("0.8"))}},
+				},
+			},
+		},
+	}
+	newResources := []Resource{
+		{Name: "aws_instance.web[0]"},
+		{Name: "aws_instance.web[1]"},
+		{Name: "aws_instance.web[2]"},
+		{
+			Name: "aws_instance.app",
+			CostComponents: []CostComponent{
+				{Name: "Instance usage (Linux/UNIX, on-demand, t3.medium)", MonthlyCost: decimalPtr(decimal.RequireFromString("30"))},
+			},
+			SubResources: []Resource{
+				{
+					Name:           "root_block_device",
+					CostComponents: []CostComponent{{Name: "Storage (general purpose SSD, gp2)", MonthlyQuantity: decimalPtr(decimal.RequireFromString("20")), MonthlyCost: decimalPtr(decimal.RequireFromString("2"))}},
+				},
+			},
+		},
+	}
+
+	added := Resource{Name: "aws_instance.web[2]"}
+	assert.Equal(t, "Count change: aws_instance.web instances 2 → 3", explainResourceDiff("USD", added, nil, &newResources[2], oldResources, newResources))
+
+	removed := Resource{Name: "aws_instance.db"}
+	assert.Equal(t, "", explainResourceDiff("USD", removed, &oldResources[2], nil, oldResources, newResources))
+
+	updated := Resource{
+		Name: "aws_instance.app",
+		CostComponents: []CostComponent{
+			{Name: "Instance usage (Linux/UNIX, on-demand, t3.small → t3.medium)", MonthlyCost: decimalPtr(decimal.RequireFromString("15"))},
+		},
+		SubResources: []Resource{
+			{
+				Name:           "root_block_device",
+				CostComponents: []CostComponent{{Name: "Storage (general purpose SSD, gp2)", Unit: "GB", MonthlyCost: decimalPtr(decimal.RequireFromString("1.2"))}},
+			},
+		},
+	}
+	assert.Equal(t, "Attribute change: Instance usage t3.small → t3.medium (+1 more change)", explainResourceDiff("USD", updated, &oldResources[3], &newResources[3], oldResources, newResources))
+}
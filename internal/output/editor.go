@@ -0,0 +1,91 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// FormatCostLens returns the monthly cost of the resources defined by a block to show above it
+// in an editor, e.g. $655.06/mo, or an empty string if the resources don't have a cost.
+func FormatCostLens(currency string, resources []*schema.Resource) string {
+	cost, ok := totalMonthlyCost(resources)
+	if !ok {
+		return ""
+	}
+
+	lens := fmt.Sprintf("%s/mo", formatCost2DP(currency, &cost))
+	if len(resources) > 1 {
+		lens += fmt.Sprintf(" across %d resources", len(resources))
+	}
+
+	return lens
+}
+
+// FormatCostHover returns Markdown describing the monthly cost of the resources defined by the
+// block with the given address to show when hovering over it in an editor. A single resource
+// is broken down by cost component, and multiple resources, e.g. from a module or count, are
+// listed with their monthly cost.
+func FormatCostHover(currency string, address string, resources []*schema.Resource) string {
+	var b strings.Builder
+
+	cost, ok := totalMonthlyCost(resources)
+	if ok {
+		fmt.Fprintf(&b, "**%s**: %s/mo\n", address, formatCost2DP(currency, &cost))
+	} else {
+		fmt.Fprintf(&b, "**%s**: no monthly cost\n", address)
+	}
+
+	if len(resources) == 1 && resources[0].Name == address {
+		r := resources[0]
+		if r.IsSkipped {
+			fmt.Fprintf(&b, "\n%s\n", r.SkipMessage)
+			return b.String()
+		}
+
+		b.WriteString("\n| Cost component | Monthly quantity | Unit | Monthly cost |\n")
+		b.WriteString("| --- | ---: | --- | ---: |\n")
+		writeHoverCostComponents(&b, currency, "", r)
+
+		return b.String()
+	}
+
+	b.WriteString("\n| Resource | Monthly cost |\n")
+	b.WriteString("| --- | ---: |\n")
+	for _, r := range resources {
+		fmt.Fprintf(&b, "| %s | %s |\n", r.Name, formatCost2DP(currency, r.MonthlyCost))
+	}
+
+	return b.String()
+}
+
+func writeHoverCostComponents(b *strings.Builder, currency string, prefix string, r *schema.Resource) {
+	for _, c := range r.CostComponents {
+		fmt.Fprintf(b, "| %s%s | %s | %s | %s |\n", prefix, c.Name, formatQuantity(c.MonthlyQuantity), c.Unit, formatCost2DP(currency, c.MonthlyCost))
+	}
+
+	for _, s := range r.SubResources {
+		writeHoverCostComponents(b, currency, prefix+s.Name+" / ", s)
+	}
+}
+
+// totalMonthlyCost returns the sum of the resources' monthly costs, and false if none of them
+// have a cost.
+func totalMonthlyCost(resources []*schema.Resource) (decimal.Decimal, bool) {
+	total := decimal.Zero
+	found := false
+
+	for _, r := range resources {
+		if r.MonthlyCost == nil {
+			continue
+		}
+
+		total = total.Add(*r.MonthlyCost)
+		found = true
+	}
+
+	return total, found && !total.IsZero()
+}
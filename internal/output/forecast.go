@@ -0,0 +1,81 @@
+package output
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// forecastMonths is the number of months that are included in a cost forecast.
+const forecastMonths = 12
+
+// Forecast is the projected monthly cost of a project over the next forecastMonths months,
+// based on the monthly growth rates set for its resources in the usage file.
+type Forecast struct {
+	Months []ForecastMonth `json:"months"`
+}
+
+type ForecastMonth struct {
+	Month       int              `json:"month"`
+	MonthlyCost *decimal.Decimal `json:"monthlyCost"`
+}
+
+// MaxMonthlyCost returns the highest monthly cost in the forecast.
+func (f *Forecast) MaxMonthlyCost() decimal.Decimal {
+	max := decimal.Zero
+	for _, m := range f.Months {
+		if m.MonthlyCost != nil && m.MonthlyCost.GreaterThan(max) {
+			max = *m.MonthlyCost
+		}
+	}
+
+	return max
+}
+
+// newForecast returns the forecast for the breakdown. Month 1 is the current estimate and each
+// following month compounds the growth rate of each resource. Resources without a growth rate
+// are forecast at their current cost. It returns nil if none of the resources have a growth rate.
+func newForecast(breakdown *Breakdown) *Forecast {
+	if breakdown == nil || !hasGrowthRate(breakdown.Resources) {
+		return nil
+	}
+
+	forecast := &Forecast{
+		Months: make([]ForecastMonth, 0, forecastMonths),
+	}
+
+	for month := 1; month <= forecastMonths; month++ {
+		cost := decimal.Zero
+		for _, r := range breakdown.Resources {
+			cost = cost.Add(applyGrowth(r.MonthlyCost, r.MonthlyGrowthRate, month))
+		}
+
+		forecast.Months = append(forecast.Months, ForecastMonth{
+			Month:       month,
+			MonthlyCost: decimalPtr(cost.Round(2)),
+		})
+	}
+
+	return forecast
+}
+
+func applyGrowth(cost *decimal.Decimal, rate *decimal.Decimal, month int) decimal.Decimal {
+	if cost == nil {
+		return decimal.Zero
+	}
+
+	if rate == nil || month <= 1 {
+		return *cost
+	}
+
+	multiplier := decimal.NewFromInt(1).Add(rate.Div(decimal.NewFromInt(100))).Pow(decimal.NewFromInt(int64(month - 1)))
+	return cost.Mul(multiplier)
+}
+
+func hasGrowthRate(resources []Resource) bool {
+	for _, r := range resources {
+		if r.MonthlyGrowthRate != nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,31 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewForecast(t *testing.T) {
+	assert.Nil(t, newForecast(&Breakdown{
+		Resources: []Resource{
+			{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+		},
+	}))
+
+	forecast := newForecast(&Breakdown{
+		Resources: []Resource{
+			{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+			{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromInt(100)), MonthlyGrowthRate: decimalPtr(decimal.NewFromInt(10))},
+		},
+	})
+
+	assert.Len(t, forecast.Months, 12)
+	assert.Equal(t, 1, forecast.Months[0].Month)
+	assert.Equal(t, "200", forecast.Months[0].MonthlyCost.String())
+	assert.Equal(t, "210", forecast.Months[1].MonthlyCost.String())
+	assert.Equal(t, "221", forecast.Months[2].MonthlyCost.String())
+	assert.Equal(t, "385.31", forecast.Months[11].MonthlyCost.String())
+	assert.Equal(t, "385.31", forecast.MaxMonthlyCost().String())
+}
@@ -0,0 +1,243 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/address"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The types of graph nodes.
+const (
+	GraphNodeProject  = "project"
+	GraphNodeModule   = "module"
+	GraphNodeResource = "resource"
+)
+
+// The types of graph edges. Contains edges go from a project or module to the modules and
+// resources in it, and references edges go from a resource to the resources it references.
+const (
+	GraphEdgeContains   = "contains"
+	GraphEdgeReferences = "references"
+)
+
+// Graph is the module and resource dependency graph of the projects, with the monthly cost of
+// each node so it's clear which modules and edges carry the spend.
+type Graph struct {
+	Currency string      `json:"currency"`
+	Nodes    []GraphNode `json:"nodes"`
+	Edges    []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a project, module or resource. The monthly cost of projects and modules is the
+// total cost of the resources in them.
+type GraphNode struct {
+	ID           string           `json:"id"`
+	Type         string           `json:"type"`
+	Project      string           `json:"project"`
+	Address      string           `json:"address,omitempty"`
+	ResourceType string           `json:"resourceType,omitempty"`
+	MonthlyCost  *decimal.Decimal `json:"monthlyCost"`
+}
+
+// GraphEdge is a dependency between two nodes. MonthlyCost is the cost of the node that contains
+// edges lead to, and is not set for references edges.
+type GraphEdge struct {
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	Type        string           `json:"type"`
+	MonthlyCost *decimal.Decimal `json:"monthlyCost,omitempty"`
+}
+
+// NewGraph builds the graph of the modules and resources in the projects from their addresses
+// and the references between resources found when parsing them.
+func NewGraph(projects []*schema.Project, currency string) Graph {
+	g := Graph{
+		Currency: currency,
+		Nodes:    []GraphNode{},
+		Edges:    []GraphEdge{},
+	}
+
+	index := map[string]int{}
+
+	addNode := func(n GraphNode) int {
+		if i, ok := index[n.ID]; ok {
+			return i
+		}
+
+		cost := decimal.Zero
+		if n.MonthlyCost == nil && n.Type != GraphNodeResource {
+			n.MonthlyCost = &cost
+		}
+
+		g.Nodes = append(g.Nodes, n)
+		index[n.ID] = len(g.Nodes) - 1
+		return index[n.ID]
+	}
+
+	addCost := func(i int, cost *decimal.Decimal) {
+		if cost == nil {
+			return
+		}
+		total := g.Nodes[i].MonthlyCost.Add(*cost)
+		g.Nodes[i].MonthlyCost = &total
+	}
+
+	for _, project := range projects {
+		projectIdx := addNode(GraphNode{ID: project.Name, Type: GraphNodeProject, Project: project.Name})
+
+		resources := make([]*schema.Resource, len(project.Resources))
+		copy(resources, project.Resources)
+		sort.Slice(resources, func(i, j int) bool {
+			return resources[i].Name < resources[j].Name
+		})
+
+		for _, r := range resources {
+			addCost(projectIdx, r.MonthlyCost)
+
+			parent := project.Name
+			for _, module := range moduleAddresses(r.Name) {
+				id := project.Name + ":" + module
+				_, exists := index[id]
+
+				i := addNode(GraphNode{ID: id, Type: GraphNodeModule, Project: project.Name, Address: module})
+				addCost(i, r.MonthlyCost)
+
+				if !exists {
+					g.Edges = append(g.Edges, GraphEdge{From: parent, To: id, Type: GraphEdgeContains})
+				}
+				parent = id
+			}
+
+			id := project.Name + ":" + r.Name
+			addNode(GraphNode{
+				ID:           id,
+				Type:         GraphNodeResource,
+				Project:      project.Name,
+				Address:      r.Name,
+				ResourceType: r.ResourceType,
+				MonthlyCost:  r.MonthlyCost,
+			})
+			g.Edges = append(g.Edges, GraphEdge{From: parent, To: id, Type: GraphEdgeContains})
+		}
+
+		for _, r := range resources {
+			for _, ref := range r.References {
+				to := project.Name + ":" + ref
+				if _, ok := index[to]; !ok {
+					continue
+				}
+
+				g.Edges = append(g.Edges, GraphEdge{From: project.Name + ":" + r.Name, To: to, Type: GraphEdgeReferences})
+			}
+		}
+	}
+
+	for i, e := range g.Edges {
+		if e.Type == GraphEdgeContains {
+			g.Edges[i].MonthlyCost = g.Nodes[index[e.To]].MonthlyCost
+		}
+	}
+
+	return g
+}
+
+// moduleAddresses returns the address of each module that the resource or module is in, from the
+// outermost module, e.g. module.a.module.b.aws_instance.web is in module.a and module.a.module.b,
+// and module.a.module.b is in module.a.
+func moduleAddresses(addr string) []string {
+	m, rest, err := address.SplitModule(addr)
+	if err != nil || len(m) == 0 {
+		return nil
+	}
+
+	if rest == "" {
+		m = m[:len(m)-1]
+		if len(m) == 0 {
+			return nil
+		}
+	}
+
+	return m.Ancestors()
+}
+
+// ToGraphJSON returns the graph as indented JSON.
+func ToGraphJSON(g Graph) ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// ToGraphDot returns the graph in the Graphviz DOT language. Node labels include their monthly
+// cost, and contains edges are drawn thicker the more spend they carry.
+func ToGraphDot(g Graph) []byte {
+	maxCost := decimal.Zero
+	for _, e := range g.Edges {
+		if e.MonthlyCost != nil && e.MonthlyCost.GreaterThan(maxCost) {
+			maxCost = *e.MonthlyCost
+		}
+	}
+
+	var b bytes.Buffer
+
+	b.WriteString("digraph infracost {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, n := range g.Nodes {
+		// Label modules and resources with their address in the parent module, since the
+		// edges show which module they're in.
+		label := n.Address
+		if modules := moduleAddresses(n.Address); len(modules) > 0 {
+			label = strings.TrimPrefix(label, modules[len(modules)-1]+".")
+		}
+		label = dotEscape(label)
+		shape := "box"
+
+		switch n.Type {
+		case GraphNodeProject:
+			label = dotEscape(n.Project)
+			shape = "box3d"
+		case GraphNodeModule:
+			shape = "folder"
+		}
+
+		if n.MonthlyCost != nil {
+			label += `\n` + dotEscape(formatCost2DP(g.Currency, n.MonthlyCost)+"/mo")
+		}
+
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\", shape=%s];\n", dotEscape(n.ID), label, shape)
+	}
+
+	for _, e := range g.Edges {
+		var attrs string
+
+		if e.Type == GraphEdgeReferences {
+			attrs = "style=dashed, arrowhead=open"
+		} else if e.MonthlyCost != nil {
+			width := decimal.NewFromInt(1)
+			if maxCost.IsPositive() {
+				width = width.Add(e.MonthlyCost.Div(maxCost).Mul(decimal.NewFromInt(4)))
+			}
+			attrs = fmt.Sprintf("label=\"%s\", penwidth=%s", dotEscape(formatCost2DP(g.Currency, e.MonthlyCost)), width.StringFixed(1))
+		}
+
+		if attrs != "" {
+			attrs = " [" + attrs + "]"
+		}
+
+		fmt.Fprintf(&b, "  \"%s\" -> \"%s\"%s;\n", dotEscape(e.From), dotEscape(e.To), attrs)
+	}
+
+	b.WriteString("}\n")
+
+	return b.Bytes()
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
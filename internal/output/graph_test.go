@@ -0,0 +1,83 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestModuleAddresses(t *testing.T) {
+	assert.Nil(t, moduleAddresses("aws_instance.web"))
+	assert.Equal(t, []string{"module.a", "module.a.module.b"}, moduleAddresses("module.a.module.b.aws_instance.web"))
+	assert.Equal(t, []string{`module.a["x.y"]`}, moduleAddresses(`module.a["x.y"].aws_instance.web[0]`))
+	assert.Equal(t, []string{`module.a["x\"].y"]`}, moduleAddresses(`module.a["x\"].y"].aws_instance.web[0]`))
+	assert.Equal(t, []string{"module.a"}, moduleAddresses("module.a.module.b"))
+	assert.Nil(t, moduleAddresses("module.a"))
+}
+
+func TestNewGraph(t *testing.T) {
+	cost := func(f float64) *decimal.Decimal {
+		d := decimal.NewFromFloat(f)
+		return &d
+	}
+
+	projects := []*schema.Project{
+		{
+			Name: "prod",
+			Resources: []*schema.Resource{
+				{Name: "aws_instance.web", ResourceType: "aws_instance", MonthlyCost: cost(100)},
+				{Name: "module.db.aws_db_instance.main", ResourceType: "aws_db_instance", MonthlyCost: cost(50)},
+				{Name: "module.db.module.backup.aws_db_snapshot.main", ResourceType: "aws_db_snapshot", MonthlyCost: cost(10), References: []string{"module.db.aws_db_instance.main", "aws_missing.ref"}},
+				{Name: "module.db.aws_db_parameter_group.main", ResourceType: "aws_db_parameter_group"},
+			},
+		},
+	}
+
+	g := NewGraph(projects, "USD")
+
+	costs := map[string]string{}
+	for _, n := range g.Nodes {
+		c := "nil"
+		if n.MonthlyCost != nil {
+			c = n.MonthlyCost.String()
+		}
+		costs[n.ID] = c
+	}
+	assert.Equal(t, map[string]string{
+		"prod":                                "160",
+		"prod:aws_instance.web":               "100",
+		"prod:module.db":                      "60",
+		"prod:module.db.aws_db_instance.main": "50",
+		"prod:module.db.module.backup":        "10",
+		"prod:module.db.module.backup.aws_db_snapshot.main": "10",
+		"prod:module.db.aws_db_parameter_group.main":        "nil",
+	}, costs)
+
+	var edges []string
+	for _, e := range g.Edges {
+		edges = append(edges, e.From+" -"+e.Type+"-> "+e.To)
+	}
+	assert.Equal(t, []string{
+		"prod -contains-> prod:aws_instance.web",
+		"prod -contains-> prod:module.db",
+		"prod:module.db -contains-> prod:module.db.aws_db_instance.main",
+		"prod:module.db -contains-> prod:module.db.aws_db_parameter_group.main",
+		"prod:module.db -contains-> prod:module.db.module.backup",
+		"prod:module.db.module.backup -contains-> prod:module.db.module.backup.aws_db_snapshot.main",
+		"prod:module.db.module.backup.aws_db_snapshot.main -references-> prod:module.db.aws_db_instance.main",
+	}, edges)
+
+	require.NotNil(t, g.Edges[1].MonthlyCost)
+	assert.Equal(t, "60", g.Edges[1].MonthlyCost.String())
+	assert.Nil(t, g.Edges[3].MonthlyCost)
+	assert.Nil(t, g.Edges[6].MonthlyCost)
+
+	dot := string(ToGraphDot(g))
+	assert.Contains(t, dot, `"prod:module.db.module.backup" [label="module.backup\n$10.00/mo", shape=folder];`)
+	assert.Contains(t, dot, `"prod" -> "prod:aws_instance.web" [label="$100.00", penwidth=5.0];`)
+	assert.Contains(t, dot, `"prod:module.db" -> "prod:module.db.aws_db_parameter_group.main";`)
+}
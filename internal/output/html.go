@@ -49,6 +49,7 @@ func ToHTML(out Root, opts Options) ([]byte, error) {
 		"projectLabel": func(p Project) string {
 			return p.Label(opts.DashboardEnabled)
 		},
+		"forecastBarStyle": forecastBarStyle,
 	})
 	tmpl, err := tmpl.Parse(HTMLTemplate)
 	if err != nil {
@@ -69,3 +70,15 @@ func ToHTML(out Root, opts Options) ([]byte, error) {
 	bufw.Flush()
 	return buf.Bytes(), nil
 }
+
+// forecastBarStyle returns the CSS for the forecast chart bar of the month, sized relative to
+// the most expensive month of the forecast.
+func forecastBarStyle(f *Forecast, m ForecastMonth) template.CSS {
+	max := f.MaxMonthlyCost()
+	if m.MonthlyCost == nil || max.IsZero() {
+		return template.CSS("width: 0%")
+	}
+
+	width := m.MonthlyCost.Div(max).Mul(decimal.NewFromInt(100)).Round(0)
+	return template.CSS(fmt.Sprintf("width: %s%%", width.String())) // nolint:gosec
+}
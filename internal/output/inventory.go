@@ -0,0 +1,240 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// The statuses of the resource types in an inventory.
+const (
+	// InventoryPriced resource types have cost components that are priced with the pricing API.
+	InventoryPriced = "priced"
+	// InventoryFree resource types are supported but have no cost.
+	InventoryFree = "free"
+	// InventoryUnsupported resource types aren't supported by Infracost yet.
+	InventoryUnsupported = "unsupported"
+)
+
+// Inventory is the number of resources of each type in the projects, and whether they are priced, free
+// or unsupported. It is built without fetching any prices, so it can be used to estimate the pricing API
+// usage of new projects and to find the resource types that aren't supported yet.
+type Inventory struct {
+	Projects []InventoryProject `json:"projects"`
+	Summary  InventorySummary   `json:"summary"`
+}
+
+// InventoryProject is the inventory of a single project.
+type InventoryProject struct {
+	Name          string                  `json:"name"`
+	ResourceTypes []InventoryResourceType `json:"resourceTypes"`
+	Summary       InventorySummary        `json:"summary"`
+}
+
+// InventoryResourceType is the number of resources of a type in a project. PriceQueries is the number of
+// cost components of the resources, which is the number of pricing API queries needed to price them.
+type InventoryResourceType struct {
+	ResourceType string `json:"resourceType"`
+	Status       string `json:"status"`
+	Count        int    `json:"count"`
+	PriceQueries int    `json:"priceQueries"`
+}
+
+// InventorySummary is the total number of resources of each status and the pricing API queries they need.
+type InventorySummary struct {
+	TotalResources       int `json:"totalResources"`
+	PricedResources      int `json:"pricedResources"`
+	FreeResources        int `json:"freeResources"`
+	UnsupportedResources int `json:"unsupportedResources"`
+	PriceQueries         int `json:"priceQueries"`
+}
+
+func (s *InventorySummary) add(o InventorySummary) {
+	s.TotalResources += o.TotalResources
+	s.PricedResources += o.PricedResources
+	s.FreeResources += o.FreeResources
+	s.UnsupportedResources += o.UnsupportedResources
+	s.PriceQueries += o.PriceQueries
+}
+
+// NewInventory counts the resources of each type in the projects. Resources of providers that Infracost
+// doesn't support, e.g. random or null, aren't counted, the same as in the summary of the other outputs.
+func NewInventory(projects []*schema.Project) Inventory {
+	inv := Inventory{
+		Projects: make([]InventoryProject, 0, len(projects)),
+	}
+
+	for _, p := range projects {
+		ip := newInventoryProject(p)
+		inv.Summary.add(ip.Summary)
+		inv.Projects = append(inv.Projects, ip)
+	}
+
+	return inv
+}
+
+func newInventoryProject(p *schema.Project) InventoryProject {
+	counts := map[string]*InventoryResourceType{}
+	var summary InventorySummary
+
+	for _, r := range p.Resources {
+		if !terraform.HasSupportedProvider(r.ResourceType) {
+			continue
+		}
+
+		status := inventoryStatus(r)
+		queries := 0
+		if status == InventoryPriced {
+			queries = countCostComponents(r)
+		}
+
+		key := r.ResourceType + "/" + status
+		c, ok := counts[key]
+		if !ok {
+			c = &InventoryResourceType{ResourceType: r.ResourceType, Status: status}
+			counts[key] = c
+		}
+		c.Count++
+		c.PriceQueries += queries
+
+		summary.TotalResources++
+		summary.PriceQueries += queries
+
+		switch status {
+		case InventoryPriced:
+			summary.PricedResources++
+		case InventoryFree:
+			summary.FreeResources++
+		default:
+			summary.UnsupportedResources++
+		}
+	}
+
+	resourceTypes := make([]InventoryResourceType, 0, len(counts))
+	for _, c := range counts {
+		resourceTypes = append(resourceTypes, *c)
+	}
+
+	// The most common resource types are first, since they matter most for API usage and coverage.
+	sort.Slice(resourceTypes, func(i, j int) bool {
+		if resourceTypes[i].Count != resourceTypes[j].Count {
+			return resourceTypes[i].Count > resourceTypes[j].Count
+		}
+		if resourceTypes[i].ResourceType != resourceTypes[j].ResourceType {
+			return resourceTypes[i].ResourceType < resourceTypes[j].ResourceType
+		}
+		return resourceTypes[i].Status < resourceTypes[j].Status
+	})
+
+	return InventoryProject{
+		Name:          p.Name,
+		ResourceTypes: resourceTypes,
+		Summary:       summary,
+	}
+}
+
+// inventoryStatus returns the status of a resource, using the same rules as BuildSummary.
+func inventoryStatus(r *schema.Resource) string {
+	switch {
+	case r.NoPrice:
+		return InventoryFree
+	case r.IsSkipped:
+		return InventoryUnsupported
+	default:
+		return InventoryPriced
+	}
+}
+
+func countCostComponents(r *schema.Resource) int {
+	count := len(r.CostComponents)
+	for _, s := range r.FlattenedSubResources() {
+		count += len(s.CostComponents)
+	}
+
+	return count
+}
+
+// ToInventoryJSON returns the inventory as indented JSON.
+func ToInventoryJSON(inv Inventory) ([]byte, error) {
+	return json.MarshalIndent(inv, "", "  ")
+}
+
+// ToInventoryTable returns a table of the resource types of each project, followed by the totals.
+func ToInventoryTable(inv Inventory) []byte {
+	var b strings.Builder
+
+	for i, p := range inv.Projects {
+		if i != 0 {
+			b.WriteString("──────────────────────────────────\n")
+		}
+
+		fmt.Fprintf(&b, "%s %s\n\n", ui.BoldString("Project:"), p.Name)
+
+		if len(p.ResourceTypes) == 0 {
+			b.WriteString("No cloud resources were detected\n\n")
+			continue
+		}
+
+		b.WriteString(inventoryTable(p.ResourceTypes))
+		fmt.Fprintf(&b, "\n\n%s\n\n", formatInventorySummary(p.Summary))
+	}
+
+	if len(inv.Projects) > 1 {
+		b.WriteString("──────────────────────────────────\n")
+		fmt.Fprintf(&b, "%s %s\n", ui.BoldString("Total:"), formatInventorySummary(inv.Summary))
+	}
+
+	return []byte(b.String())
+}
+
+func inventoryTable(resourceTypes []InventoryResourceType) string {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Resource type"),
+		ui.UnderlineString("Status"),
+		ui.UnderlineString("Count"),
+		ui.UnderlineString("Price queries"),
+	})
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 4, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	for _, r := range resourceTypes {
+		queries := "-"
+		if r.Status == InventoryPriced {
+			queries = fmt.Sprint(r.PriceQueries)
+		}
+
+		t.AppendRow(table.Row{r.ResourceType, r.Status, r.Count, queries})
+	}
+
+	return t.Render()
+}
+
+func formatInventorySummary(s InventorySummary) string {
+	return fmt.Sprintf("%d cloud resources: %d priced, %d free, %d unsupported, %d price queries",
+		s.TotalResources,
+		s.PricedResources,
+		s.FreeResources,
+		s.UnsupportedResources,
+		s.PriceQueries,
+	)
+}
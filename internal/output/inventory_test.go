@@ -0,0 +1,53 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestNewInventory(t *testing.T) {
+	instance := func() *schema.Resource {
+		return &schema.Resource{
+			Name:           "aws_instance.web",
+			ResourceType:   "aws_instance",
+			CostComponents: []*schema.CostComponent{{Name: "Instance usage"}},
+			SubResources:   []*schema.Resource{{Name: "root_block_device", CostComponents: []*schema.CostComponent{{Name: "Storage"}}}},
+		}
+	}
+
+	projects := []*schema.Project{
+		{
+			Name: "web",
+			Resources: []*schema.Resource{
+				instance(),
+				instance(),
+				{Name: "aws_iam_role.web", ResourceType: "aws_iam_role", IsSkipped: true, NoPrice: true},
+				{Name: "random_id.suffix", ResourceType: "random_id", IsSkipped: true},
+			},
+		},
+		{
+			Name: "sync",
+			Resources: []*schema.Resource{
+				{Name: "aws_datasync_task.sync", ResourceType: "aws_datasync_task", IsSkipped: true},
+			},
+		},
+	}
+
+	inv := NewInventory(projects)
+	require.Len(t, inv.Projects, 2)
+
+	assert.Equal(t, []InventoryResourceType{
+		{ResourceType: "aws_instance", Status: InventoryPriced, Count: 2, PriceQueries: 4},
+		{ResourceType: "aws_iam_role", Status: InventoryFree, Count: 1},
+	}, inv.Projects[0].ResourceTypes)
+
+	assert.Equal(t, InventorySummary{TotalResources: 4, PricedResources: 2, FreeResources: 1, UnsupportedResources: 1, PriceQueries: 4}, inv.Summary)
+
+	table := string(ToInventoryTable(inv))
+	assert.Contains(t, table, "3 cloud resources: 2 priced, 1 free, 0 unsupported, 4 price queries")
+	assert.Contains(t, table, "Total: 4 cloud resources: 2 priced, 1 free, 1 unsupported, 4 price queries")
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Lifetime is the cost of an ephemeral project, e.g. a preview environment, over its lifetime. It's
+// prorated from the hourly cost, since the monthly cost overstates what short-lived projects cost.
+type Lifetime struct {
+	Duration string           `json:"duration"`
+	Hours    *decimal.Decimal `json:"hours"`
+	Cost     *decimal.Decimal `json:"cost"`
+}
+
+// newLifetime returns the cost of the project over the given lifetime. It returns nil if there is
+// no lifetime set.
+func newLifetime(lifetime string, hourlyCost *decimal.Decimal) *Lifetime {
+	if lifetime == "" {
+		return nil
+	}
+
+	d, err := schema.ParseLifetime(lifetime)
+	if err != nil {
+		log.Debugf("Ignoring project lifetime: %s", err)
+		return nil
+	}
+
+	cost := decimal.Zero
+	if hourlyCost != nil {
+		cost = *hourlyCost
+	}
+
+	hours := decimal.NewFromFloat(d.Hours())
+
+	return &Lifetime{
+		Duration: lifetime,
+		Hours:    decimalPtr(hours),
+		Cost:     decimalPtr(cost.Mul(hours)),
+	}
+}
+
+func formatLifetime(currency string, l *Lifetime) string {
+	return fmt.Sprintf("%s %s",
+		formatCost2DP(currency, l.Cost),
+		ui.FaintStringf("(for %s)", l.Duration),
+	)
+}
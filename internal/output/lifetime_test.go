@@ -0,0 +1,24 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLifetime(t *testing.T) {
+	assert.Nil(t, newLifetime("", decimalPtr(decimal.NewFromInt(2))))
+	assert.Nil(t, newLifetime("soon", decimalPtr(decimal.NewFromInt(2))))
+
+	l := newLifetime("72h", decimalPtr(decimal.NewFromFloat(1.5)))
+	require.NotNil(t, l)
+	assert.Equal(t, "72", l.Hours.String())
+	assert.Equal(t, "108", l.Cost.String())
+
+	l = newLifetime("2d", nil)
+	require.NotNil(t, l)
+	assert.Equal(t, "48", l.Hours.String())
+	assert.Equal(t, "0", l.Cost.String())
+}
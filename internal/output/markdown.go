@@ -3,11 +3,14 @@ package output
 import (
 	"bufio"
 	"bytes"
+	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/Masterminds/sprig"
 )
@@ -64,12 +67,102 @@ func formatCostChangeSentence(currency string, pastCost, cost *decimal.Decimal,
 	return "monthly cost will increase by " + formatMarkdownCostChange(currency, pastCost, cost, true) + " " + up
 }
 
+// ToMarkdown renders the comment markdown. If the comment is longer than the MaxMessageSize it's rendered
+// again with lower verbosity levels until it fits, so it can still be posted. If it's still too long the
+// diff output is truncated and links to the FullReportURL, or the share URL of the run, instead.
 func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) ([]byte, error) {
+	diff, err := markdownDiff(out, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := renderMarkdown(out, opts, markdownOpts, diff)
+	if err != nil {
+		return nil, err
+	}
+
+	maxSize := markdownOpts.MaxMessageSize
+	if maxSize <= 0 {
+		return b, nil
+	}
+
+	for len(b) > maxSize {
+		verbosity := lowerVerbosity(opts.Verbosity)
+		if verbosity == "" {
+			break
+		}
+
+		log.Debugf("Comment is %d characters, more than the maximum of %d, using %s verbosity", len(b), maxSize, verbosity)
+
+		opts.Verbosity = verbosity
+		diff, err = markdownDiff(out, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err = renderMarkdown(out, opts, markdownOpts, diff)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(b) <= maxSize {
+		return b, nil
+	}
+
+	log.Debugf("Comment is %d characters, more than the maximum of %d, truncating the diff output", len(b), maxSize)
+
+	reportURL := markdownOpts.FullReportURL
+	if reportURL == "" {
+		reportURL = out.ShareURL
+	}
+
+	msg := truncatedMessage(maxSize, reportURL)
+	diff = truncateLines(diff, len(diff)-(len(b)-maxSize)-len(msg)) + msg
+
+	return renderMarkdown(out, opts, markdownOpts, diff)
+}
+
+func markdownDiff(out Root, opts Options) (string, error) {
 	diff, err := ToDiff(out, opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "Failed to generate diff")
+		return "", errors.Wrap(err, "Failed to generate diff")
 	}
 
+	return ui.StripColor(string(diff)), nil
+}
+
+// truncatedMessage returns the message that is added to the end of the diff output when it's truncated
+// to fit in the comment.
+func truncatedMessage(maxSize int, reportURL string) string {
+	msg := fmt.Sprintf("\n...(truncated to fit the %d character comment limit)...\n", maxSize)
+	if reportURL != "" {
+		msg += fmt.Sprintf("See the full report: %s\n", reportURL)
+	}
+
+	return msg
+}
+
+// truncateLines returns the lines at the start of s that fit in maxLen bytes. It truncates on line breaks
+// so the output is deterministic and doesn't split any multi-byte characters.
+func truncateLines(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+
+	if maxLen <= 0 {
+		return ""
+	}
+
+	i := strings.LastIndex(s[:maxLen], "\n")
+	if i == -1 {
+		return ""
+	}
+
+	return s[:i+1]
+}
+
+func renderMarkdown(out Root, opts Options, markdownOpts MarkdownOptions, diff string) ([]byte, error) {
 	var buf bytes.Buffer
 	bufw := bufio.NewWriter(&buf)
 
@@ -102,7 +195,7 @@ func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) ([]byte, e
 	if markdownOpts.BasicSyntax {
 		t = CommentMarkdownTemplate
 	}
-	tmpl, err = tmpl.Parse(t)
+	tmpl, err := tmpl.Parse(t)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -120,12 +213,14 @@ func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) ([]byte, e
 		DiffOutput          string
 		Options             Options
 		MarkdownOptions     MarkdownOptions
+		OwnerCosts          []OwnerCost
 	}{
 		out,
 		skippedProjectCount,
-		ui.StripColor(string(diff)),
+		diff,
 		opts,
-		markdownOpts})
+		markdownOpts,
+		NewOwnerCosts(out)})
 	if err != nil {
 		return []byte{}, err
 	}
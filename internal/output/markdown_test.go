@@ -0,0 +1,47 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateLines(t *testing.T) {
+	s := "line → 1\nline → 2\nline → 3\n"
+
+	assert.Equal(t, s, truncateLines(s, len(s)))
+	assert.Equal(t, "line → 1\nline → 2\n", truncateLines(s, len(s)-1))
+	assert.Equal(t, "line → 1\n", truncateLines(s, 14))
+	assert.Equal(t, "", truncateLines(s, 5))
+	assert.Equal(t, "", truncateLines(s, -10))
+}
+
+func TestToMarkdownTruncated(t *testing.T) {
+	out := verbosityTestRoot(15)
+	out.ShareURL = "https://dashboard.infracost.io/share/1234"
+
+	quiet, err := ToMarkdown(out, Options{NoColor: true, Verbosity: VerbosityQuiet}, MarkdownOptions{})
+	require.NoError(t, err)
+
+	maxSize := len(quiet) - 10
+
+	b, err := ToMarkdown(out, Options{NoColor: true}, MarkdownOptions{MaxMessageSize: maxSize, FullReportURL: "https://ci.example.com/artifacts/infracost.txt"})
+	require.NoError(t, err)
+
+	comment := string(b)
+	assert.LessOrEqual(t, len(comment), maxSize)
+	assert.Contains(t, comment, "...(truncated to fit the")
+	assert.Contains(t, comment, "See the full report: https://ci.example.com/artifacts/infracost.txt")
+
+	// the comment is the same each time so it can be compared when updating comments.
+	again, err := ToMarkdown(out, Options{NoColor: true}, MarkdownOptions{MaxMessageSize: maxSize, FullReportURL: "https://ci.example.com/artifacts/infracost.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, comment, string(again))
+
+	// the share URL of the run is linked to if there's no full report URL.
+	b, err = ToMarkdown(out, Options{NoColor: true}, MarkdownOptions{MaxMessageSize: maxSize})
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(b), "See the full report: https://dashboard.infracost.io/share/1234"))
+}
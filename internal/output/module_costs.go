@@ -0,0 +1,110 @@
+package output
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// ModuleCostsVersion is the version of the module costs format. It's increased when a field is
+// changed or removed, so that registries that publish the costs can check they can read them.
+const ModuleCostsVersion = "0.1"
+
+// ModuleCosts is the cost summary of the examples of a Terraform module, in a format that can be
+// published with the module's docs so that consumers can see its indicative cost before using it.
+type ModuleCosts struct {
+	Version  string          `json:"version"`
+	Module   string          `json:"module"`
+	Currency string          `json:"currency"`
+	Examples []ModuleExample `json:"examples"`
+}
+
+// ModuleExample is the cost of one of the example configurations of a module. Resources that have
+// usage-based costs only include them if the example has a usage file.
+type ModuleExample struct {
+	Name             string                  `json:"name"`
+	Path             string                  `json:"path"`
+	TotalMonthlyCost *decimal.Decimal        `json:"totalMonthlyCost"`
+	Resources        []ModuleExampleResource `json:"resources"`
+	Summary          ModuleExampleSummary    `json:"summary"`
+}
+
+// ModuleExampleResource is the monthly cost of a resource in an example.
+type ModuleExampleResource struct {
+	Name         string           `json:"name"`
+	ResourceType string           `json:"resourceType"`
+	MonthlyCost  *decimal.Decimal `json:"monthlyCost"`
+}
+
+// ModuleExampleSummary is the number of resources in an example that are priced, free, usage-based
+// or not supported yet.
+type ModuleExampleSummary struct {
+	TotalResources       int `json:"totalResources"`
+	PricedResources      int `json:"pricedResources"`
+	FreeResources        int `json:"freeResources"`
+	UsageBasedResources  int `json:"usageBasedResources"`
+	UnsupportedResources int `json:"unsupportedResources"`
+}
+
+// NewModuleExample returns the cost of an example from the output of estimating it. The resources
+// are sorted by their monthly cost, most expensive first, and resources without a cost are left
+// out.
+func NewModuleExample(name, path string, r Root) ModuleExample {
+	e := ModuleExample{
+		Name:             name,
+		Path:             path,
+		TotalMonthlyCost: r.TotalMonthlyCost,
+		Resources:        []ModuleExampleResource{},
+	}
+
+	for _, p := range r.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, res := range p.Breakdown.Resources {
+			if res.MonthlyCost == nil || res.MonthlyCost.IsZero() {
+				continue
+			}
+
+			e.Resources = append(e.Resources, ModuleExampleResource{
+				Name:         res.Name,
+				ResourceType: res.ResourceType(),
+				MonthlyCost:  res.MonthlyCost,
+			})
+		}
+	}
+
+	sort.SliceStable(e.Resources, func(i, j int) bool {
+		if !e.Resources[i].MonthlyCost.Equal(*e.Resources[j].MonthlyCost) {
+			return e.Resources[i].MonthlyCost.GreaterThan(*e.Resources[j].MonthlyCost)
+		}
+		return e.Resources[i].Name < e.Resources[j].Name
+	})
+
+	if s := r.Summary; s != nil {
+		e.Summary = ModuleExampleSummary{
+			TotalResources:       intValue(s.TotalDetectedResources),
+			PricedResources:      intValue(s.TotalSupportedResources),
+			FreeResources:        intValue(s.TotalNoPriceResources),
+			UsageBasedResources:  intValue(s.TotalUsageBasedResources),
+			UnsupportedResources: intValue(s.TotalUnsupportedResources),
+		}
+	}
+
+	return e
+}
+
+// ToModuleCostsJSON returns the module costs as indented JSON.
+func ToModuleCostsJSON(c ModuleCosts) ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
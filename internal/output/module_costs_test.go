@@ -0,0 +1,57 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewModuleExample(t *testing.T) {
+	cost := func(s string) *decimal.Decimal {
+		d := decimal.RequireFromString(s)
+		return &d
+	}
+	count := func(i int) *int {
+		return &i
+	}
+
+	r := Root{
+		TotalMonthlyCost: cost("150"),
+		Projects: Projects{
+			{
+				Name: "examples/complete",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_nat_gateway.nat", MonthlyCost: cost("30")},
+						{Name: "aws_instance.web", MonthlyCost: cost("120")},
+						{Name: "aws_lambda_function.api"},
+						{Name: "aws_s3_bucket.logs", MonthlyCost: cost("0")},
+					},
+				},
+			},
+		},
+		Summary: &Summary{
+			TotalDetectedResources:    count(5),
+			TotalSupportedResources:   count(4),
+			TotalUsageBasedResources:  count(2),
+			TotalNoPriceResources:     count(1),
+			TotalUnsupportedResources: count(0),
+		},
+	}
+
+	e := NewModuleExample("complete", "examples/complete", r)
+
+	assert.Equal(t, "complete", e.Name)
+	assert.Equal(t, "examples/complete", e.Path)
+	assert.Equal(t, "150", e.TotalMonthlyCost.String())
+	assert.Equal(t, []ModuleExampleResource{
+		{Name: "aws_instance.web", ResourceType: "aws_instance", MonthlyCost: cost("120")},
+		{Name: "aws_nat_gateway.nat", ResourceType: "aws_nat_gateway", MonthlyCost: cost("30")},
+	}, e.Resources)
+	assert.Equal(t, ModuleExampleSummary{TotalResources: 5, PricedResources: 4, FreeResources: 1, UsageBasedResources: 2}, e.Summary)
+
+	empty := NewModuleExample("simple", "examples/simple", Root{})
+	assert.Empty(t, empty.Resources)
+	assert.NotNil(t, empty.Resources)
+}
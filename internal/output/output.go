@@ -10,6 +10,7 @@ import (
 
 	"github.com/shopspring/decimal"
 
+	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/providers/terraform"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
@@ -32,6 +33,8 @@ type Root struct {
 	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
 	TimeGenerated        time.Time        `json:"timeGenerated"`
 	Summary              *Summary         `json:"summary"`
+	CommittedSpend       []CommittedSpend `json:"committedSpend,omitempty"`
+	Pricing              *Pricing         `json:"pricing,omitempty"`
 	FullSummary          *Summary         `json:"-"`
 	IsCIRun              bool             `json:"-"`
 }
@@ -43,7 +46,17 @@ type Project struct {
 	Breakdown     *Breakdown              `json:"breakdown"`
 	Diff          *Breakdown              `json:"diff"`
 	Summary       *Summary                `json:"summary"`
+	Budget        *Budget                 `json:"budget,omitempty"`
+	Lifetime      *Lifetime               `json:"lifetime,omitempty"`
+	Confidence    *Confidence             `json:"confidence,omitempty"`
+	Forecast      *Forecast               `json:"forecast,omitempty"`
+	Advisories    []Advisory              `json:"advisories,omitempty"`
 	fullSummary   *Summary
+
+	// hiddenResourceCount and hiddenDiffResourceCount are the number of breakdown and diff resources
+	// that aren't shown because of the verbosity level.
+	hiddenResourceCount     int
+	hiddenDiffResourceCount int
 }
 
 // ToSchemaProject generates a schema.Project from a Project. The created schema.Project is not suitable to be
@@ -79,6 +92,12 @@ func convertOutputResources(outResources []Resource) []*schema.Resource {
 			HourlyCost:     resource.HourlyCost,
 			MonthlyCost:    resource.MonthlyCost,
 			ResourceType:   resource.ResourceType(),
+
+			MonthlyGrowthRate: resource.MonthlyGrowthRate,
+			CostCenters:       resource.CostCenters,
+			Owner:             resource.Owner,
+			HeuristicCategory: resource.HeuristicCategory,
+			Confidence:        resource.Confidence,
 		}
 	}
 
@@ -99,6 +118,19 @@ func convertCostComponents(outComponents []CostComponent) []*schema.CostComponen
 			MonthlyQuantity: c.MonthlyQuantity,
 		}
 		sc.SetPrice(c.Price)
+		if c.PriceEffectiveDate != nil {
+			sc.SetPriceEffectiveDate(*c.PriceEffectiveDate)
+		}
+
+		for _, t := range c.PriceTiers {
+			st := &schema.PriceTier{
+				Name:            t.Name,
+				MonthlyQuantity: t.MonthlyQuantity,
+				MonthlyCost:     t.MonthlyCost,
+			}
+			st.SetPrice(t.Price)
+			sc.PriceTiers = append(sc.PriceTiers, st)
+		}
 
 		components[i] = sc
 	}
@@ -142,6 +174,28 @@ func (p *Project) Label(dashboardEnabled bool) string {
 	return fmt.Sprintf("%s (%s)", p.Name, p.Metadata.Path)
 }
 
+// FormattedLabels returns the project labels from the config file as a sorted, comma separated
+// list of key=value pairs, e.g. "cost-center=1234, team=platform". If the project has no labels
+// this returns an empty string.
+func (p *Project) FormattedLabels() string {
+	if p.Metadata == nil || len(p.Metadata.Labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(p.Metadata.Labels))
+	for k := range p.Metadata.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, p.Metadata.Labels[k]))
+	}
+
+	return strings.Join(pairs, ", ")
+}
+
 type Breakdown struct {
 	Resources        []Resource       `json:"resources"`
 	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
@@ -156,16 +210,39 @@ type CostComponent struct {
 	Price           decimal.Decimal  `json:"price"`
 	HourlyCost      *decimal.Decimal `json:"hourlyCost"`
 	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
+	PriceTiers      []PriceTier      `json:"priceTiers,omitempty"`
+	// PriceEffectiveDate is the date that the price took effect in the cloud provider's price list.
+	PriceEffectiveDate *time.Time `json:"priceEffectiveDate,omitempty"`
+}
+
+// PriceTier is the quantity and cost of a tier of a tiered cost component.
+type PriceTier struct {
+	Name            string           `json:"name"`
+	MonthlyQuantity *decimal.Decimal `json:"monthlyQuantity"`
+	Price           decimal.Decimal  `json:"price"`
+	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
 }
 
 type Resource struct {
-	Name           string            `json:"name"`
-	Tags           map[string]string `json:"tags,omitempty"`
-	Metadata       map[string]string `json:"metadata"`
-	HourlyCost     *decimal.Decimal  `json:"hourlyCost"`
-	MonthlyCost    *decimal.Decimal  `json:"monthlyCost"`
-	CostComponents []CostComponent   `json:"costComponents,omitempty"`
-	SubResources   []Resource        `json:"subresources,omitempty"`
+	Name           string               `json:"name"`
+	Tags           map[string]string    `json:"tags,omitempty"`
+	Metadata       map[string]string    `json:"metadata"`
+	HourlyCost     *decimal.Decimal     `json:"hourlyCost"`
+	MonthlyCost    *decimal.Decimal     `json:"monthlyCost"`
+	CostComponents []CostComponent      `json:"costComponents,omitempty"`
+	SubResources   []Resource           `json:"subresources,omitempty"`
+	Suppressions   []schema.Suppression `json:"suppressions,omitempty"`
+	// MonthlyGrowthRate is the expected percentage growth in monthly cost per month.
+	MonthlyGrowthRate *decimal.Decimal `json:"monthlyGrowthRate,omitempty"`
+	// CostCenters is the percentage of the cost that is allocated to each cost center.
+	CostCenters map[string]decimal.Decimal `json:"costCenters,omitempty"`
+	// Owner is the owners of the resource from the repo's CODEOWNERS file, e.g. @org/platform.
+	Owner string `json:"owner,omitempty"`
+	// HeuristicCategory is set when the resource is unsupported and its cost is a rough estimate
+	// from the category it was guessed to be in: compute, storage or network.
+	HeuristicCategory string `json:"heuristicCategory,omitempty"`
+	// Confidence is the confidence level of the estimate of the resource: high, medium or low.
+	Confidence string `json:"confidence,omitempty"`
 }
 
 func (r Resource) ResourceType() string {
@@ -208,14 +285,20 @@ type Options struct {
 	Fields           []string
 	IncludeHTML      bool
 	PolicyChecks     PolicyCheck
+	// Verbosity sets how much detail the table, diff and comment formats show, see VerbosityLevels.
+	Verbosity string
 }
 
 // PolicyCheck holds information if a given run has any policy checks enabled.
 // This struct is used in templates to create useful cost policy outputs.
 type PolicyCheck struct {
-	Enabled  bool
-	Failures PolicyCheckFailures
-	Passed   []string
+	Enabled    bool
+	Failures   PolicyCheckFailures
+	Passed     []string
+	Suppressed []string
+	// ResourceFailures are the failures of rules that set the resource they're for, keyed by the
+	// resource name. These are also included in Failures.
+	ResourceFailures map[string][]string
 }
 
 // HasFailed returns if the PolicyCheck has any cost policy failures
@@ -223,6 +306,69 @@ func (p PolicyCheck) HasFailed() bool {
 	return len(p.Failures) > 0
 }
 
+// FindSuppression returns the first suppression that matches the given policy on the resource with
+// the given name in the project with the given name or path. Resource names are only unique within a
+// project, so the suppressions of resources in other projects are never used. It returns nil if the
+// policy is not suppressed for the resource.
+func (r *Root) FindSuppression(project string, resourceName string, policy string) *schema.Suppression {
+	for _, p := range r.Projects {
+		if p.Breakdown == nil || !p.matches(project) {
+			continue
+		}
+
+		for _, res := range p.Breakdown.Resources {
+			if res.Name != resourceName {
+				continue
+			}
+
+			for _, s := range res.Suppressions {
+				if s.Matches(policy) {
+					return &s
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// suppressedMonthlyCost returns the total monthly cost of the resources that have a suppression
+// matching the policy, and a description of each suppression so it can be audited.
+func suppressedMonthlyCost(resources []Resource, policy string) (decimal.Decimal, []string) {
+	total := decimal.Zero
+	var suppressed []string
+
+	for _, r := range resources {
+		for _, s := range r.Suppressions {
+			if !s.Matches(policy) {
+				continue
+			}
+
+			if r.MonthlyCost != nil {
+				total = total.Add(*r.MonthlyCost)
+			}
+
+			msg := fmt.Sprintf("%s suppressed on %s", policy, r.Name)
+			if s.Reason != "" {
+				msg += fmt.Sprintf(` (reason: "%s")`, s.Reason)
+			}
+			suppressed = append(suppressed, msg)
+			break
+		}
+	}
+
+	return total, suppressed
+}
+
+// matches returns true if the project has the given name or path.
+func (p Project) matches(project string) bool {
+	if p.Name == project {
+		return true
+	}
+
+	return p.Metadata != nil && p.Metadata.Path == project
+}
+
 // PolicyCheckFailures defines a list of policy check failures that can be collected from a policy evaluation.
 type PolicyCheckFailures []string
 
@@ -241,11 +387,22 @@ func (p PolicyCheckFailures) Error() string {
 	return out.String()
 }
 
+// ExitCode implements the clierror.ExitCoder interface so policy failures exit with the failure code.
+func (p PolicyCheckFailures) ExitCode() int {
+	return clierror.ExitCodeFailure
+}
+
 type MarkdownOptions struct {
 	WillUpdate          bool
 	WillReplace         bool
 	IncludeFeedbackLink bool
 	BasicSyntax         bool
+	// MaxMessageSize is the maximum length of the comment. If the comment is longer it is rendered again
+	// with a lower verbosity, and then truncated, so that it can still be posted.
+	MaxMessageSize int
+	// FullReportURL is linked to from comments that are truncated, e.g. the URL of a CI artifact with
+	// the full report.
+	FullReportURL string
 }
 
 func outputBreakdown(resources []*schema.Resource) *Breakdown {
@@ -273,13 +430,15 @@ func outputResource(r *schema.Resource) Resource {
 	comps := make([]CostComponent, 0, len(r.CostComponents))
 	for _, c := range r.CostComponents {
 		comps = append(comps, CostComponent{
-			Name:            c.Name,
-			Unit:            c.Unit,
-			HourlyQuantity:  c.UnitMultiplierHourlyQuantity(),
-			MonthlyQuantity: c.UnitMultiplierMonthlyQuantity(),
-			Price:           c.UnitMultiplierPrice(),
-			HourlyCost:      c.HourlyCost,
-			MonthlyCost:     c.MonthlyCost,
+			Name:               c.Name,
+			Unit:               c.Unit,
+			HourlyQuantity:     c.UnitMultiplierHourlyQuantity(),
+			MonthlyQuantity:    c.UnitMultiplierMonthlyQuantity(),
+			Price:              c.UnitMultiplierPrice(),
+			HourlyCost:         c.HourlyCost,
+			MonthlyCost:        c.MonthlyCost,
+			PriceTiers:         outputPriceTiers(c),
+			PriceEffectiveDate: c.PriceEffectiveDate(),
 		})
 	}
 
@@ -296,9 +455,42 @@ func outputResource(r *schema.Resource) Resource {
 		MonthlyCost:    r.MonthlyCost,
 		CostComponents: comps,
 		SubResources:   subresources,
+		Suppressions:   r.Suppressions,
+
+		MonthlyGrowthRate: r.MonthlyGrowthRate,
+		CostCenters:       r.CostCenters,
+		Owner:             r.Owner,
+		HeuristicCategory: r.HeuristicCategory,
+		Confidence:        r.Confidence,
 	}
 }
 
+// outputPriceTiers returns the price tiers of a tiered cost component in the units shown in the output.
+// Tiers aren't used when the cost component has a custom price, so they aren't returned.
+func outputPriceTiers(c *schema.CostComponent) []PriceTier {
+	if len(c.PriceTiers) == 0 || c.CustomPrice() != nil {
+		return nil
+	}
+
+	tiers := make([]PriceTier, 0, len(c.PriceTiers))
+	for _, t := range c.PriceTiers {
+		var quantity *decimal.Decimal
+		if t.MonthlyQuantity != nil {
+			q := t.MonthlyQuantity.Div(c.UnitMultiplier)
+			quantity = &q
+		}
+
+		tiers = append(tiers, PriceTier{
+			Name:            t.Name,
+			MonthlyQuantity: quantity,
+			Price:           t.Price().Mul(c.UnitMultiplier),
+			MonthlyCost:     t.MonthlyCost,
+		})
+	}
+
+	return tiers
+}
+
 func ToOutputFormat(projects []*schema.Project) (Root, error) {
 	var totalMonthlyCost, totalHourlyCost,
 		pastTotalMonthlyCost, pastTotalHourlyCost,
@@ -366,7 +558,14 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 			}
 		}
 
-		summary, err := BuildSummary(project.Resources, SummaryOptions{
+		// Summarize the past resources of projects whose resources are all destroyed, otherwise the
+		// output says that no resources were detected.
+		summaryResources := project.Resources
+		if project.HasDiff && len(project.Resources) == 0 {
+			summaryResources = project.PastResources
+		}
+
+		summary, err := BuildSummary(summaryResources, SummaryOptions{
 			OnlyFields: []string{
 				"TotalDetectedResources",
 				"TotalSupportedResources",
@@ -382,12 +581,19 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 		}
 		summaries = append(summaries, summary)
 
-		fullSummary, err := BuildSummary(project.Resources, SummaryOptions{IncludeUnsupportedProviders: true})
+		fullSummary, err := BuildSummary(summaryResources, SummaryOptions{IncludeUnsupportedProviders: true})
 		if err != nil {
 			return Root{}, err
 		}
 		fullSummaries = append(fullSummaries, fullSummary)
 
+		var budget *Budget
+		var lifetime *Lifetime
+		if project.Metadata != nil && breakdown != nil {
+			budget = newBudget(project.Metadata.MonthlyBudget, breakdown.TotalMonthlyCost)
+			lifetime = newLifetime(project.Metadata.Lifetime, breakdown.TotalHourlyCost)
+		}
+
 		outProjects = append(outProjects, Project{
 			Name:          project.Name,
 			Metadata:      project.Metadata,
@@ -395,6 +601,11 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 			Breakdown:     breakdown,
 			Diff:          diff,
 			Summary:       summary,
+			Budget:        budget,
+			Lifetime:      lifetime,
+			Confidence:    newConfidence(breakdown),
+			Forecast:      newForecast(breakdown),
+			Advisories:    newAdvisories(project.Resources),
 			fullSummary:   fullSummary,
 		})
 	}
@@ -411,6 +622,7 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 		TimeGenerated:        time.Now(),
 		Summary:              MergeSummaries(summaries),
 		FullSummary:          MergeSummaries(fullSummaries),
+		Pricing:              newPricing(outProjects),
 	}
 
 	return out, nil
@@ -566,7 +778,9 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) (*Summary,
 				noPriceResourceCounts[r.ResourceType] = 0
 			}
 			noPriceResourceCounts[r.ResourceType]++
-		} else if r.IsSkipped {
+		} else if r.IsSkipped || r.HeuristicCategory != "" {
+			// Resources with a heuristic estimate are still counted as unsupported since the
+			// estimate is only a guess from their type.
 			totalUnsupportedResources++
 			if _, ok := unsupportedResourceCounts[r.ResourceType]; !ok {
 				unsupportedResourceCounts[r.ResourceType] = 0
@@ -5,6 +5,8 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
 )
 
 func TestCalculateTotalCosts(t *testing.T) {
@@ -32,3 +34,48 @@ func TestCalculateTotalCosts(t *testing.T) {
 	actual, _ = totalMonthlyCost.Float64()
 	assert.Equal(t, expected, actual)
 }
+
+func TestProjectFormattedLabels(t *testing.T) {
+	p := Project{Name: "my-project"}
+	assert.Equal(t, "", p.FormattedLabels())
+
+	p.Metadata = &schema.ProjectMetadata{
+		Labels: map[string]string{
+			"team":        "platform",
+			"cost-center": "1234",
+		},
+	}
+	assert.Equal(t, "cost-center=1234, team=platform", p.FormattedLabels())
+}
+
+func TestFindSuppression(t *testing.T) {
+	suppressed := Resource{
+		Name:         "aws_instance.web",
+		Suppressions: []schema.Suppression{{Policy: "max-monthly-cost", Reason: "approved"}},
+	}
+	root := Root{
+		Projects: []Project{
+			{
+				Name:      "prod",
+				Metadata:  &schema.ProjectMetadata{Path: "infra/prod"},
+				Breakdown: &Breakdown{Resources: []Resource{suppressed}},
+			},
+			{
+				Name:      "dev",
+				Metadata:  &schema.ProjectMetadata{Path: "infra/dev"},
+				Breakdown: &Breakdown{Resources: []Resource{{Name: "aws_instance.web"}}},
+			},
+		},
+	}
+
+	s := root.FindSuppression("prod", "aws_instance.web", "max-monthly-cost")
+	if assert.NotNil(t, s) {
+		assert.Equal(t, "approved", s.Reason)
+	}
+	assert.NotNil(t, root.FindSuppression("infra/prod", "aws_instance.web", "max-monthly-cost"))
+
+	// a resource with the same name in another project isn't suppressed.
+	assert.Nil(t, root.FindSuppression("dev", "aws_instance.web", "max-monthly-cost"))
+	assert.Nil(t, root.FindSuppression("infra/dev", "aws_instance.web", "max-monthly-cost"))
+	assert.Nil(t, root.FindSuppression("prod", "aws_instance.web", "other-policy"))
+}
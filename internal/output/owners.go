@@ -0,0 +1,85 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// UnownedOwner is the owner of the resources that don't match any rule in the CODEOWNERS file.
+const UnownedOwner = "unowned"
+
+// OwnerCost is the monthly cost of the resources of an owner, before and after the change.
+type OwnerCost struct {
+	Owner           string
+	PastMonthlyCost *decimal.Decimal
+	MonthlyCost     *decimal.Decimal
+}
+
+// NewOwnerCosts rolls up the monthly cost of the resources of the projects by their owners from the
+// CODEOWNERS file. It returns nil if none of the resources have an owner. Owners with the highest
+// costs are first, and the unowned resources are last.
+func NewOwnerCosts(out Root) []OwnerCost {
+	owners := map[string]*OwnerCost{}
+	hasOwner := false
+
+	add := func(r Resource, past bool) {
+		if r.MonthlyCost == nil {
+			return
+		}
+
+		name := r.Owner
+		if name == "" {
+			name = UnownedOwner
+		} else {
+			hasOwner = true
+		}
+
+		o, ok := owners[name]
+		if !ok {
+			o = &OwnerCost{Owner: name, PastMonthlyCost: decimalPtr(decimal.Zero), MonthlyCost: decimalPtr(decimal.Zero)}
+			owners[name] = o
+		}
+
+		if past {
+			o.PastMonthlyCost = decimalPtr(o.PastMonthlyCost.Add(*r.MonthlyCost))
+		} else {
+			o.MonthlyCost = decimalPtr(o.MonthlyCost.Add(*r.MonthlyCost))
+		}
+	}
+
+	for _, p := range out.Projects {
+		if p.PastBreakdown != nil {
+			for _, r := range p.PastBreakdown.Resources {
+				add(r, true)
+			}
+		}
+
+		if p.Breakdown != nil {
+			for _, r := range p.Breakdown.Resources {
+				add(r, false)
+			}
+		}
+	}
+
+	if !hasOwner {
+		return nil
+	}
+
+	ownerCosts := make([]OwnerCost, 0, len(owners))
+	for _, o := range owners {
+		ownerCosts = append(ownerCosts, *o)
+	}
+
+	sort.Slice(ownerCosts, func(i, j int) bool {
+		if (ownerCosts[i].Owner == UnownedOwner) != (ownerCosts[j].Owner == UnownedOwner) {
+			return ownerCosts[j].Owner == UnownedOwner
+		}
+		if !ownerCosts[i].MonthlyCost.Equal(*ownerCosts[j].MonthlyCost) {
+			return ownerCosts[i].MonthlyCost.GreaterThan(*ownerCosts[j].MonthlyCost)
+		}
+		return ownerCosts[i].Owner < ownerCosts[j].Owner
+	})
+
+	return ownerCosts
+}
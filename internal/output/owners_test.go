@@ -0,0 +1,85 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOwnersRoot() Root {
+	return Root{
+		Currency:             "USD",
+		PastTotalMonthlyCost: decimalPtr(decimal.NewFromInt(150)),
+		TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(400)),
+		Projects: []Project{
+			{
+				Name: "infra",
+				PastBreakdown: &Breakdown{
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(150)),
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(100)), Owner: "@org/web"},
+						{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+					},
+				},
+				Breakdown: &Breakdown{
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(400)),
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(200)), Owner: "@org/web"},
+						{Name: "aws_db_instance.main", MonthlyCost: decimalPtr(decimal.NewFromInt(150)), Owner: "@org/dba @org/web"},
+						{Name: "aws_s3_bucket.logs", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+						{Name: "aws_vpc.main", Owner: "@org/network"},
+					},
+				},
+				Diff: &Breakdown{
+					Resources: []Resource{{Name: "aws_instance.web"}},
+				},
+			},
+		},
+	}
+}
+
+func TestNewOwnerCosts(t *testing.T) {
+	owners := NewOwnerCosts(testOwnersRoot())
+
+	require.Len(t, owners, 3)
+
+	assert.Equal(t, "@org/web", owners[0].Owner)
+	assert.Equal(t, "100", owners[0].PastMonthlyCost.String())
+	assert.Equal(t, "200", owners[0].MonthlyCost.String())
+
+	assert.Equal(t, "@org/dba @org/web", owners[1].Owner)
+	assert.Equal(t, "0", owners[1].PastMonthlyCost.String())
+	assert.Equal(t, "150", owners[1].MonthlyCost.String())
+
+	// unowned resources are last.
+	assert.Equal(t, UnownedOwner, owners[2].Owner)
+	assert.Equal(t, "50", owners[2].MonthlyCost.String())
+
+	out := testOwnersRoot()
+	for i := range out.Projects[0].Breakdown.Resources {
+		out.Projects[0].Breakdown.Resources[i].Owner = ""
+	}
+	for i := range out.Projects[0].PastBreakdown.Resources {
+		out.Projects[0].PastBreakdown.Resources[i].Owner = ""
+	}
+	assert.Nil(t, NewOwnerCosts(out))
+}
+
+func TestToMarkdownOwners(t *testing.T) {
+	b, err := ToMarkdown(testOwnersRoot(), Options{NoColor: true}, MarkdownOptions{BasicSyntax: true})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), `| **Owner** | **Previous** | **New** | **Diff** |
+| --------- | -----------: | ------: | -------- |
+| @org/web | $100 | $200 | +$100 (+100%) |
+| @org/dba @org/web | $0 | $150 | +$150 |
+| unowned | $50.00 | $50.00 | $0 |`)
+
+	b, err = ToMarkdown(testOwnersRoot(), Options{NoColor: true}, MarkdownOptions{})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), "<td>Owner</td>")
+	assert.Contains(t, string(b), "<td>@org/dba @org/web</td>")
+}
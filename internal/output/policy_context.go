@@ -0,0 +1,136 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// PolicyContextCost is the cost that's added to each resource_change of a Terraform plan JSON, so
+// plan policies can use it without joining the plan with the Infracost JSON, e.g.
+// input.resource_changes[_].cost.monthly in OPA or rc.cost.monthly in Sentinel. The costs are
+// numbers, rather than strings like in the Infracost JSON, so policies can compare them. They're
+// 0 for resources that aren't supported yet, which have Supported set to false.
+type PolicyContextCost struct {
+	Currency string `json:"currency"`
+	// Supported is false if the resource type isn't supported yet, so it doesn't have a cost.
+	Supported bool `json:"supported"`
+	// Free is true for resource types that don't cost anything, e.g. IAM roles.
+	Free            bool        `json:"free"`
+	Hourly          json.Number `json:"hourly"`
+	Monthly         json.Number `json:"monthly"`
+	PreviousMonthly json.Number `json:"previous_monthly"`
+	MonthlyDiff     json.Number `json:"monthly_diff"`
+}
+
+// PolicyContextSummary is the total cost of the plan, which is added to the top level of the plan
+// JSON as infracost.
+type PolicyContextSummary struct {
+	Currency                 string      `json:"currency"`
+	TotalMonthlyCost         json.Number `json:"total_monthly_cost"`
+	PreviousTotalMonthlyCost json.Number `json:"previous_total_monthly_cost"`
+	TotalMonthlyDiff         json.Number `json:"total_monthly_diff"`
+}
+
+// ToPlanPolicyContext returns the Terraform plan JSON with the cost of each resource from the
+// project estimated from it added to its resource_change, and the total cost added at the top level.
+// The rest of the plan is unchanged, so existing plan policies can be run on it.
+func ToPlanPolicyContext(plan []byte, currency string, project *schema.Project) ([]byte, error) {
+	var doc map[string]interface{}
+
+	// Use json.Number so the numbers in the plan, e.g. large IDs, aren't changed by being read as floats.
+	d := json.NewDecoder(bytes.NewReader(plan))
+	d.UseNumber()
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	changes, ok := doc["resource_changes"].([]interface{})
+	if !ok {
+		return nil, errors.New("Terraform plan JSON doesn't have any resource_changes")
+	}
+
+	past := policyContextResources(project.PastResources)
+	current := policyContextResources(project.Resources)
+
+	for _, c := range changes {
+		change, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		address, _ := change["address"].(string)
+		change["cost"] = newPolicyContextCost(currency, past[address], current[address])
+	}
+
+	pastTotal := totalPolicyContextCost(project.PastResources)
+	total := totalPolicyContextCost(project.Resources)
+	doc["infracost"] = PolicyContextSummary{
+		Currency:                 currency,
+		TotalMonthlyCost:         policyContextNumber(total),
+		PreviousTotalMonthlyCost: policyContextNumber(pastTotal),
+		TotalMonthlyDiff:         policyContextNumber(total.Sub(pastTotal)),
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func newPolicyContextCost(currency string, past, current *schema.Resource) PolicyContextCost {
+	// Deleted resources are only in the prior state, so they're described by it.
+	r := current
+	if r == nil {
+		r = past
+	}
+
+	cost := PolicyContextCost{
+		Currency:  currency,
+		Supported: r != nil && (!r.IsSkipped || r.NoPrice),
+		Free:      r != nil && r.NoPrice,
+	}
+
+	hourly, monthly, pastMonthly := decimal.Zero, decimal.Zero, decimal.Zero
+	if current != nil && current.HourlyCost != nil {
+		hourly = *current.HourlyCost
+	}
+	if current != nil && current.MonthlyCost != nil {
+		monthly = *current.MonthlyCost
+	}
+	if past != nil && past.MonthlyCost != nil {
+		pastMonthly = *past.MonthlyCost
+	}
+
+	cost.Hourly = policyContextNumber(hourly)
+	cost.Monthly = policyContextNumber(monthly)
+	cost.PreviousMonthly = policyContextNumber(pastMonthly)
+	cost.MonthlyDiff = policyContextNumber(monthly.Sub(pastMonthly))
+
+	return cost
+}
+
+func policyContextResources(resources []*schema.Resource) map[string]*schema.Resource {
+	m := make(map[string]*schema.Resource, len(resources))
+	for _, r := range resources {
+		m[r.Name] = r
+	}
+
+	return m
+}
+
+func totalPolicyContextCost(resources []*schema.Resource) decimal.Decimal {
+	total := decimal.Zero
+	for _, r := range resources {
+		if r.MonthlyCost != nil {
+			total = total.Add(*r.MonthlyCost)
+		}
+	}
+
+	return total
+}
+
+func policyContextNumber(d decimal.Decimal) json.Number {
+	return json.Number(d.String())
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestToPlanPolicyContext(t *testing.T) {
+	plan := []byte(`{
+  "format_version": "0.1",
+  "resource_changes": [
+    {"address": "aws_instance.web", "change": {"actions": ["update"]}},
+    {"address": "aws_eip.old", "change": {"actions": ["delete"]}},
+    {"address": "aws_iam_role.web", "change": {"actions": ["create"]}},
+    {"address": "aws_unsupported.x", "change": {"actions": ["create"]}}
+  ],
+  "prior_state": {"serial": 12345678901234567890}
+}`)
+
+	project := &schema.Project{
+		PastResources: []*schema.Resource{
+			{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+			{Name: "aws_eip.old", MonthlyCost: decimalPtr(decimal.NewFromFloat(3.65))},
+		},
+		Resources: []*schema.Resource{
+			{Name: "aws_instance.web", HourlyCost: decimalPtr(decimal.NewFromFloat(0.2)), MonthlyCost: decimalPtr(decimal.NewFromInt(146))},
+			{Name: "aws_iam_role.web", IsSkipped: true, NoPrice: true},
+			{Name: "aws_unsupported.x", IsSkipped: true},
+		},
+	}
+
+	b, err := ToPlanPolicyContext(plan, "USD", project)
+	require.NoError(t, err)
+
+	doc := gjson.ParseBytes(b)
+
+	web := doc.Get(`resource_changes.#(address=="aws_instance.web").cost`)
+	assert.Equal(t, "USD", web.Get("currency").String())
+	assert.True(t, web.Get("supported").Bool())
+	assert.Equal(t, 0.2, web.Get("hourly").Float())
+	assert.Equal(t, 146.0, web.Get("monthly").Float())
+	assert.Equal(t, 100.0, web.Get("previous_monthly").Float())
+	assert.Equal(t, 46.0, web.Get("monthly_diff").Float())
+
+	eip := doc.Get(`resource_changes.#(address=="aws_eip.old").cost`)
+	assert.True(t, eip.Get("supported").Bool())
+	assert.Equal(t, 0.0, eip.Get("monthly").Float())
+	assert.Equal(t, -3.65, eip.Get("monthly_diff").Float())
+
+	role := doc.Get(`resource_changes.#(address=="aws_iam_role.web").cost`)
+	assert.True(t, role.Get("supported").Bool())
+	assert.True(t, role.Get("free").Bool())
+
+	unsupported := doc.Get(`resource_changes.#(address=="aws_unsupported.x").cost`)
+	assert.False(t, unsupported.Get("supported").Bool())
+	assert.Equal(t, gjson.Number, unsupported.Get("monthly").Type)
+
+	assert.Equal(t, 146.0, doc.Get("infracost.total_monthly_cost").Float())
+	assert.Equal(t, 103.65, doc.Get("infracost.previous_total_monthly_cost").Float())
+	assert.Equal(t, 42.35, doc.Get("infracost.total_monthly_diff").Float())
+
+	// The rest of the plan is unchanged, including numbers that don't fit in a float.
+	assert.Equal(t, "12345678901234567890", doc.Get("prior_state.serial").Raw)
+	assert.Equal(t, "0.1", doc.Get("format_version").String())
+}
+
+func TestToPlanPolicyContextNoResourceChanges(t *testing.T) {
+	_, err := ToPlanPolicyContext([]byte(`{"format_version": "0.1"}`), "USD", &schema.Project{})
+	assert.EqualError(t, err, "Terraform plan JSON doesn't have any resource_changes")
+}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"time"
+)
+
+// Pricing shows how current the prices of the estimates are, so reviewers and auditors know when the
+// costs were priced and how old the price lists are.
+type Pricing struct {
+	// OldestEffectiveDate and NewestEffectiveDate are the range of dates that the prices took effect
+	// in the price lists of the cloud providers.
+	OldestEffectiveDate *time.Time `json:"oldestEffectiveDate"`
+	NewestEffectiveDate *time.Time `json:"newestEffectiveDate"`
+	// RetrievedAt is when the oldest prices were retrieved from the pricing API, which is before the
+	// run if they're from a pricing snapshot.
+	RetrievedAt *time.Time `json:"retrievedAt"`
+}
+
+// newPricing returns the freshness of the prices of the projects. It returns nil if the pricing
+// backend didn't return any dates, e.g. for mock prices.
+func newPricing(projects []Project) *Pricing {
+	p := &Pricing{}
+
+	for _, project := range projects {
+		if project.Metadata != nil && project.Metadata.PricesRetrievedAt != nil {
+			p.RetrievedAt = earliestTime(p.RetrievedAt, project.Metadata.PricesRetrievedAt)
+		}
+
+		for _, b := range []*Breakdown{project.PastBreakdown, project.Breakdown} {
+			if b == nil {
+				continue
+			}
+
+			for _, r := range b.Resources {
+				p.addResource(r)
+			}
+		}
+	}
+
+	if p.OldestEffectiveDate == nil && p.RetrievedAt == nil {
+		return nil
+	}
+
+	return p
+}
+
+func (p *Pricing) addResource(r Resource) {
+	for _, c := range r.CostComponents {
+		if c.PriceEffectiveDate == nil {
+			continue
+		}
+
+		p.OldestEffectiveDate = earliestTime(p.OldestEffectiveDate, c.PriceEffectiveDate)
+		if p.NewestEffectiveDate == nil || c.PriceEffectiveDate.After(*p.NewestEffectiveDate) {
+			p.NewestEffectiveDate = c.PriceEffectiveDate
+		}
+	}
+
+	for _, s := range r.SubResources {
+		p.addResource(s)
+	}
+}
+
+// StaleSince returns when the prices were retrieved if that was longer ago than maxAge, otherwise nil.
+func (p *Pricing) StaleSince(maxAge time.Duration, now time.Time) *time.Time {
+	if p == nil || p.RetrievedAt == nil || now.Sub(*p.RetrievedAt) <= maxAge {
+		return nil
+	}
+
+	return p.RetrievedAt
+}
+
+func earliestTime(a, b *time.Time) *time.Time {
+	if a == nil || b.Before(*a) {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestNewPricing(t *testing.T) {
+	date := func(year int) *time.Time {
+		d := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	assert.Nil(t, newPricing([]Project{{Breakdown: &Breakdown{Resources: []Resource{{Name: "aws_instance.web", CostComponents: []CostComponent{{Name: "Instance usage"}}}}}}}))
+
+	p := newPricing([]Project{
+		{
+			Metadata: &schema.ProjectMetadata{PricesRetrievedAt: date(2024)},
+			Breakdown: &Breakdown{Resources: []Resource{
+				{
+					Name:           "aws_instance.web",
+					CostComponents: []CostComponent{{Name: "Instance usage", PriceEffectiveDate: date(2021)}},
+					SubResources: []Resource{
+						{Name: "root_block_device", CostComponents: []CostComponent{{Name: "Storage", PriceEffectiveDate: date(2019)}}},
+					},
+				},
+			}},
+		},
+		{
+			Metadata:      &schema.ProjectMetadata{PricesRetrievedAt: date(2023)},
+			PastBreakdown: &Breakdown{Resources: []Resource{{Name: "aws_nat_gateway.main", CostComponents: []CostComponent{{Name: "NAT gateway", PriceEffectiveDate: date(2022)}}}}},
+		},
+	})
+	require.NotNil(t, p)
+	assert.Equal(t, date(2019), p.OldestEffectiveDate)
+	assert.Equal(t, date(2022), p.NewestEffectiveDate)
+	assert.Equal(t, date(2023), p.RetrievedAt)
+
+	assert.Nil(t, p.StaleSince(2*365*24*time.Hour, *date(2024)))
+	assert.Equal(t, date(2023), p.StaleSince(30*24*time.Hour, *date(2024)))
+
+	var missing *Pricing
+	assert.Nil(t, missing.StaleSince(time.Hour, time.Now()))
+}
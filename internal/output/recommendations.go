@@ -0,0 +1,259 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// The rules that find savings opportunities.
+const (
+	// RuleUnattachedEIP finds Elastic IPs that aren't attached to an instance or network interface,
+	// which are charged for while they're idle.
+	RuleUnattachedEIP = "unattached_eip"
+	// RuleGP2Volume finds EBS volumes that use gp2, which costs more than gp3 for the same size.
+	RuleGP2Volume = "gp2_volume"
+	// RulePreviousGeneration finds instances of previous generation families, which cost more than
+	// the current generation.
+	RulePreviousGeneration = "previous_generation"
+	// RuleNATGatewayCount finds projects with more NAT gateways than availability zones.
+	RuleNATGatewayCount = "nat_gateway_count"
+	// RuleLoadBalancerCount finds projects with many application load balancers that could share
+	// one using host or path based routing.
+	RuleLoadBalancerCount = "load_balancer_count"
+	// RuleDetailedMonitoring finds instances that pay for detailed monitoring, which is often not
+	// needed outside production.
+	RuleDetailedMonitoring = "detailed_monitoring"
+)
+
+var (
+	// gp3 volumes cost 20% less per GB than gp2 and include a baseline of 3000 IOPS.
+	gp3Savings = decimal.NewFromFloat(0.2)
+
+	// previousGenerationSavings are the previous generation instance families with the current
+	// generation family to use instead, and how much less it costs for the same size in us-east-1.
+	previousGenerationSavings = map[string]struct {
+		family  string
+		savings decimal.Decimal
+	}{
+		"t2": {"t3", decimal.NewFromFloat(0.1)},
+		"m4": {"m5", decimal.NewFromFloat(0.04)},
+		"c4": {"c5", decimal.NewFromFloat(0.15)},
+		"r4": {"r5", decimal.NewFromFloat(0.05)},
+		"i2": {"i3", decimal.NewFromFloat(0.63)},
+	}
+
+	instanceUsageReg = regexp.MustCompile(`^Instance usage \(.*, ([a-z0-9]+)\.[a-z0-9]+\)$`)
+)
+
+const (
+	// maxNATGateways is the number of NAT gateways that a project needs for one in each availability
+	// zone, which most regions have three of.
+	maxNATGateways = 3
+	// maxLoadBalancers is the number of application load balancers in a project above which they
+	// should be consolidated.
+	maxLoadBalancers = 3
+)
+
+// Recommendation is a savings opportunity found in a project, with how much it would save each
+// month. The savings are estimates based on the current cost of the resource.
+type Recommendation struct {
+	Rule           string           `json:"rule"`
+	ProjectName    string           `json:"projectName"`
+	ResourceName   string           `json:"resourceName"`
+	Description    string           `json:"description"`
+	MonthlyCost    *decimal.Decimal `json:"monthlyCost"`
+	MonthlySavings *decimal.Decimal `json:"monthlySavings"`
+}
+
+// Recommendations are the savings opportunities found in the projects, most savings first.
+type Recommendations struct {
+	Currency            string           `json:"currency"`
+	Recommendations     []Recommendation `json:"recommendations"`
+	TotalMonthlySavings *decimal.Decimal `json:"totalMonthlySavings"`
+}
+
+// NewRecommendations scans the priced resources of the projects for common savings patterns.
+// Opportunities that wouldn't save anything, e.g. because a resource has no cost, are left out.
+func NewRecommendations(currency string, projects []*schema.Project) Recommendations {
+	recs := Recommendations{
+		Currency:        currency,
+		Recommendations: []Recommendation{},
+	}
+
+	for _, p := range projects {
+		recs.Recommendations = append(recs.Recommendations, projectRecommendations(p)...)
+	}
+
+	sort.SliceStable(recs.Recommendations, func(i, j int) bool {
+		a, b := recs.Recommendations[i], recs.Recommendations[j]
+		if !a.MonthlySavings.Equal(*b.MonthlySavings) {
+			return a.MonthlySavings.GreaterThan(*b.MonthlySavings)
+		}
+		if a.ProjectName != b.ProjectName {
+			return a.ProjectName < b.ProjectName
+		}
+		return a.ResourceName < b.ResourceName
+	})
+
+	total := decimal.Zero
+	for _, r := range recs.Recommendations {
+		total = total.Add(*r.MonthlySavings)
+	}
+	recs.TotalMonthlySavings = decimalPtr(total)
+
+	return recs
+}
+
+func projectRecommendations(p *schema.Project) []Recommendation {
+	var recs []Recommendation
+	var natGateways, loadBalancers []*schema.Resource
+
+	add := func(rule string, r *schema.Resource, description string, cost, savings decimal.Decimal) {
+		if !savings.IsPositive() {
+			return
+		}
+
+		recs = append(recs, Recommendation{
+			Rule:           rule,
+			ProjectName:    p.Name,
+			ResourceName:   r.Name,
+			Description:    description,
+			MonthlyCost:    decimalPtr(cost),
+			MonthlySavings: decimalPtr(savings.Round(2)),
+		})
+	}
+
+	for _, r := range p.Resources {
+		if r.IsSkipped || r.MonthlyCost == nil {
+			continue
+		}
+
+		switch r.ResourceType {
+		case "aws_eip":
+			add(RuleUnattachedEIP, r, "Elastic IP isn't attached to anything, release it if it's not needed", *r.MonthlyCost, *r.MonthlyCost)
+		case "aws_nat_gateway":
+			natGateways = append(natGateways, r)
+		case "aws_lb", "aws_alb":
+			if componentCost(r, "Application load balancer").IsPositive() {
+				loadBalancers = append(loadBalancers, r)
+			}
+		}
+
+		if r.ResourceType == "aws_db_instance" || r.ResourceType == "aws_dms_replication_instance" {
+			// RDS and DMS charge the same for gp2 and gp3 storage.
+			continue
+		}
+
+		for _, c := range allCostComponents(r) {
+			if c.MonthlyCost == nil {
+				continue
+			}
+
+			switch {
+			case strings.Contains(c.Name, "gp2"):
+				add(RuleGP2Volume, r, "Change the volume type from gp2 to gp3", *c.MonthlyCost, c.MonthlyCost.Mul(gp3Savings))
+			case c.Name == "EC2 detailed monitoring":
+				add(RuleDetailedMonitoring, r, "Turn off detailed monitoring if 1-minute metrics aren't needed", *c.MonthlyCost, *c.MonthlyCost)
+			default:
+				m := instanceUsageReg.FindStringSubmatch(c.Name)
+				if m == nil {
+					continue
+				}
+
+				if next, ok := previousGenerationSavings[m[1]]; ok {
+					add(RulePreviousGeneration, r, fmt.Sprintf("Change the instance family from %s to %s", m[1], next.family), *c.MonthlyCost, c.MonthlyCost.Mul(next.savings))
+				}
+			}
+		}
+	}
+
+	// the NAT gateways and load balancers over the limit are recommended for removal, in address
+	// order so the recommendations are the same for each run.
+	sort.SliceStable(natGateways, func(i, j int) bool { return natGateways[i].Name < natGateways[j].Name })
+	for i := maxNATGateways; i < len(natGateways); i++ {
+		cost := componentCost(natGateways[i], "NAT gateway")
+		add(RuleNATGatewayCount, natGateways[i], fmt.Sprintf("Project has %d NAT gateways, one per availability zone is enough", len(natGateways)), cost, cost)
+	}
+
+	sort.SliceStable(loadBalancers, func(i, j int) bool { return loadBalancers[i].Name < loadBalancers[j].Name })
+	for i := maxLoadBalancers; i < len(loadBalancers); i++ {
+		cost := componentCost(loadBalancers[i], "Application load balancer")
+		add(RuleLoadBalancerCount, loadBalancers[i], fmt.Sprintf("Project has %d application load balancers, share one using host or path based routing", len(loadBalancers)), cost, cost)
+	}
+
+	return recs
+}
+
+func allCostComponents(r *schema.Resource) []*schema.CostComponent {
+	components := append([]*schema.CostComponent{}, r.CostComponents...)
+	for _, s := range r.FlattenedSubResources() {
+		components = append(components, s.CostComponents...)
+	}
+
+	return components
+}
+
+// componentCost returns the monthly cost of the named cost component of the resource, or zero if
+// it doesn't have it.
+func componentCost(r *schema.Resource, name string) decimal.Decimal {
+	for _, c := range r.CostComponents {
+		if c.Name == name && c.MonthlyCost != nil {
+			return *c.MonthlyCost
+		}
+	}
+
+	return decimal.Zero
+}
+
+// ToRecommendationsJSON returns the recommendations as indented JSON.
+func ToRecommendationsJSON(recs Recommendations) ([]byte, error) {
+	return json.MarshalIndent(recs, "", "  ")
+}
+
+// ToRecommendationsTable returns a table of the recommendations, followed by the total savings.
+func ToRecommendationsTable(recs Recommendations) []byte {
+	if len(recs.Recommendations) == 0 {
+		return []byte("No savings opportunities were found\n")
+	}
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Project"),
+		ui.UnderlineString("Resource"),
+		ui.UnderlineString("Recommendation"),
+		ui.UnderlineString("Monthly savings"),
+	})
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 3, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 4, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	for _, r := range recs.Recommendations {
+		t.AppendRow(table.Row{r.ProjectName, r.ResourceName, r.Description, formatCost2DP(recs.Currency, r.MonthlySavings)})
+	}
+
+	var b strings.Builder
+	b.WriteString(t.Render())
+	fmt.Fprintf(&b, "\n\n%d savings opportunities could save %s/month\n", len(recs.Recommendations), formatCost2DP(recs.Currency, recs.TotalMonthlySavings))
+
+	return []byte(b.String())
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestNewRecommendations(t *testing.T) {
+	cost := func(s string) *decimal.Decimal {
+		d := decimal.RequireFromString(s)
+		return &d
+	}
+	resource := func(name, resourceType string, components ...*schema.CostComponent) *schema.Resource {
+		total := decimal.Zero
+		for _, c := range components {
+			total = total.Add(*c.MonthlyCost)
+		}
+		return &schema.Resource{Name: name, ResourceType: resourceType, CostComponents: components, MonthlyCost: &total}
+	}
+	component := func(name, monthlyCost string) *schema.CostComponent {
+		return &schema.CostComponent{Name: name, MonthlyCost: cost(monthlyCost)}
+	}
+
+	resources := []*schema.Resource{
+		resource("aws_eip.unattached", "aws_eip", component("IP address (if unused)", "3.65")),
+		resource("aws_eip.attached", "aws_eip", component("IP address (if unused)", "0")),
+		resource("aws_ebs_volume.data", "aws_ebs_volume", component("Storage (general purpose SSD, gp2)", "50")),
+		resource("aws_db_instance.db", "aws_db_instance", component("Storage (general purpose SSD, gp2)", "50")),
+		resource("aws_instance.old", "aws_instance",
+			component("Instance usage (Linux/UNIX, on-demand, c4.large)", "100"),
+			component("EC2 detailed monitoring", "2.1"),
+		),
+		resource("aws_instance.new", "aws_instance", component("Instance usage (Linux/UNIX, on-demand, c5.large)", "100")),
+	}
+	for i := 0; i < 4; i++ {
+		resources = append(resources, resource(fmt.Sprintf("aws_nat_gateway.nat[%d]", i), "aws_nat_gateway", component("NAT gateway", "32.85")))
+	}
+
+	recs := NewRecommendations("USD", []*schema.Project{{Name: "infra", Resources: resources}})
+
+	var got []string
+	for _, r := range recs.Recommendations {
+		got = append(got, fmt.Sprintf("%s %s %s", r.Rule, r.ResourceName, r.MonthlySavings))
+	}
+
+	assert.Equal(t, []string{
+		"nat_gateway_count aws_nat_gateway.nat[3] 32.85",
+		"previous_generation aws_instance.old 15",
+		"gp2_volume aws_ebs_volume.data 10",
+		"unattached_eip aws_eip.unattached 3.65",
+		"detailed_monitoring aws_instance.old 2.1",
+	}, got)
+	assert.Equal(t, "63.6", recs.TotalMonthlySavings.String())
+
+	empty := NewRecommendations("USD", nil)
+	assert.NotNil(t, empty.Recommendations)
+	assert.Equal(t, "No savings opportunities were found\n", string(ToRecommendationsTable(empty)))
+}
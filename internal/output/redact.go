@@ -0,0 +1,89 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Redact returns a copy of the root with anything that could identify the user's
+// infrastructure removed, so it can be shared outside of their organization. Project
+// names, paths, VCS metadata, resource names, tags and metadata are removed, while
+// resource types and costs are kept. Resource names are replaced consistently within
+// a project so the past breakdown, breakdown and diff can still be compared.
+func Redact(root Root) (Root, error) {
+	var redacted Root
+
+	// Round trip through JSON to get a deep copy so the original root isn't changed.
+	b, err := json.Marshal(root)
+	if err != nil {
+		return redacted, err
+	}
+
+	err = json.Unmarshal(b, &redacted)
+	if err != nil {
+		return redacted, err
+	}
+
+	redacted.RunID = ""
+	redacted.ShareURL = ""
+
+	for i := range redacted.Projects {
+		p := &redacted.Projects[i]
+		p.Name = fmt.Sprintf("project_%d", i+1)
+
+		if p.Metadata != nil {
+			p.Metadata = &schema.ProjectMetadata{
+				Path: p.Name,
+				Type: p.Metadata.Type,
+			}
+		}
+
+		names := map[string]string{}
+		for _, breakdown := range []*Breakdown{p.PastBreakdown, p.Breakdown, p.Diff} {
+			if breakdown == nil {
+				continue
+			}
+
+			redactResources(breakdown.Resources, names)
+		}
+	}
+
+	return redacted, nil
+}
+
+// redactResources replaces the resource names with their type and a counter, e.g.
+// aws_instance.resource_1. The names map is used to give the same resource the same
+// name across breakdowns.
+func redactResources(resources []Resource, names map[string]string) {
+	for i := range resources {
+		r := &resources[i]
+
+		name, ok := names[r.Name]
+		if !ok {
+			name = fmt.Sprintf("%s.resource_%d", r.ResourceType(), len(names)+1)
+			names[r.Name] = name
+		}
+
+		r.Name = name
+		r.Tags = nil
+		r.Metadata = map[string]string{}
+		r.Suppressions = nil
+
+		// Sub-resource names can contain user defined keys, e.g. from for_each.
+		redactSubResources(r.SubResources)
+	}
+}
+
+func redactSubResources(resources []Resource) {
+	for i := range resources {
+		r := &resources[i]
+		r.Name = fmt.Sprintf("subresource_%d", i+1)
+		r.Tags = nil
+		r.Metadata = map[string]string{}
+		r.Suppressions = nil
+
+		redactSubResources(r.SubResources)
+	}
+}
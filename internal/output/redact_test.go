@@ -0,0 +1,64 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestRedact(t *testing.T) {
+	cost := decimalPtr(decimal.NewFromInt(10))
+
+	root := Root{
+		RunID: "run-id",
+		Projects: []Project{
+			{
+				Name: "my-org/my-repo/prod",
+				Metadata: &schema.ProjectMetadata{
+					Path:       "/home/me/my-repo/prod",
+					Type:       "terraform_dir",
+					VCSRepoURL: "https://github.com/my-org/my-repo",
+				},
+				PastBreakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.payments", MonthlyCost: cost, Tags: map[string]string{"team": "payments"}},
+					},
+				},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.payments", MonthlyCost: cost, Metadata: map[string]string{"calls": "module.payments"}},
+						{
+							Name:         "module.db.aws_db_instance.customers",
+							MonthlyCost:  cost,
+							SubResources: []Resource{{Name: "customers_storage", MonthlyCost: cost}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	redacted, err := Redact(root)
+	require.NoError(t, err)
+
+	p := redacted.Projects[0]
+	assert.Equal(t, "", redacted.RunID)
+	assert.Equal(t, "project_1", p.Name)
+	assert.Equal(t, &schema.ProjectMetadata{Path: "project_1", Type: "terraform_dir"}, p.Metadata)
+
+	assert.Equal(t, "aws_instance.resource_1", p.PastBreakdown.Resources[0].Name)
+	assert.Nil(t, p.PastBreakdown.Resources[0].Tags)
+	assert.Equal(t, "aws_instance.resource_1", p.Breakdown.Resources[0].Name)
+	assert.Empty(t, p.Breakdown.Resources[0].Metadata)
+	assert.Equal(t, "aws_db_instance.resource_2", p.Breakdown.Resources[1].Name)
+	assert.Equal(t, "subresource_1", p.Breakdown.Resources[1].SubResources[0].Name)
+	assert.True(t, cost.Equal(*p.Breakdown.Resources[1].MonthlyCost))
+
+	// The original root should not be changed
+	assert.Equal(t, "my-org/my-repo/prod", root.Projects[0].Name)
+	assert.Equal(t, "aws_instance.payments", root.Projects[0].Breakdown.Resources[0].Name)
+}
@@ -17,9 +17,12 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 
 	s := ""
 
+	out = applyVerbosity(out, opts.Verbosity)
+
 	// Don't show the project total if there's only one project result
-	// since we will show the overall total anyway
-	includeProjectTotals := len(out.Projects) != 1
+	// since we will show the overall total anyway, unless it's the only
+	// thing shown for the project.
+	includeProjectTotals := len(out.Projects) != 1 || opts.Verbosity == VerbosityQuiet
 
 	for i, project := range out.Projects {
 		if project.Breakdown == nil {
@@ -35,7 +38,7 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 			project.Label(opts.DashboardEnabled),
 		)
 
-		tableOut := tableForBreakdown(out.Currency, *project.Breakdown, opts.Fields, includeProjectTotals)
+		tableOut := tableForBreakdown(out.Currency, *project.Breakdown, opts.Fields, includeProjectTotals, opts.Verbosity == VerbosityVerbose)
 
 		// Get the last table length so we can align the overall total with it
 		if i == len(out.Projects)-1 {
@@ -46,6 +49,26 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 
 		s += "\n"
 
+		if project.hiddenResourceCount > 0 {
+			s += fmt.Sprintf("\n%s\n", ui.FaintString(hiddenResourcesMessage(len(project.Breakdown.Resources), project.hiddenResourceCount)))
+		}
+
+		if project.Budget != nil {
+			s += fmt.Sprintf("\n%s %s\n", ui.BoldString("Budget:"), formatBudget(out.Currency, project.Budget))
+		}
+
+		if project.Lifetime != nil {
+			s += fmt.Sprintf("\n%s %s\n", ui.BoldString("Lifetime cost:"), formatLifetime(out.Currency, project.Lifetime))
+		}
+
+		if project.Confidence != nil {
+			s += fmt.Sprintf("\n%s %s\n", ui.BoldString("Confidence:"), formatConfidence(project.Confidence))
+		}
+
+		if len(project.Advisories) > 0 {
+			s += formatAdvisories(out.Currency, project.Advisories)
+		}
+
 		if i != len(out.Projects)-1 {
 			s += "\n"
 		}
@@ -63,6 +86,10 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 		fmt.Sprintf("%*s ", tableLen-(len(overallTitle)+1), totalOut), // pad based on the last line length
 	)
 
+	if len(out.CommittedSpend) > 0 {
+		s += "\n──────────────────────────────────\n" + formatCommittedSpend(out.Currency, out.CommittedSpend, false)
+	}
+
 	summaryMsg := out.summaryMessage(opts.ShowSkipped)
 
 	if summaryMsg != "" {
@@ -72,7 +99,7 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 	return []byte(s), nil
 }
 
-func tableForBreakdown(currency string, breakdown Breakdown, fields []string, includeTotal bool) string {
+func tableForBreakdown(currency string, breakdown Breakdown, fields []string, includeTotal bool, showTiers bool) string {
 	t := table.NewWriter()
 	t.Style().Options.DrawBorder = false
 	t.Style().Options.SeparateColumns = false
@@ -156,8 +183,8 @@ func tableForBreakdown(currency string, breakdown Breakdown, fields []string, in
 
 		t.AppendRow(table.Row{ui.BoldString(r.Name)})
 
-		buildCostComponentRows(t, currency, filteredComponents, "", len(r.SubResources) > 0, fields)
-		buildSubResourceRows(t, currency, filteredSubResources, "", fields)
+		buildCostComponentRows(t, currency, filteredComponents, "", len(r.SubResources) > 0, fields, showTiers)
+		buildSubResourceRows(t, currency, filteredSubResources, "", fields, showTiers)
 
 		t.AppendRow(table.Row{""})
 	}
@@ -176,7 +203,7 @@ func tableForBreakdown(currency string, breakdown Breakdown, fields []string, in
 	return t.Render()
 }
 
-func buildSubResourceRows(t table.Writer, currency string, subresources []Resource, prefix string, fields []string) {
+func buildSubResourceRows(t table.Writer, currency string, subresources []Resource, prefix string, fields []string, showTiers bool) {
 	for i, r := range subresources {
 		filteredComponents := filterZeroValComponents(r.CostComponents, r.Name)
 		filteredSubResources := filterZeroValResources(r.SubResources, r.Name)
@@ -193,16 +220,18 @@ func buildSubResourceRows(t table.Writer, currency string, subresources []Resour
 
 		t.AppendRow(table.Row{fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), r.Name)})
 
-		buildCostComponentRows(t, currency, filteredComponents, nextPrefix, len(r.SubResources) > 0, fields)
-		buildSubResourceRows(t, currency, filteredSubResources, nextPrefix, fields)
+		buildCostComponentRows(t, currency, filteredComponents, nextPrefix, len(r.SubResources) > 0, fields, showTiers)
+		buildSubResourceRows(t, currency, filteredSubResources, nextPrefix, fields, showTiers)
 	}
 }
 
-func buildCostComponentRows(t table.Writer, currency string, costComponents []CostComponent, prefix string, hasSubResources bool, fields []string) {
+func buildCostComponentRows(t table.Writer, currency string, costComponents []CostComponent, prefix string, hasSubResources bool, fields []string, showTiers bool) {
 	for i, c := range costComponents {
 		labelPrefix := prefix + "├─"
+		nextPrefix := prefix + "│  "
 		if !hasSubResources && i == len(costComponents)-1 {
 			labelPrefix = prefix + "└─"
+			nextPrefix = prefix + "   "
 		}
 
 		label := fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), c.Name)
@@ -241,10 +270,53 @@ func buildCostComponentRows(t table.Writer, currency string, costComponents []Co
 			}
 
 			t.AppendRow(tableRow)
+
+			if showTiers {
+				buildPriceTierRows(t, currency, c, nextPrefix, fields)
+			}
 		}
 	}
 }
 
+// buildPriceTierRows adds a row for each tier of a tiered cost component that has some of its quantity,
+// so the cost of the component can be checked against the price of each tier.
+func buildPriceTierRows(t table.Writer, currency string, c CostComponent, prefix string, fields []string) {
+	tiers := make([]PriceTier, 0, len(c.PriceTiers))
+	for _, tier := range c.PriceTiers {
+		if tier.MonthlyQuantity != nil && tier.MonthlyQuantity.IsPositive() {
+			tiers = append(tiers, tier)
+		}
+	}
+
+	for i, tier := range tiers {
+		labelPrefix := prefix + "├─"
+		if i == len(tiers)-1 {
+			labelPrefix = prefix + "└─"
+		}
+
+		var tableRow table.Row
+		tableRow = append(tableRow, ui.FaintString(fmt.Sprintf("%s %s", labelPrefix, tier.Name)))
+
+		if contains(fields, "price") {
+			tableRow = append(tableRow, formatPrice(currency, tier.Price))
+		}
+		if contains(fields, "monthlyQuantity") {
+			tableRow = append(tableRow, formatQuantity(tier.MonthlyQuantity))
+		}
+		if contains(fields, "unit") {
+			tableRow = append(tableRow, c.Unit)
+		}
+		if contains(fields, "hourlyCost") {
+			tableRow = append(tableRow, "")
+		}
+		if contains(fields, "monthlyCost") {
+			tableRow = append(tableRow, formatCost2DP(currency, tier.MonthlyCost))
+		}
+
+		t.AppendRow(tableRow)
+	}
+}
+
 func filterZeroValComponents(costComponents []CostComponent, resourceName string) []CostComponent {
 	var filteredComponents []CostComponent
 	for _, c := range costComponents {
@@ -114,6 +114,19 @@ table.overall-total {
   margin-top: 1rem;
 }
 
+table.forecast td.monthly-cost {
+  text-align: right;
+}
+
+table.forecast td.chart {
+  width: 20rem;
+}
+
+table.forecast .bar {
+  height: 0.75rem;
+  background-color: #6b7280;
+}
+
 {{end}}
 
 {{define "faviconBase64"}}
@@ -240,6 +253,30 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
       </tr>
     </tbody>
   </table>
+  {{if .Project.Forecast}}
+    {{template "forecastBlock" .Project.Forecast}}
+  {{end}}
+{{end}}
+
+{{define "forecastBlock"}}
+  {{$forecast := .}}
+  <p class="forecast-name">12 month forecast</p>
+  <table class="forecast">
+    <thead>
+      <th class="month">Month</th>
+      <td class="monthly-cost">{{ "Monthly Cost" | formatTitleWithCurrency }}</td>
+      <td class="chart"></td>
+    </thead>
+    <tbody>
+      {{range .Months}}
+        <tr>
+          <td class="month">{{.Month}}</td>
+          <td class="monthly-cost">{{.MonthlyCost | formatCost2DP}}</td>
+          <td class="chart"><div class="bar" style="{{forecastBarStyle $forecast .}}"></div></td>
+        </tr>
+      {{end}}
+    </tbody>
+  </table>
 {{end}}
 
 <!doctype html>
@@ -291,7 +328,7 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
 var CommentMarkdownWithHTMLTemplate = `
 {{- define "summaryRow"}}
     <tr>
-      <td>{{ truncateMiddle .Name 64 "..." }}</td>
+      <td>{{ truncateMiddle .Name 64 "..." }}{{ with .Labels }}<br><sub>{{ . }}</sub>{{ end }}</td>
       <td align="right">{{ formatCost .PastCost }}</td>
       <td align="right">{{ formatCost .Cost }}</td>
       <td>{{ formatCostChange .PastCost .Cost }}</td>
@@ -309,7 +346,7 @@ var CommentMarkdownWithHTMLTemplate = `
   <tbody>
   {{- range .Root.Projects }}
     {{- if hasDiff . }}
-      {{- template "summaryRow" dict "Name" .Name "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+      {{- template "summaryRow" dict "Name" .Name "Labels" .FormattedLabels "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
     {{- end }}
   {{- end }}
   {{- template "summaryRow" dict "Name" "All projects" "PastCost" .Root.PastTotalMonthlyCost "Cost" .Root.TotalMonthlyCost  }}
@@ -326,7 +363,23 @@ var CommentMarkdownWithHTMLTemplate = `
 {{- else }}
   <tbody>
   {{- range .Root.Projects }}
-    {{- template "summaryRow" dict "Name" .Name "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+    {{- template "summaryRow" dict "Name" .Name "Labels" .FormattedLabels "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+  {{- end }}
+  </tbody>
+</table>
+{{- end }}
+{{- if .OwnerCosts }}
+
+<table>
+  <thead>
+    <td>Owner</td>
+    <td>Previous</td>
+    <td>New</td>
+    <td>Diff</td>
+  </thead>
+  <tbody>
+  {{- range .OwnerCosts }}
+    {{- template "summaryRow" dict "Name" .Owner "PastCost" .PastMonthlyCost "Cost" .MonthlyCost }}
   {{- end }}
   </tbody>
 </table>
@@ -351,6 +404,14 @@ var CommentMarkdownWithHTMLTemplate = `
 		<details>
 			<summary><strong>✅ Policy checks passed</strong></summary>
 			{{ range $v, $f := .Options.PolicyChecks.Passed}}
+> {{ $f }}
+			{{- end}}
+		</details>
+	{{- end }}
+	{{- if gt (len .Options.PolicyChecks.Suppressed) 0 }}
+		<details>
+			<summary><strong>🔕 Policy checks suppressed</strong></summary>
+			{{ range $v, $f := .Options.PolicyChecks.Suppressed}}
 > {{ $f }}
 			{{- end}}
 		</details>
@@ -374,7 +435,7 @@ This comment will be replaced when the cost estimate changes
 
 var CommentMarkdownTemplate = `
 {{- define "summaryRow"}}
-| {{ truncateMiddle .Name 64 "..." }} | {{ formatCost .PastCost }} | {{ formatCost .Cost }} | {{ formatCostChange .PastCost .Cost }} |
+| {{ truncateMiddle .Name 64 "..." }}{{ with .Labels }} <sub>{{ . }}</sub>{{ end }} | {{ formatCost .PastCost }} | {{ formatCost .Cost }} | {{ formatCostChange .PastCost .Cost }} |
 {{- end }}
 {{- define "totalRow"}}
 | **{{ truncateMiddle .Name 64 "..." }}** | **{{ formatCost .PastCost }}** | **{{ formatCost .Cost }}** | **{{ formatCostChange .PastCost .Cost }}** |
@@ -387,7 +448,7 @@ var CommentMarkdownTemplate = `
 {{- if gt (len .Root.Projects) 1  }}
   {{- range .Root.Projects }}
     {{- if hasDiff . }}
-      {{- template "summaryRow" dict "Name" .Name "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+      {{- template "summaryRow" dict "Name" .Name "Labels" .FormattedLabels "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
     {{- end }}
   {{- end }}
   {{- template "totalRow" dict "Name" "All projects" "PastCost" .Root.PastTotalMonthlyCost "Cost" .Root.TotalMonthlyCost  }}
@@ -401,7 +462,15 @@ var CommentMarkdownTemplate = `
   {{- end }}
 {{- else }}
   {{- range .Root.Projects }}
-    {{- template "summaryRow" dict "Name" .Name "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+    {{- template "summaryRow" dict "Name" .Name "Labels" .FormattedLabels "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+  {{- end }}
+{{- end }}
+{{- if .OwnerCosts }}
+
+| **Owner** | **Previous** | **New** | **Diff** |
+| --------- | -----------: | ------: | -------- |
+  {{- range .OwnerCosts }}
+    {{- template "summaryRow" dict "Name" .Owner "PastCost" .PastMonthlyCost "Cost" .MonthlyCost }}
   {{- end }}
 {{- end }}
 
@@ -425,6 +494,14 @@ var CommentMarkdownTemplate = `
 			{{ range $v, $f := .Options.PolicyChecks.Passed}}
 > {{ $f }}
 			{{- end}}
+` + "```" /* can't escape backticks */ + `
+	{{- end }}
+	{{- if gt (len .Options.PolicyChecks.Suppressed) 0 }}
+**Policy checks suppressed:**
+` + "```" /* can't escape backticks */ + `
+			{{ range $v, $f := .Options.PolicyChecks.Suppressed}}
+> {{ $f }}
+			{{- end}}
 ` + "```" /* can't escape backticks */ + `
 	{{- end }}
 {{- end }}
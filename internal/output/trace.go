@@ -0,0 +1,81 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// FormatTrace returns the attributes and usage keys that were read to build a traced resource,
+// followed by the quantity, price and the filters sent to the pricing API for each of its cost
+// components.
+func FormatTrace(currency string, r *schema.Resource) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s\n", ui.BoldString("Trace for"), ui.BoldString(r.Name))
+
+	if r.Trace != nil {
+		b.WriteString("\nAttributes:\n")
+		writeTracedValues(&b, r.Trace.Attributes)
+		b.WriteString("\nUsage keys:\n")
+		writeTracedValues(&b, r.Trace.UsageKeys)
+	}
+
+	b.WriteString("\nCost components:\n")
+	writeTracedCostComponents(&b, currency, "", r)
+
+	return b.String()
+}
+
+func writeTracedValues(b *strings.Builder, values []schema.TracedValue) {
+	if len(values) == 0 {
+		b.WriteString("  none\n")
+		return
+	}
+
+	for _, v := range values {
+		switch {
+		case v.Default:
+			fmt.Fprintf(b, "  %s = %s %s\n", v.Key, v.Value, ui.FaintString("(default)"))
+		case v.Value == "":
+			fmt.Fprintf(b, "  %s %s\n", v.Key, ui.FaintString("(not set)"))
+		default:
+			fmt.Fprintf(b, "  %s = %s\n", v.Key, v.Value)
+		}
+	}
+}
+
+func writeTracedCostComponents(b *strings.Builder, currency string, prefix string, r *schema.Resource) {
+	for _, c := range r.CostComponents {
+		fmt.Fprintf(b, "\n  %s%s\n", prefix, c.Name)
+		fmt.Fprintf(b, "    Monthly quantity: %s %s\n", formatQuantity(c.MonthlyQuantity), c.Unit)
+		fmt.Fprintf(b, "    Price:            %s (price hash %s)\n", formatPrice(currency, c.Price()), valueOrNone(c.PriceHash()))
+		fmt.Fprintf(b, "    Monthly cost:     %s\n", formatCost(currency, c.MonthlyCost))
+		fmt.Fprintf(b, "    Product filter:   %s\n", marshalFilter(c.ProductFilter))
+		fmt.Fprintf(b, "    Price filter:     %s\n", marshalFilter(c.PriceFilter))
+	}
+
+	for _, s := range r.SubResources {
+		writeTracedCostComponents(b, currency, prefix+s.Name+" / ", s)
+	}
+}
+
+func marshalFilter(f interface{}) string {
+	j, err := json.Marshal(f)
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(j)
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+
+	return s
+}
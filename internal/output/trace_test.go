@@ -0,0 +1,53 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestFormatTrace(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	c := &schema.CostComponent{
+		Name:           "Instance usage (Linux/UNIX, on-demand, t3.micro)",
+		Unit:           "hours",
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr("t3.micro")},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+	}
+	c.SetPrice(decimal.NewFromFloat(0.0104))
+	c.SetPriceHash("abc")
+
+	tracer := schema.NewTracer()
+	d := schema.NewResourceData("aws_instance", "aws", "aws_instance.web", nil, gjson.Parse(`{"instance_type": "t3.micro"}`))
+	d.Tracer = tracer
+	d.Get("instance_type")
+	d.GetStringOrDefault("tenancy", "default")
+
+	r := &schema.Resource{
+		Name:           "aws_instance.web",
+		CostComponents: []*schema.CostComponent{c},
+		Trace:          tracer,
+	}
+	r.CalculateCosts()
+
+	out := FormatTrace("USD", r)
+	assert.Contains(t, out, "Trace for aws_instance.web")
+	assert.Contains(t, out, `instance_type = "t3.micro"`)
+	assert.Contains(t, out, "tenancy = default (default)")
+	assert.Contains(t, out, "Usage keys:\n  none")
+	assert.Contains(t, out, "Monthly quantity: 730 hours")
+	assert.Contains(t, out, "(price hash abc)")
+	assert.Contains(t, out, `Product filter:   {"vendorName":"aws","attributeFilters":[{"key":"instanceType","value":"t3.micro"}]}`)
+	assert.Contains(t, out, `Price filter:     {"purchaseOption":"on_demand"}`)
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// The verbosity levels set how much detail the table, diff and comment formats show. The JSON format
+// always has the full detail so that it can be used as the input of other commands.
+const (
+	// VerbosityVerbose shows the same as full and the price tiers of tiered cost components.
+	VerbosityVerbose = "verbose"
+	// VerbosityFull shows every resource and cost component.
+	VerbosityFull = "full"
+	// VerbositySummary shows the project totals and the resources with the largest costs.
+	VerbositySummary = "summary"
+	// VerbosityQuiet only shows the project totals.
+	VerbosityQuiet = "quiet"
+)
+
+// summaryResourceCount is the number of resources shown for each project with the summary verbosity.
+const summaryResourceCount = 10
+
+// VerbosityLevels returns the valid verbosity levels.
+func VerbosityLevels() []string {
+	return []string{VerbosityVerbose, VerbosityFull, VerbositySummary, VerbosityQuiet}
+}
+
+// IsValidVerbosity returns if v is a valid verbosity level. An empty level is the same as full.
+func IsValidVerbosity(v string) bool {
+	if v == "" {
+		return true
+	}
+
+	for _, level := range VerbosityLevels() {
+		if v == level {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lowerVerbosity returns the verbosity level that shows less detail than v, or an empty string if
+// there isn't one.
+func lowerVerbosity(v string) string {
+	switch v {
+	case VerbosityVerbose:
+		return VerbosityFull
+	case "", VerbosityFull:
+		return VerbositySummary
+	case VerbositySummary:
+		return VerbosityQuiet
+	default:
+		return ""
+	}
+}
+
+// applyVerbosity returns a copy of out with the resources of each project limited by the verbosity
+// level. The breakdown and diff totals aren't changed so they still include the resources that aren't
+// shown. The past breakdown is kept so that the diff can still look up the past resources.
+func applyVerbosity(out Root, verbosity string) Root {
+	if verbosity == "" || verbosity == VerbosityFull || verbosity == VerbosityVerbose {
+		return out
+	}
+
+	n := summaryResourceCount
+	if verbosity == VerbosityQuiet {
+		n = 0
+	}
+
+	projects := make([]Project, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		if p.Breakdown != nil {
+			b := *p.Breakdown
+			b.Resources, p.hiddenResourceCount = topResources(b.Resources, n)
+			p.Breakdown = &b
+		}
+
+		if p.Diff != nil {
+			d := *p.Diff
+			d.Resources, p.hiddenDiffResourceCount = topResources(d.Resources, n)
+			p.Diff = &d
+		}
+
+		projects = append(projects, p)
+	}
+
+	out.Projects = projects
+
+	return out
+}
+
+// hiddenResourcesMessage returns the message shown in place of the resources that aren't shown because of
+// the verbosity level.
+func hiddenResourcesMessage(shown int, hidden int) string {
+	more := ""
+	if shown > 0 {
+		more = "more "
+	}
+
+	if hidden == 1 {
+		return fmt.Sprintf("1 %sresource not shown", more)
+	}
+
+	return fmt.Sprintf("%d %sresources not shown", hidden, more)
+}
+
+// topResources returns the n resources with the largest monthly costs, or cost changes for diff resources,
+// and the number of resources that were left out.
+func topResources(resources []Resource, n int) ([]Resource, int) {
+	if len(resources) <= n {
+		return resources, 0
+	}
+
+	sorted := make([]Resource, len(resources))
+	copy(sorted, resources)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return absMonthlyCost(sorted[i]).GreaterThan(absMonthlyCost(sorted[j]))
+	})
+
+	return sorted[:n], len(resources) - n
+}
+
+func absMonthlyCost(r Resource) decimal.Decimal {
+	if r.MonthlyCost == nil {
+		return decimal.Zero
+	}
+
+	return r.MonthlyCost.Abs()
+}
@@ -0,0 +1,124 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func verbosityTestRoot(resourceCount int) Root {
+	resources := make([]Resource, 0, resourceCount)
+	total := decimal.Zero
+
+	for i := 0; i < resourceCount; i++ {
+		cost := decimal.NewFromInt(int64(i + 1))
+		total = total.Add(cost)
+
+		resources = append(resources, Resource{
+			Name:        fmt.Sprintf("aws_instance.web[%d]", i),
+			MonthlyCost: decimalPtr(cost),
+			CostComponents: []CostComponent{
+				{Name: "Instance usage (Linux/UNIX, on-demand, t3.micro)", Unit: "hours", MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)), MonthlyCost: decimalPtr(cost)},
+			},
+		})
+	}
+
+	return Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name:          "web",
+				PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.Zero)},
+				Breakdown:     &Breakdown{Resources: resources, TotalMonthlyCost: decimalPtr(total)},
+				Diff:          &Breakdown{Resources: resources, TotalMonthlyCost: decimalPtr(total)},
+			},
+		},
+		TotalMonthlyCost: decimalPtr(total),
+	}
+}
+
+func TestApplyVerbosity(t *testing.T) {
+	out := verbosityTestRoot(15)
+
+	full := applyVerbosity(out, VerbosityFull)
+	assert.Len(t, full.Projects[0].Breakdown.Resources, 15)
+
+	summary := applyVerbosity(out, VerbositySummary)
+	require.Len(t, summary.Projects[0].Breakdown.Resources, summaryResourceCount)
+	assert.Equal(t, "aws_instance.web[14]", summary.Projects[0].Breakdown.Resources[0].Name)
+	assert.Equal(t, "aws_instance.web[5]", summary.Projects[0].Breakdown.Resources[9].Name)
+	assert.Equal(t, 5, summary.Projects[0].hiddenResourceCount)
+	assert.Equal(t, 5, summary.Projects[0].hiddenDiffResourceCount)
+	assert.Equal(t, "120", summary.Projects[0].Breakdown.TotalMonthlyCost.String())
+
+	quiet := applyVerbosity(out, VerbosityQuiet)
+	assert.Empty(t, quiet.Projects[0].Diff.Resources)
+	assert.Equal(t, 15, quiet.Projects[0].hiddenDiffResourceCount)
+
+	// the original resources aren't changed.
+	assert.Len(t, out.Projects[0].Breakdown.Resources, 15)
+	assert.Equal(t, "aws_instance.web[0]", out.Projects[0].Breakdown.Resources[0].Name)
+}
+
+func TestToDiffSummary(t *testing.T) {
+	b, err := ToDiff(verbosityTestRoot(12), Options{NoColor: true, Verbosity: VerbositySummary})
+	require.NoError(t, err)
+
+	diff := string(b)
+	assert.Contains(t, diff, "aws_instance.web[11]")
+	assert.NotContains(t, diff, "aws_instance.web[1]\n")
+	assert.Contains(t, diff, "2 more resources not shown")
+	assert.Contains(t, diff, "Amount:  +$78.00")
+}
+
+func TestToMarkdownMaxMessageSize(t *testing.T) {
+	out := verbosityTestRoot(15)
+
+	full, err := ToMarkdown(out, Options{NoColor: true}, MarkdownOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(full), "aws_instance.web[0]")
+
+	summary, err := ToMarkdown(out, Options{NoColor: true}, MarkdownOptions{MaxMessageSize: len(full) - 1})
+	require.NoError(t, err)
+	assert.Less(t, len(summary), len(full))
+	assert.NotContains(t, string(summary), "aws_instance.web[0]")
+	assert.Contains(t, string(summary), "5 more resources not shown")
+
+	quiet, err := ToMarkdown(out, Options{NoColor: true}, MarkdownOptions{MaxMessageSize: len(summary) - 1})
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(string(quiet), "aws_instance.web["))
+	assert.Contains(t, string(quiet), "15 resources not shown")
+}
+
+func TestToTableVerbosePriceTiers(t *testing.T) {
+	out := verbosityTestRoot(1)
+	out.Projects[0].Breakdown.Resources[0].CostComponents = append(out.Projects[0].Breakdown.Resources[0].CostComponents, CostComponent{
+		Name:            "Data transfer out to internet",
+		Unit:            "GB",
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(15000)),
+		Price:           decimal.NewFromFloat(0.0818),
+		MonthlyCost:     decimalPtr(decimal.NewFromFloat(1226.8)),
+		PriceTiers: []PriceTier{
+			{Name: "first 10TB", MonthlyQuantity: decimalPtr(decimal.NewFromInt(10240)), Price: decimal.NewFromFloat(0.085), MonthlyCost: decimalPtr(decimal.NewFromFloat(870.4))},
+			{Name: "next 40TB", MonthlyQuantity: decimalPtr(decimal.NewFromInt(4760)), Price: decimal.NewFromFloat(0.08), MonthlyCost: decimalPtr(decimal.NewFromFloat(380.8))},
+			{Name: "next 100TB", MonthlyQuantity: decimalPtr(decimal.Zero), Price: decimal.NewFromFloat(0.06), MonthlyCost: decimalPtr(decimal.Zero)},
+		},
+	})
+
+	fields := []string{"monthlyQuantity", "unit", "monthlyCost"}
+
+	full, err := ToTable(out, Options{NoColor: true, Fields: fields})
+	require.NoError(t, err)
+	assert.Contains(t, string(full), "Data transfer out to internet")
+	assert.NotContains(t, string(full), "first 10TB")
+
+	verbose, err := ToTable(out, Options{NoColor: true, Fields: fields, Verbosity: VerbosityVerbose})
+	require.NoError(t, err)
+	assert.Regexp(t, `├─ first 10TB +10,240 +GB +\$870\.40`, string(verbose))
+	assert.Regexp(t, `└─ next 40TB +4,760 +GB +\$380\.80`, string(verbose))
+	assert.NotContains(t, string(verbose), "next 100TB")
+}
@@ -0,0 +1,103 @@
+package prices
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// DefaultPricingBackend is the name of the backend that fetches prices from the GraphQL pricing API.
+const DefaultPricingBackend = "graphql"
+
+// PriceFetcher fetches the prices of the cost components of a resource and its sub-resources.
+// Each result must be in the same format as the GraphQL pricing API returns, i.e. the products
+// matching the cost component's filters under data.products, each with a list of prices keyed by
+// currency code and a priceHash.
+type PriceFetcher interface {
+	RunQueries(r *schema.Resource) ([]apiclient.PriceQueryResult, error)
+}
+
+// RetrievedPriceFetcher is implemented by PriceFetchers that know when their prices were retrieved
+// from the pricing API, e.g. ones that return prices recorded before the run.
+type RetrievedPriceFetcher interface {
+	// PricesRetrievedAt returns when the prices were retrieved, or nil if it isn't known.
+	PricesRetrievedAt() *time.Time
+}
+
+// pricesRetrievedAt returns when the prices of the PriceFetcher were retrieved. Prices from the
+// pricing API are retrieved during the run, other backends only know if they're a RetrievedPriceFetcher.
+func pricesRetrievedAt(f PriceFetcher) *time.Time {
+	switch f := f.(type) {
+	case RetrievedPriceFetcher:
+		return f.PricesRetrievedAt()
+	case *apiclient.PricingAPIClient:
+		now := time.Now()
+		return &now
+	}
+
+	return nil
+}
+
+// PriceFetcherFunc creates a PriceFetcher for a run.
+type PriceFetcherFunc func(ctx *config.RunContext) (PriceFetcher, error)
+
+var (
+	priceFetchersMu sync.RWMutex
+	priceFetchers   = map[string]PriceFetcherFunc{
+		DefaultPricingBackend: func(ctx *config.RunContext) (PriceFetcher, error) {
+			return apiclient.NewPricingAPIClient(ctx), nil
+		},
+	}
+)
+
+// RegisterPriceFetcher registers a backend that can be selected with the pricing_backend config
+// option or INFRACOST_PRICING_BACKEND, e.g. a local price snapshot or a corporate rate card.
+// Registering a backend with the name of an existing one replaces it.
+func RegisterPriceFetcher(name string, fn PriceFetcherFunc) {
+	priceFetchersMu.Lock()
+	defer priceFetchersMu.Unlock()
+
+	priceFetchers[name] = fn
+}
+
+// NewPriceFetcher returns the PriceFetcher for the pricing backend set in the config.
+func NewPriceFetcher(ctx *config.RunContext) (PriceFetcher, error) {
+	return NewPriceFetcherForBackend(ctx, ctx.Config.PricingBackend)
+}
+
+// NewPriceFetcherForBackend returns the PriceFetcher for the named pricing backend, or the default
+// backend if name is empty. It is used by backends that wrap another backend.
+func NewPriceFetcherForBackend(ctx *config.RunContext, name string) (PriceFetcher, error) {
+	if name == "" {
+		name = DefaultPricingBackend
+	}
+
+	priceFetchersMu.RLock()
+	fn, ok := priceFetchers[name]
+	priceFetchersMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("Unknown pricing backend '%s', expected one of: %s", name, strings.Join(pricingBackends(), ", "))
+	}
+
+	return fn(ctx)
+}
+
+func pricingBackends() []string {
+	priceFetchersMu.RLock()
+	defer priceFetchersMu.RUnlock()
+
+	names := make([]string, 0, len(priceFetchers))
+	for name := range priceFetchers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,63 @@
+package prices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+type fixedPriceFetcher struct {
+	price string
+}
+
+func (f fixedPriceFetcher) RunQueries(r *schema.Resource) ([]apiclient.PriceQueryResult, error) {
+	var results []apiclient.PriceQueryResult
+	for _, c := range r.CostComponents {
+		results = append(results, apiclient.PriceQueryResult{
+			PriceQueryKey: apiclient.PriceQueryKey{Resource: r, CostComponent: c},
+			Result:        gjson.Parse(`{"data": {"products": [{"prices": [{"priceHash": "fixed", "effectiveDateStart": "2023-06-01T00:00:00Z", "EUR": "` + f.price + `"}]}]}}`),
+		})
+	}
+
+	return results, nil
+}
+
+func TestNewPriceFetcher(t *testing.T) {
+	ctx := config.EmptyRunContext()
+
+	f, err := NewPriceFetcher(ctx)
+	require.NoError(t, err)
+	assert.IsType(t, &apiclient.PricingAPIClient{}, f)
+
+	ctx.Config.PricingBackend = "missing"
+	_, err = NewPriceFetcher(ctx)
+	assert.ErrorContains(t, err, "Unknown pricing backend 'missing'")
+}
+
+func TestPopulatePricesWithRegisteredFetcher(t *testing.T) {
+	RegisterPriceFetcher("fixed", func(ctx *config.RunContext) (PriceFetcher, error) {
+		return fixedPriceFetcher{price: "1.5"}, nil
+	})
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingBackend = "fixed"
+	ctx.Config.Currency = "EUR"
+
+	c := &schema.CostComponent{Name: "Instance usage"}
+	project := &schema.Project{
+		Resources: []*schema.Resource{{Name: "aws_instance.web", CostComponents: []*schema.CostComponent{c}}},
+	}
+
+	require.NoError(t, PopulatePrices(ctx, project))
+	assert.True(t, decimal.NewFromFloat(1.5).Equal(c.Price()))
+	assert.Equal(t, "fixed", c.PriceHash())
+	assert.Equal(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), *c.PriceEffectiveDate())
+}
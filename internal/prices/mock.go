@@ -0,0 +1,85 @@
+package prices
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// MockPricingBackend is the name of the backend that returns synthetic prices without calling the
+// pricing API. It is used with --pricing-mock.
+const MockPricingBackend = "mock"
+
+func init() {
+	RegisterPriceFetcher(MockPricingBackend, func(ctx *config.RunContext) (PriceFetcher, error) {
+		currency := ctx.Config.Currency
+		if currency == "" {
+			currency = "USD"
+		}
+
+		return &MockPriceFetcher{Currency: currency}, nil
+	})
+}
+
+// MockPriceFetcher returns a synthetic price for each cost component, so that runs can be demoed
+// and tested without an API key or network access. The price is derived from the resource type and
+// cost component name, so it is the same every run, and is between 0.001 and 1.000 per unit. The
+// price of each tier of a tiered cost component is lower than the one before, like real tiered prices.
+type MockPriceFetcher struct {
+	Currency string
+}
+
+func (f *MockPriceFetcher) RunQueries(r *schema.Resource) ([]apiclient.PriceQueryResult, error) {
+	return f.queryResults(r.ResourceType, r), nil
+}
+
+func (f *MockPriceFetcher) queryResults(resourceType string, r *schema.Resource) []apiclient.PriceQueryResult {
+	var results []apiclient.PriceQueryResult
+
+	resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+	for _, res := range resources {
+		for _, c := range res.CostComponents {
+			for i, k := range apiclient.CostComponentQueryKeys(res, c) {
+				results = append(results, apiclient.PriceQueryResult{
+					PriceQueryKey: k,
+					Result:        f.result(resourceType, c, i),
+				})
+			}
+		}
+	}
+
+	for _, alt := range r.Alternatives {
+		results = append(results, f.queryResults(resourceType, alt)...)
+	}
+
+	return results
+}
+
+func (f *MockPriceFetcher) result(resourceType string, c *schema.CostComponent, tier int) gjson.Result {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(resourceType + "/" + c.Name))
+	sum := h.Sum64()
+
+	// The price is per unit shown in the output, e.g. per 1M requests, not per request.
+	price := decimal.New(int64(sum%1000)+1, -3)
+	if c.UnitMultiplier.IsPositive() {
+		price = price.Div(c.UnitMultiplier)
+	}
+
+	priceHash := fmt.Sprintf("mock-%016x", sum)
+	if tier > 0 {
+		price = price.Div(decimal.NewFromInt(int64(tier + 1)))
+		priceHash = fmt.Sprintf("%s-%d", priceHash, tier)
+	}
+
+	return gjson.Parse(fmt.Sprintf(
+		`{"data":{"products":[{"prices":[{"priceHash":"%s","%s":"%s"}]}]}}`,
+		priceHash, f.Currency, price.String(),
+	))
+}
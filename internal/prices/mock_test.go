@@ -0,0 +1,92 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestMockPriceFetcher(t *testing.T) {
+	newProject := func() (*schema.Project, []*schema.CostComponent) {
+		instance := &schema.CostComponent{Name: "Instance usage"}
+		storage := &schema.CostComponent{Name: "Storage"}
+		requests := &schema.CostComponent{Name: "Requests", UnitMultiplier: decimal.NewFromInt(1000000)}
+
+		return &schema.Project{
+			Resources: []*schema.Resource{
+				{
+					Name:           "aws_instance.web",
+					ResourceType:   "aws_instance",
+					CostComponents: []*schema.CostComponent{instance},
+					SubResources:   []*schema.Resource{{Name: "root_block_device", CostComponents: []*schema.CostComponent{storage}}},
+				},
+				{
+					Name:           "aws_lambda_function.hello",
+					ResourceType:   "aws_lambda_function",
+					CostComponents: []*schema.CostComponent{requests},
+				},
+			},
+		}, []*schema.CostComponent{instance, storage, requests}
+	}
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingBackend = MockPricingBackend
+
+	project, components := newProject()
+	require.NoError(t, PopulatePrices(ctx, project))
+
+	for _, c := range components {
+		price := c.Price()
+		if c.UnitMultiplier.IsPositive() {
+			price = c.UnitMultiplierPrice()
+		}
+
+		assert.True(t, price.GreaterThanOrEqual(decimal.NewFromFloat(0.001)), c.Name)
+		assert.True(t, price.LessThanOrEqual(decimal.NewFromInt(1)), c.Name)
+		assert.Contains(t, c.PriceHash(), "mock-")
+	}
+
+	again, againComponents := newProject()
+	require.NoError(t, PopulatePrices(ctx, again))
+
+	for i, c := range againComponents {
+		assert.True(t, components[i].Price().Equal(c.Price()), c.Name)
+	}
+}
+
+func TestMockPriceFetcherPriceTiers(t *testing.T) {
+	quantity := decimal.NewFromInt(60000)
+	storage := &schema.CostComponent{
+		Name:            "Storage",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: &quantity,
+		ProductFilter:   &schema.ProductFilter{},
+		PriceTiers: []*schema.PriceTier{
+			{Name: "first 50TB", StartUsageAmount: decimal.Zero},
+			{Name: "next 450TB", StartUsageAmount: decimal.NewFromInt(51200)},
+		},
+	}
+
+	project := &schema.Project{
+		Resources: []*schema.Resource{
+			{Name: "aws_s3_bucket.b", ResourceType: "aws_s3_bucket", CostComponents: []*schema.CostComponent{storage}},
+		},
+	}
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingBackend = MockPricingBackend
+
+	require.NoError(t, PopulatePrices(ctx, project))
+	schema.CalculateCosts(project)
+
+	first, next := storage.PriceTiers[0], storage.PriceTiers[1]
+	assert.True(t, next.Price().LessThan(first.Price()))
+	assert.NotEqual(t, first.PriceHash(), next.PriceHash())
+	assert.Equal(t, first.PriceHash(), storage.PriceHash())
+	assert.True(t, storage.MonthlyCost.Equal(first.Price().Mul(decimal.NewFromInt(51200)).Add(next.Price().Mul(decimal.NewFromInt(8800)))))
+}
@@ -0,0 +1,209 @@
+package prices
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Prefetcher fetches the prices of resources in the background before their project is priced, e.g.
+// the resources of each module as soon as the module has been evaluated, so the pricing queries
+// overlap with the evaluation of the rest of the project. It is a PriceFetcher that returns the
+// prefetched results for resources with the same name, type and price queries as a prefetched one,
+// and fetches the prices of any other resources with the PriceFetcher it wraps.
+type Prefetcher struct {
+	ctx     *config.RunContext
+	fetcher PriceFetcher
+	workers chan struct{}
+
+	mu      sync.Mutex
+	entries map[string]*prefetchEntry
+}
+
+type prefetchEntry struct {
+	done    chan struct{}
+	keys    []apiclient.PriceQueryKey
+	results []apiclient.PriceQueryResult
+	err     error
+}
+
+// NewPrefetcher returns a Prefetcher that fetches prices with the PriceFetcher f.
+func NewPrefetcher(ctx *config.RunContext, f PriceFetcher) *Prefetcher {
+	return &Prefetcher{
+		ctx:     ctx,
+		fetcher: f,
+		workers: make(chan struct{}, priceWorkers()),
+		entries: make(map[string]*prefetchEntry),
+	}
+}
+
+// Add starts fetching the prices of the resources. It doesn't wait for them to be fetched, and
+// resources that have already been added are skipped. The resources must not be changed after they
+// are added.
+func (p *Prefetcher) Add(resources []*schema.Resource) {
+	for _, r := range resources {
+		if r.IsSkipped {
+			continue
+		}
+
+		keys := queryKeys(r)
+		if len(keys) == 0 {
+			continue
+		}
+
+		key := prefetchKey(r, keys)
+
+		p.mu.Lock()
+		if _, ok := p.entries[key]; ok {
+			p.mu.Unlock()
+			continue
+		}
+		e := &prefetchEntry{done: make(chan struct{}), keys: keys}
+		p.entries[key] = e
+		p.mu.Unlock()
+
+		go p.fetch(r, e)
+	}
+}
+
+func (p *Prefetcher) fetch(r *schema.Resource, e *prefetchEntry) {
+	defer close(e.done)
+
+	p.workers <- struct{}{}
+	defer func() { <-p.workers }()
+
+	if err := p.ctx.Context().Err(); err != nil {
+		e.err = err
+		return
+	}
+
+	e.results, e.err = p.fetcher.RunQueries(r)
+	if e.err != nil {
+		log.Debugf("Error prefetching prices for %s: %s", r.Name, e.err)
+	}
+}
+
+// RunQueries returns the prefetched results for the resource, waiting for them if they're still being
+// fetched. If the resource wasn't prefetched, or fetching it failed, its prices are fetched now.
+func (p *Prefetcher) RunQueries(r *schema.Resource) ([]apiclient.PriceQueryResult, error) {
+	keys := queryKeys(r)
+
+	p.mu.Lock()
+	e, ok := p.entries[prefetchKey(r, keys)]
+	p.mu.Unlock()
+
+	if !ok {
+		return p.fetcher.RunQueries(r)
+	}
+
+	select {
+	case <-e.done:
+	case <-p.ctx.Context().Done():
+		return nil, p.ctx.Context().Err()
+	}
+
+	if e.err != nil {
+		return p.fetcher.RunQueries(r)
+	}
+
+	return remapResults(e, keys), nil
+}
+
+// PricesRetrievedAt returns when the prices of the wrapped PriceFetcher were retrieved.
+func (p *Prefetcher) PricesRetrievedAt() *time.Time {
+	return pricesRetrievedAt(p.fetcher)
+}
+
+// remapResults returns the results of a prefetched resource for the query keys of a resource that has
+// the same price queries. The keys of both are in the same order, so each result is moved to the key
+// at the same index.
+func remapResults(e *prefetchEntry, keys []apiclient.PriceQueryKey) []apiclient.PriceQueryResult {
+	index := make(map[apiclient.PriceQueryKey]int, len(e.keys))
+	for i, k := range e.keys {
+		index[k] = i
+	}
+
+	results := make([]apiclient.PriceQueryResult, 0, len(e.results))
+	for _, res := range e.results {
+		i, ok := index[res.PriceQueryKey]
+		if !ok {
+			continue
+		}
+
+		results = append(results, apiclient.PriceQueryResult{
+			PriceQueryKey: keys[i],
+			Result:        res.Result,
+		})
+	}
+
+	return results
+}
+
+// queryKeys returns the keys of all the price queries of the resource, in the order of the cost
+// components of the resource, its sub-resources and then its alternatives.
+func queryKeys(r *schema.Resource) []apiclient.PriceQueryKey {
+	var keys []apiclient.PriceQueryKey
+
+	resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+	for _, res := range resources {
+		for _, c := range res.CostComponents {
+			keys = append(keys, apiclient.CostComponentQueryKeys(res, c)...)
+		}
+	}
+
+	for _, alt := range r.Alternatives {
+		keys = append(keys, queryKeys(alt)...)
+	}
+
+	return keys
+}
+
+// prefetchKey returns the key of a resource in the Prefetcher. PriceFetchers can use any part of the
+// resource's cost components to find their prices, e.g. the mock backend uses their names, so all of
+// them are part of the key, not just the filters.
+func prefetchKey(r *schema.Resource, keys []apiclient.PriceQueryKey) string {
+	type query struct {
+		Resource       string                `json:"resource"`
+		CostComponent  string                `json:"costComponent"`
+		Unit           string                `json:"unit"`
+		UnitMultiplier decimal.Decimal       `json:"unitMultiplier"`
+		PriceTier      string                `json:"priceTier,omitempty"`
+		ProductFilter  *schema.ProductFilter `json:"productFilter"`
+		PriceFilter    *schema.PriceFilter   `json:"priceFilter"`
+	}
+
+	queries := make([]query, 0, len(keys))
+	for _, k := range keys {
+		q := query{
+			Resource:       k.Resource.Name,
+			CostComponent:  k.CostComponent.Name,
+			Unit:           k.CostComponent.Unit,
+			UnitMultiplier: k.CostComponent.UnitMultiplier,
+			ProductFilter:  k.CostComponent.ProductFilter,
+			PriceFilter:    k.PriceFilter(),
+		}
+		if k.PriceTier != nil {
+			q.PriceTier = k.PriceTier.Name
+		}
+
+		queries = append(queries, q)
+	}
+
+	b, _ := json.Marshal(struct {
+		Name         string  `json:"name"`
+		ResourceType string  `json:"resourceType"`
+		Queries      []query `json:"queries"`
+	}{r.Name, r.ResourceType, queries})
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,125 @@
+package prices
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+type countingPriceFetcher struct {
+	fetcher PriceFetcher
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *countingPriceFetcher) RunQueries(r *schema.Resource) ([]apiclient.PriceQueryResult, error) {
+	f.mu.Lock()
+	f.calls[r.Name]++
+	f.mu.Unlock()
+
+	return f.fetcher.RunQueries(r)
+}
+
+func prefetchTestResource(instanceType string) *schema.Resource {
+	return &schema.Resource{
+		Name:         "module.web.aws_instance.web",
+		ResourceType: "aws_instance",
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Instance usage",
+				Unit:            "hours",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+				ProductFilter: &schema.ProductFilter{
+					AttributeFilters: []*schema.AttributeFilter{{Key: "instanceType", Value: &instanceType}},
+				},
+			},
+		},
+	}
+}
+
+func (f *countingPriceFetcher) total() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	n := 0
+	for _, c := range f.calls {
+		n += c
+	}
+
+	return n
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func TestPrefetcher(t *testing.T) {
+	ctx := config.EmptyRunContext()
+	fetcher := &countingPriceFetcher{fetcher: &MockPriceFetcher{Currency: "USD"}, calls: map[string]int{}}
+	p := NewPrefetcher(ctx, fetcher)
+
+	p.Add([]*schema.Resource{prefetchTestResource("t3.micro")})
+	// resources that have already been added are only fetched once.
+	p.Add([]*schema.Resource{prefetchTestResource("t3.micro")})
+
+	// the project's resource is built again once the whole project has been evaluated, so the
+	// prefetched results have to be returned for its cost components.
+	r := prefetchTestResource("t3.micro")
+	require.NoError(t, PopulatePricesWithFetcher(ctx, p, &schema.Project{Resources: []*schema.Resource{r}}))
+
+	want := prefetchTestResource("t3.micro")
+	require.NoError(t, PopulatePricesWithFetcher(ctx, &MockPriceFetcher{Currency: "USD"}, &schema.Project{Resources: []*schema.Resource{want}}))
+
+	assert.Equal(t, 1, fetcher.calls["module.web.aws_instance.web"])
+	assert.True(t, want.CostComponents[0].Price().Equal(r.CostComponents[0].Price()))
+	assert.Equal(t, want.CostComponents[0].PriceHash(), r.CostComponents[0].PriceHash())
+
+	// resources with different queries than the prefetched one are fetched again.
+	changed := prefetchTestResource("t3.large")
+	require.NoError(t, PopulatePricesWithFetcher(ctx, p, &schema.Project{Resources: []*schema.Resource{changed}}))
+	assert.Equal(t, 2, fetcher.calls["module.web.aws_instance.web"])
+	assert.False(t, changed.CostComponents[0].Price().IsZero())
+}
+
+func TestPrefetcherHCLProvider(t *testing.T) {
+	ctx := config.EmptyRunContext()
+	projectCtx := config.NewProjectContext(ctx, &config.Project{Path: "../providers/terraform/testdata/hcl_provider_test/prefetches_module_resources"})
+
+	loadProject := func(prefetch func([]*schema.Resource)) *schema.Project {
+		p, err := terraform.NewHCLProvider(projectCtx, terraform.NewPlanJSONProvider(projectCtx, false))
+		require.NoError(t, err)
+
+		if prefetch != nil {
+			p.SetPrefetchFunc(prefetch)
+		}
+
+		projects, err := p.LoadResources(map[string]*schema.UsageData{})
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+
+		return projects[0]
+	}
+
+	fetcher := &countingPriceFetcher{fetcher: &MockPriceFetcher{Currency: "USD"}, calls: map[string]int{}}
+	require.NoError(t, PopulatePricesWithFetcher(ctx, fetcher, loadProject(nil)))
+	want := fetcher.total()
+
+	fetcher = &countingPriceFetcher{fetcher: &MockPriceFetcher{Currency: "USD"}, calls: map[string]int{}}
+	p := NewPrefetcher(ctx, fetcher)
+	require.NoError(t, PopulatePricesWithFetcher(ctx, p, loadProject(p.Add)))
+
+	// the module's resources that reference resources outside of the module are built differently once
+	// the whole project is evaluated, so they aren't prefetched and fetched again.
+	assert.Equal(t, 1, fetcher.calls["module.web.aws_instance.web"])
+	assert.Equal(t, want, fetcher.total())
+}
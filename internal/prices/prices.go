@@ -2,8 +2,8 @@ package prices
 
 import (
 	"runtime"
+	"time"
 
-	"github.com/infracost/infracost/internal/apiclient"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
 
@@ -13,30 +13,36 @@ import (
 )
 
 func PopulatePrices(ctx *config.RunContext, project *schema.Project) error {
-	resources := project.AllResources()
+	c, err := NewPriceFetcher(ctx)
+	if err != nil {
+		return err
+	}
 
-	c := apiclient.NewPricingAPIClient(ctx)
+	return PopulatePricesWithFetcher(ctx, c, project)
+}
+
+// PopulatePricesWithFetcher is like PopulatePrices but fetches the prices with c instead of the
+// PriceFetcher of the configured pricing backend, e.g. with a Prefetcher.
+func PopulatePricesWithFetcher(ctx *config.RunContext, c PriceFetcher, project *schema.Project) error {
+	resources := project.AllResources()
 
 	err := GetPricesConcurrent(ctx, c, resources)
 	if err != nil {
 		return err
 	}
+
+	if project.Metadata != nil {
+		project.Metadata.PricesRetrievedAt = pricesRetrievedAt(c)
+	}
+
 	return nil
 }
 
 // GetPricesConcurrent gets the prices of all resources concurrently.
 // Concurrency level is calculated using the following formula:
 // max(min(4, numCPU * 4), 16)
-func GetPricesConcurrent(ctx *config.RunContext, c *apiclient.PricingAPIClient, resources []*schema.Resource) error {
-	// Set the number of workers
-	numWorkers := 4
-	numCPU := runtime.NumCPU()
-	if numCPU*4 > numWorkers {
-		numWorkers = numCPU * 4
-	}
-	if numWorkers > 16 {
-		numWorkers = 16
-	}
+func GetPricesConcurrent(ctx *config.RunContext, c PriceFetcher, resources []*schema.Resource) error {
+	numWorkers := priceWorkers()
 	numJobs := len(resources)
 	jobs := make(chan *schema.Resource, numJobs)
 	resultErrors := make(chan error, numJobs)
@@ -45,6 +51,11 @@ func GetPricesConcurrent(ctx *config.RunContext, c *apiclient.PricingAPIClient,
 	for i := 0; i < numWorkers; i++ {
 		go func(jobs <-chan *schema.Resource, resultErrors chan<- error) {
 			for r := range jobs {
+				if err := ctx.Context().Err(); err != nil {
+					resultErrors <- err
+					continue
+				}
+
 				err := GetPrices(ctx, c, r)
 				resultErrors <- err
 			}
@@ -55,34 +66,83 @@ func GetPricesConcurrent(ctx *config.RunContext, c *apiclient.PricingAPIClient,
 	for _, r := range resources {
 		jobs <- r
 	}
+	close(jobs)
 
-	// Get the result of the jobs
+	// Get the result of the jobs, or stop waiting if the run is cancelled. Any in-flight
+	// requests are cancelled by the API client since it uses the same context.
 	for i := 0; i < numJobs; i++ {
-		err := <-resultErrors
-		if err != nil {
-			return err
+		select {
+		case err := <-resultErrors:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Context().Done():
+			return ctx.Context().Err()
 		}
 	}
 	return nil
 }
 
-func GetPrices(ctx *config.RunContext, c *apiclient.PricingAPIClient, r *schema.Resource) error {
+// priceWorkers returns the number of resources whose prices are fetched at the same time.
+func priceWorkers() int {
+	n := 4
+	numCPU := runtime.NumCPU()
+	if numCPU*4 > n {
+		n = numCPU * 4
+	}
+	if n > 16 {
+		n = 16
+	}
+
+	return n
+}
+
+func GetPrices(ctx *config.RunContext, c PriceFetcher, r *schema.Resource) error {
 	if r.IsSkipped {
 		return nil
 	}
 
+	setUnqueriedCustomPrices(r)
+
 	results, err := c.RunQueries(r)
 	if err != nil {
 		return err
 	}
 
+	currency := ctx.Config.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+
 	for _, r := range results {
-		setCostComponentPrice(ctx, c.Currency, r.Resource, r.CostComponent, r.Result)
+		if r.PriceTier != nil {
+			setPriceTierPrice(ctx, currency, r.Resource, r.CostComponent, r.PriceTier, r.Result)
+			continue
+		}
+
+		setCostComponentPrice(ctx, currency, r.Resource, r.CostComponent, r.Result)
 	}
 
 	return nil
 }
 
+// setUnqueriedCustomPrices sets the prices of the cost components that only have a custom price, e.g. the
+// ones from resource plugins, since the pricing API doesn't return results for them.
+func setUnqueriedCustomPrices(r *schema.Resource) {
+	resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+	for _, res := range resources {
+		for _, c := range res.CostComponents {
+			if c.ProductFilter == nil && c.CustomPrice() != nil {
+				c.SetPrice(*c.CustomPrice())
+			}
+		}
+	}
+
+	for _, alt := range r.Alternatives {
+		setUnqueriedCustomPrices(alt)
+	}
+}
+
 func setCostComponentPrice(ctx *config.RunContext, currency string, r *schema.Resource, c *schema.CostComponent, res gjson.Result) {
 	var p decimal.Decimal
 
@@ -157,6 +217,71 @@ func setCostComponentPrice(ctx *config.RunContext, currency string, r *schema.Re
 
 	c.SetPrice(p)
 	c.SetPriceHash(prices[0].Get("priceHash").String())
+	setPriceEffectiveDate(c, prices[0])
+}
+
+// setPriceTierPrice sets the price of a tier of a tiered cost component. Tiers without a price are
+// priced at 0.00, the same as cost components. Tiers aren't used if the cost component has a custom price.
+func setPriceTierPrice(ctx *config.RunContext, currency string, r *schema.Resource, c *schema.CostComponent, t *schema.PriceTier, res gjson.Result) {
+	if c.CustomPrice() != nil {
+		c.SetPrice(*c.CustomPrice())
+		return
+	}
+
+	var prices []gjson.Result
+	for _, product := range res.Get("data.products").Array() {
+		prices = product.Get("prices").Array()
+		if len(prices) > 0 {
+			break
+		}
+	}
+
+	if len(prices) == 0 {
+		log.Warnf("No prices found for %s %s (%s), using 0.00", r.Name, c.Name, t.Name)
+		setResourceWarningEvent(ctx, r, "No prices found")
+		t.SetPrice(decimal.Zero)
+		return
+	}
+
+	if len(prices) > 1 {
+		log.Warnf("Multiple prices found for %s %s (%s), using the first price", r.Name, c.Name, t.Name)
+		setResourceWarningEvent(ctx, r, "Multiple prices found")
+	}
+
+	p, err := decimal.NewFromString(prices[0].Get(currency).String())
+	if err != nil {
+		log.Warnf("Error converting price to '%v' (using 0.00)  '%v': %s", currency, prices[0].Get(currency).String(), err.Error())
+		setResourceWarningEvent(ctx, r, "Error converting price")
+		t.SetPrice(decimal.Zero)
+		return
+	}
+
+	t.SetPrice(p)
+	t.SetPriceHash(prices[0].Get("priceHash").String())
+	setPriceEffectiveDate(c, prices[0])
+
+	// The cost component's price is the average of the tiers it uses, so the first tier's price hash is
+	// used as the price hash of the cost component, the same as the price shown when there's no usage.
+	if len(c.PriceTiers) > 0 && c.PriceTiers[0] == t {
+		c.SetPriceHash(t.PriceHash())
+	}
+}
+
+// setPriceEffectiveDate sets the date that the price took effect on the cost component, if the
+// pricing backend returned one.
+func setPriceEffectiveDate(c *schema.CostComponent, price gjson.Result) {
+	v := price.Get("effectiveDateStart").String()
+	if v == "" {
+		return
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		log.Debugf("Error parsing price effective date '%s': %s", v, err)
+		return
+	}
+
+	c.SetPriceEffectiveDate(t)
 }
 
 func setResourceWarningEvent(ctx *config.RunContext, r *schema.Resource, msg string) {
@@ -0,0 +1,229 @@
+package prices
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// SnapshotPricingBackend is the name of the backend that returns the prices recorded in a PriceSnapshot,
+// set by the pricing_snapshot_file config option. It is used to run without network access, e.g. from
+// an air-gapped bundle.
+const SnapshotPricingBackend = "snapshot"
+
+// emptyPriceResult is returned for cost components that aren't in a snapshot, so that they are shown
+// as not found instead of failing the run.
+const emptyPriceResult = `{"data":{"products":[]}}`
+
+func init() {
+	RegisterPriceFetcher(SnapshotPricingBackend, func(ctx *config.RunContext) (PriceFetcher, error) {
+		if ctx.Config.PricingSnapshotFile == "" {
+			return nil, fmt.Errorf("The %s pricing backend needs a pricing snapshot file, set INFRACOST_PRICING_SNAPSHOT_FILE", SnapshotPricingBackend)
+		}
+
+		snapshot, err := LoadPriceSnapshot(ctx.Config.PricingSnapshotFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SnapshotPriceFetcher{Snapshot: snapshot, Currency: runCurrency(ctx)}, nil
+	})
+}
+
+// runCurrency returns the currency of the run, which defaults to USD.
+func runCurrency(ctx *config.RunContext) string {
+	if ctx.Config.Currency == "" {
+		return "USD"
+	}
+
+	return ctx.Config.Currency
+}
+
+// NewRecordingPriceFetcher returns a RecordingPriceFetcher that fetches prices with the named pricing
+// backend and records them to snapshot.
+func NewRecordingPriceFetcher(ctx *config.RunContext, backend string, snapshot *PriceSnapshot) (*RecordingPriceFetcher, error) {
+	f, err := NewPriceFetcherForBackend(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordingPriceFetcher{Fetcher: f, Snapshot: snapshot, Currency: runCurrency(ctx)}, nil
+}
+
+// PriceSnapshot is a set of pricing API results keyed by the currency and filters of the query, so that
+// the same cost components can be priced again later without calling the pricing API.
+type PriceSnapshot struct {
+	mu sync.RWMutex
+	// RecordedAt is when the prices were recorded. It's set when the snapshot is first saved, and is
+	// nil for snapshots saved before it was added.
+	RecordedAt *time.Time                 `json:"recordedAt,omitempty"`
+	Prices     map[string]json.RawMessage `json:"prices"`
+}
+
+// NewPriceSnapshot returns an empty PriceSnapshot.
+func NewPriceSnapshot() *PriceSnapshot {
+	return &PriceSnapshot{
+		Prices: make(map[string]json.RawMessage),
+	}
+}
+
+// LoadPriceSnapshot reads a PriceSnapshot written by PriceSnapshot.Save.
+func LoadPriceSnapshot(filename string) (*PriceSnapshot, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading pricing snapshot: %w", err)
+	}
+
+	s := NewPriceSnapshot()
+	err = json.Unmarshal(b, s)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing pricing snapshot %s: %w", filename, err)
+	}
+
+	if s.Prices == nil {
+		s.Prices = make(map[string]json.RawMessage)
+	}
+
+	return s, nil
+}
+
+// Save writes the snapshot to filename as JSON.
+func (s *PriceSnapshot) Save(filename string) error {
+	s.mu.Lock()
+	if s.RecordedAt == nil {
+		now := time.Now().UTC()
+		s.RecordedAt = &now
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("Error marshalling pricing snapshot: %w", err)
+	}
+
+	err = os.WriteFile(filename, b, 0600)
+	if err != nil {
+		return fmt.Errorf("Error writing pricing snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Len returns the number of prices in the snapshot.
+func (s *PriceSnapshot) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.Prices)
+}
+
+func (s *PriceSnapshot) record(key string, result gjson.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Prices[key] = json.RawMessage(result.Raw)
+}
+
+func (s *PriceSnapshot) lookup(key string) (gjson.Result, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	raw, ok := s.Prices[key]
+	if !ok {
+		return gjson.Result{}, false
+	}
+
+	return gjson.ParseBytes(raw), true
+}
+
+// snapshotKey returns the key of a price query in a snapshot. Cost components with the same filters
+// have the same price, so the name of the resource and cost component aren't part of the key.
+func snapshotKey(currency string, k apiclient.PriceQueryKey) string {
+	b, _ := json.Marshal(struct {
+		Currency      string                `json:"currency"`
+		ProductFilter *schema.ProductFilter `json:"productFilter"`
+		PriceFilter   *schema.PriceFilter   `json:"priceFilter"`
+	}{currency, k.CostComponent.ProductFilter, k.PriceFilter()})
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// RecordingPriceFetcher fetches prices with another PriceFetcher and records the results to a
+// PriceSnapshot.
+type RecordingPriceFetcher struct {
+	Fetcher  PriceFetcher
+	Snapshot *PriceSnapshot
+	Currency string
+}
+
+func (f *RecordingPriceFetcher) PricesRetrievedAt() *time.Time {
+	return pricesRetrievedAt(f.Fetcher)
+}
+
+func (f *RecordingPriceFetcher) RunQueries(r *schema.Resource) ([]apiclient.PriceQueryResult, error) {
+	results, err := f.Fetcher.RunQueries(r)
+	if err != nil {
+		return results, err
+	}
+
+	for _, res := range results {
+		f.Snapshot.record(snapshotKey(f.Currency, res.PriceQueryKey), res.Result)
+	}
+
+	return results, nil
+}
+
+// SnapshotPriceFetcher returns the prices recorded in a PriceSnapshot. Cost components that aren't in
+// the snapshot get an empty result, so they are shown without a price.
+type SnapshotPriceFetcher struct {
+	Snapshot *PriceSnapshot
+	Currency string
+}
+
+// PricesRetrievedAt returns when the snapshot was recorded, or nil for snapshots that don't have it.
+func (f *SnapshotPriceFetcher) PricesRetrievedAt() *time.Time {
+	return f.Snapshot.RecordedAt
+}
+
+func (f *SnapshotPriceFetcher) RunQueries(r *schema.Resource) ([]apiclient.PriceQueryResult, error) {
+	var results []apiclient.PriceQueryResult
+
+	resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+	for _, res := range resources {
+		for _, c := range res.CostComponents {
+			for _, k := range apiclient.CostComponentQueryKeys(res, c) {
+				result, ok := f.Snapshot.lookup(snapshotKey(f.Currency, k))
+				if !ok {
+					log.Debugf("No price in the pricing snapshot for %s %s", res.Name, c.Name)
+					result = gjson.Parse(emptyPriceResult)
+				}
+
+				results = append(results, apiclient.PriceQueryResult{
+					PriceQueryKey: k,
+					Result:        result,
+				})
+			}
+		}
+	}
+
+	for _, alt := range r.Alternatives {
+		altResults, err := f.RunQueries(alt)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, altResults...)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,83 @@
+package prices
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestPriceSnapshot(t *testing.T) {
+	newProject := func() (*schema.Project, []*schema.CostComponent) {
+		region := "us-east-1"
+		otherRegion := "eu-west-1"
+
+		instance := &schema.CostComponent{Name: "Instance usage", ProductFilter: &schema.ProductFilter{Region: &region}}
+		storage := &schema.CostComponent{Name: "Storage", ProductFilter: &schema.ProductFilter{Region: &otherRegion}}
+
+		return &schema.Project{
+			Metadata: &schema.ProjectMetadata{},
+			Resources: []*schema.Resource{
+				{
+					Name:           "aws_instance.web",
+					ResourceType:   "aws_instance",
+					CostComponents: []*schema.CostComponent{instance},
+					SubResources:   []*schema.Resource{{Name: "root_block_device", CostComponents: []*schema.CostComponent{storage}}},
+				},
+			},
+		}, []*schema.CostComponent{instance, storage}
+	}
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.Currency = "USD"
+
+	snapshot := NewPriceSnapshot()
+	recorder, err := NewRecordingPriceFetcher(ctx, MockPricingBackend, snapshot)
+	require.NoError(t, err)
+
+	recorded, recordedComponents := newProject()
+	require.NoError(t, GetPricesConcurrent(ctx, recorder, recorded.AllResources()))
+	assert.Equal(t, 2, snapshot.Len())
+	// mock prices aren't retrieved from the pricing API.
+	assert.Nil(t, recorder.PricesRetrievedAt())
+
+	filename := filepath.Join(t.TempDir(), "prices.json")
+	require.NoError(t, snapshot.Save(filename))
+	require.NotNil(t, snapshot.RecordedAt)
+
+	ctx.Config.PricingBackend = SnapshotPricingBackend
+	ctx.Config.PricingSnapshotFile = filename
+
+	replayed, replayedComponents := newProject()
+	require.NoError(t, PopulatePrices(ctx, replayed))
+
+	for i, c := range replayedComponents {
+		assert.True(t, recordedComponents[i].Price().Equal(c.Price()), c.Name)
+		assert.Equal(t, recordedComponents[i].PriceHash(), c.PriceHash(), c.Name)
+	}
+
+	require.NotNil(t, replayed.Metadata.PricesRetrievedAt)
+	assert.True(t, snapshot.RecordedAt.Equal(*replayed.Metadata.PricesRetrievedAt))
+
+	// prices in another currency aren't in the snapshot.
+	ctx.Config.Currency = "EUR"
+
+	missing, missingComponents := newProject()
+	require.NoError(t, PopulatePrices(ctx, missing))
+
+	for _, c := range missingComponents {
+		assert.Empty(t, c.PriceHash(), c.Name)
+	}
+}
+
+func TestSnapshotPricingBackendRequiresFile(t *testing.T) {
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingBackend = SnapshotPricingBackend
+
+	_, err := NewPriceFetcher(ctx)
+	assert.EqualError(t, err, "The snapshot pricing backend needs a pricing snapshot file, set INFRACOST_PRICING_SNAPSHOT_FILE")
+}
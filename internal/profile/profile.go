@@ -0,0 +1,141 @@
+// Package profile writes pprof profiles and execution traces of a run, so that users with large
+// Terraform projects can attach them to performance issues. The files can be read with
+// go tool pprof and go tool trace.
+package profile
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync"
+)
+
+// Options are the paths of the files that are written, profiles with an empty path aren't taken.
+type Options struct {
+	// CPUProfile is the path of the pprof CPU profile.
+	CPUProfile string
+	// MemProfile is the path of the pprof heap profile, which is taken when the profiling is stopped.
+	MemProfile string
+	// Trace is the path of the execution trace.
+	Trace string
+}
+
+type profiler struct {
+	mu      sync.Mutex
+	opts    Options
+	cpuFile *os.File
+	trace   *os.File
+	started bool
+}
+
+var current = &profiler{}
+
+// Start starts the CPU profile and execution trace. They, and the heap profile, are written when
+// Stop is called. Starting again before Stop is called has no effect.
+func Start(opts Options) error {
+	current.mu.Lock()
+	defer current.mu.Unlock()
+
+	if current.started {
+		return nil
+	}
+
+	if opts.CPUProfile != "" {
+		f, err := os.Create(opts.CPUProfile)
+		if err != nil {
+			return fmt.Errorf("Error creating CPU profile %s: %w", opts.CPUProfile, err)
+		}
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("Error starting CPU profile: %w", err)
+		}
+		current.cpuFile = f
+	}
+
+	if opts.Trace != "" {
+		f, err := os.Create(opts.Trace)
+		if err != nil {
+			current.stopCPUProfile()
+			return fmt.Errorf("Error creating trace %s: %w", opts.Trace, err)
+		}
+
+		if err := trace.Start(f); err != nil {
+			_ = f.Close()
+			current.stopCPUProfile()
+			return fmt.Errorf("Error starting trace: %w", err)
+		}
+		current.trace = f
+	}
+
+	current.opts = opts
+	current.started = true
+
+	return nil
+}
+
+// Stop stops the CPU profile and execution trace and writes the heap profile. It does nothing if
+// profiling wasn't started or has already been stopped. If more than one of the files can't be
+// written the first error is returned.
+func Stop() error {
+	current.mu.Lock()
+	defer current.mu.Unlock()
+
+	if !current.started {
+		return nil
+	}
+	current.started = false
+
+	var firstErr error
+
+	if err := current.stopCPUProfile(); err != nil {
+		firstErr = fmt.Errorf("Error writing CPU profile: %w", err)
+	}
+
+	if current.trace != nil {
+		trace.Stop()
+		if err := current.trace.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("Error writing trace: %w", err)
+		}
+		current.trace = nil
+	}
+
+	if current.opts.MemProfile != "" {
+		if err := writeHeapProfile(current.opts.MemProfile); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("Error writing memory profile: %w", err)
+		}
+	}
+
+	return firstErr
+}
+
+func (p *profiler) stopCPUProfile() error {
+	if p.cpuFile == nil {
+		return nil
+	}
+
+	pprof.StopCPUProfile()
+	err := p.cpuFile.Close()
+	p.cpuFile = nil
+
+	return err
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	// collect the garbage so the profile shows the memory that's still in use.
+	runtime.GC()
+
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,48 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartStop(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{
+		CPUProfile: filepath.Join(dir, "cpu.pprof"),
+		MemProfile: filepath.Join(dir, "mem.pprof"),
+		Trace:      filepath.Join(dir, "trace.out"),
+	}
+
+	require.NoError(t, Start(opts))
+	// starting again is ignored while the profiles are running.
+	require.NoError(t, Start(Options{CPUProfile: filepath.Join(dir, "other.pprof")}))
+
+	require.NoError(t, Stop())
+	require.NoError(t, Stop())
+
+	// pprof profiles are gzipped protobufs.
+	for _, path := range []string{opts.CPUProfile, opts.MemProfile} {
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Greater(t, len(b), 2, path)
+		assert.Equal(t, []byte{0x1f, 0x8b}, b[:2], path)
+	}
+
+	info, err := os.Stat(opts.Trace)
+	require.NoError(t, err)
+	assert.Positive(t, info.Size())
+
+	assert.NoFileExists(t, filepath.Join(dir, "other.pprof"))
+}
+
+func TestStartError(t *testing.T) {
+	err := Start(Options{CPUProfile: filepath.Join(t.TempDir(), "missing", "cpu.pprof")})
+	assert.ErrorContains(t, err, "Error creating CPU profile")
+
+	// nothing was started so stopping does nothing.
+	assert.NoError(t, Stop())
+}
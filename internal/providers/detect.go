@@ -49,6 +49,11 @@ func Detect(ctx *config.ProjectContext, includePastResources bool) (schema.Provi
 		return nil, fmt.Errorf("No such file or directory %s", path)
 	}
 
+	// Terraform Stacks can only be estimated by parsing their HCL, since they're planned by HCP Terraform.
+	if hcl.IsStackDir(path) {
+		return terraform.NewStackProvider(ctx, includePastResources), nil
+	}
+
 	if ctx.ProjectConfig.TerraformParseHCL {
 		if isTerragruntNestedDir(path, 5) {
 			return terraform.NewTerragruntHCLProvider(ctx, includePastResources), nil
@@ -214,7 +219,19 @@ func isTerragruntDir(path string) bool {
 	return config.FileExists(filepath.Join(path, "terragrunt.hcl")) || config.FileExists(filepath.Join(path, "terragrunt.hcl.json"))
 }
 
+// isTerragruntNestedDir returns true if path or one of its subdirectories up to maxDepth deep is a
+// Terragrunt directory. Subdirectories that are ignored by the ignore files in path aren't searched,
+// see hcl.IgnoreFiles.
 func isTerragruntNestedDir(path string, maxDepth int) bool {
+	ignoreRules, err := hcl.LoadIgnoreRules(path)
+	if err != nil {
+		log.Debugf("Error reading ignore files in %s: %s", path, err)
+	}
+
+	return isTerragruntNestedDirWithIgnore(path, maxDepth, ignoreRules)
+}
+
+func isTerragruntNestedDirWithIgnore(path string, maxDepth int, ignoreRules *hcl.IgnoreRules) bool {
 	if isTerragruntDir(path) {
 		return true
 	}
@@ -225,7 +242,11 @@ func isTerragruntNestedDir(path string, maxDepth int) bool {
 			for _, entry := range entries {
 				name := entry.Name()
 				if entry.IsDir() && name != ".infracost" && name != ".terraform" {
-					if isTerragruntNestedDir(filepath.Join(path, name), maxDepth-1) {
+					if ignoreRules.Ignored(filepath.Join(path, name), true) {
+						continue
+					}
+
+					if isTerragruntNestedDirWithIgnore(filepath.Join(path, name), maxDepth-1, ignoreRules) {
 						return true
 					}
 				}
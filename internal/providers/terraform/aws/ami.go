@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getAMICopyRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "aws_ami_copy",
+		RFunc:               NewAMICopy,
+		ReferenceAttributes: []string{"source_ami_id"},
+	}
+}
+
+func getAMIFromInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "aws_ami_from_instance",
+		RFunc:               NewAMIFromInstance,
+		ReferenceAttributes: []string{"source_instance_id"},
+	}
+}
+
+func NewAMICopy(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.AMI{Address: d.Address, Region: d.Get("region").String()}
+
+	sourceAMIRefs := d.References("source_ami_id")
+	if len(sourceAMIRefs) > 0 {
+		for _, device := range sourceAMIRefs[0].Get("ebs_block_device").Array() {
+			r.BlockDeviceSizesGB = append(r.BlockDeviceSizesGB, device.Get("volume_size").Float())
+		}
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
+
+func NewAMIFromInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.AMI{Address: d.Address, Region: d.Get("region").String()}
+
+	instanceRefs := d.References("source_instance_id")
+	if len(instanceRefs) > 0 {
+		instance := instanceRefs[0]
+
+		r.BlockDeviceSizesGB = append(r.BlockDeviceSizesGB, instance.Get("root_block_device.0.volume_size").Float())
+		for _, device := range instance.Get("ebs_block_device").Array() {
+			r.BlockDeviceSizesGB = append(r.BlockDeviceSizesGB, device.Get("volume_size").Float())
+		}
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
@@ -0,0 +1,26 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getAppAutoscalingPolicyRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_appautoscaling_policy",
+		RFunc: NewAppAutoscalingPolicy,
+		// This reference is used by aws_appautoscaling_target to generate a reverse
+		// reference so target tracking policies can be used to estimate capacity
+		ReferenceAttributes: []string{"resource_id"},
+	}
+}
+
+func NewAppAutoscalingPolicy(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return &schema.Resource{
+		Name:         d.Address,
+		ResourceType: d.Type,
+		Tags:         d.Tags,
+		IsSkipped:    true,
+		NoPrice:      true,
+		SkipMessage:  "Free resource.",
+	}
+}
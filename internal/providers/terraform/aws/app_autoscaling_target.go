@@ -11,7 +11,7 @@ func getAppAutoscalingTargetRegistryItem() *schema.RegistryItem {
 		RFunc: NewAppAutoscalingTargetResource,
 		// This reference is used by other resources (e.g. DynamoDBTable) to generate
 		// a reverse reference
-		ReferenceAttributes: []string{"resource_id"},
+		ReferenceAttributes: []string{"resource_id", "aws_appautoscaling_policy.resource_id"},
 	}
 }
 
@@ -30,6 +30,19 @@ func newAppAutoscalingTarget(d *schema.ResourceData, u *schema.UsageData) *aws.A
 		MaxCapacity:       d.Get("max_capacity").Int(),
 	}
 
+	for _, policy := range d.References("aws_appautoscaling_policy.resource_id") {
+		if policy.Get("policy_type").String() != "TargetTrackingScaling" {
+			continue
+		}
+
+		dimension := policy.Get("scalable_dimension").String()
+		if dimension != "" && dimension != r.ScalableDimension {
+			continue
+		}
+
+		r.TargetUtilization = policy.Get("target_tracking_scaling_policy_configuration.0.target_value").Float()
+	}
+
 	r.PopulateUsage(u)
 
 	return r
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/resources/aws"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
@@ -21,7 +22,11 @@ func GetAutoscalingGroupRegistryItem() *schema.RegistryItem {
 			"launch_template.0.id",
 			"launch_template.0.name",
 			"mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_id",
+			"mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_name",
 			"launch_template",
+			// this is a reverse reference, it depends on the aws_autoscaling_schedule RegistryItem
+			// defining "autoscaling_group_name" as a ReferenceAttribute
+			"aws_autoscaling_schedule.autoscaling_group_name",
 		},
 	}
 }
@@ -45,6 +50,17 @@ func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 		}
 	}
 
+	schedules := autoscalingSchedules(d)
+	if len(schedules) > 0 {
+		avg, err := resources.AverageCapacity(instanceCount, schedules)
+		if err != nil {
+			log.Warnf("Ignoring the autoscaling schedules of %s: %s", a.Address, err)
+		} else {
+			a.ScheduledInstanceCount = &avg
+			instanceCount = avg.Ceil().IntPart()
+		}
+	}
+
 	// The Autoscaling Group resource has either a Launch Configuration or Launch Template sub-resource.
 	// So we create generic resources for these and add them as a subresource of the Autoscaling Group resource.
 	launchConfigurationRef := d.References("launch_configuration")
@@ -56,6 +72,9 @@ func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 		launchTemplateRef = d.References("launch_template.0.name")
 	}
 	mixedInstanceLaunchTemplateRef := d.References("mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_id")
+	if len(mixedInstanceLaunchTemplateRef) == 0 {
+		mixedInstanceLaunchTemplateRef = d.References("mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_name")
+	}
 
 	if len(launchConfigurationRef) > 0 {
 		data := launchConfigurationRef[0]
@@ -79,6 +98,36 @@ func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 	return a.BuildResource()
 }
 
+// autoscalingSchedules returns the recurring schedules of the Autoscaling Group. One-off schedules
+// are ignored since they don't change the capacity of the group every month.
+func autoscalingSchedules(d *schema.ResourceData) []*resources.CapacitySchedule {
+	var schedules []*resources.CapacitySchedule
+
+	for _, ref := range d.References("aws_autoscaling_schedule.autoscaling_group_name") {
+		recurrence := ref.Get("recurrence").String()
+		if recurrence == "" {
+			continue
+		}
+
+		// Capacities of -1 leave the group unchanged
+		var capacity int64
+		if desired := ref.Get("desired_capacity"); desired.Exists() && desired.Int() >= 0 {
+			capacity = desired.Int()
+		} else if minSize := ref.Get("min_size"); minSize.Exists() && minSize.Int() >= 0 {
+			capacity = minSize.Int()
+		} else {
+			continue
+		}
+
+		schedules = append(schedules, &resources.CapacitySchedule{
+			Start:    recurrence,
+			Capacity: capacity,
+		})
+	}
+
+	return schedules
+}
+
 func newLaunchConfiguration(d *schema.ResourceData, u *schema.UsageData, region string, instanceCount int64) *aws.LaunchConfiguration {
 	purchaseOption := "on_demand"
 	if d.Get("spot_price").String() != "" {
@@ -181,7 +230,46 @@ func newMixedInstancesLaunchTemplate(d *schema.ResourceData, u *schema.UsageData
 		onDemandPercentageAboveBaseCount = instanceDistribution.Get("on_demand_percentage_above_base_capacity").Int()
 	}
 
-	return newLaunchTemplate(d, u, region, instanceCount, onDemandBaseCount, onDemandPercentageAboveBaseCount)
+	lt := newLaunchTemplate(d, u, region, instanceCount, onDemandBaseCount, onDemandPercentageAboveBaseCount)
+	lt.SpotInstanceTypes = getSpotInstanceTypes(mixedInstancePolicyData)
+
+	return lt
+}
+
+// getSpotInstanceTypes returns the override instance types that the spot instances of a mixed instances policy
+// are launched as, based on its spot allocation strategy. The spot prices and capacity aren't known when
+// estimating, so lowest-price uses the first spot_instance_pools overrides and the capacity based strategies
+// use all of the overrides. It returns nil if the spot instances only use the first override.
+func getSpotInstanceTypes(mixedInstancePolicyData gjson.Result) []string {
+	var instanceTypes []string
+	for _, override := range mixedInstancePolicyData.Get("launch_template.0.override").Array() {
+		if instanceType := override.Get("instance_type").String(); instanceType != "" {
+			instanceTypes = append(instanceTypes, instanceType)
+		}
+	}
+
+	instanceDistribution := mixedInstancePolicyData.Get("instances_distribution.0")
+	strategy := strings.ToLower(instanceDistribution.Get("spot_allocation_strategy").String())
+
+	switch strategy {
+	case "prioritized", "capacity-optimized-prioritized":
+		return nil
+	case "", "lowest-price":
+		pools := 2
+		if instanceDistribution.Get("spot_instance_pools").Int() > 0 {
+			pools = int(instanceDistribution.Get("spot_instance_pools").Int())
+		}
+
+		if pools < len(instanceTypes) {
+			instanceTypes = instanceTypes[:pools]
+		}
+	}
+
+	if len(instanceTypes) <= 1 {
+		return nil
+	}
+
+	return instanceTypes
 }
 
 func getInstanceTypeAndCount(mixedInstancePolicyData gjson.Result, capacity int64) (string, int64) {
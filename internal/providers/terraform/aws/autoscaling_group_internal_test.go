@@ -0,0 +1,67 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestGetSpotInstanceTypes(t *testing.T) {
+	t.Parallel()
+
+	overrides := `"launch_template": [{"override": [{"instance_type": "t3.large"}, {"instance_type": "m5.large"}, {"instance_type": "c5.large"}]}]`
+
+	tests := []struct {
+		name         string
+		distribution string
+		expected     []string
+	}{
+		{"default", `{}`, []string{"t3.large", "m5.large"}},
+		{"lowest price with pools", `{"spot_allocation_strategy": "lowest-price", "spot_instance_pools": 3}`, []string{"t3.large", "m5.large", "c5.large"}},
+		{"lowest price with one pool", `{"spot_allocation_strategy": "lowest-price", "spot_instance_pools": 1}`, nil},
+		{"capacity optimized", `{"spot_allocation_strategy": "capacity-optimized"}`, []string{"t3.large", "m5.large", "c5.large"}},
+		{"prioritized", `{"spot_allocation_strategy": "capacity-optimized-prioritized"}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := gjson.Parse(`{` + overrides + `, "instances_distribution": [` + tt.distribution + `]}`)
+			assert.Equal(t, tt.expected, getSpotInstanceTypes(policy))
+		})
+	}
+}
+
+func TestAutoscalingGroupSchedules(t *testing.T) {
+	t.Parallel()
+
+	lc := schema.NewResourceData("aws_launch_configuration", "aws", "aws_launch_configuration.lc", nil, gjson.Parse(`{"image_id": "ami-123", "instance_type": "t3.medium"}`))
+	asg := schema.NewResourceData("aws_autoscaling_group", "aws", "aws_autoscaling_group.asg", nil, gjson.Parse(`{"desired_capacity": 4}`))
+	asg.AddReference("launch_configuration", lc, nil)
+
+	reverseRefAttrs := GetAutoscalingGroupRegistryItem().ReferenceAttributes
+	for _, values := range []string{
+		`{"recurrence": "0 20 * * *", "desired_capacity": 0}`,
+		`{"recurrence": "0 8 * * *", "desired_capacity": 4}`,
+		`{"start_time": "2023-01-01T00:00:00Z", "desired_capacity": 10}`,
+	} {
+		s := schema.NewResourceData("aws_autoscaling_schedule", "aws", "aws_autoscaling_schedule.s", nil, gjson.Parse(values))
+		s.AddReference("autoscaling_group_name", asg, reverseRefAttrs)
+	}
+
+	r := NewAutoscalingGroup(asg, nil)
+	require.Len(t, r.SubResources, 1)
+
+	lcResource := r.SubResources[0]
+	assert.True(t, decimal.NewFromInt(2).Equal(*lcResource.InstanceCount), "got %s", lcResource.InstanceCount)
+	assert.True(t, decimal.NewFromInt(2).Equal(*lcResource.CostComponents[0].HourlyQuantity), "got %s", lcResource.CostComponents[0].HourlyQuantity)
+
+	// the instances usage overrides the schedules
+	u := schema.NewUsageData("aws_autoscaling_group.asg", map[string]gjson.Result{"instances": gjson.Parse("3")})
+	r = NewAutoscalingGroup(asg, u)
+	assert.True(t, decimal.NewFromInt(3).Equal(*r.SubResources[0].CostComponents[0].HourlyQuantity))
+}
@@ -0,0 +1,26 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getAutoscalingScheduleRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_autoscaling_schedule",
+		RFunc: NewAutoscalingSchedule,
+		// This reference is used by aws_autoscaling_group to generate a reverse reference,
+		// the schedule is costed as part of the instance count of the group
+		ReferenceAttributes: []string{"autoscaling_group_name"},
+	}
+}
+
+func NewAutoscalingSchedule(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return &schema.Resource{
+		Name:         d.Address,
+		ResourceType: d.Type,
+		Tags:         d.Tags,
+		IsSkipped:    true,
+		NoPrice:      true,
+		SkipMessage:  "Free resource.",
+	}
+}
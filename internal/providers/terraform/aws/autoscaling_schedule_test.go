@@ -0,0 +1,17 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/providers/terraform/tftest"
+)
+
+func TestAutoscalingScheduleGoldenFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	tftest.GoldenFileResourceTestsWithOpts(t, "autoscaling_schedule_test", &tftest.GoldenFileOptions{
+		CaptureLogs: true,
+	})
+}
@@ -13,8 +13,10 @@ func getCloudwatchLogGroupItem() *schema.RegistryItem {
 }
 func NewCloudwatchLogGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	r := &aws.CloudwatchLogGroup{
-		Address: d.Address,
-		Region:  d.Get("region").String(),
+		Address:         d.Address,
+		Region:          d.Get("region").String(),
+		RetentionInDays: d.Get("retention_in_days").Int(),
+		LogGroupClass:   d.Get("log_group_class").String(),
 	}
 
 	r.PopulateUsage(u)
@@ -0,0 +1,27 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getCloudwatchLogMetricFilterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_cloudwatch_log_metric_filter",
+		RFunc: NewCloudwatchLogMetricFilter,
+		Notes: []string{
+			"Metrics with dimensions are counted as one metric per transformation unless the metrics usage key is set.",
+		},
+	}
+}
+
+func NewCloudwatchLogMetricFilter(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.CloudwatchLogMetricFilter{
+		Address:               d.Address,
+		Region:                d.Get("region").String(),
+		MetricTransformations: int64(len(d.Get("metric_transformation").Array())),
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
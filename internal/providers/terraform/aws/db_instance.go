@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"strings"
+
 	"github.com/infracost/infracost/internal/resources/aws"
 	"github.com/infracost/infracost/internal/schema"
 )
@@ -17,23 +19,35 @@ func NewDBInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 	piEnabled := d.Get("performance_insights_enabled").Bool()
 	piLongTerm := piEnabled && d.Get("performance_insights_retention_period").Int() > 7
 	engine := d.Get("engine").String()
+	region := d.Get("region").String()
 
+	var replicationSourceRegion string
 	replicateSourceDBs := d.References("replicate_source_db")
 	if len(replicateSourceDBs) > 0 {
 		if !replicateSourceDBs[0].IsEmpty("engine") {
 			engine = replicateSourceDBs[0].Get("engine").String()
 		}
+		replicationSourceRegion = replicateSourceDBs[0].Get("region").String()
+	} else if arn := d.Get("replicate_source_db").String(); strings.HasPrefix(arn, "arn:") {
+		// Cross-region replicas have to reference the source database by its ARN, e.g.
+		// arn:aws:rds:us-east-1:123456789012:db:mydb
+		if parts := strings.Split(arn, ":"); len(parts) > 3 {
+			replicationSourceRegion = parts[3]
+		}
 	}
 
 	r := &aws.DBInstance{
 		Address:                              d.Address,
-		Region:                               d.Get("region").String(),
+		Region:                               region,
 		InstanceClass:                        d.Get("instance_class").String(),
 		Engine:                               engine,
 		MultiAZ:                              d.Get("multi_az").Bool(),
 		LicenseModel:                         d.Get("license_model").String(),
 		BackupRetentionPeriod:                d.Get("backup_retention_period").Int(),
 		IOPS:                                 d.Get("iops").Float(),
+		StorageThroughput:                    d.Get("storage_throughput").Float(),
+		MaxAllocatedStorageGB:                d.Get("max_allocated_storage").Float(),
+		ReplicationSourceRegion:              replicationSourceRegion,
 		StorageType:                          d.Get("storage_type").String(),
 		PerformanceInsightsEnabled:           piEnabled,
 		PerformanceInsightsLongTermRetention: piLongTerm,
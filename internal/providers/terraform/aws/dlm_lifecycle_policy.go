@@ -0,0 +1,75 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// dlmIntervalHours are the number of hours in each DLM interval unit.
+var dlmIntervalHours = map[string]int64{
+	"HOURS":  1,
+	"DAYS":   24,
+	"WEEKS":  168,
+	"MONTHS": 730,
+	"YEARS":  8760,
+}
+
+func getDLMLifecyclePolicyRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_dlm_lifecycle_policy",
+		RFunc: NewDLMLifecyclePolicy,
+		Notes: []string{
+			"Cross-region copies, snapshot archiving and fast snapshot restore are not supported.",
+			"Schedules that use a cron expression are assumed to create one snapshot per day.",
+		},
+	}
+}
+
+func NewDLMLifecyclePolicy(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	if strings.EqualFold(d.Get("state").String(), "DISABLED") {
+		return &schema.Resource{
+			Name:         d.Address,
+			ResourceType: d.Type,
+			Tags:         d.Tags,
+			IsSkipped:    true,
+			NoPrice:      true,
+		}
+	}
+
+	r := &aws.DLMLifecyclePolicy{Address: d.Address, Region: d.Get("region").String()}
+
+	for _, s := range d.Get("policy_details.0.schedule").Array() {
+		r.RetainedSnapshots += dlmRetainedSnapshots(s)
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
+
+// dlmRetainedSnapshots returns the number of snapshots that a DLM policy schedule keeps of each
+// volume. This is the retain rule count, or the retention period divided by the create interval
+// for age based retention.
+func dlmRetainedSnapshots(s gjson.Result) int64 {
+	retainRule := s.Get("retain_rule.0")
+	if count := retainRule.Get("count").Int(); count > 0 {
+		return count
+	}
+
+	retentionHours := retainRule.Get("interval").Int() * dlmIntervalHours[strings.ToUpper(retainRule.Get("interval_unit").String())]
+	if retentionHours <= 0 {
+		return 0
+	}
+
+	createIntervalHours := int64(24)
+	createRule := s.Get("create_rule.0")
+	if createRule.Get("cron_expression").String() == "" && createRule.Get("interval").Int() > 0 {
+		createIntervalHours = createRule.Get("interval").Int()
+	}
+
+	return decimal.NewFromInt(retentionHours).Div(decimal.NewFromInt(createIntervalHours)).Ceil().IntPart()
+}
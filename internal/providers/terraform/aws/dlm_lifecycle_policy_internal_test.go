@@ -0,0 +1,30 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestDLMRetainedSnapshots(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		schedule string
+		expected int64
+	}{
+		{"count", `{"create_rule": [{"interval": 12, "interval_unit": "HOURS"}], "retain_rule": [{"count": 14}]}`, 14},
+		{"age", `{"create_rule": [{"interval": 12, "interval_unit": "HOURS"}], "retain_rule": [{"interval": 1, "interval_unit": "WEEKS"}]}`, 14},
+		{"age with cron", `{"create_rule": [{"cron_expression": "cron(0 1 ? * MON *)"}], "retain_rule": [{"interval": 30, "interval_unit": "DAYS"}]}`, 30},
+		{"age rounded up", `{"create_rule": [{"interval": 24, "interval_unit": "HOURS"}], "retain_rule": [{"interval": 1, "interval_unit": "MONTHS"}]}`, 31},
+		{"no retain rule", `{"create_rule": [{"interval": 24, "interval_unit": "HOURS"}]}`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dlmRetainedSnapshots(gjson.Parse(tt.schedule)))
+		})
+	}
+}
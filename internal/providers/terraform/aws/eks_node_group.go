@@ -14,9 +14,11 @@ func getNewEKSNodeGroupItem() *schema.RegistryItem {
 		Name:  "aws_eks_node_group",
 		RFunc: NewEKSNodeGroup,
 		ReferenceAttributes: []string{
+			"cluster_name",
 			"launch_template.0.id",
 			"launch_template.0.name",
 		},
+		RollupReference: "cluster_name",
 	}
 }
 
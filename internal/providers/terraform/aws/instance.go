@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/infracost/infracost/internal/resources/aws"
 	"github.com/infracost/infracost/internal/schema"
@@ -35,7 +36,7 @@ func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 		purchaseOption = "spot"
 	}
 
-	var instanceType, ami, cpuCredits, tenancy string
+	var instanceType, ami, cpuCredits, tenancy, eiaType string
 	var ebsOptimized, monitoring bool
 	ltEBSBlockDevices := map[string]*aws.EBSVolume{}
 
@@ -53,6 +54,11 @@ func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 		monitoring = ref.Get("monitoring.0.enabled").Bool()
 		cpuCredits = ref.Get("credit_specification.0.cpu_credits").String()
 		tenancy = ref.Get("placement.0.tenancy").String()
+		eiaType = ref.Get("elastic_inference_accelerator.0.type").String()
+
+		if strings.ToLower(ref.Get("instance_market_options.0.market_type").String()) == "spot" {
+			purchaseOption = "spot"
+		}
 
 		for _, data := range ref.Get("block_device_mappings").Array() {
 			deviceName := data.Get("device_name").String()
@@ -76,6 +82,11 @@ func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	monitoring = d.GetBoolOrDefault("monitoring", monitoring)
 	cpuCredits = d.GetStringOrDefault("credit_specification.0.cpu_credits", cpuCredits)
 	tenancy = d.GetStringOrDefault("tenancy", tenancy)
+	eiaType = d.GetStringOrDefault("elastic_inference_accelerator.0.type", eiaType)
+
+	if strings.ToLower(d.Get("instance_market_options.0.market_type").String()) == "spot" {
+		purchaseOption = "spot"
+	}
 
 	a := &aws.Instance{
 		Address:          d.Address,
@@ -89,6 +100,10 @@ func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 		CPUCredits:       cpuCredits,
 	}
 
+	if eiaType != "" {
+		a.ElasticInferenceAcceleratorType = strPtr(eiaType)
+	}
+
 	a.RootBlockDevice = &aws.EBSVolume{
 		Address: "root_block_device",
 		Region:  region,
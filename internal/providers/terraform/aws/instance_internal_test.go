@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestInstanceSpotMarketOptions(t *testing.T) {
+	t.Parallel()
+
+	d := schema.NewResourceData("aws_instance", "aws", "aws_instance.gpu", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"ami": "ami-123",
+		"instance_type": "g5.xlarge",
+		"instance_market_options": [{"market_type": "spot"}]
+	}`))
+
+	r := NewInstance(d, nil)
+
+	c := r.CostComponents[0]
+	assert.Equal(t, "Instance usage (Linux/UNIX, spot, g5.xlarge)", c.Name)
+	assert.Equal(t, "spot", *c.PriceFilter.PurchaseOption)
+}
+
+func TestInstanceElasticInferenceAccelerator(t *testing.T) {
+	t.Parallel()
+
+	d := schema.NewResourceData("aws_instance", "aws", "aws_instance.inference", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"ami": "ami-123",
+		"instance_type": "c5.large",
+		"elastic_inference_accelerator": [{"type": "eia2.medium"}]
+	}`))
+
+	r := NewInstance(d, nil)
+
+	var names []string
+	for _, c := range r.CostComponents {
+		names = append(names, c.Name)
+	}
+	assert.Contains(t, names, "Inference accelerator (eia2.medium)")
+}
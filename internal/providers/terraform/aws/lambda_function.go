@@ -9,8 +9,10 @@ import (
 func getLambdaFunctionRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_lambda_function",
-		Notes: []string{"Provisioned concurrency is not yet supported."},
 		RFunc: NewLambdaFunction,
+		// this is a reverse reference, it depends on the aws_lambda_provisioned_concurrency_config
+		// RegistryItem defining "function_name" as a ReferenceAttribute
+		ReferenceAttributes: []string{"aws_lambda_provisioned_concurrency_config.function_name"},
 	}
 }
 
@@ -22,11 +24,26 @@ func NewLambdaFunction(d *schema.ResourceData, u *schema.UsageData) *schema.Reso
 		memorySize = d.Get("memory_size").Int()
 	}
 
+	architecture := "x86_64"
+	if archs := d.Get("architectures").Array(); len(archs) > 0 {
+		architecture = archs[0].String()
+	}
+
+	var provisionedConcurrency int64
+	for _, config := range d.References("aws_lambda_provisioned_concurrency_config.function_name") {
+		provisionedConcurrency += config.Get("provisioned_concurrent_executions").Int()
+	}
+
 	a := &aws.LambdaFunction{
-		Address:    d.Address,
-		Region:     region,
-		Name:       name,
-		MemorySize: memorySize,
+		Address:                d.Address,
+		Region:                 region,
+		Name:                   name,
+		MemorySize:             memorySize,
+		Architecture:           architecture,
+		Runtime:                d.Get("runtime").String(),
+		EphemeralStorageSize:   d.GetInt64OrDefault("ephemeral_storage.0.size", 512),
+		ProvisionedConcurrency: provisionedConcurrency,
+		SnapStartEnabled:       d.Get("snap_start.0.apply_on").String() == "PublishedVersions",
 	}
 	a.PopulateUsage(u)
 
@@ -0,0 +1,26 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getLambdaProvisionedConcurrencyConfigRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_lambda_provisioned_concurrency_config",
+		RFunc: NewLambdaProvisionedConcurrencyConfig,
+		// This reference is used by aws_lambda_function to generate a reverse reference,
+		// the provisioned concurrency is costed as part of the function
+		ReferenceAttributes: []string{"function_name"},
+	}
+}
+
+func NewLambdaProvisionedConcurrencyConfig(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return &schema.Resource{
+		Name:         d.Address,
+		ResourceType: d.Type,
+		Tags:         d.Tags,
+		IsSkipped:    true,
+		NoPrice:      true,
+		SkipMessage:  "Free resource.",
+	}
+}
@@ -7,8 +7,9 @@ import (
 
 func getRDSClusterInstanceRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
-		Name:  "aws_rds_cluster_instance",
-		RFunc: NewRDSClusterInstance,
+		Name:                "aws_rds_cluster_instance",
+		RFunc:               NewRDSClusterInstance,
+		ReferenceAttributes: []string{"cluster_identifier"},
 	}
 }
 
@@ -25,6 +26,13 @@ func NewRDSClusterInstance(d *schema.ResourceData, u *schema.UsageData) *schema.
 		PerformanceInsightsLongTermRetention: piLongTerm,
 	}
 
+	clusters := d.References("cluster_identifier")
+	if len(clusters) > 0 {
+		scaling := clusters[0].Get("serverlessv2_scaling_configuration.0")
+		r.ServerlessV2MinACU = scaling.Get("min_capacity").Float()
+		r.ServerlessV2MaxACU = scaling.Get("max_capacity").Float()
+	}
+
 	r.PopulateUsage(u)
 	return r.BuildResource()
 }
@@ -3,11 +3,15 @@ package aws
 import "github.com/infracost/infracost/internal/schema"
 
 var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getAMICopyRegistryItem(),
+	getAMIFromInstanceRegistryItem(),
 	getAPIGatewayRestAPIRegistryItem(),
 	getAPIGatewayStageRegistryItem(),
 	getAPIGatewayV2APIRegistryItem(),
 	getAppAutoscalingTargetRegistryItem(),
+	getAppAutoscalingPolicyRegistryItem(),
 	GetAutoscalingGroupRegistryItem(),
+	getAutoscalingScheduleRegistryItem(),
 	getACMCertificate(),
 	getACMPCACertificateAuthorityRegistryItem(),
 	getBackupVaultRegistryItem(),
@@ -18,6 +22,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getCloudwatchDashboardRegistryItem(),
 	getCloudwatchEventBusItem(),
 	getCloudwatchLogGroupItem(),
+	getCloudwatchLogMetricFilterRegistryItem(),
 	getCloudwatchMetricAlarmRegistryItem(),
 	getCodeBuildProjectRegistryItem(),
 	getConfigRuleItem(),
@@ -27,6 +32,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getDataTransferRegistryItem(),
 	getDBInstanceRegistryItem(),
 	getDMSRegistryItem(),
+	getDLMLifecyclePolicyRegistryItem(),
 	getDocDBClusterInstanceRegistryItem(),
 	getDocDBClusterRegistryItem(),
 	getDocDBClusterSnapshotRegistryItem(),
@@ -63,6 +69,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getKinesisAnalyticsV2ApplicationSnapshotRegistryItem(),
 	getKinesisFirehoseDeliveryStreamRegistryItem(),
 	getLambdaFunctionRegistryItem(),
+	getLambdaProvisionedConcurrencyConfigRegistryItem(),
 	getLBRegistryItem(),
 	getLightsailInstanceRegistryItem(),
 	getMSKClusterRegistryItem(),
@@ -81,6 +88,8 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getS3BucketInventoryRegistryItem(),
 	getS3BucketLifecycleConfigurationRegistryItem(),
 	getS3BucketRegistryItem(),
+	getSageMakerEndpointRegistryItem(),
+	getSageMakerNotebookInstanceRegistryItem(),
 	getSecretsManagerSecret(),
 	getSSMActivationRegistryItem(),
 	getSSMParameterRegistryItem(),
@@ -108,7 +117,6 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 // FreeResources grouped alphabetically
 var FreeResources = []string{
 	// AWS Application Auto Scaling
-	"aws_appautoscaling_policy",
 	"aws_appautoscaling_scheduled_action",
 
 	// AWS Certificate Manager
@@ -183,7 +191,6 @@ var FreeResources = []string{
 	// AWS Cloudwatch
 	"aws_cloudwatch_log_destination",
 	"aws_cloudwatch_log_destination_policy",
-	"aws_cloudwatch_log_metric_filter",
 	"aws_cloudwatch_log_resource_policy",
 	"aws_cloudwatch_log_stream",
 	"aws_cloudwatch_log_subscription_filter",
@@ -320,6 +327,7 @@ var FreeResources = []string{
 	"aws_networkfirewall_logging_configuration",
 
 	// AWS Others
+	"aws_ami",
 	"aws_db_instance_role_association",
 	"aws_db_option_group",
 	"aws_db_parameter_group",
@@ -371,6 +379,12 @@ var FreeResources = []string{
 	"aws_s3_bucket_policy",
 	"aws_s3_bucket_public_access_block",
 
+	// AWS SageMaker
+	"aws_sagemaker_code_repository",
+	"aws_sagemaker_endpoint_configuration", // Costs are shown at the endpoint level
+	"aws_sagemaker_model",
+	"aws_sagemaker_notebook_instance_lifecycle_configuration",
+
 	// AWS Secrets Manager
 	"aws_secretsmanager_secret_policy",
 	"aws_secretsmanager_secret_rotation",
@@ -22,6 +22,7 @@ func NewS3BucketResource(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 		"INTELLIGENT_TIERING": "intelligent_tiering",
 		"STANDARD_IA":         "standard_infrequent_access",
 		"ONEZONE_IA":          "one_zone_infrequent_access",
+		"GLACIER_IR":          "glacier_instant_retrieval",
 		"GLACIER":             "glacier_flexible_retrieval",
 		"DEEP_ARCHIVE":        "glacier_deep_archive",
 	}
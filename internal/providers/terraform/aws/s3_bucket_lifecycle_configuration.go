@@ -19,6 +19,7 @@ func newS3BucketLifecycleConfigurationResource(d *schema.ResourceData, u *schema
 		"INTELLIGENT_TIERING": "intelligent_tiering",
 		"STANDARD_IA":         "standard_infrequent_access",
 		"ONEZONE_IA":          "one_zone_infrequent_access",
+		"GLACIER_IR":          "glacier_instant_retrieval",
 		"GLACIER":             "glacier_flexible_retrieval",
 		"DEEP_ARCHIVE":        "glacier_deep_archive",
 	}
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSageMakerEndpointRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "aws_sagemaker_endpoint",
+		RFunc:               newSageMakerEndpoint,
+		ReferenceAttributes: []string{"endpoint_config_name"},
+		Notes: []string{
+			"Serverless inference variants are not supported.",
+		},
+	}
+}
+
+func newSageMakerEndpoint(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	variants := []*aws.SageMakerEndpointVariant{}
+
+	configRefs := d.References("endpoint_config_name")
+	if len(configRefs) > 0 {
+		config := configRefs[0]
+
+		// Shadow variants are charged the same as production variants.
+		for _, key := range []string{"production_variants", "shadow_production_variants"} {
+			for _, data := range config.Get(key).Array() {
+				instanceType := data.Get("instance_type").String()
+				if instanceType == "" {
+					continue
+				}
+
+				instanceCount := int64(1)
+				if data.Get("initial_instance_count").Exists() {
+					instanceCount = data.Get("initial_instance_count").Int()
+				}
+
+				variants = append(variants, &aws.SageMakerEndpointVariant{
+					Name:          data.Get("variant_name").String(),
+					InstanceType:  instanceType,
+					InstanceCount: instanceCount,
+				})
+			}
+		}
+	}
+
+	r := &aws.SageMakerEndpoint{
+		Address:  d.Address,
+		Region:   d.Get("region").String(),
+		Variants: variants,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,25 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSageMakerNotebookInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_sagemaker_notebook_instance",
+		RFunc: newSageMakerNotebookInstance,
+	}
+}
+
+func newSageMakerNotebookInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.SageMakerNotebookInstance{
+		Address:      d.Address,
+		Region:       d.Get("region").String(),
+		InstanceType: d.Get("instance_type").String(),
+		VolumeSizeGB: d.GetFloat64OrDefault("volume_size", 5),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,89 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-east-1a"
+  size              = 20
+}
+
+resource "aws_ebs_snapshot" "data" {
+  volume_id = aws_ebs_volume.data.id
+}
+
+resource "aws_ami" "source" {
+  name                = "source"
+  root_device_name    = "/dev/xvda"
+  virtualization_type = "hvm"
+
+  ebs_block_device {
+    device_name = "/dev/xvda"
+    snapshot_id = aws_ebs_snapshot.data.id
+    volume_size = 20
+  }
+
+  ebs_block_device {
+    device_name = "/dev/xvdb"
+    snapshot_id = aws_ebs_snapshot.data.id
+    volume_size = 50
+  }
+}
+
+resource "aws_ami_copy" "from_ami" {
+  name              = "from_ami"
+  source_ami_id     = aws_ami.source.id
+  source_ami_region = "us-east-1"
+}
+
+resource "aws_ami_copy" "unknown_source" {
+  name              = "unknown_source"
+  source_ami_id     = "ami-0123456789abcdef0"
+  source_ami_region = "us-west-2"
+}
+
+resource "aws_ami_copy" "unknown_source_with_usage" {
+  name              = "unknown_source_with_usage"
+  source_ami_id     = "ami-0123456789abcdef0"
+  source_ami_region = "us-west-2"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = "m5.large"
+
+  root_block_device {
+    volume_size = 30
+  }
+
+  ebs_block_device {
+    device_name = "/dev/sdf"
+    volume_size = 100
+  }
+}
+
+resource "aws_instance" "default_root" {
+  ami           = "ami-674cbc1e"
+  instance_type = "t3.micro"
+}
+
+resource "aws_ami_from_instance" "web" {
+  name               = "web"
+  source_instance_id = aws_instance.web.id
+}
+
+resource "aws_ami_from_instance" "default_root" {
+  name               = "default_root"
+  source_instance_id = aws_instance.default_root.id
+}
+
+resource "aws_ami_from_instance" "web_with_usage" {
+  name               = "web_with_usage"
+  source_instance_id = aws_instance.web.id
+}
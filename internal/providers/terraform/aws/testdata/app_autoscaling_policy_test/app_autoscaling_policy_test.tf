@@ -0,0 +1,109 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_dynamodb_table" "target_tracking" {
+  name           = "TargetTracking"
+  billing_mode   = "PROVISIONED"
+  read_capacity  = 30
+  write_capacity = 20
+  hash_key       = "UserId"
+
+  attribute {
+    name = "UserId"
+    type = "S"
+  }
+}
+
+resource "aws_appautoscaling_target" "target_tracking_write" {
+  max_capacity       = 500
+  min_capacity       = 5
+  resource_id        = "table/${aws_dynamodb_table.target_tracking.name}"
+  scalable_dimension = "dynamodb:table:WriteCapacityUnits"
+  service_namespace  = "dynamodb"
+}
+
+resource "aws_appautoscaling_policy" "target_tracking_write" {
+  name               = "target-tracking-write"
+  policy_type        = "TargetTrackingScaling"
+  resource_id        = aws_appautoscaling_target.target_tracking_write.resource_id
+  scalable_dimension = aws_appautoscaling_target.target_tracking_write.scalable_dimension
+  service_namespace  = aws_appautoscaling_target.target_tracking_write.service_namespace
+
+  target_tracking_scaling_policy_configuration {
+    predefined_metric_specification {
+      predefined_metric_type = "DynamoDBWriteCapacityUtilization"
+    }
+    target_value = 80
+  }
+}
+
+resource "aws_appautoscaling_target" "target_tracking_read" {
+  max_capacity       = 40
+  min_capacity       = 10
+  resource_id        = "table/${aws_dynamodb_table.target_tracking.name}"
+  scalable_dimension = "dynamodb:table:ReadCapacityUnits"
+  service_namespace  = "dynamodb"
+}
+
+resource "aws_appautoscaling_policy" "target_tracking_read" {
+  name               = "target-tracking-read"
+  policy_type        = "TargetTrackingScaling"
+  resource_id        = aws_appautoscaling_target.target_tracking_read.resource_id
+  scalable_dimension = aws_appautoscaling_target.target_tracking_read.scalable_dimension
+  service_namespace  = aws_appautoscaling_target.target_tracking_read.service_namespace
+
+  target_tracking_scaling_policy_configuration {
+    predefined_metric_specification {
+      predefined_metric_type = "DynamoDBReadCapacityUtilization"
+    }
+    target_value = 50
+  }
+}
+
+resource "aws_dynamodb_table" "step_scaling" {
+  name           = "StepScaling"
+  billing_mode   = "PROVISIONED"
+  read_capacity  = 30
+  write_capacity = 20
+  hash_key       = "UserId"
+
+  attribute {
+    name = "UserId"
+    type = "S"
+  }
+}
+
+resource "aws_appautoscaling_target" "step_scaling_write" {
+  max_capacity       = 500
+  min_capacity       = 15
+  resource_id        = "table/${aws_dynamodb_table.step_scaling.name}"
+  scalable_dimension = "dynamodb:table:WriteCapacityUnits"
+  service_namespace  = "dynamodb"
+}
+
+resource "aws_appautoscaling_policy" "step_scaling_write" {
+  name               = "step-scaling-write"
+  policy_type        = "StepScaling"
+  resource_id        = aws_appautoscaling_target.step_scaling_write.resource_id
+  scalable_dimension = aws_appautoscaling_target.step_scaling_write.scalable_dimension
+  service_namespace  = aws_appautoscaling_target.step_scaling_write.service_namespace
+
+  step_scaling_policy_configuration {
+    adjustment_type         = "ChangeInCapacity"
+    cooldown                = 60
+    metric_aggregation_type = "Average"
+
+    step_adjustment {
+      metric_interval_lower_bound = 0
+      scaling_adjustment          = 10
+    }
+  }
+}
@@ -0,0 +1,123 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_launch_configuration" "lc" {
+  image_id      = "fake_ami"
+  instance_type = "m5.large"
+
+  root_block_device {
+    volume_size = 10
+  }
+}
+
+resource "aws_autoscaling_group" "business_hours" {
+  name                 = "business_hours"
+  launch_configuration = aws_launch_configuration.lc.id
+  desired_capacity     = 2
+  max_size             = 10
+  min_size             = 1
+}
+
+# Scale up to 6 instances at 8am and back down to 2 at 6pm on weekdays
+resource "aws_autoscaling_schedule" "business_hours_scale_up" {
+  scheduled_action_name  = "scale_up"
+  autoscaling_group_name = aws_autoscaling_group.business_hours.name
+  desired_capacity       = 6
+  min_size               = -1
+  max_size               = -1
+  recurrence             = "0 8 * * MON-FRI"
+}
+
+resource "aws_autoscaling_schedule" "business_hours_scale_down" {
+  scheduled_action_name  = "scale_down"
+  autoscaling_group_name = aws_autoscaling_group.business_hours.name
+  desired_capacity       = 2
+  min_size               = -1
+  max_size               = -1
+  recurrence             = "0 18 * * MON-FRI"
+}
+
+resource "aws_autoscaling_group" "nightly_shutdown" {
+  name                 = "nightly_shutdown"
+  launch_configuration = aws_launch_configuration.lc.id
+  desired_capacity     = 4
+  max_size             = 4
+  min_size             = 0
+}
+
+# Scale to zero every night using min_size since there is no desired capacity
+resource "aws_autoscaling_schedule" "nightly_shutdown_stop" {
+  scheduled_action_name  = "stop"
+  autoscaling_group_name = aws_autoscaling_group.nightly_shutdown.name
+  min_size               = 0
+  max_size               = 0
+  recurrence             = "0 20 * * *"
+}
+
+resource "aws_autoscaling_schedule" "nightly_shutdown_start" {
+  scheduled_action_name  = "start"
+  autoscaling_group_name = aws_autoscaling_group.nightly_shutdown.name
+  min_size               = 4
+  max_size               = 4
+  recurrence             = "0 8 * * *"
+}
+
+resource "aws_autoscaling_group" "one_off" {
+  name                 = "one_off"
+  launch_configuration = aws_launch_configuration.lc.id
+  desired_capacity     = 3
+  max_size             = 10
+  min_size             = 1
+}
+
+# One-off schedules don't change the monthly instance count
+resource "aws_autoscaling_schedule" "one_off" {
+  scheduled_action_name  = "one_off"
+  autoscaling_group_name = aws_autoscaling_group.one_off.name
+  desired_capacity       = 10
+  min_size               = -1
+  max_size               = -1
+  start_time             = "2030-12-11T18:00:00Z"
+}
+
+resource "aws_autoscaling_group" "invalid_recurrence" {
+  name                 = "invalid_recurrence"
+  launch_configuration = aws_launch_configuration.lc.id
+  desired_capacity     = 2
+  max_size             = 10
+  min_size             = 1
+}
+
+resource "aws_autoscaling_schedule" "invalid_recurrence" {
+  scheduled_action_name  = "invalid_recurrence"
+  autoscaling_group_name = aws_autoscaling_group.invalid_recurrence.name
+  desired_capacity       = 5
+  min_size               = -1
+  max_size               = -1
+  recurrence             = "not a cron expression"
+}
+
+resource "aws_autoscaling_group" "business_hours_with_usage" {
+  name                 = "business_hours_with_usage"
+  launch_configuration = aws_launch_configuration.lc.id
+  desired_capacity     = 2
+  max_size             = 10
+  min_size             = 1
+}
+
+resource "aws_autoscaling_schedule" "business_hours_with_usage_scale_up" {
+  scheduled_action_name  = "scale_up"
+  autoscaling_group_name = aws_autoscaling_group.business_hours_with_usage.name
+  desired_capacity       = 6
+  min_size               = -1
+  max_size               = -1
+  recurrence             = "0 8 * * MON-FRI"
+}
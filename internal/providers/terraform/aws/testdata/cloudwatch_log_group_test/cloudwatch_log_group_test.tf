@@ -18,3 +18,24 @@ resource "aws_cloudwatch_log_group" "logs_count_withUsage" {
   count = 3
   name  = "log-group${count.index}"
 }
+
+resource "aws_cloudwatch_log_group" "retention_withUsage" {
+  name              = "log-group-retention"
+  retention_in_days = 14
+}
+
+resource "aws_cloudwatch_log_group" "retention_storage_withUsage" {
+  name              = "log-group-retention-storage"
+  retention_in_days = 90
+}
+
+resource "aws_cloudwatch_log_group" "no_retention_withUsage" {
+  name              = "log-group-no-retention"
+  retention_in_days = 0
+}
+
+resource "aws_cloudwatch_log_group" "infrequent_access_withUsage" {
+  name              = "log-group-infrequent-access"
+  log_group_class   = "INFREQUENT_ACCESS"
+  retention_in_days = 30
+}
@@ -0,0 +1,66 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_cloudwatch_log_group" "logs" {
+  name = "log-group"
+}
+
+resource "aws_cloudwatch_log_metric_filter" "errors" {
+  name           = "errors"
+  pattern        = "ERROR"
+  log_group_name = aws_cloudwatch_log_group.logs.name
+
+  metric_transformation {
+    name      = "ErrorCount"
+    namespace = "MyApp"
+    value     = "1"
+  }
+}
+
+resource "aws_cloudwatch_log_metric_filter" "with_dimensions" {
+  name           = "with_dimensions"
+  pattern        = "{ $.latency = * }"
+  log_group_name = aws_cloudwatch_log_group.logs.name
+
+  metric_transformation {
+    name      = "Latency"
+    namespace = "MyApp"
+    value     = "$.latency"
+    dimensions = {
+      Endpoint = "$.endpoint"
+    }
+  }
+}
+
+resource "aws_cloudwatch_log_metric_filter" "with_dimensions_withUsage" {
+  name           = "with_dimensions_withUsage"
+  pattern        = "{ $.latency = * }"
+  log_group_name = aws_cloudwatch_log_group.logs.name
+
+  metric_transformation {
+    name      = "Latency"
+    namespace = "MyApp"
+    value     = "$.latency"
+    dimensions = {
+      Endpoint = "$.endpoint"
+    }
+  }
+}
+
+resource "aws_cloudwatch_log_metric_filter" "count_withUsage" {
+  count          = 2
+  name           = "count_withUsage${count.index}"
+  pattern        = "WARN"
+  log_group_name = aws_cloudwatch_log_group.logs.name
+
+  metric_transformation {
+    name      = "WarnCount${count.index}"
+    namespace = "MyApp"
+    value     = "1"
+  }
+}
@@ -141,3 +141,78 @@ resource "aws_db_instance" "mysql-performance-insights-usage" {
   performance_insights_enabled          = true
   performance_insights_retention_period = 731
 }
+
+resource "aws_db_instance" "mysql-gp3" {
+  engine            = "mysql"
+  instance_class    = "db.t3.large"
+  storage_type      = "gp3"
+  allocated_storage = 100
+}
+
+resource "aws_db_instance" "mysql-gp3-above-baseline" {
+  engine             = "mysql"
+  instance_class     = "db.m5.large"
+  storage_type       = "gp3"
+  allocated_storage  = 500
+  iops               = 15000
+  storage_throughput = 750
+}
+
+resource "aws_db_instance" "sqlserver-gp3-above-baseline" {
+  engine             = "sqlserver-se"
+  instance_class     = "db.m5.xlarge"
+  storage_type       = "gp3"
+  allocated_storage  = 500
+  iops               = 4000
+  storage_throughput = 200
+}
+
+resource "aws_db_instance" "postgres-io2" {
+  engine            = "postgres"
+  instance_class    = "db.m5.large"
+  storage_type      = "io2"
+  allocated_storage = 200
+  iops              = 5000
+}
+
+resource "aws_db_instance" "postgres-io2-below-min" {
+  engine            = "postgres"
+  instance_class    = "db.m5.large"
+  storage_type      = "io2"
+  allocated_storage = 50
+  iops              = 500
+}
+
+resource "aws_db_instance" "mysql-storage-autoscaling" {
+  engine                = "mysql"
+  instance_class        = "db.t3.large"
+  storage_type          = "gp3"
+  allocated_storage     = 100
+  max_allocated_storage = 1000
+}
+
+resource "aws_db_instance" "mysql-storage-autoscaling-above-max" {
+  engine                = "mysql"
+  instance_class        = "db.t3.large"
+  allocated_storage     = 100
+  max_allocated_storage = 300
+}
+
+resource "aws_db_instance" "mysql-total-backup" {
+  engine                  = "mysql"
+  instance_class          = "db.t3.large"
+  allocated_storage       = 100
+  backup_retention_period = 7
+}
+
+resource "aws_db_instance" "mysql-total-backup-below-free" {
+  engine                  = "mysql"
+  instance_class          = "db.t3.large"
+  allocated_storage       = 100
+  backup_retention_period = 7
+}
+
+resource "aws_db_instance" "mysql-cross-region-replica" {
+  replicate_source_db = "arn:aws:rds:us-west-2:123456789012:db:mysql-source"
+  instance_class      = "db.t3.large"
+}
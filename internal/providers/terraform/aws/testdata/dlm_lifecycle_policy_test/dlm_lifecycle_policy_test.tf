@@ -0,0 +1,151 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_iam_role" "dlm" {
+  name = "dlm"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "dlm.amazonaws.com" }
+    }]
+  })
+}
+
+resource "aws_dlm_lifecycle_policy" "count_retention" {
+  description        = "count_retention"
+  execution_role_arn = aws_iam_role.dlm.arn
+
+  policy_details {
+    resource_types = ["VOLUME"]
+    target_tags    = { Snapshot = "true" }
+
+    schedule {
+      name = "daily"
+
+      create_rule {
+        interval      = 24
+        interval_unit = "HOURS"
+        times         = ["23:45"]
+      }
+
+      retain_rule {
+        count = 14
+      }
+    }
+  }
+}
+
+resource "aws_dlm_lifecycle_policy" "age_retention" {
+  description        = "age_retention"
+  execution_role_arn = aws_iam_role.dlm.arn
+
+  policy_details {
+    resource_types = ["VOLUME"]
+    target_tags    = { Snapshot = "true" }
+
+    schedule {
+      name = "every 12 hours"
+
+      create_rule {
+        interval      = 12
+        interval_unit = "HOURS"
+      }
+
+      retain_rule {
+        interval      = 7
+        interval_unit = "DAYS"
+      }
+    }
+  }
+}
+
+resource "aws_dlm_lifecycle_policy" "multiple_schedules" {
+  description        = "multiple_schedules"
+  execution_role_arn = aws_iam_role.dlm.arn
+
+  policy_details {
+    resource_types = ["VOLUME"]
+    target_tags    = { Snapshot = "true" }
+
+    schedule {
+      name = "daily"
+
+      create_rule {
+        cron_expression = "cron(0 1 ? * * *)"
+      }
+
+      retain_rule {
+        count = 7
+      }
+    }
+
+    schedule {
+      name = "monthly"
+
+      create_rule {
+        cron_expression = "cron(0 1 1 * ? *)"
+      }
+
+      retain_rule {
+        interval      = 1
+        interval_unit = "YEARS"
+      }
+    }
+  }
+}
+
+resource "aws_dlm_lifecycle_policy" "no_usage" {
+  description        = "no_usage"
+  execution_role_arn = aws_iam_role.dlm.arn
+
+  policy_details {
+    resource_types = ["VOLUME"]
+    target_tags    = { Snapshot = "true" }
+
+    schedule {
+      name = "daily"
+
+      create_rule {
+        interval = 24
+      }
+
+      retain_rule {
+        count = 14
+      }
+    }
+  }
+}
+
+resource "aws_dlm_lifecycle_policy" "disabled" {
+  description        = "disabled"
+  execution_role_arn = aws_iam_role.dlm.arn
+  state              = "DISABLED"
+
+  policy_details {
+    resource_types = ["VOLUME"]
+    target_tags    = { Snapshot = "true" }
+
+    schedule {
+      name = "daily"
+
+      create_rule {
+        interval = 24
+      }
+
+      retain_rule {
+        count = 14
+      }
+    }
+  }
+}
@@ -182,4 +182,122 @@ resource "aws_appautoscaling_target" "autoscale_dynamodb_table_literal_ref" {
   resource_id        = "table/LiteralTableRef"
   scalable_dimension = "dynamodb:table:ReadCapacityUnits"
   service_namespace  = "dynamodb"
-}
\ No newline at end of file
+}
+
+resource "aws_dynamodb_table" "autoscale_target_tracking_usage" {
+  name           = "TargetTracking"
+  billing_mode   = "PROVISIONED"
+  read_capacity  = 30
+  write_capacity = 20
+  hash_key       = "UserId"
+
+  attribute {
+    name = "UserId"
+    type = "S"
+  }
+
+  replica {
+    region_name = "us-west-1"
+  }
+}
+
+resource "aws_appautoscaling_target" "autoscale_target_tracking_write_target" {
+  max_capacity       = 1000
+  min_capacity       = 5
+  resource_id        = "table/${aws_dynamodb_table.autoscale_target_tracking_usage.name}"
+  scalable_dimension = "dynamodb:table:WriteCapacityUnits"
+  service_namespace  = "dynamodb"
+}
+
+resource "aws_appautoscaling_policy" "autoscale_target_tracking_write_policy" {
+  name               = "write-target-tracking"
+  policy_type        = "TargetTrackingScaling"
+  resource_id        = aws_appautoscaling_target.autoscale_target_tracking_write_target.resource_id
+  scalable_dimension = aws_appautoscaling_target.autoscale_target_tracking_write_target.scalable_dimension
+  service_namespace  = aws_appautoscaling_target.autoscale_target_tracking_write_target.service_namespace
+
+  target_tracking_scaling_policy_configuration {
+    predefined_metric_specification {
+      predefined_metric_type = "DynamoDBWriteCapacityUtilization"
+    }
+    target_value = 70
+  }
+}
+
+resource "aws_appautoscaling_target" "autoscale_target_tracking_read_target" {
+  max_capacity       = 100
+  min_capacity       = 50
+  resource_id        = "table/${aws_dynamodb_table.autoscale_target_tracking_usage.name}"
+  scalable_dimension = "dynamodb:table:ReadCapacityUnits"
+  service_namespace  = "dynamodb"
+}
+
+resource "aws_appautoscaling_policy" "autoscale_target_tracking_read_policy" {
+  name               = "read-target-tracking"
+  policy_type        = "TargetTrackingScaling"
+  resource_id        = aws_appautoscaling_target.autoscale_target_tracking_read_target.resource_id
+  scalable_dimension = aws_appautoscaling_target.autoscale_target_tracking_read_target.scalable_dimension
+  service_namespace  = aws_appautoscaling_target.autoscale_target_tracking_read_target.service_namespace
+
+  target_tracking_scaling_policy_configuration {
+    predefined_metric_specification {
+      predefined_metric_type = "DynamoDBReadCapacityUtilization"
+    }
+    target_value = 70
+  }
+}
+
+resource "aws_dynamodb_table" "autoscale_target_tracking_no_usage" {
+  name           = "TargetTrackingNoUsage"
+  billing_mode   = "PROVISIONED"
+  read_capacity  = 30
+  write_capacity = 20
+  hash_key       = "UserId"
+
+  attribute {
+    name = "UserId"
+    type = "S"
+  }
+}
+
+resource "aws_appautoscaling_target" "autoscale_target_tracking_no_usage_write_target" {
+  max_capacity       = 1000
+  min_capacity       = 10
+  resource_id        = "table/${aws_dynamodb_table.autoscale_target_tracking_no_usage.name}"
+  scalable_dimension = "dynamodb:table:WriteCapacityUnits"
+  service_namespace  = "dynamodb"
+}
+
+resource "aws_appautoscaling_policy" "autoscale_target_tracking_no_usage_write_policy" {
+  name               = "write-target-tracking-no-usage"
+  policy_type        = "TargetTrackingScaling"
+  resource_id        = aws_appautoscaling_target.autoscale_target_tracking_no_usage_write_target.resource_id
+  scalable_dimension = aws_appautoscaling_target.autoscale_target_tracking_no_usage_write_target.scalable_dimension
+  service_namespace  = aws_appautoscaling_target.autoscale_target_tracking_no_usage_write_target.service_namespace
+
+  target_tracking_scaling_policy_configuration {
+    predefined_metric_specification {
+      predefined_metric_type = "DynamoDBWriteCapacityUtilization"
+    }
+    target_value = 50
+  }
+}
+
+resource "aws_dynamodb_table" "replica_storage_usage" {
+  name         = "ReplicaStorage"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "UserId"
+
+  attribute {
+    name = "UserId"
+    type = "S"
+  }
+
+  replica {
+    region_name = "us-west-1"
+  }
+
+  replica {
+    region_name = "eu-west-1"
+  }
+}
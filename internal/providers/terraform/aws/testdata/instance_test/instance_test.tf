@@ -108,6 +108,36 @@ resource "aws_instance" "t2_standard_cpuCredits" {
   }
 }
 
+resource "aws_instance" "t3a_default_cpuCredits" {
+  ami           = "fake_ami"
+  instance_type = "t3a.medium"
+}
+
+resource "aws_instance" "t3_cpuCredits_defaultVCPUs" {
+  ami           = "fake_ami"
+  instance_type = "t3.medium"
+}
+
+resource "aws_instance" "t3_windows_cpuCredits" {
+  ami           = "fake_ami"
+  instance_type = "t3.medium"
+}
+
+resource "aws_instance" "t3_cpuUtilization" {
+  ami           = "fake_ami"
+  instance_type = "t3.large"
+}
+
+resource "aws_instance" "t3a_cpuUtilization_creditHrs" {
+  ami           = "fake_ami"
+  instance_type = "t3a.large"
+}
+
+resource "aws_instance" "t4g_cpuUtilization_belowBaseline" {
+  ami           = "fake_ami"
+  instance_type = "t4g.xlarge"
+}
+
 resource "aws_instance" "instance1_detailedMonitoring" {
   ami           = "fake_ami"
   instance_type = "m3.large"
@@ -236,3 +266,41 @@ resource "aws_instance" "instance_withLaunchTemplateOverride" {
     volume_size = 20
   }
 }
+
+resource "aws_instance" "gpu" {
+  ami           = "fake_ami"
+  instance_type = "g4dn.xlarge"
+}
+
+resource "aws_instance" "gpu_spot" {
+  ami           = "fake_ami"
+  instance_type = "p3.2xlarge"
+
+  instance_market_options {
+    market_type = "spot"
+  }
+}
+
+resource "aws_instance" "elastic_inference" {
+  ami           = "fake_ami"
+  instance_type = "m5.large"
+
+  elastic_inference_accelerator {
+    type = "eia2.medium"
+  }
+}
+
+resource "aws_launch_template" "gpu_spot" {
+  image_id      = "fake_ami"
+  instance_type = "g5.xlarge"
+
+  instance_market_options {
+    market_type = "spot"
+  }
+}
+
+resource "aws_instance" "gpu_spot_withLaunchTemplate" {
+  launch_template {
+    id = aws_launch_template.gpu_spot.id
+  }
+}
@@ -30,3 +30,69 @@ resource "aws_lambda_function" "lambda_withUsage512Mem" {
   runtime       = "nodejs12.x"
   memory_size   = 512
 }
+
+resource "aws_lambda_function" "lambda_arm64_withUsage" {
+  function_name = "lambda_arm64"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs18.x"
+  architectures = ["arm64"]
+  memory_size   = 1024
+}
+
+resource "aws_lambda_function" "lambda_ephemeral_storage_withUsage" {
+  function_name = "lambda_ephemeral_storage"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs18.x"
+
+  ephemeral_storage {
+    size = 10240
+  }
+}
+
+resource "aws_lambda_function" "lambda_ephemeral_storage_free" {
+  function_name = "lambda_ephemeral_storage_free"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs18.x"
+
+  ephemeral_storage {
+    size = 512
+  }
+}
+
+resource "aws_lambda_function" "lambda_snap_start_java_withUsage" {
+  function_name = "lambda_snap_start_java"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "example.Handler::handleRequest"
+  runtime       = "java21"
+  memory_size   = 2048
+
+  snap_start {
+    apply_on = "PublishedVersions"
+  }
+}
+
+resource "aws_lambda_function" "lambda_snap_start_python_withUsage" {
+  function_name = "lambda_snap_start_python"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "app.handler"
+  runtime       = "python3.12"
+  memory_size   = 1024
+
+  snap_start {
+    apply_on = "PublishedVersions"
+  }
+}
+
+resource "aws_lambda_function" "lambda_snap_start_python" {
+  function_name = "lambda_snap_start_python_no_usage"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "app.handler"
+  runtime       = "python3.12"
+
+  snap_start {
+    apply_on = "PublishedVersions"
+  }
+}
@@ -0,0 +1,102 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_lambda_function" "provisioned" {
+  function_name = "provisioned"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs18.x"
+  memory_size   = 1024
+  publish       = true
+}
+
+resource "aws_lambda_provisioned_concurrency_config" "provisioned" {
+  function_name                     = aws_lambda_function.provisioned.function_name
+  provisioned_concurrent_executions = 10
+  qualifier                         = aws_lambda_function.provisioned.version
+}
+
+resource "aws_lambda_function" "provisioned_withUsage" {
+  function_name = "provisioned_withUsage"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs18.x"
+  memory_size   = 2048
+  publish       = true
+}
+
+resource "aws_lambda_provisioned_concurrency_config" "provisioned_withUsage" {
+  function_name                     = aws_lambda_function.provisioned_withUsage.function_name
+  provisioned_concurrent_executions = 5
+  qualifier                         = aws_lambda_function.provisioned_withUsage.version
+}
+
+resource "aws_lambda_function" "provisioned_percent_withUsage" {
+  function_name = "provisioned_percent_withUsage"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs18.x"
+  memory_size   = 1024
+  publish       = true
+}
+
+resource "aws_lambda_provisioned_concurrency_config" "provisioned_percent_withUsage" {
+  function_name                     = aws_lambda_function.provisioned_percent_withUsage.function_name
+  provisioned_concurrent_executions = 1
+  qualifier                         = aws_lambda_function.provisioned_percent_withUsage.version
+}
+
+resource "aws_lambda_function" "provisioned_arm64_withUsage" {
+  function_name = "provisioned_arm64_withUsage"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs18.x"
+  architectures = ["arm64"]
+  publish       = true
+}
+
+resource "aws_lambda_provisioned_concurrency_config" "provisioned_arm64_withUsage" {
+  function_name                     = aws_lambda_function.provisioned_arm64_withUsage.function_name
+  provisioned_concurrent_executions = 2
+  qualifier                         = aws_lambda_function.provisioned_arm64_withUsage.version
+}
+
+resource "aws_lambda_function" "multiple_aliases" {
+  function_name = "multiple_aliases"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs18.x"
+  publish       = true
+}
+
+resource "aws_lambda_alias" "live" {
+  name             = "live"
+  function_name    = aws_lambda_function.multiple_aliases.function_name
+  function_version = aws_lambda_function.multiple_aliases.version
+}
+
+resource "aws_lambda_alias" "canary" {
+  name             = "canary"
+  function_name    = aws_lambda_function.multiple_aliases.function_name
+  function_version = aws_lambda_function.multiple_aliases.version
+}
+
+resource "aws_lambda_provisioned_concurrency_config" "live" {
+  function_name                     = aws_lambda_function.multiple_aliases.function_name
+  provisioned_concurrent_executions = 4
+  qualifier                         = aws_lambda_alias.live.name
+}
+
+resource "aws_lambda_provisioned_concurrency_config" "canary" {
+  function_name                     = aws_lambda_function.multiple_aliases.function_name
+  provisioned_concurrent_executions = 1
+  qualifier                         = aws_lambda_alias.canary.name
+}
@@ -60,3 +60,41 @@ resource "aws_rds_cluster_instance" "cluster_instance_performance_insights_with_
   performance_insights_enabled          = true
   performance_insights_retention_period = 731
 }
+
+resource "aws_rds_cluster" "serverless_v2" {
+  cluster_identifier = "aurora-serverless-v2-demo"
+  engine             = "aurora-postgresql"
+  engine_mode        = "provisioned"
+  database_name      = "mydb"
+  master_username    = "foo"
+  master_password    = "barbut8chars"
+
+  serverlessv2_scaling_configuration {
+    min_capacity = 0.5
+    max_capacity = 16
+  }
+}
+
+resource "aws_rds_cluster_instance" "serverless_v2" {
+  identifier         = "aurora-serverless-v2-demo"
+  cluster_identifier = aws_rds_cluster.serverless_v2.id
+  instance_class     = "db.serverless"
+  engine             = aws_rds_cluster.serverless_v2.engine
+  engine_version     = aws_rds_cluster.serverless_v2.engine_version
+}
+
+resource "aws_rds_cluster_instance" "serverless_v2_with_usage" {
+  identifier         = "aurora-serverless-v2-demo-usage"
+  cluster_identifier = aws_rds_cluster.serverless_v2.id
+  instance_class     = "db.serverless"
+  engine             = aws_rds_cluster.serverless_v2.engine
+  engine_version     = aws_rds_cluster.serverless_v2.engine_version
+}
+
+resource "aws_rds_cluster_instance" "serverless_v2_usage_above_max" {
+  identifier         = "aurora-serverless-v2-demo-above-max"
+  cluster_identifier = aws_rds_cluster.serverless_v2.id
+  instance_class     = "db.serverless"
+  engine             = aws_rds_cluster.serverless_v2.engine
+  engine_version     = aws_rds_cluster.serverless_v2.engine_version
+}
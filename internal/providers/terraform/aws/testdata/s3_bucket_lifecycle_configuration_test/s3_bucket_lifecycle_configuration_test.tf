@@ -42,6 +42,9 @@ resource "aws_s3_bucket_lifecycle_configuration" "bucket_lifecycle_config" {
     transition {
       storage_class = "STANDARD_IA"
     }
+    transition {
+      storage_class = "GLACIER_IR"
+    }
     transition {
       storage_class = "GLACIER"
     }
@@ -70,6 +73,9 @@ resource "aws_s3_bucket_lifecycle_configuration" "bucket_lifecycle_config" {
     noncurrent_version_transition {
       storage_class = "STANDARD_IA"
     }
+    noncurrent_version_transition {
+      storage_class = "GLACIER_IR"
+    }
     noncurrent_version_transition {
       storage_class = "GLACIER"
     }
@@ -104,6 +110,9 @@ resource "aws_s3_bucket_lifecycle_configuration" "bucket_lifecycle_config_noncur
     noncurrent_version_transition {
       storage_class = "STANDARD_IA"
     }
+    noncurrent_version_transition {
+      storage_class = "GLACIER_IR"
+    }
     noncurrent_version_transition {
       storage_class = "GLACIER"
     }
@@ -129,6 +138,9 @@ resource "aws_s3_bucket_lifecycle_configuration" "bucket_lifecycle_config_with_u
     transition {
       storage_class = "STANDARD_IA"
     }
+    transition {
+      storage_class = "GLACIER_IR"
+    }
     transition {
       storage_class = "GLACIER"
     }
@@ -37,6 +37,9 @@ resource "aws_s3_bucket" "bucket1" {
     transition {
       storage_class = "STANDARD_IA"
     }
+    transition {
+      storage_class = "GLACIER_IR"
+    }
     transition {
       storage_class = "GLACIER"
     }
@@ -0,0 +1,89 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_sagemaker_model" "model" {
+  name               = "model"
+  execution_role_arn = "arn:aws:iam::123456789012:role/sagemaker"
+
+  primary_container {
+    image = "123456789012.dkr.ecr.us-east-1.amazonaws.com/model:latest"
+  }
+}
+
+resource "aws_sagemaker_endpoint_configuration" "single" {
+  name = "single"
+
+  production_variants {
+    variant_name           = "primary"
+    model_name             = aws_sagemaker_model.model.name
+    instance_type          = "ml.m5.large"
+    initial_instance_count = 2
+  }
+}
+
+resource "aws_sagemaker_endpoint" "single" {
+  name                 = "single"
+  endpoint_config_name = aws_sagemaker_endpoint_configuration.single.name
+}
+
+resource "aws_sagemaker_endpoint_configuration" "gpu_with_shadow" {
+  name = "gpu-with-shadow"
+
+  production_variants {
+    variant_name           = "gpu"
+    model_name             = aws_sagemaker_model.model.name
+    instance_type          = "ml.g4dn.xlarge"
+    initial_instance_count = 1
+  }
+
+  production_variants {
+    variant_name           = "cpu"
+    model_name             = aws_sagemaker_model.model.name
+    instance_type          = "ml.c5.xlarge"
+    initial_instance_count = 3
+  }
+
+  shadow_production_variants {
+    variant_name           = "shadow"
+    model_name             = aws_sagemaker_model.model.name
+    instance_type          = "ml.g5.2xlarge"
+    initial_instance_count = 1
+  }
+}
+
+resource "aws_sagemaker_endpoint" "gpu_with_shadow" {
+  name                 = "gpu-with-shadow"
+  endpoint_config_name = aws_sagemaker_endpoint_configuration.gpu_with_shadow.name
+}
+
+resource "aws_sagemaker_endpoint_configuration" "serverless" {
+  name = "serverless"
+
+  production_variants {
+    variant_name = "serverless"
+    model_name   = aws_sagemaker_model.model.name
+
+    serverless_config {
+      max_concurrency   = 5
+      memory_size_in_mb = 2048
+    }
+  }
+}
+
+resource "aws_sagemaker_endpoint" "serverless" {
+  name                 = "serverless"
+  endpoint_config_name = aws_sagemaker_endpoint_configuration.serverless.name
+}
+
+resource "aws_sagemaker_endpoint" "with_usage" {
+  name                 = "with-usage"
+  endpoint_config_name = aws_sagemaker_endpoint_configuration.single.name
+}
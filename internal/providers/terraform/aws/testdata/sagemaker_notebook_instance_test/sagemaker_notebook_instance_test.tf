@@ -0,0 +1,30 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_sagemaker_notebook_instance" "default_volume" {
+  name          = "default-volume"
+  role_arn      = "arn:aws:iam::123456789012:role/sagemaker"
+  instance_type = "ml.t3.medium"
+}
+
+resource "aws_sagemaker_notebook_instance" "gpu" {
+  name          = "gpu"
+  role_arn      = "arn:aws:iam::123456789012:role/sagemaker"
+  instance_type = "ml.p3.2xlarge"
+  volume_size   = 50
+}
+
+resource "aws_sagemaker_notebook_instance" "with_usage" {
+  name          = "with-usage"
+  role_arn      = "arn:aws:iam::123456789012:role/sagemaker"
+  instance_type = "ml.g4dn.xlarge"
+  volume_size   = 100
+}
@@ -17,6 +17,7 @@ func GetAzureRMKubernetesClusterNodePoolRegistryItem() *schema.RegistryItem {
 		ReferenceAttributes: []string{
 			"kubernetes_cluster_id",
 		},
+		RollupReference: "kubernetes_cluster_id",
 	}
 }
 
@@ -42,7 +43,7 @@ func aksClusterNodePool(name, region string, n gjson.Result, nodeCount decimal.D
 		Name: name,
 	}
 	instanceType := n.Get("vm_size").String()
-	costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType))
+	costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, n.Get("priority").String()))
 	mainResource.CostComponents = costComponents
 	schema.MultiplyQuantities(mainResource, nodeCount)
 
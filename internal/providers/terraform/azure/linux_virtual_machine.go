@@ -15,7 +15,7 @@ func GetAzureRMLinuxVirtualMachineRegistryItem() *schema.RegistryItem {
 		RFunc: NewAzureRMLinuxVirtualMachine,
 		Notes: []string{
 			"Non-standard images such as RHEL are not supported.",
-			"Low priority, Spot and Reserved instances are not supported.",
+			"Reserved instances are not supported.",
 		},
 	}
 }
@@ -25,7 +25,7 @@ func NewAzureRMLinuxVirtualMachine(d *schema.ResourceData, u *schema.UsageData)
 
 	instanceType := d.Get("size").String()
 
-	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType)}
+	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType, d.Get("priority").String())}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -45,10 +45,15 @@ func NewAzureRMLinuxVirtualMachine(d *schema.ResourceData, u *schema.UsageData)
 	}
 }
 
-func linuxVirtualMachineCostComponent(region string, instanceType string) *schema.CostComponent {
+func linuxVirtualMachineCostComponent(region string, instanceType string, priority string) *schema.CostComponent {
 	purchaseOption := "Consumption"
 	purchaseOptionLabel := "pay as you go"
 
+	skuNameRe, priorityLabel := virtualMachinePriority(priority)
+	if priorityLabel != "" {
+		purchaseOptionLabel = priorityLabel
+	}
+
 	productNameRe := "/Virtual Machines .* Series$/"
 	if strings.HasPrefix(instanceType, "Basic_") {
 		productNameRe = "/Virtual Machines .* Series Basic$/"
@@ -67,7 +72,7 @@ func linuxVirtualMachineCostComponent(region string, instanceType string) *schem
 			Service:       strPtr("Virtual Machines"),
 			ProductFamily: strPtr("Compute"),
 			AttributeFilters: []*schema.AttributeFilter{
-				{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+				{Key: "skuName", ValueRegex: strPtr(skuNameRe)},
 				{Key: "armSkuName", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", instanceType))},
 				{Key: "productName", ValueRegex: strPtr(productNameRe)},
 			},
@@ -78,3 +83,16 @@ func linuxVirtualMachineCostComponent(region string, instanceType string) *schem
 		},
 	}
 }
+
+// virtualMachinePriority returns the skuName regex and label for the priority of a virtual machine.
+// Spot and Low Priority VMs are priced as separate SKUs of each size, e.g. NC6 Spot.
+func virtualMachinePriority(priority string) (string, string) {
+	switch strings.ToLower(priority) {
+	case "spot":
+		return "/ Spot$/i", "spot"
+	case "low":
+		return "/ Low Priority$/i", "low priority"
+	}
+
+	return "/^(?!.*(Low Priority|Spot)$).*$/i", ""
+}
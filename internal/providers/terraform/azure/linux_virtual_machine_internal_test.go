@@ -0,0 +1,26 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinuxVirtualMachineCostComponentPriority(t *testing.T) {
+	tests := []struct {
+		priority      string
+		expectedName  string
+		expectedRegex string
+	}{
+		{"", "Instance usage (pay as you go, Standard_NC6s_v3)", "/^(?!.*(Low Priority|Spot)$).*$/i"},
+		{"Regular", "Instance usage (pay as you go, Standard_NC6s_v3)", "/^(?!.*(Low Priority|Spot)$).*$/i"},
+		{"Spot", "Instance usage (spot, Standard_NC6s_v3)", "/ Spot$/i"},
+		{"Low", "Instance usage (low priority, Standard_NC6s_v3)", "/ Low Priority$/i"},
+	}
+
+	for _, tt := range tests {
+		c := linuxVirtualMachineCostComponent("eastus", "Standard_NC6s_v3", tt.priority)
+		assert.Equal(t, tt.expectedName, c.Name)
+		assert.Equal(t, tt.expectedRegex, *c.ProductFilter.AttributeFilters[0].ValueRegex)
+	}
+}
@@ -10,6 +10,9 @@ func GetAzureRMLinuxVirtualMachineScaleSetRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "azurerm_linux_virtual_machine_scale_set",
 		RFunc: NewAzureRMLinuxVirtualMachineScaleSet,
+		// this is a reverse reference, it depends on the azurerm_monitor_autoscale_setting RegistryItem
+		// defining "target_resource_id" as a ReferenceAttribute
+		ReferenceAttributes: []string{"azurerm_monitor_autoscale_setting.target_resource_id"},
 	}
 }
 
@@ -18,7 +21,7 @@ func NewAzureRMLinuxVirtualMachineScaleSet(d *schema.ResourceData, u *schema.Usa
 
 	instanceType := d.Get("sku").String()
 
-	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType)}
+	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType, d.Get("priority").String())}
 	subResources := make([]*schema.Resource, 0)
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
@@ -30,7 +33,7 @@ func NewAzureRMLinuxVirtualMachineScaleSet(d *schema.ResourceData, u *schema.Usa
 		subResources = append(subResources, osDisk)
 	}
 
-	instanceCount := decimal.NewFromInt(d.Get("instances").Int())
+	instanceCount := autoscaleInstanceCount(d, d.Get("instances").Int())
 	if u != nil && u.Get("instances").Type != gjson.Null {
 		instanceCount = decimal.NewFromInt(u.Get("instances").Int())
 	}
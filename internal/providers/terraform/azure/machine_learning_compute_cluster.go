@@ -0,0 +1,52 @@
+package azure
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetAzureRMMachineLearningComputeClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "azurerm_machine_learning_compute_cluster",
+		RFunc:               NewAzureRMMachineLearningComputeCluster,
+		ReferenceAttributes: []string{"machine_learning_workspace_id"},
+	}
+}
+
+// NewAzureRMMachineLearningComputeCluster prices the nodes of the cluster at the rate of the
+// Linux VM size, since Azure Machine Learning doesn't charge a surcharge for compute. Clusters
+// scale down to their minimum node count when idle, so the number of nodes and the hours they
+// run for can be set in the usage file.
+func NewAzureRMMachineLearningComputeCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := lookupRegion(d, []string{"machine_learning_workspace_id"})
+
+	// The cluster uses Low Priority VMs when vm_priority is LowPriority.
+	priority := d.Get("vm_priority").String()
+	if strings.EqualFold(priority, "LowPriority") {
+		priority = "Low"
+	}
+
+	instanceCost := linuxVirtualMachineCostComponent(region, d.Get("vm_size").String(), priority)
+	if u != nil && u.Get("monthly_hrs").Type != gjson.Null {
+		instanceCost.HourlyQuantity = nil
+		instanceCost.MonthlyQuantity = decimalPtr(decimal.NewFromFloat(u.Get("monthly_hrs").Float()))
+	}
+
+	nodeCount := decimal.NewFromInt(d.Get("scale_settings.0.min_node_count").Int())
+	if u != nil && u.Get("instances").Type != gjson.Null {
+		nodeCount = decimal.NewFromInt(u.Get("instances").Int())
+	}
+
+	r := &schema.Resource{
+		Name:           d.Address,
+		CostComponents: []*schema.CostComponent{instanceCost},
+	}
+
+	schema.MultiplyQuantities(r, nodeCount)
+
+	return r
+}
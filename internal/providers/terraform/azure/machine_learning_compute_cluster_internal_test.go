@@ -0,0 +1,34 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestMachineLearningComputeCluster(t *testing.T) {
+	d := schema.NewResourceData("azurerm_machine_learning_compute_cluster", "azurerm", "azurerm_machine_learning_compute_cluster.gpu", nil, gjson.Parse(`{
+		"location": "eastus",
+		"vm_size": "Standard_NC6s_v3",
+		"vm_priority": "LowPriority",
+		"scale_settings": [{"min_node_count": 1, "max_node_count": 4}]
+	}`))
+
+	r := NewAzureRMMachineLearningComputeCluster(d, nil)
+	c := r.CostComponents[0]
+	assert.Equal(t, "Instance usage (low priority, Standard_NC6s_v3)", c.Name)
+	assert.Equal(t, "1", c.HourlyQuantity.String())
+
+	u := schema.NewUsageData("azurerm_machine_learning_compute_cluster.gpu", map[string]gjson.Result{
+		"instances":   gjson.Parse("3"),
+		"monthly_hrs": gjson.Parse("100"),
+	})
+
+	r = NewAzureRMMachineLearningComputeCluster(d, u)
+	c = r.CostComponents[0]
+	assert.Nil(t, c.HourlyQuantity)
+	assert.Equal(t, "300", c.MonthlyQuantity.String())
+}
@@ -0,0 +1,96 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getMonitorAutoscaleSettingRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_monitor_autoscale_setting",
+		RFunc: NewMonitorAutoscaleSetting,
+		// This reference is used by the virtual machine scale sets to generate a reverse reference,
+		// the profiles are costed as part of the instance count of the scale set
+		ReferenceAttributes: []string{"target_resource_id"},
+	}
+}
+
+func NewMonitorAutoscaleSetting(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return &schema.Resource{
+		Name:         d.Address,
+		ResourceType: d.Type,
+		Tags:         d.Tags,
+		IsSkipped:    true,
+		NoPrice:      true,
+		SkipMessage:  "Free resource.",
+	}
+}
+
+// autoscaleInstanceCount returns the time-weighted average number of instances of a scale set that has
+// instances unless it has an autoscale setting. The default capacity of the profile without a schedule
+// is used as the number of instances, and the recurring profiles change the number of instances
+// from when they start until the next profile starts.
+func autoscaleInstanceCount(d *schema.ResourceData, instances int64) decimal.Decimal {
+	var schedules []*resources.CapacitySchedule
+
+	for _, ref := range d.References("azurerm_monitor_autoscale_setting.target_resource_id") {
+		if ref.Get("enabled").Exists() && !ref.Get("enabled").Bool() {
+			continue
+		}
+
+		for _, profile := range ref.Get("profile").Array() {
+			capacity := profile.Get("capacity.0.default").Int()
+
+			recurrence := profile.Get("recurrence.0")
+			if !recurrence.Exists() {
+				if !profile.Get("fixed_date.0").Exists() {
+					instances = capacity
+				}
+				continue
+			}
+
+			schedules = append(schedules, &resources.CapacitySchedule{
+				Start:    recurrenceCron(recurrence),
+				Capacity: capacity,
+			})
+		}
+	}
+
+	avg, err := resources.AverageCapacity(instances, schedules)
+	if err != nil {
+		log.Warnf("Ignoring the autoscale profiles of %s: %s", d.Address, err)
+		return decimal.NewFromInt(instances)
+	}
+
+	return avg
+}
+
+// recurrenceCron converts the recurrence of an autoscale profile to a cron expression.
+func recurrenceCron(recurrence gjson.Result) string {
+	days := make([]string, 0, 7)
+	for _, day := range recurrence.Get("days").Array() {
+		name := day.String()
+		if len(name) > 3 {
+			name = name[:3]
+		}
+		days = append(days, strings.ToUpper(name))
+	}
+
+	return fmt.Sprintf("%s %s * * %s", joinInts(recurrence.Get("minutes")), joinInts(recurrence.Get("hours")), strings.Join(days, ","))
+}
+
+func joinInts(values gjson.Result) string {
+	s := make([]string, 0, len(values.Array()))
+	for _, v := range values.Array() {
+		s = append(s, v.String())
+	}
+
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,31 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestAutoscaleInstanceCount(t *testing.T) {
+	vmss := schema.NewResourceData("azurerm_linux_virtual_machine_scale_set", "azurerm", "azurerm_linux_virtual_machine_scale_set.vmss", nil, gjson.Parse(`{"instances": 2}`))
+	assert.True(t, decimal.NewFromInt(2).Equal(autoscaleInstanceCount(vmss, 2)))
+
+	setting := schema.NewResourceData("azurerm_monitor_autoscale_setting", "azurerm", "azurerm_monitor_autoscale_setting.vmss", nil, gjson.Parse(`{
+		"profile": [
+			{"name": "default", "capacity": [{"default": 4, "minimum": 1, "maximum": 10}]},
+			{"name": "night", "capacity": [{"default": 0, "minimum": 0, "maximum": 0}], "recurrence": [{"timezone": "UTC", "days": ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"], "hours": [20], "minutes": [0]}]},
+			{"name": "day", "capacity": [{"default": 4, "minimum": 1, "maximum": 10}], "recurrence": [{"timezone": "UTC", "days": ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"], "hours": [8], "minutes": [0]}]}
+		]
+	}`))
+	setting.AddReference("target_resource_id", vmss, GetAzureRMLinuxVirtualMachineScaleSetRegistryItem().ReferenceAttributes)
+
+	// 4 instances for 12 hours a day
+	got := autoscaleInstanceCount(vmss, 2)
+	assert.True(t, decimal.NewFromInt(2).Equal(got), "got %s", got)
+
+	assert.Equal(t, "0 20 * * MON,TUE", recurrenceCron(gjson.Parse(`{"days": ["Monday", "Tuesday"], "hours": [20], "minutes": [0]}`)))
+}
@@ -0,0 +1,17 @@
+package azure_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/providers/terraform/tftest"
+)
+
+func TestMonitorAutoscaleSettingGoldenFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	tftest.GoldenFileResourceTestsWithOpts(t, "monitor_autoscale_setting_test", &tftest.GoldenFileOptions{
+		CaptureLogs: true,
+	})
+}
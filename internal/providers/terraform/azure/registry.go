@@ -68,8 +68,10 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetAzureRMLinuxVirtualMachineRegistryItem(),
 	GetAzureRMLinuxVirtualMachineScaleSetRegistryItem(),
 	getAzureRMLogAnalyticsWorkspaceRegistryItem(),
+	GetAzureRMMachineLearningComputeClusterRegistryItem(),
 	GetAzureRMManagedDiskRegistryItem(),
 	GetAzureRMMariaDBServerRegistryItem(),
+	getMonitorAutoscaleSettingRegistryItem(),
 	getAzureRMMSSQLDatabaseRegistryItem(),
 	GetAzureRMMySQLServerRegistryItem(),
 	GetAzureRMNotificationHubNamespaceRegistryItem(),
@@ -318,6 +320,9 @@ var FreeResources = []string{
 	"azurerm_management_group_policy_assignment",
 	"azurerm_management_lock",
 
+	// Azure Machine Learning
+	"azurerm_machine_learning_workspace", // Costs are shown for its compute and dependent resources
+
 	// Azure Managed Applications
 	"azurerm_managed_application",
 	"azurerm_managed_application_definition",
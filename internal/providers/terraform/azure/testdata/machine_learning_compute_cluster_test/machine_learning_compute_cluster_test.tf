@@ -0,0 +1,78 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "eastus"
+}
+
+resource "azurerm_machine_learning_workspace" "example" {
+  name                    = "example-workspace"
+  location                = azurerm_resource_group.example.location
+  resource_group_name     = azurerm_resource_group.example.name
+  application_insights_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Insights/components/example"
+  key_vault_id            = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.KeyVault/vaults/example"
+  storage_account_id      = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/example"
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
+
+resource "azurerm_machine_learning_compute_cluster" "dedicated" {
+  name                          = "dedicated"
+  location                      = azurerm_resource_group.example.location
+  machine_learning_workspace_id = azurerm_machine_learning_workspace.example.id
+  vm_priority                   = "Dedicated"
+  vm_size                       = "Standard_DS3_v2"
+
+  scale_settings {
+    min_node_count                       = 1
+    max_node_count                       = 4
+    scale_down_nodes_after_idle_duration = "PT30S"
+  }
+}
+
+resource "azurerm_machine_learning_compute_cluster" "scale_to_zero" {
+  name                          = "scale-to-zero"
+  location                      = azurerm_resource_group.example.location
+  machine_learning_workspace_id = azurerm_machine_learning_workspace.example.id
+  vm_priority                   = "Dedicated"
+  vm_size                       = "Standard_DS3_v2"
+
+  scale_settings {
+    min_node_count                       = 0
+    max_node_count                       = 4
+    scale_down_nodes_after_idle_duration = "PT30S"
+  }
+}
+
+resource "azurerm_machine_learning_compute_cluster" "gpu" {
+  name                          = "gpu"
+  location                      = azurerm_resource_group.example.location
+  machine_learning_workspace_id = azurerm_machine_learning_workspace.example.id
+  vm_priority                   = "Dedicated"
+  vm_size                       = "Standard_NC6s_v3"
+
+  scale_settings {
+    min_node_count                       = 0
+    max_node_count                       = 8
+    scale_down_nodes_after_idle_duration = "PT30S"
+  }
+}
+
+resource "azurerm_machine_learning_compute_cluster" "gpu_low_priority" {
+  name                          = "gpu-low-priority"
+  location                      = azurerm_resource_group.example.location
+  machine_learning_workspace_id = azurerm_machine_learning_workspace.example.id
+  vm_priority                   = "LowPriority"
+  vm_size                       = "Standard_NC6s_v3"
+
+  scale_settings {
+    min_node_count                       = 2
+    max_node_count                       = 8
+    scale_down_nodes_after_idle_duration = "PT30S"
+  }
+}
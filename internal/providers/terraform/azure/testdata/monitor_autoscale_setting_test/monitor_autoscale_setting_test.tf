@@ -0,0 +1,330 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_linux_virtual_machine_scale_set" "business_hours" {
+  name                = "business_hours"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+  instances           = 1
+
+  sku            = "Standard_D2s_v3"
+  admin_username = "fakeuser"
+  admin_password = "fakepass"
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testrg/providers/Microsoft.Network/subnets/fakesubnet"
+    }
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+
+# The default profile replaces the instances of the scale set, and the recurring profiles
+# scale it up to 6 instances during business hours on weekdays.
+resource "azurerm_monitor_autoscale_setting" "business_hours" {
+  name                = "business_hours"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+  target_resource_id  = azurerm_linux_virtual_machine_scale_set.business_hours.id
+
+  profile {
+    name = "default"
+
+    capacity {
+      default = 2
+      minimum = 1
+      maximum = 10
+    }
+  }
+
+  profile {
+    name = "business_hours_start"
+
+    capacity {
+      default = 6
+      minimum = 6
+      maximum = 10
+    }
+
+    recurrence {
+      timezone = "UTC"
+      days     = ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"]
+      hours    = [8]
+      minutes  = [0]
+    }
+  }
+
+  profile {
+    name = "business_hours_end"
+
+    capacity {
+      default = 2
+      minimum = 1
+      maximum = 10
+    }
+
+    recurrence {
+      timezone = "UTC"
+      days     = ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"]
+      hours    = [18]
+      minutes  = [0]
+    }
+  }
+}
+
+resource "azurerm_linux_virtual_machine_scale_set" "fixed_date" {
+  name                = "fixed_date"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+  instances           = 1
+
+  sku            = "Standard_D2s_v3"
+  admin_username = "fakeuser"
+  admin_password = "fakepass"
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testrg/providers/Microsoft.Network/subnets/fakesubnet"
+    }
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+
+# Fixed date profiles don't change the monthly instance count
+resource "azurerm_monitor_autoscale_setting" "fixed_date" {
+  name                = "fixed_date"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+  target_resource_id  = azurerm_linux_virtual_machine_scale_set.fixed_date.id
+
+  profile {
+    name = "default"
+
+    capacity {
+      default = 3
+      minimum = 1
+      maximum = 10
+    }
+  }
+
+  profile {
+    name = "black_friday"
+
+    capacity {
+      default = 10
+      minimum = 10
+      maximum = 20
+    }
+
+    fixed_date {
+      timezone = "UTC"
+      start    = "2030-11-29T00:00:00Z"
+      end      = "2030-11-30T23:59:00Z"
+    }
+  }
+}
+
+resource "azurerm_linux_virtual_machine_scale_set" "disabled" {
+  name                = "disabled"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+  instances           = 4
+
+  sku            = "Standard_D2s_v3"
+  admin_username = "fakeuser"
+  admin_password = "fakepass"
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testrg/providers/Microsoft.Network/subnets/fakesubnet"
+    }
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+
+resource "azurerm_monitor_autoscale_setting" "disabled" {
+  name                = "disabled"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+  target_resource_id  = azurerm_linux_virtual_machine_scale_set.disabled.id
+  enabled             = false
+
+  profile {
+    name = "default"
+
+    capacity {
+      default = 1
+      minimum = 1
+      maximum = 10
+    }
+  }
+}
+
+resource "azurerm_linux_virtual_machine_scale_set" "no_autoscale" {
+  name                = "no_autoscale"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+  instances           = 3
+
+  sku            = "Standard_D2s_v3"
+  admin_username = "fakeuser"
+  admin_password = "fakepass"
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testrg/providers/Microsoft.Network/subnets/fakesubnet"
+    }
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+
+resource "azurerm_linux_virtual_machine_scale_set" "business_hours_usage" {
+  name                = "business_hours_usage"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+  instances           = 1
+
+  sku            = "Standard_D2s_v3"
+  admin_username = "fakeuser"
+  admin_password = "fakepass"
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testrg/providers/Microsoft.Network/subnets/fakesubnet"
+    }
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+
+resource "azurerm_monitor_autoscale_setting" "business_hours_usage" {
+  name                = "business_hours_usage"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+  target_resource_id  = azurerm_linux_virtual_machine_scale_set.business_hours_usage.id
+
+  profile {
+    name = "default"
+
+    capacity {
+      default = 2
+      minimum = 1
+      maximum = 10
+    }
+  }
+
+  profile {
+    name = "business_hours_start"
+
+    capacity {
+      default = 6
+      minimum = 6
+      maximum = 10
+    }
+
+    recurrence {
+      timezone = "UTC"
+      days     = ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"]
+      hours    = [8]
+      minutes  = [0]
+    }
+  }
+
+  profile {
+    name = "business_hours_end"
+
+    capacity {
+      default = 2
+      minimum = 1
+      maximum = 10
+    }
+
+    recurrence {
+      timezone = "UTC"
+      days     = ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"]
+      hours    = [18]
+      minutes  = [0]
+    }
+  }
+}
@@ -167,3 +167,89 @@ resource "azurerm_windows_virtual_machine" "Standard_E16-8as_v4" {
     version   = "fake"
   }
 }
+
+resource "azurerm_windows_virtual_machine" "standard_nc6s_v3_gpu" {
+  name                = "standard_nc6s_v3_gpu"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+
+  size           = "Standard_NC6s_v3"
+  admin_username = "fakeuser"
+  admin_password = "fakepass"
+
+  network_interface_ids = [
+    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testrg/providers/Microsoft.Network/networkInterfaces/fakenic",
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "2016-Datacenter"
+    version   = "latest"
+  }
+}
+
+resource "azurerm_windows_virtual_machine" "standard_nc6s_v3_gpu_spot" {
+  name                = "standard_nc6s_v3_gpu_spot"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+
+  size           = "Standard_NC6s_v3"
+  admin_username = "fakeuser"
+  admin_password = "fakepass"
+
+  priority        = "Spot"
+  eviction_policy = "Deallocate"
+  max_bid_price   = -1
+
+  network_interface_ids = [
+    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testrg/providers/Microsoft.Network/networkInterfaces/fakenic",
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "2016-Datacenter"
+    version   = "latest"
+  }
+}
+
+resource "azurerm_windows_virtual_machine" "standard_nc4as_t4_v3_gpu_spot" {
+  name                = "standard_nc4as_t4_v3_gpu_spot"
+  resource_group_name = "fake_resource_group"
+  location            = "eastus"
+
+  size           = "Standard_NC4as_T4_v3"
+  admin_username = "fakeuser"
+  admin_password = "fakepass"
+
+  priority        = "Spot"
+  eviction_policy = "Deallocate"
+  max_bid_price   = -1
+
+  network_interface_ids = [
+    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testrg/providers/Microsoft.Network/networkInterfaces/fakenic",
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "2016-Datacenter"
+    version   = "latest"
+  }
+}
@@ -34,9 +34,9 @@ func NewAzureRMVirtualMachine(d *schema.ResourceData, u *schema.UsageData) *sche
 
 	if strings.ToLower(os) == "windows" {
 		licenseType := d.Get("license_type").String()
-		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType))
+		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType, ""))
 	} else {
-		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType))
+		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, ""))
 	}
 
 	costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
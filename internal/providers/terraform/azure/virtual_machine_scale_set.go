@@ -13,6 +13,9 @@ func GetAzureRMVirtualMachineScaleSetRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "azurerm_virtual_machine_scale_set",
 		RFunc: NewAzureRMVirtualMachineScaleSet,
+		// this is a reverse reference, it depends on the azurerm_monitor_autoscale_setting RegistryItem
+		// defining "target_resource_id" as a ReferenceAttribute
+		ReferenceAttributes: []string{"azurerm_monitor_autoscale_setting.target_resource_id"},
 	}
 }
 
@@ -23,7 +26,7 @@ func NewAzureRMVirtualMachineScaleSet(d *schema.ResourceData, u *schema.UsageDat
 	subResources := []*schema.Resource{}
 
 	instanceType := d.Get("sku.0.name").String()
-	capacity := decimal.NewFromInt(d.Get("sku.0.capacity").Int())
+	capacity := autoscaleInstanceCount(d, d.Get("sku.0.capacity").Int())
 
 	if u != nil && u.Get("instances").Type != gjson.Null {
 		capacity = decimal.NewFromInt(u.Get("instances").Int())
@@ -45,7 +48,7 @@ func NewAzureRMVirtualMachineScaleSet(d *schema.ResourceData, u *schema.UsageDat
 	}
 
 	if strings.ToLower(os) == "linux" {
-		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType))
+		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, d.Get("priority").String()))
 	}
 
 	if strings.ToLower(os) == "windows" {
@@ -53,7 +56,7 @@ func NewAzureRMVirtualMachineScaleSet(d *schema.ResourceData, u *schema.UsageDat
 		if d.Get("license_type").Type != gjson.Null {
 			licenseType = d.Get("license_type").String()
 		}
-		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType))
+		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType, d.Get("priority").String()))
 	}
 
 	r := &schema.Resource{
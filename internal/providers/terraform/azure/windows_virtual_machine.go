@@ -13,7 +13,7 @@ func GetAzureRMWindowsVirtualMachineRegistryItem() *schema.RegistryItem {
 		Name:  "azurerm_windows_virtual_machine",
 		RFunc: NewAzureRMWindowsVirtualMachine,
 		Notes: []string{
-			"Low priority, Spot and Reserved instances are not supported.",
+			"Reserved instances are not supported.",
 		},
 	}
 }
@@ -24,7 +24,7 @@ func NewAzureRMWindowsVirtualMachine(d *schema.ResourceData, u *schema.UsageData
 	instanceType := d.Get("size").String()
 	licenseType := d.Get("license_type").String()
 
-	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType)}
+	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, d.Get("priority").String())}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -44,10 +44,15 @@ func NewAzureRMWindowsVirtualMachine(d *schema.ResourceData, u *schema.UsageData
 	}
 }
 
-func windowsVirtualMachineCostComponent(region string, instanceType string, licenseType string) *schema.CostComponent {
+func windowsVirtualMachineCostComponent(region string, instanceType string, licenseType string, priority string) *schema.CostComponent {
 	purchaseOption := "Consumption"
 	purchaseOptionLabel := "pay as you go"
 
+	skuNameRe, priorityLabel := virtualMachinePriority(priority)
+	if priorityLabel != "" {
+		purchaseOptionLabel = priorityLabel
+	}
+
 	productNameRe := "/Virtual Machines .* Series Windows$/"
 	if strings.HasPrefix(instanceType, "Basic_") {
 		productNameRe = "/Virtual Machines .* Series Basic Windows$/"
@@ -72,7 +77,7 @@ func windowsVirtualMachineCostComponent(region string, instanceType string, lice
 			Service:       strPtr("Virtual Machines"),
 			ProductFamily: strPtr("Compute"),
 			AttributeFilters: []*schema.AttributeFilter{
-				{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+				{Key: "skuName", ValueRegex: strPtr(skuNameRe)},
 				{Key: "armSkuName", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", instanceType))},
 				{Key: "productName", ValueRegex: strPtr(productNameRe)},
 			},
@@ -10,6 +10,9 @@ func GetAzureRMWindowsVirtualMachineScaleSetRegistryItem() *schema.RegistryItem
 	return &schema.RegistryItem{
 		Name:  "azurerm_windows_virtual_machine_scale_set",
 		RFunc: NewAzureRMWindowsVirtualMachineScaleSet,
+		// this is a reverse reference, it depends on the azurerm_monitor_autoscale_setting RegistryItem
+		// defining "target_resource_id" as a ReferenceAttribute
+		ReferenceAttributes: []string{"azurerm_monitor_autoscale_setting.target_resource_id"},
 	}
 }
 
@@ -19,7 +22,7 @@ func NewAzureRMWindowsVirtualMachineScaleSet(d *schema.ResourceData, u *schema.U
 	instanceType := d.Get("sku").String()
 	licenseType := d.Get("license_type").String()
 
-	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType)}
+	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, d.Get("priority").String())}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -32,7 +35,7 @@ func NewAzureRMWindowsVirtualMachineScaleSet(d *schema.ResourceData, u *schema.U
 		subResources = append(subResources, osDisk)
 	}
 
-	instanceCount := decimal.NewFromInt(d.Get("instances").Int())
+	instanceCount := autoscaleInstanceCount(d, d.Get("instances").Int())
 	if u != nil && u.Get("instances").Type != gjson.Null {
 		instanceCount = decimal.NewFromInt(u.Get("instances").Int())
 	}
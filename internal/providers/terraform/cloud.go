@@ -6,7 +6,6 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"path"
 	"path/filepath"
 	"runtime"
 
@@ -15,13 +14,15 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/httpclient"
 )
 
 var ErrMissingCloudToken = errors.New("no Terraform Cloud token is set")
 var ErrInvalidCloudToken = errors.New("invalid Terraform Cloud Token")
 
 func cloudAPI(host string, path string, token string) ([]byte, error) {
-	client := &http.Client{}
+	client := httpclient.NewClient(httpclient.PurposeRemoteVariables)
 
 	url := fmt.Sprintf("https://%s%s", host, path)
 	log.Debugf("Calling Terraform Cloud API: %s", url)
@@ -168,5 +169,5 @@ func defaultCredFile() string {
 	} else {
 		dir, _ = homedir.Expand("~/.terraform.d")
 	}
-	return path.Join(dir, "credentials.tfrc.json")
+	return filepath.Join(dir, "credentials.tfrc.json")
 }
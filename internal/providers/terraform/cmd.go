@@ -8,12 +8,45 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	log "github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/httpclient"
+)
+
+var (
+	defaultTerraformBinary = "terraform"
+	defaultOpenTofuBinary  = "tofu"
 )
 
-var defaultTerraformBinary = "terraform"
+// IsOpenTofuBinary returns true if the given binary is the OpenTofu CLI.
+func IsOpenTofuBinary(binary string) bool {
+	name := strings.TrimSuffix(filepath.Base(binary), ".exe")
+	return name == defaultOpenTofuBinary
+}
+
+// moduleRegistryHost returns the registry host that should be used to resolve registry modules
+// for the given project. If the project has not explicitly set a registry then OpenTofu projects
+// use the OpenTofu registry, other projects use the default Terraform registry.
+func moduleRegistryHost(projectCfg *config.Project) string {
+	if projectCfg.TerraformRegistryHost != "" {
+		return projectCfg.TerraformRegistryHost
+	}
+
+	if IsOpenTofuBinary(projectCfg.TerraformBinary) {
+		return modules.OpenTofuRegistryHost
+	}
+
+	return ""
+}
 
 type CmdOptions struct {
 	TerraformBinary     string
@@ -39,6 +72,13 @@ func Cmd(opts *CmdOptions, args ...string) ([]byte, error) {
 		exe = defaultTerraformBinary
 	}
 
+	if !isOfflineCmd(args) {
+		err := httpclient.CheckAllowed(httpclient.PurposeTerraform, strings.Join(append([]string{exe}, args...), " "))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	cmd := exec.Command(exe, append(args, opts.Flags...)...)
 	log.Debugf("Running command: %s", cmd.String())
 	cmd.Dir = opts.Dir
@@ -89,6 +129,25 @@ func Cmd(opts *CmdOptions, args ...string) ([]byte, error) {
 	return outbuf.Bytes(), nil
 }
 
+// isOfflineCmd returns true if the Terraform command doesn't access the network, so it can be run
+// whatever the network policy. Showing a plan file is offline but showing the state isn't, since
+// the state can be stored in a remote backend.
+func isOfflineCmd(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch args[0] {
+	case "version":
+		return true
+	case "show":
+		last := args[len(args)-1]
+		return len(args) > 1 && !strings.HasPrefix(last, "-")
+	}
+
+	return false
+}
+
 type cmdLogger interface {
 	Log(level log.Level, args ...interface{})
 }
@@ -137,77 +196,142 @@ func (w *cmdLogWriter) Flush() {
 	}
 }
 
-func CreateConfigFile(dir string, terraformCloudHost string, terraformCloudToken string) (string, error) {
-	if terraformCloudToken == "" {
+// CreateConfigFile creates a temporary Terraform CLI config file with the Terraform Cloud credentials and
+// the provider mirror, if these are set. The user's existing CLI config, from TF_CLI_CONFIG_FILE or the
+// default location, is copied into the file so that settings such as provider_installation and
+// plugin_cache_dir are still used. It returns an empty path if no config file is needed.
+func CreateConfigFile(dir string, terraformCloudHost string, terraformCloudToken string, providerMirror string) (string, error) {
+	if terraformCloudToken == "" && providerMirror == "" {
 		return "", nil
 	}
 
-	log.Debug("Creating temporary config file for Terraform credentials")
+	log.Debug("Creating temporary Terraform CLI config file")
 	tmpFile, err := os.CreateTemp("", "")
 	if err != nil {
 		return "", err
 	}
+	defer tmpFile.Close()
 
-	if os.Getenv("TF_CLI_CONFIG_FILE") != "" {
-		log.Debugf("TF_CLI_CONFIG_FILE is set, copying existing config from %s to config to temporary config file %s", os.Getenv("TF_CLI_CONFIG_FILE"), tmpFile.Name())
-		path := os.Getenv("TF_CLI_CONFIG_FILE")
+	var existing []byte
+	if path := cliConfigFilePath(dir); path != "" {
+		log.Debugf("Copying existing Terraform CLI config from %s to temporary config file %s", path, tmpFile.Name())
 
-		if !filepath.IsAbs(path) {
-			path, err = filepath.Abs(filepath.Join(dir, path))
-			if err != nil {
-				log.Warningf("Unable to copy existing config from %s: %v", path, err)
-			}
+		existing, err = os.ReadFile(path)
+		if err != nil {
+			log.Warningf("Unable to copy existing config from %s: %v", path, err)
 		}
+	}
 
-		if err == nil {
-			err = copyFile(path, tmpFile.Name())
-			if err != nil {
-				log.Warningf("Unable to copy existing config from %s: %v", path, err)
-			}
+	contents := existing
+	if providerMirror != "" {
+		contents = withProviderMirror(existing, providerMirror)
+	}
+
+	if terraformCloudToken != "" {
+		host := terraformCloudHost
+		if host == "" {
+			host = "app.terraform.io"
 		}
+
+		log.Debugf("Writing Terraform credentials to temporary config file %s", tmpFile.Name())
+		contents = append(contents, []byte(fmt.Sprintf(`
+credentials "%s" {
+	token = "%s"
+}
+`, host, terraformCloudToken))...)
 	}
 
-	f, err := os.OpenFile(tmpFile.Name(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
+	if _, err := tmpFile.Write(contents); err != nil {
 		return tmpFile.Name(), err
 	}
-	defer f.Close()
 
-	host := terraformCloudHost
-	if host == "" {
-		host = "app.terraform.io"
+	return tmpFile.Name(), nil
+}
+
+// cliConfigFilePath returns the path of the user's Terraform CLI config file. This is TF_CLI_CONFIG_FILE,
+// resolved relative to dir, or the default CLI config file if it exists.
+func cliConfigFilePath(dir string) string {
+	if path := os.Getenv("TF_CLI_CONFIG_FILE"); path != "" {
+		if filepath.IsAbs(path) {
+			return path
+		}
+
+		abs, err := filepath.Abs(filepath.Join(dir, path))
+		if err != nil {
+			log.Warningf("Unable to resolve Terraform CLI config file %s: %v", path, err)
+			return ""
+		}
+
+		return abs
 	}
 
-	contents := fmt.Sprintf(`credentials "%s" {
-	token = "%s"
+	if path := defaultConfFile(); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	return ""
 }
-`, host, terraformCloudToken)
 
-	log.Debugf("Writing Terraform credentials to temporary config file %s", tmpFile.Name())
-	if _, err := f.WriteString(contents); err != nil {
-		return tmpFile.Name(), err
+// withProviderMirror returns the CLI config with a provider_installation block that installs all providers
+// from the mirror. The mirror is either an HTTPS URL of a network mirror or a path to a filesystem mirror.
+// Any existing provider_installation blocks are replaced since Terraform only allows one.
+func withProviderMirror(cliConfig []byte, mirror string) []byte {
+	f, diags := hclwrite.ParseConfig(cliConfig, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		log.Warningf("Unable to parse existing Terraform CLI config, ignoring it: %s", diags.Error())
+		f = hclwrite.NewEmptyFile()
 	}
 
-	return tmpFile.Name(), nil
+	for _, b := range f.Body().Blocks() {
+		if b.Type() == "provider_installation" {
+			log.Debugf("Replacing provider_installation in Terraform CLI config with provider mirror %s", mirror)
+			f.Body().RemoveBlock(b)
+		}
+	}
+
+	installation := f.Body().AppendNewBlock("provider_installation", nil)
+	if strings.HasPrefix(mirror, "https://") {
+		installation.Body().AppendNewBlock("network_mirror", nil).Body().SetAttributeValue("url", cty.StringVal(mirror))
+	} else {
+		path, err := filepath.Abs(mirror)
+		if err != nil {
+			path = mirror
+		}
+
+		installation.Body().AppendNewBlock("filesystem_mirror", nil).Body().SetAttributeValue("path", cty.StringVal(path))
+	}
+
+	return f.Bytes()
 }
 
-func copyFile(srcPath string, dstPath string) error {
-	src, err := os.Open(srcPath)
+// cliConfigPluginCacheDir returns the plugin_cache_dir set in the Terraform CLI config file at path.
+func cliConfigPluginCacheDir(path string) string {
+	src, err := os.ReadFile(path)
 	if err != nil {
-		return err
+		return ""
 	}
-	defer src.Close()
 
-	dst, err := os.Create(dstPath)
-	if err != nil {
-		return err
+	f, diags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+	if diags.HasErrors() {
+		return ""
 	}
-	defer dst.Close()
 
-	_, err = io.Copy(dst, src)
-	if err != nil {
-		return err
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return ""
+	}
+
+	attr, ok := body.Attributes["plugin_cache_dir"]
+	if !ok {
+		return ""
+	}
+
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() || !val.IsKnown() || val.Type() != cty.String {
+		return ""
 	}
 
-	return nil
+	return val.AsString()
 }
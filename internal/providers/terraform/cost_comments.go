@@ -0,0 +1,39 @@
+package terraform
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CostCommentPrefix starts the comments that infracost annotate writes above resource and
+// module blocks, e.g. # infracost: ~$214/mo.
+const CostCommentPrefix = "# infracost:"
+
+var costCommentReg = regexp.MustCompile(`^\s*# infracost:`)
+
+// UpdateCostComments returns the source of a Terraform file with the given comments above the
+// blocks that start on each line, replacing any previous infracost comment. Lines with an empty
+// comment have their previous infracost comment removed. The comments are indented to match
+// their block.
+func UpdateCostComments(src []byte, comments map[int]string) []byte {
+	lines := strings.SplitAfter(string(src), "\n")
+
+	var b strings.Builder
+	b.Grow(len(src))
+
+	for i, line := range lines {
+		// Skip the previous comment of an annotated block, since it's replaced below.
+		if _, ok := comments[i+2]; ok && costCommentReg.MatchString(line) {
+			continue
+		}
+
+		if comment, ok := comments[i+1]; ok && comment != "" {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			b.WriteString(indent + comment + "\n")
+		}
+
+		b.WriteString(line)
+	}
+
+	return []byte(b.String())
+}
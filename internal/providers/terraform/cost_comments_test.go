@@ -0,0 +1,63 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateCostComments(t *testing.T) {
+	src := `# infracost: ~$10/mo
+resource "aws_instance" "web" {
+  ami = "ami-123"
+}
+
+# Database
+# infracost: ~$20/mo
+resource "aws_db_instance" "db" {
+}
+
+module "vpc" {
+  source = "./vpc"
+}
+
+  resource "aws_eip" "nat" {
+  }
+`
+
+	expected := `# infracost: ~$15/mo
+resource "aws_instance" "web" {
+  ami = "ami-123"
+}
+
+# Database
+resource "aws_db_instance" "db" {
+}
+
+# infracost: ~$32/mo
+module "vpc" {
+  source = "./vpc"
+}
+
+  # infracost: ~$3.65/mo
+  resource "aws_eip" "nat" {
+  }
+`
+
+	actual := UpdateCostComments([]byte(src), map[int]string{
+		2:  "# infracost: ~$15/mo",
+		8:  "",
+		11: "# infracost: ~$32/mo",
+		15: "# infracost: ~$3.65/mo",
+	})
+
+	assert.Equal(t, expected, string(actual))
+
+	// Running again with the same costs doesn't change anything.
+	assert.Equal(t, expected, string(UpdateCostComments(actual, map[int]string{
+		2:  "# infracost: ~$15/mo",
+		7:  "",
+		11: "# infracost: ~$32/mo",
+		16: "# infracost: ~$3.65/mo",
+	})))
+}
@@ -24,7 +24,10 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-var minTerraformVer = "v0.12"
+var (
+	minTerraformVer = "v0.12"
+	minOpenTofuVer  = "v1.6.0"
+)
 
 type DirProvider struct {
 	ctx                  *config.ProjectContext
@@ -39,6 +42,8 @@ type DirProvider struct {
 	TerraformCloudHost   string
 	TerraformCloudToken  string
 	Env                  map[string]string
+	EphemeralWorkspace   bool
+	ProviderMirror       string
 	cachedStateJSON      []byte
 	cachedPlanJSON       []byte
 	includePastResources bool
@@ -58,9 +63,11 @@ func NewDirProvider(ctx *config.ProjectContext, includePastResources bool) schem
 		ctx:  ctx,
 		Path: ctx.ProjectConfig.Path,
 		spinnerOpts: ui.SpinnerOptions{
-			EnableLogging: ctx.RunContext.Config.IsLogging(),
-			NoColor:       ctx.RunContext.Config.NoColor,
-			Indent:        "  ",
+			EnableLogging:  ctx.RunContext.Config.IsLogging(),
+			NoColor:        ctx.RunContext.Config.NoColor,
+			NoProgress:     ctx.RunContext.Config.NoProgress,
+			NonInteractive: ctx.RunContext.Config.NonInteractive,
+			Indent:         "  ",
 		},
 		PlanFlags:            ctx.ProjectConfig.TerraformPlanFlags,
 		InitFlags:            ctx.ProjectConfig.TerraformInitFlags,
@@ -69,7 +76,9 @@ func NewDirProvider(ctx *config.ProjectContext, includePastResources bool) schem
 		TerraformBinary:      terraformBinary,
 		TerraformCloudHost:   ctx.ProjectConfig.TerraformCloudHost,
 		TerraformCloudToken:  ctx.ProjectConfig.TerraformCloudToken,
-		Env:                  ctx.ProjectConfig.Env,
+		Env:                  ctx.ProjectConfig.EnvWithCredentials(),
+		EphemeralWorkspace:   ctx.ProjectConfig.TerraformEphemeralWorkspace,
+		ProviderMirror:       ctx.ProjectConfig.ProviderMirror,
 		includePastResources: includePastResources,
 	}
 }
@@ -79,9 +88,31 @@ func (p *DirProvider) Type() string {
 }
 
 func (p *DirProvider) DisplayType() string {
+	if IsOpenTofuBinary(p.TerraformBinary) {
+		return "OpenTofu directory"
+	}
+
 	return "Terraform directory"
 }
 
+// cmdName returns the user facing name of the given subcommand for the
+// binary this DirProvider uses, e.g. "tofu plan" or "terragrunt run-all plan".
+func (p *DirProvider) cmdName(subcommand string) string {
+	if p.IsTerragrunt {
+		if subcommand == "show" {
+			return "terragrunt show"
+		}
+
+		return "terragrunt run-all " + subcommand
+	}
+
+	if IsOpenTofuBinary(p.TerraformBinary) {
+		return "tofu " + subcommand
+	}
+
+	return "terraform " + subcommand
+}
+
 func (p *DirProvider) checks() error {
 	binary := p.TerraformBinary
 
@@ -89,16 +120,20 @@ func (p *DirProvider) checks() error {
 
 	_, err := exec.LookPath(binary)
 	if err != nil {
-		msg := fmt.Sprintf("Terraform binary '%s' could not be found. You have two options:\n", binary)
-		msg += "1. Set a custom Terraform binary using the environment variable INFRACOST_TERRAFORM_BINARY.\n\n"
-		msg += fmt.Sprintf("2. Set --path to a Terraform plan JSON file. See %s for how to generate this.", ui.LinkString("https://infracost.io/troubleshoot"))
-		return clierror.NewSanitizedError(errors.Errorf(msg), "Terraform binary could not be found")
+		msg := fmt.Sprintf("Terraform binary '%s' could not be found.", binary)
+		hint := "You have two options:\n"
+		hint += "1. Set a custom Terraform binary using the environment variable INFRACOST_TERRAFORM_BINARY.\n\n"
+		hint += fmt.Sprintf("2. Set --path to a Terraform plan JSON file. See %s for how to generate this.", ui.LinkString("https://infracost.io/troubleshoot"))
+		e := clierror.New(clierror.CodeTerraformNotFound, clierror.CategoryConfig, msg, hint)
+		return clierror.NewSanitizedError(e, "Terraform binary could not be found")
 	}
 
 	out, err := exec.Command(binary, "-version").Output()
 	if err != nil {
 		msg := fmt.Sprintf("Could not get version of Terraform binary '%s'", binary)
-		return clierror.NewSanitizedError(errors.Errorf(msg), "Could not get version of Terraform binary")
+		hint := "Check the binary runs, or set a custom Terraform binary using the environment variable INFRACOST_TERRAFORM_BINARY."
+		e := clierror.New(clierror.CodeTerraformVersion, clierror.CategoryConfig, msg, hint)
+		return clierror.NewSanitizedError(e, "Could not get version of Terraform binary")
 	}
 
 	fullVersion := strings.SplitN(string(out), "\n", 2)[0]
@@ -143,9 +178,11 @@ func (p *DirProvider) LoadResources(usage map[string]*schema.UsageData) ([]*sche
 	}
 
 	spinner := ui.NewSpinner("Extracting only cost-related params from terraform", ui.SpinnerOptions{
-		EnableLogging: p.ctx.RunContext.Config.IsLogging(),
-		NoColor:       p.ctx.RunContext.Config.NoColor,
-		Indent:        "  ",
+		EnableLogging:  p.ctx.RunContext.Config.IsLogging(),
+		NoColor:        p.ctx.RunContext.Config.NoColor,
+		NoProgress:     p.ctx.RunContext.Config.NoProgress,
+		NonInteractive: p.ctx.RunContext.Config.NonInteractive,
+		Indent:         "  ",
 	})
 	defer spinner.Fail()
 
@@ -216,6 +253,16 @@ func (p *DirProvider) generatePlanJSON() ([]byte, error) {
 		defer os.Remove(opts.TerraformConfigFile)
 	}
 
+	if p.EphemeralWorkspace {
+		w, err := newEphemeralWorkspace(p.Path)
+		if err != nil {
+			return []byte{}, err
+		}
+		defer w.remove()
+
+		w.configure(opts)
+	}
+
 	spinner := ui.NewSpinner("Running terraform plan", p.spinnerOpts)
 	defer spinner.Fail()
 
@@ -260,6 +307,16 @@ func (p *DirProvider) generateStateJSON() ([]byte, error) {
 		defer os.Remove(opts.TerraformConfigFile)
 	}
 
+	if p.EphemeralWorkspace {
+		w, err := newEphemeralWorkspace(p.Path)
+		if err != nil {
+			return []byte{}, err
+		}
+		defer w.remove()
+
+		w.configure(opts)
+	}
+
 	spinner := ui.NewSpinner("Running terraform show", p.spinnerOpts)
 	defer spinner.Fail()
 
@@ -278,7 +335,7 @@ func (p *DirProvider) buildCommandOpts(path string) (*CmdOptions, error) {
 		Env:                p.Env,
 	}
 
-	cfgFile, err := CreateConfigFile(p.Path, p.TerraformCloudHost, p.TerraformCloudToken)
+	cfgFile, err := CreateConfigFile(p.Path, p.TerraformCloudHost, p.TerraformCloudToken, p.ProviderMirror)
 	if err != nil {
 		return opts, err
 	}
@@ -341,11 +398,7 @@ func (p *DirProvider) runPlan(opts *CmdOptions, spinner *ui.Spinner, initOnFail
 		spinner.Fail()
 		err = p.buildTerraformErr(err, false)
 
-		cmdName := "terraform plan"
-		if p.IsTerragrunt {
-			cmdName = "terragrunt run-all plan"
-		}
-		msg := fmt.Sprintf("%s failed", cmdName)
+		msg := fmt.Sprintf("%s failed", p.cmdName("plan"))
 		return "", planJSON, clierror.NewSanitizedError(fmt.Errorf("%s: %s", msg, err), msg)
 	}
 
@@ -378,11 +431,7 @@ func (p *DirProvider) runInit(opts *CmdOptions, spinner *ui.Spinner) error {
 		spinner.Fail()
 		err = p.buildTerraformErr(err, true)
 
-		cmdName := "terraform init"
-		if p.IsTerragrunt {
-			cmdName = "terragrunt run-all init"
-		}
-		msg := fmt.Sprintf("%s failed", cmdName)
+		msg := fmt.Sprintf("%s failed", p.cmdName("init"))
 		return clierror.NewSanitizedError(fmt.Errorf("%s: %s", msg, err), msg)
 	}
 
@@ -453,11 +502,7 @@ func (p *DirProvider) runShow(opts *CmdOptions, spinner *ui.Spinner, planFile st
 		spinner.Fail()
 		err = p.buildTerraformErr(err, false)
 
-		cmdName := "terraform show"
-		if p.IsTerragrunt {
-			cmdName = "terragrunt show"
-		}
-		msg := fmt.Sprintf("%s failed", cmdName)
+		msg := fmt.Sprintf("%s failed", p.cmdName("show"))
 		return []byte{}, clierror.NewSanitizedError(fmt.Errorf("%s: %s", msg, err), msg)
 	}
 	spinner.Success()
@@ -494,11 +539,15 @@ func checkTerraformVersion(v string, fullV string) error {
 		return fmt.Errorf("Terraform %s is not supported. Please use Terraform version >= %s. Update it or set the environment variable INFRACOST_TERRAFORM_BINARY.", v, minTerraformVer) //nolint
 	}
 
+	if strings.HasPrefix(fullV, "OpenTofu ") && semver.Compare(v, minOpenTofuVer) < 0 {
+		return fmt.Errorf("OpenTofu %s is not supported. Please use OpenTofu version >= %s. Update it or set the environment variable INFRACOST_TERRAFORM_BINARY.", v, minOpenTofuVer) //nolint
+	}
+
 	if strings.HasPrefix(fullV, "terragrunt") && semver.Compare(v, minTerragruntVer) < 0 {
 		return fmt.Errorf("Terragrunt %s is not supported. Please use Terragrunt version >= %s. Update it or set the environment variable INFRACOST_TERRAFORM_BINARY.", v, minTerragruntVer) //nolint
 	}
 
-	// Allow any non-terraform and non-terragrunt binaries
+	// Allow any other binaries
 	return nil
 }
 
@@ -508,6 +557,8 @@ func (p *DirProvider) buildTerraformErr(err error, isInit bool) error {
 	binName := "Terraform"
 	if p.IsTerragrunt {
 		binName = "Terragrunt"
+	} else if IsOpenTofuBinary(p.TerraformBinary) {
+		binName = "OpenTofu"
 	}
 
 	msg := ""
@@ -0,0 +1,77 @@
+package google
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getComputeAutoscalerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_compute_autoscaler",
+		RFunc: newComputeAutoscaler,
+		// This reference is used by google_compute_instance_group_manager to generate a reverse
+		// reference, the scaling schedules are costed as part of the target size of the group
+		ReferenceAttributes: []string{"target"},
+	}
+}
+
+func getComputeRegionAutoscalerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_compute_region_autoscaler",
+		RFunc: newComputeAutoscaler,
+		// This reference is used by google_compute_region_instance_group_manager to generate a reverse
+		// reference, the scaling schedules are costed as part of the target size of the group
+		ReferenceAttributes: []string{"target"},
+	}
+}
+
+func newComputeAutoscaler(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return &schema.Resource{
+		Name:         d.Address,
+		ResourceType: d.Type,
+		Tags:         d.Tags,
+		IsSkipped:    true,
+		NoPrice:      true,
+		SkipMessage:  "Free resource.",
+	}
+}
+
+// autoscalerTargetSize returns the time-weighted average number of instances of an instance group that
+// has an autoscaler with scaling schedules, or nil if it doesn't have one. The group has the minimum
+// replicas of the autoscaler unless a scaling schedule requires more of them.
+func autoscalerTargetSize(d *schema.ResourceData, refKey string) *decimal.Decimal {
+	for _, ref := range d.References(refKey) {
+		var schedules []*resources.CapacitySchedule
+
+		for _, s := range ref.Get("autoscaling_policy.0.scaling_schedules").Array() {
+			if s.Get("disabled").Bool() {
+				continue
+			}
+
+			schedules = append(schedules, &resources.CapacitySchedule{
+				Start:    s.Get("schedule").String(),
+				Capacity: s.Get("min_required_replicas").Int(),
+				Duration: time.Duration(s.Get("duration_sec").Int()) * time.Second,
+			})
+		}
+
+		if len(schedules) == 0 {
+			continue
+		}
+
+		avg, err := resources.AverageCapacity(ref.Get("autoscaling_policy.0.min_replicas").Int(), schedules)
+		if err != nil {
+			log.Warnf("Ignoring the scaling schedules of %s: %s", ref.Address, err)
+			continue
+		}
+
+		return &avg
+	}
+
+	return nil
+}
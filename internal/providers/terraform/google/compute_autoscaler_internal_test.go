@@ -0,0 +1,34 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestAutoscalerTargetSize(t *testing.T) {
+	igm := schema.NewResourceData("google_compute_instance_group_manager", "google", "google_compute_instance_group_manager.igm", nil, gjson.Parse(`{"target_size": 3}`))
+	assert.Nil(t, autoscalerTargetSize(igm, "google_compute_autoscaler.target"))
+
+	autoscaler := schema.NewResourceData("google_compute_autoscaler", "google", "google_compute_autoscaler.igm", nil, gjson.Parse(`{
+		"autoscaling_policy": [{
+			"min_replicas": 1,
+			"max_replicas": 10,
+			"scaling_schedules": [
+				{"name": "business-hours", "min_required_replicas": 5, "schedule": "0 9 * * *", "time_zone": "UTC", "duration_sec": 21600},
+				{"name": "disabled", "min_required_replicas": 10, "schedule": "0 0 * * *", "duration_sec": 3600, "disabled": true}
+			]
+		}]
+	}`))
+	autoscaler.AddReference("target", igm, getComputeInstanceGroupManagerRegistryItem().ReferenceAttributes)
+
+	// 5 instances for 6 hours and 1 for the other 18 hours of the day
+	got := autoscalerTargetSize(igm, "google_compute_autoscaler.target")
+	require.NotNil(t, got)
+	assert.True(t, decimal.NewFromInt(5*6+18).Div(decimal.NewFromInt(24)).Equal(*got), "got %s", got)
+}
@@ -70,10 +70,10 @@ func newComputeInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 }
 
 // getComputePurchaseOption determines the purchase option for Compute
-// resources.
+// resources. Spot VMs use the same prices as preemptible VMs.
 func getComputePurchaseOption(d gjson.Result) string {
 	purchaseOption := "on_demand"
-	if d.Get("scheduling.0.preemptible").Bool() {
+	if d.Get("scheduling.0.preemptible").Bool() || strings.EqualFold(d.Get("scheduling.0.provisioning_model").String(), "SPOT") {
 		purchaseOption = "preemptible"
 	}
 
@@ -7,10 +7,15 @@ import (
 
 func getComputeInstanceGroupManagerRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
-		Name:                "google_compute_instance_group_manager",
-		RFunc:               newComputeInstanceGroupManager,
-		Notes:               []string{"Multiple versions are not supported."},
-		ReferenceAttributes: []string{"version.0.instance_template"},
+		Name:  "google_compute_instance_group_manager",
+		RFunc: newComputeInstanceGroupManager,
+		Notes: []string{"Multiple versions are not supported."},
+		ReferenceAttributes: []string{
+			"version.0.instance_template",
+			// this is a reverse reference, it depends on the google_compute_autoscaler RegistryItem
+			// defining "target" as a ReferenceAttribute
+			"google_compute_autoscaler.target",
+		},
 	}
 }
 
@@ -61,6 +66,7 @@ func newComputeInstanceGroupManager(d *schema.ResourceData, u *schema.UsageData)
 		MachineType:       machineType,
 		PurchaseOption:    purchaseOption,
 		TargetSize:        targetSize,
+		AverageTargetSize: autoscalerTargetSize(d, "google_compute_autoscaler.target"),
 		Disks:             disks,
 		GuestAccelerators: guestAccelerators,
 	}
@@ -7,10 +7,15 @@ import (
 
 func getComputeRegionInstanceGroupManagerRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
-		Name:                "google_compute_region_instance_group_manager",
-		RFunc:               newComputeRegionInstanceGroupManager,
-		Notes:               []string{"Multiple versions are not supported."},
-		ReferenceAttributes: []string{"version.0.instance_template"},
+		Name:  "google_compute_region_instance_group_manager",
+		RFunc: newComputeRegionInstanceGroupManager,
+		Notes: []string{"Multiple versions are not supported."},
+		ReferenceAttributes: []string{
+			"version.0.instance_template",
+			// this is a reverse reference, it depends on the google_compute_region_autoscaler RegistryItem
+			// defining "target" as a ReferenceAttribute
+			"google_compute_region_autoscaler.target",
+		},
 	}
 }
 
@@ -32,9 +37,7 @@ func newComputeRegionInstanceGroupManager(d *schema.ResourceData, u *schema.Usag
 
 		machineType = instanceTemplate.Get("machine_type").String()
 
-		if instanceTemplate.Get("scheduling.0.preemptible").Bool() {
-			purchaseOption = "preemptible"
-		}
+		purchaseOption = getComputePurchaseOption(instanceTemplate.RawValues)
 
 		for _, disk := range instanceTemplate.Get("disk").Array() {
 			diskSize := int64(100)
@@ -58,6 +61,7 @@ func newComputeRegionInstanceGroupManager(d *schema.ResourceData, u *schema.Usag
 		MachineType:       machineType,
 		PurchaseOption:    purchaseOption,
 		TargetSize:        targetSize,
+		AverageTargetSize: autoscalerTargetSize(d, "google_compute_region_autoscaler.target"),
 		Disks:             disks,
 		GuestAccelerators: guestAccelerators,
 	}
@@ -15,6 +15,7 @@ func getContainerNodePoolRegistryItem() *schema.RegistryItem {
 		ReferenceAttributes: []string{
 			"cluster",
 		},
+		RollupReference: "cluster",
 		Notes: []string{
 			"Sustained use discounts are applied to monthly costs, but not to hourly costs.",
 			"Costs associated with non-standard Linux images, such as Windows and RHEL are not supported.",
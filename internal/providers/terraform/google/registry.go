@@ -8,6 +8,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getBigQueryTableRegistryItem(),
 	getCloudFunctionsRegistryItem(),
 	getComputeAddressRegistryItem(),
+	getComputeAutoscalerRegistryItem(),
 	getComputeDiskRegistryItem(),
 	getComputeExternalVPNGatewayRegistryItem(),
 	getComputeForwardingRuleRegistryItem(),
@@ -18,6 +19,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getComputeInstanceGroupManagerRegistryItem(),
 	getComputeInstanceRegistryItem(),
 	getComputeMachineImageRegistryItem(),
+	getComputeRegionAutoscalerRegistryItem(),
 	getComputeRegionInstanceGroupManagerRegistryItem(),
 	getComputeRegionTargetHTTPProxyRegistryItem(),
 	getComputeRegionTargetHTTPSProxyRegistryItem(),
@@ -53,6 +55,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getServiceNetworkingConnectionRegistryItem(),
 	GetSQLInstanceRegistryItem(),
 	getStorageBucketRegistryItem(),
+	getVertexAIEndpointRegistryItem(),
 }
 
 // FreeResources grouped alphabetically
@@ -203,12 +206,10 @@ var UsageOnlyResources = []string{}
 // google_compute_instance_from_template
 //
 // Node groups and autoscaling:
-// google_compute_autoscaler
 // google_compute_instance_template
 // google_compute_target_pool
 // google_compute_instance_group_manager
 // google_compute_per_instance_config
-// google_compute_region_autoscaler
 // google_compute_node_group
 // google_compute_node_template
 // google_compute_region_instance_group_manager
@@ -0,0 +1,166 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_compute_instance_template" "appserver" {
+  name         = "appserver-template"
+  machine_type = "n1-standard-1"
+
+  disk {
+    source_image = "debian-cloud/debian-11"
+    boot         = true
+  }
+}
+
+resource "google_compute_instance_group_manager" "business_hours" {
+  name = "business_hours"
+
+  base_instance_name = "app"
+  zone               = "us-central1-a"
+
+  version {
+    instance_template = google_compute_instance_template.appserver.id
+  }
+
+  target_size = 1
+}
+
+# Scales up to 6 instances for 10 hours on weekdays and down to 2 instances otherwise
+resource "google_compute_autoscaler" "business_hours" {
+  name   = "business_hours"
+  zone   = "us-central1-a"
+  target = google_compute_instance_group_manager.business_hours.id
+
+  autoscaling_policy {
+    min_replicas = 2
+    max_replicas = 10
+
+    scaling_schedules {
+      name                  = "business-hours"
+      schedule              = "0 8 * * MON-FRI"
+      duration_sec          = 36000
+      min_required_replicas = 6
+      time_zone             = "UTC"
+    }
+  }
+}
+
+resource "google_compute_instance_group_manager" "disabled_schedule" {
+  name = "disabled_schedule"
+
+  base_instance_name = "app"
+  zone               = "us-central1-a"
+
+  version {
+    instance_template = google_compute_instance_template.appserver.id
+  }
+
+  target_size = 4
+}
+
+# Disabled schedules are ignored so the target size of the group is used
+resource "google_compute_autoscaler" "disabled_schedule" {
+  name   = "disabled_schedule"
+  zone   = "us-central1-a"
+  target = google_compute_instance_group_manager.disabled_schedule.id
+
+  autoscaling_policy {
+    min_replicas = 2
+    max_replicas = 10
+
+    scaling_schedules {
+      name                  = "business-hours"
+      schedule              = "0 8 * * MON-FRI"
+      duration_sec          = 36000
+      min_required_replicas = 6
+      time_zone             = "UTC"
+      disabled              = true
+    }
+  }
+}
+
+resource "google_compute_instance_group_manager" "invalid_schedule" {
+  name = "invalid_schedule"
+
+  base_instance_name = "app"
+  zone               = "us-central1-a"
+
+  version {
+    instance_template = google_compute_instance_template.appserver.id
+  }
+
+  target_size = 3
+}
+
+resource "google_compute_autoscaler" "invalid_schedule" {
+  name   = "invalid_schedule"
+  zone   = "us-central1-a"
+  target = google_compute_instance_group_manager.invalid_schedule.id
+
+  autoscaling_policy {
+    min_replicas = 2
+    max_replicas = 10
+
+    scaling_schedules {
+      name                  = "invalid"
+      schedule              = "not a cron expression"
+      duration_sec          = 3600
+      min_required_replicas = 6
+      time_zone             = "UTC"
+    }
+  }
+}
+
+resource "google_compute_instance_group_manager" "no_autoscaler" {
+  name = "no_autoscaler"
+
+  base_instance_name = "app"
+  zone               = "us-central1-a"
+
+  version {
+    instance_template = google_compute_instance_template.appserver.id
+  }
+
+  target_size = 3
+}
+
+resource "google_compute_region_instance_group_manager" "business_hours" {
+  name = "region-business-hours"
+
+  base_instance_name = "app"
+  region             = "us-central1"
+
+  version {
+    instance_template = google_compute_instance_template.appserver.id
+  }
+
+  target_size = 1
+}
+
+resource "google_compute_region_autoscaler" "business_hours" {
+  name   = "business_hours"
+  region = "us-central1"
+  target = google_compute_region_instance_group_manager.business_hours.id
+
+  autoscaling_policy {
+    min_replicas = 1
+    max_replicas = 10
+
+    scaling_schedules {
+      name                  = "business-hours"
+      schedule              = "0 8 * * MON-FRI"
+      duration_sec          = 36000
+      min_required_replicas = 6
+      time_zone             = "UTC"
+    }
+
+    scaling_schedules {
+      name                  = "weekend-batch"
+      schedule              = "0 0 * * SAT"
+      duration_sec          = 7200
+      min_required_replicas = 3
+      time_zone             = "UTC"
+    }
+  }
+}
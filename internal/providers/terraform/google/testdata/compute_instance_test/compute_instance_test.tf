@@ -151,3 +151,121 @@ resource "google_compute_instance" "preemptible_gpu" {
     network = "default"
   }
 }
+
+resource "google_compute_instance" "a100_gpu" {
+  name         = "a100_gpu"
+  machine_type = "a2-highgpu-1g"
+  zone         = "us-central1-a"
+
+  boot_disk {
+    initialize_params {
+      image = "centos-cloud/centos-7"
+    }
+  }
+
+  guest_accelerator {
+    type  = "nvidia-tesla-a100"
+    count = 1
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
+
+resource "google_compute_instance" "a2_bundled_gpu" {
+  name         = "a2_bundled_gpu"
+  machine_type = "a2-ultragpu-2g"
+  zone         = "us-central1-a"
+
+  boot_disk {
+    initialize_params {
+      image = "centos-cloud/centos-7"
+    }
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
+
+resource "google_compute_instance" "g2_bundled_gpu" {
+  name         = "g2_bundled_gpu"
+  machine_type = "g2-standard-24"
+  zone         = "us-central1-a"
+
+  boot_disk {
+    initialize_params {
+      image = "centos-cloud/centos-7"
+    }
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
+
+resource "google_compute_instance" "a3_bundled_gpu" {
+  name         = "a3_bundled_gpu"
+  machine_type = "a3-highgpu-8g"
+  zone         = "us-central1-a"
+
+  boot_disk {
+    initialize_params {
+      image = "centos-cloud/centos-7"
+    }
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
+
+resource "google_compute_instance" "spot_gpu" {
+  name         = "spot_gpu"
+  machine_type = "n1-standard-8"
+  zone         = "us-central1-a"
+
+  boot_disk {
+    initialize_params {
+      image = "centos-cloud/centos-7"
+    }
+  }
+
+  guest_accelerator {
+    type  = "nvidia-tesla-t4"
+    count = 2
+  }
+
+  scheduling {
+    provisioning_model = "SPOT"
+    preemptible        = true
+    automatic_restart  = false
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
+
+resource "google_compute_instance" "spot_bundled_gpu" {
+  name         = "spot_bundled_gpu"
+  machine_type = "g2-standard-8"
+  zone         = "us-central1-a"
+
+  boot_disk {
+    initialize_params {
+      image = "centos-cloud/centos-7"
+    }
+  }
+
+  scheduling {
+    provisioning_model = "SPOT"
+    preemptible        = true
+    automatic_restart  = false
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
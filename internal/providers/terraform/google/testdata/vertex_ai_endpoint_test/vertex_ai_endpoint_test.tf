@@ -0,0 +1,34 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_vertex_ai_endpoint" "no_usage" {
+  name         = "no-usage"
+  display_name = "no-usage"
+  location     = "us-central1"
+}
+
+resource "google_vertex_ai_endpoint" "cpu" {
+  name         = "cpu"
+  display_name = "cpu"
+  location     = "us-central1"
+}
+
+resource "google_vertex_ai_endpoint" "gpu" {
+  name         = "gpu"
+  display_name = "gpu"
+  location     = "us-central1"
+}
+
+resource "google_vertex_ai_endpoint" "bundled_gpu" {
+  name         = "bundled-gpu"
+  display_name = "bundled-gpu"
+  location     = "us-central1"
+}
+
+resource "google_vertex_ai_endpoint" "unsupported_machine_type" {
+  name         = "unsupported-machine-type"
+  display_name = "unsupported-machine-type"
+  location     = "us-central1"
+}
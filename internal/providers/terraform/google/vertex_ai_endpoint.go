@@ -0,0 +1,33 @@
+package google
+
+import (
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getVertexAIEndpointRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_vertex_ai_endpoint",
+		RFunc: newVertexAIEndpoint,
+		Notes: []string{
+			"Models are deployed to endpoints outside of Terraform, so costs depend on the machine type and node hours in the usage file.",
+		},
+	}
+}
+
+func newVertexAIEndpoint(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	location := d.Get("location").String()
+	if location != "" {
+		region = location
+	}
+
+	r := &google.VertexAIEndpoint{
+		Address: d.Address,
+		Region:  region,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,17 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/providers/terraform/tftest"
+)
+
+func TestVertexAIEndpointGoldenFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	tftest.GoldenFileResourceTestsWithOpts(t, "vertex_ai_endpoint_test", &tftest.GoldenFileOptions{
+		CaptureLogs: true,
+	})
+}
@@ -1,15 +1,18 @@
 package terraform
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"regexp"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 	ctyJson "github.com/zclconf/go-cty/cty/json"
 
+	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/hcl"
 	"github.com/infracost/infracost/internal/schema"
@@ -19,8 +22,18 @@ type HCLProvider struct {
 	Parser   *hcl.Parser
 	Provider *PlanJSONProvider
 
-	schema      *PlanSchema
-	providerKey string
+	ctx             context.Context
+	schema          *PlanSchema
+	providerKey     string
+	providerSchemas *hcl.ProviderSchemas
+
+	evalReport bool
+	unresolved []hcl.UnresolvedAttribute
+	skipped    []hcl.SkippedBlock
+	outputs    map[string]interface{}
+
+	prefetch      func([]*schema.Resource)
+	prefetchUsage map[string]*schema.UsageData
 }
 
 type flagStringSlice []string
@@ -106,6 +119,51 @@ func NewHCLProvider(ctx *config.ProjectContext, provider *PlanJSONProvider, opts
 		options = append(options, withInputVars)
 	}
 
+	// with --fail-on warning any invalid variable values fail the run before it's priced.
+	if ctx.RunContext.Config.FailOn == clierror.FailOnWarning {
+		options = append(options, hcl.OptionStrictVariableValidation())
+	}
+
+	if registryHost := moduleRegistryHost(ctx.ProjectConfig); registryHost != "" {
+		options = append(options, hcl.OptionWithModuleRegistryHost(registryHost))
+	}
+
+	if env := ctx.ProjectConfig.EnvWithCredentials(); len(env) > 0 {
+		options = append(options, hcl.OptionWithModuleEnv(env))
+	}
+
+	if len(ctx.DependencyVars) > 0 {
+		options = append(options, hcl.OptionWithVariableSources(hcl.NewDependencyOutputsSource(ctx.DependencyVars)))
+	}
+
+	if sources := variableSources(ctx.ProjectConfig); len(sources) > 0 {
+		options = append(options, hcl.OptionWithVariableSources(sources...))
+	}
+
+	if ctx.ProjectConfig.GitSSHKeyFile != "" || ctx.ProjectConfig.GitKnownHosts != "" {
+		options = append(options, hcl.OptionWithModuleGitSSH(ctx.ProjectConfig.GitSSHKeyFile, ctx.ProjectConfig.GitKnownHosts))
+	}
+
+	if ctx.RunContext.Config.ReadOnly {
+		options = append(options, hcl.OptionWithModuleCacheDir(ctx.RunContext.Config.ProjectCacheDir(ctx.ProjectConfig.Path)))
+	}
+
+	providerSchemas := hcl.BundledProviderSchemas()
+	if ctx.ProjectConfig.TerraformProviderSchemaFile != "" {
+		s, err := hcl.LoadProviderSchemasFile(ctx.ProjectConfig.TerraformProviderSchemaFile)
+		if err != nil {
+			return nil, err
+		}
+		providerSchemas = providerSchemas.Merge(s)
+	}
+	options = append(options, hcl.OptionWithProviderSchemas(providerSchemas))
+
+	if ctx.RunContext.Config.HardenedParse {
+		options = append(options, hcl.OptionHardened(hcl.DefaultHardenedLimits))
+	}
+
+	options = append(options, hcl.OptionWithDirectoryLimits(directoryLimits(ctx.RunContext.Config)))
+
 	options = append(options, opts...)
 
 	host, token, remErr := findRemoteHostAndToken(ctx)
@@ -114,12 +172,63 @@ func NewHCLProvider(ctx *config.ProjectContext, provider *PlanJSONProvider, opts
 		options = append(options, hcl.OptionWithRemoteVarLoader(host, token, localWorkspace))
 	}
 
-	p := hcl.New(ctx.ProjectConfig.Path, options...)
+	hp := &HCLProvider{
+		Provider:        provider,
+		ctx:             ctx.RunContext.Context(),
+		providerSchemas: providerSchemas,
+		evalReport:      ctx.RunContext.Config.EvalReportPath != "",
+	}
+
+	options = append(options, hcl.OptionWithModuleEvaluatedFunc(hp.moduleEvaluated))
+	hp.Parser = hcl.New(ctx.ProjectConfig.Path, options...)
 
-	return &HCLProvider{
-		Parser:   p,
-		Provider: provider,
-	}, err
+	return hp, err
+}
+
+// variableSources returns the sources of the remote variables set in the project config, using
+// the project env and provider credentials to authenticate.
+func variableSources(project *config.Project) []hcl.VariableSource {
+	rv := project.RemoteVariables
+	if rv == nil {
+		return nil
+	}
+
+	env := project.EnvWithCredentials()
+
+	var sources []hcl.VariableSource
+	if g := rv.AzureDevOpsVariableGroups; g != nil && len(g.Groups) > 0 {
+		sources = append(sources, hcl.NewAzureDevOpsVariableGroupSource(g.Organization, g.Project, g.Groups, env))
+	}
+
+	if s := rv.GCPSecretManager; s != nil && len(s.Secrets) > 0 {
+		sources = append(sources, hcl.NewGCPSecretManagerSource(s.Project, s.Secrets, env))
+	}
+
+	if c := rv.GCPRuntimeConfig; c != nil && len(c.Variables) > 0 {
+		sources = append(sources, hcl.NewGCPRuntimeConfigSource(c.Project, c.Config, c.Variables, env))
+	}
+
+	return sources
+}
+
+// directoryLimits returns the default limits on the directories that are parsed, with any that are
+// set in the config.
+func directoryLimits(cfg *config.Config) hcl.DirectoryLimits {
+	limits := hcl.DefaultDirectoryLimits
+
+	if cfg.MaxFiles != nil {
+		limits.MaxFiles = *cfg.MaxFiles
+	}
+
+	if cfg.MaxFileSize != nil {
+		limits.MaxFileSize = *cfg.MaxFileSize
+	}
+
+	if cfg.MaxModuleDepth != nil {
+		limits.MaxModuleDepth = *cfg.MaxModuleDepth
+	}
+
+	return limits
 }
 
 func (p *HCLProvider) Type() string                                 { return "terraform_hcl" }
@@ -130,6 +239,14 @@ func (p *HCLProvider) AddMetadata(metadata *schema.ProjectMetadata) {}
 // representation of the terraform plan JSON files from these Blocks, this is passed to the PlanJSONProvider.
 // The PlanJSONProvider uses this shallow representation to actually load Infracost resources.
 func (p *HCLProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	if p.prefetch != nil {
+		p.prefetchUsage = usage
+		if p.prefetchUsage == nil {
+			p.prefetchUsage = map[string]*schema.UsageData{}
+		}
+		defer func() { p.prefetchUsage = nil }()
+	}
+
 	b, err := p.LoadPlanJSON()
 	if err != nil {
 		return nil, err
@@ -138,16 +255,132 @@ func (p *HCLProvider) LoadResources(usage map[string]*schema.UsageData) ([]*sche
 	return p.Provider.LoadResourcesFromSrc(usage, b, nil)
 }
 
+// SetPrefetchFunc sets the func that is called with the resources of each child module as soon as the
+// module has been evaluated by LoadResources, so their prices can be fetched while the rest of the
+// directory is evaluated.
+func (p *HCLProvider) SetPrefetchFunc(f func(resources []*schema.Resource)) {
+	p.prefetch = f
+}
+
+// moduleEvaluated builds the resources of an evaluated child module and passes them to the prefetch
+// func. The module's resources are built from a plan JSON that only has the module and the provider
+// blocks of its parents, so that they get the same region as they do once the whole directory has
+// been evaluated. Resources with references that may be to resources outside of the module are
+// skipped, since they'd be built with different price queries once the whole directory has been
+// evaluated and their prices would be fetched twice. It's called while the directory is being
+// evaluated, so it doesn't change the schema of the HCLProvider.
+func (p *HCLProvider) moduleEvaluated(module *hcl.Module) {
+	if p.prefetchUsage == nil || p.Provider == nil {
+		return
+	}
+
+	partial := &HCLProvider{providerSchemas: p.providerSchemas}
+	b, err := partial.modulesToPlanJSON(prefetchModuleTree(module))
+	if err != nil {
+		log.Debugf("Could not build the resources of %s to prefetch their prices: %s", module.Name, err)
+		return
+	}
+
+	parser := NewParser(p.Provider.ctx, false)
+	parser.onlyResolvedReferences = true
+	_, resources, err := parser.parseJSON(b, p.prefetchUsage)
+	if err != nil {
+		log.Debugf("Could not build the resources of %s to prefetch their prices: %s", module.Name, err)
+		return
+	}
+
+	p.prefetch(resources)
+}
+
+// prefetchModuleTree returns a copy of the module's parents down to the module, where the parents only
+// have their provider blocks and the module doesn't have its child modules, since those have already
+// been passed to moduleEvaluated.
+func prefetchModuleTree(module *hcl.Module) *hcl.Module {
+	tree := &hcl.Module{
+		Name:       module.Name,
+		Source:     module.Source,
+		Blocks:     module.Blocks,
+		RootPath:   module.RootPath,
+		ModulePath: module.ModulePath,
+	}
+
+	for parent := module.Parent; parent != nil; parent = parent.Parent {
+		tree = &hcl.Module{
+			Name:       parent.Name,
+			Source:     parent.Source,
+			Blocks:     parent.Blocks.OfType("provider"),
+			RootPath:   parent.RootPath,
+			ModulePath: parent.ModulePath,
+			Modules:    []*hcl.Module{tree},
+		}
+	}
+
+	return tree
+}
+
 // LoadPlanJSON parses the provided directory and returns it as a Terraform Plan JSON.
 func (p *HCLProvider) LoadPlanJSON() ([]byte, error) {
-	rootModule, err := p.Parser.ParseDirectory()
+	ctx := p.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	rootModule, err := p.Parser.ParseDirectory(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if p.evalReport {
+		p.unresolved = rootModule.UnresolvedAttributes()
+		p.skipped = rootModule.SkippedBlocks()
+	}
+
+	p.outputs = outputValues(rootModule)
+
 	return p.modulesToPlanJSON(rootModule)
 }
 
+// UnresolvedAttributes returns the attributes that couldn't be evaluated when the directory was last parsed.
+// These are only collected when an eval report is written, otherwise this returns nil.
+func (p *HCLProvider) UnresolvedAttributes() []hcl.UnresolvedAttribute {
+	return p.unresolved
+}
+
+// SkippedBlocks returns the blocks that weren't evaluated when the directory was last parsed, e.g. check
+// blocks. Like UnresolvedAttributes, these are only collected when an eval report is written.
+func (p *HCLProvider) SkippedBlocks() []hcl.SkippedBlock {
+	return p.skipped
+}
+
+// Outputs returns the values of the root module outputs that could be evaluated when the directory was
+// last parsed, so they can be passed to the projects that depend on it.
+func (p *HCLProvider) Outputs() map[string]interface{} {
+	return p.outputs
+}
+
+// outputValues returns the evaluated outputs of the module as JSON compatible values.
+func outputValues(module *hcl.Module) map[string]interface{} {
+	values := map[string]interface{}{}
+
+	for name, val := range module.Outputs() {
+		b, err := ctyJson.Marshal(val, val.Type())
+		if err != nil {
+			log.Debugf("could not marshal output %s: %s", name, err)
+			continue
+		}
+
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err != nil {
+			log.Debugf("could not unmarshal output %s: %s", name, err)
+			continue
+		}
+
+		values[name] = v
+	}
+
+	return values
+}
+
 func (p *HCLProvider) newPlanSchema() {
 	p.schema = &PlanSchema{
 		FormatVersion:    "1.0",
@@ -262,8 +495,11 @@ func (p *HCLProvider) getResourceOutput(block *hcl.Block) ResourceOutput {
 		},
 	}
 
-	jsonValues := marshalAttributeValues(block.Type(), block.Values())
-	marshalBlock(block, jsonValues)
+	// values are converted to the types in the provider schema, and attributes that aren't set are given
+	// their defaults, so that they're read the same as they would be from a plan.
+	blockSchema := p.providerSchemas.ResourceSchema(block.TypeLabel())
+	jsonValues := marshalAttributeValues(block.Type(), blockSchema.Conform(block.Values()))
+	marshalBlock(block, blockSchema, jsonValues)
 
 	changes.Change.After = jsonValues
 	planned.Values = jsonValues
@@ -412,16 +648,21 @@ func blockToReferences(block *hcl.Block) map[string]interface{} {
 	return expressionValues
 }
 
-func marshalBlock(block *hcl.Block, jsonValues map[string]interface{}) {
+func marshalBlock(block *hcl.Block, blockSchema *hcl.SchemaBlock, jsonValues map[string]interface{}) {
 	for _, b := range block.Children() {
 		key := b.Type()
 		if key == "dynamic" || key == "depends_on" {
 			continue
 		}
 
-		childValues := marshalAttributeValues(key, b.Values())
+		var childSchema *hcl.SchemaBlock
+		if blockSchema != nil {
+			childSchema = blockSchema.BlockTypes[key]
+		}
+
+		childValues := marshalAttributeValues(key, childSchema.Conform(b.Values()))
 		if len(b.Children()) > 0 {
-			marshalBlock(b, childValues)
+			marshalBlock(b, childSchema, childValues)
 		}
 
 		if v, ok := jsonValues[key]; ok {
@@ -14,7 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/zclconf/go-cty/cty"
 
+	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/schema"
 )
 
 func setMockAttributes(blockAtts map[string]map[string]string) hcl.SetAttributesFunc {
@@ -181,3 +183,43 @@ func TestHCLProvider_LoadPlanJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestHCLProvider_SetPrefetchFunc(t *testing.T) {
+	testPath := "testdata/hcl_provider_test/prefetches_module_resources"
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{Path: testPath})
+
+	p, err := NewHCLProvider(ctx, NewPlanJSONProvider(ctx, false))
+	require.NoError(t, err)
+
+	var prefetched []*schema.Resource
+	p.SetPrefetchFunc(func(resources []*schema.Resource) {
+		prefetched = append(prefetched, resources...)
+	})
+
+	projects, err := p.LoadResources(map[string]*schema.UsageData{})
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+
+	// only the resources of the child module are prefetched, the root module's resources are only built
+	// once the whole directory has been evaluated. The module's autoscaling group and EIP aren't
+	// prefetched since they're referenced by or reference resources outside of the module.
+	require.Len(t, prefetched, 1)
+	assert.Equal(t, "module.web.aws_instance.web", prefetched[0].Name)
+
+	var loaded *schema.Resource
+	for _, r := range projects[0].Resources {
+		if r.Name == "module.web.aws_instance.web" {
+			loaded = r
+		}
+	}
+	require.NotNil(t, loaded)
+
+	// the prefetched resource has the same price queries as the loaded one, including the region of
+	// the root module's provider.
+	require.Equal(t, len(loaded.CostComponents), len(prefetched[0].CostComponents))
+	for i, c := range loaded.CostComponents {
+		assert.Equal(t, c.ProductFilter, prefetched[0].CostComponents[i].ProductFilter)
+		assert.Equal(t, c.PriceFilter, prefetched[0].CostComponents[i].PriceFilter)
+	}
+	assert.Equal(t, "eu-west-2", *loaded.CostComponents[0].ProductFilter.Region)
+}
@@ -0,0 +1,152 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The categories that unsupported resources are guessed to be in when heuristic pricing is enabled.
+const (
+	HeuristicCompute = "compute"
+	HeuristicStorage = "storage"
+	HeuristicNetwork = "network"
+)
+
+// The rough list prices, in USD, of each heuristic category. They're in the range of the prices of a
+// small VM, standard block storage and a managed gateway or load balancer, across the supported
+// clouds, so they give a signal of the cost instead of $0 but shouldn't be relied on.
+var (
+	heuristicComputeHourlyPrice = decimal.RequireFromString("0.05")
+	heuristicStorageGBPrice     = decimal.RequireFromString("0.10")
+	heuristicNetworkHourlyPrice = decimal.RequireFromString("0.025")
+)
+
+// defaultHeuristicStorageGB is the size that storage resources are assumed to be when it's not one of
+// their attributes, e.g. buckets.
+const defaultHeuristicStorageGB = 100
+
+// The attributes that are used to guess the category of a resource, in the order they're checked.
+// Attributes are checked before the resource type since they're a more reliable signal, e.g. a
+// cluster with a disk_size_gb attribute is still a compute resource since it has a node_count.
+var (
+	heuristicComputeAttrs = []string{"instance_type", "machine_type", "vm_size", "node_type", "instance_class", "vcpus", "cpu", "memory", "memory_size", "node_count", "instance_count", "runtime", "image", "image_id"}
+	heuristicStorageAttrs = []string{"size_gb", "disk_size_gb", "size_in_gb", "storage_gb", "storage_size_gb", "allocated_storage", "capacity_gb", "storage_quota_in_gb", "storage_class", "storage_tier", "access_tier"}
+	heuristicNetworkAttrs = []string{"bandwidth", "bandwidth_mbps", "throughput", "vpn_type", "load_balancer_type", "frontend_ip_configuration", "listener"}
+
+	// heuristicCountAttrs are the attributes that set the number of instances of a compute resource.
+	heuristicCountAttrs = []string{"node_count", "instance_count", "desired_capacity", "desired_size", "replicas", "min_node_count"}
+	// heuristicSizeAttrs are the attributes that set the size in GB of a storage resource.
+	heuristicSizeAttrs = []string{"size_gb", "disk_size_gb", "size_in_gb", "storage_gb", "storage_size_gb", "allocated_storage", "capacity_gb", "storage_quota_in_gb", "size"}
+)
+
+// The words in resource types that are used to guess the category of a resource if none of its
+// attributes do. They're matched against whole words of the type, e.g. lb matches aws_lb_listener
+// but not aws_bulb.
+var (
+	heuristicComputeWords = []string{"instance", "vm", "virtual_machine", "cluster", "node", "node_pool", "function", "container", "server", "compute", "job", "app", "worker"}
+	heuristicStorageWords = []string{"bucket", "disk", "volume", "storage", "snapshot", "backup", "filesystem", "file_system", "share", "blob", "archive", "vault", "table", "database", "cache"}
+	heuristicNetworkWords = []string{"gateway", "load_balancer", "lb", "vpn", "endpoint", "firewall", "nat", "cdn", "router", "interconnect", "peering", "link", "ip", "address", "tunnel"}
+)
+
+// heuristicCategory guesses the category of a resource from its attributes and type, or returns an
+// empty string if it doesn't look like any of them, e.g. IAM policies.
+func heuristicCategory(d *schema.ResourceData) string {
+	for _, attrs := range []struct {
+		category string
+		keys     []string
+	}{
+		{HeuristicCompute, heuristicComputeAttrs},
+		{HeuristicStorage, heuristicStorageAttrs},
+		{HeuristicNetwork, heuristicNetworkAttrs},
+	} {
+		for _, k := range attrs.keys {
+			if d.Get(k).Exists() {
+				return attrs.category
+			}
+		}
+	}
+
+	// Strip the provider prefix so it isn't matched, e.g. the google in google_compute_instance.
+	rType := d.Type
+	if i := strings.Index(rType, "_"); i != -1 {
+		rType = rType[i+1:]
+	}
+	rType = "_" + rType + "_"
+
+	for _, words := range []struct {
+		category string
+		words    []string
+	}{
+		{HeuristicNetwork, heuristicNetworkWords},
+		{HeuristicStorage, heuristicStorageWords},
+		{HeuristicCompute, heuristicComputeWords},
+	} {
+		for _, w := range words.words {
+			if strings.Contains(rType, "_"+w+"_") {
+				return words.category
+			}
+		}
+	}
+
+	return ""
+}
+
+// newHeuristicResource returns a resource with a rough estimate of the cost of an unsupported resource
+// from its guessed category, or nil if the category can't be guessed. The estimate is flagged by the
+// resource's HeuristicCategory and the name of its cost component so it isn't mistaken for a price.
+func newHeuristicResource(d *schema.ResourceData) *schema.Resource {
+	category := heuristicCategory(d)
+	if category == "" {
+		return nil
+	}
+
+	c := &schema.CostComponent{
+		Name:           fmt.Sprintf("Rough estimate (%s, unsupported resource)", category),
+		UnitMultiplier: decimal.NewFromInt(1),
+	}
+
+	switch category {
+	case HeuristicCompute:
+		c.Unit = "hours"
+		c.HourlyQuantity = decimalPtr(decimal.NewFromInt(heuristicInt(d, heuristicCountAttrs, 1)))
+		c.SetCustomPrice(&heuristicComputeHourlyPrice)
+	case HeuristicStorage:
+		c.Unit = "GB"
+		c.MonthlyQuantity = decimalPtr(decimal.NewFromInt(heuristicInt(d, heuristicSizeAttrs, defaultHeuristicStorageGB)))
+		c.SetCustomPrice(&heuristicStorageGBPrice)
+	case HeuristicNetwork:
+		c.Unit = "hours"
+		c.HourlyQuantity = decimalPtr(decimal.NewFromInt(1))
+		c.SetCustomPrice(&heuristicNetworkHourlyPrice)
+	}
+
+	return &schema.Resource{
+		Name:              d.Address,
+		ResourceType:      d.Type,
+		Tags:              d.Tags,
+		ProviderConfigKey: d.ProviderConfigKey,
+		CostComponents:    []*schema.CostComponent{c},
+		HeuristicCategory: category,
+	}
+}
+
+// heuristicInt returns the value of the first of the attributes that's a positive number, or the
+// default if none of them are.
+func heuristicInt(d *schema.ResourceData, keys []string, def int64) int64 {
+	for _, k := range keys {
+		if v := d.Get(k); v.Type == gjson.Number && v.Int() > 0 {
+			return v.Int()
+		}
+	}
+
+	return def
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -0,0 +1,60 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestHeuristicCategory(t *testing.T) {
+	tests := []struct {
+		rType    string
+		values   string
+		expected string
+	}{
+		{"aws_appstream_fleet", `{"instance_type": "stream.standard.medium"}`, HeuristicCompute},
+		{"google_workstations_workstation_cluster", `{}`, HeuristicCompute},
+		{"azurerm_netapp_volume", `{"storage_quota_in_gb": 500}`, HeuristicStorage},
+		{"aws_fsx_openzfs_snapshot", `{}`, HeuristicStorage},
+		{"google_network_services_gateway", `{"ports": [443]}`, HeuristicNetwork},
+		{"azurerm_lb_nat_pool", `{}`, HeuristicNetwork},
+		// Attributes are checked before the type, e.g. this cluster has nodes.
+		{"aws_memorydb_cluster", `{"node_type": "db.t4g.small"}`, HeuristicCompute},
+		// The provider prefix isn't matched, and words must be whole.
+		{"aws_globalaccelerator_accelerator", `{}`, ""},
+		{"aws_iam_policy", `{"policy": "{}"}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rType, func(t *testing.T) {
+			d := schema.NewResourceData(tt.rType, "", tt.rType+".test", nil, gjson.Parse(tt.values))
+			assert.Equal(t, tt.expected, heuristicCategory(d))
+		})
+	}
+}
+
+func TestNewHeuristicResource(t *testing.T) {
+	d := schema.NewResourceData("aws_memorydb_cluster", "aws", "aws_memorydb_cluster.sessions", nil, gjson.Parse(`{"node_type": "db.t4g.small", "node_count": 3}`))
+	r := newHeuristicResource(d)
+	require.NotNil(t, r)
+
+	assert.Equal(t, HeuristicCompute, r.HeuristicCategory)
+	assert.False(t, r.IsSkipped)
+	require.Len(t, r.CostComponents, 1)
+	assert.Equal(t, "Rough estimate (compute, unsupported resource)", r.CostComponents[0].Name)
+	assert.Equal(t, "3", r.CostComponents[0].HourlyQuantity.String())
+	assert.Equal(t, "0.05", r.CostComponents[0].CustomPrice().String())
+
+	d = schema.NewResourceData("azurerm_netapp_volume", "azurerm", "azurerm_netapp_volume.shared", nil, gjson.Parse(`{}`))
+	r = newHeuristicResource(d)
+	require.NotNil(t, r)
+	assert.Equal(t, HeuristicStorage, r.HeuristicCategory)
+	assert.Equal(t, "100", r.CostComponents[0].MonthlyQuantity.String())
+
+	d = schema.NewResourceData("aws_iam_policy", "aws", "aws_iam_policy.admin", nil, gjson.Parse(`{}`))
+	assert.Nil(t, newHeuristicResource(d))
+}
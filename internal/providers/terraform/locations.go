@@ -0,0 +1,174 @@
+package terraform
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/address"
+)
+
+var (
+	resourceBlockReg = regexp.MustCompile(`^\s*resource\s+"([^"]+)"\s+"([^"]+)"`)
+	moduleBlockReg   = regexp.MustCompile(`^\s*module\s+"([^"]+)"`)
+	localSourceReg   = regexp.MustCompile(`^\s*source\s*=\s*"(\.\.?/[^"]*)"`)
+)
+
+// BlockLocation is the file and line that a resource or module block starts on.
+type BlockLocation struct {
+	Filename string
+	Line     int
+}
+
+// LoadBlockLocations reads all the Terraform files in the given directory and returns the
+// location of each resource and module block keyed by its address, e.g. aws_instance.web or
+// module.vpc. Only the files in the given directory are read, so resources
+// in child modules should be located by the module block that calls them, see
+// BlockAddress.
+func LoadBlockLocations(dir string) map[string]BlockLocation {
+	locations := map[string]BlockLocation{}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		log.Debugf("Could not list Terraform files in %s for block locations: %s", dir, err)
+		return locations
+	}
+
+	for _, filename := range matches {
+		err := loadFileBlockLocations(filename, locations)
+		if err != nil {
+			log.Debugf("Could not read block locations from %s: %s", filename, err)
+		}
+	}
+
+	return locations
+}
+
+func loadFileBlockLocations(filename string, locations map[string]BlockLocation) error {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	for addr, loc := range SourceBlockLocations(filename, src) {
+		locations[addr] = loc
+	}
+
+	return nil
+}
+
+// SourceBlockLocations returns the location of each resource and module block in the source of
+// a Terraform file keyed by its address, like LoadBlockLocations. This is used for files that
+// are being edited, since their source might not be saved.
+func SourceBlockLocations(filename string, src []byte) map[string]BlockLocation {
+	locations := map[string]BlockLocation{}
+
+	line := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+
+		if m := resourceBlockReg.FindStringSubmatch(text); m != nil {
+			locations[m[1]+"."+m[2]] = BlockLocation{Filename: filename, Line: line}
+		} else if m := moduleBlockReg.FindStringSubmatch(text); m != nil {
+			locations["module."+m[1]] = BlockLocation{Filename: filename, Line: line}
+		}
+	}
+
+	return locations
+}
+
+// BlockAddress returns the address of the block in the root module that defines the resource
+// with the given address. For resources in the root module this is the address without any
+// count or for_each index, and for resources in child modules it is the module block, e.g.
+// module.vpc.aws_nat_gateway.this[0] is defined by module.vpc.
+func BlockAddress(resourceAddress string) string {
+	if a, err := address.Parse(resourceAddress); err == nil {
+		if len(a.Module) > 0 {
+			return "module." + a.Module[0].Name
+		}
+
+		a.Key = nil
+		return a.String()
+	}
+
+	if strings.HasPrefix(resourceAddress, "module.") {
+		name := strings.SplitN(strings.TrimPrefix(resourceAddress, "module."), ".", 2)[0]
+		return "module." + address.TrimKey(name)
+	}
+
+	return address.TrimKey(resourceAddress)
+}
+
+// LocalModuleDirs returns the absolute paths of the local modules that the Terraform or
+// Terragrunt files in the given directory call, i.e. those with a source starting with ./ or
+// ../, including the local modules that those modules call.
+func LocalModuleDirs(dir string) []string {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		log.Debugf("Could not get absolute path for %s: %s", dir, err)
+		return nil
+	}
+
+	seen := map[string]bool{absDir: true}
+	var dirs []string
+
+	queue := []string{absDir}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+
+		for _, source := range loadLocalModuleSources(d) {
+			// Terragrunt uses // to separate the module from its subdirectory, e.g.
+			// ../modules//vpc.
+			moduleDir := filepath.Join(d, filepath.FromSlash(strings.ReplaceAll(source, "//", "/")))
+			if seen[moduleDir] {
+				continue
+			}
+			seen[moduleDir] = true
+
+			dirs = append(dirs, moduleDir)
+			queue = append(queue, moduleDir)
+		}
+	}
+
+	return dirs
+}
+
+func loadLocalModuleSources(dir string) []string {
+	var sources []string
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		log.Debugf("Could not list Terraform files in %s for module sources: %s", dir, err)
+		return sources
+	}
+	matches = append(matches, filepath.Join(dir, "terragrunt.hcl"))
+
+	for _, filename := range matches {
+		f, err := os.Open(filename)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Debugf("Could not read module sources from %s: %s", filename, err)
+			}
+			continue
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if m := localSourceReg.FindStringSubmatch(scanner.Text()); m != nil {
+				sources = append(sources, m[1])
+			}
+		}
+		f.Close()
+	}
+
+	return sources
+}
@@ -0,0 +1,64 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockAddress(t *testing.T) {
+	assert.Equal(t, "aws_instance.web", BlockAddress("aws_instance.web"))
+	assert.Equal(t, "aws_instance.web", BlockAddress(`aws_instance.web["a"]`))
+	assert.Equal(t, "module.vpc", BlockAddress("module.vpc.aws_nat_gateway.this[0]"))
+	assert.Equal(t, "module.vpc", BlockAddress("module.vpc[1].module.subnets.aws_subnet.this"))
+	assert.Equal(t, "module.vpc", BlockAddress(`module.vpc["a.b"].aws_nat_gateway.this[0]`))
+	assert.Equal(t, "aws_instance.web", BlockAddress(`aws_instance.web["a]\"b"]`))
+}
+
+func TestLocalModuleDirs(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name string, content string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	write("live/main.tf", `
+module "app" {
+  source = "../modules/app"
+}
+
+module "remote" {
+  source = "terraform-aws-modules/vpc/aws"
+}
+`)
+	write("modules/app/main.tf", `
+module "db" {
+  source = "./db"
+}
+`)
+	write("modules/app/db/main.tf", `
+module "app" {
+  source = "../"
+}
+`)
+	write("terragrunt/terragrunt.hcl", `
+terraform {
+  source = "../modules//app"
+}
+`)
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "modules/app"),
+		filepath.Join(dir, "modules/app/db"),
+	}, LocalModuleDirs(filepath.Join(dir, "live")))
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "modules/app"),
+		filepath.Join(dir, "modules/app/db"),
+	}, LocalModuleDirs(filepath.Join(dir, "terragrunt")))
+}
@@ -0,0 +1,99 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/address"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// AttributeOverride is a what-if change to an attribute of the resources that match an address
+// pattern, made after the resources are evaluated and before they're priced.
+type AttributeOverride struct {
+	pattern   *regexp.Regexp
+	inModule  bool
+	Attribute string
+	Value     interface{}
+}
+
+// ParseAttributeOverride parses an override in the format <address pattern>.<attribute>=<value>, e.g.
+// aws_instance.*.instance_type=t3.large. In the pattern * matches any characters. Patterns that
+// don't start with module. match the resources in any module as well as the root module. The
+// value is parsed as JSON if it's valid, e.g. 100, true or ["a"], otherwise it's a string.
+func ParseAttributeOverride(s string) (AttributeOverride, error) {
+	invalid := fmt.Errorf("invalid override %q, it should be <address pattern>.<attribute>=<value>, e.g. aws_instance.*.instance_type=t3.large", s)
+
+	target, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return AttributeOverride{}, invalid
+	}
+
+	i := strings.LastIndex(target, ".")
+	if i <= 0 || i == len(target)-1 {
+		return AttributeOverride{}, invalid
+	}
+	pattern, attr := target[:i], target[i+1:]
+
+	parts := strings.Split(pattern, "*")
+	for j, part := range parts {
+		parts[j] = regexp.QuoteMeta(part)
+	}
+
+	re, err := regexp.Compile("^" + strings.Join(parts, ".*") + "$")
+	if err != nil {
+		return AttributeOverride{}, invalid
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		v = value
+	}
+
+	return AttributeOverride{
+		pattern:   re,
+		inModule:  strings.HasPrefix(pattern, "module."),
+		Attribute: attr,
+		Value:     v,
+	}, nil
+}
+
+// Matches returns true if the resource address matches the pattern of the override.
+func (o AttributeOverride) Matches(addr string) bool {
+	if o.pattern.MatchString(addr) {
+		return true
+	}
+
+	if o.inModule {
+		return false
+	}
+
+	m, rest, err := address.SplitModule(addr)
+	return err == nil && len(m) > 0 && o.pattern.MatchString(rest)
+}
+
+// applyAttributeOverrides sets the attributes of the resources that match the overrides, the later
+// overrides winning if more than one sets the same attribute.
+func applyAttributeOverrides(resData map[string]*schema.ResourceData, overrides []string) {
+	for _, s := range overrides {
+		o, err := ParseAttributeOverride(s)
+		if err != nil {
+			log.Warnf("Ignoring %s", err)
+			continue
+		}
+
+		matched := 0
+		for addr, d := range resData {
+			if o.Matches(addr) {
+				d.Set(o.Attribute, o.Value)
+				matched++
+			}
+		}
+
+		log.Debugf("Override %s set %s on %d resources", s, o.Attribute, matched)
+	}
+}
@@ -0,0 +1,77 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestParseAttributeOverride(t *testing.T) {
+	o, err := ParseAttributeOverride("aws_instance.*.instance_type=t3.large")
+	require.NoError(t, err)
+	assert.Equal(t, "instance_type", o.Attribute)
+	assert.Equal(t, "t3.large", o.Value)
+
+	o, err = ParseAttributeOverride("aws_db_instance.db.allocated_storage=100")
+	require.NoError(t, err)
+	assert.Equal(t, "allocated_storage", o.Attribute)
+	assert.Equal(t, float64(100), o.Value)
+
+	o, err = ParseAttributeOverride("aws_db_instance.*.multi_az=true")
+	require.NoError(t, err)
+	assert.Equal(t, true, o.Value)
+
+	for _, s := range []string{"aws_instance.web", "instance_type=t3.large", "aws_instance.web.=t3.large", ".instance_type=t3.large"} {
+		_, err := ParseAttributeOverride(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestAttributeOverrideMatches(t *testing.T) {
+	tests := []struct {
+		override string
+		addr     string
+		want     bool
+	}{
+		{"aws_instance.*.instance_type=t3.large", "aws_instance.web", true},
+		{"aws_instance.*.instance_type=t3.large", "aws_instance.web[0]", true},
+		{"aws_instance.*.instance_type=t3.large", "module.app.aws_instance.web", true},
+		{"aws_instance.*.instance_type=t3.large", "module.app[\"a.b\"].module.db.aws_instance.web", true},
+		{"aws_instance.*.instance_type=t3.large", `module.app["a\"].b"].aws_instance.web`, true},
+		{"aws_instance.*.instance_type=t3.large", "aws_db_instance.db", false},
+		{"aws_instance.web.instance_type=t3.large", "aws_instance.web2", false},
+		{"module.app.aws_instance.*.instance_type=t3.large", "module.app.aws_instance.web", true},
+		{"module.app.aws_instance.*.instance_type=t3.large", "module.other.aws_instance.web", false},
+		{"module.app.aws_instance.*.instance_type=t3.large", "aws_instance.web", false},
+	}
+
+	for _, tt := range tests {
+		o, err := ParseAttributeOverride(tt.override)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, o.Matches(tt.addr), "%s %s", tt.override, tt.addr)
+	}
+}
+
+func TestApplyAttributeOverrides(t *testing.T) {
+	resData := map[string]*schema.ResourceData{
+		"aws_instance.web":   schema.NewResourceData("aws_instance", "aws", "aws_instance.web", nil, gjson.Parse(`{"instance_type": "m5.large", "ami": "ami-1"}`)),
+		"aws_instance.api":   schema.NewResourceData("aws_instance", "aws", "aws_instance.api", nil, gjson.Parse(`{"instance_type": "m5.large"}`)),
+		"aws_db_instance.db": schema.NewResourceData("aws_db_instance", "aws", "aws_db_instance.db", nil, gjson.Parse(`{"instance_class": "db.m5.large"}`)),
+	}
+
+	applyAttributeOverrides(resData, []string{
+		"aws_instance.*.instance_type=m6g.large",
+		"aws_instance.api.instance_type=m6g.xlarge",
+		"aws_db_instance.db.allocated_storage=100",
+	})
+
+	assert.Equal(t, "m6g.large", resData["aws_instance.web"].Get("instance_type").String())
+	assert.Equal(t, "ami-1", resData["aws_instance.web"].Get("ami").String())
+	assert.Equal(t, "m6g.xlarge", resData["aws_instance.api"].Get("instance_type").String())
+	assert.Equal(t, int64(100), resData["aws_db_instance.db"].Get("allocated_storage").Int())
+	assert.Equal(t, "db.m5.large", resData["aws_db_instance.db"].Get("instance_class").String())
+}
@@ -0,0 +1,81 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/codeowners"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// AddOwners sets the owner of each resource from the CODEOWNERS file of the git repo that path is
+// in. The owners are matched by the file that the resource or the module block that calls it is
+// defined in, or by the project path if the block can't be found, e.g. for plan JSON files. If the
+// path isn't in a git repo with a CODEOWNERS file then this is a no-op.
+func AddOwners(path string, resources []*schema.Resource) {
+	root := config.DetectVCSRoot(path)
+	if root == "" {
+		return
+	}
+
+	codeownersPath := codeowners.Find(root)
+	if codeownersPath == "" {
+		log.Debugf("No CODEOWNERS file found in %s", root)
+		return
+	}
+
+	file, err := codeowners.Load(codeownersPath)
+	if err != nil {
+		log.Debugf("Could not load CODEOWNERS file %s: %s", codeownersPath, err)
+		return
+	}
+
+	projectPath, ok := repoRelPath(root, path)
+	if !ok {
+		return
+	}
+
+	var locations map[string]BlockLocation
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		locations = LoadBlockLocations(path)
+		// Directory patterns only match the paths in the directory.
+		projectPath += "/"
+	}
+
+	for _, r := range resources {
+		p := projectPath
+		if loc, ok := locations[BlockAddress(r.Name)]; ok {
+			if filePath, ok := repoRelPath(root, loc.Filename); ok {
+				p = filePath
+			}
+		}
+
+		r.Owner = strings.Join(file.Owners(p), " ")
+	}
+}
+
+// repoRelPath returns the path relative to the repo root with forward slashes.
+func repoRelPath(root string, path string) (string, bool) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		log.Debugf("Could not get absolute path for %s: %s", path, err)
+		return "", false
+	}
+
+	// git returns the root with any symlinks resolved, e.g. /private/var on macOS.
+	if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+		absPath = resolved
+	}
+
+	rel, err := filepath.Rel(root, absPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		log.Debugf("Could not get path of %s relative to %s", absPath, root)
+		return "", false
+	}
+
+	return filepath.ToSlash(rel), true
+}
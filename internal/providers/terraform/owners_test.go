@@ -0,0 +1,62 @@
+package terraform
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestAddOwners(t *testing.T) {
+	root := t.TempDir()
+
+	out, err := exec.Command("git", "init", "-q", root).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	dir := filepath.Join(root, "infra", "prod")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0700))
+	require.NoError(t, os.MkdirAll(dir, 0700))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte(`
+*                 @org/platform
+/infra/prod/      @org/sre
+/infra/prod/db.tf @org/dba @org/sre
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`
+resource "aws_instance" "web" {}
+
+module "vpc" {
+  source = "./modules/vpc"
+}
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db.tf"), []byte(`
+resource "aws_db_instance" "main" {}
+`), 0600))
+
+	resources := []*schema.Resource{
+		{Name: "aws_instance.web[0]"},
+		{Name: "module.vpc.aws_nat_gateway.this[0]"},
+		{Name: "aws_db_instance.main"},
+		{Name: "aws_s3_bucket.unknown"},
+	}
+
+	AddOwners(dir, resources)
+
+	assert.Equal(t, "@org/sre", resources[0].Owner)
+	assert.Equal(t, "@org/sre", resources[1].Owner)
+	assert.Equal(t, "@org/dba @org/sre", resources[2].Owner)
+	// resources without a block are owned by the owners of the project directory.
+	assert.Equal(t, "@org/sre", resources[3].Owner)
+
+	planJSON := filepath.Join(root, "plan.json")
+	require.NoError(t, os.WriteFile(planJSON, []byte("{}"), 0600))
+
+	resources = []*schema.Resource{{Name: "aws_instance.web"}}
+	AddOwners(planJSON, resources)
+	assert.Equal(t, "@org/platform", resources[0].Owner)
+}
@@ -3,6 +3,7 @@ package terraform
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 
+	"github.com/infracost/infracost/internal/address"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
 )
@@ -41,6 +43,10 @@ type Parser struct {
 	ctx                  *config.ProjectContext
 	terraformVersion     string
 	includePastResources bool
+
+	// onlyResolvedReferences skips the resources that have references which may be to resources
+	// outside of the plan JSON, since they'd be built differently once those resources are added.
+	onlyResolvedReferences bool
 }
 
 func NewParser(ctx *config.ProjectContext, includePastResources bool) *Parser {
@@ -50,6 +56,54 @@ func NewParser(ctx *config.ProjectContext, includePastResources bool) *Parser {
 	}
 }
 
+// referenceAddresses returns the sorted addresses of the resources that d references with the
+// given attributes.
+func referenceAddresses(d *schema.ResourceData, attrs []string) []string {
+	seen := map[string]bool{}
+	var addrs []string
+
+	for _, ref := range d.References(attrs...) {
+		if ref.Address == d.Address || seen[ref.Address] {
+			continue
+		}
+		seen[ref.Address] = true
+		addrs = append(addrs, ref.Address)
+	}
+
+	sort.Strings(addrs)
+
+	return addrs
+}
+
+// referencesResolved returns true if every value of the resource's reference attributes was resolved
+// to a referenced resource, and other resources can't add references to it. Reverse reference
+// attributes are prefixed with the type of the resource that references it, e.g. aws_eip has
+// aws_nat_gateway.allocation_id.
+func referencesResolved(d *schema.ResourceData) bool {
+	registryMap := GetResourceRegistryMap()
+
+	for _, attr := range registryMap.GetReferenceAttributes(d.Type) {
+		if i := strings.Index(attr, "."); i > 0 {
+			if _, ok := (*registryMap)[attr[:i]]; ok {
+				return false
+			}
+		}
+
+		values := 0
+		for _, v := range d.Get(attr).Array() {
+			if v.String() != "" {
+				values++
+			}
+		}
+
+		if values > len(d.References(attr)) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	registryMap := GetResourceRegistryMap()
 
@@ -66,13 +120,40 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 				IsSkipped:    true,
 				NoPrice:      true,
 				SkipMessage:  "Free resource.",
+				References:   referenceAddresses(d, registryItem.ReferenceAttributes),
+			}
+		}
+
+		var tracer *schema.Tracer
+		if p.ctx.RunContext.Config.TraceResource != "" && p.ctx.RunContext.Config.TraceResource == d.Address {
+			tracer = schema.NewTracer()
+			d.Tracer = tracer
+			if u != nil {
+				u.Tracer = tracer
 			}
 		}
 
 		res := registryItem.RFunc(d, u)
+
+		d.Tracer = nil
+		if u != nil {
+			u.Tracer = nil
+		}
+
 		if res != nil {
+			res.Trace = tracer
 			res.ResourceType = d.Type
 			res.Tags = d.Tags
+			res.ProviderConfigKey = d.ProviderConfigKey
+			res.References = referenceAddresses(d, registryItem.ReferenceAttributes)
+			if !p.ctx.RunContext.Config.ShowAdvisories {
+				res.Alternatives = nil
+			}
+			if registryItem.RollupReference != "" {
+				if refs := d.References(registryItem.RollupReference); len(refs) == 1 {
+					res.RollupParent = refs[0].Address
+				}
+			}
 			if u != nil {
 				res.EstimationSummary = u.CalcEstimationSummary()
 			}
@@ -80,6 +161,12 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 		}
 	}
 
+	if p.ctx.RunContext.Config.HeuristicPricing && HasSupportedProvider(d.Type) {
+		if res := newHeuristicResource(d); res != nil {
+			return res
+		}
+	}
+
 	return &schema.Resource{
 		Name:         d.Address,
 		ResourceType: d.Type,
@@ -111,9 +198,20 @@ func (p *Parser) parseJSONResources(parsePrior bool, baseResources []*schema.Res
 	p.parseReferences(resData, conf)
 	p.loadInfracostProviderUsageData(usage, resData)
 	p.stripDataResources(resData)
+
+	// the overrides are what-if changes, so the prior state is left as it is and a diff shows their
+	// cost.
+	if !parsePrior {
+		applyAttributeOverrides(resData, p.ctx.RunContext.Config.AttributeOverrides)
+	}
+
 	p.populateUsageData(resData, usage)
 
 	for _, d := range resData {
+		if p.onlyResolvedReferences && !referencesResolved(d) {
+			continue
+		}
+
 		if r := p.createResource(d, d.UsageData); r != nil {
 			resources = append(resources, r)
 		}
@@ -139,7 +237,10 @@ func (p *Parser) populateUsageData(resData map[string]*schema.ResourceData, usag
 }
 
 func (p *Parser) parseJSON(j []byte, usage map[string]*schema.UsageData) ([]*schema.Resource, []*schema.Resource, error) {
-	baseResources := p.loadUsageFileResources(usage)
+	var baseResources []*schema.Resource
+	if !p.onlyResolvedReferences {
+		baseResources = p.loadUsageFileResources(usage)
+	}
 
 	j, _ = StripSetupTerraformWrapper(j)
 
@@ -154,6 +255,10 @@ func (p *Parser) parseJSON(j []byte, usage map[string]*schema.UsageData) ([]*sch
 	conf := parsed.Get("configuration.root_module")
 	vars := parsed.Get("variables")
 
+	if usage != nil {
+		p.loadPriorStateUsageData(usage, parsed)
+	}
+
 	resources := p.parseJSONResources(false, baseResources, usage, parsed, providerConf, conf, vars)
 	if !p.includePastResources {
 		return nil, resources, nil
@@ -268,7 +373,9 @@ func (p *Parser) parseResourceData(isState bool, providerConf, planVals gjson.Re
 
 		tags := parseTags(t, v)
 
-		resources[addr] = schema.NewResourceData(t, provider, addr, tags, v)
+		d := schema.NewResourceData(t, provider, addr, tags, v)
+		d.ProviderConfigKey = parseProviderKey(resConf)
+		resources[addr] = d
 	}
 
 	// Recursively add any resources for child modules
@@ -644,16 +751,14 @@ func addressModulePart(addr string) string {
 }
 
 func getModuleNames(addr string) []string {
-	r := regexp.MustCompile(`module\.([^\.\[]*)`)
-	matches := r.FindAllStringSubmatch(addressModulePart(addr), -1)
-
-	if matches == nil {
+	m, _, err := address.SplitModule(addressModulePart(addr))
+	if err != nil {
 		return []string{}
 	}
 
-	n := make([]string, 0, len(matches))
-	for _, m := range matches {
-		n = append(n, m[1])
+	n := make([]string, 0, len(m))
+	for _, call := range m {
+		n = append(n, call.Name)
 	}
 
 	return n
@@ -679,11 +784,18 @@ func removeAddressArrayPart(addr string) string {
 	return m[1]
 }
 
-// splitAddress splits the address by `.`, but ignores any `.`s quoted in the array part of the address
+// splitAddress splits the address by `.`, but ignores any `.`s quoted in the array part of the address.
+// Escaped quotes in the array part, e.g. ["a\".b"], don't end the quoted string.
 func splitAddress(addr string) []string {
 	quoted := false
+	escaped := false
 	return strings.FieldsFunc(addr, func(r rune) bool {
-		if r == '"' {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
 			quoted = !quoted
 		}
 		return !quoted && r == '.'
@@ -835,6 +835,8 @@ func TestAddressResourcePart(t *testing.T) {
 		{"module.my_module.module.my_submodule[\"index.1\"].data.aws_instance.my_instance", "data.aws_instance.my_instance"},
 		{"module.my_module.module.my_submodule[\"index.1\"].aws_instance.my_instance[\"index.1\"]", "aws_instance.my_instance[\"index.1\"]"},
 		{"module.my_module.module.my_submodule[\"index.1\"].data.aws_instance.my_instance[\"index.1\"]", "data.aws_instance.my_instance[\"index.1\"]"},
+		// Escaped quotes in the index
+		{`module.my_module["a\".b"].aws_instance.my_instance["c\".d"]`, `aws_instance.my_instance["c\".d"]`},
 	}
 
 	for _, test := range tests {
@@ -925,6 +927,8 @@ func TestGetModuleNames(t *testing.T) {
 		{"module.my_module.module.my_submodule[\"index.1\"].data.aws_instance.my_instance", []string{"my_module", "my_submodule"}},
 		{"module.my_module.module.my_submodule[\"index.1\"].aws_instance.my_instance[\"index.1\"]", []string{"my_module", "my_submodule"}},
 		{"module.my_module.module.my_submodule[\"index.1\"].data.aws_instance.my_instance[\"index.1\"]", []string{"my_module", "my_submodule"}},
+		// Escaped quotes in the index
+		{`module.my_module["a\".module.b"].aws_instance.my_instance`, []string{"my_module"}},
 	}
 
 	for _, test := range tests {
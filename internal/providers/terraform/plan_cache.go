@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-config-inspect/tfconfig"
 	"os"
-	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -126,6 +125,11 @@ func UsePlanCache(p *DirProvider) bool {
 		return false
 	}
 
+	if p.EphemeralWorkspace {
+		// the cache is invalidated by changes to the .terraform dir, which isn't used by ephemeral workspaces
+		return false
+	}
+
 	if p.ctx.RunContext.IsCIRun() {
 		return false
 	}
@@ -139,7 +143,7 @@ func UsePlanCache(p *DirProvider) bool {
 }
 
 func ReadPlanCache(p *DirProvider) ([]byte, error) {
-	cache := path.Join(calcCacheDir(p), cacheFileName)
+	cache := filepath.Join(calcCacheDir(p), cacheFileName)
 
 	info, err := os.Stat(cache)
 	if err != nil {
@@ -200,7 +204,7 @@ func WritePlanCache(p *DirProvider, planJSON []byte) {
 		}
 	}
 
-	err = os.WriteFile(path.Join(cacheDir, cacheFileName), cacheJSON, 0600)
+	err = os.WriteFile(filepath.Join(cacheDir, cacheFileName), cacheJSON, 0600)
 	if err != nil {
 		log.Debugf("Failed to write plan cache: %v", err)
 		return
@@ -217,23 +221,27 @@ func calcDataDir(p *DirProvider) string {
 		return dir
 	}
 
-	return path.Join(p.Path, ".terraform")
+	return filepath.Join(p.Path, ".terraform")
 }
 
 func calcCacheDir(p *DirProvider) string {
+	if p.ctx.RunContext.Config.ReadOnly {
+		return p.ctx.RunContext.Config.ProjectCacheDir(p.Path)
+	}
+
 	dataDir := calcDataDir(p)
 
-	if dataDir != (path.Join(p.Path, ".terraform")) {
+	if dataDir != (filepath.Join(p.Path, ".terraform")) {
 		// there is a custom data dir, store the cache under that
-		return path.Join(dataDir, infracostDir)
+		return filepath.Join(dataDir, infracostDir)
 	}
 
-	return path.Join(p.Path, infracostDir)
+	return filepath.Join(p.Path, infracostDir)
 }
 
 func calcConfigState(p *DirProvider) configState {
 	var tfLockFileDate string
-	if lockStat, err := os.Stat(path.Join(p.Path, ".terraform.lock.hcl")); err == nil {
+	if lockStat, err := os.Stat(filepath.Join(p.Path, ".terraform.lock.hcl")); err == nil {
 		tfLockFileDate = lockStat.ModTime().String()
 	}
 
@@ -39,9 +39,11 @@ func (p *PlanJSONProvider) AddMetadata(metadata *schema.ProjectMetadata) {
 
 func (p *PlanJSONProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
 	spinner := ui.NewSpinner("Extracting only cost-related params from terraform", ui.SpinnerOptions{
-		EnableLogging: p.ctx.RunContext.Config.IsLogging(),
-		NoColor:       p.ctx.RunContext.Config.NoColor,
-		Indent:        "  ",
+		EnableLogging:  p.ctx.RunContext.Config.IsLogging(),
+		NoColor:        p.ctx.RunContext.Config.NoColor,
+		NoProgress:     p.ctx.RunContext.Config.NoProgress,
+		NonInteractive: p.ctx.RunContext.Config.NonInteractive,
+		Indent:         "  ",
 	})
 	defer spinner.Fail()
 
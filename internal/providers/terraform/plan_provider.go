@@ -46,9 +46,11 @@ func (p *PlanProvider) LoadResources(usage map[string]*schema.UsageData) ([]*sch
 	}
 
 	spinner := ui.NewSpinner("Extracting only cost-related params from terraform", ui.SpinnerOptions{
-		EnableLogging: p.ctx.RunContext.Config.IsLogging(),
-		NoColor:       p.ctx.RunContext.Config.NoColor,
-		Indent:        "  ",
+		EnableLogging:  p.ctx.RunContext.Config.IsLogging(),
+		NoColor:        p.ctx.RunContext.Config.NoColor,
+		NoProgress:     p.ctx.RunContext.Config.NoProgress,
+		NonInteractive: p.ctx.RunContext.Config.NonInteractive,
+		Indent:         "  ",
 	})
 	defer spinner.Fail()
 
@@ -0,0 +1,143 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// priorStateUsageFunc returns the usage of a resource that can be derived from its values in the prior
+// state of a plan, i.e. the infrastructure as it is before the plan is applied. The resources are all the
+// resources in the prior state, for usage that depends on other resources.
+type priorStateUsageFunc func(r gjson.Result, resources []gjson.Result) map[string]interface{}
+
+var priorStateUsageFuncs = map[string]priorStateUsageFunc{
+	"aws_autoscaling_group": autoscalingGroupPriorStateUsage,
+	"aws_eks_node_group":    eksNodeGroupPriorStateUsage,
+	"aws_s3_bucket":         s3BucketPriorStateUsage,
+}
+
+// loadPriorStateUsageData adds the usage derived from the prior state of the plan to the usage data. This
+// is the measured usage of the existing infrastructure, e.g. the number of instances an autoscaling group
+// is currently running, so it's used for usage keys that aren't set in the usage file.
+func (p *Parser) loadPriorStateUsageData(u map[string]*schema.UsageData, parsed gjson.Result) {
+	vals := parsed.Get("prior_state.values.root_module")
+	if !vals.Exists() {
+		return
+	}
+
+	log.Debugf("Loading usage data from the plan's prior state")
+
+	resources := priorStateResources(vals)
+	loaded := 0
+
+	for _, r := range resources {
+		f, ok := priorStateUsageFuncs[r.Get("type").String()]
+		if !ok || r.Get("mode").String() == "data" {
+			continue
+		}
+
+		hints := f(r, resources)
+		if len(hints) == 0 {
+			continue
+		}
+
+		addr := r.Get("address").String()
+		attrs := map[string]gjson.Result{}
+		if existing := lookupUsageData(u, addr); existing != nil {
+			for k, v := range existing.Attributes {
+				attrs[k] = v
+			}
+		}
+
+		for k, v := range schema.ParseAttributes(hints) {
+			if attrs[k].Type != gjson.Null {
+				log.Debugf("Skipping prior state usage %s for resource %s since it has already been defined", k, addr)
+				continue
+			}
+
+			attrs[k] = v
+			loaded++
+		}
+
+		u[addr] = schema.NewUsageData(addr, attrs)
+	}
+
+	if loaded > 0 {
+		p.ctx.SetContextValue("priorStateUsageCount", loaded)
+	}
+}
+
+// lookupUsageData returns the usage data for the address, or for all the indexes of the resource if the
+// address has an index, the same as populateUsageData.
+func lookupUsageData(u map[string]*schema.UsageData, addr string) *schema.UsageData {
+	if ud := u[addr]; ud != nil {
+		return ud
+	}
+
+	if strings.HasSuffix(addr, "]") {
+		return u[fmt.Sprintf("%s[*]", addr[:strings.LastIndex(addr, "[")])]
+	}
+
+	return nil
+}
+
+// priorStateResources returns the resources in the module and all its child modules.
+func priorStateResources(module gjson.Result) []gjson.Result {
+	resources := module.Get("resources").Array()
+
+	for _, child := range module.Get("child_modules").Array() {
+		resources = append(resources, priorStateResources(child)...)
+	}
+
+	return resources
+}
+
+func autoscalingGroupPriorStateUsage(r gjson.Result, resources []gjson.Result) map[string]interface{} {
+	desired := r.Get("values.desired_capacity")
+	if desired.Type != gjson.Number {
+		return nil
+	}
+
+	return map[string]interface{}{"instances": desired.Int()}
+}
+
+func eksNodeGroupPriorStateUsage(r gjson.Result, resources []gjson.Result) map[string]interface{} {
+	desired := r.Get("values.scaling_config.0.desired_size")
+	if desired.Type != gjson.Number {
+		return nil
+	}
+
+	return map[string]interface{}{"instances": desired.Int()}
+}
+
+// s3BucketPriorStateUsage counts the tagged objects in the bucket that are managed by Terraform. Objects
+// that aren't in the state can't be counted, so this is only used if it finds some.
+func s3BucketPriorStateUsage(r gjson.Result, resources []gjson.Result) map[string]interface{} {
+	bucket := r.Get("values.bucket").String()
+	if bucket == "" {
+		return nil
+	}
+
+	tagged := 0
+	for _, obj := range resources {
+		t := obj.Get("type").String()
+		if t != "aws_s3_object" && t != "aws_s3_bucket_object" {
+			continue
+		}
+
+		if obj.Get("values.bucket").String() == bucket && len(obj.Get("values.tags").Map()) > 0 {
+			tagged++
+		}
+	}
+
+	if tagged == 0 {
+		return nil
+	}
+
+	return map[string]interface{}{"object_tags": tagged}
+}
@@ -0,0 +1,115 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestLoadPriorStateUsageData(t *testing.T) {
+	parsed := gjson.Parse(`{
+		"prior_state": {
+			"values": {
+				"root_module": {
+					"resources": [
+						{
+							"address": "aws_autoscaling_group.web",
+							"mode": "managed",
+							"type": "aws_autoscaling_group",
+							"values": {"desired_capacity": 7}
+						},
+						{
+							"address": "aws_autoscaling_group.workers[0]",
+							"mode": "managed",
+							"type": "aws_autoscaling_group",
+							"values": {"desired_capacity": 3}
+						},
+						{
+							"address": "aws_s3_bucket.assets",
+							"mode": "managed",
+							"type": "aws_s3_bucket",
+							"values": {"bucket": "assets"}
+						},
+						{
+							"address": "aws_s3_bucket.logs",
+							"mode": "managed",
+							"type": "aws_s3_bucket",
+							"values": {"bucket": "logs"}
+						},
+						{
+							"address": "aws_s3_object.logo",
+							"mode": "managed",
+							"type": "aws_s3_object",
+							"values": {"bucket": "assets", "tags": {"team": "web"}}
+						},
+						{
+							"address": "aws_s3_object.favicon",
+							"mode": "managed",
+							"type": "aws_s3_object",
+							"values": {"bucket": "assets", "tags": {}}
+						}
+					],
+					"child_modules": [
+						{
+							"resources": [
+								{
+									"address": "module.eks.aws_eks_node_group.default",
+									"mode": "managed",
+									"type": "aws_eks_node_group",
+									"values": {"scaling_config": [{"desired_size": 4, "min_size": 1, "max_size": 10}]}
+								},
+								{
+									"address": "module.eks.aws_s3_bucket_object.config",
+									"mode": "managed",
+									"type": "aws_s3_bucket_object",
+									"values": {"bucket": "assets", "tags": {"env": "prod"}}
+								}
+							]
+						}
+					]
+				}
+			}
+		}
+	}`)
+
+	usage := schema.NewUsageMap(map[string]interface{}{
+		"aws_autoscaling_group.web": map[string]interface{}{
+			"instances":           10,
+			"operating_system":    "linux",
+			"monthly_cpu_credits": 5,
+		},
+		"aws_autoscaling_group.workers[*]": map[string]interface{}{
+			"operating_system": "windows",
+		},
+	})
+	original := usage["aws_autoscaling_group.workers[*]"]
+
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{})
+	p := NewParser(ctx, true)
+	p.loadPriorStateUsageData(usage, parsed)
+
+	// usage file values take precedence over the prior state.
+	assert.Equal(t, int64(10), usage["aws_autoscaling_group.web"].Get("instances").Int())
+	assert.Equal(t, "linux", usage["aws_autoscaling_group.web"].Get("operating_system").String())
+
+	// wildcard usage is kept for the indexed resource.
+	workers := usage["aws_autoscaling_group.workers[0]"]
+	require.NotNil(t, workers)
+	assert.Equal(t, int64(3), workers.Get("instances").Int())
+	assert.Equal(t, "windows", workers.Get("operating_system").String())
+	assert.Len(t, original.Attributes, 1)
+
+	require.NotNil(t, usage["module.eks.aws_eks_node_group.default"])
+	assert.Equal(t, int64(4), usage["module.eks.aws_eks_node_group.default"].Get("instances").Int())
+
+	require.NotNil(t, usage["aws_s3_bucket.assets"])
+	assert.Equal(t, int64(2), usage["aws_s3_bucket.assets"].Get("object_tags").Int())
+	assert.Nil(t, usage["aws_s3_bucket.logs"])
+
+	assert.Equal(t, 3, ctx.ContextValues()["priorStateUsageCount"])
+}
@@ -16,6 +16,10 @@ type ResourceRegistryMap map[string]*schema.RegistryItem
 var (
 	resourceRegistryMap ResourceRegistryMap
 	once                sync.Once
+
+	// registeredResourceTypes are the resource types added with RegisterResources, which are
+	// supported even though they aren't from a supported provider.
+	registeredResourceTypes = map[string]bool{}
 )
 
 func GetResourceRegistryMap() *ResourceRegistryMap {
@@ -48,6 +52,18 @@ func GetResourceRegistryMap() *ResourceRegistryMap {
 	return &resourceRegistryMap
 }
 
+// RegisterResources adds resource types to the registry, replacing any that are already registered.
+// It's used for the resource types that are priced by plugins, so it must be called before any
+// projects are loaded.
+func RegisterResources(items []*schema.RegistryItem) {
+	registryMap := GetResourceRegistryMap()
+
+	for _, item := range items {
+		(*registryMap)[item.Name] = item
+		registeredResourceTypes[item.Name] = true
+	}
+}
+
 func (r *ResourceRegistryMap) GetReferenceAttributes(resourceDataType string) []string {
 	var refAttrs []string
 	item, ok := (*r)[resourceDataType]
@@ -74,6 +90,10 @@ func GetUsageOnlyResources() []string {
 }
 
 func HasSupportedProvider(rType string) bool {
+	if registeredResourceTypes[rType] {
+		return true
+	}
+
 	return strings.HasPrefix(rType, "aws_") || strings.HasPrefix(rType, "google_") || strings.HasPrefix(rType, "azurerm_")
 }
 
@@ -0,0 +1,129 @@
+package terraform
+
+import (
+	"fmt"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// StackProvider estimates each deployment of a Terraform Stack as a separate project. A root module is
+// written for each deployment to .infracost/stacks in the Stack directory, or the temp directory in
+// read-only runs, which is then parsed with an HCLProvider.
+type StackProvider struct {
+	ctx                  *config.ProjectContext
+	Path                 string
+	includePastResources bool
+
+	unresolved []hcl.UnresolvedAttribute
+	skipped    []hcl.SkippedBlock
+}
+
+// NewStackProvider creates a new provider initialized with the configured project path, which should be a
+// directory containing the .tfstack.hcl and .tfdeploy.hcl files of a Terraform Stack.
+func NewStackProvider(ctx *config.ProjectContext, includePastResources bool) schema.Provider {
+	return &StackProvider{
+		ctx:                  ctx,
+		Path:                 ctx.ProjectConfig.Path,
+		includePastResources: includePastResources,
+	}
+}
+
+func (p *StackProvider) Type() string {
+	return "terraform_stack"
+}
+
+func (p *StackProvider) DisplayType() string {
+	return "Terraform Stack (HCL)"
+}
+
+func (p *StackProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	// no op
+}
+
+// LoadResources loads the Terraform Stack and returns a project for each of its deployments.
+func (p *StackProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	return p.StreamResources(usage, nil)
+}
+
+// StreamResources loads the Terraform Stack like LoadResources, calling onProject with the project of
+// each deployment as soon as it has been parsed.
+func (p *StackProvider) StreamResources(usage map[string]*schema.UsageData, onProject func(*schema.Project)) ([]*schema.Project, error) {
+	stack, err := hcl.LoadStack(p.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stack.Deployments) == 0 {
+		return nil, clierror.New(
+			clierror.CodeNoTerraformFiles,
+			clierror.CategoryUser,
+			fmt.Sprintf("No deployments found in Terraform Stack %s", p.Path),
+			"Add a deployment block to a .tfdeploy.hcl file in the Stack directory.",
+		)
+	}
+
+	var allProjects []*schema.Project
+
+	for _, d := range stack.Deployments {
+		dir := filepath.Join(p.ctx.RunContext.Config.ProjectCacheDir(p.Path), "stacks", d.Name)
+		log.Debugf("Writing Terraform Stack deployment %s to %s", d.Name, dir)
+
+		err := stack.WriteDeployment(dir, d)
+		if err != nil {
+			return nil, err
+		}
+
+		pconfig := *p.ctx.ProjectConfig // clone the projectConfig
+		pconfig.Path = dir
+
+		pctx := config.NewProjectContext(p.ctx.RunContext, &pconfig)
+		h, err := NewHCLProvider(
+			pctx,
+			NewPlanJSONProvider(pctx, p.includePastResources),
+			hcl.OptionWithSpinner(p.ctx.RunContext.NewSpinner),
+			hcl.OptionWithWarningFunc(p.ctx.RunContext.NewWarningWriter()),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		projects, err := h.LoadResources(usage)
+		if err != nil {
+			return nil, err
+		}
+
+		p.unresolved = append(p.unresolved, h.UnresolvedAttributes()...)
+		p.skipped = append(p.skipped, h.SkippedBlocks()...)
+
+		for _, project := range projects {
+			metadata := config.DetectProjectMetadata(p.Path)
+			metadata.Type = p.Type()
+			metadata.TerraformStackDeployment = d.Name
+			project.Metadata = metadata
+			project.Name = schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
+			allProjects = append(allProjects, project)
+
+			if onProject != nil {
+				onProject(project)
+			}
+		}
+	}
+
+	return allProjects, nil
+}
+
+// UnresolvedAttributes returns the attributes that couldn't be evaluated in all the Stack deployments.
+func (p *StackProvider) UnresolvedAttributes() []hcl.UnresolvedAttribute {
+	return p.unresolved
+}
+
+// SkippedBlocks returns the blocks that weren't evaluated in all the Stack deployments.
+func (p *StackProvider) SkippedBlocks() []hcl.SkippedBlock {
+	return p.skipped
+}
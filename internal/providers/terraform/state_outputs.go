@@ -0,0 +1,47 @@
+package terraform
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+type stateOutput struct {
+	Value interface{} `json:"value"`
+}
+
+// stateOutputsFile has the outputs of both a Terraform state file and the output of
+// terraform show -json, which has them under values.
+type stateOutputsFile struct {
+	Outputs map[string]stateOutput `json:"outputs"`
+	Values  *struct {
+		Outputs map[string]stateOutput `json:"outputs"`
+	} `json:"values"`
+}
+
+// LoadStateOutputs returns the values of the root module outputs in a Terraform state file, or the
+// output of terraform show -json for a state.
+func LoadStateOutputs(path string) (map[string]interface{}, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading Terraform state file")
+	}
+
+	var f stateOutputsFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, errors.Wrapf(err, "Error parsing Terraform state file %s", path)
+	}
+
+	stateOutputs := f.Outputs
+	if f.Values != nil {
+		stateOutputs = f.Values.Outputs
+	}
+
+	outputs := make(map[string]interface{}, len(stateOutputs))
+	for k, o := range stateOutputs {
+		outputs[k] = o.Value
+	}
+
+	return outputs, nil
+}
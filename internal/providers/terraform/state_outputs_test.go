@@ -0,0 +1,49 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadStateOutputs(t *testing.T) {
+	dir := t.TempDir()
+
+	state := filepath.Join(dir, "terraform.tfstate")
+	require.NoError(t, os.WriteFile(state, []byte(`{
+  "version": 4,
+  "outputs": {
+    "vpc_id": {"value": "vpc-123", "type": "string"},
+    "subnet_ids": {"value": ["subnet-a", "subnet-b"], "type": ["list", "string"]}
+  },
+  "resources": []
+}`), 0600))
+
+	outputs, err := LoadStateOutputs(state)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"vpc_id":     "vpc-123",
+		"subnet_ids": []interface{}{"subnet-a", "subnet-b"},
+	}, outputs)
+
+	show := filepath.Join(dir, "state.json")
+	require.NoError(t, os.WriteFile(show, []byte(`{
+  "format_version": "1.0",
+  "values": {
+    "outputs": {
+      "vpc_id": {"value": "vpc-456", "sensitive": false}
+    },
+    "root_module": {}
+  }
+}`), 0600))
+
+	outputs, err = LoadStateOutputs(show)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"vpc_id": "vpc-456"}, outputs)
+
+	_, err = LoadStateOutputs(filepath.Join(dir, "missing.tfstate"))
+	assert.Error(t, err)
+}
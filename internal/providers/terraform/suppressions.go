@@ -0,0 +1,255 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	log "github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/infracost/infracost/internal/address"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// suppressionCommentReg matches comments in the format:
+//
+//	# infracost-ignore: max-monthly-cost reason="approved by FinOps"
+//	// infracost-ignore: max-monthly-cost,require-tags
+var suppressionCommentReg = regexp.MustCompile(`^\s*(?:#|//)\s*infracost-ignore:\s*([\w\-*,]+)(?:\s+reason="([^"]*)")?\s*$`)
+
+// LoadSuppressions reads all the Terraform files in the given directory and the child modules
+// it calls, and returns any infracost-ignore suppression comments keyed by the address of the
+// resource or data block defined beneath them, without any instance keys, e.g.
+// module.vpc.aws_nat_gateway.this. Suppression comments must be directly above the block, other
+// comments are allowed between the suppression and the block but blank lines are not.
+//
+// Child modules are found from the module manifest, so remote modules are only read once they
+// have been downloaded. Local modules are read from their source path if they aren't in the
+// manifest.
+func LoadSuppressions(dir string) map[string][]schema.Suppression {
+	suppressions := map[string][]schema.Suppression{}
+
+	manifestDirs := map[string]string{}
+	if manifest, err := modules.ReadProjectManifest(dir); err == nil {
+		for _, m := range manifest.Modules {
+			manifestDirs[m.Key] = m.Dir
+		}
+	}
+
+	loadModuleSuppressions(dir, dir, "", manifestDirs, map[string]bool{}, suppressions)
+
+	return suppressions
+}
+
+// loadModuleSuppressions adds the suppressions of the module in dir, and the modules it calls,
+// to suppressions. addrPrefix is the address of the module, e.g. module.vpc., and ancestors are
+// the directories of the modules that call it so recursive calls are skipped.
+func loadModuleSuppressions(rootDir, dir, addrPrefix string, manifestDirs map[string]string, ancestors map[string]bool, suppressions map[string][]schema.Suppression) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		log.Debugf("Could not list Terraform files in %s for suppressions: %s", dir, err)
+		return
+	}
+
+	ancestors[dir] = true
+	defer delete(ancestors, dir)
+
+	for _, filename := range matches {
+		calls, err := loadFileSuppressions(rootDir, filename, addrPrefix, suppressions)
+		if err != nil {
+			log.Debugf("Could not read suppressions from %s: %s", filename, err)
+		}
+
+		for _, call := range calls {
+			// The manifest keys are the module names without the module. prefixes, e.g. vpc.subnets.
+			key := strings.ReplaceAll(addrPrefix+call.name, "module.", "")
+
+			var moduleDir string
+			if d, ok := manifestDirs[key]; ok {
+				moduleDir = filepath.Join(rootDir, d)
+			} else if strings.HasPrefix(call.source, "./") || strings.HasPrefix(call.source, "../") {
+				moduleDir = filepath.Join(dir, call.source)
+			} else {
+				log.Debugf("Skipping suppressions in module %s%s since it has not been downloaded", addrPrefix, call.name)
+				continue
+			}
+
+			if ancestors[moduleDir] {
+				continue
+			}
+
+			loadModuleSuppressions(rootDir, moduleDir, addrPrefix+"module."+call.name+".", manifestDirs, ancestors, suppressions)
+		}
+	}
+}
+
+// moduleCall is a module block and its source.
+type moduleCall struct {
+	name   string
+	source string
+}
+
+// loadFileSuppressions adds the suppressions of the resource and data blocks in the file to
+// suppressions, and returns the module blocks it calls.
+func loadFileSuppressions(rootDir, filename, addrPrefix string, suppressions map[string][]schema.Suppression) ([]moduleCall, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	f, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, nil
+	}
+
+	// Suppressions are reported relative to the project, e.g. modules/app/main.tf.
+	relFilename, err := filepath.Rel(rootDir, filename)
+	if err != nil {
+		relFilename = filepath.Base(filename)
+	}
+
+	comments := commentLines(src, filename)
+
+	var calls []moduleCall
+
+	for _, block := range body.Blocks {
+		var addr string
+
+		switch {
+		case block.Type == "resource" && len(block.Labels) == 2:
+			addr = addrPrefix + block.Labels[0] + "." + block.Labels[1]
+		case block.Type == "data" && len(block.Labels) == 2:
+			addr = addrPrefix + "data." + block.Labels[0] + "." + block.Labels[1]
+		case block.Type == "module" && len(block.Labels) == 1:
+			calls = append(calls, moduleCall{name: block.Labels[0], source: moduleSource(block)})
+			continue
+		default:
+			continue
+		}
+
+		// The suppressions are in the comment lines directly above the block.
+		line := block.DefRange().Start.Line
+		first := line
+		for {
+			if _, ok := comments[first-1]; !ok {
+				break
+			}
+			first--
+		}
+
+		for l := first; l < line; l++ {
+			m := suppressionCommentReg.FindStringSubmatch(comments[l])
+			if m == nil {
+				continue
+			}
+
+			for _, policy := range strings.Split(m[1], ",") {
+				if policy == "" {
+					continue
+				}
+
+				suppressions[addr] = append(suppressions[addr], schema.Suppression{
+					Policy:   policy,
+					Reason:   m[2],
+					Filename: filepath.ToSlash(relFilename),
+					Line:     l,
+				})
+			}
+		}
+	}
+
+	return calls, nil
+}
+
+// commentLines returns the lines of the file that only contain a comment, keyed by line number. Lines
+// of multi-line comments other than the first are empty strings.
+func commentLines(src []byte, filename string) map[int]string {
+	tokens, _ := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+
+	comments := map[int]string{}
+	code := map[int]bool{}
+
+	for _, t := range tokens {
+		switch t.Type {
+		case hclsyntax.TokenComment:
+			// Line comments include their newline, so they end at the start of the next line.
+			end := t.Range.End.Line
+			if t.Range.End.Column == 1 && end > t.Range.Start.Line {
+				end--
+			}
+
+			comments[t.Range.Start.Line] = string(t.Bytes)
+			for l := t.Range.Start.Line + 1; l <= end; l++ {
+				comments[l] = ""
+			}
+		case hclsyntax.TokenNewline, hclsyntax.TokenEOF:
+		default:
+			for l := t.Range.Start.Line; l <= t.Range.End.Line; l++ {
+				code[l] = true
+			}
+		}
+	}
+
+	for l := range code {
+		delete(comments, l)
+	}
+
+	return comments
+}
+
+// moduleSource returns the source of the module block, or an empty string if it isn't a string
+// literal.
+func moduleSource(block *hclsyntax.Block) string {
+	attr, ok := block.Body.Attributes["source"]
+	if !ok {
+		return ""
+	}
+
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() || !val.IsKnown() || val.Type() != cty.String {
+		return ""
+	}
+
+	return val.AsString()
+}
+
+// AddSuppressions loads the suppression comments from the Terraform files at path and sets
+// them on the matching resources. If the path is not a directory then this is a no-op.
+func AddSuppressions(path string, resources []*schema.Resource) {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return
+	}
+
+	suppressions := LoadSuppressions(path)
+	if len(suppressions) == 0 {
+		return
+	}
+
+	for _, r := range resources {
+		if s, ok := suppressions[suppressionAddress(r.Name)]; ok {
+			r.Suppressions = s
+		}
+	}
+}
+
+// suppressionAddress returns the address that the suppressions of the resource are keyed by,
+// which is its address without any instance keys, e.g. module.vpc["a"].aws_subnet.this[0] is
+// module.vpc.aws_subnet.this.
+func suppressionAddress(resourceAddress string) string {
+	if a, err := address.Parse(resourceAddress); err == nil {
+		return a.ConfigAddress()
+	}
+
+	return address.TrimKey(resourceAddress)
+}
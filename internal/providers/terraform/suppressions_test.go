@@ -0,0 +1,157 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestLoadSuppressions(t *testing.T) {
+	dir := t.TempDir()
+
+	content := `# infracost-ignore: max-monthly-cost reason="approved by FinOps"
+# web servers for the marketing site
+resource "aws_instance" "web" {
+  instance_type = "m5.4xlarge"
+}
+
+# infracost-ignore: require-tags,max-monthly-cost
+
+resource "aws_instance" "not_suppressed" {
+  instance_type = "m5.large"
+}
+
+  // infracost-ignore: *
+resource "aws_db_instance" "db" {
+  instance_class = "db.r5.large"
+  user_data = <<EOF
+# infracost-ignore: *
+EOF
+}
+
+# infracost-ignore: max-monthly-cost
+data "aws_ami" "ubuntu" {
+  most_recent = true
+} # infracost-ignore: *
+resource "aws_instance" "after_code" {
+}
+`
+	err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(content), 0600)
+	assert.NoError(t, err)
+
+	actual := LoadSuppressions(dir)
+
+	assert.Equal(t, map[string][]schema.Suppression{
+		"aws_instance.web": {
+			{Policy: "max-monthly-cost", Reason: "approved by FinOps", Filename: "main.tf", Line: 1},
+		},
+		"aws_db_instance.db": {
+			{Policy: "*", Filename: "main.tf", Line: 13},
+		},
+		"data.aws_ami.ubuntu": {
+			{Policy: "max-monthly-cost", Filename: "main.tf", Line: 21},
+		},
+	}, actual)
+}
+
+func TestAddSuppressions(t *testing.T) {
+	dir := t.TempDir()
+
+	content := `# infracost-ignore: max-monthly-cost
+resource "aws_instance" "web" {
+  count = 2
+}
+`
+	err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(content), 0600)
+	assert.NoError(t, err)
+
+	resources := []*schema.Resource{
+		{Name: "aws_instance.web[0]"},
+		{Name: "aws_instance.web[1]"},
+		{Name: "module.app.aws_instance.web[0]"},
+	}
+
+	AddSuppressions(dir, resources)
+
+	assert.Len(t, resources[0].Suppressions, 1)
+	assert.Len(t, resources[1].Suppressions, 1)
+	assert.Len(t, resources[2].Suppressions, 0)
+	assert.True(t, resources[0].Suppressions[0].Matches("max-monthly-cost"))
+	assert.False(t, resources[0].Suppressions[0].Matches("require-tags"))
+}
+
+func TestLoadSuppressionsInModules(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.tf": `module "app" {
+  source = "./modules/app"
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "3.0.0"
+}
+
+module "not_downloaded" {
+  source = "terraform-aws-modules/rds/aws"
+}
+`,
+		"modules/app/main.tf": `# infracost-ignore: max-monthly-cost
+resource "aws_instance" "web" {
+}
+
+module "db" {
+  settings {
+    source = "../not_a_module"
+  }
+  source = "../db"
+}
+`,
+		"modules/db/main.tf": `# infracost-ignore: require-tags
+resource "aws_db_instance" "db" {
+}
+`,
+		".infracost/terraform_modules/vpc/main.tf": `# infracost-ignore: *
+resource "aws_nat_gateway" "this" {
+}
+`,
+		".infracost/terraform_modules/manifest.json": `{"Modules":[{"Key":"vpc","Source":"registry.terraform.io/terraform-aws-modules/vpc/aws","Version":"3.0.0","Dir":".infracost/terraform_modules/vpc"}]}`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	actual := LoadSuppressions(dir)
+
+	assert.Equal(t, map[string][]schema.Suppression{
+		"module.app.aws_instance.web": {
+			{Policy: "max-monthly-cost", Filename: "modules/app/main.tf", Line: 1},
+		},
+		"module.app.module.db.aws_db_instance.db": {
+			{Policy: "require-tags", Filename: "modules/db/main.tf", Line: 1},
+		},
+		"module.vpc.aws_nat_gateway.this": {
+			{Policy: "*", Filename: ".infracost/terraform_modules/vpc/main.tf", Line: 1},
+		},
+	}, actual)
+
+	resources := []*schema.Resource{
+		{Name: `module.app.aws_instance.web`},
+		{Name: `module.vpc["a"].aws_nat_gateway.this[0]`},
+		{Name: `module.app.module.db.aws_db_instance.other`},
+	}
+
+	AddSuppressions(dir, resources)
+
+	assert.Len(t, resources[0].Suppressions, 1)
+	assert.Len(t, resources[1].Suppressions, 1)
+	assert.Len(t, resources[2].Suppressions, 0)
+}
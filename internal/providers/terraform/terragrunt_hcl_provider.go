@@ -29,6 +29,9 @@ type TerragruntHCLProvider struct {
 	ctx                  *config.ProjectContext
 	Path                 string
 	includePastResources bool
+
+	unresolved []hcl.UnresolvedAttribute
+	skipped    []hcl.SkippedBlock
 }
 
 // NewTerragruntHCLProvider creates a new provider intialized with the configured project path (usually the terragrunt
@@ -62,6 +65,12 @@ type terragruntWorkingDirInfo struct {
 // LoadResources finds any Terragrunt projects, prepares them by downloading any required source files, then
 // process each with an HCLProvider.
 func (p *TerragruntHCLProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	return p.StreamResources(usage, nil)
+}
+
+// StreamResources loads the Terragrunt projects like LoadResources, calling onProject with the project of
+// each working dir as soon as it has been parsed.
+func (p *TerragruntHCLProvider) StreamResources(usage map[string]*schema.UsageData, onProject func(*schema.Project)) ([]*schema.Project, error) {
 	workingDirInfos, err := p.prepWorkingDirs()
 	if err != nil {
 		return nil, err
@@ -98,18 +107,35 @@ func (p *TerragruntHCLProvider) LoadResources(usage map[string]*schema.UsageData
 			return nil, err
 		}
 
+		p.unresolved = append(p.unresolved, h.UnresolvedAttributes()...)
+		p.skipped = append(p.skipped, h.SkippedBlocks()...)
+
 		for _, project := range projects {
 			metadata := config.DetectProjectMetadata(di.ConfigDir)
 			metadata.Type = p.Type()
 			p.AddMetadata(metadata)
 			project.Name = schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
 			allProjects = append(allProjects, project)
+
+			if onProject != nil {
+				onProject(project)
+			}
 		}
 	}
 
 	return allProjects, nil
 }
 
+// UnresolvedAttributes returns the attributes that couldn't be evaluated in all the Terragrunt working dirs.
+func (p *TerragruntHCLProvider) UnresolvedAttributes() []hcl.UnresolvedAttribute {
+	return p.unresolved
+}
+
+// SkippedBlocks returns the blocks that weren't evaluated in all the Terragrunt working dirs.
+func (p *TerragruntHCLProvider) SkippedBlocks() []hcl.SkippedBlock {
+	return p.skipped
+}
+
 func (p *TerragruntHCLProvider) initTerraformVars(tfVars map[string]string, inputs map[string]interface{}) map[string]string {
 	m := make(map[string]string, len(tfVars)+len(inputs))
 	for k, v := range tfVars {
@@ -124,7 +150,7 @@ func (p *TerragruntHCLProvider) initTerraformVars(tfVars map[string]string, inpu
 func (p *TerragruntHCLProvider) prepWorkingDirs() ([]*terragruntWorkingDirInfo, error) {
 	terragruntConfigPath := tgconfig.GetDefaultConfigPath(p.Path)
 
-	terragruntDownloadDir := filepath.Join(p.Path, ".infracost/.terragrunt-cache")
+	terragruntDownloadDir := filepath.Join(p.ctx.RunContext.Config.ProjectCacheDir(p.Path), ".terragrunt-cache")
 	err := os.MkdirAll(terragruntDownloadDir, os.ModePerm)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create download directories for terragrunt in working directory: %w", err)
@@ -132,6 +158,12 @@ func (p *TerragruntHCLProvider) prepWorkingDirs() ([]*terragruntWorkingDirInfo,
 
 	var workingDirsToEstimate []*terragruntWorkingDirInfo
 
+	// the Terragrunt directories that are ignored by the ignore files in the path aren't estimated.
+	ignoreRules, err := hcl.LoadIgnoreRules(p.Path)
+	if err != nil {
+		log.Debugf("Error reading ignore files in %s: %s", p.Path, err)
+	}
+
 	terragruntOptions := &tgoptions.TerragruntOptions{
 		TerragruntConfigPath:       terragruntConfigPath,
 		Logger:                     log.WithField("library", "terragrunt"),
@@ -149,6 +181,10 @@ func (p *TerragruntHCLProvider) prepWorkingDirs() ([]*terragruntWorkingDirInfo,
 				_, _ = terragruntOptions.Writer.Write([]byte(`{ "infracost_mock_output": { "type": "string", "value": "" } }`))
 				return nil
 			}
+			if ignoreRules.Ignored(terragruntOptions.WorkingDir, true) {
+				log.Debugf("Skipping ignored terragrunt dir: %s", terragruntOptions.WorkingDir)
+				return nil
+			}
 			workingDirInfo, err := p.runTerragrunt(terragruntOptions)
 			if workingDirInfo != nil {
 				workingDirsToEstimate = append(workingDirsToEstimate, workingDirInfo)
@@ -368,9 +404,10 @@ func (p *TerragruntHCLProvider) downloadSource(terraformSource *tfsource.Terrafo
 }
 
 // updateGetters returns the customized go-getter interfaces that Terragrunt relies on. Specifically:
-// - Local file path getter is updated to copy the files instead of creating symlinks, which is what go-getter defaults
-//   to.
-// - Include the customized getter for fetching sources from the Terraform Registry.
+//   - Local file path getter is updated to copy the files instead of creating symlinks, which is what go-getter defaults
+//     to.
+//   - Include the customized getter for fetching sources from the Terraform Registry.
+//
 // This creates a closure that returns a function so that we have access to the terragrunt configuration, which is
 // necessary for customizing the behavior of the file getter.
 // Copied from github.com/gruntwork-io/terragrunt
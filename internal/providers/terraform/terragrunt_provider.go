@@ -96,9 +96,11 @@ func (p *TerragruntProvider) LoadResources(usage map[string]*schema.UsageData) (
 	projects := make([]*schema.Project, 0, len(projectDirs))
 
 	spinner := ui.NewSpinner("Extracting only cost-related params from terragrunt plan", ui.SpinnerOptions{
-		EnableLogging: p.ctx.RunContext.Config.IsLogging(),
-		NoColor:       p.ctx.RunContext.Config.NoColor,
-		Indent:        "  ",
+		EnableLogging:  p.ctx.RunContext.Config.IsLogging(),
+		NoColor:        p.ctx.RunContext.Config.NoColor,
+		NoProgress:     p.ctx.RunContext.Config.NoProgress,
+		NonInteractive: p.ctx.RunContext.Config.NonInteractive,
+		Indent:         "  ",
 	})
 	defer spinner.Fail()
 	for i, projectDir := range projectDirs {
@@ -13,16 +13,14 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-getter"
 	safetemp "github.com/hashicorp/go-safetemp"
 
 	"github.com/gruntwork-io/terragrunt/errors"
 	"github.com/gruntwork-io/terragrunt/util"
-)
 
-// httpClient is the default client to be used by HttpGetters.
-var httpClient = cleanhttp.DefaultClient()
+	"github.com/infracost/infracost/internal/httpclient"
+)
 
 // Constants relevant to the module registry
 const (
@@ -274,7 +272,7 @@ func httpGETAndGetResponse(ctx context.Context, getURL url.URL) ([]byte, *http.H
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", authToken))
 	}
 
-	resp, err := httpClient.Do(req)
+	resp, err := httpclient.NewClient(httpclient.PurposeModuleRegistry).Do(req)
 	if err != nil {
 		return nil, nil, errors.WithStackTrace(err)
 	}
@@ -0,0 +1,23 @@
+provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_eip" "root" {
+  vpc = true
+}
+
+resource "aws_launch_configuration" "web" {
+  image_id      = "ami-674cbc1e"
+  instance_type = "m5.large"
+}
+
+module "web" {
+  source               = "./modules/web"
+  instance_type        = "t3.large"
+  launch_configuration = aws_launch_configuration.web.id
+}
+
+resource "aws_nat_gateway" "nat" {
+  allocation_id = module.web.eip_id
+  subnet_id     = "subnet-12345678"
+}
@@ -0,0 +1,27 @@
+variable "instance_type" {
+  type = string
+}
+
+variable "launch_configuration" {
+  type = string
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = var.instance_type
+}
+
+resource "aws_autoscaling_group" "web" {
+  launch_configuration = var.launch_configuration
+  desired_capacity     = 2
+  max_size             = 3
+  min_size             = 1
+}
+
+resource "aws_eip" "nat" {
+  vpc = true
+}
+
+output "eip_id" {
+  value = aws_eip.nat.id
+}
@@ -0,0 +1,191 @@
+package terraform
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+// workspaceSkipDirs are the directories that aren't copied into an ephemeralWorkspace. These hold
+// state from previous runs or are too large to copy and aren't needed to plan the project.
+var workspaceSkipDirs = map[string]bool{
+	".git":              true,
+	".terraform":        true,
+	".terragrunt-cache": true,
+	infracostDir:        true,
+	"node_modules":      true,
+}
+
+// ephemeralWorkspace is a temporary copy of a Terraform project that terraform init and plan are
+// run in. The copy has its own .terraform directory and plugin cache, so the user's directory is
+// never changed and projects with the same path can be run at the same time.
+type ephemeralWorkspace struct {
+	// root is the temporary directory that holds the copied config and the plugin cache.
+	root string
+	// dir is the directory of the project within the copied config.
+	dir string
+}
+
+// newEphemeralWorkspace copies the project at path into a temporary directory. If the project is in
+// a git repo the whole repo is copied so that local module sources outside of the project still
+// resolve, otherwise only the project directory is copied.
+func newEphemeralWorkspace(path string) (*ephemeralWorkspace, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting absolute path of %s: %w", path, err)
+	}
+
+	copyRoot := absPath
+	if gitRoot := config.DetectVCSRoot(absPath); gitRoot != "" {
+		copyRoot = gitRoot
+	}
+
+	rel, err := filepath.Rel(copyRoot, absPath)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting path of %s relative to %s: %w", absPath, copyRoot, err)
+	}
+
+	root, err := os.MkdirTemp("", "infracost-workspace-")
+	if err != nil {
+		return nil, fmt.Errorf("Error creating temporary workspace: %w", err)
+	}
+
+	w := &ephemeralWorkspace{
+		root: root,
+		dir:  filepath.Join(root, "config", rel),
+	}
+
+	log.Debugf("Copying %s to temporary workspace %s", copyRoot, root)
+
+	err = copyWorkspaceDir(copyRoot, filepath.Join(root, "config"))
+	if err == nil {
+		err = os.MkdirAll(w.pluginCacheDir(), os.ModePerm)
+	}
+
+	if err != nil {
+		w.remove()
+		return nil, fmt.Errorf("Error creating temporary workspace: %w", err)
+	}
+
+	return w, nil
+}
+
+func (w *ephemeralWorkspace) pluginCacheDir() string {
+	return filepath.Join(w.root, "plugin-cache")
+}
+
+// configure updates the command options to run in the workspace. The workspace's plugin cache is
+// only used if the user hasn't configured their own with TF_PLUGIN_CACHE_DIR or plugin_cache_dir in
+// the CLI config, so that providers already downloaded there are reused.
+func (w *ephemeralWorkspace) configure(opts *CmdOptions) {
+	env := make(map[string]string, len(opts.Env)+1)
+	for k, v := range opts.Env {
+		env[k] = v
+	}
+
+	if dir := userPluginCacheDir(opts); dir != "" {
+		log.Debugf("Using plugin cache %s for temporary workspace %s", dir, w.root)
+	} else {
+		env["TF_PLUGIN_CACHE_DIR"] = w.pluginCacheDir()
+	}
+
+	opts.Dir = w.dir
+	opts.Env = env
+}
+
+// userPluginCacheDir returns the plugin cache directory that the user has configured for the command.
+func userPluginCacheDir(opts *CmdOptions) string {
+	if dir := opts.Env["TF_PLUGIN_CACHE_DIR"]; dir != "" {
+		return dir
+	}
+
+	if dir := os.Getenv("TF_PLUGIN_CACHE_DIR"); dir != "" {
+		return dir
+	}
+
+	cfgFile := opts.TerraformConfigFile
+	if cfgFile == "" {
+		cfgFile = cliConfigFilePath(opts.Dir)
+	}
+
+	if cfgFile == "" {
+		return ""
+	}
+
+	return cliConfigPluginCacheDir(cfgFile)
+}
+
+// remove deletes the workspace and everything that Terraform wrote to it.
+func (w *ephemeralWorkspace) remove() {
+	err := os.RemoveAll(w.root)
+	if err != nil {
+		log.Debugf("Error removing temporary workspace %s: %s", w.root, err)
+	}
+}
+
+// copyWorkspaceDir copies the files in src to dst, skipping the workspaceSkipDirs. Symlinks are
+// copied as symlinks.
+func copyWorkspaceDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir():
+			if path != src && workspaceSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+
+			return os.MkdirAll(target, os.ModePerm)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyWorkspaceFile(path, target)
+		}
+
+		return nil
+	})
+}
+
+func copyWorkspaceFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -0,0 +1,76 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralWorkspace(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".terraform", "providers"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "modules", "web"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`module "web" { source = "./modules/web" }`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".terraform.lock.hcl"), []byte(`# lock`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "modules", "web", "main.tf"), []byte(`resource "aws_instance" "web" {}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".terraform", "terraform.tfstate"), []byte(`{}`), 0600))
+
+	w, err := newEphemeralWorkspace(dir)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, dir, w.dir)
+	assert.FileExists(t, filepath.Join(w.dir, "main.tf"))
+	assert.FileExists(t, filepath.Join(w.dir, ".terraform.lock.hcl"))
+	assert.FileExists(t, filepath.Join(w.dir, "modules", "web", "main.tf"))
+	assert.NoDirExists(t, filepath.Join(w.dir, ".terraform"))
+
+	opts := &CmdOptions{Dir: dir, Env: map[string]string{"AWS_REGION": "us-east-1"}}
+	w.configure(opts)
+	assert.Equal(t, w.dir, opts.Dir)
+	assert.Equal(t, map[string]string{"AWS_REGION": "us-east-1", "TF_PLUGIN_CACHE_DIR": w.pluginCacheDir()}, opts.Env)
+	assert.DirExists(t, w.pluginCacheDir())
+
+	opts = &CmdOptions{Dir: dir, Env: map[string]string{"TF_PLUGIN_CACHE_DIR": "/tmp/plugins"}}
+	w.configure(opts)
+	assert.Equal(t, map[string]string{"TF_PLUGIN_CACHE_DIR": "/tmp/plugins"}, opts.Env)
+
+	cfgFile := filepath.Join(dir, "terraformrc")
+	require.NoError(t, os.WriteFile(cfgFile, []byte(`plugin_cache_dir = "/tmp/cli-plugins"`), 0600))
+	opts = &CmdOptions{Dir: dir, TerraformConfigFile: cfgFile}
+	w.configure(opts)
+	assert.NotContains(t, opts.Env, "TF_PLUGIN_CACHE_DIR")
+
+	w.remove()
+	assert.NoDirExists(t, w.root)
+	assert.FileExists(t, filepath.Join(dir, ".terraform", "terraform.tfstate"))
+}
+
+func TestCreateConfigFileProviderMirror(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "terraformrc")
+	require.NoError(t, os.WriteFile(cfgFile, []byte(`plugin_cache_dir = "/tmp/plugins"
+
+provider_installation {
+  direct {}
+}
+`), 0600))
+	t.Setenv("TF_CLI_CONFIG_FILE", cfgFile)
+
+	path, err := CreateConfigFile(dir, "", "", "https://mirror.example.com/providers/")
+	require.NoError(t, err)
+	defer os.Remove(path)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `plugin_cache_dir = "/tmp/plugins"`)
+	assert.Contains(t, string(b), `url = "https://mirror.example.com/providers/"`)
+	assert.NotContains(t, string(b), "direct")
+
+	path, err = CreateConfigFile(dir, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "", path)
+}
@@ -0,0 +1,82 @@
+package reportstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpclient"
+)
+
+// azureUploader puts blobs using the Blob Storage REST API, authenticated with a shared access
+// signature (SAS) token from the AZURE_STORAGE_SAS_TOKEN env var.
+type azureUploader struct {
+	endpoint  string
+	container string
+	sasToken  string
+	client    *http.Client
+}
+
+func newAzureUploader(r *config.ReportStorage) (*azureUploader, error) {
+	endpoint := r.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", r.Account)
+	}
+
+	sasToken := strings.TrimPrefix(os.Getenv("AZURE_STORAGE_SAS_TOKEN"), "?")
+	if sasToken == "" {
+		return nil, errors.New("AZURE_STORAGE_SAS_TOKEN must be set to upload reports to Azure Blob Storage")
+	}
+
+	return &azureUploader{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		container: r.Bucket,
+		sasToken:  sasToken,
+		client:    httpclient.NewClient(httpclient.PurposeReportStorage),
+	}, nil
+}
+
+func (u *azureUploader) Upload(ctx context.Context, key string, contentType string, body []byte) error {
+	url := fmt.Sprintf("%s?%s", u.Location(key), u.sasToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		// Don't include the URL in the error since it contains the SAS token.
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("Failed to upload %s: %w", u.Location(key), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Failed to upload %s: %s %s", u.Location(key), resp.Status, bytes.TrimSpace(respBody))
+	}
+
+	return nil
+}
+
+func (u *azureUploader) Location(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = neturl.PathEscape(s)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", u.endpoint, u.container, strings.Join(segments, "/"))
+}
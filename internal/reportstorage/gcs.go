@@ -0,0 +1,65 @@
+package reportstorage
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpclient"
+)
+
+type gcsUploader struct {
+	bucket string
+	client *storage.Client
+}
+
+func newGCSUploader(ctx context.Context, r *config.ReportStorage) (*gcsUploader, error) {
+	// The GCS client uses its own HTTP client for authentication, so check the network policy
+	// before it's created.
+	err := httpclient.CheckAllowed(httpclient.PurposeReportStorage, "gs://"+r.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []option.ClientOption
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		opts = append(opts, option.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})))
+	}
+	if r.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(r.Endpoint))
+	}
+
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create GCS client: %w", err)
+	}
+
+	return &gcsUploader{bucket: r.Bucket, client: client}, nil
+}
+
+func (u *gcsUploader) Upload(ctx context.Context, key string, contentType string, body []byte) error {
+	w := u.client.Bucket(u.bucket).Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+
+	_, err := w.Write(body)
+	if err != nil {
+		_ = w.Close()
+		return fmt.Errorf("Failed to upload %s: %w", u.Location(key), err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("Failed to upload %s: %w", u.Location(key), err)
+	}
+
+	return nil
+}
+
+func (u *gcsUploader) Location(key string) string {
+	return fmt.Sprintf("gs://%s/%s", u.bucket, key)
+}
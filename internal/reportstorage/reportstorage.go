@@ -0,0 +1,84 @@
+// Package reportstorage uploads reports to S3, GCS and Azure Blob Storage buckets, or S3 compatible
+// services like MinIO, so they are kept after each run without needing the Infracost Cloud dashboard.
+package reportstorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+// KeyData is the data that the key template of the report storage is executed with.
+type KeyData struct {
+	// Branch is the VCS branch that was run, e.g. main.
+	Branch string
+	// Commit is the VCS commit SHA that was run.
+	Commit string
+	// Format is the output format of the report, which is also used as the file extension.
+	Format string
+	// Timestamp is the time the run started.
+	Timestamp time.Time
+}
+
+// Uploader uploads a report to a bucket.
+type Uploader interface {
+	// Upload puts the body at the key, replacing any object that is already there.
+	Upload(ctx context.Context, key string, contentType string, body []byte) error
+	// Location returns a URL of the object at the key for showing to the user, e.g. s3://bucket/key.
+	Location(key string) string
+}
+
+// NewUploader returns the Uploader for the provider of the report storage.
+func NewUploader(ctx context.Context, r *config.ReportStorage) (Uploader, error) {
+	switch r.Provider {
+	case config.ReportStorageS3:
+		return newS3Uploader(ctx, r)
+	case config.ReportStorageGCS:
+		return newGCSUploader(ctx, r)
+	case config.ReportStorageAzure:
+		return newAzureUploader(r)
+	}
+
+	return nil, fmt.Errorf("Unsupported report storage provider %s", r.Provider)
+}
+
+// Key executes the key template with the data. Empty path segments, e.g. from the branch when it
+// couldn't be detected, are removed so the key doesn't contain a double slash.
+func Key(tmpl string, data KeyData) (string, error) {
+	t, err := template.New("key").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("Invalid report storage key: %w", err)
+	}
+
+	var buf bytes.Buffer
+	err = t.Execute(&buf, data)
+	if err != nil {
+		return "", fmt.Errorf("Invalid report storage key: %w", err)
+	}
+
+	var parts []string
+	for _, p := range strings.Split(buf.String(), "/") {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return strings.Join(parts, "/"), nil
+}
+
+// ContentType returns the content type of the reports in the output format.
+func ContentType(format string) string {
+	switch format {
+	case "json":
+		return "application/json"
+	case "html":
+		return "text/html; charset=utf-8"
+	}
+
+	return "application/octet-stream"
+}
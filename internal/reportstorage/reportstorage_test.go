@@ -0,0 +1,116 @@
+package reportstorage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func TestKey(t *testing.T) {
+	data := KeyData{
+		Branch:    "feature/cheaper-db",
+		Commit:    "3f2a1b",
+		Format:    "json",
+		Timestamp: time.Date(2022, 5, 4, 10, 0, 0, 0, time.UTC),
+	}
+
+	key, err := Key(config.DefaultReportStorageKey, data)
+	require.NoError(t, err)
+	assert.Equal(t, "infracost/feature/cheaper-db/3f2a1b/infracost.json", key)
+
+	key, err = Key(`reports/{{ .Timestamp.Format "2006-01-02" }}/{{ .Commit }}.{{ .Format }}`, data)
+	require.NoError(t, err)
+	assert.Equal(t, "reports/2022-05-04/3f2a1b.json", key)
+
+	// The branch and commit aren't known outside a git repo.
+	key, err = Key(config.DefaultReportStorageKey, KeyData{Format: "html"})
+	require.NoError(t, err)
+	assert.Equal(t, "infracost/infracost.html", key)
+
+	_, err = Key("{{ .Project }}", data)
+	assert.Error(t, err)
+}
+
+func TestS3Uploader(t *testing.T) {
+	var method, path, contentType, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, contentType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer srv.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "minio")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "minio123")
+
+	u, err := NewUploader(context.Background(), &config.ReportStorage{
+		Provider: config.ReportStorageS3,
+		Bucket:   "reports",
+		Endpoint: srv.URL,
+	})
+	require.NoError(t, err)
+
+	err = u.Upload(context.Background(), "main/abc/infracost.json", "application/json", []byte(`{"version":"0.2"}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/reports/main/abc/infracost.json", path)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, `{"version":"0.2"}`, body)
+	assert.Equal(t, "s3://reports/main/abc/infracost.json", u.Location("main/abc/infracost.json"))
+}
+
+func TestAzureUploader(t *testing.T) {
+	var path, query, blobType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query, blobType = r.URL.Path, r.URL.RawQuery, r.Header.Get("x-ms-blob-type")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	t.Setenv("AZURE_STORAGE_SAS_TOKEN", "?sv=2021-06-08&sig=secret")
+
+	u, err := NewUploader(context.Background(), &config.ReportStorage{
+		Provider: config.ReportStorageAzure,
+		Bucket:   "reports",
+		Endpoint: srv.URL,
+	})
+	require.NoError(t, err)
+
+	err = u.Upload(context.Background(), "main/infracost.html", "text/html; charset=utf-8", []byte("<html></html>"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "/reports/main/infracost.html", path)
+	assert.Equal(t, "sv=2021-06-08&sig=secret", query)
+	assert.Equal(t, "BlockBlob", blobType)
+}
+
+func TestAzureUploaderError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("AuthenticationFailed"))
+	}))
+	defer srv.Close()
+
+	t.Setenv("AZURE_STORAGE_SAS_TOKEN", "sig=secret")
+
+	u, err := NewUploader(context.Background(), &config.ReportStorage{
+		Provider: config.ReportStorageAzure,
+		Bucket:   "reports",
+		Endpoint: srv.URL,
+	})
+	require.NoError(t, err)
+
+	err = u.Upload(context.Background(), "infracost.json", "application/json", []byte("{}"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403 Forbidden AuthenticationFailed")
+	assert.NotContains(t, err.Error(), "secret")
+}
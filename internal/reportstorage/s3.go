@@ -0,0 +1,79 @@
+package reportstorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpclient"
+)
+
+// defaultS3CompatibleRegion is used for S3 compatible services when no region is set, since
+// the SDK needs one to sign requests and services like MinIO accept any region.
+const defaultS3CompatibleRegion = "us-east-1"
+
+type s3Uploader struct {
+	bucket string
+	client *s3.Client
+}
+
+func newS3Uploader(ctx context.Context, r *config.ReportStorage) (*s3Uploader, error) {
+	target := "s3://" + r.Bucket
+	if r.Endpoint != "" {
+		target = r.Endpoint
+	}
+
+	// The AWS SDK uses its own HTTP client, so check the network policy before any requests are made.
+	err := httpclient.CheckAllowed(httpclient.PurposeReportStorage, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []func(*awsconfig.LoadOptions) error
+
+	region := r.Region
+	if region == "" && r.Endpoint != "" {
+		region = defaultS3CompatibleRegion
+	}
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if r.Endpoint != "" {
+			// S3 compatible services don't usually support virtual hosted buckets.
+			o.EndpointResolver = s3.EndpointResolverFromURL(r.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	return &s3Uploader{bucket: r.Bucket, client: client}, nil
+}
+
+func (u *s3Uploader) Upload(ctx context.Context, key string, contentType string, body []byte) error {
+	_, err := u.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(u.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+		Body:        bytes.NewReader(body),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to upload %s: %w", u.Location(key), err)
+	}
+
+	return nil
+}
+
+func (u *s3Uploader) Location(key string) string {
+	return fmt.Sprintf("s3://%s/%s", u.bucket, key)
+}
@@ -0,0 +1,350 @@
+// Package resourceplugin runs external binaries that price resource types Infracost doesn't support,
+// e.g. the resources of in-house Terraform providers, without needing changes to Infracost.
+//
+// A plugin is started once per run and is sent requests on stdin, one JSON Request per line. It
+// writes one JSON Response per line to stdout for each of them, and should exit when stdin is closed.
+// Anything the plugin writes to stderr is logged at the debug level.
+//
+// Plugins can also be WASM modules, which are run in a sandbox with a single request on stdin and
+// write the response to stdout. They can't access the filesystem or network, so they're a safer
+// alternative for organizations that don't allow plugin binaries.
+package resourceplugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/wasmext"
+)
+
+// closeTimeout is how long a plugin has to exit after its stdin is closed before it's killed.
+var closeTimeout = 5 * time.Second
+
+// Plugin is a started plugin.
+type Plugin struct {
+	Name string
+
+	mu   sync.Mutex
+	conn conn
+}
+
+// conn sends requests to a plugin and returns its responses.
+type conn interface {
+	roundTrip(req []byte) ([]byte, error)
+	close() error
+}
+
+// Start starts the plugin of cfg. Plugins with a .wasm path are WASM modules that are run in a
+// sandbox for each request, see the wasmext package. Other plugins are binaries that are run once
+// and handle all the requests.
+func Start(cfg *config.ResourcePlugin) (*Plugin, error) {
+	var c conn
+	var err error
+
+	if IsWASM(cfg) {
+		c, err = startWASM(cfg)
+	} else {
+		c, err = startProcess(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Plugin{Name: cfg.Name, conn: c}, nil
+}
+
+// IsWASM returns true if the plugin of cfg is a WASM module.
+func IsWASM(cfg *config.ResourcePlugin) bool {
+	return strings.EqualFold(filepath.Ext(cfg.Path), ".wasm")
+}
+
+// Describe returns the resource types the plugin prices.
+func (p *Plugin) Describe() ([]string, error) {
+	resp, err := p.call(Request{Method: MethodDescribe})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.ResourceTypes, nil
+}
+
+// Resource returns the resource priced by the plugin for d and its usage u, which can be nil.
+func (p *Plugin) Resource(d *schema.ResourceData, u *schema.UsageData) (*schema.Resource, error) {
+	values := json.RawMessage(d.RawValues.Raw)
+	if len(values) == 0 {
+		values = json.RawMessage("{}")
+	}
+
+	usage := map[string]interface{}{}
+	if u != nil {
+		for k, v := range u.Attributes {
+			usage[k] = v.Value()
+		}
+	}
+
+	resp, err := p.call(Request{
+		Method: MethodResource,
+		Resource: &ResourceRequest{
+			Address:      d.Address,
+			Type:         d.Type,
+			ProviderName: d.ProviderName,
+			Values:       values,
+			Usage:        usage,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Resource == nil {
+		return nil, errors.New("response has no resource")
+	}
+
+	return resp.Resource.toSchema(d.Address)
+}
+
+// Close stops the plugin.
+func (p *Plugin) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.conn.close()
+}
+
+// call sends a request to the plugin and reads its response. Requests are sent one at a time since
+// the plugin handles them in order.
+func (p *Plugin) call(req Request) (*Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := p.conn.roundTrip(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error sending %s request to resource plugin %s", req.Method, p.Name)
+	}
+
+	var resp Response
+	err = json.Unmarshal(line, &resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid %s response from resource plugin %s", req.Method, p.Name)
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("Resource plugin %s returned an error: %s", p.Name, resp.Error)
+	}
+
+	return &resp, nil
+}
+
+// processConn is a plugin binary that's sent requests on stdin and writes responses to stdout.
+type processConn struct {
+	name   string
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	stderr *io.PipeWriter
+	done   chan error
+}
+
+func startProcess(cfg *config.ResourcePlugin) (*processConn, error) {
+	cmd := exec.Command(cfg.Path, cfg.Args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stderr := log.WithField("plugin", cfg.Name).WriterLevel(log.DebugLevel)
+	cmd.Stderr = stderr
+
+	err = cmd.Start()
+	if err != nil {
+		_ = stderr.Close()
+		return nil, errors.Wrapf(err, "Error starting resource plugin %s", cfg.Name)
+	}
+
+	c := &processConn{
+		name:   cfg.Name,
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewReader(stdout),
+		stderr: stderr,
+		done:   make(chan error, 1),
+	}
+
+	go func() {
+		c.done <- cmd.Wait()
+	}()
+
+	return c, nil
+}
+
+func (c *processConn) roundTrip(req []byte) ([]byte, error) {
+	_, err := c.stdin.Write(append(req, '\n'))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.stdout.ReadBytes('\n')
+}
+
+// close closes the plugin's stdin and waits for it to exit, killing it if it doesn't exit in time.
+func (c *processConn) close() error {
+	defer c.stderr.Close()
+
+	_ = c.stdin.Close()
+
+	select {
+	case err := <-c.done:
+		return err
+	case <-time.After(closeTimeout):
+		_ = c.cmd.Process.Kill()
+		<-c.done
+		return fmt.Errorf("Resource plugin %s didn't exit after %s and was killed", c.name, closeTimeout)
+	}
+}
+
+// wasmConn is a WASM module that's run with each request on stdin and writes the response to stdout.
+type wasmConn struct {
+	module *wasmext.Module
+}
+
+func startWASM(cfg *config.ResourcePlugin) (*wasmConn, error) {
+	m, err := wasmext.Load(context.Background(), cfg.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error starting resource plugin %s", cfg.Name)
+	}
+
+	return &wasmConn{module: m}, nil
+}
+
+func (c *wasmConn) roundTrip(req []byte) ([]byte, error) {
+	out, err := c.module.Run(context.Background(), append(req, '\n'))
+	if err != nil {
+		return nil, err
+	}
+
+	line, _, _ := bytes.Cut(out, []byte("\n"))
+	return line, nil
+}
+
+func (c *wasmConn) close() error {
+	return c.module.Close(context.Background())
+}
+
+func (r *Resource) toSchema(name string) (*schema.Resource, error) {
+	if r.NoPrice {
+		return &schema.Resource{
+			Name:        name,
+			IsSkipped:   true,
+			NoPrice:     true,
+			SkipMessage: "Free resource.",
+		}, nil
+	}
+
+	if r.SkipMessage != "" {
+		return &schema.Resource{
+			Name:        name,
+			IsSkipped:   true,
+			SkipMessage: r.SkipMessage,
+		}, nil
+	}
+
+	costComponents, err := toSchemaCostComponents(r.CostComponents)
+	if err != nil {
+		return nil, err
+	}
+
+	subResources, err := toSchemaSubResources(r.SubResources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.Resource{
+		Name:           name,
+		CostComponents: costComponents,
+		SubResources:   subResources,
+	}, nil
+}
+
+func toSchemaSubResources(l []*SubResource) ([]*schema.Resource, error) {
+	subResources := make([]*schema.Resource, 0, len(l))
+
+	for _, s := range l {
+		costComponents, err := toSchemaCostComponents(s.CostComponents)
+		if err != nil {
+			return nil, err
+		}
+
+		nested, err := toSchemaSubResources(s.SubResources)
+		if err != nil {
+			return nil, err
+		}
+
+		subResources = append(subResources, &schema.Resource{
+			Name:           s.Name,
+			CostComponents: costComponents,
+			SubResources:   nested,
+		})
+	}
+
+	return subResources, nil
+}
+
+func toSchemaCostComponents(l []*CostComponent) ([]*schema.CostComponent, error) {
+	costComponents := make([]*schema.CostComponent, 0, len(l))
+
+	for _, c := range l {
+		if c.Price == nil && c.ProductFilter == nil {
+			return nil, fmt.Errorf("cost component %q must have a price or a product filter", c.Name)
+		}
+
+		unitMultiplier := decimal.NewFromInt(1)
+		if c.UnitMultiplier != nil {
+			unitMultiplier = *c.UnitMultiplier
+		}
+
+		cc := &schema.CostComponent{
+			Name:                 c.Name,
+			Unit:                 c.Unit,
+			UnitMultiplier:       unitMultiplier,
+			HourlyQuantity:       c.HourlyQuantity,
+			MonthlyQuantity:      c.MonthlyQuantity,
+			ProductFilter:        c.ProductFilter,
+			PriceFilter:          c.PriceFilter,
+			IgnoreIfMissingPrice: c.IgnoreIfMissingPrice,
+		}
+
+		if c.Price != nil {
+			cc.SetCustomPrice(c.Price)
+		}
+
+		costComponents = append(costComponents, cc)
+	}
+
+	return costComponents, nil
+}
@@ -0,0 +1,226 @@
+package resourceplugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+// TestMain runs the test binary as a plugin when it's started by the tests, so they don't need a
+// separately built plugin binary.
+func TestMain(m *testing.M) {
+	if os.Getenv("INFRACOST_TEST_RESOURCE_PLUGIN") == "1" {
+		runTestPlugin()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// runTestPlugin prices acme_database resources at an internal rate of 0.5 per hour per node, plus a
+// storage cost from the pricing API.
+func runTestPlugin() {
+	scanner := bufio.NewScanner(os.Stdin)
+	out := json.NewEncoder(os.Stdout)
+
+	for scanner.Scan() {
+		var req Request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			_ = out.Encode(Response{Error: err.Error()})
+			continue
+		}
+
+		switch req.Method {
+		case MethodDescribe:
+			_ = out.Encode(Response{ResourceTypes: []string{"acme_database", "acme_database_user", "acme_queue"}})
+		case MethodResource:
+			_ = out.Encode(testPluginResource(req.Resource))
+		default:
+			_ = out.Encode(Response{Error: fmt.Sprintf("unknown method %s", req.Method)})
+		}
+	}
+}
+
+func testPluginResource(r *ResourceRequest) Response {
+	if r.Type == "acme_database_user" {
+		return Response{Resource: &Resource{NoPrice: true}}
+	}
+
+	if r.Type == "acme_queue" {
+		return Response{Error: "acme_queue isn't supported yet"}
+	}
+
+	nodes := gjson.GetBytes(r.Values, "nodes").Int()
+	storage := decimal.NewFromInt(100)
+	if v, ok := r.Usage["storage_gb"].(float64); ok {
+		storage = decimal.NewFromFloat(v)
+	}
+
+	return Response{Resource: &Resource{
+		CostComponents: []*CostComponent{
+			{
+				Name:           "Database nodes",
+				Unit:           "hours",
+				HourlyQuantity: decimalPtr(decimal.NewFromInt(nodes)),
+				Price:          decimalPtr(decimal.NewFromFloat(0.5)),
+			},
+		},
+		SubResources: []*SubResource{
+			{
+				Name: "Storage",
+				CostComponents: []*CostComponent{
+					{
+						Name:            "Storage",
+						Unit:            "GB",
+						MonthlyQuantity: &storage,
+						ProductFilter: &schema.ProductFilter{
+							VendorName: strPtr("aws"),
+							Service:    strPtr("AmazonEC2"),
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func startTestPlugin(t *testing.T) *Plugin {
+	t.Helper()
+
+	t.Setenv("INFRACOST_TEST_RESOURCE_PLUGIN", "1")
+
+	p, err := Start(&config.ResourcePlugin{Name: "acme", Path: os.Args[0]})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, p.Close())
+	})
+
+	return p
+}
+
+func TestPluginResource(t *testing.T) {
+	p := startTestPlugin(t)
+
+	resourceTypes, err := p.Describe()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme_database", "acme_database_user", "acme_queue"}, resourceTypes)
+
+	d := schema.NewResourceData("acme_database", "acme", "acme_database.main", nil, gjson.Parse(`{"nodes": 3}`))
+	u := schema.NewUsageData("acme_database.main", map[string]gjson.Result{"storage_gb": gjson.Parse("250")})
+
+	r, err := p.Resource(d, u)
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme_database.main", r.Name)
+	require.Len(t, r.CostComponents, 1)
+	assert.Equal(t, "Database nodes", r.CostComponents[0].Name)
+	assert.True(t, r.CostComponents[0].HourlyQuantity.Equal(decimal.NewFromInt(3)))
+	assert.True(t, r.CostComponents[0].CustomPrice().Equal(decimal.NewFromFloat(0.5)))
+	assert.Nil(t, r.CostComponents[0].ProductFilter)
+
+	require.Len(t, r.SubResources, 1)
+	storage := r.SubResources[0].CostComponents[0]
+	assert.True(t, storage.MonthlyQuantity.Equal(decimal.NewFromInt(250)))
+	assert.Nil(t, storage.CustomPrice())
+	assert.Equal(t, "AmazonEC2", *storage.ProductFilter.Service)
+}
+
+func TestPluginResourceFree(t *testing.T) {
+	p := startTestPlugin(t)
+
+	d := schema.NewResourceData("acme_database_user", "acme", "acme_database_user.admin", nil, gjson.Result{})
+
+	r, err := p.Resource(d, nil)
+	require.NoError(t, err)
+	assert.True(t, r.NoPrice)
+	assert.True(t, r.IsSkipped)
+}
+
+func TestPluginResourceError(t *testing.T) {
+	p := startTestPlugin(t)
+
+	d := schema.NewResourceData("acme_queue", "acme", "acme_queue.jobs", nil, gjson.Result{})
+
+	_, err := p.Resource(d, nil)
+	assert.EqualError(t, err, "Resource plugin acme returned an error: acme_queue isn't supported yet")
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("INFRACOST_TEST_RESOURCE_PLUGIN", "1")
+	t.Cleanup(Close)
+
+	err := Load(&config.Config{ResourcePlugins: []*config.ResourcePlugin{
+		{Name: "acme", Path: os.Args[0], ResourceTypes: []string{"acme_database", "acme_unknown"}},
+	}})
+	require.NoError(t, err)
+
+	registryMap := terraform.GetResourceRegistryMap()
+
+	item, ok := (*registryMap)["acme_database"]
+	require.True(t, ok)
+	assert.Equal(t, []string{"Priced by resource plugin acme."}, item.Notes)
+	assert.True(t, terraform.HasSupportedProvider("acme_database"))
+
+	// only the resource types listed in the config are registered.
+	_, ok = (*registryMap)["acme_database_user"]
+	assert.False(t, ok)
+	assert.False(t, terraform.HasSupportedProvider("acme_unknown"))
+
+	r := item.RFunc(schema.NewResourceData("acme_database", "acme", "acme_database.main", nil, gjson.Parse(`{"nodes": 2}`)), nil)
+	require.NotNil(t, r)
+	assert.True(t, r.CostComponents[0].HourlyQuantity.Equal(decimal.NewFromInt(2)))
+}
+
+func TestLoadInvalidPath(t *testing.T) {
+	err := Load(&config.Config{ResourcePlugins: []*config.ResourcePlugin{{Name: "missing", Path: "/does/not/exist"}}})
+	assert.ErrorContains(t, err, "Error starting resource plugin missing")
+}
+
+func TestWASMPlugin(t *testing.T) {
+	path := testutil.WASMModule(t, "testdata/wasm_plugin")
+
+	p, err := Start(&config.ResourcePlugin{Name: "acme-wasm", Path: path})
+	require.NoError(t, err)
+	defer p.Close()
+
+	resourceTypes, err := p.Describe()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme_cache"}, resourceTypes)
+
+	d := schema.NewResourceData("acme_cache", "acme", "acme_cache.sessions", nil, gjson.Parse(`{"memory_gb": 8}`))
+
+	r, err := p.Resource(d, nil)
+	require.NoError(t, err)
+	require.Len(t, r.CostComponents, 1)
+	assert.True(t, r.CostComponents[0].HourlyQuantity.Equal(decimal.NewFromInt(8)))
+	assert.True(t, r.CostComponents[0].CustomPrice().Equal(decimal.NewFromFloat(0.25)))
+}
+
+func TestLoadWASMPluginsOnly(t *testing.T) {
+	err := Load(&config.Config{
+		WASMPluginsOnly: true,
+		ResourcePlugins: []*config.ResourcePlugin{{Name: "acme", Path: os.Args[0]}},
+	})
+	assert.EqualError(t, err, "Resource plugin acme isn't a WASM module, only WASM modules are allowed since INFRACOST_WASM_PLUGINS_ONLY is set")
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,71 @@
+package resourceplugin
+
+import (
+	"encoding/json"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The methods of the requests sent to plugins.
+const (
+	// MethodDescribe asks the plugin for the resource types it prices. It's sent once when the
+	// plugin is started.
+	MethodDescribe = "describe"
+	// MethodResource asks the plugin for the cost components of a resource.
+	MethodResource = "resource"
+)
+
+// Request is a request sent to a plugin. Requests are written to the plugin's stdin as single lines of
+// JSON, and the plugin writes a single line of JSON to stdout with the Response to each of them.
+type Request struct {
+	Method   string           `json:"method"`
+	Resource *ResourceRequest `json:"resource,omitempty"`
+}
+
+// ResourceRequest is the resource that a plugin is asked to price.
+type ResourceRequest struct {
+	Address      string                 `json:"address"`
+	Type         string                 `json:"type"`
+	ProviderName string                 `json:"providerName"`
+	Values       json.RawMessage        `json:"values"`
+	Usage        map[string]interface{} `json:"usage"`
+}
+
+// Response is the response of a plugin to a request. Error is set if the plugin can't handle it.
+type Response struct {
+	Error         string    `json:"error,omitempty"`
+	ResourceTypes []string  `json:"resourceTypes,omitempty"`
+	Resource      *Resource `json:"resource,omitempty"`
+}
+
+// Resource is a resource priced by a plugin.
+type Resource struct {
+	NoPrice        bool             `json:"noPrice,omitempty"`
+	SkipMessage    string           `json:"skipMessage,omitempty"`
+	CostComponents []*CostComponent `json:"costComponents,omitempty"`
+	SubResources   []*SubResource   `json:"subResources,omitempty"`
+}
+
+// SubResource is a resource nested under a resource priced by a plugin.
+type SubResource struct {
+	Name           string           `json:"name"`
+	CostComponents []*CostComponent `json:"costComponents,omitempty"`
+	SubResources   []*SubResource   `json:"subResources,omitempty"`
+}
+
+// CostComponent is a cost component of a resource priced by a plugin. It's either priced with Price,
+// for prices that only the plugin knows such as internal rates, or with the Cloud Pricing API using
+// ProductFilter and PriceFilter.
+type CostComponent struct {
+	Name                 string                `json:"name"`
+	Unit                 string                `json:"unit"`
+	UnitMultiplier       *decimal.Decimal      `json:"unitMultiplier,omitempty"`
+	HourlyQuantity       *decimal.Decimal      `json:"hourlyQuantity,omitempty"`
+	MonthlyQuantity      *decimal.Decimal      `json:"monthlyQuantity,omitempty"`
+	Price                *decimal.Decimal      `json:"price,omitempty"`
+	ProductFilter        *schema.ProductFilter `json:"productFilter,omitempty"`
+	PriceFilter          *schema.PriceFilter   `json:"priceFilter,omitempty"`
+	IgnoreIfMissingPrice bool                  `json:"ignoreIfMissingPrice,omitempty"`
+}
@@ -0,0 +1,106 @@
+package resourceplugin
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+var (
+	runningMu sync.Mutex
+	running   []*Plugin
+)
+
+// Load starts the resource plugins of the config and adds the resource types they price to the
+// Terraform resource registry. The plugins keep running until Close is called.
+func Load(c *config.Config) error {
+	for _, cfg := range c.ResourcePlugins {
+		if c.WASMPluginsOnly && !IsWASM(cfg) {
+			return fmt.Errorf("Resource plugin %s isn't a WASM module, only WASM modules are allowed since INFRACOST_WASM_PLUGINS_ONLY is set", cfg.Name)
+		}
+
+		p, err := Start(cfg)
+		if err != nil {
+			return err
+		}
+
+		runningMu.Lock()
+		running = append(running, p)
+		runningMu.Unlock()
+
+		resourceTypes, err := p.Describe()
+		if err != nil {
+			return err
+		}
+
+		items := registryItems(p, filterResourceTypes(resourceTypes, cfg.ResourceTypes))
+		log.Debugf("Resource plugin %s prices %d resource types", p.Name, len(items))
+
+		terraform.RegisterResources(items)
+	}
+
+	return nil
+}
+
+// Close stops the plugins started by Load.
+func Close() {
+	runningMu.Lock()
+	defer runningMu.Unlock()
+
+	for _, p := range running {
+		if err := p.Close(); err != nil {
+			log.Debugf("Error closing resource plugin %s: %s", p.Name, err)
+		}
+	}
+
+	running = nil
+}
+
+// filterResourceTypes returns the resource types of the plugin that are allowed by the config. All
+// of them are allowed if the config doesn't list any.
+func filterResourceTypes(resourceTypes []string, allowed []string) []string {
+	if len(allowed) == 0 {
+		return resourceTypes
+	}
+
+	allowedMap := make(map[string]bool, len(allowed))
+	for _, t := range allowed {
+		allowedMap[t] = true
+	}
+
+	var filtered []string
+	for _, t := range resourceTypes {
+		if allowedMap[t] {
+			filtered = append(filtered, t)
+		}
+	}
+
+	return filtered
+}
+
+func registryItems(p *Plugin, resourceTypes []string) []*schema.RegistryItem {
+	items := make([]*schema.RegistryItem, 0, len(resourceTypes))
+
+	for _, t := range resourceTypes {
+		items = append(items, &schema.RegistryItem{
+			Name:  t,
+			Notes: []string{"Priced by resource plugin " + p.Name + "."},
+			RFunc: func(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+				r, err := p.Resource(d, u)
+				if err != nil {
+					log.Warnf("Error pricing %s with resource plugin %s: %s", d.Address, p.Name, err)
+					return nil
+				}
+
+				return r
+			},
+		})
+	}
+
+	return items
+}
@@ -0,0 +1,49 @@
+// wasm_plugin is a resource plugin that prices acme_cache resources at an internal rate of 0.25 per
+// hour per GB of memory.
+package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+type request struct {
+	Method   string `json:"method"`
+	Resource struct {
+		Values struct {
+			MemoryGB int `json:"memory_gb"`
+		} `json:"values"`
+	} `json:"resource"`
+}
+
+func main() {
+	var req request
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		writeResponse(map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	switch req.Method {
+	case "describe":
+		writeResponse(map[string]interface{}{"resourceTypes": []string{"acme_cache"}})
+	case "resource":
+		writeResponse(map[string]interface{}{
+			"resource": map[string]interface{}{
+				"costComponents": []map[string]interface{}{
+					{
+						"name":           "Cache memory",
+						"unit":           "GB-hours",
+						"hourlyQuantity": req.Resource.Values.MemoryGB,
+						"price":          "0.25",
+					},
+				},
+			},
+		})
+	default:
+		writeResponse(map[string]interface{}{"error": "unknown method " + req.Method})
+	}
+}
+
+func writeResponse(v interface{}) {
+	_ = json.NewEncoder(os.Stdout).Encode(v)
+}
@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+// AMI is an AMI that creates new EBS snapshots of its block devices, e.g. one copied
+// from another AMI or created from an instance.
+type AMI struct {
+	Address string
+	Region  string
+	// BlockDeviceSizesGB are the sizes of the source volumes that are snapshotted. A size of 0
+	// means the volume uses the default size.
+	BlockDeviceSizesGB []float64
+
+	// "usage" args
+	// StorageGB overrides the total size of the AMI's snapshots, e.g. when the source volumes
+	// aren't known.
+	StorageGB *float64 `infracost_usage:"storage_gb"`
+}
+
+var AMIUsageSchema = []*schema.UsageItem{
+	{Key: "storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+}
+
+func (r *AMI) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *AMI) BuildResource() *schema.Resource {
+	c := ebsSnapshotCostComponent(r.Region, decimal.Zero)
+	c.MonthlyQuantity = r.storageGB()
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    AMIUsageSchema,
+		CostComponents: []*schema.CostComponent{c},
+	}
+}
+
+func (r *AMI) storageGB() *decimal.Decimal {
+	if r.StorageGB != nil {
+		return decimalPtr(decimal.NewFromFloat(*r.StorageGB))
+	}
+
+	if len(r.BlockDeviceSizesGB) == 0 {
+		return nil
+	}
+
+	total := decimal.Zero
+	for _, size := range r.BlockDeviceSizesGB {
+		if size <= 0 {
+			size = float64(defaultVolumeSize)
+		}
+
+		total = total.Add(decimal.NewFromFloat(size))
+	}
+
+	return &total
+}
@@ -0,0 +1,25 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestAMISnapshotStorage(t *testing.T) {
+	t.Parallel()
+
+	r := resources.AMI{
+		Address:            "aws_ami_from_instance.web",
+		Region:             "us-east-1",
+		BlockDeviceSizesGB: []float64{0, 50},
+	}
+
+	resource := r.BuildResource()
+	require.Len(t, resource.CostComponents, 1)
+	require.NotNil(t, resource.CostComponents[0].MonthlyQuantity)
+	assert.Equal(t, "58", resource.CostComponents[0].MonthlyQuantity.String())
+}
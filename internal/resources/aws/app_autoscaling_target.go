@@ -3,6 +3,7 @@ package aws
 import (
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
 )
 
 type AppAutoscalingTarget struct {
@@ -15,6 +16,10 @@ type AppAutoscalingTarget struct {
 	MinCapacity int64
 	MaxCapacity int64
 
+	// TargetUtilization is the target value of a target tracking scaling policy for the
+	// target, as a percentage of the capacity. It's zero if there is no such policy.
+	TargetUtilization float64
+
 	// "usage" args
 	Capacity *int64 `infracost_usage:"capacity"`
 }
@@ -33,3 +38,22 @@ func (r *AppAutoscalingTarget) BuildResource() *schema.Resource {
 		UsageSchema: AppAutoscalingTargetUsageSchema,
 	}
 }
+
+// TargetTrackingCapacity returns the capacity that a target tracking scaling policy keeps
+// provisioned for the given consumed capacity, within the min and max capacity of the target.
+// It returns nil if the target has no target tracking policy.
+func (r *AppAutoscalingTarget) TargetTrackingCapacity(consumed decimal.Decimal) *int64 {
+	if r.TargetUtilization <= 0 {
+		return nil
+	}
+
+	capacity := consumed.Div(decimal.NewFromFloat(r.TargetUtilization / 100)).Ceil().IntPart()
+	if capacity < r.MinCapacity {
+		capacity = r.MinCapacity
+	}
+	if r.MaxCapacity > 0 && capacity > r.MaxCapacity {
+		capacity = r.MaxCapacity
+	}
+
+	return &capacity
+}
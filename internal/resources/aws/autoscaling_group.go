@@ -4,6 +4,8 @@ import (
 	"context"
 	"math"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage/aws"
@@ -18,6 +20,10 @@ type AutoscalingGroup struct {
 	// "optional" args, that may be empty depending on the resource config
 	LaunchConfiguration *LaunchConfiguration
 	LaunchTemplate      *LaunchTemplate
+	// ScheduledInstanceCount is the time-weighted average number of instances of the group when it has
+	// scheduled actions. The Launch Configuration or Launch Template have this number rounded up as their
+	// instance count, and their quantities are scaled down to the average.
+	ScheduledInstanceCount *decimal.Decimal
 }
 
 var AutoscalingGroupUsageSchema = append([]*schema.UsageItem{
@@ -27,6 +33,11 @@ var AutoscalingGroupUsageSchema = append([]*schema.UsageItem{
 func (a *AutoscalingGroup) PopulateUsage(u *schema.UsageData) {
 	resources.PopulateArgsWithUsage(a, u)
 
+	// The instances usage overrides the instance count of the schedules
+	if u != nil && u.Get("instances").Exists() {
+		a.ScheduledInstanceCount = nil
+	}
+
 	// The usage keys for Launch Template and Configuration are specified on the Autoscaling Group resource
 	if a.LaunchTemplate != nil {
 		resources.PopulateArgsWithUsage(a.LaunchTemplate, u)
@@ -42,13 +53,7 @@ func (a *AutoscalingGroup) PopulateUsage(u *schema.UsageData) {
 // costs for the node group to be $0.  This can be removed when --sync-usage-file creates the usage file with usgage keys
 // commented out by default.
 func (a *AutoscalingGroup) getUsageSchemaWithDefaultInstanceCount() []*schema.UsageItem {
-	var instanceCount *int64
-	if a.LaunchConfiguration != nil {
-		instanceCount = a.LaunchConfiguration.InstanceCount
-	} else if a.LaunchTemplate != nil {
-		instanceCount = a.LaunchTemplate.InstanceCount
-	}
-
+	instanceCount := a.instanceCount()
 	if instanceCount == nil || *instanceCount == 0 {
 		return AutoscalingGroupUsageSchema
 	}
@@ -64,6 +69,16 @@ func (a *AutoscalingGroup) getUsageSchemaWithDefaultInstanceCount() []*schema.Us
 	return usageSchema
 }
 
+func (a *AutoscalingGroup) instanceCount() *int64 {
+	if a.LaunchConfiguration != nil {
+		return a.LaunchConfiguration.InstanceCount
+	} else if a.LaunchTemplate != nil {
+		return a.LaunchTemplate.InstanceCount
+	}
+
+	return nil
+}
+
 func (a *AutoscalingGroup) BuildResource() *schema.Resource {
 	costComponents := make([]*schema.CostComponent, 0)
 	subResources := make([]*schema.Resource, 0)
@@ -87,6 +102,13 @@ func (a *AutoscalingGroup) BuildResource() *schema.Resource {
 		estimateInstanceQualities = lt.EstimateUsage
 	}
 
+	instanceCount := a.instanceCount()
+	if a.ScheduledInstanceCount != nil && instanceCount != nil && *instanceCount > 0 {
+		for _, s := range subResources {
+			schema.MultiplyQuantities(s, a.ScheduledInstanceCount.Div(decimal.NewFromInt(*instanceCount)))
+		}
+	}
+
 	estimate := func(ctx context.Context, u map[string]interface{}) error {
 		if estimateInstanceQualities != nil {
 			err := estimateInstanceQualities(ctx, u)
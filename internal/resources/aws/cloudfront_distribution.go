@@ -5,7 +5,6 @@ import (
 	"github.com/infracost/infracost/internal/schema"
 
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/shopspring/decimal"
@@ -288,55 +287,37 @@ func (r *CloudfrontDistribution) buildRegionSubresource(regionData *cloudfrontDi
 }
 
 func (r *CloudfrontDistribution) dataOutToInternetCostComponents(regionData *cloudfrontDistributionRegionData) []*schema.CostComponent {
-	tierStarts := []int{0, 10240, 51200, 153600, 512000, 1048576, 5242880}
-	tierLimits := []int{10240, 40960, 102400, 358400, 536576, 4194304}
+	tierStarts := []int64{0, 10240, 51200, 153600, 512000, 1048576, 5242880}
 	tierNames := []string{"first 10TB", "next 40TB", "next 100TB", "next 350TB", "next 524TB", "next 4PB", "over 5PB"}
 
-	fromLocation := regionData.priceRegion
-
 	var quantity *decimal.Decimal
 	if regionData.monthlyDataTransferToInternetGB != nil {
 		quantity = decimalPtr(decimal.NewFromFloat(*regionData.monthlyDataTransferToInternetGB))
 	}
 
-	if quantity == nil {
-		return []*schema.CostComponent{
-			r.buildDataOutCostComponent(tierNames[0], fromLocation, 0, nil),
-		}
-	}
-
-	tiers := usage.CalculateTierBuckets(*quantity, tierLimits)
-	var components []*schema.CostComponent
-	for i := range tiers {
-		if tiers[i].GreaterThan(decimal.Zero) {
-			components = append(
-				components,
-				r.buildDataOutCostComponent(tierNames[i], fromLocation, tierStarts[i], &tiers[i]),
-			)
-		}
+	tiers := make([]*schema.PriceTier, 0, len(tierStarts))
+	for i, start := range tierStarts {
+		tiers = append(tiers, &schema.PriceTier{
+			Name:             tierNames[i],
+			StartUsageAmount: decimal.NewFromInt(start),
+		})
 	}
 
-	return components
-}
-
-func (r *CloudfrontDistribution) buildDataOutCostComponent(usageName, fromLocation string, startUsage int, quantity *decimal.Decimal) *schema.CostComponent {
-	costName := "Data transfer out to internet"
-
-	return &schema.CostComponent{
-		Name:            fmt.Sprintf("%s (%s)", costName, usageName),
-		Unit:            "GB",
-		UnitMultiplier:  decimal.NewFromInt(1),
-		MonthlyQuantity: quantity,
-		ProductFilter: &schema.ProductFilter{
-			VendorName: strPtr("aws"),
-			Service:    strPtr("AmazonCloudFront"),
-			AttributeFilters: []*schema.AttributeFilter{
-				{Key: "transferType", Value: strPtr("CloudFront Outbound")},
-				{Key: "fromLocation", Value: strPtr(fromLocation)},
+	return []*schema.CostComponent{
+		{
+			Name:            "Data transfer out to internet",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: quantity,
+			ProductFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AmazonCloudFront"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "transferType", Value: strPtr("CloudFront Outbound")},
+					{Key: "fromLocation", Value: strPtr(regionData.priceRegion)},
+				},
 			},
-		},
-		PriceFilter: &schema.PriceFilter{
-			StartUsageAmount: strPtr(strconv.Itoa(startUsage)),
+			PriceTiers: tiers,
 		},
 	}
 }
@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"strings"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 
@@ -8,8 +10,13 @@ import (
 )
 
 type CloudwatchLogGroup struct {
-	Address               string
-	Region                string
+	Address string
+	Region  string
+	// RetentionInDays is the number of days log events are kept for. 0 means they never expire.
+	RetentionInDays int64
+	// LogGroupClass is the log class of the group, STANDARD or INFREQUENT_ACCESS.
+	LogGroupClass string
+
 	MonthlyDataIngestedGB *float64 `infracost_usage:"monthly_data_ingested_gb"`
 	StorageGB             *float64 `infracost_usage:"storage_gb"`
 	MonthlyDataScannedGB  *float64 `infracost_usage:"monthly_data_scanned_gb"`
@@ -36,6 +43,14 @@ func (r *CloudwatchLogGroup) BuildResource() *schema.Resource {
 
 	if r.StorageGB != nil {
 		gbDataStorage = decimalPtr(decimal.NewFromFloat(*r.StorageGB))
+	} else if gbDataIngestion != nil && r.RetentionInDays > 0 {
+		// Once the group is full the stored data is the data ingested over the retention period.
+		gbDataStorage = decimalPtr(gbDataIngestion.Div(decimal.NewFromInt(30)).Mul(decimal.NewFromInt(r.RetentionInDays)))
+	}
+
+	ingestionUsageType := "/-DataProcessing-Bytes/"
+	if strings.EqualFold(r.LogGroupClass, "INFREQUENT_ACCESS") {
+		ingestionUsageType = "/-DataProcessingIA-Bytes/"
 	}
 
 	if r.MonthlyDataScannedGB != nil {
@@ -56,7 +71,7 @@ func (r *CloudwatchLogGroup) BuildResource() *schema.Resource {
 					Service:       strPtr("AmazonCloudWatch"),
 					ProductFamily: strPtr("Data Payload"),
 					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "usagetype", ValueRegex: strPtr("/-DataProcessing-Bytes/")},
+						{Key: "usagetype", ValueRegex: strPtr(ingestionUsageType)},
 					},
 				},
 			},
@@ -0,0 +1,56 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestCloudwatchLogGroupRetentionStorage(t *testing.T) {
+	t.Parallel()
+
+	ingestedGB := 300.0
+	r := resources.CloudwatchLogGroup{
+		Address:               "aws_cloudwatch_log_group.logs",
+		Region:                "us-east-1",
+		RetentionInDays:       14,
+		MonthlyDataIngestedGB: &ingestedGB,
+	}
+
+	resource := r.BuildResource()
+	require.Len(t, resource.CostComponents, 3)
+	require.NotNil(t, resource.CostComponents[1].MonthlyQuantity)
+	assert.Equal(t, "140", resource.CostComponents[1].MonthlyQuantity.String())
+
+	storageGB := 50.0
+	r.StorageGB = &storageGB
+	resource = r.BuildResource()
+	assert.Equal(t, "50", resource.CostComponents[1].MonthlyQuantity.String())
+
+	r.StorageGB = nil
+	r.RetentionInDays = 0
+	resource = r.BuildResource()
+	assert.Nil(t, resource.CostComponents[1].MonthlyQuantity)
+}
+
+func TestCloudwatchLogMetricFilterMetrics(t *testing.T) {
+	t.Parallel()
+
+	r := resources.CloudwatchLogMetricFilter{
+		Address:               "aws_cloudwatch_log_metric_filter.errors",
+		Region:                "us-east-1",
+		MetricTransformations: 1,
+	}
+
+	resource := r.BuildResource()
+	require.Len(t, resource.CostComponents, 1)
+	assert.Equal(t, "1", resource.CostComponents[0].MonthlyQuantity.String())
+
+	metrics := int64(12)
+	r.Metrics = &metrics
+	resource = r.BuildResource()
+	assert.Equal(t, "12", resource.CostComponents[0].MonthlyQuantity.String())
+}
@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+// CloudwatchLogMetricFilter publishes a custom metric for each of its metric transformations.
+type CloudwatchLogMetricFilter struct {
+	Address               string
+	Region                string
+	MetricTransformations int64
+
+	// "usage" args
+	// Metrics overrides the number of custom metrics, e.g. when the transformations have dimensions
+	// that publish a metric for each combination of dimension values.
+	Metrics *int64 `infracost_usage:"metrics"`
+}
+
+var CloudwatchLogMetricFilterUsageSchema = []*schema.UsageItem{
+	{Key: "metrics", ValueType: schema.Int64, DefaultValue: 0},
+}
+
+func (r *CloudwatchLogMetricFilter) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *CloudwatchLogMetricFilter) BuildResource() *schema.Resource {
+	metrics := decimal.NewFromInt(r.MetricTransformations)
+	if r.Metrics != nil {
+		metrics = decimal.NewFromInt(*r.Metrics)
+	}
+
+	return &schema.Resource{
+		Name: r.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Custom metrics",
+				Unit:            "metrics",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(metrics),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("aws"),
+					Region:        strPtr(r.Region),
+					Service:       strPtr("AmazonCloudWatch"),
+					ProductFamily: strPtr("Metric"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "usagetype", ValueRegex: strPtr("/CW:MetricMonitorUsage/")},
+					},
+				},
+				PriceTiers: []*schema.PriceTier{
+					{Name: "first 10k", StartUsageAmount: decimal.Zero},
+					{Name: "next 240k", StartUsageAmount: decimal.NewFromInt(10000)},
+					{Name: "next 750k", StartUsageAmount: decimal.NewFromInt(250000)},
+					{Name: "over 1M", StartUsageAmount: decimal.NewFromInt(1000000)},
+				},
+			},
+		},
+		UsageSchema: CloudwatchLogMetricFilterUsageSchema,
+	}
+}
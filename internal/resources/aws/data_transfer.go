@@ -8,7 +8,6 @@ import (
 
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
-	"github.com/infracost/infracost/internal/usage"
 )
 
 // DataTransfer represents data transferred "in" to and "out" of Amazon EC2.
@@ -87,7 +86,7 @@ func (r *DataTransfer) intraRegionCostComponents() []*schema.CostComponent {
 	return costComponents
 }
 
-// intraRegionCostComponents returns a cost component for outbound data
+// outboundInternetCostComponents returns a cost component for outbound data
 // transfer to the Internet only when its usage is specified.
 // China regions are calculated without tiers.
 func (r *DataTransfer) outboundInternetCostComponents() []*schema.CostComponent {
@@ -97,87 +96,34 @@ func (r *DataTransfer) outboundInternetCostComponents() []*schema.CostComponent
 		return costComponents
 	}
 
-	outboundInternetGb := decimalPtr(decimal.NewFromFloat(*r.MonthlyOutboundInternetGB))
-	networkUsage := outboundInternetGb.IntPart()
-
-	if r.Region == "cn-north-1" || r.Region == "cn-northwest-1" {
-		costComponents = append(costComponents, r.buildOutboundInternetCostComponent(
-			"Outbound data transfer to Internet",
-			decimal.NewFromInt(networkUsage),
-			"Inf",
-		))
-		return costComponents
-	}
-
-	type dataTransferRegionUsageFilterData struct {
-		usageName      string
-		tierCapacity   int64
-		endUsageNumber int64
-	}
-
-	usageFiltersData := []*dataTransferRegionUsageFilterData{
-		{
-			usageName:      "first 10TB",
-			tierCapacity:   10240,
-			endUsageNumber: 10240,
-		},
-		{
-			usageName:      "next 40TB",
-			tierCapacity:   40960,
-			endUsageNumber: 51200,
-		},
-		{
-			usageName:      "next 100TB",
-			tierCapacity:   102400,
-			endUsageNumber: 153600,
-		},
-		{
-			usageName:      "over 150TB",
-			tierCapacity:   0,
-			endUsageNumber: 0,
+	component := &schema.CostComponent{
+		Name:            "Outbound data transfer to Internet",
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromFloat(*r.MonthlyOutboundInternetGB)),
+		ProductFilter:   r.buildProductFilter("AWS Outbound", nil, ""),
+		PriceFilter: &schema.PriceFilter{
+			EndUsageAmount: strPtr("Inf"),
 		},
 	}
 
-	tierLimits := make([]int, len(usageFiltersData)-1)
-	for i, usageFilter := range usageFiltersData {
-		if usageFilter.tierCapacity > 0 {
-			tierLimits[i] = int(usageFilter.tierCapacity)
-		}
-	}
-
-	tiers := usage.CalculateTierBuckets(decimal.NewFromInt(networkUsage), tierLimits)
-	for i, usageFilter := range usageFiltersData {
-		quantity := tiers[i]
-
-		if quantity.Equals(decimal.Zero) {
-			break
-		}
-
-		filter := fmt.Sprint(usageFilter.endUsageNumber)
-		if usageFilter.endUsageNumber == 0 {
-			filter = "Inf"
+	if r.Region != "cn-north-1" && r.Region != "cn-northwest-1" {
+		// The prices of the tiers are looked up by their end usage amount.
+		component.PriceTiers = []*schema.PriceTier{
+			outboundInternetPriceTier("first 10TB", 0, "10240"),
+			outboundInternetPriceTier("next 40TB", 10240, "51200"),
+			outboundInternetPriceTier("next 100TB", 51200, "153600"),
+			outboundInternetPriceTier("over 150TB", 153600, "Inf"),
 		}
-
-		name := fmt.Sprintf("Outbound data transfer to Internet (%s)", usageFilter.usageName)
-		costComponents = append(costComponents, r.buildOutboundInternetCostComponent(
-			name,
-			quantity,
-			filter,
-		))
 	}
 
-	return costComponents
+	return append(costComponents, component)
 }
 
-// buildOutboundInternetCostComponent builds a cost component for
-// outbound data transfer to Internet.
-func (r *DataTransfer) buildOutboundInternetCostComponent(name string, networkUsage decimal.Decimal, endUsageAmount string) *schema.CostComponent {
-	return &schema.CostComponent{
-		Name:            name,
-		Unit:            "GB",
-		UnitMultiplier:  decimal.NewFromInt(1),
-		MonthlyQuantity: decimalPtr(networkUsage),
-		ProductFilter:   r.buildProductFilter("AWS Outbound", nil, ""),
+func outboundInternetPriceTier(name string, start int64, endUsageAmount string) *schema.PriceTier {
+	return &schema.PriceTier{
+		Name:             name,
+		StartUsageAmount: decimal.NewFromInt(start),
 		PriceFilter: &schema.PriceFilter{
 			EndUsageAmount: strPtr(endUsageAmount),
 		},
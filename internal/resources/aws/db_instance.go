@@ -22,16 +22,25 @@ type DBInstance struct {
 	InstanceClass                                string
 	Engine                                       string
 	IOPS                                         float64
+	StorageThroughput                            float64
 	AllocatedStorageGB                           *float64
+	MaxAllocatedStorageGB                        float64
+	ReplicationSourceRegion                      string
 	MonthlyStandardIORequests                    *int64   `infracost_usage:"monthly_standard_io_requests"`
 	AdditionalBackupStorageGB                    *float64 `infracost_usage:"additional_backup_storage_gb"`
+	TotalBackupStorageGB                         *float64 `infracost_usage:"total_backup_storage_gb"`
 	MonthlyAdditionalPerformanceInsightsRequests *int64   `infracost_usage:"monthly_additional_performance_insights_requests"`
+	StorageGB                                    *float64 `infracost_usage:"storage_gb"`
+	MonthlyReplicationDataGB                     *float64 `infracost_usage:"monthly_replication_data_gb"`
 }
 
 var DBInstanceUsageSchema = []*schema.UsageItem{
 	{Key: "monthly_standard_io_requests", ValueType: schema.Int64, DefaultValue: 0},
 	{Key: "additional_backup_storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+	{Key: "total_backup_storage_gb", ValueType: schema.Float64, DefaultValue: 0},
 	{Key: "monthly_additional_performance_insights_requests", ValueType: schema.Int64, DefaultValue: 0},
+	{Key: "storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+	{Key: "monthly_replication_data_gb", ValueType: schema.Float64, DefaultValue: 0},
 }
 
 func (r *DBInstance) PopulateUsage(u *schema.UsageData) {
@@ -99,21 +108,41 @@ func (r *DBInstance) BuildResource() *schema.Resource {
 		allocatedStorageVal = decimal.NewFromFloat(*r.AllocatedStorageGB)
 	}
 
+	// With storage autoscaling the storage can grow up to the max allocated storage, so
+	// use the storage from the usage file if there is one.
+	if r.MaxAllocatedStorageGB > 0 && r.StorageGB != nil {
+		allocatedStorageVal = decimal.Min(
+			decimal.Max(decimal.NewFromFloat(*r.StorageGB), allocatedStorageVal),
+			decimal.NewFromFloat(r.MaxAllocatedStorageGB),
+		)
+	}
+
 	volumeType := "General Purpose"
 	storageName := "Storage (general purpose SSD, gp2)"
+	storageType := strings.ToLower(r.StorageType)
 
-	if strings.ToLower(r.StorageType) == "io1" || iopsVal.GreaterThan(decimal.Zero) {
+	switch {
+	case storageType == "gp3":
+		volumeType = "General Purpose-GP3"
+		storageName = "Storage (general purpose SSD, gp3)"
+	case storageType == "io2":
+		volumeType = "Provisioned IOPS-IO2"
+		storageName = "Storage (provisioned IOPS SSD, io2)"
+	case storageType == "io1" || iopsVal.GreaterThan(decimal.Zero):
 		volumeType = "Provisioned IOPS"
 		storageName = "Storage (provisioned IOPS SSD, io1)"
+	case storageType == "standard":
+		volumeType = "Magnetic"
+		storageName = "Storage (magnetic)"
+	}
+
+	if strings.HasPrefix(volumeType, "Provisioned IOPS") {
 		if iopsVal.LessThan(decimal.NewFromInt(1000)) {
 			iopsVal = decimal.NewFromInt(1000)
 		}
 		if allocatedStorageVal.LessThan(decimal.NewFromInt(100)) {
 			allocatedStorageVal = decimal.NewFromInt(100)
 		}
-	} else if strings.ToLower(r.StorageType) == "standard" {
-		volumeType = "Magnetic"
-		storageName = "Storage (magnetic)"
 	}
 
 	instanceAttributeFilters := []*schema.AttributeFilter{
@@ -188,6 +217,30 @@ func (r *DBInstance) BuildResource() *schema.Resource {
 		})
 	}
 
+	if volumeType == "General Purpose-GP3" {
+		costComponents = append(costComponents, r.gp3CostComponents(deploymentOption, databaseEngine, allocatedStorageVal)...)
+	}
+
+	if volumeType == "Provisioned IOPS-IO2" {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Provisioned IOPS (io2)",
+			Unit:            "IOPS",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &iopsVal,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AmazonRDS"),
+				ProductFamily: strPtr("Provisioned IOPS"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+					{Key: "databaseEngine", Value: strPtr("Any")},
+					{Key: "usagetype", ValueRegex: regexPtr("PIOPS-IO2$")},
+				},
+			},
+		})
+	}
+
 	if strings.ToLower(volumeType) == "provisioned iops" {
 		costComponents = append(costComponents, &schema.CostComponent{
 			Name:            "Provisioned IOPS",
@@ -210,6 +263,9 @@ func (r *DBInstance) BuildResource() *schema.Resource {
 	var backupStorageGB *decimal.Decimal
 	if r.AdditionalBackupStorageGB != nil {
 		backupStorageGB = decimalPtr(decimal.NewFromFloat(*r.AdditionalBackupStorageGB))
+	} else if r.TotalBackupStorageGB != nil {
+		// Backup storage up to 100% of the database storage is free.
+		backupStorageGB = decimalPtr(decimal.Max(decimal.Zero, decimal.NewFromFloat(*r.TotalBackupStorageGB).Sub(allocatedStorageVal)))
 	}
 
 	if r.BackupRetentionPeriod > 0 || (backupStorageGB != nil && backupStorageGB.GreaterThan(decimal.Zero)) {
@@ -256,6 +312,10 @@ func (r *DBInstance) BuildResource() *schema.Resource {
 		}
 	}
 
+	if r.ReplicationSourceRegion != "" && r.ReplicationSourceRegion != r.Region {
+		costComponents = append(costComponents, r.crossRegionReplicationCostComponent())
+	}
+
 	return &schema.Resource{
 		Name:           r.Address,
 		CostComponents: costComponents,
@@ -263,6 +323,87 @@ func (r *DBInstance) BuildResource() *schema.Resource {
 	}
 }
 
+// gp3CostComponents returns the cost components for the IOPS and throughput provisioned above the
+// baseline that gp3 storage includes. The baseline is higher for larger volumes.
+func (r *DBInstance) gp3CostComponents(deploymentOption, databaseEngine string, storageGB decimal.Decimal) []*schema.CostComponent {
+	threshold := decimal.NewFromInt(400)
+	if databaseEngine == "Oracle" || databaseEngine == "SQL Server" {
+		threshold = decimal.NewFromInt(200)
+	}
+
+	baselineIOPS := decimal.NewFromInt(3000)
+	baselineThroughput := decimal.NewFromInt(125)
+	if storageGB.GreaterThanOrEqual(threshold) && databaseEngine != "SQL Server" {
+		baselineIOPS = decimal.NewFromInt(12000)
+		baselineThroughput = decimal.NewFromInt(500)
+	}
+
+	var costComponents []*schema.CostComponent
+
+	iops := decimal.NewFromFloat(r.IOPS)
+	if iops.GreaterThan(baselineIOPS) {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Provisioned IOPS (gp3, above baseline)",
+			Unit:            "IOPS",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(iops.Sub(baselineIOPS)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AmazonRDS"),
+				ProductFamily: strPtr("Provisioned IOPS"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+					{Key: "usagetype", ValueRegex: regexPtr("GP3-PIOPS$")},
+				},
+			},
+		})
+	}
+
+	throughput := decimal.NewFromFloat(r.StorageThroughput)
+	if throughput.GreaterThan(baselineThroughput) {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Provisioned throughput (gp3, above baseline)",
+			Unit:            "MiBps",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(throughput.Sub(baselineThroughput)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AmazonRDS"),
+				ProductFamily: strPtr("Provisioned Throughput"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+					{Key: "usagetype", ValueRegex: regexPtr("GP3-PThroughput$")},
+				},
+			},
+		})
+	}
+
+	return costComponents
+}
+
+// crossRegionReplicationCostComponent returns the cost component for the data transferred from
+// the source database to this read replica in another region.
+func (r *DBInstance) crossRegionReplicationCostComponent() *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            fmt.Sprintf("Cross-region replication data transfer (from %s)", r.ReplicationSourceRegion),
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: floatPtrToDecimalPtr(r.MonthlyReplicationDataGB),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Service:       strPtr("AWSDataTransfer"),
+			ProductFamily: strPtr("Data Transfer"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "transferType", Value: strPtr("InterRegion Outbound")},
+				{Key: "fromLocation", Value: strPtr(RegionMapping[r.ReplicationSourceRegion])},
+				{Key: "toLocation", Value: strPtr(RegionMapping[r.Region])},
+			},
+		},
+	}
+}
+
 func performanceInsightsLongTermRetentionCostComponent(region, instanceClass string) *schema.CostComponent {
 	instanceType := strings.TrimPrefix(instanceClass, "db.")
 
@@ -0,0 +1,90 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func findCostComponent(t *testing.T, r *schema.Resource, name string) *schema.CostComponent {
+	t.Helper()
+
+	for _, c := range r.CostComponents {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	require.Failf(t, "cost component not found", "%s has no cost component %q", r.Name, name)
+	return nil
+}
+
+func TestDBInstanceGP3(t *testing.T) {
+	t.Parallel()
+
+	storageGB := 500.0
+	r := resources.DBInstance{
+		Address:               "aws_db_instance.db",
+		Region:                "us-east-1",
+		Engine:                "postgres",
+		InstanceClass:         "db.m5.large",
+		StorageType:           "gp3",
+		IOPS:                  15000,
+		StorageThroughput:     600,
+		AllocatedStorageGB:    &storageGB,
+		MaxAllocatedStorageGB: 1000,
+	}
+
+	resource := r.BuildResource()
+	assert.Equal(t, "500", findCostComponent(t, resource, "Storage (general purpose SSD, gp3)").MonthlyQuantity.String())
+	assert.Equal(t, "3000", findCostComponent(t, resource, "Provisioned IOPS (gp3, above baseline)").MonthlyQuantity.String())
+	assert.Equal(t, "100", findCostComponent(t, resource, "Provisioned throughput (gp3, above baseline)").MonthlyQuantity.String())
+
+	autoscaledGB := 2000.0
+	r.StorageGB = &autoscaledGB
+	resource = r.BuildResource()
+	assert.Equal(t, "1000", findCostComponent(t, resource, "Storage (general purpose SSD, gp3)").MonthlyQuantity.String())
+}
+
+func TestDBInstanceBackupStorageBeyondFreeAllocation(t *testing.T) {
+	t.Parallel()
+
+	storageGB := 100.0
+	totalBackupGB := 250.0
+	r := resources.DBInstance{
+		Address:               "aws_db_instance.db",
+		Region:                "us-east-1",
+		Engine:                "mysql",
+		InstanceClass:         "db.t3.micro",
+		AllocatedStorageGB:    &storageGB,
+		BackupRetentionPeriod: 7,
+		TotalBackupStorageGB:  &totalBackupGB,
+	}
+
+	resource := r.BuildResource()
+	assert.Equal(t, "150", findCostComponent(t, resource, "Additional backup storage").MonthlyQuantity.String())
+}
+
+func TestDBInstanceCrossRegionReplica(t *testing.T) {
+	t.Parallel()
+
+	r := resources.DBInstance{
+		Address:                 "aws_db_instance.replica",
+		Region:                  "us-west-2",
+		Engine:                  "mysql",
+		InstanceClass:           "db.t3.micro",
+		ReplicationSourceRegion: "us-east-1",
+	}
+
+	c := findCostComponent(t, r.BuildResource(), "Cross-region replication data transfer (from us-east-1)")
+	assert.Nil(t, c.MonthlyQuantity)
+
+	r.ReplicationSourceRegion = "us-west-2"
+	for _, c := range r.BuildResource().CostComponents {
+		assert.NotContains(t, c.Name, "Cross-region")
+	}
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+// DLMLifecyclePolicy is a Data Lifecycle Manager policy that creates EBS snapshots or AMIs on
+// a schedule. The snapshots are incremental, so the storage is a full copy of the volumes plus
+// the changed data of each other retained snapshot.
+type DLMLifecyclePolicy struct {
+	Address string
+	Region  string
+	// RetainedSnapshots is the number of snapshots of each volume that the policy keeps, summed
+	// over the policy's schedules.
+	RetainedSnapshots int64
+
+	// "usage" args
+	VolumeStorageGB    *float64 `infracost_usage:"volume_storage_gb"`
+	SnapshotChangeRate *float64 `infracost_usage:"snapshot_change_rate"`
+}
+
+var DLMLifecyclePolicyUsageSchema = []*schema.UsageItem{
+	{Key: "volume_storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+	{Key: "snapshot_change_rate", ValueType: schema.Float64, DefaultValue: 0},
+}
+
+func (r *DLMLifecyclePolicy) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *DLMLifecyclePolicy) BuildResource() *schema.Resource {
+	c := ebsSnapshotCostComponent(r.Region, decimal.Zero)
+	c.MonthlyQuantity = r.snapshotStorageGB()
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    DLMLifecyclePolicyUsageSchema,
+		CostComponents: []*schema.CostComponent{c},
+	}
+}
+
+func (r *DLMLifecyclePolicy) snapshotStorageGB() *decimal.Decimal {
+	if r.VolumeStorageGB == nil || r.RetainedSnapshots == 0 {
+		return nil
+	}
+
+	volumeGB := decimal.NewFromFloat(*r.VolumeStorageGB)
+
+	changeRate := decimal.Zero
+	if r.SnapshotChangeRate != nil {
+		changeRate = decimal.NewFromFloat(*r.SnapshotChangeRate)
+	}
+
+	incrementalGB := volumeGB.Mul(changeRate).Mul(decimal.NewFromInt(r.RetainedSnapshots - 1))
+
+	return decimalPtr(volumeGB.Add(incrementalGB))
+}
@@ -0,0 +1,33 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestDLMLifecyclePolicySnapshotStorage(t *testing.T) {
+	t.Parallel()
+
+	volumeStorageGB := 100.0
+	changeRate := 0.05
+	r := resources.DLMLifecyclePolicy{
+		Address:            "aws_dlm_lifecycle_policy.daily",
+		Region:             "us-east-1",
+		RetainedSnapshots:  14,
+		VolumeStorageGB:    &volumeStorageGB,
+		SnapshotChangeRate: &changeRate,
+	}
+
+	resource := r.BuildResource()
+	require.Len(t, resource.CostComponents, 1)
+	require.NotNil(t, resource.CostComponents[0].MonthlyQuantity)
+	assert.Equal(t, "165", resource.CostComponents[0].MonthlyQuantity.String())
+
+	r.VolumeStorageGB = nil
+	resource = r.BuildResource()
+	assert.Nil(t, resource.CostComponents[0].MonthlyQuantity)
+}
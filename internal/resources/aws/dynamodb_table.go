@@ -53,6 +53,8 @@ func (a *DynamoDBTable) BuildResource() *schema.Resource {
 	costComponents := make([]*schema.CostComponent, 0)
 	subResources := make([]*schema.Resource, 0)
 
+	replicaWCU := a.WriteCapacity
+
 	if a.BillingMode == "PROVISIONED" {
 		var wcuAutoscaling, rcuAutoscaling bool
 		wcu := a.WriteCapacity
@@ -62,24 +64,19 @@ func (a *DynamoDBTable) BuildResource() *schema.Resource {
 			switch target.ScalableDimension {
 			case "dynamodb:table:WriteCapacityUnits":
 				wcuAutoscaling = true
-				if target.Capacity != nil {
-					wcu = target.Capacity
-				} else {
-					wcu = &target.MinCapacity
-				}
+				wcu = a.autoscaledCapacity(target, a.MonthlyWriteRequestUnits)
 			case "dynamodb:table:ReadCapacityUnits":
 				rcuAutoscaling = true
-				if target.Capacity != nil {
-					rcu = target.Capacity
-				} else {
-					rcu = &target.MinCapacity
-				}
+				rcu = a.autoscaledCapacity(target, a.MonthlyReadRequestUnits)
 			}
 		}
 		// Write capacity units (WCU)
 		costComponents = append(costComponents, a.wcuCostComponent(a.Region, wcu, wcuAutoscaling))
 		// Read capacity units (RCU)
 		costComponents = append(costComponents, a.rcuCostComponent(a.Region, rcu, rcuAutoscaling))
+
+		// Global table replicas are provisioned with the same write capacity as the table
+		replicaWCU = wcu
 	}
 
 	// Infracost usage data
@@ -104,7 +101,7 @@ func (a *DynamoDBTable) BuildResource() *schema.Resource {
 	costComponents = append(costComponents, a.streamCostComponent(a.Region, a.MonthlyStreamsReadRequestUnits))
 
 	// Global tables (replica)
-	subResources = append(subResources, a.globalTables(a.BillingMode, a.ReplicaRegions, a.WriteCapacity, a.MonthlyWriteRequestUnits)...)
+	subResources = append(subResources, a.globalTables(a.BillingMode, a.ReplicaRegions, replicaWCU, a.MonthlyWriteRequestUnits)...)
 
 	estimate := func(ctx context.Context, values map[string]interface{}) error {
 		storageB, err := aws.DynamoDBGetStorageBytes(ctx, a.Region, a.Name)
@@ -137,6 +134,25 @@ func (a *DynamoDBTable) BuildResource() *schema.Resource {
 	}
 }
 
+// autoscaledCapacity returns the capacity units provisioned by an autoscaling target. This is the
+// capacity from the usage file if it's set. Otherwise if the target has a target tracking policy
+// the capacity is estimated from the monthly consumed request units, falling back to the min
+// capacity of the target.
+func (a *DynamoDBTable) autoscaledCapacity(target *AppAutoscalingTarget, monthlyConsumedUnits *int64) *int64 {
+	if target.Capacity != nil {
+		return target.Capacity
+	}
+
+	if monthlyConsumedUnits != nil {
+		consumedPerSec := decimal.NewFromInt(*monthlyConsumedUnits).Div(decimal.NewFromInt(730 * 60 * 60))
+		if capacity := target.TargetTrackingCapacity(consumedPerSec); capacity != nil {
+			return capacity
+		}
+	}
+
+	return &target.MinCapacity
+}
+
 func (a *DynamoDBTable) wcuCostComponent(region string, provisionedWCU *int64, autoscaling bool) *schema.CostComponent {
 	name := "Write capacity unit (WCU)"
 	if autoscaling {
@@ -204,10 +220,17 @@ func (a *DynamoDBTable) globalTables(billingMode string, replicaRegions []string
 
 	for _, region := range replicaRegions {
 		name := fmt.Sprintf("Global table (%s)", region)
+		var replica *schema.Resource
 		if billingMode == "PROVISIONED" {
-			resources = append(resources, a.newProvisionedDynamoDBGlobalTable(name, region, writeCapacity))
+			replica = a.newProvisionedDynamoDBGlobalTable(name, region, writeCapacity)
 		} else if billingMode == "PAY_PER_REQUEST" {
-			resources = append(resources, a.newOnDemandDynamoDBGlobalTable(name, region, monthlyWRU))
+			replica = a.newOnDemandDynamoDBGlobalTable(name, region, monthlyWRU)
+		}
+
+		if replica != nil {
+			// Each replica stores a full copy of the table data
+			replica.CostComponents = append(replica.CostComponents, a.dataStorageCostComponent(region, a.StorageGB))
+			resources = append(resources, replica)
 		}
 	}
 
@@ -0,0 +1,67 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestDynamoDBTableTargetTrackingCapacity(t *testing.T) {
+	t.Parallel()
+
+	// 100 consumed WCU and 20 consumed RCU on average
+	monthlyWRU := int64(100 * 730 * 60 * 60)
+	monthlyRRU := int64(20 * 730 * 60 * 60)
+
+	r := resources.DynamoDBTable{
+		Address:                  "aws_dynamodb_table.table",
+		Region:                   "us-east-1",
+		BillingMode:              "PROVISIONED",
+		ReplicaRegions:           []string{"us-west-2"},
+		MonthlyWriteRequestUnits: &monthlyWRU,
+		MonthlyReadRequestUnits:  &monthlyRRU,
+		AppAutoscalingTarget: []*resources.AppAutoscalingTarget{
+			{
+				ScalableDimension: "dynamodb:table:WriteCapacityUnits",
+				MinCapacity:       5,
+				MaxCapacity:       1000,
+				TargetUtilization: 70,
+			},
+			{
+				ScalableDimension: "dynamodb:table:ReadCapacityUnits",
+				MinCapacity:       50,
+				MaxCapacity:       100,
+				TargetUtilization: 70,
+			},
+		},
+	}
+
+	resource := r.BuildResource()
+	assert.Equal(t, "143", resource.CostComponents[0].HourlyQuantity.String())
+	assert.Equal(t, "50", resource.CostComponents[1].HourlyQuantity.String())
+
+	require.Len(t, resource.SubResources, 1)
+	assert.Equal(t, "143", resource.SubResources[0].CostComponents[0].HourlyQuantity.String())
+	assert.Equal(t, "Data storage", resource.SubResources[0].CostComponents[1].Name)
+}
+
+func TestDynamoDBTableAutoscalingWithoutTargetTracking(t *testing.T) {
+	t.Parallel()
+
+	monthlyWRU := int64(100 * 730 * 60 * 60)
+	r := resources.DynamoDBTable{
+		Address:                  "aws_dynamodb_table.table",
+		Region:                   "us-east-1",
+		BillingMode:              "PROVISIONED",
+		MonthlyWriteRequestUnits: &monthlyWRU,
+		AppAutoscalingTarget: []*resources.AppAutoscalingTarget{
+			{ScalableDimension: "dynamodb:table:WriteCapacityUnits", MinCapacity: 5, MaxCapacity: 1000},
+		},
+	}
+
+	resource := r.BuildResource()
+	assert.Equal(t, "5", resource.CostComponents[0].HourlyQuantity.String())
+}
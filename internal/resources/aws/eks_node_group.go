@@ -26,13 +26,14 @@ type EKSNodeGroup struct {
 	LaunchTemplate  *LaunchTemplate
 
 	// "usage" args
-	InstanceCount                 *int64  `infracost_usage:"instances"`
-	OperatingSystem               *string `infracost_usage:"operating_system"`
-	ReservedInstanceType          *string `infracost_usage:"reserved_instance_type"`
-	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
-	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
-	MonthlyCPUCreditHours         *int64  `infracost_usage:"monthly_cpu_credit_hrs"`
-	VCPUCount                     *int64  `infracost_usage:"vcpu_count"`
+	InstanceCount                 *int64   `infracost_usage:"instances"`
+	OperatingSystem               *string  `infracost_usage:"operating_system"`
+	ReservedInstanceType          *string  `infracost_usage:"reserved_instance_type"`
+	ReservedInstanceTerm          *string  `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string  `infracost_usage:"reserved_instance_payment_option"`
+	MonthlyCPUCreditHours         *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
+	VCPUCount                     *int64   `infracost_usage:"vcpu_count"`
+	AverageCPUUtilizationPercent  *float64 `infracost_usage:"average_cpu_utilization_percent"`
 }
 
 var EKSNodeGroupUsageSchema = append([]*schema.UsageItem{
@@ -99,6 +100,7 @@ func (a *EKSNodeGroup) BuildResource() *schema.Resource {
 			ReservedInstancePaymentOption: a.ReservedInstancePaymentOption,
 			MonthlyCPUCreditHours:         a.MonthlyCPUCreditHours,
 			VCPUCount:                     a.VCPUCount,
+			AverageCPUUtilizationPercent:  a.AverageCPUUtilizationPercent,
 		}
 
 		instance.RootBlockDevice = &EBSVolume{
@@ -32,8 +32,8 @@ func (r *GlueCatalogDatabase) PopulateUsage(u *schema.UsageData) {
 // BuildResource builds a schema.Resource from a valid GlueCatalogDatabase struct. GlueCatalogDatabase has the following
 // schema.CostComponents associated with it:
 //
-//		1. Storage - charged for every 100,000 objects stored above 1M, per month.
-//		2. Requests - charged per million requests above 1M in a month.
+//  1. Storage - charged for every 100,000 objects stored above 1M, per month.
+//  2. Requests - charged per million requests above 1M in a month.
 //
 // This method is called after the resource is initialised by an IaC provider. See providers folder for more information.
 func (r *GlueCatalogDatabase) BuildResource() *schema.Resource {
@@ -33,12 +33,13 @@ type Instance struct {
 	EBSBlockDevices                 []*EBSVolume
 
 	// "usage" args
-	OperatingSystem               *string `infracost_usage:"operating_system"`
-	ReservedInstanceType          *string `infracost_usage:"reserved_instance_type"`
-	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
-	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
-	MonthlyCPUCreditHours         *int64  `infracost_usage:"monthly_cpu_credit_hrs"`
-	VCPUCount                     *int64  `infracost_usage:"vcpu_count"`
+	OperatingSystem               *string  `infracost_usage:"operating_system"`
+	ReservedInstanceType          *string  `infracost_usage:"reserved_instance_type"`
+	ReservedInstanceTerm          *string  `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string  `infracost_usage:"reserved_instance_payment_option"`
+	MonthlyCPUCreditHours         *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
+	VCPUCount                     *int64   `infracost_usage:"vcpu_count"`
+	AverageCPUUtilizationPercent  *float64 `infracost_usage:"average_cpu_utilization_percent"`
 }
 
 var InstanceUsageSchema = []*schema.UsageItem{
@@ -48,6 +49,7 @@ var InstanceUsageSchema = []*schema.UsageItem{
 	{Key: "reserved_instance_payment_option", DefaultValue: "", ValueType: schema.String},
 	{Key: "monthly_cpu_credit_hrs", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "vcpu_count", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "average_cpu_utilization_percent", DefaultValue: 0, ValueType: schema.Float64},
 }
 
 func (a *Instance) PopulateUsage(u *schema.UsageData) {
@@ -64,7 +66,7 @@ func (a *Instance) BuildResource() *schema.Resource {
 		a.Tenancy = "Shared"
 	}
 
-	if a.CPUCredits == "" && (strings.HasPrefix(a.InstanceType, "t3.") || strings.HasPrefix(a.InstanceType, "t4g.")) {
+	if a.CPUCredits == "" && (strings.HasPrefix(a.InstanceType, "t3.") || strings.HasPrefix(a.InstanceType, "t3a.") || strings.HasPrefix(a.InstanceType, "t4g.")) {
 		a.CPUCredits = "unlimited"
 	}
 
@@ -126,6 +128,8 @@ func (a *Instance) BuildResource() *schema.Resource {
 		CostComponents: costComponents,
 		SubResources:   subResources,
 		EstimateUsage:  estimate,
+		ImageID:        a.AMI,
+		InstanceCount:  decimalPtr(decimal.NewFromInt(1)),
 	}
 }
 
@@ -306,9 +310,12 @@ func (a *Instance) elasticInferenceAcceleratorCostComponent() *schema.CostCompon
 }
 
 func (a *Instance) cpuCreditCostComponent(instanceFamily string) *schema.CostComponent {
-	qty := decimal.Zero
-	if a.MonthlyCPUCreditHours != nil && a.VCPUCount != nil {
-		qty = decimal.NewFromInt(*a.MonthlyCPUCreditHours).Mul(decimal.NewFromInt(*a.VCPUCount))
+	qty := a.cpuCreditVCPUHours()
+
+	// Surplus credits cost more for Windows instances, and the same as Linux for the other operating systems.
+	osFilterVal := "Linux"
+	if strVal(a.OperatingSystem) == "windows" {
+		osFilterVal = "Windows"
 	}
 
 	return &schema.CostComponent{
@@ -322,9 +329,56 @@ func (a *Instance) cpuCreditCostComponent(instanceFamily string) *schema.CostCom
 			Service:       strPtr("AmazonEC2"),
 			ProductFamily: strPtr("CPU Credits"),
 			AttributeFilters: []*schema.AttributeFilter{
-				{Key: "operatingSystem", Value: strPtr("Linux")},
+				{Key: "operatingSystem", Value: strPtr(osFilterVal)},
 				{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/CPUCredits:%s$/", instanceFamily))},
 			},
 		},
 	}
 }
+
+// cpuCreditVCPUHours returns the surplus CPU credits used by an instance in unlimited mode, in
+// vCPU-hours. If the average CPU utilization is set only the utilization above the baseline of the
+// instance type is charged for, over the monthly_cpu_credit_hrs or the whole month if that isn't set,
+// and none is charged for if the baseline of the instance type isn't known. Otherwise each vCPU is
+// charged for all of the monthly_cpu_credit_hrs. The vCPU count defaults to the count of the instance
+// type.
+func (a *Instance) cpuCreditVCPUHours() decimal.Decimal {
+	vcpuCount := a.VCPUCount
+	if vcpuCount == nil {
+		if count, ok := InstanceTypeToVCPU[a.InstanceType]; ok {
+			vcpuCount = &count
+		}
+	}
+
+	if vcpuCount == nil {
+		return decimal.Zero
+	}
+	vcpus := decimal.NewFromInt(*vcpuCount)
+
+	if a.AverageCPUUtilizationPercent != nil {
+		hours := schema.HourToMonthUnitMultiplier
+		if a.MonthlyCPUCreditHours != nil {
+			hours = decimal.NewFromInt(*a.MonthlyCPUCreditHours)
+		}
+
+		// Without the baseline of the instance type the surplus can't be worked out, so none is charged
+		// for rather than charging for all of the utilization.
+		baseline, ok := burstableBaselineCPUPercent[a.InstanceType]
+		if !ok {
+			return decimal.Zero
+		}
+
+		surplus := decimal.NewFromFloat(*a.AverageCPUUtilizationPercent - baseline).Div(decimal.NewFromInt(100))
+		if !surplus.IsPositive() {
+			return decimal.Zero
+		}
+
+		return surplus.Mul(vcpus).Mul(hours)
+	}
+
+	if a.MonthlyCPUCreditHours != nil {
+		return decimal.NewFromInt(*a.MonthlyCPUCreditHours).Mul(vcpus)
+	}
+
+	return decimal.Zero
+}
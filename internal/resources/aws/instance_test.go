@@ -0,0 +1,81 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestInstanceCPUCredits(t *testing.T) {
+	t.Parallel()
+
+	int64Ptr := func(i int64) *int64 { return &i }
+	float64Ptr := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name         string
+		instanceType string
+		creditHours  *int64
+		vcpuCount    *int64
+		utilization  *float64
+		expected     string
+	}{
+		{name: "no usage", instanceType: "t3.medium", expected: "0"},
+		{name: "credit hours with vCPU count", instanceType: "t3.medium", creditHours: int64Ptr(100), vcpuCount: int64Ptr(4), expected: "400"},
+		{name: "credit hours default to the vCPUs of the instance type", instanceType: "t3.medium", creditHours: int64Ptr(100), expected: "200"},
+		{name: "utilization above the baseline for the whole month", instanceType: "t3.medium", utilization: float64Ptr(60), expected: "584"},
+		{name: "utilization above the baseline for the credit hours", instanceType: "t3a.large", utilization: float64Ptr(80), creditHours: int64Ptr(100), expected: "100"},
+		{name: "utilization below the baseline", instanceType: "t4g.xlarge", utilization: float64Ptr(35), expected: "0"},
+		{name: "utilization without a known baseline", instanceType: "t3.16xlarge", vcpuCount: int64Ptr(64), utilization: float64Ptr(60), expected: "0"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := resources.Instance{
+				Address:                      "aws_instance.web",
+				Region:                       "us-east-1",
+				InstanceType:                 tt.instanceType,
+				MonthlyCPUCreditHours:        tt.creditHours,
+				VCPUCount:                    tt.vcpuCount,
+				AverageCPUUtilizationPercent: tt.utilization,
+			}
+
+			resource := r.BuildResource()
+			assert.Equal(t, tt.expected, findCostComponent(t, resource, "CPU credits").MonthlyQuantity.String())
+		})
+	}
+}
+
+func TestInstanceCPUCreditsWindows(t *testing.T) {
+	t.Parallel()
+
+	os := "windows"
+	r := resources.Instance{
+		Address:         "aws_instance.web",
+		Region:          "us-east-1",
+		InstanceType:    "t3.medium",
+		OperatingSystem: &os,
+	}
+
+	c := findCostComponent(t, r.BuildResource(), "CPU credits")
+	assert.Equal(t, "Windows", *c.ProductFilter.AttributeFilters[0].Value)
+}
+
+func TestInstanceCPUCreditsStandardMode(t *testing.T) {
+	t.Parallel()
+
+	r := resources.Instance{
+		Address:      "aws_instance.web",
+		Region:       "us-east-1",
+		InstanceType: "t2.medium",
+	}
+
+	for _, c := range r.BuildResource().CostComponents {
+		assert.NotEqual(t, "CPU credits", c.Name)
+	}
+}
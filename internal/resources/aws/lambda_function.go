@@ -2,7 +2,9 @@ package aws
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"strings"
 
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
@@ -12,20 +14,35 @@ import (
 )
 
 type LambdaFunction struct {
-	Address    string
-	Region     string
-	Name       string
-	MemorySize int64
+	Address      string
+	Region       string
+	Name         string
+	MemorySize   int64
+	Architecture string
+	Runtime      string
+	// EphemeralStorageSize is the size of the function's /tmp directory in MB.
+	EphemeralStorageSize int64
+	// ProvisionedConcurrency is the total provisioned concurrency of the function's versions and
+	// aliases from aws_lambda_provisioned_concurrency_config resources.
+	ProvisionedConcurrency int64
+	SnapStartEnabled       bool
 
-	RequestDurationMS *int64 `infracost_usage:"request_duration_ms"`
-	MonthlyRequests   *int64 `infracost_usage:"monthly_requests"`
+	RequestDurationMS          *int64   `infracost_usage:"request_duration_ms"`
+	MonthlyRequests            *int64   `infracost_usage:"monthly_requests"`
+	ProvisionedRequestsPercent *float64 `infracost_usage:"provisioned_requests_percent"`
+	MonthlySnapStartRestores   *int64   `infracost_usage:"monthly_snapstart_restores"`
 }
 
 var LambdaFunctionUsageSchema = []*schema.UsageItem{
 	{Key: "request_duration_ms", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "monthly_requests", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "provisioned_requests_percent", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "monthly_snapstart_restores", DefaultValue: 0, ValueType: schema.Int64},
 }
 
+// lambdaFreeEphemeralStorageMB is the ephemeral storage that every function gets for free.
+const lambdaFreeEphemeralStorageMB = 512
+
 func (a *LambdaFunction) PopulateUsage(u *schema.UsageData) {
 	resources.PopulateArgsWithUsage(a, u)
 }
@@ -40,10 +57,25 @@ func (a *LambdaFunction) BuildResource() *schema.Resource {
 
 	var monthlyRequests *decimal.Decimal
 	var gbSeconds *decimal.Decimal
+	var provisionedGBSeconds *decimal.Decimal
+	var storageGBSeconds *decimal.Decimal
+
+	extraStorage := decimal.NewFromInt(a.EphemeralStorageSize - lambdaFreeEphemeralStorageMB)
 
 	if a.MonthlyRequests != nil {
 		monthlyRequests = decimalPtr(decimal.NewFromInt(*a.MonthlyRequests))
-		gbSeconds = decimalPtr(calculateGBSeconds(memorySize, averageRequestDuration, *monthlyRequests))
+
+		// Requests that are served by provisioned concurrency are charged at a lower duration rate,
+		// requests over the provisioned concurrency are charged at the on-demand rate.
+		provisionedRequests := a.provisionedRequests(*monthlyRequests, averageRequestDuration)
+		gbSeconds = decimalPtr(calculateGBSeconds(memorySize, averageRequestDuration, monthlyRequests.Sub(provisionedRequests)))
+		if a.ProvisionedConcurrency > 0 {
+			provisionedGBSeconds = decimalPtr(calculateGBSeconds(memorySize, averageRequestDuration, provisionedRequests))
+		}
+
+		if extraStorage.IsPositive() {
+			storageGBSeconds = decimalPtr(calculateGBSeconds(extraStorage, averageRequestDuration, *monthlyRequests))
+		}
 	}
 
 	estimate := func(ctx context.Context, values map[string]interface{}) error {
@@ -60,47 +92,213 @@ func (a *LambdaFunction) BuildResource() *schema.Resource {
 		return nil
 	}
 
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            a.withArchitecture("Requests"),
+			Unit:            "1M requests",
+			UnitMultiplier:  decimal.NewFromInt(1000000),
+			MonthlyQuantity: monthlyRequests,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AWSLambda"),
+				ProductFamily: strPtr("Serverless"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "group", Value: strPtr(a.armGroup("AWS-Lambda-Requests"))},
+					{Key: "usagetype", ValueRegex: a.usageTypeRegex("Request")},
+				},
+			},
+		},
+		{
+			Name:            a.withArchitecture("Duration"),
+			Unit:            "GB-seconds",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: gbSeconds,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AWSLambda"),
+				ProductFamily: strPtr("Serverless"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "group", Value: strPtr(a.armGroup("AWS-Lambda-Duration"))},
+					{Key: "usagetype", ValueRegex: a.usageTypeRegex("GB-Second")},
+				},
+			},
+		},
+	}
+
+	if a.ProvisionedConcurrency > 0 {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            a.withArchitecture("Duration (provisioned concurrency)"),
+			Unit:            "GB-seconds",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: provisionedGBSeconds,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AWSLambda"),
+				ProductFamily: strPtr("Serverless"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "group", Value: strPtr(a.armGroup("AWS-Lambda-Duration-Provisioned"))},
+					{Key: "usagetype", ValueRegex: a.usageTypeRegex("Provisioned-GB-Second")},
+				},
+			},
+		})
+	}
+
+	if extraStorage.IsPositive() {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            a.withArchitecture("Ephemeral storage"),
+			Unit:            "GB-seconds",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: storageGBSeconds,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AWSLambda"),
+				ProductFamily: strPtr("Serverless"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "group", Value: strPtr(a.armGroup("AWS-Lambda-Storage-Duration"))},
+					{Key: "usagetype", ValueRegex: a.usageTypeRegex("Lambda-Storage-GB-Second")},
+				},
+			},
+		})
+	}
+
+	if a.ProvisionedConcurrency > 0 {
+		// Provisioned concurrency is charged for the memory of every provisioned environment while
+		// it's enabled, whether it serves requests or not.
+		gb := memorySize.Div(decimal.NewFromInt(1024)).Mul(decimal.NewFromInt(a.ProvisionedConcurrency))
+		seconds := schema.HourToMonthUnitMultiplier.Mul(decimal.NewFromInt(60 * 60))
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            a.withArchitecture("Provisioned concurrency"),
+			Unit:            "GB-seconds",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(gb.Mul(seconds)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AWSLambda"),
+				ProductFamily: strPtr("Serverless"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "group", Value: strPtr(a.armGroup("AWS-Lambda-Provisioned-Concurrency"))},
+					{Key: "usagetype", ValueRegex: a.usageTypeRegex("Lambda-Provisioned-Concurrency")},
+				},
+			},
+		})
+	}
+
+	// SnapStart is free for Java functions, other runtimes pay to cache and restore snapshots.
+	if a.SnapStartEnabled && !strings.HasPrefix(a.Runtime, "java") {
+		costComponents = append(costComponents, a.snapStartCostComponents(memorySize)...)
+	}
+
 	return &schema.Resource{
-		Name:        a.Address,
-		UsageSchema: LambdaFunctionUsageSchema,
-		CostComponents: []*schema.CostComponent{
-			{
-				Name:            "Requests",
-				Unit:            "1M requests",
-				UnitMultiplier:  decimal.NewFromInt(1000000),
-				MonthlyQuantity: monthlyRequests,
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(a.Region),
-					Service:       strPtr("AWSLambda"),
-					ProductFamily: strPtr("Serverless"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "group", Value: strPtr("AWS-Lambda-Requests")},
-						{Key: "usagetype", ValueRegex: strPtr("/Request/")},
-					},
+		Name:           a.Address,
+		UsageSchema:    LambdaFunctionUsageSchema,
+		CostComponents: costComponents,
+		EstimateUsage:  estimate,
+	}
+}
+
+// provisionedRequests returns how many of the monthly requests are served by provisioned concurrency.
+// This is provisioned_requests_percent of the requests if it's set, otherwise it's assumed that the
+// provisioned environments serve as many requests as they can in the month at the average duration.
+func (a *LambdaFunction) provisionedRequests(monthlyRequests decimal.Decimal, averageRequestDuration decimal.Decimal) decimal.Decimal {
+	if a.ProvisionedConcurrency <= 0 {
+		return decimal.Zero
+	}
+
+	if a.ProvisionedRequestsPercent != nil {
+		percent := decimal.NewFromFloat(*a.ProvisionedRequestsPercent)
+		percent = decimal.Max(decimal.Zero, decimal.Min(percent, decimal.NewFromInt(100)))
+
+		return monthlyRequests.Mul(percent).Div(decimal.NewFromInt(100))
+	}
+
+	if !averageRequestDuration.IsPositive() {
+		return monthlyRequests
+	}
+
+	monthlySeconds := schema.HourToMonthUnitMultiplier.Mul(decimal.NewFromInt(60 * 60))
+	capacity := decimal.NewFromInt(a.ProvisionedConcurrency).Mul(monthlySeconds).Mul(decimal.NewFromInt(1000)).Div(averageRequestDuration.Ceil()).Floor()
+
+	return decimal.Min(monthlyRequests, capacity)
+}
+
+func (a *LambdaFunction) snapStartCostComponents(memorySize decimal.Decimal) []*schema.CostComponent {
+	gb := memorySize.Div(decimal.NewFromInt(1024))
+
+	var restoredGB *decimal.Decimal
+	if a.MonthlySnapStartRestores != nil {
+		restoredGB = decimalPtr(gb.Mul(decimal.NewFromInt(*a.MonthlySnapStartRestores)))
+	}
+
+	return []*schema.CostComponent{
+		{
+			Name:            "SnapStart cache",
+			Unit:            "GB-seconds",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(gb.Mul(schema.HourToMonthUnitMultiplier).Mul(decimal.NewFromInt(60 * 60))),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AWSLambda"),
+				ProductFamily: strPtr("Serverless"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: regexPtr("Lambda-SnapStart-Cached-GB-S$")},
 				},
 			},
-			{
-				Name:            "Duration",
-				Unit:            "GB-seconds",
-				UnitMultiplier:  decimal.NewFromInt(1),
-				MonthlyQuantity: gbSeconds,
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(a.Region),
-					Service:       strPtr("AWSLambda"),
-					ProductFamily: strPtr("Serverless"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "group", Value: strPtr("AWS-Lambda-Duration")},
-						{Key: "usagetype", ValueRegex: strPtr("/GB-Second/")},
-					},
+		},
+		{
+			Name:            "SnapStart restore",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: restoredGB,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AWSLambda"),
+				ProductFamily: strPtr("Serverless"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: regexPtr("Lambda-SnapStart-Restored-GB$")},
 				},
 			},
 		},
-		EstimateUsage: estimate,
 	}
 }
 
+func (a *LambdaFunction) isARM() bool {
+	return a.Architecture == "arm64"
+}
+
+func (a *LambdaFunction) withArchitecture(name string) string {
+	if a.isARM() {
+		return fmt.Sprintf("%s (ARM)", name)
+	}
+
+	return name
+}
+
+func (a *LambdaFunction) armGroup(group string) string {
+	if a.isARM() {
+		return group + "-ARM"
+	}
+
+	return group
+}
+
+// usageTypeRegex returns the usage type regex for x86 or ARM prices. The x86 regex is kept loose
+// since its usage types don't have a common suffix, the ARM usage types all end in -ARM.
+func (a *LambdaFunction) usageTypeRegex(usageType string) *string {
+	if a.isARM() {
+		return regexPtr(usageType + "-ARM$")
+	}
+
+	return strPtr(fmt.Sprintf("/%s/", usageType))
+}
+
 func calculateGBSeconds(memorySize decimal.Decimal, averageRequestDuration decimal.Decimal, monthlyRequests decimal.Decimal) decimal.Decimal {
 	gb := memorySize.Div(decimal.NewFromInt(1024))
 	seconds := averageRequestDuration.Ceil().Div(decimal.NewFromInt(1000)) // Round up to closest 1ms and convert to seconds
@@ -0,0 +1,43 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestLambdaFunctionProvisionedConcurrencyDuration(t *testing.T) {
+	t.Parallel()
+
+	monthlyRequests := int64(5000000)
+	durationMS := int64(1000)
+	r := resources.LambdaFunction{
+		Address:                "aws_lambda_function.fn",
+		Region:                 "us-east-1",
+		MemorySize:             1024,
+		EphemeralStorageSize:   512,
+		ProvisionedConcurrency: 1,
+		MonthlyRequests:        &monthlyRequests,
+		RequestDurationMS:      &durationMS,
+	}
+
+	// One provisioned environment serves 730 * 60 * 60 one second requests a month, the rest are on-demand.
+	resource := r.BuildResource()
+	assert.Equal(t, "2628000", findCostComponent(t, resource, "Duration (provisioned concurrency)").MonthlyQuantity.String())
+	assert.Equal(t, "2372000", findCostComponent(t, resource, "Duration").MonthlyQuantity.String())
+
+	percent := 60.0
+	r.ProvisionedRequestsPercent = &percent
+	resource = r.BuildResource()
+	assert.Equal(t, "3000000", findCostComponent(t, resource, "Duration (provisioned concurrency)").MonthlyQuantity.String())
+	assert.Equal(t, "2000000", findCostComponent(t, resource, "Duration").MonthlyQuantity.String())
+
+	r.ProvisionedConcurrency = 0
+	resource = r.BuildResource()
+	assert.Equal(t, "5000000", findCostComponent(t, resource, "Duration").MonthlyQuantity.String())
+	for _, c := range resource.CostComponents {
+		assert.NotEqual(t, "Duration (provisioned concurrency)", c.Name)
+	}
+}
@@ -28,13 +28,14 @@ type LaunchConfiguration struct {
 
 	// "usage" args
 	// These are populated from the Autoscaling Group resource
-	InstanceCount                 *int64  `infracost_usage:"instances"`
-	OperatingSystem               *string `infracost_usage:"operating_system"`
-	ReservedInstanceType          *string `infracost_usage:"reserved_instance_type"`
-	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
-	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
-	MonthlyCPUCreditHours         *int64  `infracost_usage:"monthly_cpu_credit_hrs"`
-	VCPUCount                     *int64  `infracost_usage:"vcpu_count"`
+	InstanceCount                 *int64   `infracost_usage:"instances"`
+	OperatingSystem               *string  `infracost_usage:"operating_system"`
+	ReservedInstanceType          *string  `infracost_usage:"reserved_instance_type"`
+	ReservedInstanceTerm          *string  `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string  `infracost_usage:"reserved_instance_payment_option"`
+	MonthlyCPUCreditHours         *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
+	VCPUCount                     *int64   `infracost_usage:"vcpu_count"`
+	AverageCPUUtilizationPercent  *float64 `infracost_usage:"average_cpu_utilization_percent"`
 }
 
 var LaunchConfigurationUsageSchema = InstanceUsageSchema
@@ -71,6 +72,7 @@ func (a *LaunchConfiguration) BuildResource() *schema.Resource {
 		ReservedInstancePaymentOption:   a.ReservedInstancePaymentOption,
 		MonthlyCPUCreditHours:           a.MonthlyCPUCreditHours,
 		VCPUCount:                       a.VCPUCount,
+		AverageCPUUtilizationPercent:    a.AverageCPUUtilizationPercent,
 	}
 	instanceResource := instance.BuildResource()
 
@@ -80,6 +82,7 @@ func (a *LaunchConfiguration) BuildResource() *schema.Resource {
 		CostComponents: instanceResource.CostComponents,
 		SubResources:   instanceResource.SubResources,
 		EstimateUsage:  instanceResource.EstimateUsage,
+		ImageID:        a.AMI,
 	}
 
 	qty := int64(1)
@@ -87,6 +90,7 @@ func (a *LaunchConfiguration) BuildResource() *schema.Resource {
 		qty = *a.InstanceCount
 	}
 	schema.MultiplyQuantities(r, decimal.NewFromInt(qty))
+	r.InstanceCount = decimalPtr(decimal.NewFromInt(qty))
 
 	return r
 }
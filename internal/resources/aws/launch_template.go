@@ -26,16 +26,20 @@ type LaunchTemplate struct {
 	ElasticInferenceAcceleratorType *string
 	RootBlockDevice                 *EBSVolume
 	EBSBlockDevices                 []*EBSVolume
+	// SpotInstanceTypes are the instance types that the spot instances are spread evenly across, e.g. the
+	// overrides of a mixed instances policy. If this is empty the spot instances use InstanceType.
+	SpotInstanceTypes []string
 
 	// "usage" args
 	// These are populated from the Autoscaling Group/EKS Node Group resource
-	InstanceCount                 *int64  `infracost_usage:"instances"`
-	OperatingSystem               *string `infracost_usage:"operating_system"`
-	ReservedInstanceType          *string `infracost_usage:"reserved_instance_type"`
-	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
-	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
-	MonthlyCPUCreditHours         *int64  `infracost_usage:"monthly_cpu_credit_hrs"`
-	VCPUCount                     *int64  `infracost_usage:"vcpu_count"`
+	InstanceCount                 *int64   `infracost_usage:"instances"`
+	OperatingSystem               *string  `infracost_usage:"operating_system"`
+	ReservedInstanceType          *string  `infracost_usage:"reserved_instance_type"`
+	ReservedInstanceTerm          *string  `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string  `infracost_usage:"reserved_instance_payment_option"`
+	MonthlyCPUCreditHours         *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
+	VCPUCount                     *int64   `infracost_usage:"vcpu_count"`
+	AverageCPUUtilizationPercent  *float64 `infracost_usage:"average_cpu_utilization_percent"`
 }
 
 var LaunchTemplateUsageSchema = InstanceUsageSchema
@@ -73,6 +77,7 @@ func (a *LaunchTemplate) BuildResource() *schema.Resource {
 		ReservedInstancePaymentOption:   a.ReservedInstancePaymentOption,
 		MonthlyCPUCreditHours:           a.MonthlyCPUCreditHours,
 		VCPUCount:                       a.VCPUCount,
+		AverageCPUUtilizationPercent:    a.AverageCPUUtilizationPercent,
 	}
 	instanceResource := instance.BuildResource()
 
@@ -89,6 +94,7 @@ func (a *LaunchTemplate) BuildResource() *schema.Resource {
 		CostComponents: costComponents,
 		SubResources:   instanceResource.SubResources,
 		EstimateUsage:  instanceResource.EstimateUsage,
+		ImageID:        a.AMI,
 	}
 
 	instanceCount := int64(1)
@@ -97,18 +103,28 @@ func (a *LaunchTemplate) BuildResource() *schema.Resource {
 	}
 
 	schema.MultiplyQuantities(r, decimal.NewFromInt(instanceCount))
+	r.InstanceCount = decimalPtr(decimal.NewFromInt(instanceCount))
 
 	onDemandCount, spotCount := a.calculateOnDemandAndSpotInstanceCounts()
 
 	if spotCount > 0 {
 		instance.PurchaseOption = "spot"
-		c := instance.computeCostComponent()
-		c.HourlyQuantity = decimalPtr(c.HourlyQuantity.Mul(decimal.NewFromInt(spotCount)))
-		r.CostComponents = append([]*schema.CostComponent{c}, r.CostComponents...)
+		instanceTypes, counts := a.spotInstanceTypeCounts(spotCount)
+
+		spotCostComponents := make([]*schema.CostComponent, 0, len(instanceTypes))
+		for i, instanceType := range instanceTypes {
+			instance.InstanceType = instanceType
+			c := instance.computeCostComponent()
+			c.HourlyQuantity = decimalPtr(c.HourlyQuantity.Mul(decimal.NewFromInt(counts[i])))
+			spotCostComponents = append(spotCostComponents, c)
+		}
+
+		r.CostComponents = append(spotCostComponents, r.CostComponents...)
 	}
 
 	if onDemandCount > 0 {
 		instance.PurchaseOption = "on_demand"
+		instance.InstanceType = a.InstanceType
 		c := instance.computeCostComponent()
 		c.HourlyQuantity = decimalPtr(c.HourlyQuantity.Mul(decimal.NewFromInt(onDemandCount)))
 		r.CostComponents = append([]*schema.CostComponent{c}, r.CostComponents...)
@@ -131,3 +147,31 @@ func (a *LaunchTemplate) calculateOnDemandAndSpotInstanceCounts() (int64, int64)
 
 	return onDemandInstanceCount, spotInstanceCount
 }
+
+// spotInstanceTypeCounts spreads the spot instances evenly across the SpotInstanceTypes. Any remainder
+// goes to the first instance types. Instance types with no instances are not returned.
+func (a *LaunchTemplate) spotInstanceTypeCounts(spotCount int64) ([]string, []int64) {
+	if len(a.SpotInstanceTypes) == 0 {
+		return []string{a.InstanceType}, []int64{spotCount}
+	}
+
+	n := int64(len(a.SpotInstanceTypes))
+	instanceTypes := make([]string, 0, n)
+	counts := make([]int64, 0, n)
+
+	for i, instanceType := range a.SpotInstanceTypes {
+		count := spotCount / n
+		if int64(i) < spotCount%n {
+			count++
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		instanceTypes = append(instanceTypes, instanceType)
+		counts = append(counts, count)
+	}
+
+	return instanceTypes, counts
+}
@@ -0,0 +1,41 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestLaunchTemplateSpotInstanceTypes(t *testing.T) {
+	t.Parallel()
+
+	instanceCount := int64(6)
+	lt := resources.LaunchTemplate{
+		Address:                          "aws_launch_template.lt",
+		Region:                           "us-east-1",
+		InstanceType:                     "t3.medium",
+		OnDemandBaseCount:                1,
+		OnDemandPercentageAboveBaseCount: 0,
+		InstanceCount:                    &instanceCount,
+		SpotInstanceTypes:                []string{"t3.large", "t3.xlarge", "m5.large", "m5.xlarge", "c5.large", "c5.xlarge"},
+	}
+
+	r := lt.BuildResource()
+	require.NotNil(t, r)
+
+	quantities := map[string]int64{}
+	for _, c := range r.CostComponents {
+		if c.HourlyQuantity != nil {
+			quantities[c.Name] = c.HourlyQuantity.IntPart()
+		}
+	}
+
+	assert.Equal(t, int64(1), quantities["Instance usage (Linux/UNIX, on-demand, t3.medium)"])
+	assert.Equal(t, int64(1), quantities["Instance usage (Linux/UNIX, spot, t3.large)"])
+	assert.Equal(t, int64(1), quantities["Instance usage (Linux/UNIX, spot, c5.large)"])
+	assert.NotContains(t, quantities, "Instance usage (Linux/UNIX, spot, c5.xlarge)")
+	assert.NotContains(t, quantities, "Instance usage (Linux/UNIX, spot, t3.medium)")
+}
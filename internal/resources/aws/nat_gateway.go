@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"fmt"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
@@ -10,13 +12,19 @@ type NATGateway struct {
 	Address string
 	Region  string
 
-	MonthlyDataProcessedGB *float64 `infracost_usage:"monthly_data_processed_gb"`
+	MonthlyDataProcessedGB       *float64 `infracost_usage:"monthly_data_processed_gb"`
+	MonthlyGatewayEndpointDataGB *float64 `infracost_usage:"monthly_gateway_endpoint_data_gb"`
 }
 
 var NATGatewayUsageSchema = []*schema.UsageItem{
 	{Key: "monthly_data_processed_gb", DefaultValue: 0.0, ValueType: schema.Float64},
+	{Key: "monthly_gateway_endpoint_data_gb", DefaultValue: 0.0, ValueType: schema.Float64},
 }
 
+// natInstanceType is the instance type of the NAT instance that is priced as an alternative to a
+// NAT gateway. It's the smallest Graviton instance, which is enough for most low traffic VPCs.
+const natInstanceType = "t4g.nano"
+
 func (a *NATGateway) PopulateUsage(u *schema.UsageData) {
 	resources.PopulateArgsWithUsage(a, u)
 }
@@ -28,37 +36,75 @@ func (a *NATGateway) BuildResource() *schema.Resource {
 	}
 
 	return &schema.Resource{
-		Name:        a.Address,
-		UsageSchema: NATGatewayUsageSchema,
-		CostComponents: []*schema.CostComponent{
-			{
-				Name:           "NAT gateway",
-				Unit:           "hours",
-				UnitMultiplier: decimal.NewFromInt(1),
-				HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(a.Region),
-					Service:       strPtr("AmazonEC2"),
-					ProductFamily: strPtr("NAT Gateway"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "usagetype", ValueRegex: strPtr("/NatGateway-Hours/")},
-					},
+		Name:           a.Address,
+		UsageSchema:    NATGatewayUsageSchema,
+		CostComponents: a.costComponents(gbDataProcessed),
+		Alternatives:   a.alternatives(),
+	}
+}
+
+// alternatives returns the cheaper ways of providing outbound internet access that can be compared
+// with the NAT gateway: a NAT instance, and the NAT gateway with S3 and DynamoDB traffic sent through
+// free gateway VPC endpoints instead.
+func (a *NATGateway) alternatives() []*schema.Resource {
+	natInstance := &Instance{
+		Address:        fmt.Sprintf("NAT instance (%s)", natInstanceType),
+		Region:         a.Region,
+		Tenancy:        "default",
+		PurchaseOption: "on_demand",
+		InstanceType:   natInstanceType,
+		CPUCredits:     "standard",
+		RootBlockDevice: &EBSVolume{
+			Address: "root_block_device",
+			Region:  a.Region,
+			Type:    "gp3",
+			Size:    intPtr(8),
+		},
+	}
+
+	alternatives := []*schema.Resource{natInstance.BuildResource()}
+
+	if a.MonthlyDataProcessedGB != nil && a.MonthlyGatewayEndpointDataGB != nil && *a.MonthlyGatewayEndpointDataGB > 0 {
+		gbDataProcessed := decimal.Max(decimal.Zero, decimal.NewFromFloat(*a.MonthlyDataProcessedGB).Sub(decimal.NewFromFloat(*a.MonthlyGatewayEndpointDataGB)))
+
+		alternatives = append(alternatives, &schema.Resource{
+			Name:           "NAT gateway with S3/DynamoDB gateway endpoints",
+			CostComponents: a.costComponents(&gbDataProcessed),
+		})
+	}
+
+	return alternatives
+}
+
+func (a *NATGateway) costComponents(gbDataProcessed *decimal.Decimal) []*schema.CostComponent {
+	return []*schema.CostComponent{
+		{
+			Name:           "NAT gateway",
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonEC2"),
+				ProductFamily: strPtr("NAT Gateway"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/NatGateway-Hours/")},
 				},
 			},
-			{
-				Name:            "Data processed",
-				Unit:            "GB",
-				UnitMultiplier:  decimal.NewFromInt(1),
-				MonthlyQuantity: gbDataProcessed,
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(a.Region),
-					Service:       strPtr("AmazonEC2"),
-					ProductFamily: strPtr("NAT Gateway"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "usagetype", ValueRegex: strPtr("/NatGateway-Bytes/")},
-					},
+		},
+		{
+			Name:            "Data processed",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: gbDataProcessed,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonEC2"),
+				ProductFamily: strPtr("NAT Gateway"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/NatGateway-Bytes/")},
 				},
 			},
 		},
@@ -0,0 +1,34 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestNATGatewayAlternatives(t *testing.T) {
+	t.Parallel()
+
+	processed := 100.0
+	r := resources.NATGateway{
+		Address:                "aws_nat_gateway.main",
+		Region:                 "us-east-1",
+		MonthlyDataProcessedGB: &processed,
+	}
+
+	resource := r.BuildResource()
+	require.Len(t, resource.Alternatives, 1)
+	assert.Equal(t, "NAT instance (t4g.nano)", resource.Alternatives[0].Name)
+
+	endpointData := 140.0
+	r.MonthlyGatewayEndpointDataGB = &endpointData
+
+	resource = r.BuildResource()
+	require.Len(t, resource.Alternatives, 2)
+	assert.Equal(t, "NAT gateway with S3/DynamoDB gateway endpoints", resource.Alternatives[1].Name)
+	assert.Equal(t, "0", resource.Alternatives[1].CostComponents[1].MonthlyQuantity.String())
+	assert.Equal(t, "100", resource.CostComponents[1].MonthlyQuantity.String())
+}
@@ -15,15 +15,19 @@ type RDSClusterInstance struct {
 	Engine                                       string
 	PerformanceInsightsEnabled                   bool
 	PerformanceInsightsLongTermRetention         bool
-	MonthlyCPUCreditHrs                          *int64 `infracost_usage:"monthly_cpu_credit_hrs"`
-	VCPUCount                                    *int64 `infracost_usage:"vcpu_count"`
-	MonthlyAdditionalPerformanceInsightsRequests *int64 `infracost_usage:"monthly_additional_performance_insights_requests"`
+	ServerlessV2MinACU                           float64
+	ServerlessV2MaxACU                           float64
+	MonthlyCPUCreditHrs                          *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
+	VCPUCount                                    *int64   `infracost_usage:"vcpu_count"`
+	MonthlyAdditionalPerformanceInsightsRequests *int64   `infracost_usage:"monthly_additional_performance_insights_requests"`
+	AverageACUPerHr                              *float64 `infracost_usage:"average_acu_per_hr"`
 }
 
 var RDSClusterInstanceUsageSchema = []*schema.UsageItem{
 	{Key: "monthly_cpu_credit_hrs", ValueType: schema.Int64, DefaultValue: 0},
 	{Key: "vcpu_count", ValueType: schema.Int64, DefaultValue: 0},
 	{Key: "monthly_additional_performance_insights_requests", ValueType: schema.Int64, DefaultValue: 0},
+	{Key: "average_acu_per_hr", ValueType: schema.Float64, DefaultValue: 0},
 }
 
 func (r *RDSClusterInstance) PopulateUsage(u *schema.UsageData) {
@@ -33,6 +37,14 @@ func (r *RDSClusterInstance) PopulateUsage(u *schema.UsageData) {
 func (r *RDSClusterInstance) BuildResource() *schema.Resource {
 	databaseEngine := r.databaseEngineValue()
 
+	if r.InstanceClass == "db.serverless" {
+		return &schema.Resource{
+			Name:           r.Address,
+			CostComponents: []*schema.CostComponent{r.serverlessV2CostComponent(databaseEngine)},
+			UsageSchema:    RDSClusterInstanceUsageSchema,
+		}
+	}
+
 	costComponents := []*schema.CostComponent{
 		{
 			Name:           fmt.Sprintf("Database instance (%s, %s)", "on-demand", r.InstanceClass),
@@ -93,6 +105,39 @@ func (r *RDSClusterInstance) BuildResource() *schema.Resource {
 	}
 }
 
+// serverlessV2CostComponent returns the ACU-hours of an Aurora Serverless v2 instance. The average
+// ACUs from the usage file are kept within the cluster's scaling range, and the minimum ACUs are
+// used if there is no usage.
+func (r *RDSClusterInstance) serverlessV2CostComponent(databaseEngine string) *schema.CostComponent {
+	var acus *decimal.Decimal
+	if r.AverageACUPerHr != nil {
+		avg := decimal.NewFromFloat(*r.AverageACUPerHr)
+		if r.ServerlessV2MaxACU > 0 {
+			avg = decimal.Min(avg, decimal.NewFromFloat(r.ServerlessV2MaxACU))
+		}
+		acus = decimalPtr(decimal.Max(avg, decimal.NewFromFloat(r.ServerlessV2MinACU)))
+	} else if r.ServerlessV2MinACU > 0 {
+		acus = decimalPtr(decimal.NewFromFloat(r.ServerlessV2MinACU))
+	}
+
+	return &schema.CostComponent{
+		Name:           "Aurora serverless v2",
+		Unit:           "ACU-hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: acus,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("AmazonRDS"),
+			ProductFamily: strPtr("ServerlessV2"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "databaseEngine", Value: strPtr(databaseEngine)},
+				{Key: "usagetype", ValueRegex: regexPtr("Aurora:ServerlessV2Usage$")},
+			},
+		},
+	}
+}
+
 func (r *RDSClusterInstance) databaseEngineValue() string {
 	switch r.Engine {
 	case "aurora", "aurora-mysql", "":
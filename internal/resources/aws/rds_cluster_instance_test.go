@@ -0,0 +1,32 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestRDSClusterInstanceServerlessV2(t *testing.T) {
+	t.Parallel()
+
+	r := resources.RDSClusterInstance{
+		Address:            "aws_rds_cluster_instance.serverless",
+		Region:             "us-east-1",
+		Engine:             "aurora-postgresql",
+		InstanceClass:      "db.serverless",
+		ServerlessV2MinACU: 0.5,
+		ServerlessV2MaxACU: 8,
+	}
+
+	resource := r.BuildResource()
+	require.Len(t, resource.CostComponents, 1)
+	assert.Equal(t, "0.5", resource.CostComponents[0].HourlyQuantity.String())
+
+	avg := 12.0
+	r.AverageACUPerHr = &avg
+	resource = r.BuildResource()
+	assert.Equal(t, "8", resource.CostComponents[0].HourlyQuantity.String())
+}
@@ -92,15 +92,16 @@ func TestS3Bucket(t *testing.T) {
 	stubListBucketMetricsConfigurations(stub)
 
 	storageClassBytes := map[string]int{
-		"StandardStorage":              2100000000,
-		"IntelligentTieringFAStorage":  2200000000,
-		"IntelligentTieringIAStorage":  2300000000,
-		"IntelligentTieringAAStorage":  2400000000,
-		"IntelligentTieringDAAStorage": 2500000000,
-		"StandardIAStorage":            2600000000,
-		"OneZoneIAStorage":             2700000000,
-		"GlacierStorage":               2800000000,
-		"DeepArchiveStorage":           0, // This should not appear in estimates.usages
+		"StandardStorage":                2100000000,
+		"IntelligentTieringFAStorage":    2200000000,
+		"IntelligentTieringIAStorage":    2300000000,
+		"IntelligentTieringAAStorage":    2400000000,
+		"IntelligentTieringDAAStorage":   2500000000,
+		"StandardIAStorage":              2600000000,
+		"OneZoneIAStorage":               2700000000,
+		"GlacierInstantRetrievalStorage": 2750000000,
+		"GlacierStorage":                 2800000000,
+		"DeepArchiveStorage":             0, // This should not appear in estimates.usages
 	}
 
 	for storageClass, bytes := range storageClassBytes {
@@ -155,6 +156,9 @@ func TestS3Bucket(t *testing.T) {
 		"one_zone_infrequent_access": map[string]interface{}{
 			"storage_gb": 2.7,
 		},
+		"glacier_instant_retrieval": map[string]interface{}{
+			"storage_gb": 2.75,
+		},
 		"glacier_flexible_retrieval": map[string]interface{}{
 			"storage_gb": 2.8,
 		},
@@ -168,15 +172,16 @@ func TestS3BucketNoFilter(t *testing.T) {
 	stubListBucketMetricsConfigurationsNoMatching(stub)
 
 	storageClassBytes := map[string]int{
-		"StandardStorage":              2100000000,
-		"IntelligentTieringFAStorage":  2200000000,
-		"IntelligentTieringIAStorage":  2300000000,
-		"IntelligentTieringAAStorage":  2400000000,
-		"IntelligentTieringDAAStorage": 2500000000,
-		"StandardIAStorage":            2600000000,
-		"OneZoneIAStorage":             2700000000,
-		"GlacierStorage":               2800000000,
-		"DeepArchiveStorage":           2900000000,
+		"StandardStorage":                2100000000,
+		"IntelligentTieringFAStorage":    2200000000,
+		"IntelligentTieringIAStorage":    2300000000,
+		"IntelligentTieringAAStorage":    2400000000,
+		"IntelligentTieringDAAStorage":   2500000000,
+		"StandardIAStorage":              2600000000,
+		"OneZoneIAStorage":               2700000000,
+		"GlacierInstantRetrievalStorage": 2750000000,
+		"GlacierStorage":                 2800000000,
+		"DeepArchiveStorage":             2900000000,
 	}
 
 	for storageClass, bytes := range storageClassBytes {
@@ -201,15 +206,16 @@ func TestS3BucketNoStandard(t *testing.T) {
 	stubListBucketMetricsConfigurations(stub)
 
 	storageClassBytes := map[string]int{
-		"StandardStorage":              0,
-		"IntelligentTieringFAStorage":  2200000000,
-		"IntelligentTieringIAStorage":  0,
-		"IntelligentTieringAAStorage":  0,
-		"IntelligentTieringDAAStorage": 0,
-		"StandardIAStorage":            0,
-		"OneZoneIAStorage":             0,
-		"GlacierStorage":               0,
-		"DeepArchiveStorage":           0,
+		"StandardStorage":                0,
+		"IntelligentTieringFAStorage":    2200000000,
+		"IntelligentTieringIAStorage":    0,
+		"IntelligentTieringAAStorage":    0,
+		"IntelligentTieringDAAStorage":   0,
+		"StandardIAStorage":              0,
+		"OneZoneIAStorage":               0,
+		"GlacierInstantRetrievalStorage": 0,
+		"GlacierStorage":                 0,
+		"DeepArchiveStorage":             0,
 	}
 
 	for storageClass, bytes := range storageClassBytes {
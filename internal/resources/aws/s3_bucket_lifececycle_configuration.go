@@ -37,6 +37,7 @@ var S3BucketLifecycleConfigurationUsageSchema = []*schema.UsageItem{
 	{Key: "intelligent_tiering", DefaultValue: &usage.ResourceUsage{Name: "intelligent_tiering", Items: S3IntelligentTieringStorageClassUsageSchema}, ValueType: schema.SubResourceUsage},
 	{Key: "standard_infrequent_access", DefaultValue: &usage.ResourceUsage{Name: "standard_infrequent_access", Items: S3StandardInfrequentAccessStorageClassUsageSchema}, ValueType: schema.SubResourceUsage},
 	{Key: "one_zone_infrequent_access", DefaultValue: &usage.ResourceUsage{Name: "one_zone_infrequent_access", Items: S3OneZoneInfrequentAccessStorageClassUsageSchema}, ValueType: schema.SubResourceUsage},
+	{Key: "glacier_instant_retrieval", DefaultValue: &usage.ResourceUsage{Name: "glacier_instant_retrieval", Items: S3GlacierInstantRetrievalStorageClassUsageSchema}, ValueType: schema.SubResourceUsage},
 	{Key: "glacier_flexible_retrieval", DefaultValue: &usage.ResourceUsage{Name: "glacier_flexible_retrieval", Items: S3GlacierFlexibleRetrievalStorageClassUsageSchema}, ValueType: schema.SubResourceUsage},
 	{Key: "glacier_deep_archive", DefaultValue: &usage.ResourceUsage{Name: "glacier_deep_archive", Items: S3GlacierDeepArchiveStorageClassUsageSchema}, ValueType: schema.SubResourceUsage},
 }
@@ -48,6 +49,7 @@ func (r *S3BucketLifecycleConfiguration) AllStorageClasses() []S3StorageClass {
 			&S3IntelligentTieringStorageClass{Region: r.Region},
 			&S3StandardInfrequentAccessStorageClass{Region: r.Region},
 			&S3OneZoneInfrequentAccessStorageClass{Region: r.Region},
+			&S3GlacierInstantRetrievalStorageClass{Region: r.Region},
 			&S3GlacierFlexibleRetrievalStorageClass{Region: r.Region},
 			&S3GlacierDeepArchiveStorageClass{Region: r.Region},
 		}
@@ -105,6 +107,9 @@ func (r *S3BucketLifecycleConfiguration) BuildResource() *schema.Resource {
 			"one_zone_infrequent_access": {
 				"storage_gb": "OneZoneIAStorage",
 			},
+			"glacier_instant_retrieval": {
+				"storage_gb": "GlacierInstantRetrievalStorage",
+			},
 			"glacier_flexible_retrieval": {
 				"storage_gb": "GlacierStorage",
 			},
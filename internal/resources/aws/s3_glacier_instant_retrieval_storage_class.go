@@ -0,0 +1,48 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+type S3GlacierInstantRetrievalStorageClass struct {
+	// "required" args that can't really be missing.
+	Region string
+
+	// "usage" args
+	StorageGB                          *float64 `infracost_usage:"storage_gb"`
+	MonthlyTier1Requests               *int64   `infracost_usage:"monthly_tier_1_requests"`
+	MonthlyTier2Requests               *int64   `infracost_usage:"monthly_tier_2_requests"`
+	MonthlyLifecycleTransitionRequests *int64   `infracost_usage:"monthly_lifecycle_transition_requests"`
+	MonthlyDataRetrievalGB             *float64 `infracost_usage:"monthly_data_retrieval_gb"`
+}
+
+var S3GlacierInstantRetrievalStorageClassUsageSchema = []*schema.UsageItem{
+	{Key: "storage_gb", DefaultValue: 0.0, ValueType: schema.Float64},
+	{Key: "monthly_tier_1_requests", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_tier_2_requests", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_lifecycle_transition_requests", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_data_retrieval_gb", DefaultValue: 0.0, ValueType: schema.Float64},
+}
+
+func (a *S3GlacierInstantRetrievalStorageClass) UsageKey() string {
+	return "glacier_instant_retrieval"
+}
+
+func (a *S3GlacierInstantRetrievalStorageClass) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(a, u)
+}
+
+func (a *S3GlacierInstantRetrievalStorageClass) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:        "Glacier instant retrieval",
+		UsageSchema: S3GlacierInstantRetrievalStorageClassUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			s3StorageCostComponent("Storage", "AmazonS3", a.Region, "TimedStorage-GIR-ByteHrs", a.StorageGB),
+			s3ApiCostComponent("PUT, COPY, POST, LIST requests", "AmazonS3", a.Region, "Requests-GIR-Tier1", a.MonthlyTier1Requests),
+			s3ApiCostComponent("GET, SELECT, and all other requests", "AmazonS3", a.Region, "Requests-GIR-Tier2", a.MonthlyTier2Requests),
+			s3ApiCostComponent("Lifecycle transition", "AmazonS3", a.Region, "Requests-GIR-Tier1", a.MonthlyLifecycleTransitionRequests),
+			s3DataCostComponent("Retrievals", "AmazonS3", a.Region, "Retrieval-GIR", a.MonthlyDataRetrievalGB),
+		},
+	}
+}
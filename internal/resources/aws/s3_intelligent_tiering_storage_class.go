@@ -50,7 +50,7 @@ func (a *S3IntelligentTieringStorageClass) BuildResource() *schema.Resource {
 		Name:        "Intelligent tiering",
 		UsageSchema: S3IntelligentTieringStorageClassUsageSchema,
 		CostComponents: []*schema.CostComponent{
-			s3StorageCostComponent("Storage (frequent access)", "AmazonS3", a.Region, "TimedStorage-INT-FA-ByteHrs", a.FrequentAccessStorageGB),
+			s3TieredStorageCostComponent(s3StorageCostComponent("Storage (frequent access)", "AmazonS3", a.Region, "TimedStorage-INT-FA-ByteHrs", a.FrequentAccessStorageGB)),
 			s3StorageCostComponent("Storage (infrequent access)", "AmazonS3", a.Region, "TimedStorage-INT-IA-ByteHrs", a.InfrequentAccessStorageGB),
 			s3StorageVolumeTypeCostComponent("Storage (archive access)", "AmazonS3", a.Region, "TimedStorage-INT-AA-ByteHrs", "IntelligentTieringArchiveStorage", a.FrequentAccessStorageGB),
 			s3StorageVolumeTypeCostComponent("Storage (deep archive access)", "AmazonS3", a.Region, "TimedStorage-INT-DAA-ByteHrs", "IntelligentTieringDeepArchiveStorage", a.InfrequentAccessStorageGB),
@@ -48,6 +48,18 @@ func s3StorageVolumeTypeCostComponent(name string, service string, region string
 	}
 }
 
+// s3TieredStorageCostComponent returns a storage cost component priced for the first 50TB, the next
+// 450TB and over 500TB, like S3 standard and intelligent tiering frequent access storage are.
+func s3TieredStorageCostComponent(c *schema.CostComponent) *schema.CostComponent {
+	c.PriceTiers = []*schema.PriceTier{
+		{Name: "first 50TB", StartUsageAmount: decimal.Zero},
+		{Name: "next 450TB", StartUsageAmount: decimal.NewFromInt(51200)},
+		{Name: "over 500TB", StartUsageAmount: decimal.NewFromInt(512000)},
+	}
+
+	return c
+}
+
 func s3ApiCostComponent(name string, service string, region string, usageType string, requests *int64) *schema.CostComponent {
 	return s3ApiOperationCostComponent(name, service, region, usageType, "", requests)
 }
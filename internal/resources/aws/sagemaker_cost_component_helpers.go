@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// sagemakerInstanceCostComponent returns a cost component for the hours that SageMaker ML instances run.
+// The usage type prefix is the SageMaker feature the instances are used for, e.g. Host for real-time
+// inference or Notebk for notebook instances, since each feature is priced separately for the same instance type.
+//
+// The instances are assumed to run all month, unless monthlyHrs is set.
+func sagemakerInstanceCostComponent(name, region, usageTypePrefix, instanceType string, instanceCount int64, monthlyHrs *float64) *schema.CostComponent {
+	c := &schema.CostComponent{
+		Name:           name,
+		Unit:           "hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    vendorName,
+			Region:        strPtr(region),
+			Service:       strPtr("AmazonSageMaker"),
+			ProductFamily: strPtr("ML Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: regexPtr(fmt.Sprintf("%s:%s$", usageTypePrefix, regexp.QuoteMeta(instanceType)))},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		},
+	}
+
+	if monthlyHrs != nil {
+		c.MonthlyQuantity = decimalPtr(decimal.NewFromFloat(*monthlyHrs).Mul(decimal.NewFromInt(instanceCount)))
+	} else {
+		c.HourlyQuantity = decimalPtr(decimal.NewFromInt(instanceCount))
+	}
+
+	return c
+}
+
+// sagemakerStorageCostComponent returns a cost component for the general purpose SSD storage attached to
+// SageMaker ML instances.
+func sagemakerStorageCostComponent(region, usageTypePrefix string, storageGB float64) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            "Storage (general purpose SSD)",
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromFloat(storageGB)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName: vendorName,
+			Region:     strPtr(region),
+			Service:    strPtr("AmazonSageMaker"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: regexPtr(fmt.Sprintf("%s:VolumeUsage", usageTypePrefix))},
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// SageMakerEndpoint defines an Amazon SageMaker real-time inference endpoint. The
+// endpoint is charged for each hour that the ML instances of the production variants
+// in its endpoint configuration run. Serverless variants are charged per request and
+// aren't supported yet.
+//
+// See more resource information here: https://docs.aws.amazon.com/sagemaker/latest/dg/realtime-endpoints.html.
+//
+// See the pricing information here: https://aws.amazon.com/sagemaker/pricing/.
+type SageMakerEndpoint struct {
+	Address  string
+	Region   string
+	Variants []*SageMakerEndpointVariant
+
+	// "usage" args
+	MonthlyHrs *float64 `infracost_usage:"monthly_hrs"`
+}
+
+// SageMakerEndpointVariant defines an instance-based production variant of a SageMakerEndpoint.
+type SageMakerEndpointVariant struct {
+	Name          string
+	InstanceType  string
+	InstanceCount int64
+}
+
+// SageMakerEndpointUsageSchema defines a list of usage items for SageMakerEndpoint.
+var SageMakerEndpointUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_hrs", DefaultValue: 730, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the SageMakerEndpoint.
+// It uses the `infracost_usage` struct tags to populate data into the SageMakerEndpoint.
+func (r *SageMakerEndpoint) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid SageMakerEndpoint struct.
+// This method is called after the resource is initialised by an IaC provider.
+func (r *SageMakerEndpoint) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{}
+
+	for _, v := range r.Variants {
+		name := fmt.Sprintf("Instance usage (%s)", v.InstanceType)
+		if v.Name != "" {
+			name = fmt.Sprintf("Instance usage (%s, %s)", v.Name, v.InstanceType)
+		}
+
+		costComponents = append(costComponents, sagemakerInstanceCostComponent(name, r.Region, "Host", v.InstanceType, v.InstanceCount, r.MonthlyHrs))
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    SageMakerEndpointUsageSchema,
+		CostComponents: costComponents,
+	}
+}
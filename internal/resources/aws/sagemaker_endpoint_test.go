@@ -0,0 +1,56 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestSageMakerEndpointVariants(t *testing.T) {
+	t.Parallel()
+
+	r := resources.SageMakerEndpoint{
+		Address: "aws_sagemaker_endpoint.endpoint",
+		Region:  "us-east-1",
+		Variants: []*resources.SageMakerEndpointVariant{
+			{Name: "primary", InstanceType: "ml.g5.xlarge", InstanceCount: 2},
+			{InstanceType: "ml.m5.large", InstanceCount: 1},
+		},
+	}
+
+	resource := r.BuildResource()
+	require.Len(t, resource.CostComponents, 2)
+
+	primary := findCostComponent(t, resource, "Instance usage (primary, ml.g5.xlarge)")
+	assert.Equal(t, "2", primary.HourlyQuantity.String())
+	assert.Equal(t, `/Host:ml\.g5\.xlarge$/i`, *primary.ProductFilter.AttributeFilters[0].ValueRegex)
+	assert.Equal(t, "1", findCostComponent(t, resource, "Instance usage (ml.m5.large)").HourlyQuantity.String())
+
+	hrs := 100.0
+	r.MonthlyHrs = &hrs
+	primary = findCostComponent(t, r.BuildResource(), "Instance usage (primary, ml.g5.xlarge)")
+	assert.Nil(t, primary.HourlyQuantity)
+	assert.Equal(t, "200", primary.MonthlyQuantity.String())
+}
+
+func TestSageMakerNotebookInstance(t *testing.T) {
+	t.Parallel()
+
+	hrs := 160.0
+	r := resources.SageMakerNotebookInstance{
+		Address:      "aws_sagemaker_notebook_instance.notebook",
+		Region:       "us-east-1",
+		InstanceType: "ml.t3.medium",
+		VolumeSizeGB: 50,
+		MonthlyHrs:   &hrs,
+	}
+
+	resource := r.BuildResource()
+	instance := findCostComponent(t, resource, "Instance usage (ml.t3.medium)")
+	assert.Equal(t, "160", instance.MonthlyQuantity.String())
+	assert.Equal(t, `/Notebk:ml\.t3\.medium$/i`, *instance.ProductFilter.AttributeFilters[0].ValueRegex)
+	assert.Equal(t, "50", findCostComponent(t, resource, "Storage (general purpose SSD)").MonthlyQuantity.String())
+}
@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// SageMakerNotebookInstance defines an Amazon SageMaker notebook instance. It's
+// charged for each hour the ML instance runs, and for its storage volume whether
+// or not the instance is running.
+//
+// See more resource information here: https://docs.aws.amazon.com/sagemaker/latest/dg/nbi.html.
+//
+// See the pricing information here: https://aws.amazon.com/sagemaker/pricing/.
+type SageMakerNotebookInstance struct {
+	Address      string
+	Region       string
+	InstanceType string
+	VolumeSizeGB float64
+
+	// "usage" args
+	MonthlyHrs *float64 `infracost_usage:"monthly_hrs"`
+}
+
+// SageMakerNotebookInstanceUsageSchema defines a list of usage items for SageMakerNotebookInstance.
+var SageMakerNotebookInstanceUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_hrs", DefaultValue: 730, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the SageMakerNotebookInstance.
+// It uses the `infracost_usage` struct tags to populate data into the SageMakerNotebookInstance.
+func (r *SageMakerNotebookInstance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid SageMakerNotebookInstance struct.
+// This method is called after the resource is initialised by an IaC provider.
+func (r *SageMakerNotebookInstance) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: SageMakerNotebookInstanceUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			sagemakerInstanceCostComponent(fmt.Sprintf("Instance usage (%s)", r.InstanceType), r.Region, "Notebk", r.InstanceType, 1, r.MonthlyHrs),
+			sagemakerStorageCostComponent(r.Region, "Notebk", r.VolumeSizeGB),
+		},
+	}
+}
@@ -132,15 +132,16 @@ var RegionCodeMapping = map[string]string{
 // changed on a per-region basis. e.g.
 //
 // monthly_data_processed_gb:
-//   us_gov_west_1: 188
-//   us_east_1: 78
+//
+//	us_gov_west_1: 188
+//	us_east_1: 78
 //
 // can be handled by adding a usage cost property to your resource like so:
 //
-// type MyResource struct {
-//    ...
-//    MonthlyDataProcessedGB *RegionsUsage `infracost_usage:"monthly_processed_gb"`
-// }
+//	type MyResource struct {
+//	   ...
+//	   MonthlyDataProcessedGB *RegionsUsage `infracost_usage:"monthly_processed_gb"`
+//	}
 type RegionsUsage struct {
 	USGovWest1   *float64 `infracost_usage:"us_gov_west_1"`
 	USGovEast1   *float64 `infracost_usage:"us_gov_east_1"`
@@ -206,9 +207,9 @@ func (r RegionsUsage) Values() []RegionUsage {
 // This can be used as a schema.SubResourceUsage to define a structure that's
 // commonly used with data transfer usage. e.g:
 //
-// 		monthly_data_transfer_out_gb:
-//			us_gov_west_1: 122
-//			ca_central_1: 99
+//	monthly_data_transfer_out_gb:
+//		us_gov_west_1: 122
+//		ca_central_1: 99
 //
 // See DirectoryServiceDirectory for an example usage.
 var RegionUsageSchema = []*schema.UsageItem{
@@ -251,6 +252,40 @@ func getBurstableInstanceFamily(matchPrefixes []string, instanceType string) str
 	return ""
 }
 
+// burstableBaselineCPUPercent is the baseline utilization of each vCPU of the burstable instance
+// types. Instances in unlimited mode that run above the baseline for longer than their earned
+// credits last are charged for the surplus CPU credits.
+var burstableBaselineCPUPercent = map[string]float64{
+	"t2.nano":     5,
+	"t2.micro":    10,
+	"t2.small":    20,
+	"t2.medium":   20,
+	"t2.large":    30,
+	"t2.xlarge":   22.5,
+	"t2.2xlarge":  17,
+	"t3.nano":     5,
+	"t3.micro":    10,
+	"t3.small":    20,
+	"t3.medium":   20,
+	"t3.large":    30,
+	"t3.xlarge":   40,
+	"t3.2xlarge":  40,
+	"t3a.nano":    5,
+	"t3a.micro":   10,
+	"t3a.small":   20,
+	"t3a.medium":  20,
+	"t3a.large":   30,
+	"t3a.xlarge":  40,
+	"t3a.2xlarge": 40,
+	"t4g.nano":    5,
+	"t4g.micro":   10,
+	"t4g.small":   20,
+	"t4g.medium":  20,
+	"t4g.large":   30,
+	"t4g.xlarge":  40,
+	"t4g.2xlarge": 40,
+}
+
 // this map was generated with:
 // aws ec2 describe-instance-types | jq -r '[.InstanceTypes[] | "\"" + .InstanceType + "\": " + (.VCpuInfo.DefaultVCpus | tostring) + ","] | sort | .[]'
 var InstanceTypeToVCPU = map[string]int64{
@@ -63,9 +63,9 @@ var (
 // LogAnalyticsWorkspace struct represents an Azure Monitor log workspace. A workspace consolidates data
 // from multiple sources into a single data lake. A workspace defines:
 //
-//		1. The geographic location of the data.
-//		2. Access rights that define which users can access data.
-//		3. Configuration settings such as the pricing tier and data retention.
+//  1. The geographic location of the data.
+//  2. Access rights that define which users can access data.
+//  3. Configuration settings such as the pricing tier and data retention.
 //
 // Resource information: https://azure.microsoft.com/en-gb/services/monitor/
 // Pricing information: https://azure.microsoft.com/en-gb/pricing/details/monitor/
@@ -110,12 +110,12 @@ func (r *LogAnalyticsWorkspace) PopulateUsage(u *schema.UsageData) {
 // BuildResource builds a schema.Resource from a valid LogAnalyticsWorkspace struct.
 // The returned schema.Resource can have 3 potential schema.CostComponent associated with it:
 //
-//		1. Log data ingestion, which can be either:
-//			a) Pay-as-you-go, which is only valid for a sku of PerGB2018 and uses a usage param
-//			b) Billed per commitment tiers, which is only valid for a sku of CapacityReservation
-//		2. Log retention, which is free up to 31 days. Data retained beyond these no-charge periods
-//		   will be charged for each GB of data retained for a month (pro-rated daily).
-//		3. Data export, which is billed per monthly GB exported and is defined from a usage param.
+//  1. Log data ingestion, which can be either:
+//     a) Pay-as-you-go, which is only valid for a sku of PerGB2018 and uses a usage param
+//     b) Billed per commitment tiers, which is only valid for a sku of CapacityReservation
+//  2. Log retention, which is free up to 31 days. Data retained beyond these no-charge periods
+//     will be charged for each GB of data retained for a month (pro-rated daily).
+//  3. Data export, which is billed per monthly GB exported and is defined from a usage param.
 //
 // Outside the above rules - if the workspace has sku of Free we return as a free resource & if the workspace sku
 // is in a list of unsupported skus then we mark as skipped with a warning.
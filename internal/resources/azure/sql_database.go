@@ -61,25 +61,25 @@ func (r *SQLDatabase) PopulateUsage(u *schema.UsageData) {
 //
 // SQLDatabase splits pricing into two different models. DTU & vCores.
 //
-//		Database Transaction Unit (DTU) is made a performance metric representing a mixture of performance metrics
-// 		in azure sql. Some include: CPU, I/O, Memory. DTU is used as Azure tries to simplify billing by using a single metric.
+//	Database Transaction Unit (DTU) is made a performance metric representing a mixture of performance metrics
+//	in azure sql. Some include: CPU, I/O, Memory. DTU is used as Azure tries to simplify billing by using a single metric.
 //
-//		Virtual Core (vCore) pricing is designed to translate from on premise hardware metrics (cores) into the cloud
-//		sql instance. vCore is designed to allow users to better estimate their resource limits, e.g. RAM.
+//	Virtual Core (vCore) pricing is designed to translate from on premise hardware metrics (cores) into the cloud
+//	sql instance. vCore is designed to allow users to better estimate their resource limits, e.g. RAM.
 //
 // SQL databases that follow a DTU pricing model have the following costs associated with them:
 //
-//		1. Costs based on the number of DTUs that the sql database has
-//		2. Extra backup data costs - this is configured using SQLDatabase.ExtraDataStorageGB
-//		3. Long term data backup costs - this is configured using SQLDatabase.LongTermRetentionStorageGB
+//  1. Costs based on the number of DTUs that the sql database has
+//  2. Extra backup data costs - this is configured using SQLDatabase.ExtraDataStorageGB
+//  3. Long term data backup costs - this is configured using SQLDatabase.LongTermRetentionStorageGB
 //
 // SQL databases that follow a vCore pricing model have the following costs associated with them:
 //
-//		1. Costs based on the number of vCores the resource has
-//		2. Extra pricing if any database read replicas have been provisioned
-//		3. Additional charge for sql server licencing based on vCores amount
-//		4. Charges for storage used
-//		5. Charges for long term data backup - this is configured using SQLDatabase.LongTermRetentionStorageGB
+//  1. Costs based on the number of vCores the resource has
+//  2. Extra pricing if any database read replicas have been provisioned
+//  3. Additional charge for sql server licencing based on vCores amount
+//  4. Charges for storage used
+//  5. Charges for long term data backup - this is configured using SQLDatabase.LongTermRetentionStorageGB
 //
 // This method is called after the resource is initialized by an IaC provider. SQLDatabase is used by both mssql_database
 // and sql_database terraform resources to build a sql database costing.
@@ -0,0 +1,211 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const minutesPerWeek = 7 * 24 * 60
+
+var cronDayNames = map[string]int{
+	"SUN": 0,
+	"MON": 1,
+	"TUE": 2,
+	"WED": 3,
+	"THU": 4,
+	"FRI": 5,
+	"SAT": 6,
+}
+
+// CapacitySchedule is a recurring scheduled change to the number of instances of a group, e.g. an
+// autoscaling schedule that scales a group to zero outside of working hours.
+type CapacitySchedule struct {
+	// Start is a cron expression with minute, hour, day of month, month and day of week fields for
+	// when the schedule starts. Since the average capacity is worked out over a week, the day of month
+	// and month fields must be *.
+	Start string
+	// Capacity is the number of instances that the group has from the start of the schedule.
+	Capacity int64
+	// Duration is how long the schedule lasts for. If it's zero, the group keeps the capacity until
+	// another schedule starts. Otherwise Capacity is the minimum number of instances of the group
+	// for the duration.
+	Duration time.Duration
+}
+
+// AverageCapacity returns the time-weighted average number of instances of a group that has
+// capacity instances unless one of the schedules applies. Schedules that start at the same time are
+// applied in order. The schedules are evaluated in the same time zone, so the offsets of their
+// time zones are ignored.
+func AverageCapacity(capacity int64, schedules []*CapacitySchedule) (decimal.Decimal, error) {
+	// starts is the capacity set by the schedules that start at each minute of the week, and minimums
+	// is the minimum capacity of the schedules with a duration that are running at each minute.
+	starts := make([]*int64, minutesPerWeek)
+	minimums := make([]int64, minutesPerWeek)
+
+	for _, s := range schedules {
+		startMinutes, err := weeklyStartMinutes(s.Start)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		for _, m := range startMinutes {
+			if s.Duration <= 0 {
+				c := s.Capacity
+				starts[m] = &c
+				continue
+			}
+
+			for i := 0; i < int(s.Duration.Minutes()) && i < minutesPerWeek; i++ {
+				j := (m + i) % minutesPerWeek
+				if s.Capacity > minimums[j] {
+					minimums[j] = s.Capacity
+				}
+			}
+		}
+	}
+
+	// The capacity at the start of the week is the one set by the last schedule of the week, since
+	// the schedules repeat every week.
+	current := capacity
+	for _, c := range starts {
+		if c != nil {
+			current = *c
+		}
+	}
+
+	total := int64(0)
+	for m := 0; m < minutesPerWeek; m++ {
+		if starts[m] != nil {
+			current = *starts[m]
+		}
+
+		if minimums[m] > current {
+			total += minimums[m]
+		} else {
+			total += current
+		}
+	}
+
+	return decimal.NewFromInt(total).Div(decimal.NewFromInt(minutesPerWeek)), nil
+}
+
+// weeklyStartMinutes returns the minutes of the week, starting at midnight on Sunday, that the cron
+// expression matches.
+func weeklyStartMinutes(expr string) ([]int, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("Invalid cron expression %q: expected 5 fields", expr)
+	}
+
+	if !isCronWildcard(fields[2]) || !isCronWildcard(fields[3]) {
+		return nil, fmt.Errorf("Unsupported cron expression %q: only schedules that repeat every week are supported", expr)
+	}
+
+	minutes, err := parseCronField(fields[0], 0, 59, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid cron expression %q: %w", expr, err)
+	}
+
+	hours, err := parseCronField(fields[1], 0, 23, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid cron expression %q: %w", expr, err)
+	}
+
+	days, err := parseCronField(fields[4], 0, 7, cronDayNames)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid cron expression %q: %w", expr, err)
+	}
+
+	var startMinutes []int
+	for d := 0; d < 7; d++ {
+		// Sunday can be either 0 or 7
+		if !days[d] && !(d == 0 && days[7]) {
+			continue
+		}
+
+		for h := 0; h < 24; h++ {
+			if !hours[h] {
+				continue
+			}
+
+			for m := 0; m < 60; m++ {
+				if minutes[m] {
+					startMinutes = append(startMinutes, (d*24+h)*60+m)
+				}
+			}
+		}
+	}
+
+	return startMinutes, nil
+}
+
+func isCronWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseCronField returns which of the values from min to max the cron field matches. It supports
+// lists, ranges, steps and the names of the values.
+func parseCronField(field string, min, max int, names map[string]int) ([]bool, error) {
+	matches := make([]bool, max+1)
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q", part)
+			}
+		}
+
+		start, end := min, max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			start, err = parseCronValue(from, min, max, names)
+			if err != nil {
+				return nil, err
+			}
+
+			end = start
+			if isRange {
+				end, err = parseCronValue(to, min, max, names)
+				if err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				end = max
+			}
+
+			if end < start {
+				return nil, fmt.Errorf("invalid range %q", rangePart)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			matches[v] = true
+		}
+	}
+
+	return matches, nil
+}
+
+func parseCronValue(s string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAverageCapacity(t *testing.T) {
+	tests := []struct {
+		name      string
+		capacity  int64
+		schedules []*CapacitySchedule
+		want      decimal.Decimal
+	}{
+		{
+			name:     "no schedules",
+			capacity: 4,
+			want:     decimal.NewFromInt(4),
+		},
+		{
+			name:     "scale to zero at night",
+			capacity: 4,
+			schedules: []*CapacitySchedule{
+				{Start: "0 20 * * *", Capacity: 0},
+				{Start: "0 8 * * *", Capacity: 4},
+			},
+			// 12 hours a day at 4 instances
+			want: decimal.NewFromInt(2),
+		},
+		{
+			name:     "working hours",
+			capacity: 6,
+			schedules: []*CapacitySchedule{
+				{Start: "0 9 * * MON-FRI", Capacity: 6},
+				{Start: "0 17 * * 1-5", Capacity: 0},
+			},
+			// 40 of the 168 hours of the week
+			want: decimal.NewFromInt(6 * 40).Div(decimal.NewFromInt(168)),
+		},
+		{
+			name:     "scale down on the weekend",
+			capacity: 2,
+			schedules: []*CapacitySchedule{
+				{Start: "0 0 * * SAT", Capacity: 0},
+				{Start: "0 0 * * 0", Capacity: 2},
+			},
+			want: decimal.NewFromInt(2 * 6).Div(decimal.NewFromInt(7)),
+		},
+		{
+			name:     "minimum for a duration",
+			capacity: 1,
+			schedules: []*CapacitySchedule{
+				{Start: "0 9 * * *", Capacity: 5, Duration: 6 * time.Hour},
+			},
+			// 5 instances for 6 hours and 1 for the other 18 hours of the day
+			want: decimal.NewFromInt(5*6 + 18).Div(decimal.NewFromInt(24)),
+		},
+		{
+			name:     "minimum below the capacity",
+			capacity: 3,
+			schedules: []*CapacitySchedule{
+				{Start: "*/30 * * * *", Capacity: 2, Duration: 10 * time.Minute},
+			},
+			want: decimal.NewFromInt(3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AverageCapacity(tt.capacity, tt.schedules)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestAverageCapacityInvalidSchedule(t *testing.T) {
+	tests := []struct {
+		start string
+		want  string
+	}{
+		{start: "0 8 * *", want: `Invalid cron expression "0 8 * *": expected 5 fields`},
+		{start: "0 8 1 * *", want: `Unsupported cron expression "0 8 1 * *": only schedules that repeat every week are supported`},
+		{start: "0 25 * * *", want: `Invalid cron expression "0 25 * * *": invalid value "25"`},
+		{start: "0 8 * * FRI-MON", want: `Invalid cron expression "0 8 * * FRI-MON": invalid range "FRI-MON"`},
+		{start: "*/0 8 * * *", want: `Invalid cron expression "*/0 8 * * *": invalid step "*/0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.start, func(t *testing.T) {
+			_, err := AverageCapacity(1, []*CapacitySchedule{{Start: tt.start}})
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
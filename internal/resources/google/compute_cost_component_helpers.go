@@ -2,6 +2,7 @@ package google
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
@@ -124,49 +125,108 @@ func computeDiskCostComponent(region string, diskType string, diskSize float64,
 	}
 }
 
+// guestAcceleratorTypes are the names and SKU description prefixes of the guest accelerator types.
+// The older GPUs get sustained use discounts, but the GPUs of the accelerator-optimized machine
+// types don't.
+var guestAcceleratorTypes = map[string]struct {
+	name                 string
+	descPrefix           string
+	sustainedUseDiscount bool
+}{
+	"nvidia-tesla-t4":   {name: "NVIDIA Tesla T4", descPrefix: "Nvidia Tesla T4 GPU", sustainedUseDiscount: true},
+	"nvidia-tesla-p4":   {name: "NVIDIA Tesla P4", descPrefix: "Nvidia Tesla P4 GPU", sustainedUseDiscount: true},
+	"nvidia-tesla-v100": {name: "NVIDIA Tesla V100", descPrefix: "Nvidia Tesla V100 GPU", sustainedUseDiscount: true},
+	"nvidia-tesla-p100": {name: "NVIDIA Tesla P100", descPrefix: "Nvidia Tesla P100 GPU", sustainedUseDiscount: true},
+	"nvidia-tesla-k80":  {name: "NVIDIA Tesla K80", descPrefix: "Nvidia Tesla K80 GPU", sustainedUseDiscount: true},
+	"nvidia-tesla-a100": {name: "NVIDIA A100 40GB", descPrefix: "Nvidia Tesla A100 GPU"},
+	"nvidia-a100-80gb":  {name: "NVIDIA A100 80GB", descPrefix: "Nvidia Tesla A100 80GB GPU"},
+	"nvidia-l4":         {name: "NVIDIA L4", descPrefix: "Nvidia L4 GPU"},
+	"nvidia-h100-80gb":  {name: "NVIDIA H100 80GB", descPrefix: "Nvidia H100 80GB GPU"},
+}
+
+// g2MachineTypeGPUs is the number of L4 GPUs of each G2 machine type.
+var g2MachineTypeGPUs = map[string]int64{
+	"g2-standard-4":  1,
+	"g2-standard-8":  1,
+	"g2-standard-12": 1,
+	"g2-standard-16": 1,
+	"g2-standard-24": 2,
+	"g2-standard-32": 1,
+	"g2-standard-48": 4,
+	"g2-standard-96": 8,
+}
+
+// machineTypeGuestAccelerators returns the GPUs that come with the accelerator-optimized machine
+// types, e.g. a2-highgpu-2g has 2 A100 GPUs. They're billed separately to the machine type, so
+// they're priced like guest accelerators even though they aren't set in guest_accelerator blocks.
+func machineTypeGuestAccelerators(machineType string) []*ComputeGuestAccelerator {
+	if count, ok := g2MachineTypeGPUs[machineType]; ok {
+		return []*ComputeGuestAccelerator{{Type: "nvidia-l4", Count: count}}
+	}
+
+	var acceleratorType string
+	switch {
+	case strings.HasPrefix(machineType, "a2-highgpu-"), strings.HasPrefix(machineType, "a2-megagpu-"):
+		acceleratorType = "nvidia-tesla-a100"
+	case strings.HasPrefix(machineType, "a2-ultragpu-"):
+		acceleratorType = "nvidia-a100-80gb"
+	case strings.HasPrefix(machineType, "a3-highgpu-"):
+		acceleratorType = "nvidia-h100-80gb"
+	default:
+		return nil
+	}
+
+	// The GPU count is the suffix of the machine type, e.g. 8g.
+	parts := strings.Split(machineType, "-")
+	count, err := strconv.ParseInt(strings.TrimSuffix(parts[len(parts)-1], "g"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return []*ComputeGuestAccelerator{{Type: acceleratorType, Count: count}}
+}
+
+// guestAcceleratorCostComponents returns the cost components for the guest accelerators of Compute
+// resources, or for the GPUs that come with the machine type if no guest accelerators are set.
+// Accelerator types that aren't supported are skipped.
+func guestAcceleratorCostComponents(region string, purchaseOption string, machineType string, guestAccelerators []*ComputeGuestAccelerator, instanceCount int64) []*schema.CostComponent {
+	if len(guestAccelerators) == 0 {
+		guestAccelerators = machineTypeGuestAccelerators(machineType)
+	}
+
+	costComponents := []*schema.CostComponent{}
+	for _, guestAccel := range guestAccelerators {
+		if c := guestAcceleratorCostComponent(region, purchaseOption, guestAccel.Type, guestAccel.Count, instanceCount); c != nil {
+			costComponents = append(costComponents, c)
+		}
+	}
+
+	return costComponents
+}
+
 // guestAcceleratorCostComponent returns a cost component for Guest Accelerator
 // usage for Compute resources.
 func guestAcceleratorCostComponent(region string, purchaseOption string, guestAcceleratorType string, guestAcceleratorCount int64, instanceCount int64) *schema.CostComponent {
-	var (
-		name       string
-		descPrefix string
-	)
-
-	switch guestAcceleratorType {
-	case "nvidia-tesla-t4":
-		name = "NVIDIA Tesla T4"
-		descPrefix = "Nvidia Tesla T4 GPU"
-	case "nvidia-tesla-p4":
-		name = "NVIDIA Tesla P4"
-		descPrefix = "Nvidia Tesla P4 GPU"
-	case "nvidia-tesla-v100":
-		name = "NVIDIA Tesla V100"
-		descPrefix = "Nvidia Tesla V100 GPU"
-	case "nvidia-tesla-p100":
-		name = "NVIDIA Tesla P100"
-		descPrefix = "Nvidia Tesla P100 GPU"
-	case "nvidia-tesla-k80":
-		name = "NVIDIA Tesla K80"
-		descPrefix = "Nvidia Tesla K80 GPU"
-	default:
+	acceleratorType, ok := guestAcceleratorTypes[guestAcceleratorType]
+	if !ok {
 		return nil
 	}
 
-	descRegex := fmt.Sprintf("/^%s running/", descPrefix)
+	descRegex := fmt.Sprintf("/^%s running/", acceleratorType.descPrefix)
 	if strings.ToLower(purchaseOption) == "preemptible" {
-		descRegex = fmt.Sprintf("/^%s attached to Spot Preemptible VMs running/", descPrefix)
+		descRegex = fmt.Sprintf("/^%s attached to Spot Preemptible VMs running/", acceleratorType.descPrefix)
 	}
 
 	count := decimal.NewFromInt(guestAcceleratorCount)
 	count = decimal.NewFromInt(instanceCount).Mul(count)
 
 	sustainedUseDiscount := 0.0
-	if strings.ToLower(purchaseOption) == "on_demand" {
+	if strings.ToLower(purchaseOption) == "on_demand" && acceleratorType.sustainedUseDiscount {
 		sustainedUseDiscount = 0.3
 	}
 
 	return &schema.CostComponent{
-		Name:                fmt.Sprintf("%s (%s)", name, purchaseOptionLabel(purchaseOption)),
+		Name:                fmt.Sprintf("%s (%s)", acceleratorType.name, purchaseOptionLabel(purchaseOption)),
 		Unit:                "hours",
 		UnitMultiplier:      decimal.NewFromInt(1),
 		HourlyQuantity:      decimalPtr(count),
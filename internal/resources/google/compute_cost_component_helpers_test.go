@@ -0,0 +1,47 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMachineTypeGuestAccelerators(t *testing.T) {
+	tests := []struct {
+		machineType string
+		expected    []*ComputeGuestAccelerator
+	}{
+		{"n1-standard-4", nil},
+		{"a2-highgpu-2g", []*ComputeGuestAccelerator{{Type: "nvidia-tesla-a100", Count: 2}}},
+		{"a2-megagpu-16g", []*ComputeGuestAccelerator{{Type: "nvidia-tesla-a100", Count: 16}}},
+		{"a2-ultragpu-8g", []*ComputeGuestAccelerator{{Type: "nvidia-a100-80gb", Count: 8}}},
+		{"a3-highgpu-8g", []*ComputeGuestAccelerator{{Type: "nvidia-h100-80gb", Count: 8}}},
+		{"g2-standard-24", []*ComputeGuestAccelerator{{Type: "nvidia-l4", Count: 2}}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, machineTypeGuestAccelerators(tt.machineType), tt.machineType)
+	}
+}
+
+func TestGuestAcceleratorCostComponents(t *testing.T) {
+	// The GPUs of the machine type are only added if no guest accelerators are set.
+	components := guestAcceleratorCostComponents("us-central1", "on_demand", "a2-highgpu-2g", nil, 3)
+	require.Len(t, components, 1)
+	assert.Equal(t, "NVIDIA A100 40GB (on-demand)", components[0].Name)
+	assert.Equal(t, "6", components[0].HourlyQuantity.String())
+	assert.Equal(t, 0.0, components[0].MonthlyDiscountPerc)
+
+	components = guestAcceleratorCostComponents("us-central1", "preemptible", "n1-standard-8", []*ComputeGuestAccelerator{
+		{Type: "nvidia-tesla-t4", Count: 2},
+		{Type: "nvidia-unknown", Count: 1},
+	}, 1)
+	require.Len(t, components, 1)
+	assert.Equal(t, "NVIDIA Tesla T4 (preemptible)", components[0].Name)
+	assert.Equal(t, "/^Nvidia Tesla T4 GPU attached to Spot Preemptible VMs running/", *components[0].ProductFilter.AttributeFilters[0].ValueRegex)
+
+	components = guestAcceleratorCostComponents("us-central1", "on_demand", "n1-standard-8", []*ComputeGuestAccelerator{{Type: "nvidia-tesla-t4", Count: 1}}, 1)
+	require.Len(t, components, 1)
+	assert.Equal(t, 0.3, components[0].MonthlyDiscountPerc)
+}
@@ -45,9 +45,7 @@ func (r *ComputeInstance) BuildResource() *schema.Resource {
 		costComponents = append(costComponents, scratchDiskCostComponent(r.Region, r.PurchaseOption, r.ScratchDisks))
 	}
 
-	for _, guestAccel := range r.GuestAccelerators {
-		costComponents = append(costComponents, guestAcceleratorCostComponent(r.Region, r.PurchaseOption, guestAccel.Type, guestAccel.Count, r.Size))
-	}
+	costComponents = append(costComponents, guestAcceleratorCostComponents(r.Region, r.PurchaseOption, r.MachineType, r.GuestAccelerators, r.Size)...)
 
 	return &schema.Resource{
 		Name:           r.Address,
@@ -1,6 +1,8 @@
 package google
 
 import (
+	"github.com/shopspring/decimal"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 )
@@ -11,9 +13,12 @@ type ComputeRegionInstanceGroupManager struct {
 	Address string
 	Region  string
 
-	MachineType       string
-	PurchaseOption    string
-	TargetSize        int64
+	MachineType    string
+	PurchaseOption string
+	TargetSize     int64
+	// AverageTargetSize is the time-weighted average number of instances when the group has an
+	// autoscaler with scaling schedules. It's used instead of TargetSize when it's set.
+	AverageTargetSize *decimal.Decimal
 	Disks             []*ComputeDisk
 	GuestAccelerators []*ComputeGuestAccelerator
 }
@@ -31,21 +36,32 @@ func (r *ComputeRegionInstanceGroupManager) PopulateUsage(u *schema.UsageData) {
 // This method is called after the resource is initialised by an IaC provider.
 // See providers folder for more information.
 func (r *ComputeRegionInstanceGroupManager) BuildResource() *schema.Resource {
+	// The cost components are built for a single instance and multiplied by the average target
+	// size since it isn't a whole number of instances.
+	targetSize := r.TargetSize
+	if r.AverageTargetSize != nil {
+		targetSize = 1
+	}
+
 	costComponents := []*schema.CostComponent{
-		computeCostComponent(r.Region, r.MachineType, r.PurchaseOption, r.TargetSize),
+		computeCostComponent(r.Region, r.MachineType, r.PurchaseOption, targetSize),
 	}
 
 	for _, disk := range r.Disks {
-		costComponents = append(costComponents, computeDiskCostComponent(r.Region, disk.Type, disk.Size, r.TargetSize))
+		costComponents = append(costComponents, computeDiskCostComponent(r.Region, disk.Type, disk.Size, targetSize))
 	}
 
-	for _, guestAccel := range r.GuestAccelerators {
-		costComponents = append(costComponents, guestAcceleratorCostComponent(r.Region, r.PurchaseOption, guestAccel.Type, guestAccel.Count, r.TargetSize))
-	}
+	costComponents = append(costComponents, guestAcceleratorCostComponents(r.Region, r.PurchaseOption, r.MachineType, r.GuestAccelerators, targetSize)...)
 
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Name:           r.Address,
 		UsageSchema:    ComputeRegionInstanceGroupManagerUsageSchema,
 		CostComponents: costComponents,
 	}
+
+	if r.AverageTargetSize != nil {
+		schema.MultiplyQuantities(resource, *r.AverageTargetSize)
+	}
+
+	return resource
 }
@@ -52,9 +52,7 @@ func (r *ContainerNodePool) BuildResource() *schema.Resource {
 		costComponents = append(costComponents, scratchDiskCostComponent(r.Region, r.NodeConfig.PurchaseOption, int(r.NodeConfig.LocalSSDCount)))
 	}
 
-	for _, guestAccel := range r.NodeConfig.GuestAccelerators {
-		costComponents = append(costComponents, guestAcceleratorCostComponent(r.Region, r.NodeConfig.PurchaseOption, guestAccel.Type, guestAccel.Count, poolSize))
-	}
+	costComponents = append(costComponents, guestAcceleratorCostComponents(r.Region, r.NodeConfig.PurchaseOption, r.NodeConfig.MachineType, r.NodeConfig.GuestAccelerators, poolSize)...)
 
 	resource := &schema.Resource{
 		Name:           r.Address,
@@ -0,0 +1,181 @@
+package google
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// VertexAIEndpoint defines a Vertex AI endpoint that serves online predictions.
+// Endpoints are charged for the node hours of the models deployed to them. Models
+// are deployed outside of Terraform, so the machine type and node hours come from
+// the usage file.
+//
+// See more resource information here: https://cloud.google.com/vertex-ai/docs/predictions/overview.
+//
+// See the pricing information here: https://cloud.google.com/vertex-ai/pricing#prediction-prices.
+type VertexAIEndpoint struct {
+	Address string
+	Region  string
+
+	// "usage" args
+	MachineType      *string  `infracost_usage:"machine_type"`
+	AcceleratorType  *string  `infracost_usage:"accelerator_type"`
+	AcceleratorCount *int64   `infracost_usage:"accelerator_count"`
+	MonthlyNodeHrs   *float64 `infracost_usage:"monthly_node_hrs"`
+}
+
+// VertexAIEndpointUsageSchema defines a list of usage items for VertexAIEndpoint.
+var VertexAIEndpointUsageSchema = []*schema.UsageItem{
+	{Key: "machine_type", DefaultValue: "n1-standard-2", ValueType: schema.String},
+	{Key: "accelerator_type", DefaultValue: "", ValueType: schema.String},
+	{Key: "accelerator_count", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_node_hrs", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the VertexAIEndpoint.
+// It uses the `infracost_usage` struct tags to populate data into the VertexAIEndpoint.
+func (r *VertexAIEndpoint) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid VertexAIEndpoint struct.
+// Prediction nodes are charged per vCPU and GB of memory of their machine type,
+// plus any GPUs attached to them.
+//
+// This method is called after the resource is initialised by an IaC provider.
+func (r *VertexAIEndpoint) BuildResource() *schema.Resource {
+	machineType := "n1-standard-2"
+	if r.MachineType != nil && *r.MachineType != "" {
+		machineType = *r.MachineType
+	}
+
+	costComponents := []*schema.CostComponent{}
+
+	vCPUs, memoryGB, ok := vertexAIMachineTypeResources(machineType)
+	if ok {
+		family := strings.ToUpper(strings.Split(machineType, "-")[0])
+
+		costComponents = append(costComponents,
+			r.predictionNodeCostComponent(fmt.Sprintf("Prediction node vCPU (%s)", machineType), "vCPU-hours", fmt.Sprintf("Prediction %s Predefined Instance Core", family), vCPUs),
+			r.predictionNodeCostComponent(fmt.Sprintf("Prediction node memory (%s)", machineType), "GB-hours", fmt.Sprintf("Prediction %s Predefined Instance Ram", family), memoryGB),
+		)
+	} else {
+		log.Warnf("Skipping vCPU and memory costs for %s since machine type %s is not supported", r.Address, machineType)
+	}
+
+	accelerators := machineTypeGuestAccelerators(machineType)
+	if r.AcceleratorType != nil && *r.AcceleratorType != "" {
+		accelerators = []*ComputeGuestAccelerator{{Type: *r.AcceleratorType, Count: 1}}
+		if r.AcceleratorCount != nil && *r.AcceleratorCount > 0 {
+			accelerators[0].Count = *r.AcceleratorCount
+		}
+	}
+
+	for _, accel := range accelerators {
+		acceleratorType, ok := guestAcceleratorTypes[accel.Type]
+		if !ok {
+			log.Warnf("Skipping accelerator costs for %s since accelerator type %s is not supported", r.Address, accel.Type)
+			continue
+		}
+
+		costComponents = append(costComponents, r.predictionNodeCostComponent(acceleratorType.name, "hours", fmt.Sprintf("Prediction %s", acceleratorType.descPrefix), float64(accel.Count)))
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    VertexAIEndpointUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+// predictionNodeCostComponent returns a cost component for a resource of the prediction nodes,
+// where perNode is the amount of that resource each node has, e.g. 4 vCPUs.
+func (r *VertexAIEndpoint) predictionNodeCostComponent(name, unit, description string, perNode float64) *schema.CostComponent {
+	var quantity *decimal.Decimal
+	if r.MonthlyNodeHrs != nil {
+		quantity = decimalPtr(decimal.NewFromFloat(*r.MonthlyNodeHrs).Mul(decimal.NewFromFloat(perNode)))
+	}
+
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("Vertex AI"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: regexPtr(fmt.Sprintf("%s running", description))},
+			},
+		},
+	}
+}
+
+// vertexAIMemoryPerVCPU is the GB of memory per vCPU of the predefined machine types that
+// Vertex AI prediction nodes can use.
+var vertexAIMemoryPerVCPU = map[string]float64{
+	"n1-standard":  3.75,
+	"n1-highmem":   6.5,
+	"n1-highcpu":   0.9,
+	"n2-standard":  4,
+	"n2-highmem":   8,
+	"n2-highcpu":   1,
+	"n2d-standard": 4,
+	"n2d-highmem":  8,
+	"n2d-highcpu":  1,
+	"e2-standard":  4,
+	"e2-highmem":   8,
+	"e2-highcpu":   1,
+	"c2-standard":  4,
+	"g2-standard":  4,
+}
+
+// vertexAIPerGPUResources is the vCPUs and GB of memory per GPU of the accelerator-optimized
+// machine types, e.g. a2-highgpu-2g has 24 vCPUs and 170 GB of memory.
+var vertexAIPerGPUResources = map[string][2]float64{
+	"a2-highgpu":  {12, 85},
+	"a2-megagpu":  {6, 85},
+	"a2-ultragpu": {12, 170},
+	"a3-highgpu":  {26, 234},
+}
+
+// vertexAIMachineTypeResources returns the vCPUs and GB of memory of the machine type, or false
+// if the machine type isn't supported.
+func vertexAIMachineTypeResources(machineType string) (float64, float64, bool) {
+	parts := strings.Split(machineType, "-")
+	if len(parts) != 3 {
+		return 0, 0, false
+	}
+
+	series := parts[0] + "-" + parts[1]
+
+	if perGPU, ok := vertexAIPerGPUResources[series]; ok {
+		gpus, err := strconv.ParseFloat(strings.TrimSuffix(parts[2], "g"), 64)
+		if err != nil {
+			return 0, 0, false
+		}
+
+		return perGPU[0] * gpus, perGPU[1] * gpus, true
+	}
+
+	memoryPerVCPU, ok := vertexAIMemoryPerVCPU[series]
+	if !ok {
+		return 0, 0, false
+	}
+
+	vCPUs, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return vCPUs, vCPUs * memoryPerVCPU, true
+}
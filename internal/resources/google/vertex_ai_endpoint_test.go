@@ -0,0 +1,70 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVertexAIMachineTypeResources(t *testing.T) {
+	tests := []struct {
+		machineType string
+		vCPUs       float64
+		memoryGB    float64
+		ok          bool
+	}{
+		{"n1-standard-4", 4, 15, true},
+		{"n1-highcpu-16", 16, 14.4, true},
+		{"g2-standard-12", 12, 48, true},
+		{"a2-highgpu-2g", 24, 170, true},
+		{"a2-megagpu-16g", 96, 1360, true},
+		{"a3-highgpu-8g", 208, 1872, true},
+		{"n1-custom-4-5120", 0, 0, false},
+		{"m1-ultramem-40", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		vCPUs, memoryGB, ok := vertexAIMachineTypeResources(tt.machineType)
+		assert.Equal(t, tt.ok, ok, tt.machineType)
+		assert.InDelta(t, tt.vCPUs, vCPUs, 0.001, tt.machineType)
+		assert.InDelta(t, tt.memoryGB, memoryGB, 0.001, tt.machineType)
+	}
+}
+
+func TestVertexAIEndpoint(t *testing.T) {
+	r := &VertexAIEndpoint{
+		Address: "google_vertex_ai_endpoint.endpoint",
+		Region:  "us-central1",
+	}
+
+	// The cost depends on usage, using the default machine type of Vertex AI.
+	resource := r.BuildResource()
+	require.Len(t, resource.CostComponents, 2)
+	assert.Equal(t, "Prediction node vCPU (n1-standard-2)", resource.CostComponents[0].Name)
+	assert.Nil(t, resource.CostComponents[0].MonthlyQuantity)
+
+	machineType := "g2-standard-24"
+	hrs := 100.0
+	r.MachineType = &machineType
+	r.MonthlyNodeHrs = &hrs
+
+	resource = r.BuildResource()
+	require.Len(t, resource.CostComponents, 3)
+	assert.Equal(t, "2400", resource.CostComponents[0].MonthlyQuantity.String())
+	assert.Equal(t, "/Prediction G2 Predefined Instance Core running/i", *resource.CostComponents[0].ProductFilter.AttributeFilters[0].ValueRegex)
+	assert.Equal(t, "9600", resource.CostComponents[1].MonthlyQuantity.String())
+	assert.Equal(t, "NVIDIA L4", resource.CostComponents[2].Name)
+	assert.Equal(t, "200", resource.CostComponents[2].MonthlyQuantity.String())
+
+	machineType = "n1-standard-4"
+	acceleratorType := "nvidia-tesla-t4"
+	acceleratorCount := int64(2)
+	r.AcceleratorType = &acceleratorType
+	r.AcceleratorCount = &acceleratorCount
+
+	resource = r.BuildResource()
+	require.Len(t, resource.CostComponents, 3)
+	assert.Equal(t, "NVIDIA Tesla T4", resource.CostComponents[2].Name)
+	assert.Equal(t, "200", resource.CostComponents[2].MonthlyQuantity.String())
+}
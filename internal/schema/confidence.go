@@ -0,0 +1,75 @@
+package schema
+
+// The confidence levels of the estimate of a resource.
+const (
+	// ConfidenceHigh resources are priced from their attributes, and from the usage data for all of
+	// their usage keys.
+	ConfidenceHigh = "high"
+	// ConfidenceMedium resources assume default values for some of their usage keys since they aren't
+	// set in the usage data.
+	ConfidenceMedium = "medium"
+	// ConfidenceLow resources have usage-based costs that aren't included in the estimate since there
+	// is no usage data for them, or are unsupported resources with a heuristic estimate.
+	ConfidenceLow = "low"
+)
+
+// SetConfidence sets the confidence level of each of the priced resources. Free and unsupported
+// resources don't have a confidence level.
+func SetConfidence(resources []*Resource) {
+	for _, r := range resources {
+		if r.NoPrice || r.IsSkipped {
+			continue
+		}
+
+		r.Confidence = resourceConfidence(r)
+	}
+}
+
+func resourceConfidence(r *Resource) string {
+	// Heuristic estimates are guessed from the resource type, so they're never more than rough.
+	if r.HeuristicCategory != "" || hasUnknownQuantity(r) {
+		return ConfidenceLow
+	}
+
+	if assumesDefaultUsage(r) {
+		return ConfidenceMedium
+	}
+
+	return ConfidenceHigh
+}
+
+// hasUnknownQuantity returns true if any of the cost components of the resource or its sub-resources
+// have no quantity, which is how the usage-based costs without usage data are shown.
+func hasUnknownQuantity(r *Resource) bool {
+	for _, c := range r.CostComponents {
+		if c.HourlyQuantity == nil && c.MonthlyQuantity == nil {
+			return true
+		}
+	}
+
+	for _, s := range r.SubResources {
+		if hasUnknownQuantity(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// assumesDefaultUsage returns true if the resource or its sub-resources have usage keys that don't
+// have an estimate in the usage data.
+func assumesDefaultUsage(r *Resource) bool {
+	for _, u := range r.UsageSchema {
+		if !r.EstimationSummary[u.Key] {
+			return true
+		}
+	}
+
+	for _, s := range r.SubResources {
+		if assumesDefaultUsage(s) {
+			return true
+		}
+	}
+
+	return false
+}
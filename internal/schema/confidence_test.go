@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetConfidence(t *testing.T) {
+	quantity := decimal.NewFromInt(1)
+
+	priced := &Resource{
+		Name:           "aws_instance.web",
+		CostComponents: []*CostComponent{{Name: "Instance usage", HourlyQuantity: &quantity}},
+	}
+	usageSet := &Resource{
+		Name:              "aws_instance.worker",
+		CostComponents:    []*CostComponent{{Name: "Instance usage", HourlyQuantity: &quantity}},
+		UsageSchema:       []*UsageItem{{Key: "operating_system"}},
+		EstimationSummary: map[string]bool{"operating_system": true},
+	}
+	defaultUsage := &Resource{
+		Name:           "aws_instance.batch",
+		CostComponents: []*CostComponent{{Name: "Instance usage", HourlyQuantity: &quantity}},
+		UsageSchema:    []*UsageItem{{Key: "operating_system"}},
+	}
+	noUsage := &Resource{
+		Name: "aws_s3_bucket.logs",
+		SubResources: []*Resource{
+			{Name: "Standard", CostComponents: []*CostComponent{{Name: "Storage"}}},
+		},
+	}
+	free := &Resource{Name: "aws_vpc.main", NoPrice: true}
+
+	SetConfidence([]*Resource{priced, usageSet, defaultUsage, noUsage, free})
+
+	assert.Equal(t, ConfidenceHigh, priced.Confidence)
+	assert.Equal(t, ConfidenceHigh, usageSet.Confidence)
+	assert.Equal(t, ConfidenceMedium, defaultUsage.Confidence)
+	assert.Equal(t, ConfidenceLow, noUsage.Confidence)
+	assert.Empty(t, free.Confidence)
+}
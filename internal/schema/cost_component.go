@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 )
 
@@ -17,12 +19,22 @@ type CostComponent struct {
 	price                decimal.Decimal
 	customPrice          *decimal.Decimal
 	priceHash            string
+	priceEffectiveDate   *time.Time
 	HourlyCost           *decimal.Decimal
 	MonthlyCost          *decimal.Decimal
+	// PriceTiers are set for cost components whose price depends on the quantity used, e.g. the first
+	// 50TB of S3 storage is priced differently to the next 450TB. The monthly quantity is split across
+	// the tiers in order and the price of the cost component is the average price of the quantity.
+	PriceTiers []*PriceTier
 }
 
 func (c *CostComponent) CalculateCosts() {
 	c.fillQuantities()
+	if len(c.PriceTiers) > 0 && c.customPrice == nil {
+		c.calculateTierCosts()
+		return
+	}
+
 	if c.HourlyQuantity != nil {
 		c.HourlyCost = decimalPtr(c.price.Mul(*c.HourlyQuantity))
 	}
@@ -32,6 +44,68 @@ func (c *CostComponent) CalculateCosts() {
 	}
 }
 
+// calculateTierCosts splits the monthly quantity across the price tiers and sets the cost to the sum
+// of the costs of the tiers. Cost components that only have an hourly quantity are split by the monthly
+// quantity it's filled in from, since the tiers are ranges of the monthly usage. If there's no quantity
+// the price of the first tier is used as the price, so it's shown as the price of the usage.
+func (c *CostComponent) calculateTierCosts() {
+	c.price = c.PriceTiers[0].price
+	for _, t := range c.PriceTiers {
+		t.MonthlyQuantity = nil
+		t.MonthlyCost = nil
+	}
+
+	if c.MonthlyQuantity == nil {
+		return
+	}
+
+	discountMul := decimal.NewFromFloat(1.0 - c.MonthlyDiscountPerc)
+	remaining := *c.MonthlyQuantity
+	total := decimal.Zero
+
+	for i, t := range c.PriceTiers {
+		quantity := remaining
+		if i < len(c.PriceTiers)-1 {
+			size := c.PriceTiers[i+1].StartUsageAmount.Sub(t.StartUsageAmount)
+			if quantity.GreaterThan(size) {
+				quantity = size
+			}
+		}
+		if quantity.IsNegative() {
+			quantity = decimal.Zero
+		}
+		remaining = remaining.Sub(quantity)
+
+		t.MonthlyQuantity = decimalPtr(quantity)
+		t.MonthlyCost = decimalPtr(t.price.Mul(quantity).Mul(discountMul))
+		total = total.Add(t.price.Mul(quantity))
+	}
+
+	if c.MonthlyQuantity.IsPositive() {
+		c.price = total.Div(*c.MonthlyQuantity)
+	}
+
+	c.MonthlyCost = decimalPtr(total.Mul(discountMul))
+	c.HourlyCost = decimalPtr(c.MonthlyCost.Div(HourToMonthUnitMultiplier))
+}
+
+// TierPriceFilter returns the price filter used to look up the price of a price tier. It's the
+// cost component's price filter for the start usage amount of the tier, unless the tier has its own.
+func (c *CostComponent) TierPriceFilter(t *PriceTier) *PriceFilter {
+	if t.PriceFilter != nil {
+		return t.PriceFilter
+	}
+
+	f := PriceFilter{}
+	if c.PriceFilter != nil {
+		f = *c.PriceFilter
+	}
+	start := t.StartUsageAmount.String()
+	f.StartUsageAmount = &start
+
+	return &f
+}
+
 func (c *CostComponent) fillQuantities() {
 	if c.MonthlyQuantity != nil && c.HourlyQuantity == nil {
 		c.HourlyQuantity = decimalPtr(c.MonthlyQuantity.Div(HourToMonthUnitMultiplier))
@@ -56,6 +130,20 @@ func (c *CostComponent) PriceHash() string {
 	return c.priceHash
 }
 
+// SetPriceEffectiveDate sets the date that the price of the cost component took effect in the price
+// list. Tiered cost components have a price for each tier, so the oldest date is kept.
+func (c *CostComponent) SetPriceEffectiveDate(t time.Time) {
+	if c.priceEffectiveDate == nil || t.Before(*c.priceEffectiveDate) {
+		c.priceEffectiveDate = &t
+	}
+}
+
+// PriceEffectiveDate returns the date that the price took effect, or nil if the pricing backend
+// didn't return one.
+func (c *CostComponent) PriceEffectiveDate() *time.Time {
+	return c.priceEffectiveDate
+}
+
 func (c *CostComponent) SetCustomPrice(price *decimal.Decimal) {
 	c.customPrice = price
 }
@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tieredCostComponent(quantity *decimal.Decimal) *CostComponent {
+	start, unit := "0", "GB-Mo"
+
+	return &CostComponent{
+		Name:            "Storage",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		PriceFilter:     &PriceFilter{StartUsageAmount: &start, Unit: &unit},
+		PriceTiers: []*PriceTier{
+			{Name: "first 10GB", StartUsageAmount: decimal.Zero, price: decimal.NewFromInt(3)},
+			{Name: "next 20GB", StartUsageAmount: decimal.NewFromInt(10), price: decimal.NewFromInt(2)},
+			{Name: "over 30GB", StartUsageAmount: decimal.NewFromInt(30), price: decimal.NewFromInt(1)},
+		},
+	}
+}
+
+func TestCostComponentPriceTiers(t *testing.T) {
+	c := tieredCostComponent(decimalPtr(decimal.NewFromInt(35)))
+	c.CalculateCosts()
+
+	for i, want := range []struct{ quantity, cost int64 }{{10, 30}, {20, 40}, {5, 5}} {
+		require.NotNil(t, c.PriceTiers[i].MonthlyQuantity, c.PriceTiers[i].Name)
+		assert.Equal(t, decimal.NewFromInt(want.quantity).String(), c.PriceTiers[i].MonthlyQuantity.String(), c.PriceTiers[i].Name)
+		assert.Equal(t, decimal.NewFromInt(want.cost).String(), c.PriceTiers[i].MonthlyCost.String(), c.PriceTiers[i].Name)
+	}
+
+	assert.Equal(t, "75", c.MonthlyCost.String())
+	assert.True(t, c.HourlyCost.Equal(decimal.NewFromInt(75).Div(HourToMonthUnitMultiplier)))
+	assert.True(t, c.Price().Equal(decimal.NewFromInt(75).Div(decimal.NewFromInt(35))))
+
+	small := tieredCostComponent(decimalPtr(decimal.NewFromInt(4)))
+	small.CalculateCosts()
+	assert.Equal(t, "12", small.MonthlyCost.String())
+	assert.Equal(t, "0", small.PriceTiers[1].MonthlyQuantity.String())
+	assert.Equal(t, "3", small.Price().String())
+}
+
+func TestCostComponentPriceTiersHourlyQuantity(t *testing.T) {
+	c := tieredCostComponent(nil)
+	c.HourlyQuantity = decimalPtr(decimal.NewFromInt(1))
+	c.CalculateCosts()
+
+	require.NotNil(t, c.MonthlyQuantity)
+	assert.Equal(t, "730", c.MonthlyQuantity.String())
+	assert.Equal(t, "700", c.PriceTiers[2].MonthlyQuantity.String())
+	assert.Equal(t, "770", c.MonthlyCost.String())
+	assert.True(t, c.HourlyCost.Equal(decimal.NewFromInt(770).Div(HourToMonthUnitMultiplier)))
+	assert.True(t, c.Price().Equal(decimal.NewFromInt(770).Div(decimal.NewFromInt(730))))
+}
+
+func TestCostComponentPriceTiersWithoutQuantity(t *testing.T) {
+	c := tieredCostComponent(nil)
+	c.CalculateCosts()
+
+	assert.Nil(t, c.MonthlyCost)
+	assert.Nil(t, c.PriceTiers[0].MonthlyQuantity)
+	assert.Equal(t, "3", c.Price().String())
+}
+
+func TestCostComponentPriceTiersCustomPrice(t *testing.T) {
+	c := tieredCostComponent(decimalPtr(decimal.NewFromInt(35)))
+	price := decimal.NewFromFloat(0.5)
+	c.SetCustomPrice(&price)
+	c.SetPrice(price)
+	c.CalculateCosts()
+
+	assert.Equal(t, "17.5", c.MonthlyCost.String())
+	assert.Nil(t, c.PriceTiers[0].MonthlyQuantity)
+}
+
+func TestCostComponentTierPriceFilter(t *testing.T) {
+	c := tieredCostComponent(nil)
+
+	f := c.TierPriceFilter(c.PriceTiers[1])
+	assert.Equal(t, "10", *f.StartUsageAmount)
+	assert.Equal(t, "GB-Mo", *f.Unit)
+	assert.Equal(t, "0", *c.PriceFilter.StartUsageAmount)
+
+	end := "Inf"
+	c.PriceTiers[2].PriceFilter = &PriceFilter{EndUsageAmount: &end}
+	assert.Equal(t, c.PriceTiers[2].PriceFilter, c.TierPriceFilter(c.PriceTiers[2]))
+}
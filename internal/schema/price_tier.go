@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// PriceTier is a range of the quantity of a tiered cost component that has its own price.
+type PriceTier struct {
+	// Name describes the range, e.g. first 10TB or over 150TB.
+	Name string
+	// StartUsageAmount is the quantity the tier starts at, in the same units as the cost component's
+	// monthly quantity. The tier ends where the next one starts.
+	StartUsageAmount decimal.Decimal
+	// PriceFilter overrides the price filter used to look up the price of the tier, for prices that
+	// can't be matched by their start usage amount.
+	PriceFilter     *PriceFilter
+	MonthlyQuantity *decimal.Decimal
+	MonthlyCost     *decimal.Decimal
+	price           decimal.Decimal
+	priceHash       string
+}
+
+func (t *PriceTier) SetPrice(price decimal.Decimal) {
+	t.price = price
+}
+
+func (t *PriceTier) Price() decimal.Decimal {
+	return t.price
+}
+
+func (t *PriceTier) SetPriceHash(priceHash string) {
+	t.priceHash = priceHash
+}
+
+func (t *PriceTier) PriceHash() string {
+	return t.priceHash
+}
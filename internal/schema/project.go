@@ -8,18 +8,50 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 )
 
 type ProjectMetadata struct {
-	Path               string `json:"path"`
-	Type               string `json:"type"`
-	VCSRepoURL         string `json:"vcsRepoUrl,omitempty"`
-	VCSSubPath         string `json:"vcsSubPath,omitempty"`
-	VCSPullRequestURL  string `json:"vcsPullRequestUrl,omitempty"`
-	TerraformWorkspace string `json:"terraformWorkspace,omitempty"`
+	Path                     string            `json:"path"`
+	Type                     string            `json:"type"`
+	VCSRepoURL               string            `json:"vcsRepoUrl,omitempty"`
+	VCSSubPath               string            `json:"vcsSubPath,omitempty"`
+	VCSPullRequestURL        string            `json:"vcsPullRequestUrl,omitempty"`
+	TerraformWorkspace       string            `json:"terraformWorkspace,omitempty"`
+	TerraformStackDeployment string            `json:"terraformStackDeployment,omitempty"`
+	Labels                   map[string]string `json:"labels,omitempty"`
+	MonthlyBudget            *decimal.Decimal  `json:"monthlyBudget,omitempty"`
+	// Lifetime is how long an ephemeral project, e.g. a preview environment, exists for. See ParseLifetime.
+	Lifetime string `json:"lifetime,omitempty"`
+	// PricesRetrievedAt is when the prices of the project were retrieved from the pricing API, which is
+	// before the run if they're from a pricing snapshot. It's not set if the pricing backend doesn't know.
+	PricesRetrievedAt *time.Time `json:"pricesRetrievedAt,omitempty"`
+}
+
+// ParseLifetime parses the lifetime of a project, which is either a duration such as 72h or 90m, or a
+// number of days such as 7d.
+func ParseLifetime(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+
+	if days := strings.TrimSuffix(s, "d"); days != s {
+		var n float64
+		n, err = strconv.ParseFloat(days, 64)
+		d = time.Duration(n * float64(24*time.Hour))
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("lifetime %q is not a valid duration, e.g. 72h or 7d", s)
+	}
+
+	return d, nil
 }
 
 // Projects is a slice of Project that is ordered alphabetically by project name.
@@ -101,6 +133,10 @@ func GenerateProjectName(metadata *ProjectMetadata, dashboardEnabled bool) strin
 		n += fmt.Sprintf(" (%s)", metadata.TerraformWorkspace)
 	}
 
+	if metadata.TerraformStackDeployment != "" {
+		n += fmt.Sprintf(" (%s)", metadata.TerraformStackDeployment)
+	}
+
 	return n
 }
 
@@ -0,0 +1,93 @@
+package schema
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// ProjectNameData is the data that project name templates are executed with.
+type ProjectNameData struct {
+	// Name is the name that would be generated for the project if it didn't have a name template.
+	Name string
+	// Path is the path of the project, e.g. the Terragrunt module directory for projects found by
+	// Terragrunt.
+	Path string
+	// Dir is the directory of the project, which is the path unless it's a file, e.g. a plan JSON file.
+	Dir string
+	// Workspace is the Terraform workspace, which is default if none is set.
+	Workspace string
+	// StackDeployment is the Terraform Stacks deployment, if the project is one.
+	StackDeployment string
+	// Repo is the org/repo name of the VCS repository.
+	Repo string
+	// Labels are the labels of the project from the config file.
+	Labels map[string]string
+}
+
+// projectNameFuncs are the functions that can be used in project name templates, in addition to the
+// builtin text/template functions. The functions that take more than one argument take the string
+// last so they can be used in pipelines, e.g. {{ .Dir | trimPrefix "envs/" }}.
+var projectNameFuncs = template.FuncMap{
+	"base":       filepath.Base,
+	"dir":        filepath.Dir,
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+}
+
+// ParseProjectNameTemplate parses a project name template from the config file.
+func ParseProjectNameTemplate(s string) (*template.Template, error) {
+	return template.New("name").Option("missingkey=zero").Funcs(projectNameFuncs).Parse(s)
+}
+
+// NewProjectNameData returns the data for executing a project name template for the project.
+func NewProjectNameData(project *Project) ProjectNameData {
+	data := ProjectNameData{
+		Name:      project.Name,
+		Workspace: "default",
+	}
+
+	m := project.Metadata
+	if m == nil {
+		return data
+	}
+
+	data.Path = m.Path
+	data.Dir = m.Path
+	if info, err := os.Stat(m.Path); err == nil && !info.IsDir() {
+		data.Dir = filepath.Dir(m.Path)
+	}
+
+	if m.TerraformWorkspace != "" {
+		data.Workspace = m.TerraformWorkspace
+	}
+	data.StackDeployment = m.TerraformStackDeployment
+	data.Labels = m.Labels
+
+	if m.VCSRepoURL != "" {
+		data.Repo = nameFromRepoURL(m.VCSRepoURL)
+	}
+
+	return data
+}
+
+// RenderProjectName executes the project name template for the project.
+func RenderProjectName(tmpl string, project *Project) (string, error) {
+	t, err := ParseProjectNameTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = t.Execute(&buf, NewProjectNameData(project))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
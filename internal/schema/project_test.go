@@ -2,6 +2,7 @@ package schema
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -26,3 +27,64 @@ func TestNameFromRepoURL(t *testing.T) {
 		assert.Equal(t, test.name, actual)
 	}
 }
+
+func TestParseLifetime(t *testing.T) {
+	tests := []struct {
+		lifetime string
+		expected time.Duration
+		err      string
+	}{
+		{lifetime: "72h", expected: 72 * time.Hour},
+		{lifetime: "90m", expected: 90 * time.Minute},
+		{lifetime: "7d", expected: 7 * 24 * time.Hour},
+		{lifetime: "1.5d", expected: 36 * time.Hour},
+		{lifetime: "3 days", err: `lifetime "3 days" is not a valid duration, e.g. 72h or 7d`},
+		{lifetime: "0h", err: `lifetime "0h" is not a valid duration, e.g. 72h or 7d`},
+		{lifetime: "-2d", err: `lifetime "-2d" is not a valid duration, e.g. 72h or 7d`},
+	}
+
+	for _, test := range tests {
+		actual, err := ParseLifetime(test.lifetime)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, actual)
+	}
+}
+
+func TestRenderProjectName(t *testing.T) {
+	project := &Project{
+		Name: "org/repo/envs/prod",
+		Metadata: &ProjectMetadata{
+			Path:               "envs/prod",
+			VCSRepoURL:         "git@github.com:org/repo.git",
+			TerraformWorkspace: "eu",
+			Labels:             map[string]string{"team": "platform"},
+		},
+	}
+
+	tests := []struct {
+		tmpl     string
+		expected string
+	}{
+		{tmpl: "{{ .Dir | base }}-{{ .Workspace }}", expected: "prod-eu"},
+		{tmpl: `{{ .Path | trimPrefix "envs/" | upper }}`, expected: "PROD"},
+		{tmpl: "{{ .Labels.team }}: {{ .Repo }}", expected: "platform: org/repo"},
+		{tmpl: `{{ .Name | replace "/" "-" }}`, expected: "org-repo-envs-prod"},
+		{tmpl: "{{ .Labels.owner }}", expected: ""},
+	}
+
+	for _, test := range tests {
+		actual, err := RenderProjectName(test.tmpl, project)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, actual)
+	}
+
+	project.Metadata.TerraformWorkspace = ""
+	actual, err := RenderProjectName("{{ .Workspace }}", project)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", actual)
+}
@@ -6,3 +6,27 @@ type Provider interface {
 	AddMetadata(*ProjectMetadata)
 	LoadResources(map[string]*UsageData) ([]*Project, error)
 }
+
+// StreamingProvider is implemented by the providers that load more than one project, e.g. a
+// project for each Terragrunt module, so that each project can be priced as soon as it's loaded
+// instead of after all of them have been loaded. Providers that load a single project don't implement
+// it, since their project can't be priced until all of its resources have been evaluated, but they
+// can implement PrefetchingProvider.
+type StreamingProvider interface {
+	Provider
+	// StreamResources loads the projects like LoadResources, calling onProject with each project
+	// as soon as it has been loaded. The provider doesn't change a project after it's passed to
+	// onProject, so it can be used by another goroutine.
+	StreamResources(usage map[string]*UsageData, onProject func(*Project)) ([]*Project, error)
+}
+
+// PrefetchingProvider is implemented by the providers that can pass resources on to be priced
+// while the rest of their project is still being loaded, e.g. the resources of each Terraform module
+// as soon as the module has been evaluated. These resources are only used to fetch prices ahead of
+// time, the project is still priced once all of its resources have been loaded.
+type PrefetchingProvider interface {
+	Provider
+	// SetPrefetchFunc sets the func that is called with the resources as they're loaded by the next
+	// call to LoadResources. The provider doesn't change the resources after they're passed to f.
+	SetPrefetchFunc(f func(resources []*Resource))
+}
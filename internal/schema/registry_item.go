@@ -10,4 +10,7 @@ type RegistryItem struct {
 	ReferenceAttributes []string
 	CustomRefIDFunc     ReferenceIDFunc
 	NoPrice             bool
+	// RollupReference is the reference attribute of the resource that this resource is nested under when
+	// resources are rolled up, e.g. the cluster of a Kubernetes node pool.
+	RollupReference string
 }
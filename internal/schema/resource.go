@@ -25,6 +25,41 @@ type Resource struct {
 	UsageSchema       []*UsageItem
 	EstimateUsage     EstimateFunc
 	EstimationSummary map[string]bool
+	Suppressions      []Suppression
+	// MonthlyGrowthRate is the percentage that the monthly cost of the resource is expected
+	// to grow by each month. This is used to forecast the future cost of the resource.
+	MonthlyGrowthRate *decimal.Decimal
+	// ProviderConfigKey is the key of the provider config used by the resource, e.g. aws.prod
+	ProviderConfigKey string
+	// Alternatives are other ways of providing what the resource does, e.g. a NAT instance instead of a
+	// NAT gateway. They are priced so their cost can be compared, but aren't included in any totals.
+	Alternatives []*Resource
+	// RollupParent is the name of the resource that this resource is nested under when resources
+	// are rolled up, e.g. the cluster of a Kubernetes node pool.
+	RollupParent string
+	// References are the addresses of the resources that this resource references, e.g. the VPC
+	// of a subnet. Only the reference attributes in the resource registry are tracked.
+	References []string
+	// Trace holds the attributes and usage keys that were read to build the resource. It is only
+	// set for the resource passed to --trace-resource.
+	Trace *Tracer
+	// ImageID is the machine image that a compute resource runs, e.g. the AMI of an EC2 instance, and
+	// InstanceCount is the number of instances that run it. They're set for compute resources so that
+	// the license fees in the usage file can be added to them.
+	ImageID       string
+	InstanceCount *decimal.Decimal
+	// CostCenters is the percentage of the cost of the resource that is allocated to each cost center
+	// by the cost center rules in the config file.
+	CostCenters map[string]decimal.Decimal
+	// Owner is the owners of the file that defines the resource in the repo's CODEOWNERS file, e.g.
+	// @org/platform. It's only set when owners are attributed in the config.
+	Owner string
+	// HeuristicCategory is the category that an unsupported resource was guessed to be in, e.g.
+	// compute, when it's given a rough estimate by heuristic pricing instead of being skipped.
+	HeuristicCategory string
+	// Confidence is how much the estimate of the resource can be trusted, e.g. ConfidenceLow if it has
+	// usage-based costs without usage data. It's only set when it is shown in the output.
+	Confidence string
 }
 
 func CalculateCosts(project *Project) {
@@ -68,6 +103,11 @@ func (r *Resource) CalculateCosts() {
 		r.HourlyCost = &h
 		r.MonthlyCost = &m
 	}
+
+	for _, a := range r.Alternatives {
+		a.CalculateCosts()
+	}
+
 	if r.NoPrice {
 		log.Debugf("Skipping free resource %s", r.Name)
 	}
@@ -104,7 +144,44 @@ func SortResources(project *Project) {
 	})
 }
 
+// RollupResources nests each resource that has a RollupParent under that resource as a sub resource,
+// so its cost is included in the parent's cost, and returns the remaining top-level resources.
+// Resources whose parent isn't in resources or is skipped are left at the top level.
+func RollupResources(resources []*Resource) []*Resource {
+	byName := make(map[string]*Resource, len(resources))
+	for _, r := range resources {
+		byName[r.Name] = r
+	}
+
+	children := make(map[*Resource][]*Resource)
+	topLevel := make([]*Resource, 0, len(resources))
+
+	for _, r := range resources {
+		parent, ok := byName[r.RollupParent]
+		if r.IsSkipped || !ok || parent == r || parent.IsSkipped {
+			topLevel = append(topLevel, r)
+			continue
+		}
+
+		children[parent] = append(children[parent], r)
+	}
+
+	for parent, subResources := range children {
+		sort.Slice(subResources, func(i, j int) bool {
+			return subResources[i].Name < subResources[j].Name
+		})
+
+		parent.SubResources = append(parent.SubResources, subResources...)
+	}
+
+	return topLevel
+}
+
 func MultiplyQuantities(resource *Resource, multiplier decimal.Decimal) {
+	if resource.InstanceCount != nil {
+		resource.InstanceCount = decimalPtr(resource.InstanceCount.Mul(multiplier))
+	}
+
 	for _, costComponent := range resource.CostComponents {
 		if costComponent.HourlyQuantity != nil {
 			costComponent.HourlyQuantity = decimalPtr(costComponent.HourlyQuantity.Mul(multiplier))
@@ -2,6 +2,7 @@ package schema
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/awslabs/goformation/v4/cloudformation"
 
@@ -17,6 +18,10 @@ type ResourceData struct {
 	referencesMap map[string][]*ResourceData
 	CFResource    cloudformation.Resource
 	UsageData     *UsageData
+	// ProviderConfigKey is the key of the provider config used by the resource, e.g. aws.prod
+	ProviderConfigKey string
+	// Tracer records the attributes read from the resource, if it is being traced.
+	Tracer *Tracer
 }
 
 func NewResourceData(resourceType string, providerName string, address string, tags map[string]string, rawValues gjson.Result) *ResourceData {
@@ -44,16 +49,21 @@ func NewCFResourceData(resourceType string, providerName string, address string,
 }
 
 func (d *ResourceData) Get(key string) gjson.Result {
-	return d.RawValues.Get(key)
+	v := d.RawValues.Get(key)
+	d.Tracer.traceAttribute(key, v.Raw, false)
+
+	return v
 }
 
 // GetStringOrDefault returns the value of key within ResourceData as a string.
 // If the retrieved value is not set GetStringOrDefault will return def.
 func (d *ResourceData) GetStringOrDefault(key, def string) string {
 	if !d.IsEmpty(key) {
-		return d.RawValues.Get(key).String()
+		return d.Get(key).String()
 	}
 
+	d.Tracer.traceAttribute(key, fmt.Sprint(def), true)
+
 	return def
 }
 
@@ -61,9 +71,11 @@ func (d *ResourceData) GetStringOrDefault(key, def string) string {
 // If the retrieved value is not set GetInt64OrDefault will return def.
 func (d *ResourceData) GetInt64OrDefault(key string, def int64) int64 {
 	if !d.IsEmpty(key) {
-		return d.RawValues.Get(key).Int()
+		return d.Get(key).Int()
 	}
 
+	d.Tracer.traceAttribute(key, fmt.Sprint(def), true)
+
 	return def
 }
 
@@ -71,17 +83,21 @@ func (d *ResourceData) GetInt64OrDefault(key string, def int64) int64 {
 // If the retrieved value is not set GetFloat64OrDefault will return def.
 func (d *ResourceData) GetFloat64OrDefault(key string, def float64) float64 {
 	if !d.IsEmpty(key) {
-		return d.RawValues.Get(key).Float()
+		return d.Get(key).Float()
 	}
 
+	d.Tracer.traceAttribute(key, fmt.Sprint(def), true)
+
 	return def
 }
 
 func (d *ResourceData) GetBoolOrDefault(key string, def bool) bool {
 	if !d.IsEmpty(key) {
-		return d.RawValues.Get(key).Bool()
+		return d.Get(key).Bool()
 	}
 
+	d.Tracer.traceAttribute(key, fmt.Sprint(def), true)
+
 	return def
 }
 
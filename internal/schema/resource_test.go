@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupResources(t *testing.T) {
+	cluster := &Resource{
+		Name: "aws_eks_cluster.main",
+		CostComponents: []*CostComponent{
+			{Name: "EKS cluster", HourlyQuantity: decimalPtr(decimal.NewFromInt(1)), price: decimal.NewFromFloat(0.1)},
+		},
+	}
+	nodeGroupB := &Resource{
+		Name:         "aws_eks_node_group.b",
+		RollupParent: "aws_eks_cluster.main",
+		CostComponents: []*CostComponent{
+			{Name: "Instance usage", HourlyQuantity: decimalPtr(decimal.NewFromInt(2)), price: decimal.NewFromFloat(0.05)},
+		},
+	}
+	nodeGroupA := &Resource{Name: "aws_eks_node_group.a", RollupParent: "aws_eks_cluster.main"}
+	orphan := &Resource{Name: "aws_eks_node_group.orphan", RollupParent: "aws_eks_cluster.other"}
+	skippedCluster := &Resource{Name: "aws_eks_cluster.skipped", IsSkipped: true}
+	skippedParent := &Resource{Name: "aws_eks_node_group.c", RollupParent: "aws_eks_cluster.skipped"}
+
+	resources := RollupResources([]*Resource{nodeGroupB, cluster, orphan, nodeGroupA, skippedCluster, skippedParent})
+
+	assert.Equal(t, []*Resource{cluster, orphan, skippedCluster, skippedParent}, resources)
+	assert.Equal(t, []*Resource{nodeGroupA, nodeGroupB}, cluster.SubResources)
+
+	cluster.CalculateCosts()
+	require.NotNil(t, cluster.HourlyCost)
+	assert.Equal(t, "0.2", cluster.HourlyCost.String())
+}
@@ -0,0 +1,26 @@
+package schema
+
+// Suppression represents an inline `# infracost-ignore: <policy> reason="..."` comment
+// that is placed directly above a resource block. It suppresses the named cost policy
+// check for the resource beneath it, the reason is kept so the suppression can be audited.
+type Suppression struct {
+	Policy   string `json:"policy"`
+	Reason   string `json:"reason,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// Matches returns true if the Suppression applies to the given policy. A policy
+// of "*" suppresses all policies.
+func (s Suppression) Matches(policy string) bool {
+	return s.Policy == "*" || s.Policy == policy
+}
+
+// The policies of the cost thresholds that can be suppressed on a resource. Suppressed resources
+// aren't counted towards the threshold, e.g. `# infracost-ignore: budget` excludes the resource's
+// monthly cost when checking if its project is over budget.
+const (
+	BudgetPolicy         = "budget"
+	MaxDiffPolicy        = "max-diff"
+	MaxDiffPercentPolicy = "max-diff-percent"
+)
@@ -0,0 +1,42 @@
+package schema
+
+// TracedValue is an attribute or usage key that was read while a resource was being built.
+type TracedValue struct {
+	Key   string
+	Value string
+	// Default is true if the key wasn't set and a default value was used instead.
+	Default bool
+}
+
+// Tracer records which attributes and usage keys are read when a resource is built, so that
+// the inputs to each cost component can be shown with --trace-resource.
+type Tracer struct {
+	Attributes []TracedValue
+	UsageKeys  []TracedValue
+
+	seen map[string]bool
+}
+
+func NewTracer() *Tracer {
+	return &Tracer{
+		seen: make(map[string]bool),
+	}
+}
+
+func (t *Tracer) traceAttribute(key, value string, isDefault bool) {
+	if t == nil || t.seen["attr:"+key] {
+		return
+	}
+
+	t.seen["attr:"+key] = true
+	t.Attributes = append(t.Attributes, TracedValue{Key: key, Value: value, Default: isDefault})
+}
+
+func (t *Tracer) traceUsageKey(key, value string) {
+	if t == nil || t.seen["usage:"+key] {
+		return
+	}
+
+	t.seen["usage:"+key] = true
+	t.UsageKeys = append(t.UsageKeys, TracedValue{Key: key, Value: value})
+}
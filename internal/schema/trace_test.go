@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestTracer(t *testing.T) {
+	d := NewResourceData("aws_instance", "aws", "aws_instance.web", map[string]string{}, gjson.Parse(`{"instance_type": "t3.micro", "tenancy": ""}`))
+	u := NewUsageData("aws_instance.web", ParseAttributes(map[string]interface{}{"operating_system": "linux"}))
+
+	tracer := NewTracer()
+	d.Tracer = tracer
+	u.Tracer = tracer
+
+	assert.Equal(t, "t3.micro", d.Get("instance_type").String())
+	assert.Equal(t, "t3.micro", d.Get("instance_type").String())
+	assert.Equal(t, "default", d.GetStringOrDefault("tenancy", "default"))
+	assert.False(t, d.Get("ebs_optimized").Bool())
+	assert.Equal(t, "linux", *u.GetString("operating_system"))
+	assert.Nil(t, u.GetFloat("monthly_hrs"))
+
+	assert.Equal(t, []TracedValue{
+		{Key: "instance_type", Value: `"t3.micro"`},
+		{Key: "tenancy", Value: "default", Default: true},
+		{Key: "ebs_optimized"},
+	}, tracer.Attributes)
+	assert.Equal(t, []TracedValue{
+		{Key: "operating_system", Value: `"linux"`},
+		{Key: "monthly_hrs"},
+	}, tracer.UsageKeys)
+}
+
+func TestTracerNotSet(t *testing.T) {
+	d := NewResourceData("aws_instance", "aws", "aws_instance.web", map[string]string{}, gjson.Parse(`{"instance_type": "t3.micro"}`))
+
+	assert.Equal(t, "t3.micro", d.Get("instance_type").String())
+	assert.Nil(t, d.Tracer)
+}
@@ -11,6 +11,8 @@ import (
 type UsageData struct {
 	Address    string
 	Attributes map[string]gjson.Result
+	// Tracer records the usage keys read for the resource, if it is being traced.
+	Tracer *Tracer
 }
 
 func NewUsageData(address string, attributes map[string]gjson.Result) *UsageData {
@@ -21,6 +23,13 @@ func NewUsageData(address string, attributes map[string]gjson.Result) *UsageData
 }
 
 func (u *UsageData) Get(key string) gjson.Result {
+	v := u.get(key)
+	u.Tracer.traceUsageKey(key, v.Raw)
+
+	return v
+}
+
+func (u *UsageData) get(key string) gjson.Result {
 	if u.Attributes[key].Type != gjson.Null {
 		return u.Attributes[key]
 	} else if strings.Contains(key, "[") && strings.Contains(key, "]") {
@@ -0,0 +1,22 @@
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// WASMModule returns the path of the prebuilt WASI module of the Go program in dir, which is named
+// after the directory, e.g. testdata/upper/upper.wasm. The modules are checked in since building them
+// needs Go 1.21 or later, so the test fails if the module is missing rather than being skipped.
+// Rebuild the modules with `make wasm_testdata` after changing their programs.
+func WASMModule(t *testing.T, dir string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.Base(dir)+".wasm")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("WASM module %s is missing, build it with `make wasm_testdata`: %s", path, err)
+	}
+
+	return path
+}
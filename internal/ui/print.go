@@ -47,6 +47,8 @@ var (
 	githubIssuesLink = LinkString("https://github.com/infracost/infracost/issues/new")
 
 	stackErrorMsg = "An unexpected error occurred. We've been notified of it and will investigate it soon. If you would like to follow-up, please copy the above output and create an issue at:"
+
+	crashReportMsg = "An unexpected error occurred. We've been notified of it and will investigate it soon. If you would like to follow-up, please create an issue and attach the crash report at:"
 )
 
 // PrintUnexpectedErrorStack prints a full stack trace of a fatal error.
@@ -62,3 +64,20 @@ func PrintUnexpectedErrorStack(out io.Writer, err error) {
 
 	fmt.Fprint(out, msg)
 }
+
+// PrintUnexpectedErrorWithCrashReport prints a fatal error along with the path to the crash report
+// that contains the stack trace, instead of printing the full stack trace.
+func PrintUnexpectedErrorWithCrashReport(out io.Writer, errMsg string, reportPath string) {
+	msg := fmt.Sprintf("\n%s %s\n\n%s\nEnvironment:\n%s\n\n%s %s\n\n%s %s\n",
+		ErrorString("Error:"),
+		"An unexpected error occurred",
+		errMsg,
+		fmt.Sprintf("Infracost %s", version.Version),
+		"A crash report with the stack trace and a redacted copy of your config has been written to:",
+		PrimaryString(reportPath),
+		crashReportMsg,
+		githubIssuesLink,
+	)
+
+	fmt.Fprint(out, msg)
+}
@@ -2,8 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"runtime"
+	"sync"
 	"time"
 
 	spinnerpkg "github.com/briandowns/spinner"
@@ -14,12 +16,32 @@ type SpinnerOptions struct {
 	EnableLogging bool
 	NoColor       bool
 	Indent        string
+	// NoProgress disables all progress output.
+	NoProgress bool
+	// NonInteractive replaces the spinner with a line when the action starts and then
+	// periodic lines while it is still running, since the spinner can't redraw the line
+	// when the output isn't a terminal.
+	NonInteractive bool
 }
 
+// progressInterval is how often a line is written for a running action when the
+// spinner is NonInteractive.
+var progressInterval = 30 * time.Second
+
+// spinnerWriter is where the spinner and progress lines are written.
+var spinnerWriter io.Writer = os.Stderr
+
 type Spinner struct {
 	spinner *spinnerpkg.Spinner
 	msg     string
 	opts    SpinnerOptions
+	writer  io.Writer
+
+	// done stops the periodic progress lines when the spinner is NonInteractive.
+	done chan struct{}
+	// progressWg waits for the progress goroutine to exit so no lines are written after
+	// the spinner is stopped.
+	progressWg sync.WaitGroup
 }
 
 // SpinnerFunc defines a function that returns a Spinner which can be used
@@ -32,13 +54,21 @@ func NewSpinner(msg string, opts SpinnerOptions) *Spinner {
 		spinnerCharNumb = 9
 	}
 	s := &Spinner{
-		spinner: spinnerpkg.New(spinnerpkg.CharSets[spinnerCharNumb], 100*time.Millisecond, spinnerpkg.WithWriter(os.Stderr)),
+		spinner: spinnerpkg.New(spinnerpkg.CharSets[spinnerCharNumb], 100*time.Millisecond, spinnerpkg.WithWriter(spinnerWriter)),
 		msg:     msg,
 		opts:    opts,
+		writer:  spinnerWriter,
 	}
 
 	if s.opts.EnableLogging {
 		log.Infof("Starting: %s", msg)
+	} else if s.opts.NoProgress {
+		return s
+	} else if s.opts.NonInteractive {
+		fmt.Fprintf(s.writer, "%s%s...\n", s.opts.Indent, msg)
+		s.done = make(chan struct{})
+		s.progressWg.Add(1)
+		go s.writeProgress(time.Now(), progressInterval)
 	} else {
 		s.spinner.Prefix = opts.Indent
 		s.spinner.Suffix = fmt.Sprintf(" %s", msg)
@@ -51,19 +81,54 @@ func NewSpinner(msg string, opts SpinnerOptions) *Spinner {
 	return s
 }
 
+// writeProgress writes a line every interval until the spinner is stopped.
+func (s *Spinner) writeProgress(start time.Time, interval time.Duration) {
+	defer s.progressWg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			fmt.Fprintf(s.writer, "%s%s (still running after %s)\n", s.opts.Indent, s.msg, time.Since(start).Round(time.Second))
+		}
+	}
+}
+
+// isActive returns true if the spinner, or the periodic progress lines, are running.
+func (s *Spinner) isActive() bool {
+	if s.done != nil {
+		select {
+		case <-s.done:
+			return false
+		default:
+			return true
+		}
+	}
+
+	return s.spinner != nil && s.spinner.Active()
+}
+
 func (s *Spinner) Stop() {
+	if s.done != nil && s.isActive() {
+		close(s.done)
+		s.progressWg.Wait()
+	}
 	s.spinner.Stop()
 }
 
 func (s *Spinner) Fail() {
-	if s.spinner == nil || !s.spinner.Active() {
+	if !s.isActive() {
 		return
 	}
 	s.Stop()
 	if s.opts.EnableLogging {
 		log.Errorf("Failed: %s", s.msg)
 	} else {
-		fmt.Fprintf(os.Stderr, "%s%s %s\n",
+		fmt.Fprintf(s.writer, "%s%s %s\n",
 			s.opts.Indent,
 			ErrorString("✖"),
 			s.msg,
@@ -77,14 +142,14 @@ func (s *Spinner) SuccessWithMessage(newMsg string) {
 }
 
 func (s *Spinner) Success() {
-	if !s.spinner.Active() {
+	if !s.isActive() {
 		return
 	}
 	s.Stop()
 	if s.opts.EnableLogging {
 		log.Infof("Completed: %s", s.msg)
 	} else {
-		fmt.Fprintf(os.Stderr, "%s%s %s\n",
+		fmt.Fprintf(s.writer, "%s%s %s\n",
 			s.opts.Indent,
 			PrimaryString("✔"),
 			s.msg,
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer that can be written to by the progress goroutine while
+// the test reads it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func captureSpinnerOutput(t *testing.T, interval time.Duration) *syncBuffer {
+	t.Helper()
+
+	buf := &syncBuffer{}
+
+	origWriter, origInterval := spinnerWriter, progressInterval
+	spinnerWriter, progressInterval = buf, interval
+	t.Cleanup(func() {
+		spinnerWriter, progressInterval = origWriter, origInterval
+	})
+
+	return buf
+}
+
+func TestSpinnerNoProgress(t *testing.T) {
+	buf := captureSpinnerOutput(t, time.Millisecond)
+
+	s := NewSpinner("Retrieving cloud prices", SpinnerOptions{NoProgress: true, NonInteractive: true})
+	time.Sleep(10 * time.Millisecond)
+	s.Success()
+
+	s = NewSpinner("Syncing usage data", SpinnerOptions{NoProgress: true})
+	s.Fail()
+
+	assert.Empty(t, buf.String())
+}
+
+func TestSpinnerNonInteractive(t *testing.T) {
+	buf := captureSpinnerOutput(t, time.Hour)
+
+	s := NewSpinner("Retrieving cloud prices", SpinnerOptions{NonInteractive: true, NoColor: true, Indent: "  "})
+	s.Success()
+	// A second call is a no-op since the spinner has already stopped.
+	s.Success()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "  Retrieving cloud prices...", lines[0])
+	assert.Contains(t, lines[1], "✔")
+	assert.True(t, strings.HasSuffix(lines[1], " Retrieving cloud prices"))
+	assert.NotContains(t, buf.String(), "still running")
+}
+
+func TestSpinnerNonInteractiveProgressLines(t *testing.T) {
+	buf := captureSpinnerOutput(t, 5*time.Millisecond)
+
+	s := NewSpinner("Retrieving cloud prices", SpinnerOptions{NonInteractive: true, NoColor: true, Indent: "  "})
+
+	require.Eventually(t, func() bool {
+		return strings.Count(buf.String(), "still running after") >= 2
+	}, time.Second, time.Millisecond)
+
+	s.Fail()
+	out := buf.String()
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	assert.Equal(t, "  Retrieving cloud prices...", lines[0])
+	for _, line := range lines[1 : len(lines)-1] {
+		assert.True(t, strings.HasPrefix(line, "  Retrieving cloud prices (still running after "), line)
+	}
+	assert.Contains(t, lines[len(lines)-1], "✖")
+
+	// No more progress lines are written once the spinner has stopped.
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, out, buf.String())
+}
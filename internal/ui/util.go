@@ -1,10 +1,19 @@
 package ui
 
 import (
+	"os"
 	"regexp"
 	"strings"
+
+	"github.com/mattn/go-isatty"
 )
 
+// IsTerminal returns true if the file is an interactive terminal. This is false when the
+// output is piped or redirected, e.g. in Docker containers without a TTY.
+func IsTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
 func Indent(s, indent string) string {
 	lines := make([]string, 0)
 
@@ -0,0 +1,397 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/mod/semver"
+
+	"github.com/infracost/infracost/internal/version"
+
+	"github.com/infracost/infracost/internal/httpclient"
+)
+
+const (
+	// ChannelStable only includes full releases.
+	ChannelStable = "stable"
+	// ChannelBeta also includes pre-releases, e.g. v0.10.0-beta.1.
+	ChannelBeta = "beta"
+)
+
+// releasesURL is the GitHub releases API endpoint that is used as the release feed.
+var releasesURL = "https://api.github.com/repos/infracost/infracost/releases"
+
+// releasePublicKey is the base64 encoded PEM of the cosign public key that release
+// checksums are signed with. It is set at build time with -ldflags, and updates are
+// refused if it is empty so builds without a key can't install unverified binaries.
+var releasePublicKey string
+
+// checksumsName is the release asset that lists the SHA256 of every archive. It is
+// signed with cosign sign-blob and the signature is uploaded as checksumsName + ".sig".
+const checksumsName = "infracost-checksums.txt"
+
+// Channels returns the supported update channels.
+func Channels() []string {
+	return []string{ChannelStable, ChannelBeta}
+}
+
+// IsValidChannel returns true if the channel is one of the supported update channels.
+func IsValidChannel(channel string) bool {
+	for _, c := range Channels() {
+		if c == channel {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Release is the release that the binary can be updated to.
+type Release struct {
+	Version      string
+	ArchiveURL   string
+	ChecksumsURL string
+	SignatureURL string
+}
+
+// IsNewer returns true if the release is newer than the running version.
+func (r *Release) IsNewer() bool {
+	return semver.Compare(version.Version, r.Version) < 0
+}
+
+type githubAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+type githubRelease struct {
+	TagName    string        `json:"tag_name"`
+	Draft      bool          `json:"draft"`
+	Prerelease bool          `json:"prerelease"`
+	Assets     []githubAsset `json:"assets"`
+}
+
+// FindLatestRelease returns the latest release on the channel that has an archive
+// for the current OS and architecture.
+func FindLatestRelease(ctx context.Context, channel string) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesURL+"?per_page=30", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpclient.NewClient(httpclient.PurposeUpdate).Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error fetching releases")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Error fetching releases: %s", resp.Status)
+	}
+
+	var releases []githubRelease
+	err = json.NewDecoder(resp.Body).Decode(&releases)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing releases")
+	}
+
+	return selectRelease(releases, channel, runtime.GOOS, runtime.GOARCH, version.FIPS())
+}
+
+// selectRelease returns the highest version release on the channel with assets for the
+// given OS and architecture, and the FIPS assets if fips is set. Releases without signed
+// checksums are still returned so that Apply can refuse them, rather than silently
+// falling back to an older release.
+func selectRelease(releases []githubRelease, channel string, goos string, goarch string, fips bool) (*Release, error) {
+	archiveName := archiveName(goos, goarch, fips)
+
+	var latest *Release
+	for _, r := range releases {
+		if r.Draft || (r.Prerelease && channel != ChannelBeta) {
+			continue
+		}
+
+		v := r.TagName
+		if !strings.HasPrefix(v, "v") {
+			v = "v" + v
+		}
+
+		if !semver.IsValid(v) || (latest != nil && semver.Compare(v, latest.Version) <= 0) {
+			continue
+		}
+
+		release := &Release{Version: v}
+		for _, a := range r.Assets {
+			switch a.Name {
+			case archiveName:
+				release.ArchiveURL = a.BrowserDownloadURL
+			case checksumsName:
+				release.ChecksumsURL = a.BrowserDownloadURL
+			case checksumsName + ".sig":
+				release.SignatureURL = a.BrowserDownloadURL
+			}
+		}
+
+		if release.ArchiveURL == "" {
+			log.Debugf("Skipping release %s as it has no %s archive", v, archiveName)
+			continue
+		}
+
+		latest = release
+	}
+
+	if latest == nil {
+		if fips {
+			return nil, fmt.Errorf("No %s FIPS release found for %s/%s", channel, goos, goarch)
+		}
+
+		return nil, fmt.Errorf("No %s release found for %s/%s", channel, goos, goarch)
+	}
+
+	return latest, nil
+}
+
+// Apply downloads the release, verifies the signature of its checksums and the checksum
+// of the archive, then replaces the binary at exePath.
+func Apply(ctx context.Context, release *Release, exePath string) error {
+	if release.ChecksumsURL == "" || release.SignatureURL == "" {
+		return errors.Errorf("Release %s has no signed checksums so it can't be verified, refusing to update", release.Version)
+	}
+
+	checksums, err := download(ctx, release.ChecksumsURL)
+	if err != nil {
+		return errors.Wrap(err, "Error downloading release checksums")
+	}
+
+	signature, err := download(ctx, release.SignatureURL)
+	if err != nil {
+		return errors.Wrap(err, "Error downloading release checksums signature")
+	}
+
+	err = verifySignature(checksums, signature, releasePublicKey)
+	if err != nil {
+		return err
+	}
+
+	archive, err := download(ctx, release.ArchiveURL)
+	if err != nil {
+		return errors.Wrap(err, "Error downloading release")
+	}
+
+	err = verifyChecksum(archive, archiveName(runtime.GOOS, runtime.GOARCH, version.FIPS()), checksums)
+	if err != nil {
+		return err
+	}
+
+	bin, err := extractBinary(archive, binaryName(runtime.GOOS, runtime.GOARCH, version.FIPS()))
+	if err != nil {
+		return err
+	}
+
+	return replaceBinary(exePath, bin)
+}
+
+// archiveName returns the name of the release archive for the OS and architecture, which
+// matches the archives built by the release and release_fips Makefile targets.
+func archiveName(goos string, goarch string, fips bool) string {
+	if fips {
+		return fmt.Sprintf("infracost-%s-%s-fips.tar.gz", goos, goarch)
+	}
+
+	return fmt.Sprintf("infracost-%s-%s.tar.gz", goos, goarch)
+}
+
+// binaryName returns the name of the binary in the release archive, without any extension.
+// The Windows archives have infracost.exe and infracost-arm64.exe rather than the OS and
+// architecture in the name.
+func binaryName(goos string, goarch string, fips bool) string {
+	if fips {
+		return fmt.Sprintf("infracost-%s-%s-fips", goos, goarch)
+	}
+
+	if goos == "windows" {
+		if goarch == "amd64" {
+			return "infracost"
+		}
+
+		return fmt.Sprintf("infracost-%s", goarch)
+	}
+
+	return fmt.Sprintf("infracost-%s-%s", goos, goarch)
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpclient.NewClient(httpclient.PurposeUpdate).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("%s returned %s", url, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// verifySignature checks the checksums file was signed by the release key. The signature
+// is the base64 encoded ASN.1 ECDSA signature of the file's SHA256, which is what cosign
+// sign-blob outputs for its default key type.
+func verifySignature(checksums []byte, signature []byte, publicKey string) error {
+	if publicKey == "" {
+		return errors.New("This build of Infracost has no release signing key so updates can't be verified, download the new release manually instead")
+	}
+
+	pemData, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return errors.Wrap(err, "Error decoding release signing key")
+	}
+
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return errors.New("Error decoding release signing key: no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing release signing key")
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.Errorf("Release signing key has unsupported type %T", key)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return errors.Wrap(err, "Error decoding release checksums signature")
+	}
+
+	digest := sha256.Sum256(checksums)
+	if !ecdsa.VerifyASN1(ecdsaKey, digest[:], sig) {
+		return errors.New("Release checksums signature is invalid, the download may be corrupt or tampered with")
+	}
+
+	return nil
+}
+
+// verifyChecksum checks the SHA256 of the data matches the entry for name in the
+// checksums file, which is in the shasum format: "<hex digest>  <filename>" per line.
+func verifyChecksum(data []byte, name string, checksums []byte) error {
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return errors.New("Release checksum does not match, the download may be corrupt or tampered with")
+		}
+
+		return nil
+	}
+
+	return errors.Errorf("Release checksums do not include %s", name)
+}
+
+// extractBinary returns the contents of the binary from the tar.gz archive. The binary
+// name can have an extension, e.g. .exe on Windows.
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading release archive")
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading release archive")
+		}
+
+		base := filepath.Base(hdr.Name)
+		if hdr.Typeflag == tar.TypeReg && strings.TrimSuffix(base, filepath.Ext(base)) == name {
+			return io.ReadAll(tr)
+		}
+	}
+
+	return nil, fmt.Errorf("Release archive does not contain %s", name)
+}
+
+// replaceBinary replaces the binary at path. The new binary is written next to the old
+// one and renamed over it so a failed update doesn't leave a partial binary. The old
+// binary is moved aside first since Windows doesn't allow replacing a running executable.
+func replaceBinary(path string, bin []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".infracost-update-*")
+	if err != nil {
+		return errors.Wrap(err, "Error creating new binary, you might need to run the update with sudo")
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(bin)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "Error writing new binary")
+	}
+
+	err = os.Chmod(tmp.Name(), info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	oldPath := path + ".old"
+	_ = os.Remove(oldPath)
+
+	err = os.Rename(path, oldPath)
+	if err != nil {
+		return errors.Wrap(err, "Error moving old binary")
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		_ = os.Rename(oldPath, path)
+		return errors.Wrap(err, "Error replacing binary")
+	}
+
+	err = os.Remove(oldPath)
+	if err != nil {
+		log.Debugf("Could not remove old binary %s: %s", oldPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/version"
+)
+
+func testRelease(tag string, prerelease bool) githubRelease {
+	return githubRelease{
+		TagName:    tag,
+		Prerelease: prerelease,
+		Assets: []githubAsset{
+			{Name: "infracost-linux-amd64.tar.gz", BrowserDownloadURL: "https://example.com/" + tag + "/infracost-linux-amd64.tar.gz"},
+			{Name: "infracost-linux-amd64-fips.tar.gz", BrowserDownloadURL: "https://example.com/" + tag + "/infracost-linux-amd64-fips.tar.gz"},
+			{Name: "infracost-windows-amd64.tar.gz", BrowserDownloadURL: "https://example.com/" + tag + "/infracost-windows-amd64.tar.gz"},
+			{Name: "infracost-checksums.txt", BrowserDownloadURL: "https://example.com/" + tag + "/infracost-checksums.txt"},
+			{Name: "infracost-checksums.txt.sig", BrowserDownloadURL: "https://example.com/" + tag + "/infracost-checksums.txt.sig"},
+		},
+	}
+}
+
+func TestSelectRelease(t *testing.T) {
+	releases := []githubRelease{
+		testRelease("v0.11.0-beta.1", true),
+		testRelease("v0.10.2", false),
+		testRelease("v0.10.1", false),
+		{TagName: "v0.12.0", Draft: true},
+	}
+
+	stable, err := selectRelease(releases, ChannelStable, "linux", "amd64", false)
+	require.NoError(t, err)
+	assert.Equal(t, &Release{
+		Version:      "v0.10.2",
+		ArchiveURL:   "https://example.com/v0.10.2/infracost-linux-amd64.tar.gz",
+		ChecksumsURL: "https://example.com/v0.10.2/infracost-checksums.txt",
+		SignatureURL: "https://example.com/v0.10.2/infracost-checksums.txt.sig",
+	}, stable)
+
+	beta, err := selectRelease(releases, ChannelBeta, "linux", "amd64", false)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.11.0-beta.1", beta.Version)
+
+	fips, err := selectRelease(releases, ChannelStable, "linux", "amd64", true)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/v0.10.2/infracost-linux-amd64-fips.tar.gz", fips.ArchiveURL)
+
+	windows, err := selectRelease(releases, ChannelStable, "windows", "amd64", false)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/v0.10.2/infracost-windows-amd64.tar.gz", windows.ArchiveURL)
+
+	_, err = selectRelease(releases, ChannelStable, "darwin", "arm64", false)
+	assert.EqualError(t, err, "No stable release found for darwin/arm64")
+
+	_, err = selectRelease(releases, ChannelStable, "linux", "arm64", true)
+	assert.EqualError(t, err, "No stable FIPS release found for linux/arm64")
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("infracost")
+	sum := sha256.Sum256(data)
+	checksums := []byte("0123  infracost-darwin-arm64.tar.gz\n" + hex.EncodeToString(sum[:]) + "  infracost-linux-amd64.tar.gz\n")
+
+	assert.NoError(t, verifyChecksum(data, "infracost-linux-amd64.tar.gz", checksums))
+	assert.EqualError(t, verifyChecksum([]byte("tampered"), "infracost-linux-amd64.tar.gz", checksums), "Release checksum does not match, the download may be corrupt or tampered with")
+	assert.EqualError(t, verifyChecksum(data, "infracost-windows-amd64.tar.gz", checksums), "Release checksums do not include infracost-windows-amd64.tar.gz")
+	assert.Error(t, verifyChecksum(data, "infracost-linux-amd64.tar.gz", []byte("")))
+}
+
+func testSigningKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	return key, base64.StdEncoding.EncodeToString(pemData)
+}
+
+func testSign(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	t.Helper()
+
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	return []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, publicKey := testSigningKey(t)
+	otherKey, _ := testSigningKey(t)
+	checksums := []byte("abcd  infracost-linux-amd64.tar.gz\n")
+
+	assert.NoError(t, verifySignature(checksums, testSign(t, key, checksums), publicKey))
+
+	assert.EqualError(t, verifySignature([]byte("abcd  infracost-evil.tar.gz\n"), testSign(t, key, checksums), publicKey), "Release checksums signature is invalid, the download may be corrupt or tampered with")
+	assert.EqualError(t, verifySignature(checksums, testSign(t, otherKey, checksums), publicKey), "Release checksums signature is invalid, the download may be corrupt or tampered with")
+	assert.Error(t, verifySignature(checksums, []byte(""), publicKey))
+	assert.Error(t, verifySignature(checksums, []byte("not base64!"), publicKey))
+	assert.EqualError(t, verifySignature(checksums, testSign(t, key, checksums), ""), "This build of Infracost has no release signing key so updates can't be verified, download the new release manually instead")
+}
+
+func TestApplyRequiresSignedChecksums(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "infracost")
+	require.NoError(t, os.WriteFile(exe, []byte("old binary"), 0755))
+
+	release := &Release{
+		Version:      "v0.10.2",
+		ArchiveURL:   "https://example.com/v0.10.2/infracost-linux-amd64.tar.gz",
+		ChecksumsURL: "https://example.com/v0.10.2/infracost-checksums.txt",
+	}
+
+	err := Apply(context.Background(), release, exe)
+	assert.EqualError(t, err, "Release v0.10.2 has no signed checksums so it can't be verified, refusing to update")
+
+	actual, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("old binary"), actual)
+}
+
+func testArchive(t *testing.T, name string, contents []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(contents)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write(contents)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func TestApply(t *testing.T) {
+	key, publicKey := testSigningKey(t)
+	otherKey, _ := testSigningKey(t)
+
+	archive := testArchive(t, binaryName(runtime.GOOS, runtime.GOARCH, version.FIPS()), []byte("new binary"))
+	sum := sha256.Sum256(archive)
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  " + archiveName(runtime.GOOS, runtime.GOARCH, version.FIPS()) + "\n")
+
+	tests := []struct {
+		name      string
+		publicKey string
+		signature []byte
+		wantErr   string
+	}{
+		{name: "valid signature", publicKey: publicKey, signature: testSign(t, key, checksums)},
+		{name: "wrong key", publicKey: publicKey, signature: testSign(t, otherKey, checksums), wantErr: "Release checksums signature is invalid, the download may be corrupt or tampered with"},
+		{name: "no public key", publicKey: "", signature: testSign(t, key, checksums), wantErr: "This build of Infracost has no release signing key so updates can't be verified, download the new release manually instead"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origKey := releasePublicKey
+			releasePublicKey = tt.publicKey
+			t.Cleanup(func() { releasePublicKey = origKey })
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/archive":
+					_, _ = w.Write(archive)
+				case "/checksums":
+					_, _ = w.Write(checksums)
+				case "/signature":
+					_, _ = w.Write(tt.signature)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer ts.Close()
+
+			exe := filepath.Join(t.TempDir(), "infracost")
+			require.NoError(t, os.WriteFile(exe, []byte("old binary"), 0755))
+
+			err := Apply(context.Background(), &Release{
+				Version:      "v0.10.2",
+				ArchiveURL:   ts.URL + "/archive",
+				ChecksumsURL: ts.URL + "/checksums",
+				SignatureURL: ts.URL + "/signature",
+			}, exe)
+
+			actual, readErr := os.ReadFile(exe)
+			require.NoError(t, readErr)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, []byte("old binary"), actual)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []byte("new binary"), actual)
+		})
+	}
+}
+
+func TestExtractBinaryAndReplace(t *testing.T) {
+	contents := []byte("new binary")
+	archive := testArchive(t, "infracost-linux-amd64", contents)
+
+	bin, err := extractBinary(archive, "infracost-linux-amd64")
+	require.NoError(t, err)
+	assert.Equal(t, contents, bin)
+
+	_, err = extractBinary(archive, "infracost-darwin-arm64")
+	assert.Error(t, err)
+
+	exe := filepath.Join(t.TempDir(), "infracost")
+	require.NoError(t, os.WriteFile(exe, []byte("old binary"), 0755))
+
+	require.NoError(t, replaceBinary(exe, bin))
+
+	actual, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	assert.Equal(t, contents, actual)
+	assert.NoFileExists(t, exe+".old")
+}
+
+// TestReleaseArchiveNames checks the names match the archives and binaries that the release and
+// release_fips Makefile targets build.
+func TestReleaseArchiveNames(t *testing.T) {
+	tests := []struct {
+		goos    string
+		goarch  string
+		fips    bool
+		archive string
+		binary  string
+		file    string
+	}{
+		{goos: "linux", goarch: "amd64", archive: "infracost-linux-amd64.tar.gz", binary: "infracost-linux-amd64", file: "infracost-linux-amd64"},
+		{goos: "darwin", goarch: "arm64", archive: "infracost-darwin-arm64.tar.gz", binary: "infracost-darwin-arm64", file: "infracost-darwin-arm64"},
+		{goos: "windows", goarch: "amd64", archive: "infracost-windows-amd64.tar.gz", binary: "infracost", file: "infracost.exe"},
+		{goos: "windows", goarch: "arm64", archive: "infracost-windows-arm64.tar.gz", binary: "infracost-arm64", file: "infracost-arm64.exe"},
+		{goos: "linux", goarch: "amd64", fips: true, archive: "infracost-linux-amd64-fips.tar.gz", binary: "infracost-linux-amd64-fips", file: "infracost-linux-amd64-fips"},
+		{goos: "linux", goarch: "arm64", fips: true, archive: "infracost-linux-arm64-fips.tar.gz", binary: "infracost-linux-arm64-fips", file: "infracost-linux-arm64-fips"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.archive, func(t *testing.T) {
+			assert.Equal(t, tt.archive, archiveName(tt.goos, tt.goarch, tt.fips))
+			assert.Equal(t, tt.binary, binaryName(tt.goos, tt.goarch, tt.fips))
+
+			contents := []byte("new binary")
+			bin, err := extractBinary(testArchive(t, tt.file, contents), binaryName(tt.goos, tt.goarch, tt.fips))
+			require.NoError(t, err)
+			assert.Equal(t, contents, bin)
+		})
+	}
+}
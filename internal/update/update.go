@@ -18,6 +18,7 @@ import (
 	"golang.org/x/mod/semver"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpclient"
 	"github.com/infracost/infracost/internal/version"
 )
 
@@ -42,7 +43,7 @@ func CheckForUpdate(ctx *config.RunContext) (*Info, error) {
 		return nil, nil
 	}
 
-	isBrew, err := isBrewInstall()
+	isBrew, err := IsBrewInstall()
 	if err != nil {
 		// don't fail if we can't detect brew, just fallback to other update method
 		log.Debugf("error checking if executable was installed via brew: %v", err)
@@ -52,10 +53,7 @@ func CheckForUpdate(ctx *config.RunContext) (*Info, error) {
 	if isBrew {
 		cmd = "$ brew upgrade infracost"
 	} else {
-		cmd = "Go to https://www.infracost.io/docs/update for instructions"
-		if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
-			cmd = "$ curl -fsSL https://raw.githubusercontent.com/infracost/infracost/master/scripts/install.sh | sh"
-		}
+		cmd = "$ infracost update"
 	}
 
 	// Get the latest version
@@ -93,7 +91,8 @@ func skipUpdateCheck(ctx *config.RunContext) bool {
 	return ctx.Config.SkipUpdateCheck || config.IsTest() || config.IsDev()
 }
 
-func isBrewInstall() (bool, error) {
+// IsBrewInstall returns true if the running binary was installed using Homebrew.
+func IsBrewInstall() (bool, error) {
 	if runtime.GOOS != "darwin" {
 		return false, nil
 	}
@@ -137,7 +136,7 @@ func getLatestBrewVersion() (string, error) {
 		Versions versionsResp `json:"versions"`
 	}
 
-	resp, err := http.Get("https://formulae.brew.sh/api/formula/infracost.json")
+	resp, err := httpclient.NewClient(httpclient.PurposeUpdate).Get("https://formulae.brew.sh/api/formula/infracost.json")
 	if err != nil {
 		return "", err
 	}
@@ -170,7 +169,7 @@ func getLatestGitHubVersion() (string, error) {
 		TagName string `json:"tag_name"`
 	}
 
-	resp, err := http.Get("https://api.github.com/repos/infracost/infracost/releases/latest")
+	resp, err := httpclient.NewClient(httpclient.PurposeUpdate).Get("https://api.github.com/repos/infracost/infracost/releases/latest")
 	if err != nil {
 		return "", err
 	}
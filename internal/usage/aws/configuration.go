@@ -9,6 +9,8 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/infracost/infracost/internal/httpclient"
 )
 
 type ctxConfigOptsKeyType struct{}
@@ -18,6 +20,13 @@ var ctxConfigOptsKey = &ctxConfigOptsKeyType{}
 var configMux sync.Mutex
 
 func getConfig(ctx context.Context, region string) (aws.Config, error) {
+	// The AWS SDK uses its own HTTP client, so check the network policy before any requests are
+	// made to fetch usage from CloudWatch and other AWS APIs.
+	err := httpclient.CheckAllowed(httpclient.PurposeCloudUsage, "AWS "+region)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
 	opts := []func(*config.LoadOptions) error{
 		config.WithRegion(region),
 		// config.WithClientLogMode(aws.LogRequestWithBody | aws.LogResponseWithBody),
@@ -0,0 +1,33 @@
+package usage
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/address"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// MonthlyGrowthRateKey is a usage key that can be set on any resource to specify the percentage that
+// its monthly cost is expected to grow by each month, e.g. 5 for 5% month-over-month growth.
+const MonthlyGrowthRateKey = "monthly_growth_rate"
+
+// SetMonthlyGrowthRates sets the monthly growth rate on each resource that has one in the usage data.
+// Resources with an index will use the wildcard usage, e.g. aws_lambda_function.my_lambda[*], if they
+// don't have their own.
+func SetMonthlyGrowthRates(resources []*schema.Resource, usageData map[string]*schema.UsageData) {
+	for _, r := range resources {
+		u, ok := usageData[r.Name]
+		if name := address.TrimKey(r.Name); name != r.Name && (!ok || u.GetFloat(MonthlyGrowthRateKey) == nil) {
+			u, ok = usageData[name+"[*]"]
+		}
+
+		if !ok || u == nil {
+			continue
+		}
+
+		if rate := u.GetFloat(MonthlyGrowthRateKey); rate != nil {
+			d := decimal.NewFromFloat(*rate)
+			r.MonthlyGrowthRate = &d
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestSetMonthlyGrowthRates(t *testing.T) {
+	usageData := schema.NewUsageMap(map[string]interface{}{
+		"aws_s3_bucket.logs": map[string]interface{}{
+			"monthly_growth_rate": 5,
+		},
+		"aws_lambda_function.fn[*]": map[string]interface{}{
+			"monthly_growth_rate": 2.5,
+		},
+		"aws_lambda_function.fn[1]": map[string]interface{}{
+			"monthly_requests": 1000,
+		},
+	})
+
+	resources := []*schema.Resource{
+		{Name: "aws_s3_bucket.logs"},
+		{Name: "aws_lambda_function.fn[0]"},
+		{Name: "aws_lambda_function.fn[1]"},
+		{Name: "aws_instance.web"},
+	}
+
+	SetMonthlyGrowthRates(resources, usageData)
+
+	assert.Equal(t, "5", resources[0].MonthlyGrowthRate.String())
+	assert.Equal(t, "2.5", resources[1].MonthlyGrowthRate.String())
+	assert.Equal(t, "2.5", resources[2].MonthlyGrowthRate.String())
+	assert.Nil(t, resources[3].MonthlyGrowthRate)
+}
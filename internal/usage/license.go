@@ -0,0 +1,106 @@
+package usage
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// LicenseFee is an hourly fee for the instances that run an image, e.g. an AWS Marketplace AMI or a
+// bring-your-own license, that isn't included in the price of the instances. Fees apply to the compute
+// resources that run one of the AMIs and have all of the tags. At least one of them must be set.
+type LicenseFee struct {
+	// Name is shown in the name of the cost component, e.g. License (SQL Server Standard).
+	Name string `yaml:"name"`
+	// HourlyRate is the fee per instance per hour.
+	HourlyRate float64 `yaml:"hourly_rate"`
+	// AMIs are the IDs of the images the fee applies to.
+	AMIs []string `yaml:"ami_ids,omitempty"`
+	// Tags are the tags that the resources the fee applies to must have.
+	Tags map[string]string `yaml:"tags,omitempty"`
+}
+
+func (l *LicenseFee) validate() error {
+	if l.Name == "" {
+		return fmt.Errorf("license fee must have a name")
+	}
+
+	if l.HourlyRate <= 0 {
+		return fmt.Errorf("license fee %s must have a positive hourly_rate", l.Name)
+	}
+
+	if len(l.AMIs) == 0 && len(l.Tags) == 0 {
+		return fmt.Errorf("license fee %s must match ami_ids or tags", l.Name)
+	}
+
+	return nil
+}
+
+func (l *LicenseFee) matches(r *schema.Resource, tags map[string]string) bool {
+	if len(l.AMIs) > 0 && !containsString(l.AMIs, r.ImageID) {
+		return false
+	}
+
+	for k, v := range l.Tags {
+		if tags[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l *LicenseFee) costComponent(instances decimal.Decimal) *schema.CostComponent {
+	c := &schema.CostComponent{
+		Name:           fmt.Sprintf("License (%s)", l.Name),
+		Unit:           "hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: &instances,
+	}
+	c.SetCustomPrice(decimalPtr(decimal.NewFromFloat(l.HourlyRate)))
+
+	return c
+}
+
+// AddLicenseFees adds a license cost component for each of the fees that apply to the compute resources,
+// or to the compute sub-resources of them, e.g. the launch template of an autoscaling group. Sub-resources
+// are matched with the tags of the resource they belong to.
+func AddLicenseFees(resources []*schema.Resource, fees []*LicenseFee) {
+	if len(fees) == 0 {
+		return
+	}
+
+	for _, r := range resources {
+		addLicenseFees(r, r.Tags, fees)
+	}
+}
+
+func addLicenseFees(r *schema.Resource, tags map[string]string, fees []*LicenseFee) {
+	if r.InstanceCount != nil {
+		for _, fee := range fees {
+			if fee.matches(r, tags) {
+				r.CostComponents = append(r.CostComponents, fee.costComponent(*r.InstanceCount))
+			}
+		}
+	}
+
+	for _, s := range r.SubResources {
+		addLicenseFees(s, tags, fees)
+	}
+}
+
+func containsString(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -0,0 +1,122 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestAddLicenseFees(t *testing.T) {
+	fees := []*LicenseFee{
+		{Name: "SQL Server Standard", HourlyRate: 0.48, AMIs: []string{"ami-sqlserver"}},
+		{Name: "Security agent", HourlyRate: 0.02, Tags: map[string]string{"agent": "enabled"}},
+	}
+
+	sqlServer := &schema.Resource{
+		Name:          "aws_instance.db",
+		ImageID:       "ami-sqlserver",
+		InstanceCount: decimalPtr(decimal.NewFromInt(1)),
+	}
+	web := &schema.Resource{
+		Name:          "aws_instance.web",
+		ImageID:       "ami-ubuntu",
+		InstanceCount: decimalPtr(decimal.NewFromInt(1)),
+		Tags:          map[string]string{"agent": "enabled"},
+	}
+	launchTemplate := &schema.Resource{
+		Name:          "aws_launch_template.workers",
+		ImageID:       "ami-sqlserver",
+		InstanceCount: decimalPtr(decimal.NewFromInt(3)),
+	}
+	asg := &schema.Resource{
+		Name:         "aws_autoscaling_group.workers",
+		Tags:         map[string]string{"agent": "enabled"},
+		SubResources: []*schema.Resource{launchTemplate},
+	}
+	bucket := &schema.Resource{
+		Name: "aws_s3_bucket.logs",
+		Tags: map[string]string{"agent": "enabled"},
+	}
+
+	AddLicenseFees([]*schema.Resource{sqlServer, web, asg, bucket}, fees)
+
+	require.Len(t, sqlServer.CostComponents, 1)
+	assert.Equal(t, "License (SQL Server Standard)", sqlServer.CostComponents[0].Name)
+	assert.True(t, sqlServer.CostComponents[0].HourlyQuantity.Equal(decimal.NewFromInt(1)))
+	assert.True(t, sqlServer.CostComponents[0].CustomPrice().Equal(decimal.NewFromFloat(0.48)))
+
+	require.Len(t, web.CostComponents, 1)
+	assert.Equal(t, "License (Security agent)", web.CostComponents[0].Name)
+
+	// the launch template is matched with the tags of the autoscaling group.
+	require.Len(t, launchTemplate.CostComponents, 2)
+	assert.Equal(t, "License (SQL Server Standard)", launchTemplate.CostComponents[0].Name)
+	assert.True(t, launchTemplate.CostComponents[0].HourlyQuantity.Equal(decimal.NewFromInt(3)))
+	assert.Equal(t, "License (Security agent)", launchTemplate.CostComponents[1].Name)
+	assert.Empty(t, asg.CostComponents)
+
+	// resources without instances don't get license fees.
+	assert.Empty(t, bucket.CostComponents)
+}
+
+func TestLoadUsageFileLicenseFees(t *testing.T) {
+	usageFile, err := LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_instance.db:
+    operating_system: linux
+license_fees:
+  - name: SQL Server Standard
+    hourly_rate: 0.48
+    ami_ids:
+      - ami-sqlserver
+`)
+	require.NoError(t, err)
+
+	require.Len(t, usageFile.LicenseFees, 1)
+	assert.Equal(t, &LicenseFee{Name: "SQL Server Standard", HourlyRate: 0.48, AMIs: []string{"ami-sqlserver"}}, usageFile.LicenseFees[0])
+
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	require.NoError(t, usageFile.WriteToPath(path))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "license_fees:\n  - name: SQL Server Standard\n    hourly_rate: 0.48\n")
+}
+
+func TestLoadUsageFileInvalidLicenseFees(t *testing.T) {
+	tests := []struct {
+		name string
+		fee  string
+		want string
+	}{
+		{
+			name: "missing name",
+			fee:  "hourly_rate: 0.1\n    ami_ids: [ami-123]",
+			want: "Error loading license fees: license fee must have a name",
+		},
+		{
+			name: "missing rate",
+			fee:  "name: Agent\n    ami_ids: [ami-123]",
+			want: "Error loading license fees: license fee Agent must have a positive hourly_rate",
+		},
+		{
+			name: "missing match",
+			fee:  "name: Agent\n    hourly_rate: 0.1",
+			want: "Error loading license fees: license fee Agent must match ami_ids or tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadUsageFileFromString("version: 0.1\nlicense_fees:\n  - " + tt.fee + "\n")
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
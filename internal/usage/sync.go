@@ -210,7 +210,7 @@ func syncResource(projectCtx *config.ProjectContext, resource *schema.Resource,
 
 		resourceUsageMap := resourceUsage.Map()
 
-		ctx := context.WithValue(context.Background(), "env", projectCtx.ProjectConfig.Env)
+		ctx := context.WithValue(context.Background(), "env", projectCtx.ProjectConfig.EnvForProvider(resource.ProviderConfigKey))
 		err := resource.EstimateUsage(ctx, resourceUsageMap)
 		if err != nil {
 			syncResult.EstimationErrors[resource.Name] = err
@@ -23,6 +23,8 @@ type UsageFile struct { // nolint:revive
 	RawResourceUsage yamlv3.Node `yaml:"resource_usage"`
 	// The raw usage is then parsed into this struct
 	ResourceUsages []*ResourceUsage `yaml:"-"`
+	// LicenseFees are the hourly fees of images that are added to the compute resources that run them.
+	LicenseFees []*LicenseFee `yaml:"license_fees,omitempty"`
 }
 
 // CreateUsageFile creates a blank usage file if it does not exists
@@ -91,6 +93,13 @@ func LoadUsageFileFromString(s string) (*UsageFile, error) {
 		return usageFile, errors.Wrap(err, "Error loading YAML file")
 	}
 
+	for _, l := range usageFile.LicenseFees {
+		err = l.validate()
+		if err != nil {
+			return usageFile, errors.Wrap(err, "Error loading license fees")
+		}
+	}
+
 	return usageFile, nil
 }
 
@@ -126,6 +135,22 @@ See https://infracost.io/usage-file/ for docs`,
 		&u.RawResourceUsage,
 	)
 
+	if len(u.LicenseFees) > 0 {
+		licenseFeesNode := &yamlv3.Node{}
+		err := licenseFeesNode.Encode(u.LicenseFees)
+		if err != nil {
+			return err
+		}
+
+		root.Content = append(root.Content,
+			&yamlv3.Node{
+				Kind:  yamlv3.ScalarNode,
+				Value: "license_fees",
+			},
+			licenseFeesNode,
+		)
+	}
+
 	// Add a comment to the first commented-out resource
 	for _, node := range u.RawResourceUsage.Content {
 		if isNodeMarkedAsCommented(node) {
@@ -191,6 +216,10 @@ func (u *UsageFile) InvalidKeys() ([]string, error) {
 		// Iterate over provided keys and check if they are
 		// present in the reference usage file
 		for _, item := range resourceUsage.Items {
+			if item.Key == MonthlyGrowthRateKey {
+				continue
+			}
+
 			invalidKeys = append(invalidKeys, findInvalidKeys(item, refItemMap)...)
 		}
 	}
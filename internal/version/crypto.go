@@ -0,0 +1,14 @@
+//go:build !boringcrypto
+
+package version
+
+// FIPS returns if crypto operations are handled by the FIPS 140-2 validated BoringCrypto module. This is only
+// the case for binaries built with GOEXPERIMENT=boringcrypto, see the fips Makefile target.
+func FIPS() bool {
+	return false
+}
+
+// CryptoMode returns a description of the crypto module the binary uses.
+func CryptoMode() string {
+	return "Go standard library"
+}
@@ -0,0 +1,25 @@
+//go:build boringcrypto
+
+package version
+
+import (
+	"crypto/boring"
+
+	// Only allow the FIPS approved TLS versions, cipher suites and curves.
+	_ "crypto/tls/fipsonly"
+)
+
+// FIPS returns if crypto operations are handled by the FIPS 140-2 validated BoringCrypto module.
+func FIPS() bool {
+	return boring.Enabled()
+}
+
+// CryptoMode returns a description of the crypto module the binary uses.
+func CryptoMode() string {
+	if boring.Enabled() {
+		return "BoringCrypto (FIPS 140-2)"
+	}
+
+	// BoringCrypto is only available on linux/amd64 and linux/arm64 with cgo.
+	return "BoringCrypto (not available on this platform)"
+}
@@ -0,0 +1,181 @@
+// Package wasmext runs user-supplied WASM modules, such as output transforms, policy checks and
+// resource plugins, in a sandbox. It's a safer alternative to running plugin binaries for
+// organizations that don't allow arbitrary binaries to be run from config.
+//
+// Modules are WASI command modules, e.g. built with GOOS=wasip1 GOARCH=wasm, TinyGo or Rust's
+// wasm32-wasi target. Each run gets its input on stdin and writes its output to stdout. Runs are
+// sandboxed so that modules:
+//
+//   - can't access the filesystem, the network or the environment variables of the CLI.
+//   - only see a fake clock and a deterministic random source, so their output is reproducible.
+//   - have a limited amount of memory, time and output.
+//   - start with fresh memory, so no state is kept between runs.
+package wasmext
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// The limits of the sandbox that modules are run in.
+const (
+	// MemoryLimitPages is the maximum memory of a module in 64 KiB pages, which is 128 MiB.
+	MemoryLimitPages = 2048
+	// MaxOutputSize is the maximum number of bytes a module can write to stdout in a run.
+	MaxOutputSize = 64 << 20
+	// maxStderrSize is the number of bytes of stderr that are kept to show in errors.
+	maxStderrSize = 4096
+)
+
+// Timeout is the maximum time a run of a module can take.
+var Timeout = 30 * time.Second
+
+// Module is a compiled WASM module.
+type Module struct {
+	Name string
+
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+}
+
+// Load compiles the WASM module at path.
+func Load(ctx context.Context, path string) (*Module, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading WASM module: %w", err)
+	}
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(MemoryLimitPages).
+		WithCloseOnContextDone(true))
+
+	_, err = wasi_snapshot_preview1.Instantiate(ctx, runtime)
+	if err != nil {
+		_ = runtime.Close(ctx)
+		return nil, err
+	}
+
+	compiled, err := runtime.CompileModule(ctx, b)
+	if err != nil {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("Error compiling WASM module %s: %w", path, err)
+	}
+
+	return &Module{
+		Name:     strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		runtime:  runtime,
+		compiled: compiled,
+	}, nil
+}
+
+// Run runs the module with input on stdin and returns what it writes to stdout. It returns an error
+// if the module exits with a non-zero code or exceeds the limits of the sandbox.
+func (m *Module) Run(ctx context.Context, input []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{limit: MaxOutputSize}
+	stderr := &limitedBuffer{limit: maxStderrSize, truncate: true}
+
+	// The modules don't get any filesystem, environment variables or real clock since they aren't
+	// added to the config, and are anonymous so the same module can be run concurrently.
+	cfg := wazero.NewModuleConfig().
+		WithName("").
+		WithArgs(m.Name).
+		WithStdin(bytes.NewReader(input)).
+		WithStdout(stdout).
+		WithStderr(stderr)
+
+	mod, err := m.runtime.InstantiateModule(ctx, m.compiled, cfg)
+	if mod != nil {
+		_ = mod.Close(ctx)
+	}
+
+	if stderr.Len() > 0 {
+		log.Debugf("WASM module %s stderr: %s", m.Name, stderr.String())
+	}
+
+	if stdout.exceeded {
+		return nil, fmt.Errorf("WASM module %s wrote more than the %d bytes of output allowed", m.Name, MaxOutputSize)
+	}
+
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("WASM module %s didn't finish within %s", m.Name, Timeout)
+		}
+
+		var exitErr *sys.ExitError
+		if errors.As(err, &exitErr) {
+			msg := fmt.Sprintf("WASM module %s exited with code %d", m.Name, exitErr.ExitCode())
+			if l := lastLine(stderr.String()); l != "" {
+				msg += ": " + l
+			}
+			return nil, errors.New(msg)
+		}
+
+		return nil, fmt.Errorf("Error running WASM module %s: %w", m.Name, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// Close releases the resources of the module.
+func (m *Module) Close(ctx context.Context) error {
+	return m.runtime.Close(ctx)
+}
+
+// RunFile loads the WASM module at path and runs it once with input.
+func RunFile(ctx context.Context, path string, input []byte) ([]byte, error) {
+	m, err := Load(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close(ctx)
+
+	return m.Run(ctx, input)
+}
+
+// limitedBuffer is a buffer that only accepts up to limit bytes. Writes past the limit either fail,
+// or are dropped if truncate is set.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	truncate bool
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		b.exceeded = true
+
+		if !b.truncate {
+			return 0, errors.New("output limit exceeded")
+		}
+
+		n := len(p)
+		_, _ = b.Buffer.Write(p[:b.limit-b.Len()])
+		return n, nil
+	}
+
+	return b.Buffer.Write(p)
+}
+
+func lastLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndex(s, "\n"); i != -1 {
+		return s[i+1:]
+	}
+
+	return s
+}
@@ -0,0 +1,69 @@
+package wasmext
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestRun(t *testing.T) {
+	path := testutil.WASMModule(t, "testdata/upper")
+
+	ctx := context.Background()
+	m, err := Load(ctx, path)
+	require.NoError(t, err)
+	defer m.Close(ctx)
+
+	out, err := m.Run(ctx, []byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "HELLO", string(out))
+
+	// each run starts from scratch.
+	out, err = m.Run(ctx, []byte("again"))
+	require.NoError(t, err)
+	assert.Equal(t, "AGAIN", string(out))
+}
+
+func TestRunExitCode(t *testing.T) {
+	path := testutil.WASMModule(t, "testdata/fail")
+
+	_, err := RunFile(context.Background(), path, nil)
+	assert.EqualError(t, err, "WASM module fail exited with code 3: invalid input")
+}
+
+func TestRunSandbox(t *testing.T) {
+	path := testutil.WASMModule(t, "testdata/sandbox")
+	t.Setenv("INFRACOST_TEST_SECRET", "secret")
+
+	out, err := RunFile(context.Background(), path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "file: false\nenv: false\nnetwork: false\n", string(out))
+}
+
+func TestRunMemoryLimit(t *testing.T) {
+	path := testutil.WASMModule(t, "testdata/memory")
+
+	_, err := RunFile(context.Background(), path, nil)
+	assert.ErrorContains(t, err, "WASM module memory exited with code 2")
+}
+
+func TestRunTimeout(t *testing.T) {
+	path := testutil.WASMModule(t, "testdata/loop")
+
+	timeout := Timeout
+	Timeout = 100 * time.Millisecond
+	defer func() { Timeout = timeout }()
+
+	_, err := RunFile(context.Background(), path, nil)
+	assert.EqualError(t, err, "WASM module loop didn't finish within 100ms")
+}
+
+func TestLoadInvalidModule(t *testing.T) {
+	_, err := Load(context.Background(), "testdata/upper/main.go")
+	assert.ErrorContains(t, err, "Error compiling WASM module testdata/upper/main.go")
+}
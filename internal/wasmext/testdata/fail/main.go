@@ -0,0 +1,13 @@
+// fail writes an error to stderr and exits with code 3.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Fprintln(os.Stderr, "starting")
+	fmt.Fprintln(os.Stderr, "invalid input")
+	os.Exit(3)
+}
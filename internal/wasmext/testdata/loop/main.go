@@ -0,0 +1,7 @@
+// loop never exits.
+package main
+
+func main() {
+	for {
+	}
+}
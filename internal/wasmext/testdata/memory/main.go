@@ -0,0 +1,9 @@
+// memory allocates more memory than the sandbox allows.
+package main
+
+import "fmt"
+
+func main() {
+	b := make([]byte, 256<<20)
+	fmt.Println(len(b))
+}
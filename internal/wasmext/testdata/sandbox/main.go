@@ -0,0 +1,18 @@
+// sandbox writes what it can access outside of the sandbox.
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+func main() {
+	_, err := os.ReadFile("/etc/hosts")
+	fmt.Printf("file: %t\n", err == nil)
+
+	fmt.Printf("env: %t\n", len(os.Environ()) > 0)
+
+	_, err = net.Dial("tcp", "127.0.0.1:80")
+	fmt.Printf("network: %t\n", err == nil)
+}
@@ -0,0 +1,17 @@
+// upper writes its input to stdout in upper case.
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+func main() {
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		panic(err)
+	}
+
+	_, _ = os.Stdout.WriteString(strings.ToUpper(string(b)))
+}
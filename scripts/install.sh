@@ -60,4 +60,4 @@ else
   mv "/tmp/infracost-$os-$arch" "/usr/local/bin/infracost"
 fi
 echo
-echo "Completed installing $(infracost --version)"
+echo "Completed installing $(infracost --version | head -n 1)"
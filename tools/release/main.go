@@ -75,6 +75,8 @@ func findReleaseAssets() ([]string, error) {
 		"./build/*.tar.gz",
 		"./build/*.zip",
 		"./build/*.sha256",
+		"./build/*-checksums.txt",
+		"./build/*-checksums.txt.sig",
 		"./docs/generated/docs.tar.gz",
 	}
 